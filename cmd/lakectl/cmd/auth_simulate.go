@@ -0,0 +1,113 @@
+package cmd
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/go-openapi/swag"
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
+)
+
+const authSimulateTemplate = `{{.ResultsTable | table -}}
+Decision: {{ .Decision | bold }}
+`
+
+var authSimulateCmd = &cobra.Command{
+	Use:   "simulate",
+	Short: "Simulate the authorization of a user or group",
+	Long: `Evaluate the policies of a user or group for each of the given actions on each of the given resources,
+the same way a request is authorized, and show the decision and the policy statement that produced it.
+The actions are not performed.`,
+	Example: "lakectl auth simulate --user jane.doe --action fs:WriteObject --resource arn:lakefs:fs:::repository/example-repo/object/path",
+	Run: func(cmd *cobra.Command, args []string) {
+		user := Must(cmd.Flags().GetString("user"))
+		group := Must(cmd.Flags().GetString("group"))
+		accessKeyID := Must(cmd.Flags().GetString("access-key-id"))
+		actions := Must(cmd.Flags().GetStringSlice("action"))
+		resources := Must(cmd.Flags().GetStringSlice("resource"))
+		branch := Must(cmd.Flags().GetString("branch"))
+		sourceIP := Must(cmd.Flags().GetString("source-ip"))
+		isJSON := Must(cmd.Flags().GetBool(jsonFlagName))
+		if (user == "") == (group == "") {
+			Die("Exactly one of --user and --group is required", 1)
+		}
+		if accessKeyID != "" && user == "" {
+			Die("--access-key-id requires --user", 1)
+		}
+
+		body := apigen.SimulatePolicyJSONRequestBody{}
+		if user != "" {
+			body.UserId = swag.String(user)
+			if accessKeyID != "" {
+				body.AccessKeyId = swag.String(accessKeyID)
+			}
+		} else {
+			body.GroupId = swag.String(group)
+		}
+		for _, action := range actions {
+			for _, resource := range resources {
+				body.Permissions = append(body.Permissions, apigen.PolicySimulationPermission{
+					Action:   action,
+					Resource: resource,
+				})
+			}
+		}
+		if branch != "" || sourceIP != "" {
+			body.Context = &apigen.PolicySimulationContext{}
+			if branch != "" {
+				body.Context.Branch = swag.String(branch)
+			}
+			if sourceIP != "" {
+				body.Context.SourceIp = swag.String(sourceIP)
+			}
+		}
+
+		clt := getClient()
+		resp, err := clt.SimulatePolicyWithResponse(cmd.Context(), body)
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		if isJSON {
+			Write("{{ . | json }}\n", resp.JSON200)
+			return
+		}
+
+		rows := make([][]interface{}, len(resp.JSON200.Results))
+		for i, result := range resp.JSON200.Results {
+			policy, statement := "", ""
+			if result.Policy != nil {
+				policy = *result.Policy
+				statement = strconv.Itoa(swag.IntValue(result.StatementIndex))
+			}
+			rows[i] = []interface{}{result.Action, result.Resource, result.Decision, policy, statement, swag.StringValue(result.Error)}
+		}
+		Write(authSimulateTemplate, struct {
+			ResultsTable *Table
+			Decision     apigen.PolicySimulationDecision
+		}{
+			ResultsTable: &Table{
+				Headers: []interface{}{"Action", "Resource", "Decision", "Policy ID", "Statement #", "Error"},
+				Rows:    rows,
+			},
+			Decision: resp.JSON200.Decision,
+		})
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	authSimulateCmd.Flags().String("user", "", "Username to simulate, including the policies of its groups")
+	authSimulateCmd.Flags().String("group", "", "Group ID to simulate")
+	authSimulateCmd.Flags().String("access-key-id", "", "Access key of the user to simulate, narrowing the user policies by its inline policy")
+	authSimulateCmd.Flags().StringSlice("action", nil, "Action to evaluate, e.g. fs:WriteObject (repeatable)")
+	authSimulateCmd.Flags().StringSlice("resource", nil, "ARN of a resource to evaluate each action on (repeatable)")
+	authSimulateCmd.Flags().String("branch", "", "Branch of the simulated request, for statement conditions")
+	authSimulateCmd.Flags().String("source-ip", "", "Source IP address of the simulated request, for statement conditions")
+	authSimulateCmd.Flags().Bool(jsonFlagName, false, "print the simulation result as JSON")
+	_ = authSimulateCmd.MarkFlagRequired("action")
+	_ = authSimulateCmd.MarkFlagRequired("resource")
+
+	authCmd.AddCommand(authSimulateCmd)
+}
@@ -1,23 +1,40 @@
 package cmd
 
 import (
+	"encoding/json"
+	"io"
 	"net/http"
+	"os"
+	"time"
 
+	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
 )
 
 const credentialsCreatedTemplate = `{{ "Credentials created successfully." | green }}
 {{ "Access Key ID:" | ljust 18 }} {{ .AccessKeyId | bold }}
 {{ "Secret Access Key:" | ljust 18 }} {{  .SecretAccessKey | bold }}
-
+{{ if .ExpirationDate }}{{ "Expiration Date:" | ljust 18 }} {{ .ExpirationDate | date }}
+{{ end }}
 {{ "Keep these somewhere safe since you will not be able to see the secret key again" | yellow }}
 `
 
 var authUsersCredentialsCreate = &cobra.Command{
 	Use:   "create",
 	Short: "Create user credentials",
+	Long: `Create user credentials.
+Use --expires-in to create credentials that are rejected after the given duration, and --statement-document to
+narrow them with an inline policy: the credentials are allowed only the actions that both the policies of the user
+and the statements of the document allow.`,
+	Example: "lakectl auth users credentials create --id batch-job --expires-in 2h --statement-document read-only.json",
 	Run: func(cmd *cobra.Command, args []string) {
 		id := Must(cmd.Flags().GetString("id"))
+		expiresIn := Must(cmd.Flags().GetDuration("expires-in"))
+		document := Must(cmd.Flags().GetString("statement-document"))
+		if expiresIn < 0 {
+			Die("--expires-in must be positive", 1)
+		}
 		clt := getClient()
 
 		if id == "" {
@@ -29,7 +46,32 @@ var authUsersCredentialsCreate = &cobra.Command{
 			id = resp.JSON200.User.Id
 		}
 
-		resp, err := clt.CreateCredentialsWithResponse(cmd.Context(), id)
+		body := apigen.CreateCredentialsJSONRequestBody{}
+		if expiresIn > 0 {
+			body.ExpirationDate = swag.Int64(time.Now().Add(expiresIn).Unix())
+		}
+		if document != "" {
+			var err error
+			var fp io.ReadCloser
+			if document == "-" {
+				fp = os.Stdin
+			} else {
+				fp, err = os.Open(document)
+				if err != nil {
+					DieFmt("could not open statement document: %v", err)
+				}
+				defer func() {
+					_ = fp.Close()
+				}()
+			}
+			var doc StatementDoc
+			if err := json.NewDecoder(fp).Decode(&doc); err != nil {
+				DieFmt("could not parse statement JSON document: %v", err)
+			}
+			body.Statement = &doc.Statement
+		}
+
+		resp, err := clt.CreateCredentialsWithResponse(cmd.Context(), id, body)
 		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusCreated)
 		if resp.JSON201 == nil {
 			Die("Bad response from server", 1)
@@ -43,6 +85,8 @@ var authUsersCredentialsCreate = &cobra.Command{
 //nolint:gochecknoinits
 func init() {
 	authUsersCredentialsCreate.Flags().String("id", "", "Username (email for password-based users, default: current user)")
+	authUsersCredentialsCreate.Flags().Duration("expires-in", 0, "Duration after which the credentials expire (default: never)")
+	authUsersCredentialsCreate.Flags().String("statement-document", "", "JSON statement document path (or \"-\" for stdin) of an inline policy narrowing the credentials")
 
 	authUsersCredentials.AddCommand(authUsersCredentialsCreate)
 }
@@ -39,10 +39,18 @@ var authUsersCredentialsList = &cobra.Command{
 		rows := make([][]interface{}, len(credentials))
 		for i, c := range credentials {
 			ts := time.Unix(c.CreationDate, 0).String()
-			rows[i] = []interface{}{c.AccessKeyId, ts}
+			expiration := ""
+			if c.ExpirationDate != nil {
+				expiration = time.Unix(*c.ExpirationDate, 0).String()
+			}
+			inlinePolicy := ""
+			if c.Statement != nil {
+				inlinePolicy = "yes"
+			}
+			rows[i] = []interface{}{c.AccessKeyId, ts, expiration, inlinePolicy}
 		}
 		pagination := resp.JSON200.Pagination
-		PrintTable(rows, []interface{}{"Access Key ID", "Issued Date"}, &pagination, amount)
+		PrintTable(rows, []interface{}{"Access Key ID", "Issued Date", "Expiration Date", "Inline Policy"}, &pagination, amount)
 	},
 }
 
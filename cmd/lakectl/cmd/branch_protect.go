@@ -3,6 +3,7 @@ package cmd
 import (
 	"net/http"
 	"slices"
+	"strconv"
 
 	"github.com/go-openapi/swag"
 	"github.com/spf13/cobra"
@@ -17,7 +18,7 @@ const (
 var branchProtectCmd = &cobra.Command{
 	Use:   "branch-protect",
 	Short: "Create and manage branch protection rules",
-	Long:  "Define branch protection rules to prevent direct changes. Changes to protected branches can only be done by merging from other branches.",
+	Long:  "Define branch protection rules to prevent direct changes. Changes to protected branches can only be done by merging from other branches, optionally only through approved pull requests.",
 }
 
 var branchProtectListCmd = &cobra.Command{
@@ -36,9 +37,13 @@ var branchProtectListCmd = &cobra.Command{
 		}
 		patterns := make([][]interface{}, len(*resp.JSON200))
 		for i, rule := range *resp.JSON200 {
-			patterns[i] = []interface{}{rule.Pattern}
+			approvals := "-"
+			if rule.RequiredApprovals != nil {
+				approvals = strconv.Itoa(*rule.RequiredApprovals)
+			}
+			patterns[i] = []interface{}{rule.Pattern, approvals}
 		}
-		PrintTable(patterns, []interface{}{"Branch Name Pattern"}, &apigen.Pagination{
+		PrintTable(patterns, []interface{}{"Branch Name Pattern", "Required Approvals"}, &apigen.Pagination{
 			HasMore: false,
 			Results: len(patterns),
 		}, len(patterns))
@@ -49,19 +54,27 @@ var branchProtectAddCmd = &cobra.Command{
 	Use:               "add <repository URI> <pattern>",
 	Short:             "Add a branch protection rule",
 	Long:              "Add a branch protection rule for a given branch name pattern",
-	Example:           "lakectl branch-protect add " + myRepoExample + " 'stable_*'",
+	Example:           "lakectl branch-protect add " + myRepoExample + " 'stable_*' --required-approvals 2",
 	Args:              cobra.ExactArgs(branchProtectAddCmdArgs),
 	ValidArgsFunction: ValidArgsRepository,
 	Run: func(cmd *cobra.Command, args []string) {
 		client := getClient()
 		u := MustParseRepoURI("repository URI", args[0])
+		requiredApprovals := Must(cmd.Flags().GetInt("required-approvals"))
+		if requiredApprovals < 0 {
+			Die("Required approvals must not be negative", 1)
+		}
 		resp, err := client.GetBranchProtectionRulesWithResponse(cmd.Context(), u.Repository)
 
 		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
 		rules := *resp.JSON200
-		rules = append(rules, apigen.BranchProtectionRule{
+		rule := apigen.BranchProtectionRule{
 			Pattern: args[1],
-		})
+		}
+		if requiredApprovals > 0 {
+			rule.RequiredApprovals = &requiredApprovals
+		}
+		rules = append(rules, rule)
 		params := &apigen.SetBranchProtectionRulesParams{}
 		etag := swag.String(resp.HTTPResponse.Header.Get("ETag"))
 		if etag != nil && *etag != "" {
@@ -105,6 +118,7 @@ var branchProtectDeleteCmd = &cobra.Command{
 //nolint:gochecknoinits
 func init() {
 	rootCmd.AddCommand(branchProtectCmd)
+	branchProtectAddCmd.Flags().Int("required-approvals", 0, "Only allow merges into matching branches through pull requests with this many approvals")
 	branchProtectCmd.AddCommand(branchProtectAddCmd)
 	branchProtectCmd.AddCommand(branchProtectListCmd)
 	branchProtectCmd.AddCommand(branchProtectDeleteCmd)
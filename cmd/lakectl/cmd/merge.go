@@ -1,11 +1,15 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"os"
+	"strings"
 
 	"github.com/spf13/cobra"
 	"github.com/treeverse/lakefs/pkg/api/apigen"
+	"github.com/treeverse/lakefs/pkg/api/apiutil"
 )
 
 const (
@@ -14,6 +18,12 @@ const (
 
 	mergeCreateTemplate = `Merged "{{.Merge.FromRef|yellow}}" into "{{.Merge.ToRef|yellow}}" to get "{{.Result.Reference|green}}".
 `
+	mergePreviewTemplate = `Merge base: {{.BaseCommitId|yellow}}
+Added: {{.Summary.Added}}, changed: {{.Summary.Changed}}, removed: {{.Summary.Removed}}, conflicts: {{.Summary.Conflict|red}}
+`
+	mergePreviewConflictTemplate = `{{range .}}conflict {{.Path|red}}
+	base: {{.BaseIdentity}} source: {{.SourceIdentity}} destination: {{.DestinationIdentity}}
+{{end}}`
 )
 
 type FromTo struct {
@@ -43,6 +53,10 @@ var mergeCmd = &cobra.Command{
 		force := Must(cmd.Flags().GetBool("force"))
 		allowEmpty := Must(cmd.Flags().GetBool("allow-empty"))
 		squash := Must(cmd.Flags().GetBool("squash"))
+		resolverType := Must(cmd.Flags().GetString("resolver"))
+		resolverScript := Must(cmd.Flags().GetString("resolver-script"))
+		strategyRules := Must(cmd.Flags().GetStringArray("strategy-rule"))
+		dryRun := Must(cmd.Flags().GetBool("dry-run"))
 
 		fmt.Println("Source:", sourceRef)
 		fmt.Println("Destination:", destinationRef)
@@ -53,14 +67,44 @@ var mergeCmd = &cobra.Command{
 		if strategy != "dest-wins" && strategy != "source-wins" && strategy != "" {
 			Die("Invalid strategy value. Expected \"dest-wins\" or \"source-wins\"", 1)
 		}
+		var rules *[]apigen.MergeStrategyRule
+		if len(strategyRules) > 0 {
+			rules = apiutil.Ptr(parseMergeStrategyRules(strategyRules))
+		}
+		var resolver *apigen.MergeConflictResolver
+		if resolverType != "" {
+			if strategy != "" {
+				Die("Only one of --strategy and --resolver can be used", 1)
+			}
+			resolver = &apigen.MergeConflictResolver{Type: resolverType}
+			if resolverScript != "" {
+				script, err := os.ReadFile(resolverScript)
+				if err != nil {
+					DieErr(err)
+				}
+				resolver.Script = apiutil.Ptr(string(script))
+			}
+		} else if resolverScript != "" {
+			Die("--resolver-script requires --resolver lua", 1)
+		}
+		if dryRun {
+			printMergePreview(cmd.Context(), client, destinationRef.Repository, sourceRef.Ref, destinationRef.Ref, apigen.PreviewMergeJSONRequestBody{
+				Strategy:      &strategy,
+				StrategyRules: rules,
+				Resolver:      resolver,
+			})
+			return
+		}
 
 		body := apigen.MergeIntoBranchJSONRequestBody{
-			Message:     &message,
-			Metadata:    &apigen.Merge_Metadata{AdditionalProperties: kvPairs},
-			Strategy:    &strategy,
-			Force:       &force,
-			AllowEmpty:  &allowEmpty,
-			SquashMerge: &squash,
+			Message:       &message,
+			Metadata:      &apigen.Merge_Metadata{AdditionalProperties: kvPairs},
+			Strategy:      &strategy,
+			StrategyRules: rules,
+			Resolver:      resolver,
+			Force:         &force,
+			AllowEmpty:    &allowEmpty,
+			SquashMerge:   &squash,
 		}
 
 		resp, err := client.MergeIntoBranchWithResponse(cmd.Context(), destinationRef.Repository, sourceRef.Ref, destinationRef.Ref, body)
@@ -85,10 +129,61 @@ var mergeCmd = &cobra.Command{
 	},
 }
 
+// printMergePreview prints what merging sourceRef into destinationBranch would change and
+// every conflicting object, failing if there are any conflicts
+func printMergePreview(ctx context.Context, client apigen.ClientWithResponsesInterface, repository, sourceRef, destinationBranch string, body apigen.PreviewMergeJSONRequestBody) {
+	var (
+		params    apigen.PreviewMergeParams
+		conflicts int
+	)
+	for {
+		resp, err := client.PreviewMergeWithResponse(ctx, repository, sourceRef, destinationBranch, &params, body)
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		preview := resp.JSON200
+		// the summary is only returned with the first page
+		if params.After == nil {
+			Write(mergePreviewTemplate, preview)
+			conflicts = preview.Summary.Conflict
+		}
+		Write(mergePreviewConflictTemplate, preview.Results)
+		if !preview.Pagination.HasMore {
+			if conflicts > 0 {
+				Die("Conflict found.", 1)
+			}
+			return
+		}
+		params.After = apiutil.Ptr(apigen.PaginationAfter(preview.Pagination.NextOffset))
+	}
+}
+
+// parseMergeStrategyRules parses ordered "<pattern>=<strategy>" flag values
+func parseMergeStrategyRules(values []string) []apigen.MergeStrategyRule {
+	rules := make([]apigen.MergeStrategyRule, 0, len(values))
+	for _, v := range values {
+		idx := strings.LastIndex(v, "=")
+		if idx <= 0 {
+			DieFmt("Invalid strategy rule %q. Expected <pattern>=<strategy>", v)
+		}
+		pattern, strategy := v[:idx], v[idx+1:]
+		if strategy != "default" && strategy != "dest-wins" && strategy != "source-wins" {
+			DieFmt("Invalid strategy %q in rule %q. Expected \"default\", \"dest-wins\" or \"source-wins\"", strategy, v)
+		}
+		rules = append(rules, apigen.MergeStrategyRule{Pattern: pattern, Strategy: strategy})
+	}
+	return rules
+}
+
 //nolint:gochecknoinits
 func init() {
 	flags := mergeCmd.Flags()
 	flags.String("strategy", "", "In case of a merge conflict, this option will force the merge process to automatically favor changes from the dest branch (\"dest-wins\") or from the source branch(\"source-wins\"). In case no selection is made, the merge process will fail in case of a conflict")
+	flags.StringArray("strategy-rule", nil, "Strategy for conflicting objects matching a path prefix or glob, in the form <pattern>=<strategy>. Can be repeated, the first matching rule applies. Objects no rule matches use --strategy or --resolver")
+	flags.String("resolver", "", "Resolve merge conflicts object by object: \"newest-wins\", \"larger-wins\" or \"lua\". Cannot be combined with --strategy")
+	flags.String("resolver-script", "", "Path to a Lua script defining a 'resolve' function, used by the \"lua\" resolver")
+	flags.Bool("dry-run", false, "Show the changes and conflicts of the merge without merging. Exits with an error if there are conflicts")
 	flags.Bool("force", false, "Allow merge into a read-only branch or into a branch with the same content")
 	flags.Bool("allow-empty", false, "Allow merge when the branches have the same content")
 	flags.Bool("squash", false, "Squash all changes from source into a single commit on destination")
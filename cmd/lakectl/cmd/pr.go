@@ -0,0 +1,17 @@
+package cmd
+
+import (
+	"github.com/spf13/cobra"
+)
+
+// prCmd represents the pr command
+var prCmd = &cobra.Command{
+	Use:   "pr",
+	Short: "Manage pull requests within a repository",
+	Long:  `Create, review, merge and manage pull requests within a lakeFS repository`,
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(prCmd)
+}
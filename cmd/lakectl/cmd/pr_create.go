@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
+	"github.com/treeverse/lakefs/pkg/api/apiutil"
+)
+
+const prCreateCmdArgs = 2
+
+var prCreateCmd = &cobra.Command{
+	Use:     "create <source branch URI> <destination branch URI>",
+	Short:   "Create a pull request",
+	Example: "lakectl pr create lakefs://example-repo/feature lakefs://example-repo/main --title 'Add partition'",
+	Args:    cobra.ExactArgs(prCreateCmdArgs),
+	ValidArgsFunction: func(cmd *cobra.Command, args []string, toComplete string) ([]string, cobra.ShellCompDirective) {
+		if len(args) >= prCreateCmdArgs {
+			return nil, cobra.ShellCompDirectiveNoFileComp
+		}
+		return validRepositoryToComplete(cmd.Context(), toComplete)
+	},
+	Run: func(cmd *cobra.Command, args []string) {
+		sourceURI := MustParseBranchURI("source branch URI", args[0])
+		destinationURI := MustParseBranchURI("destination branch URI", args[1])
+		if sourceURI.Repository != destinationURI.Repository {
+			Die("both branches must belong to the same repository", 1)
+		}
+		title := Must(cmd.Flags().GetString("title"))
+		description := Must(cmd.Flags().GetString("description"))
+
+		client := getClient()
+		body := apigen.CreatePullRequestJSONRequestBody{
+			Title:             title,
+			SourceBranch:      sourceURI.Ref,
+			DestinationBranch: destinationURI.Ref,
+		}
+		if description != "" {
+			body.Description = apiutil.Ptr(description)
+		}
+		resp, err := client.CreatePullRequestWithResponse(cmd.Context(), sourceURI.Repository, body)
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusCreated)
+		if resp.JSON201 == nil {
+			Die("Bad response from server", 1)
+		}
+		fmt.Printf("Pull request %s created\n", resp.JSON201.Id)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	flags := prCreateCmd.Flags()
+	flags.StringP("title", "t", "", "pull request title")
+	flags.StringP("description", "d", "", "pull request description")
+	_ = prCreateCmd.MarkFlagRequired("title")
+
+	prCmd.AddCommand(prCreateCmd)
+}
@@ -0,0 +1,42 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/uri"
+)
+
+const prDiffCmdArgs = 2
+
+var prDiffCmd = &cobra.Command{
+	Use:               "diff <repository URI> <pull request ID>",
+	Short:             "Show the changes a pull request merges",
+	Long:              "Show the changes on the source branch of a pull request since its merge base with the destination branch",
+	Example:           "lakectl pr diff " + myRepoExample + " <pull request ID>",
+	Args:              cobra.ExactArgs(prDiffCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		prefix := Must(cmd.Flags().GetString(prefixFlagName))
+		client := getClient()
+		resp, err := client.GetPullRequestWithResponse(cmd.Context(), u.Repository, args[1])
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		pr := resp.JSON200
+		destination := &uri.URI{Repository: u.Repository, Ref: pr.DestinationBranch}
+		source := &uri.URI{Repository: u.Repository, Ref: pr.SourceBranch}
+		fmt.Printf("Source: %s\nDestination: %s\n", source, destination)
+		printDiffRefs(cmd.Context(), client, destination, source, false, prefix)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	prDiffCmd.Flags().String(prefixFlagName, "", "Show only changes in the given prefix.")
+
+	prCmd.AddCommand(prDiffCmd)
+}
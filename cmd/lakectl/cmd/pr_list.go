@@ -0,0 +1,59 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
+	"github.com/treeverse/lakefs/pkg/api/apiutil"
+)
+
+var prListCmd = &cobra.Command{
+	Use:               "list <repository URI>",
+	Short:             "List pull requests in a repository",
+	Example:           "lakectl pr list " + myRepoExample + " --status open",
+	Args:              cobra.ExactArgs(1),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		prefix, after, amount := getPaginationFlags(cmd)
+		u := MustParseRepoURI("repository URI", args[0])
+		status := Must(cmd.Flags().GetString("status"))
+		isJSON := Must(cmd.Flags().GetBool(jsonFlagName))
+		if status != "open" && status != "closed" && status != "all" {
+			Die("Invalid status value. Expected \"open\", \"closed\" or \"all\"", 1)
+		}
+
+		client := getClient()
+		resp, err := client.ListPullRequestsWithResponse(cmd.Context(), u.Repository, &apigen.ListPullRequestsParams{
+			Prefix: apiutil.Ptr(apigen.PaginationPrefix(prefix)),
+			After:  apiutil.Ptr(apigen.PaginationAfter(after)),
+			Amount: apiutil.Ptr(apigen.PaginationAmount(amount)),
+			Status: apiutil.Ptr(status),
+		})
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		if isJSON {
+			Write("{{ . | json }}\n", resp.JSON200)
+			return
+		}
+
+		pulls := resp.JSON200.Results
+		rows := make([][]interface{}, len(pulls))
+		for i, pr := range pulls {
+			rows[i] = []interface{}{pr.Id, apiutil.Value(pr.Title), apiutil.Value(pr.Status), pr.Author, pr.SourceBranch, pr.DestinationBranch, pr.CreationDate}
+		}
+		pagination := resp.JSON200.Pagination
+		PrintTable(rows, []interface{}{"ID", "Title", "Status", "Author", "Source", "Destination", "Created"}, &pagination, amount)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	withPaginationFlags(prListCmd)
+	prListCmd.Flags().String("status", "all", "filter pull requests by status: \"open\", \"closed\" or \"all\"")
+	prListCmd.Flags().Bool(jsonFlagName, false, "print pull requests as JSON")
+
+	prCmd.AddCommand(prListCmd)
+}
@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+const prMergeCmdArgs = 2
+
+var prMergeCmd = &cobra.Command{
+	Use:               "merge <repository URI> <pull request ID>",
+	Short:             "Merge a pull request",
+	Long:              "Merge the source branch of a pull request into its destination branch, and mark the pull request merged",
+	Example:           "lakectl pr merge " + myRepoExample + " <pull request ID>",
+	Args:              cobra.ExactArgs(prMergeCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		pullRequestID := args[1]
+		client := getClient()
+		resp, err := client.MergePullRequestWithResponse(cmd.Context(), u.Repository, pullRequestID)
+		if resp != nil && resp.JSON409 != nil {
+			Die("Conflict found.", 1)
+		}
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		fmt.Printf("Pull request %s merged to get %s\n", pullRequestID, resp.JSON200.Reference)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	prCmd.AddCommand(prMergeCmd)
+}
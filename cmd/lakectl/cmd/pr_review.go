@@ -0,0 +1,69 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
+	"github.com/treeverse/lakefs/pkg/api/apiutil"
+)
+
+const prReviewCmdArgs = 2
+
+var prReviewCmd = &cobra.Command{
+	Use:   "review <repository URI> <pull request ID>",
+	Short: "Review a pull request",
+	Long: "Approve a pull request, request changes to it or comment on it. Without --approve or --request-changes the review is a comment.\n" +
+		"The review is of --commit, by default the current head of the source branch, and fails if the source branch moved since.",
+	Example: "lakectl pr review " + myRepoExample + " <pull request ID> --approve\n" +
+		"lakectl pr review " + myRepoExample + " <pull request ID> --request-changes --comment 'missing partition'",
+	Args:              cobra.ExactArgs(prReviewCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		pullRequestID := args[1]
+		approve := Must(cmd.Flags().GetBool("approve"))
+		requestChanges := Must(cmd.Flags().GetBool("request-changes"))
+		comment := Must(cmd.Flags().GetString("comment"))
+		commitID := Must(cmd.Flags().GetString("commit"))
+
+		body := apigen.CreatePullRequestReviewJSONRequestBody{State: "commented"}
+		switch {
+		case approve && requestChanges:
+			Die("Only one of --approve and --request-changes can be used", 1)
+		case approve:
+			body.State = "approved"
+		case requestChanges:
+			body.State = "changes_requested"
+		case comment == "":
+			Die("A comment review requires --comment", 1)
+		}
+		if comment != "" {
+			body.Comment = apiutil.Ptr(comment)
+		}
+
+		client := getClient()
+		if commitID == "" {
+			prResp, err := client.GetPullRequestWithResponse(cmd.Context(), u.Repository, pullRequestID)
+			DieOnErrorOrUnexpectedStatusCode(prResp, err, http.StatusOK)
+			branchResp, err := client.GetBranchWithResponse(cmd.Context(), u.Repository, prResp.JSON200.SourceBranch)
+			DieOnErrorOrUnexpectedStatusCode(branchResp, err, http.StatusOK)
+			commitID = branchResp.JSON200.CommitId
+		}
+		body.CommitId = commitID
+		resp, err := client.CreatePullRequestReviewWithResponse(cmd.Context(), u.Repository, pullRequestID, body)
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusNoContent)
+		fmt.Printf("Pull request %s reviewed: %s\n", pullRequestID, body.State)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	flags := prReviewCmd.Flags()
+	flags.Bool("approve", false, "Approve the pull request")
+	flags.Bool("request-changes", false, "Request changes to the pull request")
+	flags.String("comment", "", "Review comment")
+	flags.String("commit", "", "Commit ID reviewed, must be the head of the source branch. By default, its current head")
+	prCmd.AddCommand(prReviewCmd)
+}
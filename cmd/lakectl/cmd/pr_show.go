@@ -0,0 +1,54 @@
+package cmd
+
+import (
+	"net/http"
+
+	"github.com/spf13/cobra"
+)
+
+const (
+	prShowCmdArgs = 2
+
+	prShowTemplate = `ID: {{.Id|yellow}}
+Title: {{.Title}}
+Status: {{.Status}}
+Author: {{.Author}}
+Source: {{.SourceBranch}}
+Destination: {{.DestinationBranch}}
+Created: {{.CreationDate}}{{if .ClosedDate}}
+Closed: {{.ClosedDate}}{{end}}{{if .MergedCommitId}}
+Merged commit: {{.MergedCommitId|green}}{{end}}
+{{if .Description}}
+{{.Description}}
+{{end}}`
+)
+
+var prShowCmd = &cobra.Command{
+	Use:               "show <repository URI> <pull request ID>",
+	Short:             "Show a pull request",
+	Example:           "lakectl pr show " + myRepoExample + " <pull request ID>",
+	Args:              cobra.ExactArgs(prShowCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		isJSON := Must(cmd.Flags().GetBool(jsonFlagName))
+		client := getClient()
+		resp, err := client.GetPullRequestWithResponse(cmd.Context(), u.Repository, args[1])
+		DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusOK)
+		if resp.JSON200 == nil {
+			Die("Bad response from server", 1)
+		}
+		if isJSON {
+			Write("{{ . | json }}\n", resp.JSON200)
+			return
+		}
+		Write(prShowTemplate, resp.JSON200)
+	},
+}
+
+//nolint:gochecknoinits
+func init() {
+	prShowCmd.Flags().Bool(jsonFlagName, false, "print the pull request as JSON")
+
+	prCmd.AddCommand(prShowCmd)
+}
@@ -0,0 +1,68 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/api/apigen"
+	"github.com/treeverse/lakefs/pkg/api/apiutil"
+)
+
+const prUpdateCmdArgs = 2
+
+var prUpdateCmd = &cobra.Command{
+	Use:               "update <repository URI> <pull request ID>",
+	Short:             "Update the title or description of a pull request",
+	Example:           "lakectl pr update " + myRepoExample + " <pull request ID> --title 'Add partition 2024-01'",
+	Args:              cobra.ExactArgs(prUpdateCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		pullRequestID := args[1]
+		var body apigen.UpdatePullRequestJSONRequestBody
+		if cmd.Flags().Changed("title") {
+			body.Title = apiutil.Ptr(Must(cmd.Flags().GetString("title")))
+		}
+		if cmd.Flags().Changed("description") {
+			body.Description = apiutil.Ptr(Must(cmd.Flags().GetString("description")))
+		}
+		if body.Title == nil && body.Description == nil {
+			Die("Nothing to update, use --title or --description", 1)
+		}
+		updatePullRequest(cmd, u.Repository, pullRequestID, body)
+		fmt.Printf("Pull request %s updated\n", pullRequestID)
+	},
+}
+
+var prCloseCmd = &cobra.Command{
+	Use:               "close <repository URI> <pull request ID>",
+	Short:             "Close a pull request without merging it",
+	Example:           "lakectl pr close " + myRepoExample + " <pull request ID>",
+	Args:              cobra.ExactArgs(prUpdateCmdArgs),
+	ValidArgsFunction: ValidArgsRepository,
+	Run: func(cmd *cobra.Command, args []string) {
+		u := MustParseRepoURI("repository URI", args[0])
+		pullRequestID := args[1]
+		updatePullRequest(cmd, u.Repository, pullRequestID, apigen.UpdatePullRequestJSONRequestBody{
+			Status: apiutil.Ptr("closed"),
+		})
+		fmt.Printf("Pull request %s closed\n", pullRequestID)
+	},
+}
+
+func updatePullRequest(cmd *cobra.Command, repository, pullRequestID string, body apigen.UpdatePullRequestJSONRequestBody) {
+	client := getClient()
+	resp, err := client.UpdatePullRequestWithResponse(cmd.Context(), repository, pullRequestID, body)
+	DieOnErrorOrUnexpectedStatusCode(resp, err, http.StatusNoContent)
+}
+
+//nolint:gochecknoinits
+func init() {
+	flags := prUpdateCmd.Flags()
+	flags.StringP("title", "t", "", "new pull request title")
+	flags.StringP("description", "d", "", "new pull request description")
+
+	prCmd.AddCommand(prUpdateCmd)
+	prCmd.AddCommand(prCloseCmd)
+}
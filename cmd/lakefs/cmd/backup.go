@@ -0,0 +1,210 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	blockfactory "github.com/treeverse/lakefs/modules/block/factory"
+	"github.com/treeverse/lakefs/pkg/block"
+	"github.com/treeverse/lakefs/pkg/config"
+	"github.com/treeverse/lakefs/pkg/kv"
+)
+
+const backupCmdNumArgs = 1
+
+var backupCmd = &cobra.Command{
+	Use:   "backup",
+	Short: "Back up and restore all lakeFS metadata",
+	Long: `Back up and restore all lakeFS metadata: the keys and values of every partition of the Key-Value Store,
+including repositories, users, groups, policies, credentials, settings and action runs.
+Archives are stored in the object store configured for lakeFS. Secret access keys in them are encrypted with
+auth.encrypt.secret_key, which restores need; everything else is in the clear, so protect the archives.`,
+}
+
+var backupCreateCmd = &cobra.Command{
+	Use:   "create <location>",
+	Short: "Write a backup archive of the Key-Value Store to the object store",
+	Long: `Write a backup archive of the Key-Value Store to the given object store location, such as s3://bucket/backups/lakefs.kvbak.
+A location ending with / is completed with a name holding the time of the backup.
+The store is not snapshotted, so lakeFS must be stopped while backing up. Unless --no-verify is given, the backup
+checksums every partition before backing it up and the whole store after, and fails if the store changed while backing up.
+cosmosdb stores cannot be backed up, as they cannot list their partitions.`,
+	Args: cobra.ExactArgs(backupCmdNumArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		noVerify, err := cmd.Flags().GetBool("no-verify")
+		if err != nil {
+			return err
+		}
+		location := args[0]
+		if strings.HasSuffix(location, "/") {
+			location += "lakefs-backup-" + time.Now().UTC().Format("20060102T150405Z") + ".kvbak"
+		}
+
+		cfg := LoadConfig()
+		if err := checkListPartitions(&cfg.GetBaseConfig().Database); err != nil {
+			return err
+		}
+		ctx := cmd.Context()
+		adapter, err := blockfactory.BuildBlockAdapter(ctx, nil, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create block adapter: %w", err)
+		}
+		kvStore, err := openKVStore(ctx, &cfg.GetBaseConfig().Database)
+		if err != nil {
+			return err
+		}
+		defer kvStore.Close()
+
+		// archives are written to a file first, as the object store needs their size
+		f, err := os.CreateTemp("", "lakefs-backup-")
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+		manifest, err := kv.WriteBackup(ctx, kvStore, f, kv.BackupOptions{
+			Source: cfg.GetBaseConfig().Database.Type,
+			Verify: !noVerify,
+		})
+		if err != nil {
+			return fmt.Errorf("backup failed: %w", err)
+		}
+		size, err := f.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Seek(0, io.SeekStart); err != nil {
+			return err
+		}
+		_, err = adapter.Put(ctx, backupObjectPointer(location), size, f, block.PutOpts{})
+		if err != nil {
+			return fmt.Errorf("write %s: %w", location, err)
+		}
+		return printBackupManifest(location, manifest)
+	},
+}
+
+var backupRestoreCmd = &cobra.Command{
+	Use:   "restore <location>",
+	Short: "Restore a backup archive from the object store to an empty Key-Value Store",
+	Long: `Restore a backup archive from the given object store location to an empty Key-Value Store.
+The archive is verified against its checksum before anything is restored. By default, the archive is restored to the store
+configured for lakeFS. --to names a lakeFS configuration file of which only the database section is used, to restore to
+another store, which may use another database type, except cosmosdb. Stop lakeFS while restoring.`,
+	Args: cobra.ExactArgs(backupCmdNumArgs),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		to, err := cmd.Flags().GetString("to")
+		if err != nil {
+			return err
+		}
+		noVerify, err := cmd.Flags().GetBool("no-verify")
+		if err != nil {
+			return err
+		}
+		location := args[0]
+
+		cfg := LoadConfig()
+		targetDatabase := &cfg.GetBaseConfig().Database
+		if to != "" {
+			targetDatabase, err = config.ReadDatabaseFile(to)
+			if err != nil {
+				return fmt.Errorf("read target configuration: %w", err)
+			}
+		}
+		// restoring checks that the target is empty by listing its partitions
+		if err := checkListPartitions(targetDatabase); err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+
+		ctx := cmd.Context()
+		adapter, err := blockfactory.BuildBlockAdapter(ctx, nil, cfg)
+		if err != nil {
+			return fmt.Errorf("failed to create block adapter: %w", err)
+		}
+		f, err := downloadBackup(ctx, adapter, location)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			_ = f.Close()
+			_ = os.Remove(f.Name())
+		}()
+
+		kvStore, err := openKVStore(ctx, targetDatabase)
+		if err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+		defer kvStore.Close()
+		manifest, err := kv.RestoreBackup(ctx, f, kvStore, kv.RestoreOptions{Verify: !noVerify})
+		if err != nil {
+			return fmt.Errorf("restore failed: %w", err)
+		}
+		return printBackupManifest(location, manifest)
+	},
+}
+
+func backupObjectPointer(location string) block.ObjectPointer {
+	return block.ObjectPointer{
+		StorageID:      config.SingleBlockstoreID,
+		Identifier:     location,
+		IdentifierType: block.IdentifierTypeFull,
+	}
+}
+
+// downloadBackup copies the archive at location to a temporary file, as restoring reads it twice
+func downloadBackup(ctx context.Context, adapter block.Adapter, location string) (*os.File, error) {
+	reader, err := adapter.Get(ctx, backupObjectPointer(location))
+	if err != nil {
+		return nil, fmt.Errorf("read %s: %w", location, err)
+	}
+	defer func() { _ = reader.Close() }()
+	f, err := os.CreateTemp("", "lakefs-restore-")
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(f, reader); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, fmt.Errorf("read %s: %w", location, err)
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		_ = f.Close()
+		_ = os.Remove(f.Name())
+		return nil, err
+	}
+	return f, nil
+}
+
+func printBackupManifest(location string, manifest *kv.BackupManifest) error {
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	err := encoder.Encode(struct {
+		Location string `json:"location"`
+		*kv.BackupManifest
+	}{
+		Location:       location,
+		BackupManifest: manifest,
+	})
+	if err != nil {
+		return fmt.Errorf("json.Marshal failed: %w", err)
+	}
+	return nil
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(backupCmd)
+	backupCmd.AddCommand(backupCreateCmd)
+	backupCreateCmd.Flags().Bool("no-verify", false, "skip checking that the store did not change while backing up")
+	backupCmd.AddCommand(backupRestoreCmd)
+	backupRestoreCmd.Flags().String("to", "", "configuration file of the target store. By default, the store configured for lakeFS")
+	backupRestoreCmd.Flags().Bool("no-verify", false, "skip comparing the target store to the archive once restored")
+}
@@ -1,10 +1,12 @@
 package cmd
 
 import (
+	"context"
 	"encoding/json"
 	"errors"
 	"fmt"
 	"os"
+	"strings"
 
 	_ "github.com/treeverse/lakefs/pkg/actions"
 	_ "github.com/treeverse/lakefs/pkg/auth"
@@ -12,7 +14,9 @@ import (
 	_ "github.com/treeverse/lakefs/pkg/graveler"
 
 	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/config"
 	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/cosmosdb"
 	"github.com/treeverse/lakefs/pkg/kv/kvparams"
 )
 
@@ -156,6 +160,226 @@ var kvScanCmd = &cobra.Command{
 	},
 }
 
+var kvCopyCmd = &cobra.Command{
+	Use:   "copy",
+	Short: "Copy all keys and values to another Key-Value Store",
+	Long: `Copy all keys and values to another Key-Value Store, such as when moving from the local store to postgres or dynamodb.
+lakeFS must be stopped while copying: changes made during the copy are not copied.  Unless --no-verify is
+given, the copy checksums the source store before copying and fails if it changed by the time it is verified.
+The target store must be empty, unless resuming from a checkpoint.  cosmosdb stores cannot be copied, as they
+cannot list their partitions.
+Each store is given by a lakeFS configuration file, of which only the database section is used.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		flags := cmd.Flags()
+		from, err := flags.GetString("from")
+		if err != nil {
+			return err
+		}
+		to, err := flags.GetString("to")
+		if err != nil {
+			return err
+		}
+		checkpointFile, err := flags.GetString("checkpoint")
+		if err != nil {
+			return err
+		}
+		checkpointInterval, err := flags.GetInt("checkpoint-interval")
+		if err != nil {
+			return err
+		}
+		noVerify, err := flags.GetBool("no-verify")
+		if err != nil {
+			return err
+		}
+
+		var sourceDatabase *config.Database
+		if from == "" {
+			sourceDatabase = &LoadConfig().GetBaseConfig().Database
+		} else {
+			sourceDatabase, err = config.ReadDatabaseFile(from)
+			if err != nil {
+				return fmt.Errorf("read source configuration: %w", err)
+			}
+		}
+		targetDatabase, err := config.ReadDatabaseFile(to)
+		if err != nil {
+			return fmt.Errorf("read target configuration: %w", err)
+		}
+		if err := checkListPartitions(sourceDatabase); err != nil {
+			return fmt.Errorf("source: %w", err)
+		}
+		if err := checkListPartitions(targetDatabase); err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+
+		ctx := cmd.Context()
+		source, err := openKVStore(ctx, sourceDatabase)
+		if err != nil {
+			return fmt.Errorf("source: %w", err)
+		}
+		defer source.Close()
+		target, err := openKVStore(ctx, targetDatabase)
+		if err != nil {
+			return fmt.Errorf("target: %w", err)
+		}
+		defer target.Close()
+
+		opts := kv.CopyOptions{
+			CheckpointInterval: checkpointInterval,
+			Verify:             !noVerify,
+		}
+		if checkpointFile != "" {
+			opts.Checkpoint, err = readCopyCheckpoint(checkpointFile)
+			if err != nil {
+				return err
+			}
+			if opts.Checkpoint != nil {
+				fmt.Printf("Resuming copy after partition %q key %q\n", opts.Checkpoint.PartitionKey, opts.Checkpoint.Key)
+			}
+			opts.OnCheckpoint = func(_ context.Context, checkpoint kv.CopyCheckpoint) error {
+				return writeCopyCheckpoint(checkpointFile, checkpoint)
+			}
+		}
+
+		report, copyErr := kv.Copy(ctx, source, target, opts)
+		if report != nil {
+			encoder := json.NewEncoder(os.Stdout)
+			encoder.SetIndent("", "  ")
+			if err := encoder.Encode(report); err != nil {
+				return fmt.Errorf("json.Marshal failed: %w", err)
+			}
+		}
+		if copyErr != nil {
+			return fmt.Errorf("copy failed: %w", copyErr)
+		}
+		if checkpointFile != "" {
+			// the copy is complete, a new copy starts over
+			if err := os.Remove(checkpointFile); err != nil && !errors.Is(err, os.ErrNotExist) {
+				return err
+			}
+		}
+		return nil
+	},
+}
+
+var kvDumpCmd = &cobra.Command{
+	Use:   "dump",
+	Short: "Print all keys and values of the Key-Value Store",
+	Long: `Print all keys and values of the Key-Value Store, one JSON record per line, ordered by partition and key.
+Values of known keys are decoded, others are printed as base64.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		cfg := LoadConfig().GetBaseConfig()
+
+		partitionPrefix, err := cmd.Flags().GetString("partition-prefix")
+		if err != nil {
+			return err
+		}
+		pretty, err := cmd.Flags().GetBool("pretty")
+		if err != nil {
+			return err
+		}
+
+		ctx := cmd.Context()
+		kvStore, err := openKVStore(ctx, &cfg.Database)
+		if err != nil {
+			return err
+		}
+		defer kvStore.Close()
+
+		partitions, err := kv.ListPartitions(ctx, kvStore)
+		if err != nil {
+			return fmt.Errorf("list partitions: %w", err)
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		if pretty {
+			encoder.SetIndent("", "  ")
+		}
+		for _, partitionKey := range partitions {
+			if !strings.HasPrefix(string(partitionKey), partitionPrefix) {
+				continue
+			}
+			if err := dumpPartition(ctx, kvStore, string(partitionKey), encoder); err != nil {
+				return fmt.Errorf("dump partition %s: %w", partitionKey, err)
+			}
+		}
+		return nil
+	},
+}
+
+func dumpPartition(ctx context.Context, kvStore kv.Store, partitionKey string, encoder *json.Encoder) error {
+	iter, err := kvStore.Scan(ctx, []byte(partitionKey), kv.ScanOptions{})
+	if err != nil {
+		return fmt.Errorf("scan failed: %w", err)
+	}
+	defer iter.Close()
+	for iter.Next() {
+		entry := iter.Entry()
+		kvObj, err := kv.NewRecord(partitionKey, string(entry.Key), entry.Value)
+		if err != nil {
+			return fmt.Errorf("KV record from value: %w", err)
+		}
+		if err := encoder.Encode(kvObj); err != nil {
+			return fmt.Errorf("json.Marshal failed: %w", err)
+		}
+	}
+	if iter.Err() != nil {
+		return fmt.Errorf("scan operation ended with error: %w", iter.Err())
+	}
+	return nil
+}
+
+// checkListPartitions fails if the store of database cannot list its partitions, which copies,
+// backups and restores require
+func checkListPartitions(database *config.Database) error {
+	if database.Type == cosmosdb.DriverName {
+		return fmt.Errorf("%s store: %w", database.Type, kv.ErrListPartitionsNotSupported)
+	}
+	return nil
+}
+
+func openKVStore(ctx context.Context, database *config.Database) (kv.Store, error) {
+	kvParams, err := kvparams.NewConfig(database)
+	if err != nil {
+		return nil, fmt.Errorf("KV params: %w", err)
+	}
+	kvStore, err := kv.Open(ctx, kvParams)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open KV store: %w", err)
+	}
+	return kvStore, nil
+}
+
+// readCopyCheckpoint returns the checkpoint stored in filename, nil if it does not exist
+func readCopyCheckpoint(filename string) (*kv.CopyCheckpoint, error) {
+	data, err := os.ReadFile(filename)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("read checkpoint: %w", err)
+	}
+	var checkpoint kv.CopyCheckpoint
+	if err := json.Unmarshal(data, &checkpoint); err != nil {
+		return nil, fmt.Errorf("parse checkpoint %s: %w", filename, err)
+	}
+	return &checkpoint, nil
+}
+
+// writeCopyCheckpoint atomically replaces the checkpoint stored in filename
+func writeCopyCheckpoint(filename string, checkpoint kv.CopyCheckpoint) error {
+	data, err := json.Marshal(checkpoint)
+	if err != nil {
+		return err
+	}
+	tmp := filename + ".tmp"
+	if err := os.WriteFile(tmp, data, 0o600); err != nil { //nolint:mnd
+		return fmt.Errorf("write checkpoint: %w", err)
+	}
+	return os.Rename(tmp, filename)
+}
+
 //nolint:gochecknoinits
 func init() {
 	rootCmd.AddCommand(kvCmd)
@@ -165,4 +389,14 @@ func init() {
 	kvScanCmd.Flags().Int("limit", 0, "maximal number of results to return. By default, all results are returned")
 	kvScanCmd.Flags().String("until", "", "last prefix to scan. If this prefix is reached or exceeded, scan will stop")
 	kvScanCmd.Flags().Bool("pretty", false, "print indented output")
+	kvCmd.AddCommand(kvDumpCmd)
+	kvDumpCmd.Flags().String("partition-prefix", "", "dump only partitions starting with this prefix")
+	kvDumpCmd.Flags().Bool("pretty", false, "print indented output")
+	kvCmd.AddCommand(kvCopyCmd)
+	kvCopyCmd.Flags().String("from", "", "configuration file of the source store. By default, the store configured for lakeFS")
+	kvCopyCmd.Flags().String("to", "", "configuration file of the target store")
+	_ = kvCopyCmd.MarkFlagRequired("to")
+	kvCopyCmd.Flags().String("checkpoint", "", "file to save the progress of the copy in, and to resume a failed copy from")
+	kvCopyCmd.Flags().Int("checkpoint-interval", kv.DefaultCopyCheckpointInterval, "number of keys copied between saves of the progress")
+	kvCopyCmd.Flags().Bool("no-verify", false, "skip comparing the stores after the copy, and checking that the source store did not change")
 }
@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/treeverse/lakefs/pkg/logging"
+	"github.com/treeverse/lakefs/pkg/notifications"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+const deadLettersListDefaultAmount = 100
+
+var notificationsCmd = &cobra.Command{
+	Use:   "notifications",
+	Short: "Manage repository bucket notifications",
+}
+
+var deadLettersCmd = &cobra.Command{
+	Use:   "dead-letters",
+	Short: "List, replay and delete notifications that could not be delivered",
+	Long: `List, replay and delete notifications that could not be delivered.
+Dead letters older than notifications.dead_letter_retention are deleted by lakeFS.`,
+}
+
+var deadLettersListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List undelivered notifications, oldest first",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, _ []string) error {
+		after, err := cmd.Flags().GetString("after")
+		if err != nil {
+			return err
+		}
+		amount, err := cmd.Flags().GetInt("amount")
+		if err != nil {
+			return err
+		}
+		return withNotificationService(cmd.Context(), func(ctx context.Context, svc *notifications.Service) error {
+			letters, err := svc.ListDeadLetters(ctx, after, amount)
+			if err != nil {
+				return fmt.Errorf("list dead letters: %w", err)
+			}
+			for _, letter := range letters {
+				data, err := protojson.Marshal(letter)
+				if err != nil {
+					return fmt.Errorf("encode dead letter: %w", err)
+				}
+				fmt.Println(string(data))
+			}
+			return nil
+		})
+	},
+}
+
+var deadLettersReplayCmd = &cobra.Command{
+	Use:   "replay <id>...",
+	Short: "Send undelivered notifications to their sinks again, deleting the delivered ones",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withNotificationService(cmd.Context(), func(ctx context.Context, svc *notifications.Service) error {
+			for _, id := range args {
+				if err := svc.ReplayDeadLetter(ctx, id); err != nil {
+					return fmt.Errorf("replay %s: %w", id, err)
+				}
+				fmt.Printf("Replayed %s\n", id)
+			}
+			return nil
+		})
+	},
+}
+
+var deadLettersDeleteCmd = &cobra.Command{
+	Use:   "delete <id>...",
+	Short: "Delete undelivered notifications",
+	Args:  cobra.MinimumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return withNotificationService(cmd.Context(), func(ctx context.Context, svc *notifications.Service) error {
+			for _, id := range args {
+				if err := svc.DeleteDeadLetter(ctx, id); err != nil {
+					return fmt.Errorf("delete %s: %w", id, err)
+				}
+				fmt.Printf("Deleted %s\n", id)
+			}
+			return nil
+		})
+	},
+}
+
+// withNotificationService calls fn with a notification service on the configured store and sinks, without workers
+func withNotificationService(ctx context.Context, fn func(context.Context, *notifications.Service) error) error {
+	cfg := LoadConfig().GetBaseConfig()
+	kvStore, err := openKVStore(ctx, &cfg.Database)
+	if err != nil {
+		return err
+	}
+	defer kvStore.Close()
+	svc, err := notifications.NewService(cfg.Notifications, nil, kvStore, logging.ContextUnavailable())
+	if err != nil {
+		return fmt.Errorf("notifications service: %w", err)
+	}
+	return fn(ctx, svc)
+}
+
+//nolint:gochecknoinits
+func init() {
+	rootCmd.AddCommand(notificationsCmd)
+	notificationsCmd.AddCommand(deadLettersCmd)
+	deadLettersCmd.AddCommand(deadLettersListCmd)
+	deadLettersListCmd.Flags().String("after", "", "list the dead letters after this ID")
+	deadLettersListCmd.Flags().Int("amount", deadLettersListDefaultAmount, "maximum number of dead letters to list")
+	deadLettersCmd.AddCommand(deadLettersReplayCmd)
+	deadLettersCmd.AddCommand(deadLettersDeleteCmd)
+}
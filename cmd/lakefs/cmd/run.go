@@ -33,6 +33,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/gateway"
 	"github.com/treeverse/lakefs/pkg/gateway/multipart"
 	"github.com/treeverse/lakefs/pkg/gateway/sig"
+	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/ref"
 	"github.com/treeverse/lakefs/pkg/httputil"
 	"github.com/treeverse/lakefs/pkg/kv"
@@ -42,7 +43,9 @@ import (
 	"github.com/treeverse/lakefs/pkg/kv/local"
 	"github.com/treeverse/lakefs/pkg/kv/mem"
 	_ "github.com/treeverse/lakefs/pkg/kv/postgres"
+	"github.com/treeverse/lakefs/pkg/kv/sqlite"
 	"github.com/treeverse/lakefs/pkg/logging"
+	"github.com/treeverse/lakefs/pkg/notifications"
 	"github.com/treeverse/lakefs/pkg/stats"
 	"github.com/treeverse/lakefs/pkg/upload"
 	"github.com/treeverse/lakefs/pkg/version"
@@ -158,6 +161,10 @@ var runCmd = &cobra.Command{
 		}
 		defer func() { _ = c.Close() }()
 
+		if baseCfg.Lifecycle.Enabled {
+			c.StartLifecycle(ctx, baseCfg.Lifecycle.Interval, baseCfg.Lifecycle.DryRun, logger.WithField("service", "lifecycle"))
+		}
+
 		// usage report setup - default usage reporter ids a no-op
 		usageReporter := stats.DefaultUsageReporter
 		if baseCfg.UsageReport.Enabled {
@@ -175,7 +182,7 @@ var runCmd = &cobra.Command{
 
 		// initial setup - support only when a local database is configured.
 		// local database lock will make sure that only one instance will run the setup.
-		if (kvParams.Type == local.DriverName || kvParams.Type == mem.DriverName) &&
+		if (kvParams.Type == local.DriverName || kvParams.Type == sqlite.DriverName || kvParams.Type == mem.DriverName) &&
 			baseCfg.Installation.UserName != "" && baseCfg.Installation.AccessKeyID.SecureValue() != "" && baseCfg.Installation.SecretAccessKey.SecureValue() != "" {
 			setupCreds, err := setupLakeFS(ctx, cfg, authMetadataManager, authService, baseCfg.Installation.UserName,
 				baseCfg.Installation.AccessKeyID.SecureValue(), baseCfg.Installation.SecretAccessKey.SecureValue(), false)
@@ -200,7 +207,18 @@ var runCmd = &cobra.Command{
 
 		// wire actions into entry catalog
 		defer actionsService.Stop()
-		c.SetHooksHandler(actionsService)
+		var hooksHandler graveler.HooksHandler = actionsService
+		if baseCfg.Notifications.Enabled {
+			notificationService, err := notifications.NewService(baseCfg.Notifications, c, kvStore, logger.WithField("service", "notifications"))
+			if err != nil {
+				logger.WithError(err).Fatal("Failed to create notifications service")
+			}
+			notificationService.Start(ctx)
+			defer notificationService.Stop()
+			c.SetNotifier(notificationService)
+			hooksHandler = notificationService.HooksHandler(actionsService)
+		}
+		c.SetHooksHandler(hooksHandler)
 
 		middlewareAuthenticator, err := authenticationfactory.BuildAuthenticatorChain(cfg, logger, authService)
 		if err != nil {
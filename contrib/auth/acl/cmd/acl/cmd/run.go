@@ -10,7 +10,9 @@ import (
 
 	"github.com/spf13/cobra"
 	"github.com/treeverse/lakefs/contrib/auth/acl"
+	"github.com/treeverse/lakefs/pkg/auth"
 	"github.com/treeverse/lakefs/pkg/auth/crypt"
+	"github.com/treeverse/lakefs/pkg/auth/model"
 	"github.com/treeverse/lakefs/pkg/kv"
 	_ "github.com/treeverse/lakefs/pkg/kv/dynamodb"
 	"github.com/treeverse/lakefs/pkg/kv/kvparams"
@@ -45,6 +47,9 @@ var runCmd = &cobra.Command{
 		defer kvStore.Close()
 		secretStore := crypt.NewSecretStore(cfg.AuthEncryptionSecret())
 		authService := acl.NewAuthService(kvStore, secretStore, cfg.Cache)
+		if err := auth.WatchCache(cmd.Context(), kvStore, model.PartitionKey, authService.Cache()); err != nil {
+			logger.WithError(err).Warn("Failed to watch auth changes, cached entities will expire")
+		}
 
 		// Setup if needed
 		if err = acl.SetupACLServer(cmd.Context(), authService); err != nil {
@@ -39,13 +39,7 @@ func (c *Controller) GetCredentials(w http.ResponseWriter, r *http.Request, acce
 	if c.handleAPIError(w, err) {
 		return
 	}
-	response := apigen.CredentialsWithSecret{
-		UserName:        swag.String(credentials.Username),
-		AccessKeyId:     credentials.AccessKeyID,
-		SecretAccessKey: credentials.SecretAccessKey,
-		CreationDate:    credentials.IssuedDate.Unix(),
-	}
-	writeResponse(w, http.StatusOK, response)
+	writeResponse(w, http.StatusOK, serializeCredentialsWithSecret(credentials))
 }
 
 func (c *Controller) GetExternalPrincipal(w http.ResponseWriter, _ *http.Request, _ apigen.GetExternalPrincipalParams) {
@@ -228,9 +222,10 @@ func (c *Controller) CreatePolicy(w http.ResponseWriter, r *http.Request, body a
 	stmts := make(model.Statements, len(body.Statement))
 	for i, apiStatement := range body.Statement {
 		stmts[i] = model.Statement{
-			Effect:   apiStatement.Effect,
-			Action:   apiStatement.Action,
-			Resource: apiStatement.Resource,
+			Effect:    apiStatement.Effect,
+			Action:    apiStatement.Action,
+			Resource:  apiStatement.Resource,
+			Condition: conditionFromAPI(apiStatement.Condition),
 		}
 	}
 
@@ -276,9 +271,10 @@ func (c *Controller) UpdatePolicy(w http.ResponseWriter, r *http.Request, body a
 	stmts := make(model.Statements, len(body.Statement))
 	for i, apiStatement := range body.Statement {
 		stmts[i] = model.Statement{
-			Effect:   apiStatement.Effect,
-			Action:   apiStatement.Action,
-			Resource: apiStatement.Resource,
+			Effect:    apiStatement.Effect,
+			Action:    apiStatement.Action,
+			Resource:  apiStatement.Resource,
+			Condition: conditionFromAPI(apiStatement.Condition),
 		}
 	}
 
@@ -421,36 +417,30 @@ func (c *Controller) ListUserCredentials(w http.ResponseWriter, r *http.Request,
 		},
 	}
 	for _, c := range credentials {
-		response.Results = append(response.Results, apigen.Credentials{
-			AccessKeyId:  c.AccessKeyID,
-			CreationDate: c.IssuedDate.Unix(),
-		})
+		response.Results = append(response.Results, serializeCredentials(c))
 	}
 	writeResponse(w, http.StatusOK, response)
 }
 
-func (c *Controller) CreateCredentials(w http.ResponseWriter, r *http.Request, userID string, params apigen.CreateCredentialsParams) {
+func (c *Controller) CreateCredentials(w http.ResponseWriter, r *http.Request, body apigen.CreateCredentialsJSONRequestBody, userID string, params apigen.CreateCredentialsParams) {
 	ctx := r.Context()
 	var (
 		credentials *model.Credential
 		err         error
 	)
-	if params.AccessKey != nil && params.SecretKey != nil {
+	limits := credentialLimitsFromAPI(apigen.CredentialsCreation(body))
+	switch {
+	case !limits.IsZero():
+		credentials, err = c.Auth.CreateLimitedCredentials(ctx, userID, limits)
+	case params.AccessKey != nil && params.SecretKey != nil:
 		credentials, err = c.Auth.AddCredentials(ctx, userID, *params.AccessKey, *params.SecretKey)
-	} else {
+	default:
 		credentials, err = c.Auth.CreateCredentials(ctx, userID)
 	}
 	if c.handleAPIError(w, err) {
 		return
 	}
-
-	response := apigen.CredentialsWithSecret{
-		UserName:        swag.String(credentials.Username),
-		AccessKeyId:     credentials.AccessKeyID,
-		SecretAccessKey: credentials.SecretAccessKey,
-		CreationDate:    credentials.IssuedDate.Unix(),
-	}
-	writeResponse(w, http.StatusCreated, response)
+	writeResponse(w, http.StatusCreated, serializeCredentialsWithSecret(credentials))
 }
 
 func (c *Controller) DeleteCredentials(w http.ResponseWriter, r *http.Request, userID string, accessKeyID string) {
@@ -476,11 +466,7 @@ func (c *Controller) GetCredentialsForUser(w http.ResponseWriter, r *http.Reques
 	if c.handleAPIError(w, err) {
 		return
 	}
-	response := apigen.Credentials{
-		AccessKeyId:  credentials.AccessKeyID,
-		CreationDate: credentials.IssuedDate.Unix(),
-	}
-	writeResponse(w, http.StatusOK, response)
+	writeResponse(w, http.StatusOK, serializeCredentials(credentials))
 }
 
 func (c *Controller) CreateUserExternalPrincipal(w http.ResponseWriter, _ *http.Request, _ string, _ apigen.CreateUserExternalPrincipalParams) {
@@ -573,9 +559,10 @@ func serializePolicy(p *model.Policy) apigen.Policy {
 	stmts := make([]apigen.Statement, 0, len(p.Statement))
 	for _, s := range p.Statement {
 		stmts = append(stmts, apigen.Statement{
-			Action:   s.Action,
-			Effect:   s.Effect,
-			Resource: s.Resource,
+			Action:    s.Action,
+			Effect:    s.Effect,
+			Resource:  s.Resource,
+			Condition: serializeCondition(s.Condition),
 		})
 	}
 	createdAt := p.CreatedAt.Unix()
@@ -591,6 +578,89 @@ func serializePolicy(p *model.Policy) apigen.Policy {
 	}
 }
 
+func serializeCredentials(c *model.Credential) apigen.Credentials {
+	expirationDate, statement := serializeCredentialLimits(c.CredentialLimits)
+	return apigen.Credentials{
+		AccessKeyId:    c.AccessKeyID,
+		CreationDate:   c.IssuedDate.Unix(),
+		ExpirationDate: expirationDate,
+		Statement:      statement,
+	}
+}
+
+func serializeCredentialsWithSecret(c *model.Credential) apigen.CredentialsWithSecret {
+	expirationDate, statement := serializeCredentialLimits(c.CredentialLimits)
+	return apigen.CredentialsWithSecret{
+		UserName:        swag.String(c.Username),
+		AccessKeyId:     c.AccessKeyID,
+		SecretAccessKey: c.SecretAccessKey,
+		CreationDate:    c.IssuedDate.Unix(),
+		ExpirationDate:  expirationDate,
+		Statement:       statement,
+	}
+}
+
+func serializeCredentialLimits(limits model.CredentialLimits) (*int64, *[]apigen.Statement) {
+	var expirationDate *int64
+	if !limits.ExpirationDate.IsZero() {
+		expirationDate = swag.Int64(limits.ExpirationDate.Unix())
+	}
+	var statement *[]apigen.Statement
+	if len(limits.Statement) > 0 {
+		stmts := make([]apigen.Statement, 0, len(limits.Statement))
+		for _, s := range limits.Statement {
+			stmts = append(stmts, apigen.Statement{
+				Action:    s.Action,
+				Effect:    s.Effect,
+				Resource:  s.Resource,
+				Condition: serializeCondition(s.Condition),
+			})
+		}
+		statement = &stmts
+	}
+	return expirationDate, statement
+}
+
+func credentialLimitsFromAPI(body apigen.CredentialsCreation) model.CredentialLimits {
+	var limits model.CredentialLimits
+	if body.ExpirationDate != nil {
+		limits.ExpirationDate = time.Unix(*body.ExpirationDate, 0)
+	}
+	if body.Statement != nil {
+		for _, apiStatement := range *body.Statement {
+			limits.Statement = append(limits.Statement, model.Statement{
+				Effect:    apiStatement.Effect,
+				Action:    apiStatement.Action,
+				Resource:  apiStatement.Resource,
+				Condition: conditionFromAPI(apiStatement.Condition),
+			})
+		}
+	}
+	return limits
+}
+
+func serializeCondition(condition model.Condition) *apigen.Statement_Condition {
+	if len(condition) == 0 {
+		return nil
+	}
+	c := &apigen.Statement_Condition{AdditionalProperties: make(map[string]apigen.PolicyCondition, len(condition))}
+	for operator, keys := range condition {
+		c.AdditionalProperties[operator] = apigen.PolicyCondition{AdditionalProperties: keys}
+	}
+	return c
+}
+
+func conditionFromAPI(c *apigen.Statement_Condition) model.Condition {
+	if c == nil || len(c.AdditionalProperties) == 0 {
+		return nil
+	}
+	condition := make(model.Condition, len(c.AdditionalProperties))
+	for operator, keys := range c.AdditionalProperties {
+		condition[operator] = keys.AdditionalProperties
+	}
+	return condition
+}
+
 func (c *Controller) DetachPolicyFromUser(w http.ResponseWriter, _ *http.Request, _, _ string) {
 	writeError(w, http.StatusNotImplemented, "Not implemented")
 }
@@ -621,15 +621,7 @@ func ValidatePolicy(policy *model.Policy) error {
 		return err
 	}
 	for _, stmt := range policy.Statement {
-		for _, action := range stmt.Action {
-			if err := model.ValidateActionName(action); err != nil {
-				return err
-			}
-		}
-		if err := model.ValidateArn(stmt.Resource); err != nil {
-			return err
-		}
-		if err := model.ValidateStatementEffect(stmt.Effect); err != nil {
+		if err := model.ValidateStatement(stmt); err != nil {
 			return err
 		}
 	}
@@ -739,7 +731,20 @@ func (s *AuthService) CreateCredentials(ctx context.Context, username string) (*
 	return s.AddCredentials(ctx, username, accessKeyID, secretAccessKey)
 }
 
+func (s *AuthService) CreateLimitedCredentials(ctx context.Context, username string, limits model.CredentialLimits) (*model.Credential, error) {
+	if err := model.ValidateCredentialLimits(limits); err != nil {
+		return nil, err
+	}
+	accessKeyID := keys.GenAccessKeyID()
+	secretAccessKey := keys.GenSecretAccessKey()
+	return s.addCredentials(ctx, username, accessKeyID, secretAccessKey, limits)
+}
+
 func (s *AuthService) AddCredentials(ctx context.Context, username, accessKeyID, secretAccessKey string) (*model.Credential, error) {
+	return s.addCredentials(ctx, username, accessKeyID, secretAccessKey, model.CredentialLimits{})
+}
+
+func (s *AuthService) addCredentials(ctx context.Context, username, accessKeyID, secretAccessKey string, limits model.CredentialLimits) (*model.Credential, error) {
 	if !IsValidAccessKeyID(accessKeyID) {
 		return nil, auth.ErrInvalidAccessKeyID
 	}
@@ -763,7 +768,8 @@ func (s *AuthService) AddCredentials(ctx context.Context, username, accessKeyID,
 			SecretAccessKeyEncryptedBytes: encryptedKey,
 			IssuedDate:                    now,
 		},
-		Username: user.Username,
+		CredentialLimits: limits,
+		Username:         user.Username,
 	}
 	credentialsKey := model.CredentialPath(user.Username, c.AccessKeyID)
 	err = kv.SetMsgIf(ctx, s.store, model.PartitionKey, credentialsKey, model.ProtoFromCredential(c), nil)
@@ -901,7 +907,7 @@ func (s *AuthService) Authorize(ctx context.Context, req *auth.AuthorizationRequ
 		return nil, err
 	}
 	permAudit := &auth.MissingPermissions{}
-	allowed := auth.CheckPermissions(ctx, req.RequiredPermissions, req.Username, policies, permAudit)
+	allowed := auth.CheckPermissions(ctx, req.RequiredPermissions, req.Username, policies, req.RequestContext, permAudit)
 
 	if allowed != auth.CheckAllow {
 		return &auth.AuthorizationResponse{
@@ -662,3 +662,47 @@ func TestACL(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthService_CreateLimitedCredentials(t *testing.T) {
+	ctx := context.Background()
+	authService, _ := authtestutil.SetupService(t, ctx, someSecret)
+	const userName = "limited"
+	_, err := authService.CreateUser(ctx, &model.User{Username: userName})
+	require.NoError(t, err)
+
+	readStatement := model.Statement{
+		Effect:   model.StatementEffectAllow,
+		Action:   []string{"fs:Read*"},
+		Resource: permissions.RepoArn("repo1") + "*",
+	}
+	limits := model.CredentialLimits{
+		ExpirationDate: time.Now().Add(time.Hour).UTC().Truncate(time.Second),
+		Statement:      model.Statements{readStatement},
+	}
+	creds, err := authService.CreateLimitedCredentials(ctx, userName, limits)
+	require.NoError(t, err)
+	require.Equal(t, userName, creds.Username)
+	require.NotEmpty(t, creds.SecretAccessKey)
+
+	got, err := authService.GetCredentials(ctx, creds.AccessKeyID)
+	require.NoError(t, err)
+	require.True(t, got.ExpirationDate.Equal(limits.ExpirationDate), "expiration date %s, expected %s", got.ExpirationDate, limits.ExpirationDate)
+	require.Equal(t, limits.Statement, got.Statement)
+	require.False(t, got.IsExpired(time.Now()))
+	require.True(t, got.IsExpired(limits.ExpirationDate.Add(time.Second)))
+	require.Equal(t, creds.AccessKeyID, got.InlinePolicy().DisplayName)
+
+	t.Run("expired", func(t *testing.T) {
+		_, err := authService.CreateLimitedCredentials(ctx, userName, model.CredentialLimits{
+			ExpirationDate: time.Now().Add(-time.Minute),
+		})
+		require.ErrorIs(t, err, model.ErrValidationError)
+	})
+
+	t.Run("invalid statement", func(t *testing.T) {
+		_, err := authService.CreateLimitedCredentials(ctx, userName, model.CredentialLimits{
+			Statement: model.Statements{{Effect: "Maybe", Action: []string{"fs:ReadObject"}, Resource: "*"}},
+		})
+		require.ErrorIs(t, err, model.ErrValidationError)
+	})
+}
@@ -36,6 +36,21 @@ type Credentials struct {
 
 	// Unix Epoch in seconds
 	CreationDate int64 `json:"creation_date"`
+
+	// Unix Epoch in seconds after which the credentials are rejected. Credentials without it do not expire.
+	ExpirationDate *int64 `json:"expiration_date,omitempty"`
+
+	// Inline policy of the credentials. The credentials are allowed only the actions that both the policies of the user and these statements allow. Credentials without it have all the permissions of the user.
+	Statement *[]Statement `json:"statement,omitempty"`
+}
+
+// CredentialsCreation defines model for CredentialsCreation.
+type CredentialsCreation struct {
+	// Unix Epoch in seconds after which the credentials are rejected. Credentials without it do not expire.
+	ExpirationDate *int64 `json:"expiration_date,omitempty"`
+
+	// Inline policy of the credentials. The credentials are allowed only the actions that both the policies of the user and these statements allow. Credentials without it have all the permissions of the user.
+	Statement *[]Statement `json:"statement,omitempty"`
 }
 
 // CredentialsList defines model for CredentialsList.
@@ -49,9 +64,15 @@ type CredentialsWithSecret struct {
 	AccessKeyId string `json:"access_key_id"`
 
 	// Unix Epoch in seconds
-	CreationDate    int64  `json:"creation_date"`
+	CreationDate int64 `json:"creation_date"`
+
+	// Unix Epoch in seconds after which the credentials are rejected. Credentials without it do not expire.
+	ExpirationDate  *int64 `json:"expiration_date,omitempty"`
 	SecretAccessKey string `json:"secret_access_key"`
-	UserId          int64  `json:"user_id"`
+
+	// Inline policy of the credentials. The credentials are allowed only the actions that both the policies of the user and these statements allow. Credentials without it have all the permissions of the user.
+	Statement *[]Statement `json:"statement,omitempty"`
+	UserId    int64        `json:"user_id"`
 
 	// A unique identifier for the user. In password-based authentication should be the email.
 	UserName *string `json:"user_name,omitempty"`
@@ -122,6 +143,11 @@ type Policy struct {
 	Statement    []Statement `json:"statement"`
 }
 
+// PolicyCondition Maps request context keys to the values a condition operator tests them against
+type PolicyCondition struct {
+	AdditionalProperties map[string][]string `json:"-"`
+}
+
 // PolicyList defines model for PolicyList.
 type PolicyList struct {
 	Pagination Pagination `json:"pagination"`
@@ -130,9 +156,17 @@ type PolicyList struct {
 
 // Statement defines model for Statement.
 type Statement struct {
-	Action   []string `json:"action"`
-	Effect   string   `json:"effect"`
-	Resource string   `json:"resource"`
+	Action []string `json:"action"`
+
+	// Condition Optional conditions for the statement to apply. Maps a condition operator to the request context keys it tests and the values to test them against.
+	Condition *Statement_Condition `json:"condition,omitempty"`
+	Effect    string               `json:"effect"`
+	Resource  string               `json:"resource"`
+}
+
+// Statement_Condition Optional conditions for the statement to apply. Maps a condition operator to the request context keys it tests and the values to test them against.
+type Statement_Condition struct {
+	AdditionalProperties map[string]PolicyCondition `json:"-"`
 }
 
 // User defines model for User.
@@ -299,6 +333,9 @@ type ListUserCredentialsParams struct {
 	Amount *PaginationAmount `json:"amount,omitempty"`
 }
 
+// CreateCredentialsJSONBody defines parameters for CreateCredentials.
+type CreateCredentialsJSONBody CredentialsCreation
+
 // CreateCredentialsParams defines parameters for CreateCredentials.
 type CreateCredentialsParams struct {
 	AccessKey *string `json:"access_key,omitempty"`
@@ -377,12 +414,121 @@ type ClaimTokenIdJSONRequestBody ClaimTokenIdJSONBody
 // CreateUserJSONRequestBody defines body for CreateUser for application/json ContentType.
 type CreateUserJSONRequestBody CreateUserJSONBody
 
+// CreateCredentialsJSONRequestBody defines body for CreateCredentials for application/json ContentType.
+type CreateCredentialsJSONRequestBody CreateCredentialsJSONBody
+
 // UpdateUserFriendlyNameJSONRequestBody defines body for UpdateUserFriendlyName for application/json ContentType.
 type UpdateUserFriendlyNameJSONRequestBody UpdateUserFriendlyNameJSONBody
 
 // UpdatePasswordJSONRequestBody defines body for UpdatePassword for application/json ContentType.
 type UpdatePasswordJSONRequestBody UpdatePasswordJSONBody
 
+// Getter for additional properties for PolicyCondition. Returns the specified
+// element and whether it was found
+func (a PolicyCondition) Get(fieldName string) (value []string, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for PolicyCondition
+func (a *PolicyCondition) Set(fieldName string, value []string) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string][]string)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for PolicyCondition to handle AdditionalProperties
+func (a *PolicyCondition) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string][]string)
+		for fieldName, fieldBuf := range object {
+			var fieldVal []string
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for PolicyCondition to handle AdditionalProperties
+func (a PolicyCondition) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
+// Getter for additional properties for Statement_Condition. Returns the specified
+// element and whether it was found
+func (a Statement_Condition) Get(fieldName string) (value PolicyCondition, found bool) {
+	if a.AdditionalProperties != nil {
+		value, found = a.AdditionalProperties[fieldName]
+	}
+	return
+}
+
+// Setter for additional properties for Statement_Condition
+func (a *Statement_Condition) Set(fieldName string, value PolicyCondition) {
+	if a.AdditionalProperties == nil {
+		a.AdditionalProperties = make(map[string]PolicyCondition)
+	}
+	a.AdditionalProperties[fieldName] = value
+}
+
+// Override default JSON handling for Statement_Condition to handle AdditionalProperties
+func (a *Statement_Condition) UnmarshalJSON(b []byte) error {
+	object := make(map[string]json.RawMessage)
+	err := json.Unmarshal(b, &object)
+	if err != nil {
+		return err
+	}
+
+	if len(object) != 0 {
+		a.AdditionalProperties = make(map[string]PolicyCondition)
+		for fieldName, fieldBuf := range object {
+			var fieldVal PolicyCondition
+			err := json.Unmarshal(fieldBuf, &fieldVal)
+			if err != nil {
+				return fmt.Errorf("error unmarshaling field %s: %w", fieldName, err)
+			}
+			a.AdditionalProperties[fieldName] = fieldVal
+		}
+	}
+	return nil
+}
+
+// Override default JSON handling for Statement_Condition to handle AdditionalProperties
+func (a Statement_Condition) MarshalJSON() ([]byte, error) {
+	var err error
+	object := make(map[string]json.RawMessage)
+
+	for fieldName, field := range a.AdditionalProperties {
+		object[fieldName], err = json.Marshal(field)
+		if err != nil {
+			return nil, fmt.Errorf("error marshaling '%s': %w", fieldName, err)
+		}
+	}
+	return json.Marshal(object)
+}
+
 // chi-interface.tmpl based on https://github.com/deepmap/oapi-codegen/tree/master/pkg/codegen/templates
 
 // ServerInterface represents all server handlers.
@@ -458,7 +604,7 @@ type ServerInterface interface {
 	ListUserCredentials(w http.ResponseWriter, r *http.Request, userId string, params ListUserCredentialsParams)
 	// create credentials
 	// (POST /auth/users/{userId}/credentials)
-	CreateCredentials(w http.ResponseWriter, r *http.Request, userId string, params CreateCredentialsParams)
+	CreateCredentials(w http.ResponseWriter, r *http.Request, body CreateCredentialsJSONRequestBody, userId string, params CreateCredentialsParams)
 	// delete credentials
 	// (DELETE /auth/users/{userId}/credentials/{accessKeyId})
 	DeleteCredentials(w http.ResponseWriter, r *http.Request, userId string, accessKeyId string)
@@ -1408,6 +1554,16 @@ func (siw *ServerInterfaceWrapper) ListUserCredentials(w http.ResponseWriter, r
 func (siw *ServerInterfaceWrapper) CreateCredentials(w http.ResponseWriter, r *http.Request) {
 	ctx := r.Context()
 
+	// ------------- Body parse -------------
+	var body CreateCredentialsJSONRequestBody
+	parseBody := r.ContentLength != 0
+	if parseBody {
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			http.Error(w, "Error unmarshalling body 'CreateCredentials' as JSON", http.StatusBadRequest)
+			return
+		}
+	}
+
 	var err error
 
 	// ------------- Path parameter "userId" -------------
@@ -1445,7 +1601,7 @@ func (siw *ServerInterfaceWrapper) CreateCredentials(w http.ResponseWriter, r *h
 	}
 
 	handler := func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.CreateCredentials(w, r, userId, params)
+		siw.Handler.CreateCredentials(w, r, body, userId, params)
 	}
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -0,0 +1,82 @@
+package esti
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var rePullRequestCreated = regexp.MustCompile(`Pull request (\S+) created`)
+
+// createPullRequest creates a pull request with lakectl and returns its ID
+func createPullRequest(t *testing.T, repoName, sourceBranch, destinationBranch, title string, vars map[string]string) string {
+	t.Helper()
+	out := runCmd(t, Lakectl()+" pr create lakefs://"+repoName+"/"+sourceBranch+" lakefs://"+repoName+"/"+destinationBranch+" --title '"+title+"'", false, false, vars)
+	match := rePullRequestCreated.FindStringSubmatch(out)
+	require.NotNilf(t, match, "Unexpected output for pr create: %s", out)
+	return match[1]
+}
+
+func TestLakectlPullRequest(t *testing.T) {
+	repoName := GenerateUniqueRepositoryName()
+	storage := GenerateUniqueStorageNamespace(repoName)
+	vars := map[string]string{
+		"REPO":    repoName,
+		"STORAGE": storage,
+		"BRANCH":  mainBranch,
+	}
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" repo create lakefs://"+repoName+" "+storage, false, "lakectl_repo_create", vars)
+
+	featureBranch := "feature"
+	branchVars := map[string]string{
+		"REPO":          repoName,
+		"STORAGE":       storage,
+		"SOURCE_BRANCH": mainBranch,
+		"DEST_BRANCH":   featureBranch,
+	}
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" branch create lakefs://"+repoName+"/"+featureBranch+" --source lakefs://"+repoName+"/"+mainBranch, false, "lakectl_branch_create", branchVars)
+
+	// upload 'file1' on 'feature' and commit
+	filePath := "file1"
+	vars["BRANCH"] = featureBranch
+	vars["FILE_PATH"] = filePath
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" fs upload -s files/ro_1k lakefs://"+repoName+"/"+featureBranch+"/"+filePath, false, "lakectl_fs_upload", vars)
+	commitMessage := "add file on feature branch"
+	vars["MESSAGE"] = commitMessage
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" commit lakefs://"+repoName+"/"+featureBranch+" -m \""+commitMessage+"\"", false, "lakectl_commit", vars)
+
+	// branches of different repositories
+	RunCmdAndVerifyFailureContainsText(t, Lakectl()+" pr create lakefs://"+repoName+"/"+featureBranch+" lakefs://other-repo/"+mainBranch+" --title 'add file1'", false, "both branches must belong to the same repository", vars)
+
+	pullRequestID := createPullRequest(t, repoName, featureBranch, mainBranch, "add file1", vars)
+	prVars := map[string]string{
+		"REPO":          repoName,
+		"PR_ID":         pullRequestID,
+		"SOURCE_BRANCH": featureBranch,
+		"DEST_BRANCH":   mainBranch,
+	}
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr show lakefs://"+repoName+" "+pullRequestID, false, "ID: ${PR_ID}\nTitle: add file1\nStatus: open\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr show lakefs://"+repoName+" "+pullRequestID, false, "Source: ${SOURCE_BRANCH}\nDestination: ${DEST_BRANCH}\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr list lakefs://"+repoName+" --status open", false, pullRequestID, prVars)
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" pr diff lakefs://"+repoName+" "+pullRequestID, false, "lakectl_pr_diff", prVars)
+
+	// update and review
+	RunCmdAndVerifyFailureContainsText(t, Lakectl()+" pr update lakefs://"+repoName+" "+pullRequestID, false, "Nothing to update, use --title or --description", prVars)
+	RunCmdAndVerifySuccess(t, Lakectl()+" pr update lakefs://"+repoName+" "+pullRequestID+" --title 'add first file' --description 'adds file1'", false, "Pull request ${PR_ID} updated\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr show lakefs://"+repoName+" "+pullRequestID, false, "Title: add first file\n", prVars)
+	RunCmdAndVerifyFailureContainsText(t, Lakectl()+" pr review lakefs://"+repoName+" "+pullRequestID, false, "A comment review requires --comment", prVars)
+	RunCmdAndVerifySuccess(t, Lakectl()+" pr review lakefs://"+repoName+" "+pullRequestID+" --comment 'looks good'", false, "Pull request ${PR_ID} reviewed: commented\n", prVars)
+
+	// merge
+	RunCmdAndVerifySuccess(t, Lakectl()+" pr merge lakefs://"+repoName+" "+pullRequestID, false, "Pull request ${PR_ID} merged to get <COMMIT_ID>\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr show lakefs://"+repoName+" "+pullRequestID, false, "Status: merged\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr list lakefs://"+repoName+" --status closed", false, pullRequestID, prVars)
+
+	// close without merging
+	pullRequestID = createPullRequest(t, repoName, featureBranch, mainBranch, "nothing to merge", vars)
+	prVars["PR_ID"] = pullRequestID
+	RunCmdAndVerifySuccess(t, Lakectl()+" pr close lakefs://"+repoName+" "+pullRequestID, false, "Pull request ${PR_ID} closed\n", prVars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" pr show lakefs://"+repoName+" "+pullRequestID, false, "Status: closed\n", prVars)
+	RunCmdAndVerifyFailureContainsText(t, Lakectl()+" pr merge lakefs://"+repoName+" "+pullRequestID, false, "bad pull request status", prVars)
+}
@@ -907,6 +907,41 @@ func TestLakectlFsStat(t *testing.T) {
 	})
 }
 
+func TestLakectlRefAtTime(t *testing.T) {
+	repoName := GenerateUniqueRepositoryName()
+	storage := GenerateUniqueStorageNamespace(repoName)
+	vars := map[string]string{
+		"REPO":    repoName,
+		"STORAGE": storage,
+		"BRANCH":  mainBranch,
+	}
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" repo create lakefs://"+repoName+" "+storage, false, "lakectl_repo_create", vars)
+
+	// commits dated after the initial commit of the repository, an hour apart
+	firstDate := time.Now().Add(time.Hour).Truncate(time.Second)
+	for i, date := range []time.Time{firstDate, firstDate.Add(time.Hour)} {
+		vars["FILE_PATH"] = fmt.Sprintf("data/ro_1k.%d", i)
+		RunCmdAndVerifySuccessWithFile(t, Lakectl()+" fs upload -s files/ro_1k lakefs://"+repoName+"/"+mainBranch+"/"+vars["FILE_PATH"], false, "lakectl_fs_upload", vars)
+		runCmd(t, Lakectl()+" commit lakefs://"+repoName+"/"+mainBranch+fmt.Sprintf(` -m "commit %d" --epoch-time-seconds %d`, i, date.Unix()), false, false, vars)
+	}
+	// quoted lakeFS URI of the main branch at a time, and of a path on it
+	refAt := func(at time.Time, path ...string) string {
+		return `"lakefs://` + repoName + "/" + strings.Join(append([]string{mainBranch + "@{" + at.UTC().Format(time.RFC3339) + "}"}, path...), "/") + `"`
+	}
+	betweenCommits := firstDate.Add(30 * time.Minute)
+
+	RunCmdAndVerifyContainsText(t, Lakectl()+" log --amount 1 "+refAt(betweenCommits), false, "commit 0", vars)
+	RunCmdAndVerifyContainsText(t, Lakectl()+" log --amount 1 "+refAt(firstDate.Add(2*time.Hour)), false, "commit 1", vars)
+	runCmd(t, Lakectl()+" fs stat "+refAt(betweenCommits, "data/ro_1k.0"), false, false, vars)
+	runCmd(t, Lakectl()+" fs stat "+refAt(betweenCommits, "data/ro_1k.1"), true, false, vars)
+
+	// a commit dated before its parent makes resolving across it fail
+	vars["FILE_PATH"] = "data/ro_1k.2"
+	RunCmdAndVerifySuccessWithFile(t, Lakectl()+" fs upload -s files/ro_1k lakefs://"+repoName+"/"+mainBranch+"/"+vars["FILE_PATH"], false, "lakectl_fs_upload", vars)
+	runCmd(t, Lakectl()+" commit lakefs://"+repoName+"/"+mainBranch+` -m "commit 2" --epoch-time-seconds 0`, false, false, vars)
+	RunCmdAndVerifyFailureContainsText(t, Lakectl()+" log --amount 1 "+refAt(betweenCommits), false, "commit creation dates out of order", vars)
+}
+
 func TestLakectlImport(t *testing.T) {
 	// TODO(barak): generalize test to work all supported object stores
 	const IngestTestBucketPath = "s3://esti-system-testing-data/ingest-test-data/"
@@ -3,11 +3,15 @@ package esti
 import (
 	"bytes"
 	"context"
+	"crypto/md5" //nolint:gosec
+	cryptorand "crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"io"
 	"math/rand"
 	"net/http"
 	"net/url"
+	"os"
 	"strconv"
 	"strings"
 	"sync"
@@ -285,10 +289,9 @@ func TestS3IfNoneMatch(t *testing.T) {
 			IfNoneMatch: "*",
 		},
 		{
-			Name:          "unsupported value",
-			Path:          "main/object3",
-			IfNoneMatch:   "unsupported string",
-			ExpectedError: gtwerrors.ErrNotImplemented.Error(),
+			Name:        "etag on missing object",
+			Path:        "main/object3",
+			IfNoneMatch: `"some-etag"`,
 		},
 	}
 
@@ -309,20 +312,239 @@ func TestS3IfNoneMatch(t *testing.T) {
 }
 
 func setIfNonMatchHeader(ifNoneMatch string) func(*middleware.Stack) error {
+	return setRequestHeader("If-None-Match", ifNoneMatch)
+}
+
+// setRequestHeader sets a request header the S3 client has no input field for
+func setRequestHeader(name, value string) func(*middleware.Stack) error {
 	return func(stack *middleware.Stack) error {
-		return stack.Build.Add(middleware.BuildMiddlewareFunc("AddIfNoneMatchHeader", func(
+		return stack.Build.Add(middleware.BuildMiddlewareFunc("AddHeader"+name, func(
 			ctx context.Context, in middleware.BuildInput, next middleware.BuildHandler,
 		) (
 			middleware.BuildOutput, middleware.Metadata, error,
 		) {
 			if req, ok := in.Request.(*smithyhttp.Request); ok {
-				req.Header.Set("If-None-Match", ifNoneMatch)
+				req.Header.Set(name, value)
 			}
 			return next.HandleBuild(ctx, in)
 		}), middleware.Before)
 	}
 }
 
+func requireHTTPStatus(t *testing.T, err error, expectedStatus int) {
+	t.Helper()
+	var respErr *smithyhttp.ResponseError
+	require.ErrorAs(t, err, &respErr)
+	require.Equal(t, expectedStatus, respErr.HTTPStatusCode())
+}
+
+func TestS3ConditionalRequests(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	const key = "main/conditional"
+	putResp, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(repo),
+		Key:    aws.String(key),
+		Body:   strings.NewReader("first"),
+	})
+	require.NoError(t, err)
+	etag := aws.ToString(putResp.ETag)
+	const otherETag = `"0123456789abcdef0123456789abcdef"`
+	past := time.Now().Add(-time.Hour)
+	future := time.Now().Add(time.Hour)
+
+	t.Run("get", func(t *testing.T) {
+		testCases := []struct {
+			Name           string
+			Input          s3.GetObjectInput
+			ExpectedStatus int
+		}{
+			{Name: "if-match", Input: s3.GetObjectInput{IfMatch: aws.String(etag)}, ExpectedStatus: http.StatusOK},
+			{Name: "if-match mismatch", Input: s3.GetObjectInput{IfMatch: aws.String(otherETag)}, ExpectedStatus: http.StatusPreconditionFailed},
+			{Name: "if-none-match", Input: s3.GetObjectInput{IfNoneMatch: aws.String(etag)}, ExpectedStatus: http.StatusNotModified},
+			{Name: "if-none-match mismatch", Input: s3.GetObjectInput{IfNoneMatch: aws.String(otherETag)}, ExpectedStatus: http.StatusOK},
+			{Name: "if-modified-since", Input: s3.GetObjectInput{IfModifiedSince: aws.Time(past)}, ExpectedStatus: http.StatusOK},
+			{Name: "if-modified-since not modified", Input: s3.GetObjectInput{IfModifiedSince: aws.Time(future)}, ExpectedStatus: http.StatusNotModified},
+			{Name: "if-unmodified-since", Input: s3.GetObjectInput{IfUnmodifiedSince: aws.Time(future)}, ExpectedStatus: http.StatusOK},
+			{Name: "if-unmodified-since modified", Input: s3.GetObjectInput{IfUnmodifiedSince: aws.Time(past)}, ExpectedStatus: http.StatusPreconditionFailed},
+			{Name: "if-match overrides if-unmodified-since", Input: s3.GetObjectInput{IfMatch: aws.String(etag), IfUnmodifiedSince: aws.Time(past)}, ExpectedStatus: http.StatusOK},
+			{Name: "if-none-match overrides if-modified-since", Input: s3.GetObjectInput{IfNoneMatch: aws.String(etag), IfModifiedSince: aws.Time(past)}, ExpectedStatus: http.StatusNotModified},
+		}
+		for _, tt := range testCases {
+			t.Run(tt.Name, func(t *testing.T) {
+				input := tt.Input
+				input.Bucket = aws.String(repo)
+				input.Key = aws.String(key)
+				resp, err := s3Client.GetObject(ctx, &input)
+				if tt.ExpectedStatus == http.StatusOK {
+					require.NoError(t, err)
+					_ = resp.Body.Close()
+					return
+				}
+				requireHTTPStatus(t, err, tt.ExpectedStatus)
+			})
+		}
+	})
+
+	t.Run("head", func(t *testing.T) {
+		_, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(repo), Key: aws.String(key), IfNoneMatch: aws.String(etag)})
+		requireHTTPStatus(t, err, http.StatusNotModified)
+		_, err = s3Client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(repo), Key: aws.String(key), IfMatch: aws.String(otherETag)})
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+		_, err = s3Client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(repo), Key: aws.String(key), IfMatch: aws.String(etag)})
+		require.NoError(t, err)
+	})
+
+	t.Run("put", func(t *testing.T) {
+		input := &s3.PutObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(key),
+			Body:   strings.NewReader("second"),
+		}
+		_, err := s3Client.PutObject(ctx, input, s3.WithAPIOptions(setRequestHeader("If-Match", otherETag)))
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+		_, err = s3Client.PutObject(ctx, input, s3.WithAPIOptions(setRequestHeader("If-None-Match", etag)))
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+		_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String("main/conditional-missing"),
+			Body:   strings.NewReader("second"),
+		}, s3.WithAPIOptions(setRequestHeader("If-Match", "*")))
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+
+		input.Body = strings.NewReader("second")
+		resp, err := s3Client.PutObject(ctx, input, s3.WithAPIOptions(setRequestHeader("If-Match", etag)))
+		require.NoError(t, err)
+		require.NotEqual(t, etag, aws.ToString(resp.ETag))
+		etag = aws.ToString(resp.ETag)
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		copySource := repo + "/" + key
+		const dest = "main/conditional-copy"
+		_, err := s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            aws.String(repo),
+			Key:               aws.String(dest),
+			CopySource:        aws.String(copySource),
+			CopySourceIfMatch: aws.String(otherETag),
+		})
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+		_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:                aws.String(repo),
+			Key:                   aws.String(dest),
+			CopySource:            aws.String(copySource),
+			CopySourceIfNoneMatch: aws.String(etag),
+		})
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+		_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:                    aws.String(repo),
+			Key:                       aws.String(dest),
+			CopySource:                aws.String(copySource),
+			CopySourceIfModifiedSince: aws.Time(future),
+		})
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+
+		_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:            aws.String(repo),
+			Key:               aws.String(dest),
+			CopySource:        aws.String(copySource),
+			CopySourceIfMatch: aws.String(etag),
+		}, s3.WithAPIOptions(setRequestHeader("If-None-Match", "*")))
+		require.NoError(t, err)
+
+		// destination exists now
+		_, err = s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(repo),
+			Key:        aws.String(dest),
+			CopySource: aws.String(copySource),
+		}, s3.WithAPIOptions(setRequestHeader("If-None-Match", "*")))
+		requireHTTPStatus(t, err, http.StatusPreconditionFailed)
+	})
+}
+
+func TestS3SelectObjectContent(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	const key = "main/data.csv"
+	_, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket: aws.String(repo),
+		Key:    aws.String(key),
+		Body:   strings.NewReader("name,size\na,1\nb,20\nc,300\n"),
+	})
+	require.NoError(t, err)
+
+	selectInput := func(expression string) *s3.SelectObjectContentInput {
+		return &s3.SelectObjectContentInput{
+			Bucket:         aws.String(repo),
+			Key:            aws.String(key),
+			Expression:     aws.String(expression),
+			ExpressionType: types.ExpressionTypeSql,
+			InputSerialization: &types.InputSerialization{
+				CSV: &types.CSVInput{FileHeaderInfo: types.FileHeaderInfoUse},
+			},
+			OutputSerialization: &types.OutputSerialization{
+				JSON: &types.JSONOutput{},
+			},
+		}
+	}
+
+	t.Run("select", func(t *testing.T) {
+		resp, err := s3Client.SelectObjectContent(ctx, selectInput("SELECT s.name FROM S3Object s WHERE CAST(s.size AS INT) > 10"))
+		require.NoError(t, err)
+		stream := resp.GetStream()
+		defer func() { _ = stream.Close() }()
+		var (
+			records []byte
+			stats   *types.Stats
+			ended   bool
+		)
+		for event := range stream.Events() {
+			switch e := event.(type) {
+			case *types.SelectObjectContentEventStreamMemberRecords:
+				records = append(records, e.Value.Payload...)
+			case *types.SelectObjectContentEventStreamMemberStats:
+				stats = e.Value.Details
+			case *types.SelectObjectContentEventStreamMemberEnd:
+				ended = true
+			}
+		}
+		require.NoError(t, stream.Err())
+		require.Equal(t, "{\"name\":\"b\"}\n{\"name\":\"c\"}\n", string(records))
+		require.True(t, ended, "expected End event")
+		require.NotNil(t, stats)
+		require.Equal(t, int64(len(records)), aws.ToInt64(stats.BytesReturned))
+	})
+
+	t.Run("parse error", func(t *testing.T) {
+		_, err := s3Client.SelectObjectContent(ctx, selectInput("SELECT FROM S3Object"))
+		requireHTTPStatus(t, err, http.StatusBadRequest)
+		require.ErrorContains(t, err, "ParseSelectFailure")
+	})
+
+	t.Run("evaluation error", func(t *testing.T) {
+		resp, err := s3Client.SelectObjectContent(ctx, selectInput("SELECT CAST(s.name AS INT) FROM S3Object s"))
+		require.NoError(t, err)
+		stream := resp.GetStream()
+		defer func() { _ = stream.Close() }()
+		for range stream.Events() {
+		}
+		require.ErrorContains(t, stream.Err(), "EvaluatorInvalidArguments")
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		input := selectInput("SELECT * FROM S3Object")
+		input.Key = aws.String("main/no-such-object.csv")
+		_, err := s3Client.SelectObjectContent(ctx, input)
+		requireHTTPStatus(t, err, http.StatusNotFound)
+	})
+}
+
 func TestListMultipartUploads(t *testing.T) {
 	t.Parallel()
 	RequireBlockstoreType(t, block.BlockstoreTypeS3)
@@ -902,23 +1124,553 @@ func TestS3CopyObject(t *testing.T) {
 	})
 }
 
-func TestS3PutObjectTagging(t *testing.T) {
+func TestS3ObjectTagging(t *testing.T) {
 	t.Parallel()
 	ctx, _, repo := setupTest(t)
 	defer tearDownTest(repo)
 
-	srcPath := gatewayTestPrefix + "source-file"
-	s3lakefsClient := newMinioClient(t, credentials.NewStaticV2)
+	objPath := gatewayTestPrefix + "tagged-file"
+	s3lakefsClient := newMinioClient(t, credentials.NewStaticV4)
+
+	_, err := s3lakefsClient.PutObject(ctx, repo, objPath, strings.NewReader("data"), int64(len("data")), minio.PutObjectOptions{
+		UserTags: map[string]string{"team": "data", "stage": "raw"},
+	})
+	require.NoError(t, err)
+
+	objTags, err := s3lakefsClient.GetObjectTagging(ctx, repo, objPath, minio.GetObjectTaggingOptions{})
+	require.NoError(t, err)
+	require.Equal(t, map[string]string{"team": "data", "stage": "raw"}, objTags.ToMap())
+
+	info, err := s3lakefsClient.StatObject(ctx, repo, objPath, minio.StatObjectOptions{})
+	require.NoError(t, err)
+	require.Equal(t, 2, info.UserTagCount)
+
+	t.Run("put", func(t *testing.T) {
+		tag, err := tags.NewTags(map[string]string{"tag1": "value1", "key with space": "value/with+chars"}, true)
+		require.NoError(t, err)
+		err = s3lakefsClient.PutObjectTagging(ctx, repo, objPath, tag, minio.PutObjectTaggingOptions{})
+		require.NoError(t, err)
+
+		objTags, err := s3lakefsClient.GetObjectTagging(ctx, repo, objPath, minio.GetObjectTaggingOptions{})
+		require.NoError(t, err)
+		require.Equal(t, tag.ToMap(), objTags.ToMap())
+
+		// tags are staged on the branch like any other change
+		resp, err := client.DiffBranchWithResponse(ctx, repo, mainBranch, &apigen.DiffBranchParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, resp.JSON200.Results, 1)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		err := s3lakefsClient.RemoveObjectTagging(ctx, repo, objPath, minio.RemoveObjectTaggingOptions{})
+		require.NoError(t, err)
+
+		objTags, err := s3lakefsClient.GetObjectTagging(ctx, repo, objPath, minio.GetObjectTaggingOptions{})
+		require.NoError(t, err)
+		require.Empty(t, objTags.ToMap())
+	})
+
+	t.Run("missing object", func(t *testing.T) {
+		tag, err := tags.NewTags(map[string]string{"tag1": "value1"}, true)
+		require.NoError(t, err)
+		err = s3lakefsClient.PutObjectTagging(ctx, repo, gatewayTestPrefix+"no-such-file", tag, minio.PutObjectTaggingOptions{})
+		require.Equal(t, "NoSuchKey", minio.ToErrorResponse(err).Code)
+	})
+
+	t.Run("too many tags", func(t *testing.T) {
+		s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+		tagSet := make([]types.Tag, 11)
+		for i := range tagSet {
+			tagSet[i] = types.Tag{Key: aws.String(fmt.Sprintf("key%d", i)), Value: aws.String("value")}
+		}
+		_, err := s3Client.PutObjectTagging(ctx, &s3.PutObjectTaggingInput{
+			Bucket:  aws.String(repo),
+			Key:     aws.String(objPath),
+			Tagging: &types.Tagging{TagSet: tagSet},
+		})
+		require.ErrorContains(t, err, "InvalidTag")
+	})
+}
+
+func TestS3BucketLifecycle(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	_, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(repo)})
+	require.ErrorContains(t, err, "NoSuchLifecycleConfiguration")
+
+	_, err = s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+		Bucket: aws.String(repo),
+		LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+			Rules: []types.LifecycleRule{
+				{
+					ID:         aws.String("logs"),
+					Filter:     &types.LifecycleRuleFilterMemberPrefix{Value: mainBranch + "/logs/"},
+					Status:     types.ExpirationStatusEnabled,
+					Expiration: &types.LifecycleExpiration{Days: aws.Int32(30)},
+				},
+			},
+		},
+	})
+	require.NoError(t, err)
+
+	getResp, err := s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(repo)})
+	require.NoError(t, err)
+	require.Len(t, getResp.Rules, 1)
+	require.Equal(t, "logs", aws.ToString(getResp.Rules[0].ID))
+	filter, ok := getResp.Rules[0].Filter.(*types.LifecycleRuleFilterMemberPrefix)
+	require.True(t, ok, "filter %T", getResp.Rules[0].Filter)
+	require.Equal(t, mainBranch+"/logs/", filter.Value)
+	require.Equal(t, types.ExpirationStatusEnabled, getResp.Rules[0].Status)
+	require.Equal(t, int32(30), aws.ToInt32(getResp.Rules[0].Expiration.Days))
+
+	// the rules are the lakeFS repository lifecycle rules
+	rulesResp, err := client.GetLifecycleRulesWithResponse(ctx, repo)
+	require.NoError(t, err)
+	require.Equal(t, http.StatusOK, rulesResp.StatusCode())
+	require.Len(t, rulesResp.JSON200.Rules, 1)
+	require.Equal(t, mainBranch, swag.StringValue(rulesResp.JSON200.Rules[0].BranchPattern))
+	require.Equal(t, "logs/", swag.StringValue(rulesResp.JSON200.Rules[0].Prefix))
+	require.Equal(t, 30, swag.IntValue(rulesResp.JSON200.Rules[0].CommittedExpirationDays))
+
+	t.Run("invalid prefix", func(t *testing.T) {
+		_, err := s3Client.PutBucketLifecycleConfiguration(ctx, &s3.PutBucketLifecycleConfigurationInput{
+			Bucket: aws.String(repo),
+			LifecycleConfiguration: &types.BucketLifecycleConfiguration{
+				Rules: []types.LifecycleRule{
+					{
+						ID:         aws.String("no-branch"),
+						Filter:     &types.LifecycleRuleFilterMemberPrefix{Value: "logs"},
+						Status:     types.ExpirationStatusEnabled,
+						Expiration: &types.LifecycleExpiration{Days: aws.Int32(30)},
+					},
+				},
+			},
+		})
+		require.ErrorContains(t, err, "InvalidArgument")
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		_, err := s3Client.DeleteBucketLifecycle(ctx, &s3.DeleteBucketLifecycleInput{Bucket: aws.String(repo)})
+		require.NoError(t, err)
+
+		_, err = s3Client.GetBucketLifecycleConfiguration(ctx, &s3.GetBucketLifecycleConfigurationInput{Bucket: aws.String(repo)})
+		require.ErrorContains(t, err, "NoSuchLifecycleConfiguration")
+	})
+}
+
+func TestS3BucketNotification(t *testing.T) {
+	// the lakeFS server must define a file notification sink, readable by the test
+	sinkName := viper.GetString("notification_sink")
+	sinkPath := viper.GetString("notification_sink_path")
+	if sinkName == "" || sinkPath == "" {
+		t.Skip("No notification file sink configured")
+	}
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	getResp, err := s3Client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: aws.String(repo)})
+	require.NoError(t, err)
+	require.Empty(t, getResp.QueueConfigurations)
+
+	t.Run("unknown sink", func(t *testing.T) {
+		_, err := s3Client.PutBucketNotificationConfiguration(ctx, &s3.PutBucketNotificationConfigurationInput{
+			Bucket: aws.String(repo),
+			NotificationConfiguration: &types.NotificationConfiguration{
+				QueueConfigurations: []types.QueueConfiguration{{
+					QueueArn: aws.String("arn:lakefs:notifications:::no-such-sink"),
+					Events:   []types.Event{"s3:ObjectCreated:*"},
+				}},
+			},
+		})
+		require.ErrorContains(t, err, "InvalidArgument")
+	})
+
+	_, err = s3Client.PutBucketNotificationConfiguration(ctx, &s3.PutBucketNotificationConfigurationInput{
+		Bucket: aws.String(repo),
+		NotificationConfiguration: &types.NotificationConfiguration{
+			QueueConfigurations: []types.QueueConfiguration{{
+				Id:       aws.String("csv"),
+				QueueArn: aws.String("arn:lakefs:notifications:::" + sinkName),
+				Events:   []types.Event{"s3:ObjectCreated:*"},
+				Filter: &types.NotificationConfigurationFilter{
+					Key: &types.S3KeyFilter{FilterRules: []types.FilterRule{
+						{Name: types.FilterRuleNamePrefix, Value: aws.String(mainBranch + "/data/")},
+						{Name: types.FilterRuleNameSuffix, Value: aws.String(".csv")},
+					}},
+				},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	getResp, err = s3Client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: aws.String(repo)})
+	require.NoError(t, err)
+	require.Len(t, getResp.QueueConfigurations, 1)
+	require.Equal(t, "csv", aws.ToString(getResp.QueueConfigurations[0].Id))
+	require.Equal(t, []types.Event{"s3:ObjectCreated:*"}, getResp.QueueConfigurations[0].Events)
+
+	for _, key := range []string{"data/skipped.json", "other/skipped.csv", "data/sent.csv"} {
+		_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(mainBranch + "/" + key),
+			Body:   strings.NewReader("content"),
+		})
+		require.NoError(t, err)
+	}
+
+	// notifications are delivered asynchronously, and other tests may share the sink
+	bucketName := fmt.Sprintf(`"name":%q`, repo)
+	var received []string
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(sinkPath)
+		if err != nil {
+			return false
+		}
+		received = received[:0]
+		for _, line := range strings.Split(string(data), "\n") {
+			if strings.Contains(line, bucketName) {
+				received = append(received, line)
+			}
+		}
+		return len(received) > 0
+	}, 30*time.Second, 100*time.Millisecond)
+	require.Len(t, received, 1)
+	require.Contains(t, received[0], `"key":"`+mainBranch+`/data/sent.csv"`)
+	require.Contains(t, received[0], `"eventName":"ObjectCreated:Put"`)
+
+	// an empty configuration stops notifications
+	_, err = s3Client.PutBucketNotificationConfiguration(ctx, &s3.PutBucketNotificationConfigurationInput{
+		Bucket:                    aws.String(repo),
+		NotificationConfiguration: &types.NotificationConfiguration{},
+	})
+	require.NoError(t, err)
+	getResp, err = s3Client.GetBucketNotificationConfiguration(ctx, &s3.GetBucketNotificationConfigurationInput{Bucket: aws.String(repo)})
+	require.NoError(t, err)
+	require.Empty(t, getResp.QueueConfigurations)
+}
+
+func TestS3ObjectLock(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	_, err := s3Client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String(repo)})
+	require.ErrorContains(t, err, "ObjectLockConfigurationNotFoundError")
+
+	_, err = s3Client.PutObjectLockConfiguration(ctx, &s3.PutObjectLockConfigurationInput{
+		Bucket:                  aws.String(repo),
+		ObjectLockConfiguration: &types.ObjectLockConfiguration{ObjectLockEnabled: types.ObjectLockEnabledEnabled},
+	})
+	require.NoError(t, err)
+	getResp, err := s3Client.GetObjectLockConfiguration(ctx, &s3.GetObjectLockConfigurationInput{Bucket: aws.String(repo)})
+	require.NoError(t, err)
+	require.Equal(t, types.ObjectLockEnabledEnabled, getResp.ObjectLockConfiguration.ObjectLockEnabled)
+
+	key := mainBranch + "/locked/file"
+	_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(repo), Key: aws.String(key), Body: strings.NewReader("content")})
+	require.NoError(t, err)
+
+	t.Run("uncommitted", func(t *testing.T) {
+		_, err := s3Client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(key),
+			LegalHold: &types.ObjectLockLegalHold{Status: types.ObjectLockLegalHoldStatusOn},
+		})
+		require.ErrorContains(t, err, "InvalidRequest")
+	})
+
+	commitResp, err := client.CommitWithResponse(ctx, repo, mainBranch, &apigen.CommitParams{}, apigen.CommitJSONRequestBody{Message: "lock me"})
+	require.NoError(t, err)
+	require.Equal(t, http.StatusCreated, commitResp.StatusCode())
+
+	t.Run("legal hold", func(t *testing.T) {
+		_, err := s3Client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(key),
+			LegalHold: &types.ObjectLockLegalHold{Status: types.ObjectLockLegalHoldStatusOn},
+		})
+		require.NoError(t, err)
+		holdResp, err := s3Client.GetObjectLegalHold(ctx, &s3.GetObjectLegalHoldInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.NoError(t, err)
+		require.Equal(t, types.ObjectLockLegalHoldStatusOn, holdResp.LegalHold.Status)
+
+		_, err = s3Client.PutObject(ctx, &s3.PutObjectInput{Bucket: aws.String(repo), Key: aws.String(key), Body: strings.NewReader("overwrite")})
+		require.ErrorContains(t, err, "AccessDenied")
+		_, err = s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.ErrorContains(t, err, "AccessDenied")
+
+		_, err = s3Client.PutObjectLegalHold(ctx, &s3.PutObjectLegalHoldInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(key),
+			LegalHold: &types.ObjectLockLegalHold{Status: types.ObjectLockLegalHoldStatusOff},
+		})
+		require.NoError(t, err)
+	})
+
+	t.Run("governance retention", func(t *testing.T) {
+		retainUntil := time.Now().Add(time.Hour).UTC().Truncate(time.Second)
+		_, err := s3Client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(key),
+			Retention: &types.ObjectLockRetention{
+				Mode:            types.ObjectLockRetentionModeGovernance,
+				RetainUntilDate: aws.Time(retainUntil),
+			},
+		})
+		require.NoError(t, err)
+		retentionResp, err := s3Client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.NoError(t, err)
+		require.Equal(t, types.ObjectLockRetentionModeGovernance, retentionResp.Retention.Mode)
+		require.True(t, retainUntil.Equal(aws.ToTime(retentionResp.Retention.RetainUntilDate)))
+
+		deleteResp, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(repo),
+			Delete: &types.Delete{Objects: []types.ObjectIdentifier{{Key: aws.String(key)}}},
+		})
+		require.NoError(t, err)
+		require.Len(t, deleteResp.Errors, 1)
+		require.Equal(t, "AccessDenied", aws.ToString(deleteResp.Errors[0].Code))
+
+		// shortening governance retention requires bypassing it
+		_, err = s3Client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(key),
+			Retention: &types.ObjectLockRetention{},
+		})
+		require.ErrorContains(t, err, "AccessDenied")
+		_, err = s3Client.PutObjectRetention(ctx, &s3.PutObjectRetentionInput{
+			Bucket:                    aws.String(repo),
+			Key:                       aws.String(key),
+			Retention:                 &types.ObjectLockRetention{},
+			BypassGovernanceRetention: aws.Bool(true),
+		})
+		require.NoError(t, err)
+		_, err = s3Client.GetObjectRetention(ctx, &s3.GetObjectRetentionInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.ErrorContains(t, err, "NoSuchObjectLockConfiguration")
+
+		_, err = s3Client.DeleteObject(ctx, &s3.DeleteObjectInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.NoError(t, err)
+	})
+}
+
+func TestS3ObjectVersions(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
 
-	tag, err := tags.NewTags(map[string]string{"tag1": "value1"}, true)
+	objPath := gatewayTestPrefix + "versioned-file"
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	putObject := func(content string) {
+		_, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(objPath),
+			Body:   strings.NewReader(content),
+		})
+		require.NoError(t, err)
+	}
+	commit := func(message string) string {
+		resp, err := client.CommitWithResponse(ctx, repo, mainBranch, &apigen.CommitParams{}, apigen.CommitJSONRequestBody{Message: message})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+		return resp.JSON201.Id
+	}
+	getObject := func(versionID string) string {
+		resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(objPath),
+			VersionId: aws.String(versionID),
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, versionID, aws.ToString(resp.VersionId))
+		return string(body)
+	}
+
+	putObject("first")
+	firstCommit := commit("first version")
+	putObject("second")
+	secondCommit := commit("second version")
+	putObject("third")
+
+	versions, err := s3Client.ListObjectVersions(ctx, &s3.ListObjectVersionsInput{
+		Bucket: aws.String(repo),
+		Prefix: aws.String(objPath),
+	})
 	require.NoError(t, err)
+	require.False(t, aws.ToBool(versions.IsTruncated))
+	require.Len(t, versions.Versions, 3)
+	expectedVersionIDs := []string{"staging", secondCommit, firstCommit}
+	for i, version := range versions.Versions {
+		require.Equal(t, objPath, aws.ToString(version.Key))
+		require.Equal(t, expectedVersionIDs[i], aws.ToString(version.VersionId))
+		require.Equal(t, i == 0, aws.ToBool(version.IsLatest))
+	}
+
+	t.Run("paginated", func(t *testing.T) {
+		var versionIDs []string
+		input := &s3.ListObjectVersionsInput{
+			Bucket:  aws.String(repo),
+			Prefix:  aws.String(objPath),
+			MaxKeys: aws.Int32(1),
+		}
+		for {
+			page, err := s3Client.ListObjectVersions(ctx, input)
+			require.NoError(t, err)
+			for _, version := range page.Versions {
+				versionIDs = append(versionIDs, aws.ToString(version.VersionId))
+			}
+			if !aws.ToBool(page.IsTruncated) {
+				break
+			}
+			input.KeyMarker = page.NextKeyMarker
+			input.VersionIdMarker = page.NextVersionIdMarker
+		}
+		require.Equal(t, expectedVersionIDs, versionIDs)
+	})
+
+	t.Run("get version", func(t *testing.T) {
+		require.Equal(t, "first", getObject(firstCommit))
+		require.Equal(t, "second", getObject(secondCommit))
+		require.Equal(t, "third", getObject("staging"))
+
+		_, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:    aws.String(repo),
+			Key:       aws.String(objPath),
+			VersionId: aws.String(mainBranch),
+		})
+		require.Error(t, err, "a branch is not a version ID")
+	})
+
+	t.Run("delete version", func(t *testing.T) {
+		resp, err := s3Client.DeleteObjects(ctx, &s3.DeleteObjectsInput{
+			Bucket: aws.String(repo),
+			Delete: &types.Delete{
+				Objects: []types.ObjectIdentifier{{Key: aws.String(objPath), VersionId: aws.String(firstCommit)}},
+			},
+		})
+		require.NoError(t, err)
+		require.Empty(t, resp.Deleted)
+		require.Len(t, resp.Errors, 1)
+		require.Equal(t, "NotImplemented", aws.ToString(resp.Errors[0].Code))
+		require.Equal(t, objPath, aws.ToString(resp.Errors[0].Key))
+		require.Equal(t, firstCommit, aws.ToString(resp.Errors[0].VersionId))
+		require.Equal(t, "third", getObject("staging"), "object deleted by a version delete")
+	})
+
+	t.Run("restore version", func(t *testing.T) {
+		restoredPath := gatewayTestPrefix + "restored-file"
+		_, err := s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(repo),
+			Key:        aws.String(restoredPath),
+			CopySource: aws.String(repo + "/" + objPath + "?versionId=" + firstCommit),
+		})
+		require.NoError(t, err)
+		resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(restoredPath),
+		})
+		require.NoError(t, err)
+		defer func() { _ = resp.Body.Close() }()
+		body, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+		require.Equal(t, "first", string(body))
+	})
+}
+
+func TestS3ReadObjectAtTime(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+
+	objPath := "data/file"
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	putObject := func(content string) {
+		_, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(mainBranch + "/" + objPath),
+			Body:   strings.NewReader(content),
+		})
+		require.NoError(t, err)
+	}
+	commit := func(message string, date time.Time) {
+		resp, err := client.CommitWithResponse(ctx, repo, mainBranch, &apigen.CommitParams{}, apigen.CommitJSONRequestBody{
+			Message: message,
+			Date:    apiutil.Ptr(date.Unix()),
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+	}
+	refAt := func(at time.Time) string {
+		return mainBranch + "@{" + at.UTC().Format(time.RFC3339) + "}"
+	}
+
+	// commits dated after the initial commit of the repository, an hour apart
+	firstDate := time.Now().Add(time.Hour).Truncate(time.Second)
+	putObject("first")
+	commit("first version", firstDate)
+	putObject("second")
+	commit("second version", firstDate.Add(time.Hour))
+	putObject("uncommitted")
+
+	for _, tc := range []struct {
+		name     string
+		at       time.Time
+		expected string
+	}{
+		{name: "between commits", at: firstDate.Add(30 * time.Minute), expected: "first"},
+		{name: "at commit", at: firstDate.Add(time.Hour), expected: "second"},
+		{name: "after commits", at: firstDate.Add(2 * time.Hour), expected: "second"},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			resp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+				Bucket: aws.String(repo),
+				Key:    aws.String(refAt(tc.at) + "/" + objPath),
+			})
+			require.NoError(t, err)
+			defer func() { _ = resp.Body.Close() }()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+			require.Equal(t, tc.expected, string(body))
 
-	err = s3lakefsClient.PutObjectTagging(ctx, repo, srcPath, tag, minio.PutObjectTaggingOptions{})
-	require.Error(t, err)
+			list, err := s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{
+				Bucket: aws.String(repo),
+				Prefix: aws.String(refAt(tc.at) + "/data/"),
+			})
+			require.NoError(t, err)
+			require.Len(t, list.Contents, 1)
+			require.Equal(t, refAt(tc.at)+"/"+objPath, aws.ToString(list.Contents[0].Key))
+		})
+	}
+
+	t.Run("before history", func(t *testing.T) {
+		_, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(refAt(time.Unix(0, 0)) + "/" + objPath),
+		})
+		requireHTTPStatus(t, err, http.StatusNotFound)
+	})
 
-	errResponse := minio.ToErrorResponse(err)
-	require.Equal(t, "ERRLakeFSNotSupported", errResponse.Code)
-	require.Equal(t, "This operation is not supported in LakeFS", errResponse.Message)
+	t.Run("dates out of order", func(t *testing.T) {
+		// a commit dated before its parent makes resolving across it fail
+		putObject("third")
+		commit("third version", time.Unix(0, 0))
+		_, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket: aws.String(repo),
+			Key:    aws.String(refAt(firstDate.Add(30*time.Minute)) + "/" + objPath),
+		})
+		requireHTTPStatus(t, err, http.StatusBadRequest)
+	})
 }
 
 func TestS3CopyObjectErrors(t *testing.T) {
@@ -1047,7 +1799,7 @@ func TestPossibleAPIEndpointError(t *testing.T) {
 
 	t.Run("use_proper_client_endpoint", func(t *testing.T) {
 		t.Parallel()
-		s3Client := createS3Client(endpointURL, t)
+		s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
 		_, listErr := s3Client.ListObjectsV2(ctx, &s3.ListObjectsV2Input{Bucket: aws.String("not-exists")})
 		require.ErrorContains(t, listErr, gtwerrors.ErrNoSuchBucket.Error())
 	})
@@ -1112,7 +1864,7 @@ func TestDeleteObjects_Viewer(t *testing.T) {
 	require.NoError(t, err, "Failed to add user to Viewers group")
 	require.Equal(t, http.StatusCreated, resAssociateUser.StatusCode(), "AddGroupMembershipWithResponse unexpectedly status code")
 
-	resCreateCreds, err := client.CreateCredentialsWithResponse(ctx, "del-viewer")
+	resCreateCreds, err := client.CreateCredentialsWithResponse(ctx, "del-viewer", apigen.CreateCredentialsJSONRequestBody{})
 	require.NoError(t, err, "Failed to create credentials")
 	require.NotNil(t, resCreateCreds.JSON201, "CreateCredentials unexpectedly empty response")
 
@@ -1147,3 +1899,168 @@ func TestDeleteObjects_Viewer(t *testing.T) {
 	assert.Len(t, listOut.Contents, 1, "list should find 'delete-me' file")
 	assert.Equal(t, aws.ToString(listOut.Contents[0].Key), mainBranch+"/"+filename)
 }
+
+func TestS3ServerSideEncryptionCustomerKey(t *testing.T) {
+	t.Parallel()
+	ctx, _, repo := setupTest(t)
+	defer tearDownTest(repo)
+	s3Client := createS3Client(viper.GetString("s3_endpoint"), t)
+
+	newKey := func() (string, string) {
+		key := make([]byte, 32)
+		_, err := cryptorand.Read(key)
+		require.NoError(t, err)
+		sum := md5.Sum(key) //nolint:gosec
+		return base64.StdEncoding.EncodeToString(key), base64.StdEncoding.EncodeToString(sum[:])
+	}
+	encodedKey, keyMD5 := newKey()
+	const content = "encrypted content"
+	key := mainBranch + "/encrypted/file"
+
+	putResp, err := s3Client.PutObject(ctx, &s3.PutObjectInput{
+		Bucket:               aws.String(repo),
+		Key:                  aws.String(key),
+		Body:                 strings.NewReader(content),
+		SSECustomerAlgorithm: aws.String("AES256"),
+		SSECustomerKey:       aws.String(encodedKey),
+		SSECustomerKeyMD5:    aws.String(keyMD5),
+	})
+	require.NoError(t, err)
+	require.Equal(t, "AES256", aws.ToString(putResp.SSECustomerAlgorithm))
+	require.Equal(t, keyMD5, aws.ToString(putResp.SSECustomerKeyMD5))
+
+	t.Run("get", func(t *testing.T) {
+		getResp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(key),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(encodedKey),
+			SSECustomerKeyMD5:    aws.String(keyMD5),
+		})
+		require.NoError(t, err)
+		defer func() { _ = getResp.Body.Close() }()
+		body, err := io.ReadAll(getResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, content, string(body))
+		require.Equal(t, keyMD5, aws.ToString(getResp.SSECustomerKeyMD5))
+	})
+
+	t.Run("get range", func(t *testing.T) {
+		getResp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(key),
+			Range:                aws.String("bytes=2-8"),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(encodedKey),
+			SSECustomerKeyMD5:    aws.String(keyMD5),
+		})
+		require.NoError(t, err)
+		defer func() { _ = getResp.Body.Close() }()
+		body, err := io.ReadAll(getResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, content[2:9], string(body))
+	})
+
+	t.Run("head", func(t *testing.T) {
+		_, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.Error(t, err)
+		headResp, err := s3Client.HeadObject(ctx, &s3.HeadObjectInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(key),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(encodedKey),
+			SSECustomerKeyMD5:    aws.String(keyMD5),
+		})
+		require.NoError(t, err)
+		require.Equal(t, int64(len(content)), aws.ToInt64(headResp.ContentLength))
+	})
+
+	t.Run("get without key", func(t *testing.T) {
+		_, err := s3Client.GetObject(ctx, &s3.GetObjectInput{Bucket: aws.String(repo), Key: aws.String(key)})
+		require.ErrorContains(t, err, "InvalidRequest")
+	})
+
+	t.Run("get with wrong key", func(t *testing.T) {
+		wrongKey, wrongKeyMD5 := newKey()
+		_, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(key),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(wrongKey),
+			SSECustomerKeyMD5:    aws.String(wrongKeyMD5),
+		})
+		require.ErrorContains(t, err, "InvalidArgument")
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		_, err := s3Client.CopyObject(ctx, &s3.CopyObjectInput{
+			Bucket:     aws.String(repo),
+			Key:        aws.String(mainBranch + "/encrypted/copy"),
+			CopySource: aws.String(repo + "/" + key),
+		})
+		require.ErrorContains(t, err, "NotImplemented")
+	})
+
+	t.Run("multipart upload", func(t *testing.T) {
+		RequireBlockstoreType(t, block.BlockstoreTypeLocal, block.BlockstoreTypeS3)
+		mpuKey := mainBranch + "/encrypted/multipart"
+		createResp, err := s3Client.CreateMultipartUpload(ctx, &s3.CreateMultipartUploadInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(mpuKey),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(encodedKey),
+			SSECustomerKeyMD5:    aws.String(keyMD5),
+		})
+		require.NoError(t, err)
+
+		_, err = s3Client.UploadPart(ctx, &s3.UploadPartInput{
+			Bucket:     aws.String(repo),
+			Key:        aws.String(mpuKey),
+			UploadId:   createResp.UploadId,
+			PartNumber: aws.Int32(1),
+			Body:       strings.NewReader("no key"),
+		})
+		require.ErrorContains(t, err, "InvalidRequest")
+
+		parts := [][]byte{
+			bytes.Repeat([]byte("a"), minDataContentLengthForMultipart),
+			[]byte("last part"),
+		}
+		var completedParts []types.CompletedPart
+		for i, part := range parts {
+			partNumber := aws.Int32(int32(i + 1)) //nolint:gosec
+			partResp, err := s3Client.UploadPart(ctx, &s3.UploadPartInput{
+				Bucket:               aws.String(repo),
+				Key:                  aws.String(mpuKey),
+				UploadId:             createResp.UploadId,
+				PartNumber:           partNumber,
+				Body:                 bytes.NewReader(part),
+				SSECustomerAlgorithm: aws.String("AES256"),
+				SSECustomerKey:       aws.String(encodedKey),
+				SSECustomerKeyMD5:    aws.String(keyMD5),
+			})
+			require.NoError(t, err)
+			completedParts = append(completedParts, types.CompletedPart{ETag: partResp.ETag, PartNumber: partNumber})
+		}
+		_, err = s3Client.CompleteMultipartUpload(ctx, &s3.CompleteMultipartUploadInput{
+			Bucket:          aws.String(repo),
+			Key:             aws.String(mpuKey),
+			UploadId:        createResp.UploadId,
+			MultipartUpload: &types.CompletedMultipartUpload{Parts: completedParts},
+		})
+		require.NoError(t, err)
+
+		getResp, err := s3Client.GetObject(ctx, &s3.GetObjectInput{
+			Bucket:               aws.String(repo),
+			Key:                  aws.String(mpuKey),
+			SSECustomerAlgorithm: aws.String("AES256"),
+			SSECustomerKey:       aws.String(encodedKey),
+			SSECustomerKeyMD5:    aws.String(keyMD5),
+		})
+		require.NoError(t, err)
+		defer func() { _ = getResp.Body.Close() }()
+		body, err := io.ReadAll(getResp.Body)
+		require.NoError(t, err)
+		require.Equal(t, bytes.Join(parts, nil), body)
+	})
+}
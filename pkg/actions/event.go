@@ -2,6 +2,7 @@ package actions
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 
 	"github.com/treeverse/lakefs/pkg/graveler"
@@ -21,6 +22,31 @@ type EventInfo struct {
 	Committer      string            `json:"committer,omitempty"`
 	CommitMetadata map[string]string `json:"commit_metadata,omitempty"`
 	MergeSource    string            `json:"merge_source,omitempty"`
+	PullRequestID  string            `json:"pull_request_id,omitempty"`
+	PullRequest    *PullRequestInfo  `json:"pull_request,omitempty"`
+}
+
+type PullRequestInfo struct {
+	Title             string `json:"title"`
+	Description       string `json:"description,omitempty"`
+	Author            string `json:"author"`
+	Status            string `json:"status"`
+	SourceBranch      string `json:"source_branch"`
+	DestinationBranch string `json:"destination_branch"`
+}
+
+func newPullRequestInfo(pr *graveler.PullRequest) *PullRequestInfo {
+	if pr == nil {
+		return nil
+	}
+	return &PullRequestInfo{
+		Title:             pr.Title,
+		Description:       pr.Description,
+		Author:            pr.Author,
+		Status:            strings.ToLower(pr.Status.String()),
+		SourceBranch:      pr.Source,
+		DestinationBranch: pr.Destination,
+	}
 }
 
 func marshalEventInformation(actionName, hookID string, record graveler.HookRecord) ([]byte, error) {
@@ -39,6 +65,8 @@ func marshalEventInformation(actionName, hookID string, record graveler.HookReco
 		Committer:      record.Commit.Committer,
 		CommitMetadata: record.Commit.Metadata,
 		MergeSource:    record.MergeSource.String(),
+		PullRequestID:  record.PullRequestID.String(),
+		PullRequest:    newPullRequestInfo(record.PullRequest),
 	}
 	return json.Marshal(info)
 }
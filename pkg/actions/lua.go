@@ -43,7 +43,7 @@ func applyRecord(l *lua.State, actionName, hookID string, record graveler.HookRe
 	for k, v := range record.Commit.Metadata {
 		metadata[k] = v
 	}
-	luautil.DeepPush(l, map[string]interface{}{
+	actionRecord := map[string]interface{}{
 		"action_name":       actionName,
 		"hook_id":           hookID,
 		"run_id":            record.RunID,
@@ -64,7 +64,21 @@ func applyRecord(l *lua.State, actionName, hookID string, record graveler.HookRe
 			"metadata":      metadata,
 			"parents":       parents,
 		},
-	})
+	}
+	if record.PullRequestID != "" {
+		actionRecord["pull_request_id"] = record.PullRequestID.String()
+	}
+	if pr := newPullRequestInfo(record.PullRequest); pr != nil {
+		actionRecord["pull_request"] = map[string]interface{}{
+			"title":              pr.Title,
+			"description":        pr.Description,
+			"author":             pr.Author,
+			"status":             pr.Status,
+			"source_branch":      pr.SourceBranch,
+			"destination_branch": pr.DestinationBranch,
+		}
+	}
+	luautil.DeepPush(l, actionRecord)
 	l.SetGlobal("action")
 }
 
@@ -606,6 +606,22 @@ func (s *StoreService) PostCherryPickHook(ctx context.Context, record graveler.H
 	return nil
 }
 
+func (s *StoreService) PreCreatePullRequestHook(ctx context.Context, record graveler.HookRecord) error {
+	return s.Run(ctx, record)
+}
+
+func (s *StoreService) PostCreatePullRequestHook(ctx context.Context, record graveler.HookRecord) {
+	s.asyncRun(ctx, record)
+}
+
+func (s *StoreService) PreMergePullRequestHook(ctx context.Context, record graveler.HookRecord) error {
+	return s.Run(ctx, record)
+}
+
+func (s *StoreService) PostClosePullRequestHook(ctx context.Context, record graveler.HookRecord) {
+	s.asyncRun(ctx, record)
+}
+
 func (s *StoreService) NewRunID() string {
 	return s.idGen.NewRunID()
 }
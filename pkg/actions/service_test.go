@@ -517,6 +517,9 @@ func checkEvent(t *testing.T, record graveler.HookRecord, event actions.EventInf
 	if event.MergeSource != record.MergeSource.String() {
 		t.Errorf("Webhook post MergeSource=%s, expected=%s", event.MergeSource, record.MergeSource)
 	}
+	if event.PullRequestID != record.PullRequestID.String() {
+		t.Errorf("Webhook post PullRequestID=%s, expected=%s", event.PullRequestID, record.PullRequestID)
+	}
 	if event.CommitMessage != record.Commit.Message {
 		t.Errorf("Webhook post CommitMessage=%s, expected=%s", event.CommitMessage, record.Commit.Message)
 	}
@@ -68,14 +68,13 @@ func GenericAuthMiddleware(logger logging.Logger, authenticator auth.Authenticat
 	sessionStore := sessions.NewCookieStore(authService.SecretStore().SharedSecret())
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-			user, err := checkSecurityRequirements(r, swagger.Security, logger, authenticator, authService, sessionStore, oidcConfig, cookieAuthConfig)
+			authn, err := checkSecurityRequirements(r, swagger.Security, logger, authenticator, authService, sessionStore, oidcConfig, cookieAuthConfig)
 			if err != nil {
 				writeError(w, r, http.StatusUnauthorized, err)
 				return
 			}
-			if user != nil {
-				ctx := logging.AddFields(r.Context(), logging.Fields{logging.UserFieldKey: user.Username})
-				r = r.WithContext(auth.WithUser(ctx, user))
+			if authn != nil {
+				r = r.WithContext(authn.withContext(r.Context()))
 			}
 			next.ServeHTTP(w, r)
 		})
@@ -99,20 +98,41 @@ func AuthMiddleware(logger logging.Logger, swagger *openapi3.T, authenticator au
 				writeError(w, r, http.StatusBadRequest, err)
 				return
 			}
-			user, err := checkSecurityRequirements(r, securityRequirements, logger, authenticator, authService, sessionStore, oidcConfig, cookieAuthConfig)
+			authn, err := checkSecurityRequirements(r, securityRequirements, logger, authenticator, authService, sessionStore, oidcConfig, cookieAuthConfig)
 			if err != nil {
 				writeError(w, r, http.StatusUnauthorized, err)
 				return
 			}
-			if user != nil {
-				ctx := logging.AddFields(r.Context(), logging.Fields{logging.UserFieldKey: user.Username})
-				r = r.WithContext(auth.WithUser(ctx, user))
+			if authn != nil {
+				r = r.WithContext(authn.withContext(r.Context()))
 			}
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// authenticatedUser is the user a request authenticated as, with the attributes of the authentication that
+// authorizing the request depends on
+type authenticatedUser struct {
+	user *model.User
+	// claims of the identity token, for users authenticated by an OIDC or SAML session
+	claims map[string][]string
+	// credential the user authenticated with, for users authenticated by access key
+	credential *model.Credential
+}
+
+// withContext returns ctx with the authenticated user
+func (a *authenticatedUser) withContext(ctx context.Context) context.Context {
+	ctx = logging.AddFields(ctx, logging.Fields{logging.UserFieldKey: a.user.Username})
+	if a.claims != nil {
+		ctx = auth.WithClaims(ctx, a.claims)
+	}
+	if a.credential != nil {
+		ctx = auth.WithCredential(ctx, a.credential)
+	}
+	return auth.WithUser(ctx, a.user)
+}
+
 // checkSecurityRequirements goes over the security requirements and check the authentication. returns the user information and error if the security check was required.
 // it will return nil user and error in case of no security checks to match.
 func checkSecurityRequirements(r *http.Request,
@@ -123,9 +143,11 @@ func checkSecurityRequirements(r *http.Request,
 	sessionStore sessions.Store,
 	oidcConfig *OIDCConfig,
 	cookieAuthConfig *CookieAuthConfig,
-) (*model.User, error) {
+) (*authenticatedUser, error) {
 	ctx := r.Context()
 	var user *model.User
+	var claims map[string][]string
+	var cred *model.Credential
 	var err error
 
 	logger = logger.WithContext(ctx)
@@ -151,7 +173,7 @@ func checkSecurityRequirements(r *http.Request,
 				if !ok {
 					continue
 				}
-				user, err = userByAuth(ctx, logger, authenticator, authService, accessKey, secretKey)
+				user, cred, err = userByAuth(ctx, logger, authenticator, authService, accessKey, secretKey)
 			case "cookie_auth":
 				var internalAuthSession *sessions.Session
 				internalAuthSession, _ = sessionStore.Get(r, InternalAuthSessionName)
@@ -170,6 +192,7 @@ func checkSecurityRequirements(r *http.Request,
 					return nil, err
 				}
 				user, err = userFromOIDC(ctx, logger, authService, oidcSession, oidcConfig)
+				claims = sessionClaims(oidcSession, IDTokenClaimsSessionKey)
 			case "saml_auth":
 				var samlSession *sessions.Session
 				samlSession, err = sessionStore.Get(r, SAMLAuthSessionName)
@@ -177,6 +200,7 @@ func checkSecurityRequirements(r *http.Request,
 					return nil, err
 				}
 				user, err = userFromSAML(ctx, logger, authService, samlSession, cookieAuthConfig)
+				claims = sessionClaims(samlSession, SAMLTokenClaimsSessionKey)
 			default:
 				// unknown security requirement to check
 				logger.WithField("provider", provider).Error("Authentication middleware unknown security requirement provider")
@@ -187,13 +211,33 @@ func checkSecurityRequirements(r *http.Request,
 				return nil, err
 			}
 			if user != nil {
-				return user, nil
+				return &authenticatedUser{user: user, claims: claims, credential: cred}, nil
 			}
 		}
 	}
 	return nil, nil
 }
 
+// sessionClaims returns the identity token claims stored in the session under key, each as a list of string values
+func sessionClaims(session *sessions.Session, key string) map[string][]string {
+	tokenClaims, _ := session.Values[key].(oidcencoding.Claims)
+	if tokenClaims == nil {
+		return nil
+	}
+	claims := make(map[string][]string, len(tokenClaims))
+	for name, value := range tokenClaims {
+		switch v := value.(type) {
+		case []interface{}:
+			for _, item := range v {
+				claims[name] = append(claims[name], fmt.Sprint(item))
+			}
+		default:
+			claims[name] = []string{fmt.Sprint(v)}
+		}
+	}
+	return claims
+}
+
 func enhanceWithFriendlyName(ctx context.Context, user *model.User, friendlyName string, persistFriendlyName bool, authService auth.Service, logger logging.Logger) *model.User {
 	log := logger.WithFields(logging.Fields{"friendly_name": friendlyName, "persist_friendly_name": persistFriendlyName})
 	if user == nil {
@@ -402,17 +446,28 @@ func userByToken(ctx context.Context, logger logging.Logger, authService auth.Se
 	return userData, nil
 }
 
-func userByAuth(ctx context.Context, logger logging.Logger, authenticator auth.Authenticator, authService auth.Service, accessKey string, secretKey string) (*model.User, error) {
+// userByAuth authenticates the user by access key and secret key. It also returns the credentials of the
+// access key, nil if the user authenticated with an authenticator other than the builtin one.
+func userByAuth(ctx context.Context, logger logging.Logger, authenticator auth.Authenticator, authService auth.Service, accessKey string, secretKey string) (*model.User, *model.Credential, error) {
 	// TODO(ariels): Rename keys.
-	username, err := authenticator.AuthenticateUser(ctx, accessKey, secretKey)
+	var (
+		username string
+		cred     *model.Credential
+		err      error
+	)
+	if credAuthenticator, ok := authenticator.(auth.CredentialAuthenticator); ok {
+		username, cred, err = credAuthenticator.AuthenticateCredential(ctx, accessKey, secretKey)
+	} else {
+		username, err = authenticator.AuthenticateUser(ctx, accessKey, secretKey)
+	}
 	if err != nil {
 		logger.WithError(err).WithField("user", accessKey).Error("authenticate")
-		return nil, ErrAuthenticatingRequest
+		return nil, nil, ErrAuthenticatingRequest
 	}
 	user, err := authService.GetUser(ctx, username)
 	if err != nil {
 		logger.WithError(err).WithFields(logging.Fields{"user_name": username}).Debug("could not find user id by credentials")
-		return nil, ErrAuthenticatingRequest
+		return nil, nil, ErrAuthenticatingRequest
 	}
-	return user, nil
+	return user, cred, nil
 }
@@ -18,10 +18,12 @@ import (
 	"regexp"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/davecgh/go-spew/spew"
+	"github.com/go-chi/chi/v5"
 	"github.com/go-openapi/swag"
 	"github.com/gorilla/sessions"
 	authacl "github.com/treeverse/lakefs/contrib/auth/acl"
@@ -37,6 +39,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/cloud"
 	"github.com/treeverse/lakefs/pkg/config"
 	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/objectlock"
 	"github.com/treeverse/lakefs/pkg/httputil"
 	"github.com/treeverse/lakefs/pkg/kv"
 	"github.com/treeverse/lakefs/pkg/license"
@@ -742,15 +745,23 @@ func (c *Controller) DeleteObjects(w http.ResponseWriter, r *http.Request, body
 
 func (c *Controller) Login(w http.ResponseWriter, r *http.Request, body apigen.LoginJSONRequestBody) {
 	ctx := r.Context()
-	user, err := userByAuth(ctx, c.Logger, c.Authenticator, c.Auth, body.AccessKeyId, body.SecretAccessKey)
+	user, cred, err := userByAuth(ctx, c.Logger, c.Authenticator, c.Auth, body.AccessKeyId, body.SecretAccessKey)
 	if errors.Is(err, ErrAuthenticatingRequest) {
 		writeResponse(w, r, http.StatusUnauthorized, http.StatusText(http.StatusUnauthorized))
 		return
 	}
+	// a login token carries the permissions of the user, not the inline policy of the access key
+	if cred != nil && cred.InlinePolicy() != nil {
+		writeError(w, r, http.StatusUnauthorized, "credentials with an inline policy cannot log in")
+		return
+	}
 
 	loginTime := time.Now()
 	duration := c.Config.AuthConfig().LoginDuration
 	expires := loginTime.Add(duration)
+	if cred != nil && !cred.ExpirationDate.IsZero() && cred.ExpirationDate.Before(expires) {
+		expires = cred.ExpirationDate
+	}
 	secret := c.Auth.SecretStore().SharedSecret()
 
 	tokenString, err := auth.GenerateJWTLogin(secret, user.Username, loginTime, expires)
@@ -1392,11 +1403,7 @@ func (c *Controller) ListGroupPolicies(w http.ResponseWriter, r *http.Request, g
 func serializePolicy(p *model.Policy) apigen.Policy {
 	stmts := make([]apigen.Statement, 0, len(p.Statement))
 	for _, s := range p.Statement {
-		stmts = append(stmts, apigen.Statement{
-			Action:   s.Action,
-			Effect:   s.Effect,
-			Resource: s.Resource,
-		})
+		stmts = append(stmts, serializeStatement(s))
 	}
 	createdAt := p.CreatedAt.Unix()
 	return apigen.Policy{
@@ -1406,6 +1413,34 @@ func serializePolicy(p *model.Policy) apigen.Policy {
 	}
 }
 
+func serializeStatement(s model.Statement) apigen.Statement {
+	return apigen.Statement{
+		Action:    s.Action,
+		Effect:    s.Effect,
+		Resource:  s.Resource,
+		Condition: serializeCondition(s.Condition),
+	}
+}
+
+func serializeCondition(condition model.Condition) *apigen.Statement_Condition {
+	operators := model.ConditionToAPI(condition, func(keys map[string][]string) apigen.PolicyCondition {
+		return apigen.PolicyCondition{AdditionalProperties: keys}
+	})
+	if operators == nil {
+		return nil
+	}
+	return &apigen.Statement_Condition{AdditionalProperties: operators}
+}
+
+func conditionFromAPI(c *apigen.Statement_Condition) model.Condition {
+	if c == nil {
+		return nil
+	}
+	return model.ConditionFromAPI(c.AdditionalProperties, func(keys apigen.PolicyCondition) map[string][]string {
+		return keys.AdditionalProperties
+	})
+}
+
 func (c *Controller) DetachPolicyFromGroup(w http.ResponseWriter, r *http.Request, groupID, policyID string) {
 	if c.Config.AuthConfig().IsAuthUISimplified() {
 		writeError(w, r, http.StatusNotImplemented, "Not implemented")
@@ -1516,9 +1551,10 @@ func (c *Controller) CreatePolicy(w http.ResponseWriter, r *http.Request, body a
 	stmts := make(model.Statements, len(body.Statement))
 	for i, apiStatement := range body.Statement {
 		stmts[i] = model.Statement{
-			Effect:   apiStatement.Effect,
-			Action:   apiStatement.Action,
-			Resource: apiStatement.Resource,
+			Effect:    apiStatement.Effect,
+			Action:    apiStatement.Action,
+			Resource:  apiStatement.Resource,
+			Condition: conditionFromAPI(apiStatement.Condition),
 		}
 	}
 
@@ -1615,9 +1651,10 @@ func (c *Controller) UpdatePolicy(w http.ResponseWriter, r *http.Request, body a
 	stmts := make(model.Statements, len(body.Statement))
 	for i, apiStatement := range body.Statement {
 		stmts[i] = model.Statement{
-			Effect:   apiStatement.Effect,
-			Action:   apiStatement.Action,
-			Resource: apiStatement.Resource,
+			Effect:    apiStatement.Effect,
+			Action:    apiStatement.Action,
+			Resource:  apiStatement.Resource,
+			Condition: conditionFromAPI(apiStatement.Condition),
 		}
 	}
 
@@ -1634,6 +1671,159 @@ func (c *Controller) UpdatePolicy(w http.ResponseWriter, r *http.Request, body a
 	writeResponse(w, r, http.StatusOK, response)
 }
 
+func (c *Controller) SimulatePolicy(w http.ResponseWriter, r *http.Request, body apigen.SimulatePolicyJSONRequestBody) {
+	if c.Config.AuthConfig().IsAuthUISimplified() {
+		writeError(w, r, http.StatusNotImplemented, "Not implemented")
+		return
+	}
+	userID := swag.StringValue(body.UserId)
+	groupID := swag.StringValue(body.GroupId)
+	if (userID == "") == (groupID == "") {
+		writeError(w, r, http.StatusBadRequest, "exactly one of user_id and group_id is required")
+		return
+	}
+	accessKeyID := swag.StringValue(body.AccessKeyId)
+	if accessKeyID != "" && userID == "" {
+		writeError(w, r, http.StatusBadRequest, "access_key_id requires user_id")
+		return
+	}
+	perm := permissions.Permission{
+		Action:   permissions.ReadUserAction,
+		Resource: permissions.UserArn(userID),
+	}
+	if groupID != "" {
+		perm = permissions.Permission{
+			Action:   permissions.ReadGroupAction,
+			Resource: permissions.GroupArn(groupID),
+		}
+	}
+	if !c.authorize(w, r, permissions.Node{Permission: perm}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "simulate_policy", r, "", "", "")
+
+	for _, p := range body.Permissions {
+		if err := model.ValidateActionName(p.Action); err != nil {
+			writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
+		if err := model.ValidateArn(p.Resource); err != nil {
+			writeError(w, r, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	var (
+		policies         []*model.Policy
+		credentialPolicy *model.Policy
+	)
+	if userID != "" {
+		_, err := c.Auth.GetUser(ctx, userID)
+		if c.handleAPIError(ctx, w, r, err) {
+			return
+		}
+		policies, _, err = c.Auth.ListEffectivePolicies(ctx, userID, &model.PaginationParams{
+			Amount: -1, // all
+		})
+		if c.handleAPIError(ctx, w, r, err) {
+			return
+		}
+		if accessKeyID != "" {
+			cred, err := c.Auth.GetCredentialsForUser(ctx, userID, accessKeyID)
+			if c.handleAPIError(ctx, w, r, err) {
+				return
+			}
+			credentialPolicy = cred.InlinePolicy()
+		}
+	} else {
+		_, err := c.Auth.GetGroup(ctx, groupID)
+		if c.handleAPIError(ctx, w, r, err) {
+			return
+		}
+		policies, err = c.listAllGroupPolicies(ctx, groupID)
+		if c.handleAPIError(ctx, w, r, err) {
+			return
+		}
+	}
+
+	reqCtx := simulationRequestContext(body.Context)
+	reqCtx.CredentialPolicy = credentialPolicy
+	response := apigen.PolicySimulationResult{
+		Decision: apigen.PolicySimulationDecision_allow,
+		Results:  make([]apigen.PolicySimulationPermissionResult, 0, len(body.Permissions)),
+	}
+	for _, p := range body.Permissions {
+		decision, err := auth.EvaluatePermission(permissions.Permission{Action: p.Action, Resource: p.Resource}, userID, policies, reqCtx)
+		result := apigen.PolicySimulationPermissionResult{
+			Action:   p.Action,
+			Resource: p.Resource,
+			Decision: simulationDecision(decision.Result),
+		}
+		if decision.Policy != nil {
+			stmt := serializeStatement(decision.Policy.Statement[decision.StatementIndex])
+			result.Policy = swag.String(decision.Policy.DisplayName)
+			result.StatementIndex = swag.Int(decision.StatementIndex)
+			result.Statement = &stmt
+		}
+		if err != nil {
+			result.Error = swag.String(err.Error())
+		}
+		switch {
+		case result.Decision == apigen.PolicySimulationDecision_deny:
+			response.Decision = apigen.PolicySimulationDecision_deny
+		case result.Decision == apigen.PolicySimulationDecision_neutral && response.Decision == apigen.PolicySimulationDecision_allow:
+			response.Decision = apigen.PolicySimulationDecision_neutral
+		}
+		response.Results = append(response.Results, result)
+	}
+	writeResponse(w, r, http.StatusOK, response)
+}
+
+func (c *Controller) listAllGroupPolicies(ctx context.Context, groupID string) ([]*model.Policy, error) {
+	var policies []*model.Policy
+	params := &model.PaginationParams{Amount: auth.MaxPage}
+	for {
+		page, paginator, err := c.Auth.ListGroupPolicies(ctx, groupID, params)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, page...)
+		if paginator.NextPageToken == "" {
+			return policies, nil
+		}
+		params.After = paginator.NextPageToken
+	}
+}
+
+// simulationRequestContext returns the request context a policy simulation evaluates conditions with
+func simulationRequestContext(simulationContext *apigen.PolicySimulationContext) *auth.RequestContext {
+	reqCtx := &auth.RequestContext{Time: time.Now()}
+	if simulationContext == nil {
+		return reqCtx
+	}
+	if simulationContext.Time != nil {
+		reqCtx.Time = time.Unix(*simulationContext.Time, 0)
+	}
+	reqCtx.SourceIP = swag.StringValue(simulationContext.SourceIp)
+	reqCtx.Branch = swag.StringValue(simulationContext.Branch)
+	if simulationContext.ObjectMetadata != nil {
+		reqCtx.ObjectMetadata = simulationContext.ObjectMetadata.AdditionalProperties
+	}
+	return reqCtx
+}
+
+func simulationDecision(result auth.CheckResult) apigen.PolicySimulationDecision {
+	switch result {
+	case auth.CheckAllow:
+		return apigen.PolicySimulationDecision_allow
+	case auth.CheckDeny:
+		return apigen.PolicySimulationDecision_deny
+	default:
+		return apigen.PolicySimulationDecision_neutral
+	}
+}
+
 func (c *Controller) ListUsers(w http.ResponseWriter, r *http.Request, params apigen.ListUsersParams) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -1798,15 +1988,58 @@ func (c *Controller) ListUserCredentials(w http.ResponseWriter, r *http.Request,
 		},
 	}
 	for _, c := range credentials {
-		response.Results = append(response.Results, apigen.Credentials{
-			AccessKeyId:  c.AccessKeyID,
-			CreationDate: c.IssuedDate.Unix(),
-		})
+		response.Results = append(response.Results, serializeCredentials(c))
 	}
 	writeResponse(w, r, http.StatusOK, response)
 }
 
-func (c *Controller) CreateCredentials(w http.ResponseWriter, r *http.Request, userID string) {
+func serializeCredentials(c *model.Credential) apigen.Credentials {
+	expirationDate, statement := serializeCredentialLimits(c.CredentialLimits)
+	return apigen.Credentials{
+		AccessKeyId:    c.AccessKeyID,
+		CreationDate:   c.IssuedDate.Unix(),
+		ExpirationDate: expirationDate,
+		Statement:      statement,
+	}
+}
+
+// serializeCredentialLimits returns the expiration date and the inline policy statements of credentials, each nil
+// if the credentials are not limited by it
+func serializeCredentialLimits(limits model.CredentialLimits) (*int64, *[]apigen.Statement) {
+	var expirationDate *int64
+	if !limits.ExpirationDate.IsZero() {
+		expirationDate = swag.Int64(limits.ExpirationDate.Unix())
+	}
+	var statement *[]apigen.Statement
+	if len(limits.Statement) > 0 {
+		stmts := make([]apigen.Statement, len(limits.Statement))
+		for i, s := range limits.Statement {
+			stmts[i] = serializeStatement(s)
+		}
+		statement = &stmts
+	}
+	return expirationDate, statement
+}
+
+func credentialLimitsFromAPI(body apigen.CredentialsCreation) model.CredentialLimits {
+	var limits model.CredentialLimits
+	if body.ExpirationDate != nil {
+		limits.ExpirationDate = time.Unix(*body.ExpirationDate, 0)
+	}
+	if body.Statement != nil {
+		for _, apiStatement := range *body.Statement {
+			limits.Statement = append(limits.Statement, model.Statement{
+				Effect:    apiStatement.Effect,
+				Action:    apiStatement.Action,
+				Resource:  apiStatement.Resource,
+				Condition: conditionFromAPI(apiStatement.Condition),
+			})
+		}
+	}
+	return limits
+}
+
+func (c *Controller) CreateCredentials(w http.ResponseWriter, r *http.Request, body apigen.CreateCredentialsJSONRequestBody, userID string) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
 			Action:   permissions.CreateCredentialsAction,
@@ -1817,14 +2050,35 @@ func (c *Controller) CreateCredentials(w http.ResponseWriter, r *http.Request, u
 	}
 	ctx := r.Context()
 	c.LogAction(ctx, "create_credentials", r, "", "", "")
-	credentials, err := c.Auth.CreateCredentials(ctx, userID)
+	limits := credentialLimitsFromAPI(apigen.CredentialsCreation(body))
+	// credentials created with limited credentials are limited at least as much, or they could be used to escape limits
+	if caller := auth.GetCredential(ctx); caller != nil {
+		var err error
+		limits, err = limits.Within(caller.CredentialLimits)
+		if err != nil {
+			writeError(w, r, http.StatusForbidden, err)
+			return
+		}
+	}
+	var (
+		credentials *model.Credential
+		err         error
+	)
+	if limits.IsZero() {
+		credentials, err = c.Auth.CreateCredentials(ctx, userID)
+	} else {
+		credentials, err = c.Auth.CreateLimitedCredentials(ctx, userID, limits)
+	}
 	if c.handleAPIError(ctx, w, r, err) {
 		return
 	}
+	expirationDate, statement := serializeCredentialLimits(credentials.CredentialLimits)
 	response := apigen.CredentialsWithSecret{
 		AccessKeyId:     credentials.AccessKeyID,
 		SecretAccessKey: credentials.SecretAccessKey,
 		CreationDate:    credentials.IssuedDate.Unix(),
+		ExpirationDate:  expirationDate,
+		Statement:       statement,
 	}
 	writeResponse(w, r, http.StatusCreated, response)
 }
@@ -1872,11 +2126,7 @@ func (c *Controller) GetCredentials(w http.ResponseWriter, r *http.Request, user
 		return
 	}
 
-	response := apigen.Credentials{
-		AccessKeyId:  credentials.AccessKeyID,
-		CreationDate: credentials.IssuedDate.Unix(),
-	}
-	writeResponse(w, r, http.StatusOK, response)
+	writeResponse(w, r, http.StatusOK, serializeCredentials(credentials))
 }
 
 func (c *Controller) ListUserGroups(w http.ResponseWriter, r *http.Request, userID string, params apigen.ListUserGroupsParams) {
@@ -2500,10 +2750,14 @@ func (c *Controller) GetBranchProtectionRules(w http.ResponseWriter, r *http.Req
 		return
 	}
 	resp := make([]*apigen.BranchProtectionRule, 0, len(rules.BranchPatternToBlockedActions))
-	for pattern := range rules.BranchPatternToBlockedActions {
-		resp = append(resp, &apigen.BranchProtectionRule{
+	for pattern, blockedActions := range rules.BranchPatternToBlockedActions {
+		rule := &apigen.BranchProtectionRule{
 			Pattern: pattern,
-		})
+		}
+		if slices.Contains(blockedActions.GetValue(), graveler.BranchProtectionBlockedAction_MERGE_WITHOUT_APPROVAL) {
+			rule.RequiredApprovals = apiutil.Ptr(int(blockedActions.GetRequiredApprovals()))
+		}
+		resp = append(resp, rule)
 	}
 	w.Header().Set("ETag", swag.StringValue(eTag))
 	writeResponse(w, r, http.StatusOK, resp)
@@ -2521,16 +2775,11 @@ func (c *Controller) SetBranchProtectionRules(w http.ResponseWriter, r *http.Req
 	ctx := r.Context()
 	c.LogAction(ctx, "create_branch_protection_rule", r, repository, "", "")
 
-	// For now, all protected branches use the same default set of blocked actions. In the future this set will be user configurable.
-	blockedActions := []graveler.BranchProtectionBlockedAction{graveler.BranchProtectionBlockedAction_STAGING_WRITE, graveler.BranchProtectionBlockedAction_COMMIT}
-
 	rules := &graveler.BranchProtectionRules{
 		BranchPatternToBlockedActions: make(map[string]*graveler.BranchProtectionBlockedActions),
 	}
 	for _, r := range body {
-		rules.BranchPatternToBlockedActions[r.Pattern] = &graveler.BranchProtectionBlockedActions{
-			Value: blockedActions,
-		}
+		rules.BranchPatternToBlockedActions[r.Pattern] = branchProtectionBlockedActions(r)
 	}
 	err := c.Catalog.SetBranchProtectionRules(ctx, repository, rules, params.IfMatch)
 	if c.handleAPIError(ctx, w, r, err) {
@@ -2539,6 +2788,19 @@ func (c *Controller) SetBranchProtectionRules(w http.ResponseWriter, r *http.Req
 	writeResponse(w, r, http.StatusNoContent, nil)
 }
 
+// branchProtectionBlockedActions returns the actions a branch protection rule blocks. All protected branches
+// block the same default set of actions, rules requiring approvals also block merges without them.
+func branchProtectionBlockedActions(rule apigen.BranchProtectionRule) *graveler.BranchProtectionBlockedActions {
+	blockedActions := &graveler.BranchProtectionBlockedActions{
+		Value: []graveler.BranchProtectionBlockedAction{graveler.BranchProtectionBlockedAction_STAGING_WRITE, graveler.BranchProtectionBlockedAction_COMMIT},
+	}
+	if rule.RequiredApprovals != nil {
+		blockedActions.Value = append(blockedActions.Value, graveler.BranchProtectionBlockedAction_MERGE_WITHOUT_APPROVAL)
+		blockedActions.RequiredApprovals = int32(*rule.RequiredApprovals) //nolint:gosec
+	}
+	return blockedActions
+}
+
 func (c *Controller) DeleteGCRules(w http.ResponseWriter, r *http.Request, repository string) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -2602,6 +2864,157 @@ func (c *Controller) SetGCRules(w http.ResponseWriter, r *http.Request, body api
 	writeResponse(w, r, http.StatusNoContent, nil)
 }
 
+func (c *Controller) GetLifecycleRules(w http.ResponseWriter, r *http.Request, repository string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.GetLifecycleRulesAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	rules, _, err := c.Catalog.GetLifecycleRules(ctx, repository)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	resp := apigen.LifecycleRules{Rules: make([]apigen.LifecycleRule, 0, len(rules.GetRules()))}
+	for _, rule := range rules.GetRules() {
+		resp.Rules = append(resp.Rules, apigen.LifecycleRule{
+			Id:                         rule.GetId(),
+			Prefix:                     swag.String(rule.GetPrefix()),
+			BranchPattern:              swag.String(rule.GetBranchPattern()),
+			Enabled:                    rule.GetEnabled(),
+			UncommittedExpirationHours: swag.Int(int(rule.GetUncommittedExpirationHours())),
+			CommittedExpirationDays:    swag.Int(int(rule.GetCommittedExpirationDays())),
+		})
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+}
+
+func (c *Controller) SetLifecycleRules(w http.ResponseWriter, r *http.Request, body apigen.SetLifecycleRulesJSONRequestBody, repository string) {
+	if !c.authorize(w, r, permissions.LifecycleRulesNode(repository)) {
+		return
+	}
+	ctx := r.Context()
+	rules := &graveler.LifecycleRules{}
+	for _, rule := range body.Rules {
+		rules.Rules = append(rules.Rules, &graveler.LifecycleRule{
+			Id:                         rule.Id,
+			Prefix:                     swag.StringValue(rule.Prefix),
+			BranchPattern:              swag.StringValue(rule.BranchPattern),
+			Enabled:                    rule.Enabled,
+			UncommittedExpirationHours: int32(swag.IntValue(rule.UncommittedExpirationHours)), //nolint:gosec
+			CommittedExpirationDays:    int32(swag.IntValue(rule.CommittedExpirationDays)),    //nolint:gosec
+		})
+	}
+	err := c.Catalog.SetLifecycleRules(ctx, repository, rules, nil)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func (c *Controller) DeleteLifecycleRules(w http.ResponseWriter, r *http.Request, repository string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.SetLifecycleRulesAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	err := c.Catalog.DeleteLifecycleRules(ctx, repository)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func (c *Controller) GetObjectLockConfiguration(w http.ResponseWriter, r *http.Request, repository string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.GetObjectLockConfigurationAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	cfg, _, err := c.Catalog.GetObjectLockConfiguration(ctx, repository)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusOK, apigen.ObjectLockConfiguration{Enabled: cfg.GetEnabled()})
+}
+
+func (c *Controller) SetObjectLockConfiguration(w http.ResponseWriter, r *http.Request, body apigen.SetObjectLockConfigurationJSONRequestBody, repository string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.SetObjectLockConfigurationAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "set_object_lock_configuration", r, repository, "", "")
+	err := c.Catalog.SetObjectLockConfiguration(ctx, repository, &graveler.ObjectLockConfiguration{Enabled: body.Enabled}, nil)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func (c *Controller) RunLifecycle(w http.ResponseWriter, r *http.Request, repository string, params apigen.RunLifecycleParams) {
+	dryRun := swag.BoolValue(params.DryRun)
+	// a dry run only reads, while a run expires objects as the rules were set to
+	required := permissions.LifecycleRulesNode(repository)
+	if dryRun {
+		required = permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.GetLifecycleRulesAction,
+				Resource: permissions.RepoArn(repository),
+			},
+		}
+	}
+	if !c.authorize(w, r, required) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "run_lifecycle", r, repository, "", "")
+	result, err := c.Catalog.RunLifecycle(ctx, repository, dryRun)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	resp := apigen.LifecycleRunResult{
+		DryRun:   result.DryRun,
+		RunTime:  result.RunTime.Unix(),
+		Branches: make([]apigen.LifecycleBranchResult, 0, len(result.Branches)),
+	}
+	for _, branch := range result.Branches {
+		branchResult := apigen.LifecycleBranchResult{
+			Branch:                  branch.Branch,
+			ExpiredUncommitted:      append([]string{}, branch.ExpiredUncommitted...),
+			ExpiredUncommittedCount: branch.ExpiredUncommittedCount,
+			ExpiredCommitted:        append([]string{}, branch.ExpiredCommitted...),
+			ExpiredCommittedCount:   branch.ExpiredCommittedCount,
+		}
+		if branch.CommitID != "" {
+			branchResult.CommitId = swag.String(branch.CommitID)
+		}
+		if branch.Skipped != "" {
+			branchResult.Skipped = swag.String(branch.Skipped)
+		}
+		if branch.Error != "" {
+			branchResult.Error = swag.String(branch.Error)
+		}
+		resp.Branches = append(resp.Branches, branchResult)
+	}
+	writeResponse(w, r, http.StatusOK, resp)
+}
+
 func (c *Controller) ListRepositoryRuns(w http.ResponseWriter, r *http.Request, repository string, params apigen.ListRepositoryRunsParams) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -2957,7 +3370,9 @@ func (c *Controller) handleAPIErrorCallback(ctx context.Context, w http.Response
 
 	case errors.Is(err, block.ErrForbidden),
 		errors.Is(err, graveler.ErrProtectedBranch),
+		errors.Is(err, graveler.ErrObjectLocked),
 		errors.Is(err, graveler.ErrReadOnlyRepository),
+		errors.Is(err, graveler.ErrPullRequestCommentNotAuthor),
 		errors.Is(err, graveler.ErrDeleteDefaultBranch):
 		cb(w, r, http.StatusForbidden, err)
 
@@ -3641,13 +4056,18 @@ func (c *Controller) StageObject(w http.ResponseWriter, r *http.Request, body ap
 func (c *Controller) CopyObject(w http.ResponseWriter, r *http.Request, body apigen.CopyObjectJSONRequestBody, repository, branch string, params apigen.CopyObjectParams) {
 	srcPath := body.SrcPath
 	destPath := params.DestPath
+	// use destination repository as source if not specified
+	srcRepository := swag.StringValue(body.SrcRepository)
+	if srcRepository == "" {
+		srcRepository = repository
+	}
 	if !c.authorize(w, r, permissions.Node{
 		Type: permissions.NodeTypeAnd,
 		Nodes: []permissions.Node{
 			{
 				Permission: permissions.Permission{
 					Action:   permissions.ReadObjectAction,
-					Resource: permissions.ObjectArn(repository, srcPath),
+					Resource: permissions.ObjectArn(srcRepository, srcPath),
 				},
 			},
 			{
@@ -3679,19 +4099,23 @@ func (c *Controller) CopyObject(w http.ResponseWriter, r *http.Request, body api
 		return
 	}
 
-	// use destination branch as source if not specified
+	// use destination branch as source if not specified, unless copying from another repository
 	srcRef := swag.StringValue(body.SrcRef)
 	if srcRef == "" {
+		if srcRepository != repository {
+			writeError(w, r, http.StatusBadRequest, "src_ref is required when copying from another repository")
+			return
+		}
 		srcRef = branch
 	}
 
 	// copy entry
-	entry, err := c.Catalog.CopyEntry(ctx, repository, srcRef, srcPath, repository, branch, destPath, false, nil, graveler.WithForce(swag.BoolValue(body.Force)))
+	entry, err := c.Catalog.CopyEntry(ctx, srcRepository, srcRef, srcPath, repository, branch, destPath, false, nil, graveler.WithForce(swag.BoolValue(body.Force)))
 	if c.handleAPIError(ctx, w, r, err) {
 		return
 	}
 
-	qk, err := c.BlockAdapter.ResolveNamespace(repo.StorageID, repo.StorageNamespace, entry.PhysicalAddress, block.IdentifierTypeRelative)
+	qk, err := c.BlockAdapter.ResolveNamespace(repo.StorageID, repo.StorageNamespace, entry.PhysicalAddress, entry.AddressType.ToIdentifierType())
 	if err != nil {
 		writeError(w, r, http.StatusInternalServerError, err)
 		return
@@ -3825,8 +4249,7 @@ func (c *Controller) GetCommit(w http.ResponseWriter, r *http.Request, repositor
 		writeError(w, r, http.StatusNotFound, "commit not found")
 		return
 	}
-	if err != nil {
-		writeError(w, r, http.StatusInternalServerError, err)
+	if c.handleAPIError(ctx, w, r, err) {
 		return
 	}
 	response := apigen.Commit{
@@ -3980,10 +4403,7 @@ func (c *Controller) InternalCreateBranchProtectionRule(w http.ResponseWriter, r
 	if rules.BranchPatternToBlockedActions == nil {
 		rules.BranchPatternToBlockedActions = make(map[string]*graveler.BranchProtectionBlockedActions)
 	}
-	blockedActions := &graveler.BranchProtectionBlockedActions{
-		Value: []graveler.BranchProtectionBlockedAction{graveler.BranchProtectionBlockedAction_STAGING_WRITE, graveler.BranchProtectionBlockedAction_COMMIT},
-	}
-	rules.BranchPatternToBlockedActions[body.Pattern] = blockedActions
+	rules.BranchPatternToBlockedActions[body.Pattern] = branchProtectionBlockedActions(apigen.BranchProtectionRule(body))
 	err = c.Catalog.SetBranchProtectionRules(ctx, repository, rules, nil)
 	if c.handleAPIError(ctx, w, r, err) {
 		return
@@ -4751,6 +5171,10 @@ func (c *Controller) GetObject(w http.ResponseWriter, r *http.Request, repositor
 		writeError(w, r, http.StatusGone, "resource expired")
 		return
 	}
+	if entry.IsCustomerKeyEncrypted() {
+		writeError(w, r, http.StatusBadRequest, "object is encrypted with a customer key: read it through the S3 gateway")
+		return
+	}
 
 	eTag := httputil.ETag(entry.Checksum)
 
@@ -4908,6 +5332,7 @@ func (c *Controller) ListObjects(w http.ResponseWriter, r *http.Request, reposit
 							Resource: permissions.ObjectArn(repository, entry.Path),
 						},
 					},
+					RequestContext: c.requestContext(r),
 				})
 				if c.handleAPIError(ctx, w, r, err) {
 					return
@@ -5035,6 +5460,133 @@ func (c *Controller) UpdateObjectUserMetadata(w http.ResponseWriter, r *http.Req
 	writeResponse(w, r, http.StatusNoContent, nil)
 }
 
+// objectRetentionPermissions returns the permissions required to set the retention of the object at path, which
+// include bypassing GOVERNANCE retention when bypassGovernance is set
+func objectRetentionPermissions(repository, path string, bypassGovernance bool) permissions.Node {
+	node := permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.SetObjectRetentionAction,
+			Resource: permissions.ObjectArn(repository, path),
+		},
+	}
+	if !bypassGovernance {
+		return node
+	}
+	return permissions.Node{
+		Type: permissions.NodeTypeAnd,
+		Nodes: []permissions.Node{
+			node,
+			{
+				Permission: permissions.Permission{
+					Action:   permissions.BypassGovernanceRetentionAction,
+					Resource: permissions.ObjectArn(repository, path),
+				},
+			},
+		},
+	}
+}
+
+func (c *Controller) GetObjectRetention(w http.ResponseWriter, r *http.Request, repository, branch string, params apigen.GetObjectRetentionParams) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.GetObjectRetentionAction,
+			Resource: permissions.ObjectArn(repository, params.Path),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "get_object_retention", r, repository, branch, "")
+	lock, err := c.Catalog.GetObjectLock(ctx, repository, branch, params.Path)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	if lock.GetRetainUntil() == nil {
+		writeError(w, r, http.StatusNotFound, "object has no retention")
+		return
+	}
+	writeResponse(w, r, http.StatusOK, apigen.ObjectRetention{
+		Mode:        lock.GetMode().String(),
+		RetainUntil: lock.GetRetainUntil().AsTime().Unix(),
+	})
+}
+
+func (c *Controller) SetObjectRetention(w http.ResponseWriter, r *http.Request, body apigen.SetObjectRetentionJSONRequestBody, repository, branch string, params apigen.SetObjectRetentionParams) {
+	bypassGovernance := swag.BoolValue(params.BypassGovernance)
+	if !c.authorize(w, r, objectRetentionPermissions(repository, params.Path, bypassGovernance)) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "set_object_retention", r, repository, branch, "")
+	mode, ok := graveler.ObjectLockMode_value[body.Mode]
+	if !ok {
+		writeError(w, r, http.StatusBadRequest, fmt.Sprintf("invalid retention mode '%s'", body.Mode))
+		return
+	}
+	retention := &objectlock.Retention{
+		Mode:        graveler.ObjectLockMode(mode),
+		RetainUntil: time.Unix(body.RetainUntil, 0),
+	}
+	err := c.Catalog.SetObjectRetention(ctx, repository, branch, params.Path, retention, bypassGovernance)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func (c *Controller) DeleteObjectRetention(w http.ResponseWriter, r *http.Request, repository, branch string, params apigen.DeleteObjectRetentionParams) {
+	if !c.authorize(w, r, objectRetentionPermissions(repository, params.Path, true)) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "delete_object_retention", r, repository, branch, "")
+	err := c.Catalog.SetObjectRetention(ctx, repository, branch, params.Path, nil, true)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func (c *Controller) GetObjectLegalHold(w http.ResponseWriter, r *http.Request, repository, branch string, params apigen.GetObjectLegalHoldParams) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.GetObjectLegalHoldAction,
+			Resource: permissions.ObjectArn(repository, params.Path),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "get_object_legal_hold", r, repository, branch, "")
+	lock, err := c.Catalog.GetObjectLock(ctx, repository, branch, params.Path)
+	if errors.Is(err, graveler.ErrNotFound) {
+		// objects that were never locked are not under legal hold
+		err = nil
+	}
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusOK, apigen.ObjectLegalHold{Status: lock.GetLegalHold()})
+}
+
+func (c *Controller) SetObjectLegalHold(w http.ResponseWriter, r *http.Request, body apigen.SetObjectLegalHoldJSONRequestBody, repository, branch string, params apigen.SetObjectLegalHoldParams) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.SetObjectLegalHoldAction,
+			Resource: permissions.ObjectArn(repository, params.Path),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "set_object_legal_hold", r, repository, branch, "")
+	err := c.Catalog.SetObjectLegalHold(ctx, repository, branch, params.Path, body.Status)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
 func (c *Controller) GetUnderlyingProperties(w http.ResponseWriter, r *http.Request, repository, ref string, params apigen.GetUnderlyingPropertiesParams) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -5097,15 +5649,23 @@ func (c *Controller) MergeIntoBranch(w http.ResponseWriter, r *http.Request, bod
 		metadata = body.Metadata.AdditionalProperties
 	}
 
+	conflictOpts, err := c.mergeConflictOptions(ctx, body.StrategyRules, body.Resolver)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	opts := append([]graveler.SetOptionsFunc{
+		graveler.WithForce(swag.BoolValue(body.Force)),
+		graveler.WithAllowEmpty(swag.BoolValue(body.AllowEmpty)),
+		graveler.WithSquashMerge(swag.BoolValue(body.SquashMerge)),
+	}, conflictOpts...)
+
 	reference, err := c.Catalog.Merge(ctx,
 		repository, destinationBranch, sourceRef,
 		user.Committer(),
 		swag.StringValue(body.Message),
 		metadata,
 		swag.StringValue(body.Strategy),
-		graveler.WithForce(swag.BoolValue(body.Force)),
-		graveler.WithAllowEmpty(swag.BoolValue(body.AllowEmpty)),
-		graveler.WithSquashMerge(swag.BoolValue(body.SquashMerge)),
+		opts...,
 	)
 
 	if errors.Is(err, graveler.ErrConflictFound) {
@@ -5122,6 +5682,34 @@ func (c *Controller) MergeIntoBranch(w http.ResponseWriter, r *http.Request, bod
 	})
 }
 
+// mergeConflictOptions returns the options deciding merge conflicts by strategy rules and resolver
+func (c *Controller) mergeConflictOptions(ctx context.Context, strategyRules *[]apigen.MergeStrategyRule, resolverBody *apigen.MergeConflictResolver) ([]graveler.SetOptionsFunc, error) {
+	var opts []graveler.SetOptionsFunc
+	if strategyRules != nil {
+		rules := make(graveler.MergeStrategyRules, 0, len(*strategyRules))
+		for _, ruleBody := range *strategyRules {
+			rule, err := graveler.NewMergeStrategyRule(ruleBody.Pattern, ruleBody.Strategy)
+			if err != nil {
+				return nil, err
+			}
+			rules = append(rules, rule)
+		}
+		opts = append(opts, graveler.WithMergeStrategyRules(rules))
+	}
+	if resolverBody != nil {
+		luaConfig := c.Config.GetBaseConfig().Graveler.LuaConflictResolver
+		resolver, err := catalog.NewConflictResolver(ctx, resolverBody.Type, swag.StringValue(resolverBody.Script), catalog.ConflictResolverConfig{
+			LuaEnabled: luaConfig.Enabled,
+			LuaTimeout: luaConfig.Timeout,
+		})
+		if err != nil {
+			return nil, err
+		}
+		opts = append(opts, graveler.WithConflictResolver(resolver))
+	}
+	return opts, nil
+}
+
 func (c *Controller) FindMergeBase(w http.ResponseWriter, r *http.Request, repository string, sourceRef string, destinationRef string) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -5145,6 +5733,50 @@ func (c *Controller) FindMergeBase(w http.ResponseWriter, r *http.Request, repos
 	})
 }
 
+func (c *Controller) PreviewMerge(w http.ResponseWriter, r *http.Request, body apigen.PreviewMergeJSONRequestBody, repository string, sourceRef string, destinationBranch string, params apigen.PreviewMergeParams) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.ListObjectsAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "preview_merge", r, repository, destinationBranch, sourceRef)
+
+	opts, err := c.mergeConflictOptions(ctx, body.StrategyRules, body.Resolver)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	preview, hasMore, err := c.Catalog.PreviewMerge(ctx, repository, destinationBranch, sourceRef, swag.StringValue(body.Strategy), paginationAmount(params.Amount), paginationAfter(params.After), opts...)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	results := make([]apigen.MergePreviewConflict, 0, len(preview.Results))
+	for _, conflict := range preview.Results {
+		results = append(results, apigen.MergePreviewConflict{
+			Path:                conflict.Path,
+			BaseIdentity:        conflict.BaseIdentity,
+			SourceIdentity:      conflict.SourceIdentity,
+			DestinationIdentity: conflict.DestinationIdentity,
+		})
+	}
+	writeResponse(w, r, http.StatusOK, apigen.MergePreview{
+		SourceCommitId:      preview.SourceCommitID,
+		DestinationCommitId: preview.DestinationCommitID,
+		BaseCommitId:        preview.BaseCommitID,
+		Summary: apigen.MergePreviewSummary{
+			Added:    preview.Added,
+			Changed:  preview.Changed,
+			Removed:  preview.Removed,
+			Conflict: preview.Conflicts,
+		},
+		Pagination: paginationFor(hasMore, results, "Path"),
+		Results:    results,
+	})
+}
+
 func (c *Controller) ListTags(w http.ResponseWriter, r *http.Request, repository string, params apigen.ListTagsParams) {
 	if !c.authorize(w, r, permissions.Node{
 		Permission: permissions.Permission{
@@ -5729,7 +6361,9 @@ func (c *Controller) MergePullRequest(w http.ResponseWriter, r *http.Request, re
 	}
 
 	// Attempt to merge branches
-	reference, err := c.Catalog.Merge(ctx, repository, pr.Destination, pr.Source, user.Committer(), "", nil, "")
+	metadata := catalog.Metadata{graveler.PullRequestMetadataKey: pullRequestID}
+	reference, err := c.Catalog.Merge(ctx, repository, pr.Destination, pr.Source, user.Committer(), "", metadata, "",
+		graveler.WithPullRequest(graveler.PullRequestID(pullRequestID)))
 	if c.handleAPIError(ctx, w, r, err) {
 		return
 	}
@@ -5749,6 +6383,154 @@ func (c *Controller) MergePullRequest(w http.ResponseWriter, r *http.Request, re
 	})
 }
 
+func (c *Controller) ListPullRequestReviews(w http.ResponseWriter, r *http.Request, repository string, pullRequestID string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.ReadPullRequestAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "list_pull_request_reviews", r, repository, pullRequestID, "")
+	pr, err := c.Catalog.GetPullRequest(ctx, repository, pullRequestID)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	reviews := make([]apigen.PullRequestReview, 0, len(pr.Reviews))
+	for _, review := range pr.Reviews {
+		reviews = append(reviews, apigen.PullRequestReview{
+			Reviewer:     review.Reviewer,
+			State:        strings.ToLower(review.State.String()),
+			Comment:      apiutil.Ptr(review.Comment),
+			CreationDate: review.CreationDate,
+			CommitId:     review.CommitID.String(),
+		})
+	}
+	writeResponse(w, r, http.StatusOK, apigen.PullRequestReviewList{Results: reviews})
+}
+
+func (c *Controller) CreatePullRequestReview(w http.ResponseWriter, r *http.Request, body apigen.CreatePullRequestReviewJSONRequestBody, repository string, pullRequestID string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.ReviewPullRequestAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "review_pull_request", r, repository, pullRequestID, "")
+
+	user, err := auth.GetUser(ctx)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	err = c.Catalog.ReviewPullRequest(ctx, repository, pullRequestID, user.Username, body.State, swag.StringValue(body.Comment), body.CommitId)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusNoContent, nil)
+}
+
+func pullRequestCommentResponse(comment *graveler.PullRequestComment) apigen.PullRequestComment {
+	return apigen.PullRequestComment{
+		Id:           comment.ID.String(),
+		Author:       comment.Author,
+		Body:         comment.Body,
+		Path:         apiutil.Ptr(comment.Path),
+		ReplyTo:      apiutil.Ptr(comment.ReplyTo.String()),
+		CreationDate: comment.CreationDate,
+		UpdateDate:   comment.UpdateDate,
+		Resolved:     comment.Resolved,
+		ResolvedBy:   apiutil.Ptr(comment.ResolvedBy),
+	}
+}
+
+func (c *Controller) ListPullRequestComments(w http.ResponseWriter, r *http.Request, repository string, pullRequestID string, params apigen.ListPullRequestCommentsParams) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.ReadPullRequestAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "list_pull_request_comments", r, repository, pullRequestID, "")
+	res, hasMore, err := c.Catalog.ListPullRequestComments(ctx, repository, pullRequestID, paginationAmount(params.Amount), paginationAfter(params.After))
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	comments := make([]apigen.PullRequestComment, 0, len(res))
+	for _, comment := range res {
+		comments = append(comments, pullRequestCommentResponse(comment))
+	}
+	writeResponse(w, r, http.StatusOK, apigen.PullRequestCommentList{
+		Pagination: paginationFor(hasMore, comments, "Id"),
+		Results:    comments,
+	})
+}
+
+func (c *Controller) CreatePullRequestComment(w http.ResponseWriter, r *http.Request, body apigen.CreatePullRequestCommentJSONRequestBody, repository string, pullRequestID string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.CommentPullRequestAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "create_pull_request_comment", r, repository, pullRequestID, "")
+
+	user, err := auth.GetUser(ctx)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	commentID, err := c.Catalog.CreatePullRequestComment(ctx, repository, pullRequestID, user.Username, body.Body, swag.StringValue(body.Path), swag.StringValue(body.ReplyTo))
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	comment, err := c.Catalog.GetPullRequestComment(ctx, repository, pullRequestID, commentID)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusCreated, pullRequestCommentResponse(comment))
+}
+
+func (c *Controller) UpdatePullRequestComment(w http.ResponseWriter, r *http.Request, body apigen.UpdatePullRequestCommentJSONRequestBody, repository string, pullRequestID string, commentID string) {
+	if !c.authorize(w, r, permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.CommentPullRequestAction,
+			Resource: permissions.RepoArn(repository),
+		},
+	}) {
+		return
+	}
+	ctx := r.Context()
+	c.LogAction(ctx, "update_pull_request_comment", r, repository, pullRequestID, "")
+
+	user, err := auth.GetUser(ctx)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	err = c.Catalog.UpdatePullRequestComment(ctx, repository, pullRequestID, commentID, &graveler.UpdatePullRequestComment{
+		Editor:   user.Username,
+		Body:     body.Body,
+		Resolved: body.Resolved,
+	})
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	comment, err := c.Catalog.GetPullRequestComment(ctx, repository, pullRequestID, commentID)
+	if c.handleAPIError(ctx, w, r, err) {
+		return
+	}
+	writeResponse(w, r, http.StatusOK, pullRequestCommentResponse(comment))
+}
+
 func writeError(w http.ResponseWriter, r *http.Request, code int, v interface{}) {
 	apiErr := apigen.Error{
 		Message: fmt.Sprint(v),
@@ -5924,6 +6706,7 @@ func (c *Controller) authorizeCallback(w http.ResponseWriter, r *http.Request, p
 	resp, err := c.Auth.Authorize(ctx, &auth.AuthorizationRequest{
 		Username:            user.Username,
 		RequiredPermissions: perms,
+		RequestContext:      c.requestContext(r),
 	})
 	if err != nil {
 		cb(w, r, http.StatusInternalServerError, err)
@@ -5944,6 +6727,27 @@ func (c *Controller) authorize(w http.ResponseWriter, r *http.Request, perms per
 	return c.authorizeCallback(w, r, perms, writeError)
 }
 
+// requestContext returns the attributes of r tested by policy statement conditions
+func (c *Controller) requestContext(r *http.Request) *auth.RequestContext {
+	branch := chi.URLParam(r, "branch")
+	if branch == "" {
+		branch = chi.URLParam(r, "destinationBranch")
+	}
+	reqCtx := auth.NewRequestContext(r, branch, extractLakeFSMetadata(r.Header))
+	if ref := chi.URLParam(r, "ref"); branch == "" && ref != "" {
+		// a ref operates on a branch only if it is one, not on the branch of an expression such as "branch~1"
+		repository := chi.URLParam(r, "repository")
+		reqCtx.ResolveBranch = sync.OnceValue(func() string {
+			exists, err := c.Catalog.BranchExists(r.Context(), repository, ref)
+			if err != nil || !exists {
+				return ""
+			}
+			return ref
+		})
+	}
+	return reqCtx
+}
+
 func (c *Controller) isNameValid(name, nameType string) (bool, string) {
 	// URLs are % encoded. Allowing % signs in entity names would
 	// limit the ability to use these entity names in the URL for both
@@ -823,6 +823,61 @@ func TestController_GetCommitHandler(t *testing.T) {
 		}
 	})
 
+	t.Run("branch commit at time", func(t *testing.T) {
+		ctx := context.Background()
+		repo := testUniqueRepoName()
+		_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+		testutil.Must(t, err)
+		// commits dated after the initial commit of the repository
+		firstDate := time.Now().Add(time.Hour).Truncate(time.Second)
+		var commitIDs []string
+		for i, date := range []time.Time{firstDate, firstDate.Add(time.Hour)} {
+			testutil.MustDo(t, "create entry", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: fmt.Sprintf("foo/bar%d", i), PhysicalAddress: "pa", CreationDate: time.Now(), Size: 1, Checksum: "cs"}))
+			commitDate := date.Unix()
+			resp, err := clt.CommitWithResponse(ctx, repo, "main", &apigen.CommitParams{}, apigen.CommitJSONRequestBody{
+				Message: "some message",
+				Date:    &commitDate,
+			})
+			verifyResponseOK(t, resp, err)
+			commitIDs = append(commitIDs, resp.JSON201.Id)
+		}
+
+		refAt := func(at time.Time) string {
+			return "main@{" + at.UTC().Format(time.RFC3339) + "}"
+		}
+
+		resp, err := clt.GetCommitWithResponse(ctx, repo, refAt(firstDate.Add(30*time.Minute)))
+		verifyResponseOK(t, resp, err)
+		if resp.JSON200.Id != commitIDs[0] {
+			t.Fatalf("GetCommit ID=%s, expected=%s", resp.JSON200.Id, commitIDs[0])
+		}
+
+		resp, err = clt.GetCommitWithResponse(ctx, repo, refAt(firstDate.Add(time.Hour)))
+		verifyResponseOK(t, resp, err)
+		if resp.JSON200.Id != commitIDs[1] {
+			t.Fatalf("GetCommit ID=%s, expected=%s", resp.JSON200.Id, commitIDs[1])
+		}
+
+		resp, err = clt.GetCommitWithResponse(ctx, repo, "main@{2020-01-01T00:00:00Z}")
+		testutil.Must(t, err)
+		if resp.JSON404 == nil {
+			t.Fatalf("GetCommit before the history of the branch: expected not found, got status %d", resp.StatusCode())
+		}
+
+		// a commit dated before its parent makes resolving across it fail
+		testutil.MustDo(t, "create entry", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "foo/bar2", PhysicalAddress: "pa", CreationDate: time.Now(), Size: 1, Checksum: "cs"}))
+		commitResp, err := clt.CommitWithResponse(ctx, repo, "main", &apigen.CommitParams{}, apigen.CommitJSONRequestBody{
+			Message: "some message",
+			Date:    apiutil.Ptr(int64(0)),
+		})
+		verifyResponseOK(t, commitResp, err)
+		resp, err = clt.GetCommitWithResponse(ctx, repo, refAt(firstDate.Add(30*time.Minute)))
+		testutil.Must(t, err)
+		if resp.StatusCode() != http.StatusBadRequest {
+			t.Fatalf("GetCommit across commits dated out of order: expected bad request, got status %d", resp.StatusCode())
+		}
+	})
+
 	t.Run("initial commit", func(t *testing.T) {
 		// validate a new repository's initial commit existence and structure
 		ctx := context.Background()
@@ -2786,6 +2841,18 @@ func TestController_ObjectsGetObjectHandler(t *testing.T) {
 	testutil.Must(t, deps.catalog.CreateEntry(ctx, repo, "main", emptyEtag))
 	expectedEtag := "\"" + blob.Checksum + "\""
 
+	t.Run("get customer key encrypted object", func(t *testing.T) {
+		encrypted := entry
+		encrypted.Path = "foo/encrypted"
+		encrypted.Metadata = catalog.Metadata{catalog.SSECustomerKeyMD5MetadataKey: "key-md5"}
+		testutil.Must(t, deps.catalog.CreateEntry(ctx, repo, "main", encrypted))
+		resp, err := clt.GetObjectWithResponse(ctx, repo, "main", &apigen.GetObjectParams{Path: encrypted.Path})
+		testutil.Must(t, err)
+		if resp.StatusCode() != http.StatusBadRequest {
+			t.Errorf("GetObject() status code = %d, expected %d", resp.StatusCode(), http.StatusBadRequest)
+		}
+	})
+
 	t.Run("get object", func(t *testing.T) {
 		resp, err := clt.GetObjectWithResponse(ctx, repo, "main", &apigen.GetObjectParams{Path: "foo/bar"})
 		if err != nil {
@@ -4059,6 +4126,242 @@ func TestController_MergeSquashing(t *testing.T) {
 	}
 }
 
+func TestController_MergeWithResolver(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+
+	// setup env: both branches change the same object, main with the larger and older one
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	testutil.Must(t, err)
+	err = deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "foo/bar", PhysicalAddress: "base-addr", CreationDate: time.Now(), Size: 1, Checksum: "base"})
+	testutil.Must(t, err)
+	_, err = deps.catalog.Commit(ctx, repo, "main", "base", DefaultUserID, nil, nil, nil, false)
+	testutil.Must(t, err)
+	_, err = deps.catalog.CreateBranch(ctx, repo, "branch1", "main")
+	testutil.Must(t, err)
+	now := time.Now()
+	err = deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "foo/bar", PhysicalAddress: "main-addr", CreationDate: now.Add(-time.Hour), Size: 100, Checksum: "main"})
+	testutil.Must(t, err)
+	_, err = deps.catalog.Commit(ctx, repo, "main", "change on main", DefaultUserID, nil, nil, nil, false)
+	testutil.Must(t, err)
+	err = deps.catalog.CreateEntry(ctx, repo, "branch1", catalog.DBEntry{Path: "foo/bar", PhysicalAddress: "branch-addr", CreationDate: now, Size: 10, Checksum: "branch"})
+	testutil.Must(t, err)
+	_, err = deps.catalog.Commit(ctx, repo, "branch1", "change on branch", DefaultUserID, nil, nil, nil, false)
+	testutil.Must(t, err)
+
+	t.Run("conflict", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+	})
+
+	t.Run("resolver_with_strategy", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			Strategy: apiutil.Ptr("dest-wins"),
+			Resolver: &apigen.MergeConflictResolver{Type: catalog.ConflictResolverNewestWins},
+		})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("unknown_resolver", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			Resolver: &apigen.MergeConflictResolver{Type: "no-such-resolver"},
+		})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("newest_wins", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			Resolver: &apigen.MergeConflictResolver{Type: catalog.ConflictResolverNewestWins},
+		})
+		verifyResponseOK(t, resp, err)
+
+		entry, err := deps.catalog.GetEntry(ctx, repo, "main", "foo/bar", catalog.GetEntryParams{})
+		testutil.Must(t, err)
+		require.Equal(t, "branch", entry.Checksum)
+
+		commit, err := deps.catalog.GetCommit(ctx, repo, resp.JSON200.Reference)
+		testutil.Must(t, err)
+		require.Equal(t, graveler.MergeStrategyResolveStr, commit.Metadata[graveler.MergeStrategyMetadataKey])
+		require.Equal(t, catalog.ConflictResolverNewestWins, commit.Metadata[graveler.MergeResolverMetadataKey])
+		resolutions, err := graveler.MergeResolutions(graveler.Metadata(commit.Metadata))
+		testutil.Must(t, err)
+		require.Len(t, resolutions, 1)
+		require.Equal(t, "foo/bar", resolutions[0].Key)
+		require.Equal(t, graveler.ConflictResolutionSource, resolutions[0].Resolution)
+	})
+}
+
+func TestController_MergeWithStrategyRules(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+
+	// setup env: both branches change the same two objects
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	testutil.Must(t, err)
+	for _, p := range []string{"models/m1", "table/_delta_log/0001.json"} {
+		err = deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: p, PhysicalAddress: "base-addr", CreationDate: time.Now(), Size: 1, Checksum: "base"})
+		testutil.Must(t, err)
+	}
+	_, err = deps.catalog.Commit(ctx, repo, "main", "base", DefaultUserID, nil, nil, nil, false)
+	testutil.Must(t, err)
+	_, err = deps.catalog.CreateBranch(ctx, repo, "branch1", "main")
+	testutil.Must(t, err)
+	for _, branch := range []string{"main", "branch1"} {
+		for _, p := range []string{"models/m1", "table/_delta_log/0001.json"} {
+			err = deps.catalog.CreateEntry(ctx, repo, branch, catalog.DBEntry{Path: p, PhysicalAddress: branch + "-addr", CreationDate: time.Now(), Size: 1, Checksum: branch})
+			testutil.Must(t, err)
+		}
+		_, err = deps.catalog.Commit(ctx, repo, branch, "change on "+branch, DefaultUserID, nil, nil, nil, false)
+		testutil.Must(t, err)
+	}
+
+	t.Run("invalid_rule", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			StrategyRules: &[]apigen.MergeStrategyRule{{Pattern: "models/", Strategy: "newest"}},
+		})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("unmatched_conflict", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			StrategyRules: &[]apigen.MergeStrategyRule{{Pattern: "models/", Strategy: "source-wins"}},
+		})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusConflict, resp.StatusCode())
+	})
+
+	t.Run("rules", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "branch1", "main", apigen.MergeIntoBranchJSONRequestBody{
+			StrategyRules: &[]apigen.MergeStrategyRule{
+				{Pattern: "**/_delta_log/**", Strategy: "dest-wins"},
+				{Pattern: "models/", Strategy: "source-wins"},
+			},
+		})
+		verifyResponseOK(t, resp, err)
+
+		entry, err := deps.catalog.GetEntry(ctx, repo, "main", "models/m1", catalog.GetEntryParams{})
+		testutil.Must(t, err)
+		require.Equal(t, "branch1", entry.Checksum)
+		entry, err = deps.catalog.GetEntry(ctx, repo, "main", "table/_delta_log/0001.json", catalog.GetEntryParams{})
+		testutil.Must(t, err)
+		require.Equal(t, "main", entry.Checksum)
+
+		commit, err := deps.catalog.GetCommit(ctx, repo, resp.JSON200.Reference)
+		testutil.Must(t, err)
+		require.JSONEq(t, `[{"pattern":"**/_delta_log/**","strategy":"dest-wins"},{"pattern":"models/","strategy":"source-wins"}]`,
+			commit.Metadata[graveler.MergeStrategyRulesMetadataKey])
+	})
+}
+
+func TestController_PreviewMerge(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+
+	// setup env: both branches change "a" and "e", the source also changes "b", removes "c" and adds "d"
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	testutil.Must(t, err)
+	for _, p := range []string{"a", "b", "c", "e"} {
+		err = deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: p, PhysicalAddress: "base-addr", CreationDate: time.Now(), Size: 1, Checksum: "base"})
+		testutil.Must(t, err)
+	}
+	_, err = deps.catalog.Commit(ctx, repo, "main", "base", DefaultUserID, nil, nil, nil, false)
+	testutil.Must(t, err)
+	_, err = deps.catalog.CreateBranch(ctx, repo, "branch1", "main")
+	testutil.Must(t, err)
+	changes := map[string][]string{
+		"main":    {"a", "e"},
+		"branch1": {"a", "b", "d", "e"},
+	}
+	for branch, paths := range changes {
+		for _, p := range paths {
+			err = deps.catalog.CreateEntry(ctx, repo, branch, catalog.DBEntry{Path: p, PhysicalAddress: branch + "-addr", CreationDate: time.Now(), Size: 1, Checksum: branch})
+			testutil.Must(t, err)
+		}
+	}
+	testutil.Must(t, deps.catalog.DeleteEntry(ctx, repo, "branch1", "c"))
+	for branch := range changes {
+		_, err = deps.catalog.Commit(ctx, repo, branch, "change on "+branch, DefaultUserID, nil, nil, nil, false)
+		testutil.Must(t, err)
+	}
+
+	t.Run("summary", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{}, apigen.PreviewMergeJSONRequestBody{})
+		verifyResponseOK(t, resp, err)
+		require.Equal(t, apigen.MergePreviewSummary{Added: 1, Changed: 1, Removed: 1, Conflict: 2}, resp.JSON200.Summary)
+		require.NotEmpty(t, resp.JSON200.BaseCommitId)
+		require.Len(t, resp.JSON200.Results, 2)
+		conflict := resp.JSON200.Results[0]
+		require.Equal(t, "a", conflict.Path)
+		require.NotEmpty(t, conflict.BaseIdentity)
+		require.NotEmpty(t, conflict.SourceIdentity)
+		require.NotEmpty(t, conflict.DestinationIdentity)
+		require.NotEqual(t, conflict.SourceIdentity, conflict.DestinationIdentity)
+		require.False(t, resp.JSON200.Pagination.HasMore)
+	})
+
+	t.Run("pagination", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{
+			Amount: apiutil.Ptr(apigen.PaginationAmount(1)),
+		}, apigen.PreviewMergeJSONRequestBody{})
+		verifyResponseOK(t, resp, err)
+		require.Len(t, resp.JSON200.Results, 1)
+		require.Equal(t, "a", resp.JSON200.Results[0].Path)
+		require.True(t, resp.JSON200.Pagination.HasMore)
+		require.Equal(t, 2, resp.JSON200.Summary.Conflict)
+
+		resp, err = clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{
+			After:  apiutil.Ptr(apigen.PaginationAfter(resp.JSON200.Pagination.NextOffset)),
+			Amount: apiutil.Ptr(apigen.PaginationAmount(1)),
+		}, apigen.PreviewMergeJSONRequestBody{})
+		verifyResponseOK(t, resp, err)
+		require.Len(t, resp.JSON200.Results, 1)
+		require.Equal(t, "e", resp.JSON200.Results[0].Path)
+		require.False(t, resp.JSON200.Pagination.HasMore)
+		require.Equal(t, apigen.MergePreviewSummary{}, resp.JSON200.Summary, "summary on a later page")
+	})
+
+	t.Run("strategy", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{}, apigen.PreviewMergeJSONRequestBody{
+			Strategy: apiutil.Ptr("source-wins"),
+		})
+		verifyResponseOK(t, resp, err)
+		require.Equal(t, apigen.MergePreviewSummary{Added: 1, Changed: 3, Removed: 1}, resp.JSON200.Summary)
+		require.Empty(t, resp.JSON200.Results)
+	})
+
+	t.Run("strategy_rules", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{}, apigen.PreviewMergeJSONRequestBody{
+			StrategyRules: &[]apigen.MergeStrategyRule{{Pattern: "a", Strategy: "dest-wins"}},
+		})
+		verifyResponseOK(t, resp, err)
+		require.Equal(t, apigen.MergePreviewSummary{Added: 1, Changed: 1, Removed: 1, Conflict: 1}, resp.JSON200.Summary)
+		require.Len(t, resp.JSON200.Results, 1)
+		require.Equal(t, "e", resp.JSON200.Results[0].Path)
+	})
+
+	t.Run("resolver", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "main", &apigen.PreviewMergeParams{}, apigen.PreviewMergeJSONRequestBody{
+			Resolver: &apigen.MergeConflictResolver{Type: "newest-wins"},
+		})
+		verifyResponseOK(t, resp, err)
+		require.Zero(t, resp.JSON200.Summary.Conflict)
+		require.Empty(t, resp.JSON200.Results)
+	})
+
+	t.Run("no_branch", func(t *testing.T) {
+		resp, err := clt.PreviewMergeWithResponse(ctx, repo, "branch1", "no-such-branch", &apigen.PreviewMergeParams{}, apigen.PreviewMergeJSONRequestBody{})
+		testutil.Must(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+}
+
 func TestController_MergeDirtyBranch(t *testing.T) {
 	clt, deps := setupClientWithAdmin(t)
 	ctx := context.Background()
@@ -4632,6 +4935,17 @@ func TestController_Policy(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, http.StatusNotImplemented, resp.StatusCode())
 	})
+
+	t.Run("simulate", func(t *testing.T) {
+		resp, err := clt.SimulatePolicyWithResponse(ctx, apigen.SimulatePolicyJSONRequestBody{
+			UserId: apiutil.Ptr("admin"),
+			Permissions: []apigen.PolicySimulationPermission{
+				{Action: "fs:ReadObject", Resource: "*"},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotImplemented, resp.StatusCode())
+	})
 }
 
 func TestController_GetPhysicalAddress(t *testing.T) {
@@ -5171,6 +5485,30 @@ func TestController_CopyObjectHandler(t *testing.T) {
 		require.Nil(t, deep.Equal(statResp.JSON200, copyStat))
 	})
 
+	t.Run("customer_key_encrypted", func(t *testing.T) {
+		const (
+			srcPath  = "foo/encrypted"
+			destPath = "foo/encrypted-copy"
+		)
+		objStat := uploadContent(t, repo, "main", srcPath)
+		require.NoError(t, deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{
+			Path:            srcPath,
+			PhysicalAddress: objStat.PhysicalAddress,
+			AddressType:     catalog.AddressTypeFull,
+			CreationDate:    time.Now(),
+			Size:            apiutil.Value(objStat.SizeBytes),
+			Checksum:        objStat.Checksum,
+			Metadata:        catalog.Metadata{catalog.SSECustomerKeyMD5MetadataKey: "key-md5"},
+		}))
+		copyResp, err := clt.CopyObjectWithResponse(ctx, repo, "main", &apigen.CopyObjectParams{
+			DestPath: destPath,
+		}, apigen.CopyObjectJSONRequestBody{
+			SrcPath: srcPath,
+		})
+		require.NoError(t, err)
+		require.NotNil(t, copyResp.JSON400)
+	})
+
 	t.Run("different_branch", func(t *testing.T) {
 		const (
 			srcPath  = "foo/bar2"
@@ -5245,6 +5583,81 @@ func TestController_CopyObjectHandler(t *testing.T) {
 		require.Nil(t, deep.Equal(statResp.JSON200, copyStat))
 	})
 
+	t.Run("other_repository", func(t *testing.T) {
+		const (
+			srcPath  = "foo/bar4"
+			destPath = "foo/bar-from-repository"
+		)
+		destRepo := testUniqueRepoName()
+		_, err := deps.catalog.CreateRepository(ctx, destRepo, config.SingleBlockstoreID, onBlock(deps, "bucket/prefix-dest"), "main", false)
+		require.NoError(t, err)
+		objStat := uploadContent(t, repo, "alt", srcPath)
+
+		copyResp, err := clt.CopyObjectWithResponse(ctx, destRepo, "main", &apigen.CopyObjectParams{
+			DestPath: destPath,
+		}, apigen.CopyObjectJSONRequestBody{
+			SrcPath:       srcPath,
+			SrcRef:        apiutil.Ptr("alt"),
+			SrcRepository: apiutil.Ptr(repo),
+		})
+		verifyResponseOK(t, copyResp, err)
+
+		// data managed by the source repository is copied to the destination repository
+		copyStat := copyResp.JSON201
+		require.NotNil(t, copyStat)
+		require.NotEqual(t, objStat.PhysicalAddress, copyStat.PhysicalAddress)
+		require.True(t, strings.HasPrefix(copyStat.PhysicalAddress, onBlock(deps, "bucket/prefix-dest/")), "physical address %s", copyStat.PhysicalAddress)
+		require.Equal(t, objStat.Checksum, copyStat.Checksum)
+
+		getResp, err := clt.GetObjectWithResponse(ctx, destRepo, "main", &apigen.GetObjectParams{Path: destPath})
+		verifyResponseOK(t, getResp, err)
+		require.Equal(t, "hello world this is my awesome content", string(getResp.Body))
+
+		// src_ref is required
+		resp, err := clt.CopyObjectWithResponse(ctx, destRepo, "main", &apigen.CopyObjectParams{
+			DestPath: destPath,
+		}, apigen.CopyObjectJSONRequestBody{
+			SrcPath:       srcPath,
+			SrcRepository: apiutil.Ptr(repo),
+		})
+		require.NoError(t, err)
+		require.NotNil(t, resp.JSON400)
+	})
+
+	t.Run("other_repository_external_data", func(t *testing.T) {
+		const (
+			srcPath  = "external/obj"
+			destPath = "foo/bar-external"
+		)
+		destRepo := testUniqueRepoName()
+		_, err := deps.catalog.CreateRepository(ctx, destRepo, config.SingleBlockstoreID, onBlock(deps, "bucket/prefix-external"), "main", false)
+		require.NoError(t, err)
+		physicalAddress := onBlock(deps, "another-bucket/imported/obj")
+		err = deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{
+			Path:            srcPath,
+			PhysicalAddress: physicalAddress,
+			AddressType:     catalog.AddressTypeFull,
+			CreationDate:    time.Now(),
+			Size:            3,
+			Checksum:        "cafe",
+		})
+		require.NoError(t, err)
+
+		copyResp, err := clt.CopyObjectWithResponse(ctx, destRepo, "main", &apigen.CopyObjectParams{
+			DestPath: destPath,
+		}, apigen.CopyObjectJSONRequestBody{
+			SrcPath:       srcPath,
+			SrcRef:        apiutil.Ptr("main"),
+			SrcRepository: apiutil.Ptr(repo),
+		})
+		verifyResponseOK(t, copyResp, err)
+
+		// data outside both repositories is linked, not copied
+		require.NotNil(t, copyResp.JSON201)
+		require.Equal(t, physicalAddress, copyResp.JSON201.PhysicalAddress)
+		require.Equal(t, "cafe", copyResp.JSON201.Checksum)
+	})
+
 	t.Run("not_found", func(t *testing.T) {
 		resp, err := clt.CopyObjectWithResponse(ctx, repo, "main", &apigen.CopyObjectParams{
 			DestPath: "bar/foo",
@@ -5450,6 +5863,223 @@ func TestController_GarbageCollectionRules(t *testing.T) {
 	})
 }
 
+func TestController_LifecycleRules(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	testutil.MustDo(t, "create repository", err)
+
+	t.Run("set get delete", func(t *testing.T) {
+		getResp, err := clt.GetLifecycleRulesWithResponse(ctx, repo)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode())
+		require.Empty(t, getResp.JSON200.Rules)
+
+		setResp, err := clt.SetLifecycleRulesWithResponse(ctx, repo, apigen.SetLifecycleRulesJSONRequestBody{
+			Rules: []apigen.LifecycleRule{{Id: "tmp", Prefix: swag.String("tmp/"), Enabled: true, UncommittedExpirationHours: swag.Int(1)}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+
+		getResp, err = clt.GetLifecycleRulesWithResponse(ctx, repo)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode())
+		require.Len(t, getResp.JSON200.Rules, 1)
+		require.Equal(t, "tmp", getResp.JSON200.Rules[0].Id)
+		require.Equal(t, "tmp/", swag.StringValue(getResp.JSON200.Rules[0].Prefix))
+		require.Equal(t, 1, swag.IntValue(getResp.JSON200.Rules[0].UncommittedExpirationHours))
+
+		deleteResp, err := clt.DeleteLifecycleRulesWithResponse(ctx, repo)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, deleteResp.StatusCode())
+
+		getResp, err = clt.GetLifecycleRulesWithResponse(ctx, repo)
+		require.NoError(t, err)
+		require.Empty(t, getResp.JSON200.Rules)
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		resp, err := clt.SetLifecycleRulesWithResponse(ctx, repo, apigen.SetLifecycleRulesJSONRequestBody{
+			Rules: []apigen.LifecycleRule{{Id: "no-expiration", Enabled: true}},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("run", func(t *testing.T) {
+		old := time.Now().AddDate(0, 0, -10)
+		for _, p := range []string{"logs/old", "data/old"} {
+			testutil.MustDo(t, "create entry "+p, deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: p, PhysicalAddress: onBlock(deps, p), CreationDate: old, Size: 1, Checksum: "cksum"}))
+		}
+		testutil.MustDo(t, "create entry logs/new", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "logs/new", PhysicalAddress: onBlock(deps, "logs/new"), CreationDate: time.Now(), Size: 1, Checksum: "cksum"}))
+		_, err := deps.catalog.Commit(ctx, repo, "main", "logs", "tester", nil, nil, nil, false)
+		testutil.MustDo(t, "commit", err)
+		testutil.MustDo(t, "create entry tmp/old", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "tmp/old", PhysicalAddress: onBlock(deps, "tmp/old"), CreationDate: old, Size: 1, Checksum: "cksum"}))
+
+		setResp, err := clt.SetLifecycleRulesWithResponse(ctx, repo, apigen.SetLifecycleRulesJSONRequestBody{
+			Rules: []apigen.LifecycleRule{
+				{Id: "tmp", Prefix: swag.String("tmp/"), Enabled: true, UncommittedExpirationHours: swag.Int(1)},
+				{Id: "logs", Prefix: swag.String("logs/"), BranchPattern: swag.String("main"), Enabled: true, CommittedExpirationDays: swag.Int(7)},
+			},
+		})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+
+		dryRunResp, err := clt.RunLifecycleWithResponse(ctx, repo, &apigen.RunLifecycleParams{DryRun: swag.Bool(true)})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, dryRunResp.StatusCode())
+		require.True(t, dryRunResp.JSON200.DryRun)
+		require.Len(t, dryRunResp.JSON200.Branches, 1)
+		dryRunBranch := dryRunResp.JSON200.Branches[0]
+		require.Equal(t, []string{"tmp/old"}, dryRunBranch.ExpiredUncommitted)
+		require.Equal(t, []string{"logs/old"}, dryRunBranch.ExpiredCommitted)
+		require.Nil(t, dryRunBranch.CommitId)
+		_, err = deps.catalog.GetEntry(ctx, repo, "main", "tmp/old", catalog.GetEntryParams{})
+		require.NoError(t, err, "dry run should not expire objects")
+
+		runResp, err := clt.RunLifecycleWithResponse(ctx, repo, &apigen.RunLifecycleParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, runResp.StatusCode())
+		require.Len(t, runResp.JSON200.Branches, 1)
+		branch := runResp.JSON200.Branches[0]
+		require.Nil(t, branch.Error)
+		require.Equal(t, 1, branch.ExpiredUncommittedCount)
+		require.Equal(t, 1, branch.ExpiredCommittedCount)
+		require.NotNil(t, branch.CommitId)
+
+		for _, p := range []string{"tmp/old", "logs/old"} {
+			_, err = deps.catalog.GetEntry(ctx, repo, "main", p, catalog.GetEntryParams{})
+			require.ErrorIs(t, err, graveler.ErrNotFound, p)
+		}
+		for _, p := range []string{"logs/new", "data/old"} {
+			_, err = deps.catalog.GetEntry(ctx, repo, "main", p, catalog.GetEntryParams{})
+			require.NoError(t, err, p)
+		}
+		commit, err := deps.catalog.GetCommit(ctx, repo, *branch.CommitId)
+		require.NoError(t, err)
+		require.Equal(t, catalog.LifecycleCommitter, commit.Committer)
+		require.Equal(t, "logs", commit.Metadata[catalog.LifecycleRulesMetadataKey])
+	})
+
+	t.Run("run skips dirty branch", func(t *testing.T) {
+		testutil.MustDo(t, "create entry logs/staged", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "logs/staged", PhysicalAddress: onBlock(deps, "logs/staged"), CreationDate: time.Now(), Size: 1, Checksum: "cksum"}))
+		resp, err := clt.RunLifecycleWithResponse(ctx, repo, &apigen.RunLifecycleParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, resp.StatusCode())
+		require.Len(t, resp.JSON200.Branches, 1)
+		require.NotNil(t, resp.JSON200.Branches[0].Skipped)
+	})
+}
+
+func TestController_ObjectLock(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	testutil.MustDo(t, "create repository", err)
+	for _, p := range []string{"data/locked", "data/other"} {
+		testutil.MustDo(t, "create entry "+p, deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: p, PhysicalAddress: onBlock(deps, p), CreationDate: time.Now(), Size: 1, Checksum: "cksum"}))
+	}
+	_, err = deps.catalog.Commit(ctx, repo, "main", "data", "tester", nil, nil, nil, false)
+	testutil.MustDo(t, "commit", err)
+	const lockedPath = "data/locked"
+
+	t.Run("not enabled", func(t *testing.T) {
+		resp, err := clt.SetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.SetObjectLegalHoldParams{Path: lockedPath}, apigen.SetObjectLegalHoldJSONRequestBody{Status: true})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("configuration", func(t *testing.T) {
+		setResp, err := clt.SetObjectLockConfigurationWithResponse(ctx, repo, apigen.SetObjectLockConfigurationJSONRequestBody{Enabled: true})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+		getResp, err := clt.GetObjectLockConfigurationWithResponse(ctx, repo)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode())
+		require.True(t, getResp.JSON200.Enabled)
+
+		setResp, err = clt.SetObjectLockConfigurationWithResponse(ctx, repo, apigen.SetObjectLockConfigurationJSONRequestBody{Enabled: false})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, setResp.StatusCode())
+	})
+
+	t.Run("uncommitted", func(t *testing.T) {
+		testutil.MustDo(t, "create entry data/staged", deps.catalog.CreateEntry(ctx, repo, "main", catalog.DBEntry{Path: "data/staged", PhysicalAddress: onBlock(deps, "data/staged"), CreationDate: time.Now(), Size: 1, Checksum: "cksum"}))
+		resp, err := clt.SetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.SetObjectLegalHoldParams{Path: "data/staged"}, apigen.SetObjectLegalHoldJSONRequestBody{Status: true})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("legal hold", func(t *testing.T) {
+		getResp, err := clt.GetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.GetObjectLegalHoldParams{Path: lockedPath})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode())
+		require.False(t, getResp.JSON200.Status)
+
+		setResp, err := clt.SetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.SetObjectLegalHoldParams{Path: lockedPath}, apigen.SetObjectLegalHoldJSONRequestBody{Status: true})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+		getResp, err = clt.GetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.GetObjectLegalHoldParams{Path: lockedPath})
+		require.NoError(t, err)
+		require.True(t, getResp.JSON200.Status)
+
+		deleteResp, err := clt.DeleteObjectWithResponse(ctx, repo, "main", &apigen.DeleteObjectParams{Path: lockedPath})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, deleteResp.StatusCode())
+		deleteResp, err = clt.DeleteObjectWithResponse(ctx, repo, "main", &apigen.DeleteObjectParams{Path: "data/other"})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, deleteResp.StatusCode())
+
+		setResp, err = clt.SetObjectLegalHoldWithResponse(ctx, repo, "main", &apigen.SetObjectLegalHoldParams{Path: lockedPath}, apigen.SetObjectLegalHoldJSONRequestBody{Status: false})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+	})
+
+	t.Run("retention", func(t *testing.T) {
+		_, err := deps.catalog.CreateBranch(ctx, repo, "retained", "main")
+		testutil.MustDo(t, "create branch", err)
+		params := &apigen.GetObjectRetentionParams{Path: lockedPath}
+		getResp, err := clt.GetObjectRetentionWithResponse(ctx, repo, "retained", params)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, getResp.StatusCode())
+
+		day := time.Now().Add(24 * time.Hour).Unix()
+		setResp, err := clt.SetObjectRetentionWithResponse(ctx, repo, "retained", &apigen.SetObjectRetentionParams{Path: lockedPath}, apigen.SetObjectRetentionJSONRequestBody{Mode: "GOVERNANCE", RetainUntil: day})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+		getResp, err = clt.GetObjectRetentionWithResponse(ctx, repo, "retained", params)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusOK, getResp.StatusCode())
+		require.Equal(t, "GOVERNANCE", getResp.JSON200.Mode)
+		require.Equal(t, day, getResp.JSON200.RetainUntil)
+
+		deleteBranchResp, err := clt.DeleteBranchWithResponse(ctx, repo, "retained", &apigen.DeleteBranchParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, deleteBranchResp.StatusCode())
+
+		hour := time.Now().Add(time.Hour).Unix()
+		setResp, err = clt.SetObjectRetentionWithResponse(ctx, repo, "retained", &apigen.SetObjectRetentionParams{Path: lockedPath}, apigen.SetObjectRetentionJSONRequestBody{Mode: "GOVERNANCE", RetainUntil: hour})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, setResp.StatusCode())
+		setResp, err = clt.SetObjectRetentionWithResponse(ctx, repo, "retained", &apigen.SetObjectRetentionParams{Path: lockedPath, BypassGovernance: swag.Bool(true)}, apigen.SetObjectRetentionJSONRequestBody{Mode: "GOVERNANCE", RetainUntil: hour})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, setResp.StatusCode())
+
+		setResp, err = clt.SetObjectRetentionWithResponse(ctx, repo, "retained", &apigen.SetObjectRetentionParams{Path: lockedPath}, apigen.SetObjectRetentionJSONRequestBody{Mode: "UNKNOWN", RetainUntil: hour})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, setResp.StatusCode())
+
+		removeResp, err := clt.DeleteObjectRetentionWithResponse(ctx, repo, "retained", &apigen.DeleteObjectRetentionParams{Path: lockedPath})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, removeResp.StatusCode())
+		deleteBranchResp, err = clt.DeleteBranchWithResponse(ctx, repo, "retained", &apigen.DeleteBranchParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, deleteBranchResp.StatusCode())
+	})
+}
+
 func TestController_DumpRestoreRepository(t *testing.T) {
 	clt, deps := setupClientWithAdmin(t)
 	ctx := context.Background()
@@ -5810,7 +6440,7 @@ func TestCheckPermissions_UnpermittedRequests(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			perm := &auth.MissingPermissions{}
-			_ = auth.CheckPermissions(ctx, tc.node, tc.username, tc.policies, perm)
+			_ = auth.CheckPermissions(ctx, tc.node, tc.username, tc.policies, nil, perm)
 			require.Equal(t, tc.expected, perm.String())
 		})
 	}
@@ -5999,12 +6629,61 @@ func TestCheckPermissions_multipleResources(t *testing.T) {
 	for _, tc := range testCases {
 		t.Run(tc.name, func(t *testing.T) {
 			perm := &auth.MissingPermissions{}
-			result := auth.CheckPermissions(ctx, tc.node, tc.username, tc.policies, perm)
+			result := auth.CheckPermissions(ctx, tc.node, tc.username, tc.policies, nil, perm)
 			require.Equal(t, tc.expected, result)
 		})
 	}
 }
 
+func TestCheckPermissions_LifecycleRules(t *testing.T) {
+	ctx := context.Background()
+	policyOf := func(statements ...model.Statement) []*model.Policy {
+		return []*model.Policy{{DisplayName: "policy", Statement: statements}}
+	}
+	allow := func(resource string, actions ...string) model.Statement {
+		return model.Statement{Effect: model.StatementEffectAllow, Action: actions, Resource: resource}
+	}
+	testCases := []struct {
+		name     string
+		policies []*model.Policy
+		expected auth.CheckResult
+	}{
+		{
+			name:     "retention only",
+			policies: policyOf(allow("*", "retention:*")),
+			expected: auth.CheckNeutral,
+		},
+		{
+			name:     "retention and delete",
+			policies: policyOf(allow("*", "retention:*", "fs:DeleteObject")),
+			expected: auth.CheckNeutral,
+		},
+		{
+			name:     "commit on one branch",
+			policies: policyOf(allow("*", "retention:*", "fs:DeleteObject"), allow(permissions.BranchArn("repo1", "main"), "fs:CreateCommit")),
+			expected: auth.CheckNeutral,
+		},
+		{
+			name:     "retention delete and commit",
+			policies: policyOf(allow("*", "retention:*", "fs:DeleteObject", "fs:CreateCommit")),
+			expected: auth.CheckAllow,
+		},
+		{
+			name:     "repository scoped",
+			policies: policyOf(allow(permissions.RepoArn("repo1")+"*", "retention:SetLifecycleRules", "fs:*")),
+			expected: auth.CheckAllow,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			result := auth.CheckPermissions(ctx, permissions.LifecycleRulesNode("repo1"), "user1", tc.policies, nil, &auth.MissingPermissions{})
+			if result != tc.expected {
+				t.Errorf("CheckPermissions() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
+
 func TestController_CreatePullRequest(t *testing.T) {
 	clt, deps := setupClientWithAdmin(t)
 	ctx := context.Background()
@@ -6594,6 +7273,226 @@ func TestController_MergePullRequest(t *testing.T) {
 	})
 }
 
+func TestController_PullRequestReviews(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	require.NoError(t, err)
+
+	protectResp, err := clt.SetBranchProtectionRulesWithResponse(ctx, repo, &apigen.SetBranchProtectionRulesParams{}, []apigen.BranchProtectionRule{
+		{Pattern: "main", RequiredApprovals: apiutil.Ptr(1)},
+	})
+	verifyResponseOK(t, protectResp, err)
+	rulesResp, err := clt.GetBranchProtectionRulesWithResponse(ctx, repo)
+	verifyResponseOK(t, rulesResp, err)
+	require.Equal(t, []apigen.BranchProtectionRule{{Pattern: "main", RequiredApprovals: apiutil.Ptr(1)}}, *rulesResp.JSON200)
+
+	_, err = deps.catalog.CreateBranch(ctx, repo, "feature", "main")
+	require.NoError(t, err)
+	commitFeature := func(path string) {
+		testutil.Must(t, deps.catalog.CreateEntry(ctx, repo, "feature", catalog.DBEntry{Path: path, PhysicalAddress: path, CreationDate: time.Now(), Size: 1, Checksum: path}))
+		_, err := deps.catalog.Commit(ctx, repo, "feature", "add "+path, DefaultUserID, nil, nil, nil, false)
+		testutil.Must(t, err)
+	}
+	commitFeature("a")
+	featureHead := func() string {
+		commitID, err := deps.catalog.GetBranchReference(ctx, repo, "feature")
+		testutil.Must(t, err)
+		return commitID
+	}
+
+	prResp, err := clt.CreatePullRequestWithResponse(ctx, repo, apigen.CreatePullRequestJSONRequestBody{
+		Title:             "feature",
+		SourceBranch:      "feature",
+		DestinationBranch: "main",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, prResp.JSON201)
+	prID := prResp.JSON201.Id
+
+	t.Run("direct merge", func(t *testing.T) {
+		resp, err := clt.MergeIntoBranchWithResponse(ctx, repo, "feature", "main", apigen.MergeIntoBranchJSONRequestBody{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+
+	t.Run("merge without approval", func(t *testing.T) {
+		resp, err := clt.MergePullRequestWithResponse(ctx, repo, prID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+
+	t.Run("author approval", func(t *testing.T) {
+		resp, err := clt.CreatePullRequestReviewWithResponse(ctx, repo, prID, apigen.CreatePullRequestReviewJSONRequestBody{State: "approved", CommitId: featureHead()})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("invalid state", func(t *testing.T) {
+		resp, err := clt.CreatePullRequestReviewWithResponse(ctx, repo, prID, apigen.CreatePullRequestReviewJSONRequestBody{State: "rejected", CommitId: featureHead()})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("comment", func(t *testing.T) {
+		resp, err := clt.CreatePullRequestReviewWithResponse(ctx, repo, prID, apigen.CreatePullRequestReviewJSONRequestBody{State: "commented", Comment: swag.String("looks good"), CommitId: featureHead()})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNoContent, resp.StatusCode())
+	})
+
+	t.Run("stale approval", func(t *testing.T) {
+		testutil.Must(t, deps.catalog.ReviewPullRequest(ctx, repo, prID, "reviewer", "approved", "", featureHead()))
+		commitFeature("b")
+		resp, err := clt.MergePullRequestWithResponse(ctx, repo, prID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+
+	t.Run("approval of moved source", func(t *testing.T) {
+		reviewed := featureHead()
+		commitFeature("c")
+		err := deps.catalog.ReviewPullRequest(ctx, repo, prID, "reviewer", "approved", "", reviewed)
+		require.ErrorIs(t, err, graveler.ErrPullRequestSourceMoved)
+		resp, err := clt.CreatePullRequestReviewWithResponse(ctx, repo, prID, apigen.CreatePullRequestReviewJSONRequestBody{State: "commented", Comment: swag.String("old"), CommitId: reviewed})
+		require.NoError(t, err)
+		require.NotNil(t, resp.JSON412, resp.Status())
+	})
+
+	t.Run("changes requested", func(t *testing.T) {
+		testutil.Must(t, deps.catalog.ReviewPullRequest(ctx, repo, prID, "reviewer", "approved", "", featureHead()))
+		testutil.Must(t, deps.catalog.ReviewPullRequest(ctx, repo, prID, "other-reviewer", "changes_requested", "missing c", featureHead()))
+		resp, err := clt.MergePullRequestWithResponse(ctx, repo, prID)
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, resp.StatusCode())
+	})
+
+	t.Run("approved", func(t *testing.T) {
+		testutil.Must(t, deps.catalog.ReviewPullRequest(ctx, repo, prID, "other-reviewer", "approved", "", featureHead()))
+
+		listResp, err := clt.ListPullRequestReviewsWithResponse(ctx, repo, prID)
+		verifyResponseOK(t, listResp, err)
+		states := make([]string, 0, len(listResp.JSON200.Results))
+		for _, review := range listResp.JSON200.Results {
+			states = append(states, review.Reviewer+":"+review.State)
+		}
+		require.Equal(t, []string{"admin:commented", "reviewer:approved", "reviewer:approved", "other-reviewer:changes_requested", "other-reviewer:approved"}, states)
+
+		resp, err := clt.MergePullRequestWithResponse(ctx, repo, prID)
+		verifyResponseOK(t, resp, err)
+	})
+
+	t.Run("review merged", func(t *testing.T) {
+		resp, err := clt.CreatePullRequestReviewWithResponse(ctx, repo, prID, apigen.CreatePullRequestReviewJSONRequestBody{State: "commented", Comment: swag.String("late"), CommitId: featureHead()})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+}
+
+func TestController_PullRequestComments(t *testing.T) {
+	clt, deps := setupClientWithAdmin(t)
+	ctx := context.Background()
+	repo := testUniqueRepoName()
+	_, err := deps.catalog.CreateRepository(ctx, repo, config.SingleBlockstoreID, onBlock(deps, repo), "main", false)
+	require.NoError(t, err)
+	_, err = deps.catalog.CreateBranch(ctx, repo, "feature", "main")
+	require.NoError(t, err)
+	testutil.Must(t, deps.catalog.CreateEntry(ctx, repo, "feature", catalog.DBEntry{Path: "a", PhysicalAddress: "a", CreationDate: time.Now(), Size: 1, Checksum: "a"}))
+	_, err = deps.catalog.Commit(ctx, repo, "feature", "add a", DefaultUserID, nil, nil, nil, false)
+	require.NoError(t, err)
+
+	prResp, err := clt.CreatePullRequestWithResponse(ctx, repo, apigen.CreatePullRequestJSONRequestBody{
+		Title:             "feature",
+		SourceBranch:      "feature",
+		DestinationBranch: "main",
+	})
+	require.NoError(t, err)
+	require.NotNil(t, prResp.JSON201)
+	prID := prResp.JSON201.Id
+
+	threadResp, err := clt.CreatePullRequestCommentWithResponse(ctx, repo, prID, apigen.CreatePullRequestCommentJSONRequestBody{Body: "why a?", Path: swag.String("a")})
+	require.NoError(t, err)
+	require.NotNil(t, threadResp.JSON201)
+	thread := threadResp.JSON201
+	require.Equal(t, "admin", thread.Author)
+	require.Equal(t, "a", swag.StringValue(thread.Path))
+
+	t.Run("empty body", func(t *testing.T) {
+		resp, err := clt.CreatePullRequestCommentWithResponse(ctx, repo, prID, apigen.CreatePullRequestCommentJSONRequestBody{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resp.StatusCode())
+	})
+
+	t.Run("reply", func(t *testing.T) {
+		replyID, err := deps.catalog.CreatePullRequestComment(ctx, repo, prID, "reviewer", "because", "", thread.Id)
+		require.NoError(t, err)
+		// a reply to a reply joins the thread
+		resp, err := clt.CreatePullRequestCommentWithResponse(ctx, repo, prID, apigen.CreatePullRequestCommentJSONRequestBody{Body: "ok", ReplyTo: swag.String(replyID)})
+		require.NoError(t, err)
+		require.NotNil(t, resp.JSON201)
+		require.Equal(t, thread.Id, swag.StringValue(resp.JSON201.ReplyTo))
+		require.Equal(t, "a", swag.StringValue(resp.JSON201.Path))
+
+		resolveResp, err := clt.UpdatePullRequestCommentWithResponse(ctx, repo, prID, replyID, apigen.UpdatePullRequestCommentJSONRequestBody{Resolved: swag.Bool(true)})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusBadRequest, resolveResp.StatusCode())
+
+		editResp, err := clt.UpdatePullRequestCommentWithResponse(ctx, repo, prID, replyID, apigen.UpdatePullRequestCommentJSONRequestBody{Body: swag.String("edited")})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusForbidden, editResp.StatusCode())
+	})
+
+	t.Run("edit and resolve", func(t *testing.T) {
+		resp, err := clt.UpdatePullRequestCommentWithResponse(ctx, repo, prID, thread.Id, apigen.UpdatePullRequestCommentJSONRequestBody{
+			Body:     swag.String("why is a here?"),
+			Resolved: swag.Bool(true),
+		})
+		verifyResponseOK(t, resp, err)
+		require.Equal(t, "why is a here?", resp.JSON200.Body)
+		require.NotNil(t, resp.JSON200.UpdateDate)
+		require.True(t, resp.JSON200.Resolved)
+		require.Equal(t, "admin", swag.StringValue(resp.JSON200.ResolvedBy))
+	})
+
+	t.Run("merged", func(t *testing.T) {
+		mergeResp, err := clt.MergePullRequestWithResponse(ctx, repo, prID)
+		verifyResponseOK(t, mergeResp, err)
+		commitResp, err := clt.GetCommitWithResponse(ctx, repo, mergeResp.JSON200.Reference)
+		verifyResponseOK(t, commitResp, err)
+		require.Equal(t, prID, commitResp.JSON200.Metadata.AdditionalProperties[graveler.PullRequestMetadataKey])
+
+		resp, err := clt.CreatePullRequestCommentWithResponse(ctx, repo, prID, apigen.CreatePullRequestCommentJSONRequestBody{Body: "merged"})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusCreated, resp.StatusCode())
+	})
+
+	t.Run("list", func(t *testing.T) {
+		var bodies []string
+		var after *apigen.PaginationAfter
+		for {
+			resp, err := clt.ListPullRequestCommentsWithResponse(ctx, repo, prID, &apigen.ListPullRequestCommentsParams{
+				After:  after,
+				Amount: apiutil.Ptr(apigen.PaginationAmount(2)),
+			})
+			verifyResponseOK(t, resp, err)
+			for _, comment := range resp.JSON200.Results {
+				bodies = append(bodies, comment.Body)
+			}
+			if !resp.JSON200.Pagination.HasMore {
+				break
+			}
+			after = apiutil.Ptr(apigen.PaginationAfter(resp.JSON200.Pagination.NextOffset))
+		}
+		require.Equal(t, []string{"why is a here?", "because", "ok", "merged"}, bodies)
+	})
+
+	t.Run("unknown pull request", func(t *testing.T) {
+		resp, err := clt.ListPullRequestCommentsWithResponse(ctx, repo, graveler.NewRunID(), &apigen.ListPullRequestCommentsParams{})
+		require.NoError(t, err)
+		require.Equal(t, http.StatusNotFound, resp.StatusCode())
+	})
+}
+
 // pollRestoreStatus polls the restore status endpoint until the restore is complete or times out.
 // test will fail in case of error.
 // will return nil in case of timeout.
@@ -22,6 +22,15 @@ type Authenticator interface {
 	AuthenticateUser(ctx context.Context, username, password string) (string, error)
 }
 
+// CredentialAuthenticator is an Authenticator that also returns the credentials of the access keys it authenticates,
+// so callers need not fetch them again.
+type CredentialAuthenticator interface {
+	Authenticator
+	// AuthenticateCredential authenticates like AuthenticateUser. It also returns the credentials of the access key,
+	// nil if it authenticated the user other than by stored access key credentials.
+	AuthenticateCredential(ctx context.Context, accessKeyID, secretAccessKey string) (string, *model.Credential, error)
+}
+
 // Credentialler fetches S3-style credentials for access keys.
 type Credentialler interface {
 	GetCredentials(ctx context.Context, accessKeyID string) (*model.Credential, error)
@@ -32,18 +41,32 @@ type Credentialler interface {
 type ChainAuthenticator []Authenticator
 
 func (ca ChainAuthenticator) AuthenticateUser(ctx context.Context, username, password string) (string, error) {
+	id, _, err := ca.AuthenticateCredential(ctx, username, password)
+	return id, err
+}
+
+func (ca ChainAuthenticator) AuthenticateCredential(ctx context.Context, username, password string) (string, *model.Credential, error) {
 	var merr *multierror.Error
 	logger := logging.FromContext(ctx).WithField("username", username)
 	for _, a := range ca {
-		id, err := a.AuthenticateUser(ctx, username, password)
+		var (
+			id   string
+			cred *model.Credential
+			err  error
+		)
+		if credAuthenticator, ok := a.(CredentialAuthenticator); ok {
+			id, cred, err = credAuthenticator.AuthenticateCredential(ctx, username, password)
+		} else {
+			id, err = a.AuthenticateUser(ctx, username, password)
+		}
 		if err == nil {
-			return id, nil
+			return id, cred, nil
 		}
 		// TODO(ariels): Add authenticator ID here.
 		merr = multierror.Append(merr, fmt.Errorf("%s: %w", a, err))
 	}
 	logger.WithError(merr).Info("Failed to authenticate user")
-	return InvalidUserID, merr
+	return InvalidUserID, nil, merr
 }
 
 // BuiltinAuthenticator authenticates users by their access key IDs and
@@ -57,15 +80,23 @@ func NewBuiltinAuthenticator(service Service) *BuiltinAuthenticator {
 }
 
 func (ba *BuiltinAuthenticator) AuthenticateUser(ctx context.Context, username, password string) (string, error) {
+	id, _, err := ba.AuthenticateCredential(ctx, username, password)
+	return id, err
+}
+
+func (ba *BuiltinAuthenticator) AuthenticateCredential(ctx context.Context, username, password string) (string, *model.Credential, error) {
 	// Look user up in DB.  username is really the access key ID.
 	cred, err := ba.creds.GetCredentials(ctx, username)
 	if err != nil {
-		return InvalidUserID, err
+		return InvalidUserID, nil, err
 	}
 	if subtle.ConstantTimeCompare([]byte(password), []byte(cred.SecretAccessKey)) != 1 {
-		return InvalidUserID, ErrInvalidSecretAccessKey
+		return InvalidUserID, nil, ErrInvalidSecretAccessKey
+	}
+	if cred.IsExpired(time.Now()) {
+		return InvalidUserID, nil, ErrExpiredAccessKey
 	}
-	return cred.Username, nil
+	return cred.Username, cred, nil
 }
 
 func (ba *BuiltinAuthenticator) String() string {
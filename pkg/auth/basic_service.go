@@ -224,6 +224,10 @@ func (s *BasicAuthService) CreateCredentials(ctx context.Context, username strin
 	return s.AddCredentials(ctx, user.Username, accessKeyID, secretAccessKey)
 }
 
+func (s *BasicAuthService) CreateLimitedCredentials(_ context.Context, _ string, _ model.CredentialLimits) (*model.Credential, error) {
+	return nil, ErrNotImplemented
+}
+
 func (s *BasicAuthService) AddCredentials(ctx context.Context, username, accessKeyID, secretAccessKey string) (*model.Credential, error) {
 	_, err := s.GetUser(ctx, username)
 	if err != nil {
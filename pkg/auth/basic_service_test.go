@@ -3,6 +3,7 @@ package auth_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 	"github.com/treeverse/lakefs/pkg/auth"
@@ -274,3 +275,26 @@ func createOldCreds(t *testing.T, ctx context.Context, store kv.Store, s *auth.B
 	require.NoError(t, store.Set(ctx, []byte(model.PartitionKey), model.CredentialPath(username, creds.AccessKeyId), credsData))
 	return creds
 }
+
+func TestBasicAuthService_WatchCache(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kvStore := kvtest.GetStore(ctx, t)
+	s := auth.NewBasicAuthService(kvStore, crypt.NewSecretStore([]byte(secret)), authparams.ServiceCache{
+		Enabled: true,
+		Size:    100,
+		TTL:     time.Hour,
+	}, logging.ContextUnavailable())
+	require.NoError(t, auth.WatchCache(ctx, kvStore, auth.BasicPartitionKey, s.Cache()))
+
+	username := "testUser"
+	_, err := s.CreateUser(ctx, &model.User{Username: username})
+	require.NoError(t, err)
+	_, err = s.GetUser(ctx, username)
+	require.NoError(t, err)
+
+	// another lakeFS instance deletes the user: the cached user is purged
+	require.NoError(t, kvStore.Delete(ctx, []byte(auth.BasicPartitionKey), model.UserPath(auth.SuperAdminKey)))
+	_, err = s.GetUser(ctx, username)
+	require.ErrorIs(t, err, auth.ErrNotFound)
+}
@@ -1,10 +1,14 @@
 package auth
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/treeverse/lakefs/pkg/auth/model"
 	"github.com/treeverse/lakefs/pkg/cache"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
 )
 
 type (
@@ -26,6 +30,8 @@ type Cache interface {
 	GetUser(key UserKey, setFn UserSetFn) (*model.User, error)
 	GetUserPolicies(userID string, setFn UserPoliciesSetFn) ([]*model.Policy, error)
 	GetExternalPrincipal(key string, setFn ExternalPrincipalFn) (*model.ExternalPrincipal, error)
+	// Purge removes all cached entities
+	Purge()
 }
 
 type LRUCache struct {
@@ -77,6 +83,27 @@ func (c *LRUCache) GetExternalPrincipal(key string, setFn ExternalPrincipalFn) (
 	return v.(*model.ExternalPrincipal), nil
 }
 
+func (c *LRUCache) Purge() {
+	c.credentialsCache.Purge()
+	c.userCache.Purge()
+	c.policyCache.Purge()
+	c.externalPrincipalCache.Purge()
+}
+
+// WatchCache purges c whenever a key of partitionKey in store changes, until ctx is done.
+// Cached entities depend on each other (users, groups, policies and credentials), so any
+// change purges them all.  If store cannot watch, cached entities expire as usual.
+func WatchCache(ctx context.Context, store kv.Store, partitionKey string, c Cache) error {
+	err := kv.Watch(ctx, store, []byte(partitionKey), nil, func(kv.WatchEvent) {
+		c.Purge()
+	})
+	if errors.Is(err, kv.ErrWatchNotSupported) {
+		logging.FromContext(ctx).Debug("KV store does not watch changes, cached auth entities will expire")
+		return nil
+	}
+	return err
+}
+
 // DummyCache dummy cache that doesn't cache
 type DummyCache struct{}
 
@@ -95,3 +122,5 @@ func (d *DummyCache) GetUserPolicies(_ string, setFn UserPoliciesSetFn) ([]*mode
 func (d *DummyCache) GetExternalPrincipal(_ string, setFn ExternalPrincipalFn) (*model.ExternalPrincipal, error) {
 	return setFn()
 }
+
+func (d *DummyCache) Purge() {}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"cmp"
+	"fmt"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/auth/model"
+	"github.com/treeverse/lakefs/pkg/auth/wildcard"
+)
+
+// RequestContext holds the attributes of a request that authorizing it depends on, beyond the policies of the user
+type RequestContext struct {
+	// Time of the request, the current time if zero
+	Time time.Time
+	// SourceIP is the IP address the request came from
+	SourceIP string
+	// Branch the request operates on, if any
+	Branch string
+	// ResolveBranch, if set, returns the branch the request operates on in place of Branch, or the empty string if
+	// it operates on no branch. It is called only for conditions on the branch.
+	ResolveBranch func() string
+	// ObjectMetadata is the user metadata of the object the request writes, if any
+	ObjectMetadata map[string]string
+	// Claims of the identity token the caller signed in with, if any
+	Claims map[string][]string
+	// CredentialPolicy is the inline policy of the access key the caller authenticated with, if any. Only
+	// permissions that both the policies of the user and this policy allow are allowed.
+	CredentialPolicy *model.Policy
+}
+
+// NewRequestContext returns the request context of r, operating on branch and writing an object with objectMetadata
+func NewRequestContext(r *http.Request, branch string, objectMetadata map[string]string) *RequestContext {
+	sourceIP, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		sourceIP = r.RemoteAddr
+	}
+	reqCtx := &RequestContext{
+		Time:           time.Now(),
+		SourceIP:       sourceIP,
+		Branch:         branch,
+		ObjectMetadata: objectMetadata,
+		Claims:         GetClaims(r.Context()),
+	}
+	if cred := GetCredential(r.Context()); cred != nil {
+		reqCtx.CredentialPolicy = cred.InlinePolicy()
+	}
+	return reqCtx
+}
+
+// credentialPolicy returns the inline policy of the access key of the request context, nil if none
+func (r *RequestContext) credentialPolicy() *model.Policy {
+	if r == nil {
+		return nil
+	}
+	return r.CredentialPolicy
+}
+
+// values returns the values of the request context key
+func (r *RequestContext) values(key string) []string {
+	if r == nil {
+		r = &RequestContext{}
+	}
+	t := r.Time
+	if t.IsZero() {
+		t = time.Now()
+	}
+	t = t.UTC()
+	switch key {
+	case model.ConditionKeyCurrentTime:
+		return []string{t.Format(time.RFC3339)}
+	case model.ConditionKeyCurrentHour:
+		return []string{strconv.Itoa(t.Hour())}
+	case model.ConditionKeyCurrentWeekday:
+		return []string{t.Weekday().String()}
+	case model.ConditionKeySourceIP:
+		return nonEmptyValues(r.SourceIP)
+	case model.ConditionKeyBranch:
+		if r.ResolveBranch != nil {
+			return nonEmptyValues(r.ResolveBranch())
+		}
+		return nonEmptyValues(r.Branch)
+	}
+	if name, ok := strings.CutPrefix(key, model.ConditionKeyObjectMetadataPrefix); ok {
+		for k, v := range r.ObjectMetadata {
+			if strings.EqualFold(k, name) {
+				return []string{v}
+			}
+		}
+		return nil
+	}
+	if name, ok := strings.CutPrefix(key, model.ConditionKeyPrincipalClaimPrefix); ok {
+		return r.Claims[name]
+	}
+	return nil
+}
+
+func nonEmptyValues(v string) []string {
+	if v == "" {
+		return nil
+	}
+	return []string{v}
+}
+
+// conditionMatch returns true if all of condition holds for the request context. If missingMatches, a condition
+// on a key missing from the request context holds, so that a "Deny" statement fails closed on requests that lack it.
+func conditionMatch(condition model.Condition, reqCtx *RequestContext, missingMatches bool) (bool, error) {
+	for operator, keys := range condition {
+		for key, values := range keys {
+			contextValues := reqCtx.values(key)
+			if len(contextValues) == 0 && missingMatches {
+				if _, ok := model.ConditionOperatorType(operator); !ok {
+					return false, fmt.Errorf("%w: condition operator '%s'", model.ErrValidationError, operator)
+				}
+				continue
+			}
+			match, err := conditionOperatorMatch(operator, contextValues, values)
+			if err != nil {
+				return false, err
+			}
+			if !match {
+				return false, nil
+			}
+		}
+	}
+	return true, nil
+}
+
+// conditionOperatorMatch returns true if operator holds for any of the request context values and any of the
+// condition values. Negated operators hold if the positive operator does not, including when the key is missing.
+func conditionOperatorMatch(operator string, contextValues, values []string) (bool, error) {
+	switch operator {
+	case model.ConditionStringEquals:
+		return anyConditionMatch(contextValues, values, stringEquals)
+	case model.ConditionStringNotEquals:
+		return negateConditionMatch(anyConditionMatch(contextValues, values, stringEquals))
+	case model.ConditionStringLike:
+		return anyConditionMatch(contextValues, values, stringLike)
+	case model.ConditionStringNotLike:
+		return negateConditionMatch(anyConditionMatch(contextValues, values, stringLike))
+	case model.ConditionNumericEquals:
+		return anyConditionMatch(contextValues, values, numericCompare(func(c int) bool { return c == 0 }))
+	case model.ConditionNumericLessThan:
+		return anyConditionMatch(contextValues, values, numericCompare(func(c int) bool { return c < 0 }))
+	case model.ConditionNumericLessThanEquals:
+		return anyConditionMatch(contextValues, values, numericCompare(func(c int) bool { return c <= 0 }))
+	case model.ConditionNumericGreaterThan:
+		return anyConditionMatch(contextValues, values, numericCompare(func(c int) bool { return c > 0 }))
+	case model.ConditionNumericGreaterThanEquals:
+		return anyConditionMatch(contextValues, values, numericCompare(func(c int) bool { return c >= 0 }))
+	case model.ConditionDateLessThan:
+		return anyConditionMatch(contextValues, values, dateCompare(func(c int) bool { return c < 0 }))
+	case model.ConditionDateGreaterThan:
+		return anyConditionMatch(contextValues, values, dateCompare(func(c int) bool { return c > 0 }))
+	case model.ConditionIPAddress:
+		return anyConditionMatch(contextValues, values, ipAddressIn)
+	case model.ConditionNotIPAddress:
+		return negateConditionMatch(anyConditionMatch(contextValues, values, ipAddressIn))
+	default:
+		return false, fmt.Errorf("%w: condition operator '%s'", model.ErrValidationError, operator)
+	}
+}
+
+// conditionValueMatch returns true if the request context value matches the condition value. It fails only on
+// an invalid condition value.
+type conditionValueMatch func(contextValue, value string) (bool, error)
+
+func anyConditionMatch(contextValues, values []string, match conditionValueMatch) (bool, error) {
+	for _, value := range values {
+		for _, contextValue := range contextValues {
+			ok, err := match(contextValue, value)
+			if err != nil {
+				return false, err
+			}
+			if ok {
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+func negateConditionMatch(match bool, err error) (bool, error) {
+	if err != nil {
+		return false, err
+	}
+	return !match, nil
+}
+
+func stringEquals(contextValue, value string) (bool, error) {
+	return contextValue == value, nil
+}
+
+func stringLike(contextValue, value string) (bool, error) {
+	return wildcard.Match(value, contextValue), nil
+}
+
+// numericCompare returns a match testing the comparison of the request context value to the condition value
+func numericCompare(test func(int) bool) conditionValueMatch {
+	return func(contextValue, value string) (bool, error) {
+		v, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return false, fmt.Errorf("%w: condition value '%s': %w", model.ErrValidationError, value, err)
+		}
+		c, err := strconv.ParseFloat(contextValue, 64)
+		if err != nil {
+			return false, nil
+		}
+		return test(cmp.Compare(c, v)), nil
+	}
+}
+
+// dateCompare returns a match testing the comparison of the request context time to the condition time
+func dateCompare(test func(int) bool) conditionValueMatch {
+	return func(contextValue, value string) (bool, error) {
+		v, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			return false, fmt.Errorf("%w: condition value '%s': %w", model.ErrValidationError, value, err)
+		}
+		c, err := time.Parse(time.RFC3339, contextValue)
+		if err != nil {
+			return false, nil
+		}
+		return test(c.Compare(v)), nil
+	}
+}
+
+func ipAddressIn(contextValue, value string) (bool, error) {
+	ipNet, err := model.ParseConditionIPNet(value)
+	if err != nil {
+		return false, fmt.Errorf("%w: condition value '%s': %w", model.ErrValidationError, value, err)
+	}
+	ip := net.ParseIP(contextValue)
+	return ip != nil && ipNet.Contains(ip), nil
+}
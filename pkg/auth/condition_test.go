@@ -0,0 +1,399 @@
+package auth_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/auth"
+	"github.com/treeverse/lakefs/pkg/auth/model"
+	"github.com/treeverse/lakefs/pkg/permissions"
+)
+
+func TestCheckPermissions_Conditions(t *testing.T) {
+	ctx := context.Background()
+	node := permissions.Node{
+		Type: permissions.NodeTypeNode,
+		Permission: permissions.Permission{
+			Action:   permissions.WriteObjectAction,
+			Resource: permissions.ObjectArn("repo1", "path/to/object"),
+		},
+	}
+	// Monday, 10:00 UTC
+	requestTime := time.Date(2024, 7, 1, 10, 0, 0, 0, time.UTC)
+	reqCtx := &auth.RequestContext{
+		Time:           requestTime,
+		SourceIP:       "10.0.1.17",
+		Branch:         "dev-feature",
+		ObjectMetadata: map[string]string{"classification": "public"},
+		Claims:         map[string][]string{"groups": {"engineering", "data"}},
+	}
+	allowWrite := model.Statement{
+		Effect:   model.StatementEffectAllow,
+		Action:   []string{"fs:WriteObject"},
+		Resource: "*",
+	}
+	allowWriteWhen := func(condition model.Condition) model.Statement {
+		stmt := allowWrite
+		stmt.Condition = condition
+		return stmt
+	}
+	denyWriteWhen := func(condition model.Condition) model.Statement {
+		return model.Statement{
+			Effect:    model.StatementEffectDeny,
+			Action:    []string{"fs:WriteObject"},
+			Resource:  "*",
+			Condition: condition,
+		}
+	}
+
+	testCases := []struct {
+		name       string
+		statements []model.Statement
+		reqCtx     *auth.RequestContext
+		expected   auth.CheckResult
+	}{
+		{
+			name:       "branch like",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringLike: {model.ConditionKeyBranch: {"dev-*"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name:       "branch not like",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringLike: {model.ConditionKeyBranch: {"prod-*"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckNeutral,
+		},
+		{
+			name:       "missing branch",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringLike: {model.ConditionKeyBranch: {"*"}}})},
+			reqCtx:     &auth.RequestContext{Time: requestTime},
+			expected:   auth.CheckNeutral,
+		},
+		{
+			name:       "no request context",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {"main"}}})},
+			expected:   auth.CheckNeutral,
+		},
+		{
+			name:       "source ip in block",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"10.0.0.0/16"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name:       "source ip address",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"10.0.1.18", "10.0.1.17"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name: "deny outside ip block",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionNotIPAddress: {model.ConditionKeySourceIP: {"192.168.0.0/16"}}}),
+			},
+			reqCtx:   reqCtx,
+			expected: auth.CheckDeny,
+		},
+		{
+			name: "deny outside business hours",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionNumericGreaterThanEquals: {model.ConditionKeyCurrentHour: {"18"}}}),
+				denyWriteWhen(model.Condition{model.ConditionNumericLessThan: {model.ConditionKeyCurrentHour: {"9"}}}),
+				denyWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyCurrentWeekday: {"Saturday", "Sunday"}}}),
+			},
+			reqCtx:   reqCtx,
+			expected: auth.CheckAllow,
+		},
+		{
+			name: "deny after date",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionDateGreaterThan: {model.ConditionKeyCurrentTime: {"2024-06-30T00:00:00Z"}}}),
+			},
+			reqCtx:   reqCtx,
+			expected: auth.CheckDeny,
+		},
+		{
+			name: "deny branch applies to missing branch",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {"main"}}}),
+			},
+			reqCtx:   &auth.RequestContext{Time: requestTime},
+			expected: auth.CheckDeny,
+		},
+		{
+			name: "deny branch applies to ref that is not a branch",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {"main"}}}),
+			},
+			reqCtx:   &auth.RequestContext{Time: requestTime, Branch: "main~1", ResolveBranch: func() string { return "" }},
+			expected: auth.CheckDeny,
+		},
+		{
+			name:       "resolved branch",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringLike: {model.ConditionKeyBranch: {"dev-*"}}})},
+			reqCtx:     &auth.RequestContext{Time: requestTime, ResolveBranch: func() string { return "dev-feature" }},
+			expected:   auth.CheckAllow,
+		},
+		{
+			name: "deny object metadata applies to missing metadata",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyObjectMetadataPrefix + "classification": {"secret"}}}),
+			},
+			reqCtx:   &auth.RequestContext{Time: requestTime, Branch: "main"},
+			expected: auth.CheckDeny,
+		},
+		{
+			name: "deny branch does not apply to other branch",
+			statements: []model.Statement{
+				allowWrite,
+				denyWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {"main"}}}),
+			},
+			reqCtx:   reqCtx,
+			expected: auth.CheckAllow,
+		},
+		{
+			name:       "object metadata",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyObjectMetadataPrefix + "Classification": {"public"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name:       "missing object metadata not equals",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringNotEquals: {model.ConditionKeyObjectMetadataPrefix + "owner": {"finance"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name:       "principal claim any value",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionStringEquals: {model.ConditionKeyPrincipalClaimPrefix + "groups": {"data"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckAllow,
+		},
+		{
+			name: "all conditions must hold",
+			statements: []model.Statement{allowWriteWhen(model.Condition{
+				model.ConditionStringLike: {model.ConditionKeyBranch: {"dev-*"}},
+				model.ConditionIPAddress:  {model.ConditionKeySourceIP: {"192.168.0.0/16"}},
+			})},
+			reqCtx:   reqCtx,
+			expected: auth.CheckNeutral,
+		},
+		{
+			name:       "invalid condition value",
+			statements: []model.Statement{allowWriteWhen(model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"not-an-ip"}}})},
+			reqCtx:     reqCtx,
+			expected:   auth.CheckDeny,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			policies := []*model.Policy{{DisplayName: "policy", Statement: tc.statements}}
+			result := auth.CheckPermissions(ctx, node, "user1", policies, tc.reqCtx, &auth.MissingPermissions{})
+			if result != tc.expected {
+				t.Errorf("CheckPermissions() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestCheckPermissions_CredentialPolicy(t *testing.T) {
+	ctx := context.Background()
+	policies := []*model.Policy{{
+		DisplayName: "FSFullAccess",
+		Statement: []model.Statement{{
+			Effect:   model.StatementEffectAllow,
+			Action:   []string{"fs:*"},
+			Resource: "*",
+		}},
+	}}
+	readOnlyRepo1 := &model.Policy{
+		DisplayName: "AKIAEXAMPLE",
+		Statement: []model.Statement{{
+			Effect:   model.StatementEffectAllow,
+			Action:   []string{"fs:Read*"},
+			Resource: permissions.RepoArn("repo1") + "*",
+		}},
+	}
+	nodeFor := func(action, repository string) permissions.Node {
+		return permissions.Node{
+			Type: permissions.NodeTypeNode,
+			Permission: permissions.Permission{
+				Action:   action,
+				Resource: permissions.ObjectArn(repository, "path/to/object"),
+			},
+		}
+	}
+
+	testCases := []struct {
+		name             string
+		node             permissions.Node
+		credentialPolicy *model.Policy
+		expected         auth.CheckResult
+	}{
+		{
+			name:     "no credential policy",
+			node:     nodeFor(permissions.WriteObjectAction, "repo1"),
+			expected: auth.CheckAllow,
+		},
+		{
+			name:             "allowed by both",
+			node:             nodeFor(permissions.ReadObjectAction, "repo1"),
+			credentialPolicy: readOnlyRepo1,
+			expected:         auth.CheckAllow,
+		},
+		{
+			name:             "action outside credential policy",
+			node:             nodeFor(permissions.WriteObjectAction, "repo1"),
+			credentialPolicy: readOnlyRepo1,
+			expected:         auth.CheckNeutral,
+		},
+		{
+			name:             "resource outside credential policy",
+			node:             nodeFor(permissions.ReadObjectAction, "repo2"),
+			credentialPolicy: readOnlyRepo1,
+			expected:         auth.CheckNeutral,
+		},
+		{
+			name: "denied by credential policy",
+			node: nodeFor(permissions.ReadObjectAction, "repo1"),
+			credentialPolicy: &model.Policy{
+				DisplayName: "AKIAEXAMPLE",
+				Statement: []model.Statement{{
+					Effect:   model.StatementEffectDeny,
+					Action:   []string{"fs:ReadObject"},
+					Resource: "*",
+				}},
+			},
+			expected: auth.CheckDeny,
+		},
+		{
+			name: "credential policy does not extend user policies",
+			node: nodeFor(permissions.CreateUserAction, ""),
+			credentialPolicy: &model.Policy{
+				DisplayName: "AKIAEXAMPLE",
+				Statement: []model.Statement{{
+					Effect:   model.StatementEffectAllow,
+					Action:   []string{"auth:*"},
+					Resource: "*",
+				}},
+			},
+			expected: auth.CheckNeutral,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqCtx := &auth.RequestContext{CredentialPolicy: tc.credentialPolicy}
+			result := auth.CheckPermissions(ctx, tc.node, "user1", policies, reqCtx, &auth.MissingPermissions{})
+			if result != tc.expected {
+				t.Errorf("CheckPermissions() = %v, expected %v", result, tc.expected)
+			}
+		})
+	}
+}
+
+func TestValidateStatementCondition(t *testing.T) {
+	testCases := []struct {
+		name      string
+		condition model.Condition
+		valid     bool
+	}{
+		{name: "empty", condition: nil, valid: true},
+		{name: "string", condition: model.Condition{model.ConditionStringLike: {model.ConditionKeyBranch: {"dev-*"}}}, valid: true},
+		{name: "ip address", condition: model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"10.0.0.1", "10.0.0.0/8", "::1"}}}, valid: true},
+		{name: "date", condition: model.Condition{model.ConditionDateLessThan: {model.ConditionKeyCurrentTime: {"2024-01-01T00:00:00Z"}}}, valid: true},
+		{name: "metadata key", condition: model.Condition{model.ConditionStringEquals: {model.ConditionKeyObjectMetadataPrefix + "owner": {"me"}}}, valid: true},
+		{name: "unknown operator", condition: model.Condition{"StringSimilar": {model.ConditionKeyBranch: {"dev"}}}, valid: false},
+		{name: "unknown key", condition: model.Condition{model.ConditionStringEquals: {"lakefs:Color": {"blue"}}}, valid: false},
+		{name: "empty prefixed key", condition: model.Condition{model.ConditionStringEquals: {model.ConditionKeyPrincipalClaimPrefix: {"x"}}}, valid: false},
+		{name: "no keys", condition: model.Condition{model.ConditionStringEquals: {}}, valid: false},
+		{name: "no values", condition: model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {}}}, valid: false},
+		{name: "invalid ip", condition: model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"10.0.0.0/33"}}}, valid: false},
+		{name: "invalid number", condition: model.Condition{model.ConditionNumericLessThan: {model.ConditionKeyCurrentHour: {"nine"}}}, valid: false},
+		{name: "invalid date", condition: model.Condition{model.ConditionDateGreaterThan: {model.ConditionKeyCurrentTime: {"yesterday"}}}, valid: false},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := model.ValidateStatementCondition(tc.condition)
+			if tc.valid && err != nil {
+				t.Fatalf("ValidateStatementCondition() unexpected error: %s", err)
+			}
+			if !tc.valid && !errors.Is(err, model.ErrValidationError) {
+				t.Fatalf("ValidateStatementCondition() error = %v, expected %s", err, model.ErrValidationError)
+			}
+		})
+	}
+}
+
+func TestEvaluatePermission(t *testing.T) {
+	permission := permissions.Permission{
+		Action:   permissions.WriteObjectAction,
+		Resource: permissions.ObjectArn("repo1", "path/to/object"),
+	}
+	readOnly := &model.Policy{DisplayName: "read", Statement: []model.Statement{
+		{Effect: model.StatementEffectAllow, Action: []string{"fs:Read*"}, Resource: "*"},
+	}}
+	readWrite := &model.Policy{DisplayName: "write", Statement: []model.Statement{
+		{Effect: model.StatementEffectAllow, Action: []string{"fs:ReadObject"}, Resource: "*"},
+		{Effect: model.StatementEffectAllow, Action: []string{"fs:WriteObject"}, Resource: "*"},
+	}}
+	denyRepo := &model.Policy{DisplayName: "deny", Statement: []model.Statement{
+		{Effect: model.StatementEffectDeny, Action: []string{"fs:*"}, Resource: permissions.RepoArn("repo1")},
+		{Effect: model.StatementEffectDeny, Action: []string{"fs:*"}, Resource: "arn:lakefs:fs:::repository/repo1/*"},
+	}}
+	invalid := &model.Policy{DisplayName: "invalid", Statement: []model.Statement{
+		{
+			Effect:    model.StatementEffectAllow,
+			Action:    []string{"fs:WriteObject"},
+			Resource:  "*",
+			Condition: model.Condition{model.ConditionIPAddress: {model.ConditionKeySourceIP: {"not-an-ip"}}},
+		},
+	}}
+
+	testCases := []struct {
+		name             string
+		policies         []*model.Policy
+		credentialPolicy *model.Policy
+		expected         auth.CheckResult
+		expectedPolicy   string
+		expectedIndex    int
+		expectedErr      bool
+	}{
+		{name: "no policies", expected: auth.CheckNeutral},
+		{name: "no matching statement", policies: []*model.Policy{readOnly}, expected: auth.CheckNeutral},
+		{name: "allow", policies: []*model.Policy{readOnly, readWrite}, expected: auth.CheckAllow, expectedPolicy: "write", expectedIndex: 1},
+		{name: "deny precedes allow", policies: []*model.Policy{readWrite, denyRepo}, expected: auth.CheckDeny, expectedPolicy: "deny", expectedIndex: 1},
+		{name: "invalid statement", policies: []*model.Policy{invalid, readWrite}, expected: auth.CheckDeny, expectedPolicy: "invalid", expectedErr: true},
+		{name: "credential policy allows", policies: []*model.Policy{readWrite}, credentialPolicy: readWrite, expected: auth.CheckAllow, expectedPolicy: "write", expectedIndex: 1},
+		{name: "credential policy narrows", policies: []*model.Policy{readWrite}, credentialPolicy: readOnly, expected: auth.CheckNeutral},
+		{name: "credential policy denies", policies: []*model.Policy{readWrite}, credentialPolicy: denyRepo, expected: auth.CheckDeny, expectedPolicy: "deny", expectedIndex: 1},
+		{name: "credential policy does not extend", policies: []*model.Policy{readOnly}, credentialPolicy: readWrite, expected: auth.CheckNeutral},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			reqCtx := &auth.RequestContext{SourceIP: "10.0.0.1", CredentialPolicy: tc.credentialPolicy}
+			decision, err := auth.EvaluatePermission(permission, "user1", tc.policies, reqCtx)
+			if tc.expectedErr != (err != nil) {
+				t.Fatalf("EvaluatePermission() error = %v, expected error %t", err, tc.expectedErr)
+			}
+			if decision.Result != tc.expected {
+				t.Errorf("EvaluatePermission() result = %v, expected %v", decision.Result, tc.expected)
+			}
+			policy := ""
+			if decision.Policy != nil {
+				policy = decision.Policy.DisplayName
+			}
+			if policy != tc.expectedPolicy || decision.StatementIndex != tc.expectedIndex {
+				t.Errorf("EvaluatePermission() decided by %s[%d], expected %s[%d]", policy, decision.StatementIndex, tc.expectedPolicy, tc.expectedIndex)
+			}
+		})
+	}
+}
@@ -9,7 +9,9 @@ import (
 type contextKey string
 
 const (
-	userContextKey contextKey = "user"
+	userContextKey       contextKey = "user"
+	claimsContextKey     contextKey = "claims"
+	credentialContextKey contextKey = "credential"
 )
 
 func GetUser(ctx context.Context) (*model.User, error) {
@@ -23,3 +25,23 @@ func GetUser(ctx context.Context) (*model.User, error) {
 func WithUser(ctx context.Context, user *model.User) context.Context {
 	return context.WithValue(ctx, userContextKey, user)
 }
+
+// GetClaims returns the claims of the identity token the user signed in with, if any
+func GetClaims(ctx context.Context) map[string][]string {
+	claims, _ := ctx.Value(claimsContextKey).(map[string][]string)
+	return claims
+}
+
+func WithClaims(ctx context.Context, claims map[string][]string) context.Context {
+	return context.WithValue(ctx, claimsContextKey, claims)
+}
+
+// GetCredential returns the credentials the user authenticated with by access key, if any
+func GetCredential(ctx context.Context) *model.Credential {
+	cred, _ := ctx.Value(credentialContextKey).(*model.Credential)
+	return cred
+}
+
+func WithCredential(ctx context.Context, cred *model.Credential) context.Context {
+	return context.WithValue(ctx, credentialContextKey, cred)
+}
@@ -14,6 +14,7 @@ var (
 	ErrInsufficientPermissions = errors.New("insufficient permissions")
 	ErrInvalidAccessKeyID      = errors.New("invalid access key ID")
 	ErrInvalidSecretAccessKey  = errors.New("invalid secret access key")
+	ErrExpiredAccessKey        = errors.New("expired access key")
 	ErrUnexpectedStatusCode    = errors.New("unexpected status code")
 	ErrUnexpectedSigningMethod = errors.New("unexpected signing method")
 	ErrInvalidToken            = errors.New("invalid token")
@@ -38,6 +38,9 @@ func NewAuthService(ctx context.Context, cfg config.Config, logger logging.Logge
 			authparams.ServiceCache(authCfg.Cache),
 			logger.WithField("service", "auth_service"),
 		)
+		if err := auth.WatchCache(ctx, kvStore, auth.BasicPartitionKey, apiService.Cache()); err != nil {
+			logger.WithError(err).Warn("Failed to watch auth changes, cached entities will expire")
+		}
 		// Check if migration needed
 		initialized, err := metadataManager.IsInitialized(ctx)
 		if err != nil {
@@ -136,11 +136,31 @@ func (mr *MockClientWithResponsesInterfaceMockRecorder) ClaimTokenIdWithResponse
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ClaimTokenIdWithResponse", reflect.TypeOf((*MockClientWithResponsesInterface)(nil).ClaimTokenIdWithResponse), varargs...)
 }
 
+// CreateCredentialsWithBodyWithResponse mocks base method.
+func (m *MockClientWithResponsesInterface) CreateCredentialsWithBodyWithResponse(arg0 context.Context, arg1 string, arg2 *auth.CreateCredentialsParams, arg3 string, arg4 io.Reader, arg5 ...auth.RequestEditorFn) (*auth.CreateCredentialsResponse, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{arg0, arg1, arg2, arg3, arg4}
+	for _, a := range arg5 {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "CreateCredentialsWithBodyWithResponse", varargs...)
+	ret0, _ := ret[0].(*auth.CreateCredentialsResponse)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateCredentialsWithBodyWithResponse indicates an expected call of CreateCredentialsWithBodyWithResponse.
+func (mr *MockClientWithResponsesInterfaceMockRecorder) CreateCredentialsWithBodyWithResponse(arg0, arg1, arg2, arg3, arg4 interface{}, arg5 ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3, arg4}, arg5...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredentialsWithBodyWithResponse", reflect.TypeOf((*MockClientWithResponsesInterface)(nil).CreateCredentialsWithBodyWithResponse), varargs...)
+}
+
 // CreateCredentialsWithResponse mocks base method.
-func (m *MockClientWithResponsesInterface) CreateCredentialsWithResponse(arg0 context.Context, arg1 string, arg2 *auth.CreateCredentialsParams, arg3 ...auth.RequestEditorFn) (*auth.CreateCredentialsResponse, error) {
+func (m *MockClientWithResponsesInterface) CreateCredentialsWithResponse(arg0 context.Context, arg1 string, arg2 *auth.CreateCredentialsParams, arg3 auth.CreateCredentialsJSONRequestBody, arg4 ...auth.RequestEditorFn) (*auth.CreateCredentialsResponse, error) {
 	m.ctrl.T.Helper()
-	varargs := []interface{}{arg0, arg1, arg2}
-	for _, a := range arg3 {
+	varargs := []interface{}{arg0, arg1, arg2, arg3}
+	for _, a := range arg4 {
 		varargs = append(varargs, a)
 	}
 	ret := m.ctrl.Call(m, "CreateCredentialsWithResponse", varargs...)
@@ -150,9 +170,9 @@ func (m *MockClientWithResponsesInterface) CreateCredentialsWithResponse(arg0 co
 }
 
 // CreateCredentialsWithResponse indicates an expected call of CreateCredentialsWithResponse.
-func (mr *MockClientWithResponsesInterfaceMockRecorder) CreateCredentialsWithResponse(arg0, arg1, arg2 interface{}, arg3 ...interface{}) *gomock.Call {
+func (mr *MockClientWithResponsesInterfaceMockRecorder) CreateCredentialsWithResponse(arg0, arg1, arg2, arg3 interface{}, arg4 ...interface{}) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	varargs := append([]interface{}{arg0, arg1, arg2}, arg3...)
+	varargs := append([]interface{}{arg0, arg1, arg2, arg3}, arg4...)
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateCredentialsWithResponse", reflect.TypeOf((*MockClientWithResponsesInterface)(nil).CreateCredentialsWithResponse), varargs...)
 }
 
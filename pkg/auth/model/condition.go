@@ -0,0 +1,130 @@
+package model
+
+import (
+	"net"
+	"strings"
+)
+
+// Condition operators supported in policy statements
+const (
+	ConditionStringEquals             = "StringEquals"
+	ConditionStringNotEquals          = "StringNotEquals"
+	ConditionStringLike               = "StringLike"
+	ConditionStringNotLike            = "StringNotLike"
+	ConditionNumericEquals            = "NumericEquals"
+	ConditionNumericLessThan          = "NumericLessThan"
+	ConditionNumericLessThanEquals    = "NumericLessThanEquals"
+	ConditionNumericGreaterThan       = "NumericGreaterThan"
+	ConditionNumericGreaterThanEquals = "NumericGreaterThanEquals"
+	ConditionDateLessThan             = "DateLessThan"
+	ConditionDateGreaterThan          = "DateGreaterThan"
+	ConditionIPAddress                = "IpAddress"
+	ConditionNotIPAddress             = "NotIpAddress"
+)
+
+// Request context keys policy statement conditions can test
+const (
+	// ConditionKeyCurrentTime is the request time, RFC3339 formatted
+	ConditionKeyCurrentTime = "lakefs:CurrentTime"
+	// ConditionKeyCurrentHour is the hour of the request time in UTC, 0 to 23
+	ConditionKeyCurrentHour = "lakefs:CurrentHour"
+	// ConditionKeyCurrentWeekday is the day of the week of the request time in UTC, e.g. "Monday"
+	ConditionKeyCurrentWeekday = "lakefs:CurrentWeekday"
+	// ConditionKeySourceIP is the IP address the request came from
+	ConditionKeySourceIP = "lakefs:SourceIp"
+	// ConditionKeyBranch is the branch the request operates on
+	ConditionKeyBranch = "lakefs:Branch"
+	// ConditionKeyObjectMetadataPrefix is followed by a user metadata key of the object the request writes
+	ConditionKeyObjectMetadataPrefix = "lakefs:ObjectMetadata/"
+	// ConditionKeyPrincipalClaimPrefix is followed by a claim of the identity token the caller signed in with
+	ConditionKeyPrincipalClaimPrefix = "lakefs:PrincipalClaim/"
+)
+
+// ConditionType is the type of values a condition operator compares
+type ConditionType int
+
+const (
+	ConditionTypeString ConditionType = iota
+	ConditionTypeNumeric
+	ConditionTypeDate
+	ConditionTypeIPAddress
+)
+
+var conditionOperatorTypes = map[string]ConditionType{
+	ConditionStringEquals:             ConditionTypeString,
+	ConditionStringNotEquals:          ConditionTypeString,
+	ConditionStringLike:               ConditionTypeString,
+	ConditionStringNotLike:            ConditionTypeString,
+	ConditionNumericEquals:            ConditionTypeNumeric,
+	ConditionNumericLessThan:          ConditionTypeNumeric,
+	ConditionNumericLessThanEquals:    ConditionTypeNumeric,
+	ConditionNumericGreaterThan:       ConditionTypeNumeric,
+	ConditionNumericGreaterThanEquals: ConditionTypeNumeric,
+	ConditionDateLessThan:             ConditionTypeDate,
+	ConditionDateGreaterThan:          ConditionTypeDate,
+	ConditionIPAddress:                ConditionTypeIPAddress,
+	ConditionNotIPAddress:             ConditionTypeIPAddress,
+}
+
+// ConditionOperatorType returns the type of values operator compares, and whether operator is supported
+func ConditionOperatorType(operator string) (ConditionType, bool) {
+	t, ok := conditionOperatorTypes[operator]
+	return t, ok
+}
+
+// IsConditionKey returns true if key is a request context key conditions can test
+func IsConditionKey(key string) bool {
+	switch key {
+	case ConditionKeyCurrentTime, ConditionKeyCurrentHour, ConditionKeyCurrentWeekday, ConditionKeySourceIP, ConditionKeyBranch:
+		return true
+	}
+	for _, prefix := range []string{ConditionKeyObjectMetadataPrefix, ConditionKeyPrincipalClaimPrefix} {
+		if strings.HasPrefix(key, prefix) && len(key) > len(prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// ParseConditionIPNet parses a CIDR block, or a single IP address as a block containing only that address
+func ParseConditionIPNet(s string) (*net.IPNet, error) {
+	if !strings.Contains(s, "/") {
+		ip := net.ParseIP(s)
+		if ip == nil {
+			return nil, &net.ParseError{Type: "IP address", Text: s}
+		}
+		bits := 8 * net.IPv6len
+		if ip4 := ip.To4(); ip4 != nil {
+			ip, bits = ip4, 8*net.IPv4len
+		}
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+	_, ipNet, err := net.ParseCIDR(s)
+	return ipNet, err
+}
+
+// ConditionToAPI returns the operators of condition in a generated API condition, the keys of each operator wrapped
+// by newKeys. It returns nil for an empty condition.
+func ConditionToAPI[K any](condition Condition, newKeys func(map[string][]string) K) map[string]K {
+	if len(condition) == 0 {
+		return nil
+	}
+	operators := make(map[string]K, len(condition))
+	for operator, keys := range condition {
+		operators[operator] = newKeys(keys)
+	}
+	return operators
+}
+
+// ConditionFromAPI returns the condition of the operators of a generated API condition, the keys of each operator
+// unwrapped by keys. It returns nil for no operators.
+func ConditionFromAPI[K any](operators map[string]K, keys func(K) map[string][]string) Condition {
+	if len(operators) == 0 {
+		return nil
+	}
+	condition := make(Condition, len(operators))
+	for operator, k := range operators {
+		condition[operator] = keys(k)
+	}
+	return condition
+}
@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"errors"
 	"fmt"
+	"maps"
+	"slices"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/go-openapi/swag"
@@ -77,7 +80,10 @@ func MetadataKeyPath(key string) string {
 	return kv.FormatPath(metadataPrefix, key)
 }
 
-var ErrInvalidStatementSrcFormat = errors.New("invalid statements src format")
+var (
+	ErrInvalidStatementSrcFormat = errors.New("invalid statements src format")
+	ErrCredentialLimitsExceeded  = errors.New("credential limits exceeded")
+)
 
 type PaginationParams struct {
 	Prefix string
@@ -161,9 +167,23 @@ type DBPolicy struct {
 }
 
 type Statement struct {
-	Effect   string   `json:"Effect"`
-	Action   []string `json:"Action"`
-	Resource string   `json:"Resource"`
+	Effect    string    `json:"Effect"`
+	Action    []string  `json:"Action"`
+	Resource  string    `json:"Resource"`
+	Condition Condition `json:"Condition,omitempty"`
+}
+
+// Condition maps a condition operator to the request context keys it tests, and
+// each key to the values it is tested against. A statement applies to a request
+// only when all of its conditions hold.
+type Condition map[string]map[string][]string
+
+// Equal returns true if s and other are the same statement
+func (s Statement) Equal(other Statement) bool {
+	return s.Effect == other.Effect && s.Resource == other.Resource && slices.Equal(s.Action, other.Action) &&
+		maps.EqualFunc(s.Condition, other.Condition, func(a, b map[string][]string) bool {
+			return maps.EqualFunc(a, b, slices.Equal[[]string])
+		})
 }
 
 type Statements []Statement
@@ -178,6 +198,62 @@ type BaseCredential struct {
 type Credential struct {
 	Username string
 	BaseCredential
+	CredentialLimits
+}
+
+// CredentialLimits narrow the use of an access key beyond the permissions of its user
+type CredentialLimits struct {
+	// ExpirationDate after which the access key is rejected, never if zero
+	ExpirationDate time.Time
+	// Statement of an inline policy: the access key is allowed only the actions that both the policies of its
+	// user and these statements allow. Not narrowed if empty.
+	Statement Statements
+}
+
+// IsZero returns true if the access key is not limited
+func (l CredentialLimits) IsZero() bool {
+	return l.ExpirationDate.IsZero() && len(l.Statement) == 0
+}
+
+// Within returns l narrowed to the limits of the access key that creates the access key, parent: it expires no later
+// than parent and has every statement of parent. Other statements must deny, as another allow statement may allow
+// more than parent does.
+func (l CredentialLimits) Within(parent CredentialLimits) (CredentialLimits, error) {
+	if !parent.ExpirationDate.IsZero() && (l.ExpirationDate.IsZero() || l.ExpirationDate.After(parent.ExpirationDate)) {
+		l.ExpirationDate = parent.ExpirationDate
+	}
+	if len(parent.Statement) == 0 {
+		return l, nil
+	}
+	statement := slices.Clone(parent.Statement)
+	for _, stmt := range l.Statement {
+		if slices.ContainsFunc(parent.Statement, stmt.Equal) {
+			continue
+		}
+		if stmt.Effect != StatementEffectDeny {
+			return CredentialLimits{}, fmt.Errorf("%w: statement allowing %s on %s is not in the inline policy of the caller",
+				ErrCredentialLimitsExceeded, strings.Join(stmt.Action, ","), stmt.Resource)
+		}
+		statement = append(statement, stmt)
+	}
+	l.Statement = statement
+	return l, nil
+}
+
+// IsExpired returns true if the access key is expired at t
+func (l CredentialLimits) IsExpired(t time.Time) bool {
+	return !l.ExpirationDate.IsZero() && !t.Before(l.ExpirationDate)
+}
+
+// InlinePolicy returns the inline policy of the access key, nil if it is not narrowed
+func (c *Credential) InlinePolicy() *Policy {
+	if len(c.Statement) == 0 {
+		return nil
+	}
+	return &Policy{
+		DisplayName: c.AccessKeyID,
+		Statement:   c.Statement,
+	}
 }
 
 type DBCredential struct {
@@ -307,7 +383,7 @@ func CredentialFromProto(s crypt.SecretStore, pb *CredentialData) (*Credential,
 	if err != nil {
 		return nil, err
 	}
-	return &Credential{
+	c := &Credential{
 		Username: string(pb.UserId),
 		BaseCredential: BaseCredential{
 			AccessKeyID:                   pb.AccessKeyId,
@@ -315,32 +391,76 @@ func CredentialFromProto(s crypt.SecretStore, pb *CredentialData) (*Credential,
 			SecretAccessKeyEncryptedBytes: pb.SecretAccessKeyEncryptedBytes,
 			IssuedDate:                    pb.IssuedDate.AsTime(),
 		},
-	}, nil
+	}
+	if pb.ExpirationDate != nil {
+		c.ExpirationDate = pb.ExpirationDate.AsTime()
+	}
+	if len(pb.Statements) > 0 {
+		c.Statement = *statementsFromProto(pb.Statements)
+	}
+	return c, nil
 }
 
 func ProtoFromCredential(c *Credential) *CredentialData {
-	return &CredentialData{
+	pb := &CredentialData{
 		AccessKeyId:                   c.AccessKeyID,
 		SecretAccessKeyEncryptedBytes: c.SecretAccessKeyEncryptedBytes,
 		IssuedDate:                    timestamppb.New(c.IssuedDate),
 		UserId:                        []byte(c.Username),
 	}
+	if !c.ExpirationDate.IsZero() {
+		pb.ExpirationDate = timestamppb.New(c.ExpirationDate)
+	}
+	if len(c.Statement) > 0 {
+		pb.Statements = protoFromStatements(&c.Statement)
+	}
+	return pb
 }
 
 func statementFromProto(pb *StatementData) *Statement {
 	return &Statement{
-		Effect:   pb.Effect,
-		Action:   pb.Action,
-		Resource: pb.Resource,
+		Effect:    pb.Effect,
+		Action:    pb.Action,
+		Resource:  pb.Resource,
+		Condition: conditionFromProto(pb.Conditions),
 	}
 }
 
 func protoFromStatement(s *Statement) *StatementData {
 	return &StatementData{
-		Effect:   s.Effect,
-		Action:   s.Action,
-		Resource: s.Resource,
+		Effect:     s.Effect,
+		Action:     s.Action,
+		Resource:   s.Resource,
+		Conditions: protoFromCondition(s.Condition),
+	}
+}
+
+func conditionFromProto(pb []*ConditionData) Condition {
+	if len(pb) == 0 {
+		return nil
+	}
+	condition := make(Condition)
+	for _, c := range pb {
+		if condition[c.Operator] == nil {
+			condition[c.Operator] = make(map[string][]string)
+		}
+		condition[c.Operator][c.Key] = c.Values
+	}
+	return condition
+}
+
+func protoFromCondition(c Condition) []*ConditionData {
+	var conditions []*ConditionData
+	for operator, keys := range c {
+		for key, values := range keys {
+			conditions = append(conditions, &ConditionData{
+				Operator: operator,
+				Key:      key,
+				Values:   values,
+			})
+		}
 	}
+	return conditions
 }
 
 func statementsFromProto(pb []*StatementData) *Statements {
@@ -317,6 +317,8 @@ type CredentialData struct {
 	SecretAccessKeyEncryptedBytes []byte                 `protobuf:"bytes,2,opt,name=secret_access_key_encrypted_bytes,json=secretAccessKeyEncryptedBytes,proto3" json:"secret_access_key_encrypted_bytes,omitempty"`
 	IssuedDate                    *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=issued_date,json=issuedDate,proto3" json:"issued_date,omitempty"`
 	UserId                        []byte                 `protobuf:"bytes,4,opt,name=user_id,json=userId,proto3" json:"user_id,omitempty"`
+	ExpirationDate                *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=expiration_date,json=expirationDate,proto3" json:"expiration_date,omitempty"`
+	Statements                    []*StatementData       `protobuf:"bytes,6,rep,name=statements,proto3" json:"statements,omitempty"`
 	unknownFields                 protoimpl.UnknownFields
 	sizeCache                     protoimpl.SizeCache
 }
@@ -379,12 +381,27 @@ func (x *CredentialData) GetUserId() []byte {
 	return nil
 }
 
+func (x *CredentialData) GetExpirationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.ExpirationDate
+	}
+	return nil
+}
+
+func (x *CredentialData) GetStatements() []*StatementData {
+	if x != nil {
+		return x.Statements
+	}
+	return nil
+}
+
 // message data model for model.Statement struct
 type StatementData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Effect        string                 `protobuf:"bytes,1,opt,name=effect,proto3" json:"effect,omitempty"`
 	Action        []string               `protobuf:"bytes,2,rep,name=action,proto3" json:"action,omitempty"`
 	Resource      string                 `protobuf:"bytes,3,opt,name=resource,proto3" json:"resource,omitempty"`
+	Conditions    []*ConditionData       `protobuf:"bytes,4,rep,name=conditions,proto3" json:"conditions,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -440,6 +457,74 @@ func (x *StatementData) GetResource() string {
 	return ""
 }
 
+func (x *StatementData) GetConditions() []*ConditionData {
+	if x != nil {
+		return x.Conditions
+	}
+	return nil
+}
+
+// message data model for a single model.Condition operator and context key
+type ConditionData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Operator      string                 `protobuf:"bytes,1,opt,name=operator,proto3" json:"operator,omitempty"`
+	Key           string                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	Values        []string               `protobuf:"bytes,3,rep,name=values,proto3" json:"values,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConditionData) Reset() {
+	*x = ConditionData{}
+	mi := &file_auth_model_model_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConditionData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConditionData) ProtoMessage() {}
+
+func (x *ConditionData) ProtoReflect() protoreflect.Message {
+	mi := &file_auth_model_model_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConditionData.ProtoReflect.Descriptor instead.
+func (*ConditionData) Descriptor() ([]byte, []int) {
+	return file_auth_model_model_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ConditionData) GetOperator() string {
+	if x != nil {
+		return x.Operator
+	}
+	return ""
+}
+
+func (x *ConditionData) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *ConditionData) GetValues() []string {
+	if x != nil {
+		return x.Values
+	}
+	return nil
+}
+
 // message data model for rest password token
 type TokenData struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -451,7 +536,7 @@ type TokenData struct {
 
 func (x *TokenData) Reset() {
 	*x = TokenData{}
-	mi := &file_auth_model_model_proto_msgTypes[6]
+	mi := &file_auth_model_model_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -463,7 +548,7 @@ func (x *TokenData) String() string {
 func (*TokenData) ProtoMessage() {}
 
 func (x *TokenData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_model_model_proto_msgTypes[6]
+	mi := &file_auth_model_model_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -476,7 +561,7 @@ func (x *TokenData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use TokenData.ProtoReflect.Descriptor instead.
 func (*TokenData) Descriptor() ([]byte, []int) {
-	return file_auth_model_model_proto_rawDescGZIP(), []int{6}
+	return file_auth_model_model_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *TokenData) GetTokenId() string {
@@ -504,7 +589,7 @@ type RepositoriesData struct {
 
 func (x *RepositoriesData) Reset() {
 	*x = RepositoriesData{}
-	mi := &file_auth_model_model_proto_msgTypes[7]
+	mi := &file_auth_model_model_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -516,7 +601,7 @@ func (x *RepositoriesData) String() string {
 func (*RepositoriesData) ProtoMessage() {}
 
 func (x *RepositoriesData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_model_model_proto_msgTypes[7]
+	mi := &file_auth_model_model_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -529,7 +614,7 @@ func (x *RepositoriesData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepositoriesData.ProtoReflect.Descriptor instead.
 func (*RepositoriesData) Descriptor() ([]byte, []int) {
-	return file_auth_model_model_proto_rawDescGZIP(), []int{7}
+	return file_auth_model_model_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *RepositoriesData) GetAll() bool {
@@ -557,7 +642,7 @@ type UIData struct {
 
 func (x *UIData) Reset() {
 	*x = UIData{}
-	mi := &file_auth_model_model_proto_msgTypes[8]
+	mi := &file_auth_model_model_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -569,7 +654,7 @@ func (x *UIData) String() string {
 func (*UIData) ProtoMessage() {}
 
 func (x *UIData) ProtoReflect() protoreflect.Message {
-	mi := &file_auth_model_model_proto_msgTypes[8]
+	mi := &file_auth_model_model_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -582,7 +667,7 @@ func (x *UIData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UIData.ProtoReflect.Descriptor instead.
 func (*UIData) Descriptor() ([]byte, []int) {
-	return file_auth_model_model_proto_rawDescGZIP(), []int{8}
+	return file_auth_model_model_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *UIData) GetPermission() string {
@@ -633,17 +718,28 @@ const file_auth_model_model_proto_rawDesc = "" +
 	"\n" +
 	"statements\x18\x03 \x03(\v2-.io.treeverse.lakefs.auth.model.StatementDataR\n" +
 	"statements\x129\n" +
-	"\x03acl\x18\x04 \x01(\v2'.io.treeverse.lakefs.auth.model.ACLDataR\x03acl\"\xd4\x01\n" +
+	"\x03acl\x18\x04 \x01(\v2'.io.treeverse.lakefs.auth.model.ACLDataR\x03acl\"\xe8\x02\n" +
 	"\x0eCredentialData\x12\"\n" +
 	"\raccess_key_id\x18\x01 \x01(\tR\vaccessKeyId\x12H\n" +
 	"!secret_access_key_encrypted_bytes\x18\x02 \x01(\fR\x1dsecretAccessKeyEncryptedBytes\x12;\n" +
 	"\vissued_date\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\n" +
 	"issuedDate\x12\x17\n" +
-	"\auser_id\x18\x04 \x01(\fR\x06userId\"[\n" +
+	"\auser_id\x18\x04 \x01(\fR\x06userId\x12C\n" +
+	"\x0fexpiration_date\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\x0eexpirationDate\x12M\n" +
+	"\n" +
+	"statements\x18\x06 \x03(\v2-.io.treeverse.lakefs.auth.model.StatementDataR\n" +
+	"statements\"\xaa\x01\n" +
 	"\rStatementData\x12\x16\n" +
 	"\x06effect\x18\x01 \x01(\tR\x06effect\x12\x16\n" +
 	"\x06action\x18\x02 \x03(\tR\x06action\x12\x1a\n" +
-	"\bresource\x18\x03 \x01(\tR\bresource\"a\n" +
+	"\bresource\x18\x03 \x01(\tR\bresource\x12M\n" +
+	"\n" +
+	"conditions\x18\x04 \x03(\v2-.io.treeverse.lakefs.auth.model.ConditionDataR\n" +
+	"conditions\"U\n" +
+	"\rConditionData\x12\x1a\n" +
+	"\boperator\x18\x01 \x01(\tR\boperator\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\tR\x03key\x12\x16\n" +
+	"\x06values\x18\x03 \x03(\tR\x06values\"a\n" +
 	"\tTokenData\x12\x19\n" +
 	"\btoken_id\x18\x01 \x01(\tR\atokenId\x129\n" +
 	"\n" +
@@ -669,7 +765,7 @@ func file_auth_model_model_proto_rawDescGZIP() []byte {
 	return file_auth_model_model_proto_rawDescData
 }
 
-var file_auth_model_model_proto_msgTypes = make([]protoimpl.MessageInfo, 9)
+var file_auth_model_model_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
 var file_auth_model_model_proto_goTypes = []any{
 	(*UserData)(nil),              // 0: io.treeverse.lakefs.auth.model.UserData
 	(*GroupData)(nil),             // 1: io.treeverse.lakefs.auth.model.GroupData
@@ -677,25 +773,29 @@ var file_auth_model_model_proto_goTypes = []any{
 	(*PolicyData)(nil),            // 3: io.treeverse.lakefs.auth.model.PolicyData
 	(*CredentialData)(nil),        // 4: io.treeverse.lakefs.auth.model.CredentialData
 	(*StatementData)(nil),         // 5: io.treeverse.lakefs.auth.model.StatementData
-	(*TokenData)(nil),             // 6: io.treeverse.lakefs.auth.model.TokenData
-	(*RepositoriesData)(nil),      // 7: io.treeverse.lakefs.auth.model.RepositoriesData
-	(*UIData)(nil),                // 8: io.treeverse.lakefs.auth.model.UIData
-	(*timestamppb.Timestamp)(nil), // 9: google.protobuf.Timestamp
+	(*ConditionData)(nil),         // 6: io.treeverse.lakefs.auth.model.ConditionData
+	(*TokenData)(nil),             // 7: io.treeverse.lakefs.auth.model.TokenData
+	(*RepositoriesData)(nil),      // 8: io.treeverse.lakefs.auth.model.RepositoriesData
+	(*UIData)(nil),                // 9: io.treeverse.lakefs.auth.model.UIData
+	(*timestamppb.Timestamp)(nil), // 10: google.protobuf.Timestamp
 }
 var file_auth_model_model_proto_depIdxs = []int32{
-	9, // 0: io.treeverse.lakefs.auth.model.UserData.created_at:type_name -> google.protobuf.Timestamp
-	9, // 1: io.treeverse.lakefs.auth.model.GroupData.created_at:type_name -> google.protobuf.Timestamp
-	9, // 2: io.treeverse.lakefs.auth.model.PolicyData.created_at:type_name -> google.protobuf.Timestamp
-	5, // 3: io.treeverse.lakefs.auth.model.PolicyData.statements:type_name -> io.treeverse.lakefs.auth.model.StatementData
-	2, // 4: io.treeverse.lakefs.auth.model.PolicyData.acl:type_name -> io.treeverse.lakefs.auth.model.ACLData
-	9, // 5: io.treeverse.lakefs.auth.model.CredentialData.issued_date:type_name -> google.protobuf.Timestamp
-	9, // 6: io.treeverse.lakefs.auth.model.TokenData.expired_at:type_name -> google.protobuf.Timestamp
-	7, // 7: io.treeverse.lakefs.auth.model.UIData.repositories:type_name -> io.treeverse.lakefs.auth.model.RepositoriesData
-	8, // [8:8] is the sub-list for method output_type
-	8, // [8:8] is the sub-list for method input_type
-	8, // [8:8] is the sub-list for extension type_name
-	8, // [8:8] is the sub-list for extension extendee
-	0, // [0:8] is the sub-list for field type_name
+	10, // 0: io.treeverse.lakefs.auth.model.UserData.created_at:type_name -> google.protobuf.Timestamp
+	10, // 1: io.treeverse.lakefs.auth.model.GroupData.created_at:type_name -> google.protobuf.Timestamp
+	10, // 2: io.treeverse.lakefs.auth.model.PolicyData.created_at:type_name -> google.protobuf.Timestamp
+	5,  // 3: io.treeverse.lakefs.auth.model.PolicyData.statements:type_name -> io.treeverse.lakefs.auth.model.StatementData
+	2,  // 4: io.treeverse.lakefs.auth.model.PolicyData.acl:type_name -> io.treeverse.lakefs.auth.model.ACLData
+	10, // 5: io.treeverse.lakefs.auth.model.CredentialData.issued_date:type_name -> google.protobuf.Timestamp
+	10, // 6: io.treeverse.lakefs.auth.model.CredentialData.expiration_date:type_name -> google.protobuf.Timestamp
+	5,  // 7: io.treeverse.lakefs.auth.model.CredentialData.statements:type_name -> io.treeverse.lakefs.auth.model.StatementData
+	6,  // 8: io.treeverse.lakefs.auth.model.StatementData.conditions:type_name -> io.treeverse.lakefs.auth.model.ConditionData
+	10, // 9: io.treeverse.lakefs.auth.model.TokenData.expired_at:type_name -> google.protobuf.Timestamp
+	8,  // 10: io.treeverse.lakefs.auth.model.UIData.repositories:type_name -> io.treeverse.lakefs.auth.model.RepositoriesData
+	11, // [11:11] is the sub-list for method output_type
+	11, // [11:11] is the sub-list for method input_type
+	11, // [11:11] is the sub-list for extension type_name
+	11, // [11:11] is the sub-list for extension extendee
+	0,  // [0:11] is the sub-list for field type_name
 }
 
 func init() { file_auth_model_model_proto_init() }
@@ -709,7 +809,7 @@ func file_auth_model_model_proto_init() {
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_auth_model_model_proto_rawDesc), len(file_auth_model_model_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   9,
+			NumMessages:   10,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -0,0 +1,88 @@
+package model_test
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/auth/model"
+)
+
+func TestCredentialLimits_Within(t *testing.T) {
+	now := time.Now()
+	allowRead := model.Statement{Effect: model.StatementEffectAllow, Action: []string{"fs:ReadObject"}, Resource: "*"}
+	allowWrite := model.Statement{Effect: model.StatementEffectAllow, Action: []string{"fs:WriteObject"}, Resource: "*"}
+	denyMain := model.Statement{
+		Effect:    model.StatementEffectDeny,
+		Action:    []string{"fs:*"},
+		Resource:  "*",
+		Condition: model.Condition{model.ConditionStringEquals: {model.ConditionKeyBranch: {"main"}}},
+	}
+
+	testCases := []struct {
+		name        string
+		limits      model.CredentialLimits
+		parent      model.CredentialLimits
+		expected    model.CredentialLimits
+		expectedErr error
+	}{
+		{
+			name:     "unlimited parent",
+			limits:   model.CredentialLimits{Statement: model.Statements{allowWrite}},
+			expected: model.CredentialLimits{Statement: model.Statements{allowWrite}},
+		},
+		{
+			name:     "inherits expiration",
+			parent:   model.CredentialLimits{ExpirationDate: now.Add(time.Hour)},
+			expected: model.CredentialLimits{ExpirationDate: now.Add(time.Hour)},
+		},
+		{
+			name:     "caps expiration",
+			limits:   model.CredentialLimits{ExpirationDate: now.Add(2 * time.Hour)},
+			parent:   model.CredentialLimits{ExpirationDate: now.Add(time.Hour)},
+			expected: model.CredentialLimits{ExpirationDate: now.Add(time.Hour)},
+		},
+		{
+			name:     "earlier expiration",
+			limits:   model.CredentialLimits{ExpirationDate: now.Add(time.Minute)},
+			parent:   model.CredentialLimits{ExpirationDate: now.Add(time.Hour)},
+			expected: model.CredentialLimits{ExpirationDate: now.Add(time.Minute)},
+		},
+		{
+			name:     "inherits statements",
+			parent:   model.CredentialLimits{Statement: model.Statements{allowRead, denyMain}},
+			expected: model.CredentialLimits{Statement: model.Statements{allowRead, denyMain}},
+		},
+		{
+			name:     "adds deny statement",
+			limits:   model.CredentialLimits{Statement: model.Statements{allowRead, denyMain}},
+			parent:   model.CredentialLimits{Statement: model.Statements{allowRead}},
+			expected: model.CredentialLimits{Statement: model.Statements{allowRead, denyMain}},
+		},
+		{
+			name:        "adds allow statement",
+			limits:      model.CredentialLimits{Statement: model.Statements{allowRead, allowWrite}},
+			parent:      model.CredentialLimits{Statement: model.Statements{allowRead}},
+			expectedErr: model.ErrCredentialLimitsExceeded,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			limits, err := tc.limits.Within(tc.parent)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Fatalf("Within() error = %v, expected %v", err, tc.expectedErr)
+			}
+			if !limits.ExpirationDate.Equal(tc.expected.ExpirationDate) {
+				t.Errorf("Within() expiration date = %s, expected %s", limits.ExpirationDate, tc.expected.ExpirationDate)
+			}
+			if len(limits.Statement) != len(tc.expected.Statement) {
+				t.Fatalf("Within() statements = %v, expected %v", limits.Statement, tc.expected.Statement)
+			}
+			for i, stmt := range limits.Statement {
+				if !stmt.Equal(tc.expected.Statement[i]) {
+					t.Errorf("Within() statement %d = %v, expected %v", i, stmt, tc.expected.Statement[i])
+				}
+			}
+		})
+	}
+}
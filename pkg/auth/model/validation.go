@@ -3,7 +3,9 @@ package model
 import (
 	"errors"
 	"fmt"
+	"strconv"
 	"strings"
+	"time"
 
 	"github.com/aws/aws-sdk-go-v2/aws/arn"
 	"github.com/treeverse/lakefs/pkg/kv"
@@ -43,3 +45,71 @@ func ValidateStatementEffect(effect string) error {
 	}
 	return nil
 }
+
+func ValidateStatementCondition(condition Condition) error {
+	for operator, keys := range condition {
+		conditionType, ok := ConditionOperatorType(operator)
+		if !ok {
+			return fmt.Errorf("%w: condition operator '%s'", ErrValidationError, operator)
+		}
+		if len(keys) == 0 {
+			return fmt.Errorf("%w: condition operator '%s' has no keys", ErrValidationError, operator)
+		}
+		for key, values := range keys {
+			if !IsConditionKey(key) {
+				return fmt.Errorf("%w: condition key '%s'", ErrValidationError, key)
+			}
+			if len(values) == 0 {
+				return fmt.Errorf("%w: condition key '%s' has no values", ErrValidationError, key)
+			}
+			for _, value := range values {
+				if err := validateConditionValue(conditionType, value); err != nil {
+					return fmt.Errorf("%w: condition %s value '%s': %w", ErrValidationError, operator, value, err)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+func validateConditionValue(conditionType ConditionType, value string) error {
+	var err error
+	switch conditionType {
+	case ConditionTypeNumeric:
+		_, err = strconv.ParseFloat(value, 64)
+	case ConditionTypeDate:
+		_, err = time.Parse(time.RFC3339, value)
+	case ConditionTypeIPAddress:
+		_, err = ParseConditionIPNet(value)
+	}
+	return err
+}
+
+func ValidateStatement(stmt Statement) error {
+	for _, action := range stmt.Action {
+		if err := ValidateActionName(action); err != nil {
+			return err
+		}
+	}
+	if err := ValidateArn(stmt.Resource); err != nil {
+		return err
+	}
+	if err := ValidateStatementEffect(stmt.Effect); err != nil {
+		return err
+	}
+	return ValidateStatementCondition(stmt.Condition)
+}
+
+// ValidateCredentialLimits validates limits of new credentials: the expiration date is in the future and the
+// inline policy statements are valid
+func ValidateCredentialLimits(limits CredentialLimits) error {
+	if !limits.ExpirationDate.IsZero() && !limits.ExpirationDate.After(time.Now()) {
+		return fmt.Errorf("%w: expiration date %s is not in the future", ErrValidationError, limits.ExpirationDate.Format(time.RFC3339))
+	}
+	for _, stmt := range limits.Statement {
+		if err := ValidateStatement(stmt); err != nil {
+			return err
+		}
+	}
+	return nil
+}
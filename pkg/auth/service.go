@@ -37,6 +37,8 @@ import (
 type AuthorizationRequest struct {
 	Username            string
 	RequiredPermissions permissions.Node
+	// RequestContext holds the request attributes policy statement conditions test, if nil only the current time is known
+	RequestContext *RequestContext
 }
 
 type AuthorizationResponse struct {
@@ -138,6 +140,8 @@ type Service interface {
 
 	// credentials
 	CredentialsCreator
+	// CreateLimitedCredentials creates credentials for the user that expire or are narrowed by an inline policy
+	CreateLimitedCredentials(ctx context.Context, username string, limits model.CredentialLimits) (*model.Credential, error)
 	AddCredentials(ctx context.Context, username, accessKeyID, secretAccessKey string) (*model.Credential, error)
 	DeleteCredentials(ctx context.Context, username, accessKeyID string) error
 	GetCredentialsForUser(ctx context.Context, username, accessKeyID string) (*model.Credential, error)
@@ -559,10 +563,14 @@ func (a *APIAuthService) WritePolicy(ctx context.Context, policy *model.Policy,
 	}
 	stmts := make([]Statement, len(policy.Statement))
 	for i, s := range policy.Statement {
+		if err := model.ValidateStatementCondition(s.Condition); err != nil {
+			return err
+		}
 		stmts[i] = Statement{
-			Action:   s.Action,
-			Effect:   s.Effect,
-			Resource: s.Resource,
+			Action:    s.Action,
+			Effect:    s.Effect,
+			Resource:  s.Resource,
+			Condition: serializeCondition(s.Condition),
 		}
 	}
 	createdAt := policy.CreatedAt.Unix()
@@ -592,14 +600,7 @@ func (a *APIAuthService) WritePolicy(ctx context.Context, policy *model.Policy,
 }
 
 func serializePolicyToModalPolicy(p Policy) *model.Policy {
-	stmts := make(model.Statements, len(p.Statement))
-	for i, apiStatement := range p.Statement {
-		stmts[i] = model.Statement{
-			Effect:   apiStatement.Effect,
-			Action:   apiStatement.Action,
-			Resource: apiStatement.Resource,
-		}
-	}
+	stmts := statementsFromAPI(p.Statement)
 	var creationTime time.Time
 	if p.CreationDate != nil {
 		creationTime = time.Unix(*p.CreationDate, 0)
@@ -614,6 +615,63 @@ func serializePolicyToModalPolicy(p Policy) *model.Policy {
 	}
 }
 
+func statementsFromAPI(statements []Statement) model.Statements {
+	stmts := make(model.Statements, len(statements))
+	for i, apiStatement := range statements {
+		stmts[i] = model.Statement{
+			Effect:    apiStatement.Effect,
+			Action:    apiStatement.Action,
+			Resource:  apiStatement.Resource,
+			Condition: conditionFromAPI(apiStatement.Condition),
+		}
+	}
+	return stmts
+}
+
+func serializeStatements(statements model.Statements) []Statement {
+	stmts := make([]Statement, len(statements))
+	for i, s := range statements {
+		stmts[i] = Statement{
+			Action:    s.Action,
+			Effect:    s.Effect,
+			Resource:  s.Resource,
+			Condition: serializeCondition(s.Condition),
+		}
+	}
+	return stmts
+}
+
+// credentialLimitsFromAPI returns the limits of credentials returned by the auth service
+func credentialLimitsFromAPI(expirationDate *int64, statements *[]Statement) model.CredentialLimits {
+	var limits model.CredentialLimits
+	if expirationDate != nil {
+		limits.ExpirationDate = time.Unix(*expirationDate, 0)
+	}
+	if statements != nil && len(*statements) > 0 {
+		limits.Statement = statementsFromAPI(*statements)
+	}
+	return limits
+}
+
+func serializeCondition(condition model.Condition) *Statement_Condition {
+	operators := model.ConditionToAPI(condition, func(keys map[string][]string) PolicyCondition {
+		return PolicyCondition{AdditionalProperties: keys}
+	})
+	if operators == nil {
+		return nil
+	}
+	return &Statement_Condition{AdditionalProperties: operators}
+}
+
+func conditionFromAPI(c *Statement_Condition) model.Condition {
+	if c == nil {
+		return nil
+	}
+	return model.ConditionFromAPI(c.AdditionalProperties, func(keys PolicyCondition) map[string][]string {
+		return keys.AdditionalProperties
+	})
+}
+
 func (a *APIAuthService) GetPolicy(ctx context.Context, policyDisplayName string) (*model.Policy, error) {
 	ctx = httputil.SetClientTrace(ctx, "api_auth")
 	resp, err := a.apiClient.GetPolicyWithResponse(ctx, policyDisplayName)
@@ -662,7 +720,7 @@ func (a *APIAuthService) ListPolicies(ctx context.Context, params *model.Paginat
 
 func (a *APIAuthService) CreateCredentials(ctx context.Context, username string) (*model.Credential, error) {
 	ctx = httputil.SetClientTrace(ctx, "api_auth")
-	resp, err := a.apiClient.CreateCredentialsWithResponse(ctx, username, &CreateCredentialsParams{})
+	resp, err := a.apiClient.CreateCredentialsWithResponse(ctx, username, &CreateCredentialsParams{}, CreateCredentialsJSONRequestBody{})
 	if err != nil {
 		a.logger.WithError(err).WithField("username", username).Error("failed to create credentials")
 		return nil, err
@@ -681,12 +739,53 @@ func (a *APIAuthService) CreateCredentials(ctx context.Context, username string)
 	}, err
 }
 
+func (a *APIAuthService) CreateLimitedCredentials(ctx context.Context, username string, limits model.CredentialLimits) (*model.Credential, error) {
+	ctx = httputil.SetClientTrace(ctx, "api_auth")
+	if err := model.ValidateCredentialLimits(limits); err != nil {
+		return nil, err
+	}
+	body := CreateCredentialsJSONRequestBody{}
+	if !limits.ExpirationDate.IsZero() {
+		body.ExpirationDate = swag.Int64(limits.ExpirationDate.Unix())
+	}
+	if len(limits.Statement) > 0 {
+		stmts := serializeStatements(limits.Statement)
+		body.Statement = &stmts
+	}
+	resp, err := a.apiClient.CreateCredentialsWithResponse(ctx, username, &CreateCredentialsParams{}, body)
+	if err != nil {
+		a.logger.WithError(err).WithField("username", username).Error("failed to create limited credentials")
+		return nil, err
+	}
+	if err := a.validateResponse(resp, http.StatusCreated); err != nil {
+		return nil, err
+	}
+	credentials := resp.JSON201
+	c := &model.Credential{
+		Username: username,
+		BaseCredential: model.BaseCredential{
+			AccessKeyID:     credentials.AccessKeyId,
+			SecretAccessKey: credentials.SecretAccessKey,
+			IssuedDate:      time.Unix(credentials.CreationDate, 0),
+		},
+		CredentialLimits: credentialLimitsFromAPI(credentials.ExpirationDate, credentials.Statement),
+	}
+	// an auth service that ignores the limits creates credentials with all the permissions of the user
+	if !c.ExpirationDate.Equal(limits.ExpirationDate.Truncate(time.Second)) || len(c.Statement) != len(limits.Statement) {
+		if err := a.DeleteCredentials(ctx, username, c.AccessKeyID); err != nil {
+			a.logger.WithError(err).WithField("username", username).Error("failed to delete credentials created without limits")
+		}
+		return nil, fmt.Errorf("auth service does not support limited credentials: %w", ErrNotImplemented)
+	}
+	return c, nil
+}
+
 func (a *APIAuthService) AddCredentials(ctx context.Context, username, accessKeyID, secretAccessKey string) (*model.Credential, error) {
 	ctx = httputil.SetClientTrace(ctx, "api_auth")
 	resp, err := a.apiClient.CreateCredentialsWithResponse(ctx, username, &CreateCredentialsParams{
 		AccessKey: &accessKeyID,
 		SecretKey: &secretAccessKey,
-	})
+	}, CreateCredentialsJSONRequestBody{})
 	if err != nil {
 		a.logger.WithError(err).WithField("username", username).Error("failed to add credentials")
 		return nil, err
@@ -731,7 +830,8 @@ func (a *APIAuthService) GetCredentialsForUser(ctx context.Context, username, ac
 			AccessKeyID: credentials.AccessKeyId,
 			IssuedDate:  time.Unix(credentials.CreationDate, 0),
 		},
-		Username: username,
+		CredentialLimits: credentialLimitsFromAPI(credentials.ExpirationDate, credentials.Statement),
+		Username:         username,
 	}, nil
 }
 
@@ -765,7 +865,8 @@ func (a *APIAuthService) GetCredentials(ctx context.Context, accessKeyID string)
 				SecretAccessKeyEncryptedBytes: nil,
 				IssuedDate:                    time.Unix(credentials.CreationDate, 0),
 			},
-			Username: username,
+			CredentialLimits: credentialLimitsFromAPI(credentials.ExpirationDate, credentials.Statement),
+			Username:         username,
 		}, nil
 	})
 }
@@ -793,7 +894,8 @@ func (a *APIAuthService) ListUserCredentials(ctx context.Context, username strin
 				AccessKeyID: r.AccessKeyId,
 				IssuedDate:  time.Unix(r.CreationDate, 0),
 			},
-			Username: strconv.Itoa(0),
+			CredentialLimits: credentialLimitsFromAPI(r.ExpirationDate, r.Statement),
+			Username:         strconv.Itoa(0),
 		}
 	}
 	return credentials, toPagination(resp.JSON200.Pagination), nil
@@ -937,7 +1039,7 @@ func (a *APIAuthService) Authorize(ctx context.Context, req *AuthorizationReques
 		return nil, err
 	}
 	permAudit := &MissingPermissions{}
-	allowed := CheckPermissions(ctx, req.RequiredPermissions, req.Username, policies, permAudit)
+	allowed := CheckPermissions(ctx, req.RequiredPermissions, req.Username, policies, req.RequestContext, permAudit)
 
 	if allowed != CheckAllow {
 		return &AuthorizationResponse{
@@ -1177,43 +1279,14 @@ func (n *MissingPermissions) String() string {
 	return UserNotAllowed
 }
 
-func CheckPermissions(ctx context.Context, node permissions.Node, username string, policies []*model.Policy, permAudit *MissingPermissions) CheckResult {
+func CheckPermissions(ctx context.Context, node permissions.Node, username string, policies []*model.Policy, reqCtx *RequestContext, permAudit *MissingPermissions) CheckResult {
 	allowed := CheckNeutral
 	switch node.Type {
 	case permissions.NodeTypeNode:
-		hasPermission := false
-		// check whether the permission is allowed, denied or natural (not allowed and not denied)
-		for _, policy := range policies {
-			for _, stmt := range policy.Statement {
-				resources, err := ParsePolicyResourceAsList(stmt.Resource)
-				if err != nil {
-					logging.FromContext(ctx).Error(err)
-					return CheckDeny
-				}
-				for _, resource := range resources {
-					resource = interpolateUser(resource, username)
-					if !ArnMatch(resource, node.Permission.Resource) {
-						continue
-					}
-
-					for _, action := range stmt.Action {
-						if !wildcard.Match(action, node.Permission.Action) {
-							continue // not a matching action
-						}
-
-						if stmt.Effect == model.StatementEffectDeny {
-							// this is a "Deny" and it takes precedence
-							permAudit.Denied = append(permAudit.Denied, action)
-							return CheckDeny
-						}
-						hasPermission = true
-						allowed = CheckAllow
-					}
-				}
-			}
-		}
-		if !hasPermission {
-			permAudit.Unauthorized = append(permAudit.Unauthorized, node.Permission.Action)
+		allowed = checkPermission(ctx, node.Permission, username, policies, reqCtx, permAudit)
+		// the inline policy of the access key narrows what the user is allowed
+		if credentialPolicy := reqCtx.credentialPolicy(); allowed == CheckAllow && credentialPolicy != nil {
+			allowed = checkPermission(ctx, node.Permission, username, []*model.Policy{credentialPolicy}, reqCtx, permAudit)
 		}
 
 	case permissions.NodeTypeOr:
@@ -1222,7 +1295,7 @@ func CheckPermissions(ctx context.Context, node permissions.Node, username strin
 		// Denied - one of the permissions is Deny
 		// Natural - otherwise
 		for _, node := range node.Nodes {
-			result := CheckPermissions(ctx, node, username, policies, permAudit)
+			result := CheckPermissions(ctx, node, username, policies, reqCtx, permAudit)
 			if result == CheckDeny {
 				return CheckDeny
 			}
@@ -1237,7 +1310,7 @@ func CheckPermissions(ctx context.Context, node permissions.Node, username strin
 		// Denied - one of the permissions is Deny
 		// Natural - otherwise
 		for _, node := range node.Nodes {
-			result := CheckPermissions(ctx, node, username, policies, permAudit)
+			result := CheckPermissions(ctx, node, username, policies, reqCtx, permAudit)
 			if result == CheckNeutral || result == CheckDeny {
 				return result
 			}
@@ -1251,6 +1324,105 @@ func CheckPermissions(ctx context.Context, node permissions.Node, username strin
 	return allowed
 }
 
+// checkPermission returns whether policies allow, deny or are neutral (neither allow nor deny) on a single permission
+func checkPermission(ctx context.Context, permission permissions.Permission, username string, policies []*model.Policy, reqCtx *RequestContext, permAudit *MissingPermissions) CheckResult {
+	allowed := CheckNeutral
+	for _, policy := range policies {
+		for _, stmt := range policy.Statement {
+			action, match, err := statementMatch(stmt, permission, username, reqCtx)
+			if err != nil {
+				logging.FromContext(ctx).WithError(err).WithField("policy", policy.DisplayName).Error("Failed to evaluate policy statement")
+				return CheckDeny
+			}
+			if !match {
+				continue
+			}
+			if stmt.Effect == model.StatementEffectDeny {
+				// this is a "Deny" and it takes precedence
+				permAudit.Denied = append(permAudit.Denied, action)
+				return CheckDeny
+			}
+			allowed = CheckAllow
+		}
+	}
+	if allowed != CheckAllow {
+		permAudit.Unauthorized = append(permAudit.Unauthorized, permission.Action)
+	}
+	return allowed
+}
+
+// statementMatch returns the statement action matching permission, if the statement applies to permission for the request.
+// A "Deny" statement applies to requests missing the keys its condition tests.
+func statementMatch(stmt model.Statement, permission permissions.Permission, username string, reqCtx *RequestContext) (string, bool, error) {
+	applies, err := conditionMatch(stmt.Condition, reqCtx, stmt.Effect == model.StatementEffectDeny)
+	if err != nil || !applies {
+		return "", false, err
+	}
+	resources, err := ParsePolicyResourceAsList(stmt.Resource)
+	if err != nil {
+		return "", false, err
+	}
+	for _, resource := range resources {
+		resource = interpolateUser(resource, username)
+		if !ArnMatch(resource, permission.Resource) {
+			continue
+		}
+		for _, action := range stmt.Action {
+			if wildcard.Match(action, permission.Action) {
+				return action, true, nil
+			}
+		}
+	}
+	return "", false, nil
+}
+
+// PermissionDecision is the result of evaluating policies for a single permission, and the statement that produced it
+type PermissionDecision struct {
+	Result CheckResult
+	// Policy holding the deciding statement, nil if no statement applies
+	Policy *model.Policy
+	// StatementIndex of the deciding statement in the policy
+	StatementIndex int
+}
+
+// EvaluatePermission evaluates policies for a single permission the same way CheckPermissions does, and returns the
+// statement that decided it: the first applying "deny" statement, or else the first applying "allow" statement.
+// If policies allow and the request context has a credential policy, the credential policy decides instead.
+// A statement that cannot be evaluated decides CheckDeny, and is returned together with the error.
+func EvaluatePermission(permission permissions.Permission, username string, policies []*model.Policy, reqCtx *RequestContext) (*PermissionDecision, error) {
+	decision, err := evaluatePolicies(permission, username, policies, reqCtx)
+	if err != nil || decision.Result != CheckAllow {
+		return decision, err
+	}
+	// the inline policy of the access key narrows what the user is allowed
+	if credentialPolicy := reqCtx.credentialPolicy(); credentialPolicy != nil {
+		return evaluatePolicies(permission, username, []*model.Policy{credentialPolicy}, reqCtx)
+	}
+	return decision, nil
+}
+
+func evaluatePolicies(permission permissions.Permission, username string, policies []*model.Policy, reqCtx *RequestContext) (*PermissionDecision, error) {
+	decision := &PermissionDecision{Result: CheckNeutral}
+	for _, policy := range policies {
+		for i, stmt := range policy.Statement {
+			_, match, err := statementMatch(stmt, permission, username, reqCtx)
+			if err != nil {
+				return &PermissionDecision{Result: CheckDeny, Policy: policy, StatementIndex: i}, err
+			}
+			if !match {
+				continue
+			}
+			if stmt.Effect == model.StatementEffectDeny {
+				return &PermissionDecision{Result: CheckDeny, Policy: policy, StatementIndex: i}, nil
+			}
+			if decision.Result == CheckNeutral {
+				decision = &PermissionDecision{Result: CheckAllow, Policy: policy, StatementIndex: i}
+			}
+		}
+	}
+	return decision, nil
+}
+
 func interpolateUser(resource string, username string) string {
 	return strings.ReplaceAll(resource, "${user}", username)
 }
@@ -1861,7 +1861,7 @@ func TestAPIAuthService_CreateCredentials(t *testing.T) {
 					SecretAccessKey: tt.returnedSecretKey,
 				},
 			}
-			mockClient.EXPECT().CreateCredentialsWithResponse(gomock.Any(), tt.username, &auth.CreateCredentialsParams{}).Return(response, nil)
+			mockClient.EXPECT().CreateCredentialsWithResponse(gomock.Any(), tt.username, &auth.CreateCredentialsParams{}, auth.CreateCredentialsJSONRequestBody{}).Return(response, nil)
 			ctx := context.Background()
 			resCredentials, err := s.CreateCredentials(ctx, tt.username)
 			if !errors.Is(err, tt.expectedErr) {
@@ -1964,7 +1964,7 @@ func TestAPIAuthService_AddCredentials(t *testing.T) {
 			mockClient.EXPECT().CreateCredentialsWithResponse(gomock.Any(), tt.username, &auth.CreateCredentialsParams{
 				AccessKey: &tt.accessKey,
 				SecretKey: &tt.secretKey,
-			}).Return(response, nil)
+			}, auth.CreateCredentialsJSONRequestBody{}).Return(response, nil)
 			ctx := context.Background()
 			resCredentials, err := s.AddCredentials(ctx, tt.username, tt.accessKey, tt.secretKey)
 			if !errors.Is(err, tt.expectedErr) {
@@ -72,6 +72,37 @@ type ObjectPointer struct {
 	// Indicates whether the Identifier is relative to the StorageNamespace,
 	// full address to an object, or unknown.
 	IdentifierType IdentifierType
+
+	// CustomerKey is the key the object was encrypted with, if it was written with
+	// ServerSideEncryptionCustomerKey.  It is required to read the object, and to
+	// upload parts of a multipart upload created with a customer key.
+	CustomerKey []byte
+}
+
+// ServerSideEncryptionMode is the way the object store encrypts the data of an object
+type ServerSideEncryptionMode string
+
+const (
+	// ServerSideEncryptionAES256 encrypts with keys managed by the object store
+	ServerSideEncryptionAES256 ServerSideEncryptionMode = "AES256"
+	// ServerSideEncryptionKMS encrypts with a key of the key management service of the object store
+	ServerSideEncryptionKMS ServerSideEncryptionMode = "aws:kms"
+	// ServerSideEncryptionCustomerKey encrypts with a key the client provides on every request
+	ServerSideEncryptionCustomerKey ServerSideEncryptionMode = "customer-key"
+)
+
+// CustomerKeySize is the size of a customer provided encryption key: an AES-256 key
+const CustomerKeySize = 32
+
+// ServerSideEncryption requests encryption of the data of an object by the object store
+type ServerSideEncryption struct {
+	Mode ServerSideEncryptionMode
+	// KMSKeyID is the key used with ServerSideEncryptionKMS.  If empty, the default key
+	// of the object store is used.
+	KMSKeyID string
+	// CustomerKey is the key used with ServerSideEncryptionCustomerKey.  The object
+	// store does not keep it: it is needed again to read the object.
+	CustomerKey []byte
 }
 
 // PutOpts contains optional arguments for Put.  These should be
@@ -83,7 +114,8 @@ type ObjectPointer struct {
 // contents but different option values, the first supplied option
 // value is retained.
 type PutOpts struct {
-	StorageClass *string // S3 storage class
+	StorageClass         *string               // S3 storage class
+	ServerSideEncryption *ServerSideEncryption // overrides the encryption configured on the adapter
 }
 
 // WalkOpts is a unique identifier of a prefix in the object store.
@@ -141,7 +173,8 @@ type ListMultipartUploadsResponse struct {
 // contents but different option values, the first supplied option
 // value is retained.
 type CreateMultiPartUploadOpts struct {
-	StorageClass *string // S3 storage class
+	StorageClass         *string               // S3 storage class
+	ServerSideEncryption *ServerSideEncryption // overrides the encryption configured on the adapter
 }
 
 // ListPartsOpts contains optional arguments for the ListParts request.
@@ -2,6 +2,8 @@ package azure
 
 import (
 	"context"
+	"crypto/sha256"
+	"encoding/base64"
 	"errors"
 	"fmt"
 	"io"
@@ -14,6 +16,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/Azure/azure-sdk-for-go/sdk/azcore/to"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/blob"
 	"github.com/Azure/azure-sdk-for-go/sdk/storage/azblob/bloberror"
@@ -173,6 +176,17 @@ func resolveBlobURLInfo(obj block.ObjectPointer) (BlobURLInfo, error) {
 
 func (a *Adapter) translatePutOpts(ctx context.Context, opts block.PutOpts) azblob.UploadStreamOptions {
 	res := azblob.UploadStreamOptions{}
+	if sse := opts.ServerSideEncryption; sse != nil {
+		switch sse.Mode {
+		case block.ServerSideEncryptionCustomerKey:
+			res.CPKInfo = customerProvidedKeyInfo(sse.CustomerKey)
+		case block.ServerSideEncryptionKMS:
+			// Azure encrypts with customer-managed keys through encryption scopes
+			if sse.KMSKeyID != "" {
+				res.CPKScopeInfo = &blob.CPKScopeInfo{EncryptionScope: &sse.KMSKeyID}
+			}
+		}
+	}
 	if opts.StorageClass == nil {
 		return res
 	}
@@ -192,6 +206,19 @@ func (a *Adapter) translatePutOpts(ctx context.Context, opts block.PutOpts) azbl
 	return res
 }
 
+// customerProvidedKeyInfo returns the parameters to use key as a customer-provided key, nil if key is nil
+func customerProvidedKeyInfo(key []byte) *blob.CPKInfo {
+	if key == nil {
+		return nil
+	}
+	sum := sha256.Sum256(key)
+	return &blob.CPKInfo{
+		EncryptionAlgorithm: to.Ptr(blob.EncryptionAlgorithmTypeAES256),
+		EncryptionKey:       to.Ptr(base64.StdEncoding.EncodeToString(key)),
+		EncryptionKeySHA256: to.Ptr(base64.StdEncoding.EncodeToString(sum[:])),
+	}
+}
+
 func (a *Adapter) log(ctx context.Context) logging.Logger {
 	return logging.FromContext(ctx)
 }
@@ -364,6 +391,7 @@ func (a *Adapter) Download(ctx context.Context, obj block.ObjectPointer, offset,
 			Offset: offset,
 			Count:  count,
 		},
+		CPKInfo: customerProvidedKeyInfo(obj.CustomerKey),
 	})
 	if bloberror.HasCode(err, bloberror.BlobNotFound) {
 		return nil, block.ErrDataNotFound
@@ -531,10 +559,15 @@ func (a *Adapter) Copy(ctx context.Context, sourceObj, destinationObj block.Obje
 	}
 }
 
-func (a *Adapter) CreateMultiPartUpload(_ context.Context, obj block.ObjectPointer, _ *http.Request, _ block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
+func (a *Adapter) CreateMultiPartUpload(_ context.Context, obj block.ObjectPointer, _ *http.Request, opts block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
 	// Azure has no create multipart upload
 	var err error
 	defer reportMetrics("CreateMultiPartUpload", obj.StorageID, time.Now(), nil, &err)
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Mode != block.ServerSideEncryptionAES256 {
+		// parts are staged and committed without encryption parameters
+		err = fmt.Errorf("%w: multipart upload with server-side encryption", block.ErrOperationNotSupported)
+		return nil, err
+	}
 
 	qualifiedKey, err := resolveBlobURLInfo(obj)
 	if err != nil {
@@ -588,6 +621,9 @@ func (a *Adapter) UploadCopyPartRange(ctx context.Context, sourceObj, destinatio
 }
 
 func (a *Adapter) copyPartRange(ctx context.Context, sourceObj, destinationObj block.ObjectPointer, startPosition, count int64) (*block.UploadPartResponse, error) {
+	if sourceObj.CustomerKey != nil {
+		return nil, fmt.Errorf("%w: copy from object encrypted with a customer-provided key", block.ErrOperationNotSupported)
+	}
 	qualifiedSourceKey, err := resolveBlobURLInfo(sourceObj)
 	if err != nil {
 		return nil, err
@@ -129,6 +129,32 @@ type storageObjectHandle struct {
 	*storage.ObjectHandle
 }
 
+// withObjectReadHandle returns a handle for reading an object written with customerKey, or based on the
+// encryption settings if it has no customer key.
+func (o *storageObjectHandle) withObjectReadHandle(ctx context.Context, a *Adapter, customerKey []byte) *storageObjectHandle {
+	if customerKey == nil {
+		return o.withReadHandle(ctx, a)
+	}
+	o.ObjectHandle = o.Key(customerKey)
+	return o
+}
+
+// newEncryptedWriter returns a writer of the object encrypted with sse, instead of the encryption settings
+func (o *storageObjectHandle) newEncryptedWriter(ctx context.Context, sse *block.ServerSideEncryption) *storage.Writer {
+	switch sse.Mode {
+	case block.ServerSideEncryptionCustomerKey:
+		return o.Key(sse.CustomerKey).NewWriter(ctx)
+	case block.ServerSideEncryptionKMS:
+		w := o.NewWriter(ctx)
+		// an empty key name uses the default key of the bucket
+		w.KMSKeyName = sse.KMSKeyID
+		return w
+	default:
+		// GCS always encrypts with keys it manages
+		return o.NewWriter(ctx)
+	}
+}
+
 func (o *storageObjectHandle) withWriteHandle(a *Adapter) *storageObjectHandle {
 	if a.ServerSideEncryptionCustomerSupplied != nil {
 		o.ObjectHandle = o.Key(a.ServerSideEncryptionCustomerSupplied)
@@ -160,7 +186,7 @@ func (o *storageObjectHandle) newComposer(a *Adapter, srcs ...*storage.ObjectHan
 	return c
 }
 
-func (a *Adapter) Put(ctx context.Context, obj block.ObjectPointer, sizeBytes int64, reader io.Reader, _ block.PutOpts) (*block.PutResponse, error) {
+func (a *Adapter) Put(ctx context.Context, obj block.ObjectPointer, sizeBytes int64, reader io.Reader, opts block.PutOpts) (*block.PutResponse, error) {
 	var err error
 	defer reportMetrics("Put", obj.StorageID, time.Now(), &sizeBytes, &err)
 	bucket, key, err := a.extractParamsFromObj(obj)
@@ -168,7 +194,12 @@ func (a *Adapter) Put(ctx context.Context, obj block.ObjectPointer, sizeBytes in
 		return nil, err
 	}
 	h := storageObjectHandle{a.client.Bucket(bucket).Object(key)}
-	w := h.withWriteHandle(a).newWriter(ctx, a)
+	var w *storage.Writer
+	if opts.ServerSideEncryption != nil {
+		w = h.newEncryptedWriter(ctx, opts.ServerSideEncryption)
+	} else {
+		w = h.withWriteHandle(a).newWriter(ctx, a)
+	}
 	_, err = io.Copy(w, reader)
 	if err != nil {
 		return nil, fmt.Errorf("io.Copy: %w", err)
@@ -188,7 +219,7 @@ func (a *Adapter) Get(ctx context.Context, obj block.ObjectPointer) (io.ReadClos
 		return nil, err
 	}
 	h := storageObjectHandle{a.client.Bucket(bucket).Object(key)}
-	objHandle := h.withReadHandle(ctx, a)
+	objHandle := h.withObjectReadHandle(ctx, a, obj.CustomerKey)
 	r, err := objHandle.NewReader(ctx)
 	if isErrNotFound(err) {
 		return nil, block.ErrDataNotFound
@@ -293,7 +324,7 @@ func (a *Adapter) GetRange(ctx context.Context, obj block.ObjectPointer, startPo
 		return nil, err
 	}
 	h := storageObjectHandle{a.client.Bucket(bucket).Object(key)}
-	objHandle := h.withReadHandle(ctx, a)
+	objHandle := h.withObjectReadHandle(ctx, a, obj.CustomerKey)
 	r, err := objHandle.NewRangeReader(ctx, startPosition, endPosition-startPosition+1)
 	if isErrNotFound(err) {
 		return nil, block.ErrDataNotFound
@@ -357,9 +388,14 @@ func (a *Adapter) Copy(ctx context.Context, sourceObj, destinationObj block.Obje
 	return nil
 }
 
-func (a *Adapter) CreateMultiPartUpload(ctx context.Context, obj block.ObjectPointer, _ *http.Request, _ block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
+func (a *Adapter) CreateMultiPartUpload(ctx context.Context, obj block.ObjectPointer, _ *http.Request, opts block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
 	var err error
 	defer reportMetrics("CreateMultiPartUpload", obj.StorageID, time.Now(), nil, &err)
+	if opts.ServerSideEncryption != nil && opts.ServerSideEncryption.Mode != block.ServerSideEncryptionAES256 {
+		// parts are composed with the encryption settings of the adapter
+		err = fmt.Errorf("%w: multipart upload with server-side encryption", block.ErrOperationNotSupported)
+		return nil, err
+	}
 	bucket, uploadID, err := a.extractParamsFromObj(obj)
 	if err != nil {
 		return nil, err
@@ -430,7 +466,7 @@ func (a *Adapter) UploadCopyPart(ctx context.Context, sourceObj, destinationObj
 	}
 
 	srcHandle := &storageObjectHandle{a.client.Bucket(srcBucket).Object(srcKey)}
-	srcHandle = srcHandle.withReadHandle(ctx, a)
+	srcHandle = srcHandle.withObjectReadHandle(ctx, a, sourceObj.CustomerKey)
 	h := storageObjectHandle{a.client.Bucket(bucket).Object(objName)}
 	copier := h.withWriteHandle(a).newCopier(a, srcHandle.ObjectHandle)
 	attrs, err := copier.Run(ctx)
@@ -151,24 +151,43 @@ func (l *Adapter) Path() string {
 	return l.path
 }
 
-func (l *Adapter) Put(_ context.Context, obj block.ObjectPointer, _ int64, reader io.Reader, _ block.PutOpts) (*block.PutResponse, error) {
+func (l *Adapter) Put(_ context.Context, obj block.ObjectPointer, _ int64, reader io.Reader, opts block.PutOpts) (*block.PutResponse, error) {
+	if err := l.put(obj, reader, opts.ServerSideEncryption, 0); err != nil {
+		return nil, err
+	}
+	return &block.PutResponse{}, nil
+}
+
+// putPart writes the data of reader to the file of part partNumber of uploadID
+func (l *Adapter) putPart(obj block.ObjectPointer, reader io.Reader, uploadID string, partNumber int) error {
+	objectPointer := block.ObjectPointer{
+		StorageID:        obj.StorageID,
+		StorageNamespace: obj.StorageNamespace,
+		Identifier:       uploadID + fmt.Sprintf("-%05d", partNumber),
+	}
+	return l.put(objectPointer, reader, customerKeyEncryption(obj), uint32(partNumber)) //nolint:gosec // part numbers are positive
+}
+
+// put writes the data of reader to obj, encrypted with sse as part number part
+func (l *Adapter) put(obj block.ObjectPointer, reader io.Reader, sse *block.ServerSideEncryption, part uint32) error {
 	p, err := l.extractParamsFromObj(obj)
 	if err != nil {
-		return nil, err
+		return err
+	}
+	reader, err = encryptReader(reader, sse, part)
+	if err != nil {
+		return err
 	}
 	p = filepath.Clean(p)
 	f, err := l.maybeMkdir(p, os.Create)
 	if err != nil {
-		return nil, err
+		return err
 	}
 	defer func() {
 		_ = f.Close()
 	}()
 	_, err = io.Copy(f, reader)
-	if err != nil {
-		return nil, err
-	}
-	return &block.PutResponse{}, nil
+	return err
 }
 
 func (l *Adapter) Remove(_ context.Context, obj block.ObjectPointer) error {
@@ -244,13 +263,7 @@ func (l *Adapter) UploadCopyPart(ctx context.Context, sourceObj, destinationObj
 		return nil, fmt.Errorf("copy get: %w", err)
 	}
 	md5Read := block.NewHashingReader(r, block.HashFunctionMD5)
-	fName := uploadID + fmt.Sprintf("-%05d", partNumber)
-	objectPointer := block.ObjectPointer{
-		StorageID:        destinationObj.StorageID,
-		StorageNamespace: destinationObj.StorageNamespace,
-		Identifier:       fName,
-	}
-	_, err = l.Put(ctx, objectPointer, -1, md5Read, block.PutOpts{})
+	err = l.putPart(destinationObj, md5Read, uploadID, partNumber)
 	if err != nil {
 		return nil, fmt.Errorf("copy put: %w", err)
 	}
@@ -269,13 +282,7 @@ func (l *Adapter) UploadCopyPartRange(ctx context.Context, sourceObj, destinatio
 		return nil, fmt.Errorf("copy range get: %w", err)
 	}
 	md5Read := block.NewHashingReader(r, block.HashFunctionMD5)
-	fName := uploadID + fmt.Sprintf("-%05d", partNumber)
-	objectPointer := block.ObjectPointer{
-		StorageID:        destinationObj.StorageID,
-		StorageNamespace: destinationObj.StorageNamespace,
-		Identifier:       fName,
-	}
-	_, err = l.Put(ctx, objectPointer, -1, md5Read, block.PutOpts{})
+	err = l.putPart(destinationObj, md5Read, uploadID, partNumber)
 	if err != nil {
 		return nil, fmt.Errorf("copy range put: %w", err)
 	}
@@ -297,7 +304,21 @@ func (l *Adapter) Get(_ context.Context, obj block.ObjectPointer) (reader io.Rea
 	if err != nil {
 		return nil, err
 	}
-	return f, nil
+	if obj.CustomerKey == nil {
+		return f, nil
+	}
+	decrypted, err := newDecryptingReader(f, obj.CustomerKey)
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
+	return &struct {
+		io.Reader
+		io.Closer
+	}{
+		Reader: decrypted,
+		Closer: f,
+	}, nil
 }
 
 func (l *Adapter) GetWalker(_ string, opts block.WalkerOptions) (block.Walker, error) {
@@ -346,11 +367,28 @@ func (l *Adapter) GetRange(_ context.Context, obj block.ObjectPointer, start int
 		}
 		return nil, err
 	}
+	if obj.CustomerKey == nil {
+		return &struct {
+			io.Reader
+			io.Closer
+		}{
+			Reader: io.NewSectionReader(f, start, end-start+1),
+			Closer: f,
+		}, nil
+	}
+	decrypted, err := newDecryptingReader(f, obj.CustomerKey)
+	if err == nil {
+		err = decrypted.skip(start)
+	}
+	if err != nil {
+		_ = f.Close()
+		return nil, err
+	}
 	return &struct {
 		io.Reader
 		io.Closer
 	}{
-		Reader: io.NewSectionReader(f, start, end-start+1),
+		Reader: io.LimitReader(decrypted, end-start+1),
 		Closer: f,
 	}, nil
 }
@@ -382,7 +420,10 @@ func isDirectoryWritable(pth string) bool {
 	return true
 }
 
-func (l *Adapter) CreateMultiPartUpload(_ context.Context, obj block.ObjectPointer, _ *http.Request, _ block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
+func (l *Adapter) CreateMultiPartUpload(ctx context.Context, obj block.ObjectPointer, _ *http.Request, opts block.CreateMultiPartUploadOpts) (*block.CreateMultiPartUploadResponse, error) {
+	if sse := opts.ServerSideEncryption; sse != nil && sse.Mode == block.ServerSideEncryptionKMS {
+		return nil, fmt.Errorf("%w: KMS encryption", block.ErrOperationNotSupported)
+	}
 	if strings.Contains(obj.Identifier, "/") {
 		fullPath, err := l.extractParamsFromObj(obj)
 		if err != nil {
@@ -396,23 +437,27 @@ func (l *Adapter) CreateMultiPartUpload(_ context.Context, obj block.ObjectPoint
 	}
 	uidBytes := uuid.New()
 	uploadID := hex.EncodeToString(uidBytes[:])
+	if sse := opts.ServerSideEncryption; sse != nil && sse.Mode == block.ServerSideEncryptionCustomerKey {
+		marker := block.ObjectPointer{
+			StorageID:        obj.StorageID,
+			StorageNamespace: obj.StorageNamespace,
+			Identifier:       uploadID + encryptedUploadMarker,
+		}
+		if _, err := l.Put(ctx, marker, 0, strings.NewReader(""), block.PutOpts{}); err != nil {
+			return nil, err
+		}
+	}
 	return &block.CreateMultiPartUploadResponse{
 		UploadID: uploadID,
 	}, nil
 }
 
-func (l *Adapter) UploadPart(ctx context.Context, obj block.ObjectPointer, _ int64, reader io.Reader, uploadID string, partNumber int) (*block.UploadPartResponse, error) {
+func (l *Adapter) UploadPart(_ context.Context, obj block.ObjectPointer, _ int64, reader io.Reader, uploadID string, partNumber int) (*block.UploadPartResponse, error) {
 	if err := isValidUploadID(uploadID); err != nil {
 		return nil, err
 	}
 	md5Read := block.NewHashingReader(reader, block.HashFunctionMD5)
-	fName := uploadID + fmt.Sprintf("-%05d", partNumber)
-	objectPointer := block.ObjectPointer{
-		StorageID:        obj.StorageID,
-		StorageNamespace: obj.StorageNamespace,
-		Identifier:       fName,
-	}
-	_, err := l.Put(ctx, objectPointer, -1, md5Read, block.PutOpts{})
+	err := l.putPart(obj, md5Read, uploadID, partNumber)
 	etag := hex.EncodeToString(md5Read.Md5.Sum(nil))
 	return &block.UploadPartResponse{
 		ETag: etag,
@@ -449,6 +494,16 @@ func (l *Adapter) CompleteMultiPartUpload(_ context.Context, obj block.ObjectPoi
 	if err = l.removePartFiles(partFiles); err != nil {
 		return nil, err
 	}
+	if slices.ContainsFunc(partFiles, isEncryptedUploadMarker) {
+		p, err := l.extractParamsFromObj(obj)
+		if err != nil {
+			return nil, err
+		}
+		size, err = encryptedFileDataSize(p)
+		if err != nil {
+			return nil, err
+		}
+	}
 	return &block.CompleteMultiPartUploadResponse{
 		ETag:          etag,
 		ContentLength: size,
@@ -518,6 +573,10 @@ func (l *Adapter) removePartFiles(files []string) error {
 	return firstErr
 }
 
+func isEncryptedUploadMarker(name string) bool {
+	return strings.HasSuffix(name, encryptedUploadMarker)
+}
+
 func (l *Adapter) getPartFiles(uploadID string, obj block.ObjectPointer) ([]string, error) {
 	newObj := block.ObjectPointer{
 		StorageID:        obj.StorageID,
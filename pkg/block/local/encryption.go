@@ -0,0 +1,340 @@
+package local
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/treeverse/lakefs/pkg/block"
+)
+
+// Objects written with a customer key are stored as a sequence of frames, each holding
+// up to encryptionFrameSize bytes of data sealed with AES-GCM:
+//
+//	| sealed length (uint32) | part number (uint32) | flags (uint8) | nonce | sealed data and tag |
+//
+// Integers are big endian.  The data of a frame is authenticated along with its part
+// number, its index in the part and its flags, so frames cannot be reordered, dropped or
+// moved between parts.  The last frame of a part is flagged encryptionFrameFinal, and
+// reading fails if the data ends before a final frame, so a part cannot be truncated.
+//
+// Objects written in one piece are part 0.  The parts of a multipart upload are encrypted
+// on their own, as completing the upload passes no key, and concatenating their files
+// yields a valid encrypted object.  Readers check that part numbers increase, but cannot
+// tell if a whole trailing part was dropped.
+const (
+	encryptionFrameSize   = 64 * 1024
+	encryptionFrameHeader = 9
+	encryptionFrameFinal  = 1
+	// standard AES-GCM sizes, used to read frames without a key
+	encryptionNonceSize = 12
+	encryptionTagSize   = 16
+)
+
+var (
+	ErrInvalidCustomerKey   = errors.New("invalid customer key")
+	ErrInvalidEncryptedData = errors.New("invalid encrypted data")
+)
+
+func newCustomerKeyAEAD(key []byte) (cipher.AEAD, error) {
+	if len(key) != block.CustomerKeySize {
+		return nil, fmt.Errorf("%w: key must be %d bytes", ErrInvalidCustomerKey, block.CustomerKeySize)
+	}
+	c, err := aes.NewCipher(key)
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(c)
+}
+
+// frameHeader is the header of an encryption frame
+type frameHeader struct {
+	sealedLen int
+	part      uint32
+	flags     uint8
+}
+
+func (h frameHeader) final() bool {
+	return h.flags&encryptionFrameFinal != 0
+}
+
+func (h frameHeader) marshal() []byte {
+	buf := make([]byte, encryptionFrameHeader)
+	binary.BigEndian.PutUint32(buf, uint32(h.sealedLen)) //nolint:gosec // bounded by encryptionFrameSize
+	binary.BigEndian.PutUint32(buf[4:], h.part)
+	buf[8] = h.flags
+	return buf
+}
+
+func unmarshalFrameHeader(buf []byte) frameHeader {
+	return frameHeader{
+		sealedLen: int(binary.BigEndian.Uint32(buf)),
+		part:      binary.BigEndian.Uint32(buf[4:]),
+		flags:     buf[8],
+	}
+}
+
+// frameAdditionalData returns the additional data authenticated with the frame at index in its part
+func frameAdditionalData(h frameHeader, index uint64) []byte {
+	ad := make([]byte, 4+8+1) //nolint:mnd // part number, index and flags
+	binary.BigEndian.PutUint32(ad, h.part)
+	binary.BigEndian.PutUint64(ad[4:], index)
+	ad[12] = h.flags
+	return ad
+}
+
+// encryptingReader reads the data of src encrypted into the frames of a part
+type encryptingReader struct {
+	aead  cipher.AEAD
+	src   *bufio.Reader
+	part  uint32
+	index uint64
+	plain []byte
+	out   bytes.Buffer
+	err   error
+}
+
+func newEncryptingReader(src io.Reader, key []byte, part uint32) (*encryptingReader, error) {
+	aead, err := newCustomerKeyAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &encryptingReader{
+		aead:  aead,
+		src:   bufio.NewReader(src),
+		part:  part,
+		plain: make([]byte, encryptionFrameSize),
+	}, nil
+}
+
+func (r *encryptingReader) Read(p []byte) (int, error) {
+	for r.out.Len() == 0 && r.err == nil {
+		n, err := io.ReadFull(r.src, r.plain)
+		switch {
+		case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+			err = nil
+			r.err = io.EOF
+		case err == nil:
+			// a full frame is final if no data follows it
+			if _, err = r.src.Peek(1); errors.Is(err, io.EOF) {
+				err = nil
+				r.err = io.EOF
+			}
+		}
+		if err != nil {
+			r.err = err
+			break
+		}
+		if err := r.sealFrame(r.plain[:n], r.err != nil); err != nil {
+			return 0, err
+		}
+	}
+	if r.out.Len() > 0 {
+		return r.out.Read(p)
+	}
+	return 0, r.err
+}
+
+func (r *encryptingReader) sealFrame(plain []byte, final bool) error {
+	nonce := make([]byte, r.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return err
+	}
+	h := frameHeader{part: r.part}
+	if final {
+		h.flags |= encryptionFrameFinal
+	}
+	sealed := r.aead.Seal(nil, nonce, plain, frameAdditionalData(h, r.index))
+	r.index++
+	h.sealedLen = len(sealed)
+	r.out.Write(h.marshal())
+	r.out.Write(nonce)
+	r.out.Write(sealed)
+	return nil
+}
+
+// decryptingReader reads the data of frames read from src
+type decryptingReader struct {
+	aead   cipher.AEAD
+	src    io.Reader
+	sealed []byte
+	plain  []byte
+	// header is the header of the last frame read, index its index in its part
+	header  frameHeader
+	index   uint64
+	started bool
+}
+
+func newDecryptingReader(src io.Reader, key []byte) (*decryptingReader, error) {
+	aead, err := newCustomerKeyAEAD(key)
+	if err != nil {
+		return nil, err
+	}
+	return &decryptingReader{
+		aead:   aead,
+		src:    src,
+		sealed: make([]byte, aead.NonceSize()+encryptionFrameSize+aead.Overhead()),
+	}, nil
+}
+
+func (r *decryptingReader) Read(p []byte) (int, error) {
+	for len(r.plain) == 0 {
+		if err := r.readFrameHeader(); err != nil {
+			return 0, err
+		}
+		if err := r.readFrame(); err != nil {
+			return 0, err
+		}
+	}
+	n := copy(p, r.plain)
+	r.plain = r.plain[n:]
+	return n, nil
+}
+
+// readFrame decrypts the rest of the frame whose header was read last
+func (r *decryptingReader) readFrame() error {
+	frame := r.sealed[:r.aead.NonceSize()+r.header.sealedLen]
+	if _, err := io.ReadFull(r.src, frame); err != nil {
+		return fmt.Errorf("%w: truncated frame", ErrInvalidEncryptedData)
+	}
+	nonce, sealed := frame[:r.aead.NonceSize()], frame[r.aead.NonceSize():]
+	plain, err := r.aead.Open(sealed[:0], nonce, sealed, frameAdditionalData(r.header, r.index))
+	if err != nil {
+		return fmt.Errorf("%w: wrong key or corrupt data", ErrInvalidEncryptedData)
+	}
+	r.plain = plain
+	return nil
+}
+
+// readFrameHeader reads the header of the next frame, io.EOF if the data ended after a final frame
+func (r *decryptingReader) readFrameHeader() error {
+	var buf [encryptionFrameHeader]byte
+	_, err := io.ReadFull(r.src, buf[:])
+	if errors.Is(err, io.EOF) {
+		if !r.started || !r.header.final() {
+			return fmt.Errorf("%w: data ends before the final frame", ErrInvalidEncryptedData)
+		}
+		return io.EOF
+	}
+	if err != nil {
+		return fmt.Errorf("%w: truncated frame header", ErrInvalidEncryptedData)
+	}
+	h := unmarshalFrameHeader(buf[:])
+	if h.sealedLen < r.aead.Overhead() || h.sealedLen > encryptionFrameSize+r.aead.Overhead() {
+		return fmt.Errorf("%w: frame length %d", ErrInvalidEncryptedData, h.sealedLen)
+	}
+	switch {
+	case !r.started:
+		r.index = 0
+	case r.header.final():
+		if h.part <= r.header.part {
+			return fmt.Errorf("%w: part %d follows part %d", ErrInvalidEncryptedData, h.part, r.header.part)
+		}
+		r.index = 0
+	default:
+		if h.part != r.header.part {
+			return fmt.Errorf("%w: part %d ends before its final frame", ErrInvalidEncryptedData, r.header.part)
+		}
+		r.index++
+	}
+	r.header = h
+	r.started = true
+	return nil
+}
+
+// skip discards the first n bytes of data, passing over whole frames without decrypting them
+func (r *decryptingReader) skip(n int64) error {
+	for n > 0 {
+		err := r.readFrameHeader()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		plainLen := int64(r.header.sealedLen - r.aead.Overhead())
+		if plainLen > n {
+			if err := r.readFrame(); err != nil {
+				return err
+			}
+			r.plain = r.plain[n:]
+			return nil
+		}
+		if _, err := io.CopyN(io.Discard, r.src, int64(r.aead.NonceSize()+r.header.sealedLen)); err != nil {
+			return fmt.Errorf("%w: truncated frame", ErrInvalidEncryptedData)
+		}
+		n -= plainLen
+	}
+	return nil
+}
+
+// encryptedFileDataSize returns the size of the data stored encrypted in file name.  It
+// reads only frame headers, so it needs no key.
+func encryptedFileDataSize(name string) (int64, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { _ = f.Close() }()
+	r := bufio.NewReader(f)
+	var size int64
+	for {
+		var buf [encryptionFrameHeader]byte
+		_, err := io.ReadFull(r, buf[:])
+		if errors.Is(err, io.EOF) {
+			return size, nil
+		}
+		if err != nil {
+			return 0, fmt.Errorf("%w: truncated frame header", ErrInvalidEncryptedData)
+		}
+		h := unmarshalFrameHeader(buf[:])
+		if h.sealedLen < encryptionTagSize {
+			return 0, fmt.Errorf("%w: frame length %d", ErrInvalidEncryptedData, h.sealedLen)
+		}
+		if _, err := r.Discard(encryptionNonceSize + h.sealedLen); err != nil {
+			return 0, fmt.Errorf("%w: truncated frame", ErrInvalidEncryptedData)
+		}
+		size += int64(h.sealedLen - encryptionTagSize)
+	}
+}
+
+// encryptedUploadMarker names an empty file written next to the part files of a multipart
+// upload encrypted with a customer key.  Completing the upload needs it to count the data
+// in the frames of the parts, as no key is passed then.  Being empty, it adds nothing when
+// part files are united, and it is removed along with them.
+const encryptedUploadMarker = "-encrypted"
+
+// encryptReader returns a reader of the data of reader as stored with sse, as part number
+// part.  Data is encrypted only with a customer key: there is no key management service for
+// local storage, and the object store keys of ServerSideEncryptionAES256 are left to the disk.
+func encryptReader(reader io.Reader, sse *block.ServerSideEncryption, part uint32) (io.Reader, error) {
+	if sse == nil {
+		return reader, nil
+	}
+	switch sse.Mode {
+	case block.ServerSideEncryptionCustomerKey:
+		return newEncryptingReader(reader, sse.CustomerKey, part)
+	case block.ServerSideEncryptionKMS:
+		return nil, fmt.Errorf("%w: KMS encryption", block.ErrOperationNotSupported)
+	default:
+		return reader, nil
+	}
+}
+
+// customerKeyEncryption returns the encryption of the parts of a multipart upload to obj
+func customerKeyEncryption(obj block.ObjectPointer) *block.ServerSideEncryption {
+	if obj.CustomerKey == nil {
+		return nil
+	}
+	return &block.ServerSideEncryption{
+		Mode:        block.ServerSideEncryptionCustomerKey,
+		CustomerKey: obj.CustomerKey,
+	}
+}
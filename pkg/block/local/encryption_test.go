@@ -0,0 +1,206 @@
+package local_test
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"io"
+	"path"
+	"slices"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/block"
+	"github.com/treeverse/lakefs/pkg/block/local"
+)
+
+func newCustomerKey(t *testing.T) []byte {
+	t.Helper()
+	key := make([]byte, block.CustomerKeySize)
+	_, err := rand.Read(key)
+	require.NoError(t, err)
+	return key
+}
+
+func readAll(t *testing.T, r io.ReadCloser) []byte {
+	t.Helper()
+	defer func() { _ = r.Close() }()
+	data, err := io.ReadAll(r)
+	require.NoError(t, err)
+	return data
+}
+
+func TestAdapterCustomerKeyEncryption(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := local.NewAdapter(path.Join(t.TempDir(), "lakefs"), local.WithRemoveEmptyDir(false))
+	require.NoError(t, err)
+
+	// spans several encryption frames, the last one partial
+	data := make([]byte, 200*1024+17)
+	_, err = rand.Read(data)
+	require.NoError(t, err)
+	key := newCustomerKey(t)
+	obj := block.ObjectPointer{
+		StorageNamespace: testStorageNamespace,
+		Identifier:       "encrypted",
+		IdentifierType:   block.IdentifierTypeRelative,
+	}
+	_, err = adapter.Put(ctx, obj, int64(len(data)), bytes.NewReader(data), block.PutOpts{
+		ServerSideEncryption: &block.ServerSideEncryption{Mode: block.ServerSideEncryptionCustomerKey, CustomerKey: key},
+	})
+	require.NoError(t, err)
+
+	t.Run("stored_encrypted", func(t *testing.T) {
+		r, err := adapter.Get(ctx, obj)
+		require.NoError(t, err)
+		stored := readAll(t, r)
+		require.NotEqual(t, data, stored)
+		require.False(t, bytes.Contains(stored, data[:64]), "stored data contains plain data")
+	})
+
+	t.Run("get", func(t *testing.T) {
+		r, err := adapter.Get(ctx, block.ObjectPointer{
+			StorageNamespace: obj.StorageNamespace,
+			Identifier:       obj.Identifier,
+			IdentifierType:   obj.IdentifierType,
+			CustomerKey:      key,
+		})
+		require.NoError(t, err)
+		require.Equal(t, data, readAll(t, r))
+	})
+
+	t.Run("get_range", func(t *testing.T) {
+		decryptObj := obj
+		decryptObj.CustomerKey = key
+		ranges := []struct{ start, end int64 }{
+			{start: 0, end: 0},
+			{start: 10, end: 100},
+			{start: 64*1024 - 1, end: 64 * 1024},
+			{start: 70 * 1024, end: 190 * 1024},
+			{start: 200 * 1024, end: int64(len(data)) - 1},
+		}
+		for _, rng := range ranges {
+			r, err := adapter.GetRange(ctx, decryptObj, rng.start, rng.end)
+			require.NoError(t, err)
+			require.Equal(t, data[rng.start:rng.end+1], readAll(t, r), "range %d-%d", rng.start, rng.end)
+		}
+	})
+
+	t.Run("wrong_key", func(t *testing.T) {
+		wrongObj := obj
+		wrongObj.CustomerKey = newCustomerKey(t)
+		r, err := adapter.Get(ctx, wrongObj)
+		require.NoError(t, err)
+		defer func() { _ = r.Close() }()
+		_, err = io.ReadAll(r)
+		require.ErrorIs(t, err, local.ErrInvalidEncryptedData)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		r, err := adapter.Get(ctx, obj)
+		require.NoError(t, err)
+		stored := readAll(t, r)
+		// header, nonce, data and tag of a full frame
+		const frameLen = 9 + 12 + 64*1024 + 16
+		frame := func(i int) []byte { return stored[i*frameLen : (i+1)*frameLen] }
+		tampered := map[string][]byte{
+			"truncated_at_frame": stored[:3*frameLen],
+			"truncated_in_frame": stored[:len(stored)-1],
+			"empty":              {},
+			"reordered":          slices.Concat(frame(1), frame(0), stored[2*frameLen:]),
+			"dropped_frame":      slices.Concat(frame(0), stored[2*frameLen:]),
+		}
+		for name, data := range tampered {
+			t.Run(name, func(t *testing.T) {
+				tamperedObj := block.ObjectPointer{
+					StorageNamespace: obj.StorageNamespace,
+					Identifier:       "tampered-" + name,
+					IdentifierType:   obj.IdentifierType,
+				}
+				_, err := adapter.Put(ctx, tamperedObj, int64(len(data)), bytes.NewReader(data), block.PutOpts{})
+				require.NoError(t, err)
+				tamperedObj.CustomerKey = key
+				r, err := adapter.Get(ctx, tamperedObj)
+				require.NoError(t, err)
+				defer func() { _ = r.Close() }()
+				_, err = io.ReadAll(r)
+				require.ErrorIs(t, err, local.ErrInvalidEncryptedData)
+			})
+		}
+	})
+
+	t.Run("empty", func(t *testing.T) {
+		emptyObj := block.ObjectPointer{
+			StorageNamespace: obj.StorageNamespace,
+			Identifier:       "encrypted-empty",
+			IdentifierType:   obj.IdentifierType,
+		}
+		_, err := adapter.Put(ctx, emptyObj, 0, bytes.NewReader(nil), block.PutOpts{
+			ServerSideEncryption: &block.ServerSideEncryption{Mode: block.ServerSideEncryptionCustomerKey, CustomerKey: key},
+		})
+		require.NoError(t, err)
+		emptyObj.CustomerKey = key
+		r, err := adapter.Get(ctx, emptyObj)
+		require.NoError(t, err)
+		require.Empty(t, readAll(t, r))
+	})
+
+	t.Run("invalid_key", func(t *testing.T) {
+		_, err := adapter.Put(ctx, obj, int64(len(data)), bytes.NewReader(data), block.PutOpts{
+			ServerSideEncryption: &block.ServerSideEncryption{Mode: block.ServerSideEncryptionCustomerKey, CustomerKey: []byte("short")},
+		})
+		require.ErrorIs(t, err, local.ErrInvalidCustomerKey)
+	})
+
+	t.Run("kms", func(t *testing.T) {
+		_, err := adapter.Put(ctx, obj, int64(len(data)), bytes.NewReader(data), block.PutOpts{
+			ServerSideEncryption: &block.ServerSideEncryption{Mode: block.ServerSideEncryptionKMS},
+		})
+		require.ErrorIs(t, err, block.ErrOperationNotSupported)
+	})
+}
+
+func TestAdapterCustomerKeyMultipartUpload(t *testing.T) {
+	ctx := context.Background()
+	adapter, err := local.NewAdapter(path.Join(t.TempDir(), "lakefs"), local.WithRemoveEmptyDir(false))
+	require.NoError(t, err)
+
+	key := newCustomerKey(t)
+	obj := block.ObjectPointer{
+		StorageNamespace: testStorageNamespace,
+		Identifier:       "encrypted-multipart",
+		IdentifierType:   block.IdentifierTypeRelative,
+		CustomerKey:      key,
+	}
+	resp, err := adapter.CreateMultiPartUpload(ctx, obj, nil, block.CreateMultiPartUploadOpts{
+		ServerSideEncryption: &block.ServerSideEncryption{Mode: block.ServerSideEncryptionCustomerKey, CustomerKey: key},
+	})
+	require.NoError(t, err)
+
+	var data []byte
+	var parts []block.MultipartPart
+	for partNumber := 1; partNumber <= 2; partNumber++ {
+		part := make([]byte, 100*1024+partNumber)
+		_, err := rand.Read(part)
+		require.NoError(t, err)
+		partResp, err := adapter.UploadPart(ctx, obj, int64(len(part)), bytes.NewReader(part), resp.UploadID, partNumber)
+		require.NoError(t, err)
+		parts = append(parts, block.MultipartPart{PartNumber: partNumber, ETag: partResp.ETag})
+		data = append(data, part...)
+	}
+	// completing passes no key
+	completeObj := obj
+	completeObj.CustomerKey = nil
+	completeResp, err := adapter.CompleteMultiPartUpload(ctx, completeObj, resp.UploadID, &block.MultipartUploadCompletion{Part: parts})
+	require.NoError(t, err)
+	require.Equal(t, int64(len(data)), completeResp.ContentLength)
+
+	r, err := adapter.Get(ctx, obj)
+	require.NoError(t, err)
+	require.Equal(t, data, readAll(t, r))
+
+	// range spanning both parts
+	r, err = adapter.GetRange(ctx, obj, 90*1024, 120*1024)
+	require.NoError(t, err)
+	require.Equal(t, data[90*1024:120*1024+1], readAll(t, r))
+}
@@ -132,7 +132,7 @@ func (a *Adapter) Put(_ context.Context, obj block.ObjectPointer, _ int64, reade
 		a.properties[storageID] = make(map[string]block.Properties)
 	}
 	a.data[storageID][key] = data
-	a.properties[storageID][key] = block.Properties(opts)
+	a.properties[storageID][key] = block.Properties{StorageClass: opts.StorageClass}
 	return &block.PutResponse{}, nil
 }
 
@@ -259,12 +259,12 @@ func (a *Adapter) Put(ctx context.Context, obj block.ObjectPointer, sizeBytes in
 	if opts.StorageClass != nil {
 		putObject.StorageClass = types.StorageClass(*opts.StorageClass)
 	}
-	if a.ServerSideEncryption != "" {
-		putObject.ServerSideEncryption = types.ServerSideEncryption(a.ServerSideEncryption)
-	}
-	if a.ServerSideEncryptionKmsKeyID != "" {
-		putObject.SSEKMSKeyId = aws.String(a.ServerSideEncryptionKmsKeyID)
-	}
+	sse := a.writeSSEParams(opts.ServerSideEncryption)
+	putObject.ServerSideEncryption = sse.mode
+	putObject.SSEKMSKeyId = sse.kmsKeyID
+	putObject.SSECustomerAlgorithm = sse.customerAlgorithm
+	putObject.SSECustomerKey = sse.customerKey
+	putObject.SSECustomerKeyMD5 = sse.customerKeyMD5
 
 	client := a.clients.Get(ctx, bucket)
 	resp, err := client.PutObject(ctx, &putObject,
@@ -324,11 +324,18 @@ func (a *Adapter) UploadPart(ctx context.Context, obj block.ObjectPointer, sizeB
 		Body:          reader,
 		ContentLength: aws.Int64(sizeBytes),
 	}
-	if a.ServerSideEncryption != "" {
-		uploadPartInput.SSECustomerAlgorithm = &a.ServerSideEncryption
-	}
-	if a.ServerSideEncryptionKmsKeyID != "" {
-		uploadPartInput.SSECustomerKey = &a.ServerSideEncryptionKmsKeyID
+	if obj.CustomerKey != nil {
+		sse := customerKeyParams(obj.CustomerKey)
+		uploadPartInput.SSECustomerAlgorithm = sse.customerAlgorithm
+		uploadPartInput.SSECustomerKey = sse.customerKey
+		uploadPartInput.SSECustomerKeyMD5 = sse.customerKeyMD5
+	} else {
+		if a.ServerSideEncryption != "" {
+			uploadPartInput.SSECustomerAlgorithm = &a.ServerSideEncryption
+		}
+		if a.ServerSideEncryptionKmsKeyID != "" {
+			uploadPartInput.SSECustomerKey = &a.ServerSideEncryptionKmsKeyID
+		}
 	}
 
 	client := a.clients.Get(ctx, bucket)
@@ -368,9 +375,13 @@ func (a *Adapter) Get(ctx context.Context, obj block.ObjectPointer) (io.ReadClos
 		return nil, err
 	}
 
+	sse := customerKeyParams(obj.CustomerKey)
 	getObjectInput := s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
 	}
 	client := a.clients.Get(ctx, bucket)
 	objectOutput, err := client.GetObject(ctx, &getObjectInput)
@@ -539,10 +550,14 @@ func (a *Adapter) GetRange(ctx context.Context, obj block.ObjectPointer, startPo
 		return nil, err
 	}
 	log := a.log(ctx).WithField("operation", "GetObjectRange")
+	sse := customerKeyParams(obj.CustomerKey)
 	getObjectInput := s3.GetObjectInput{
-		Bucket: aws.String(bucket),
-		Key:    aws.String(key),
-		Range:  aws.String(fmt.Sprintf("bytes=%d-%d", startPosition, endPosition)),
+		Bucket:               aws.String(bucket),
+		Key:                  aws.String(key),
+		Range:                aws.String(fmt.Sprintf("bytes=%d-%d", startPosition, endPosition)),
+		SSECustomerAlgorithm: sse.customerAlgorithm,
+		SSECustomerKey:       sse.customerKey,
+		SSECustomerKeyMD5:    sse.customerKeyMD5,
 	}
 	client := a.clients.Get(ctx, bucket)
 	objectOutput, err := client.GetObject(ctx, &getObjectInput)
@@ -632,6 +647,14 @@ func (a *Adapter) copyPart(ctx context.Context, sourceObj, destinationObj block.
 	if byteRange != nil {
 		uploadPartCopyObject.CopySourceRange = byteRange
 	}
+	sourceSSE := customerKeyParams(sourceObj.CustomerKey)
+	uploadPartCopyObject.CopySourceSSECustomerAlgorithm = sourceSSE.customerAlgorithm
+	uploadPartCopyObject.CopySourceSSECustomerKey = sourceSSE.customerKey
+	uploadPartCopyObject.CopySourceSSECustomerKeyMD5 = sourceSSE.customerKeyMD5
+	sse := customerKeyParams(destinationObj.CustomerKey)
+	uploadPartCopyObject.SSECustomerAlgorithm = sse.customerAlgorithm
+	uploadPartCopyObject.SSECustomerKey = sse.customerKey
+	uploadPartCopyObject.SSECustomerKeyMD5 = sse.customerKeyMD5
 	client := a.clients.Get(ctx, bucket)
 	resp, err := client.UploadPartCopy(ctx, &uploadPartCopyObject)
 	if err != nil {
@@ -710,12 +733,12 @@ func (a *Adapter) CreateMultiPartUpload(ctx context.Context, obj block.ObjectPoi
 	if opts.StorageClass != nil {
 		input.StorageClass = types.StorageClass(*opts.StorageClass)
 	}
-	if a.ServerSideEncryption != "" {
-		input.ServerSideEncryption = types.ServerSideEncryption(a.ServerSideEncryption)
-	}
-	if a.ServerSideEncryptionKmsKeyID != "" {
-		input.SSEKMSKeyId = &a.ServerSideEncryptionKmsKeyID
-	}
+	sse := a.writeSSEParams(opts.ServerSideEncryption)
+	input.ServerSideEncryption = sse.mode
+	input.SSEKMSKeyId = sse.kmsKeyID
+	input.SSECustomerAlgorithm = sse.customerAlgorithm
+	input.SSECustomerKey = sse.customerKey
+	input.SSECustomerKeyMD5 = sse.customerKeyMD5
 	client := a.clients.Get(ctx, bucket)
 	resp, err := client.CreateMultipartUpload(ctx, input)
 	if err != nil {
@@ -980,12 +1003,12 @@ func (a *Adapter) managerUpload(ctx context.Context, obj block.ObjectPointer, re
 	if opts.StorageClass != nil {
 		input.StorageClass = types.StorageClass(*opts.StorageClass)
 	}
-	if a.ServerSideEncryption != "" {
-		input.ServerSideEncryption = types.ServerSideEncryption(a.ServerSideEncryption)
-	}
-	if a.ServerSideEncryptionKmsKeyID != "" {
-		input.SSEKMSKeyId = aws.String(a.ServerSideEncryptionKmsKeyID)
-	}
+	sse := a.writeSSEParams(opts.ServerSideEncryption)
+	input.ServerSideEncryption = sse.mode
+	input.SSEKMSKeyId = sse.kmsKeyID
+	input.SSECustomerAlgorithm = sse.customerAlgorithm
+	input.SSECustomerKey = sse.customerKey
+	input.SSECustomerKeyMD5 = sse.customerKeyMD5
 
 	output, err := uploader.Upload(ctx, input)
 	if err != nil {
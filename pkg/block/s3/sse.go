@@ -0,0 +1,62 @@
+package s3
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/base64"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/treeverse/lakefs/pkg/block"
+)
+
+// sseCustomerAlgorithm is the only algorithm S3 supports for customer provided keys
+const sseCustomerAlgorithm = "AES256"
+
+// sseParams are the server-side encryption parameters of an S3 request
+type sseParams struct {
+	mode              types.ServerSideEncryption
+	kmsKeyID          *string
+	customerAlgorithm *string
+	customerKey       *string
+	customerKeyMD5    *string
+}
+
+// customerKeyParams returns the SSE-C parameters to use key, none if key is nil
+func customerKeyParams(key []byte) sseParams {
+	if key == nil {
+		return sseParams{}
+	}
+	sum := md5.Sum(key) //nolint:gosec
+	return sseParams{
+		customerAlgorithm: aws.String(sseCustomerAlgorithm),
+		customerKey:       aws.String(base64.StdEncoding.EncodeToString(key)),
+		customerKeyMD5:    aws.String(base64.StdEncoding.EncodeToString(sum[:])),
+	}
+}
+
+// writeSSEParams returns the parameters to encrypt a new object with sse, or with the
+// encryption configured on the adapter if sse is nil
+func (a *Adapter) writeSSEParams(sse *block.ServerSideEncryption) sseParams {
+	if sse == nil {
+		var params sseParams
+		if a.ServerSideEncryption != "" {
+			params.mode = types.ServerSideEncryption(a.ServerSideEncryption)
+		}
+		if a.ServerSideEncryptionKmsKeyID != "" {
+			params.kmsKeyID = aws.String(a.ServerSideEncryptionKmsKeyID)
+		}
+		return params
+	}
+	switch sse.Mode {
+	case block.ServerSideEncryptionCustomerKey:
+		return customerKeyParams(sse.CustomerKey)
+	case block.ServerSideEncryptionKMS:
+		params := sseParams{mode: types.ServerSideEncryptionAwsKms}
+		if sse.KMSKeyID != "" {
+			params.kmsKeyID = aws.String(sse.KMSKeyID)
+		}
+		return params
+	default:
+		return sseParams{mode: types.ServerSideEncryption(sse.Mode)}
+	}
+}
@@ -2,6 +2,7 @@ package cache
 
 import (
 	"math/rand"
+	"sync/atomic"
 	"time"
 
 	lru "github.com/hnlq715/golang-lru"
@@ -20,6 +21,10 @@ type SetFnWithExpiry func() (v interface{}, expiry time.Duration, err error)
 type Cache interface {
 	GetOrSet(k interface{}, setFn SetFn) (v interface{}, err error)
 	GetOrSetWithExpiry(k interface{}, setFn SetFnWithExpiry) (v interface{}, err error)
+	// Remove removes k from the cache, so the next GetOrSet of k sets it
+	Remove(k interface{})
+	// Purge removes all keys from the cache
+	Purge()
 }
 
 type GetSetCache struct {
@@ -27,6 +32,8 @@ type GetSetCache struct {
 	computations *ChanOnlyOne
 	expiry       time.Duration
 	jitterFn     JitterFn
+	// removals counts calls to Remove and Purge, values set while removing are not cached
+	removals atomic.Uint64
 }
 
 func NewCache(size int, expiry time.Duration, jitterFn JitterFn) *GetSetCache {
@@ -56,10 +63,14 @@ func (c *GetSetCache) GetOrSetWithExpiry(k interface{}, setFn SetFnWithExpiry) (
 		return v, nil
 	}
 	return c.computations.Compute(k, func() (interface{}, error) {
+		removals := c.removals.Load()
 		v, expiry, err := setFn()
 		if err != nil { // Don't cache errors
 			return nil, err
 		}
+		if removals != c.removals.Load() { // Don't cache values that may have been removed
+			return v, nil
+		}
 		if expiry == 0 {
 			expiry = c.expiry + c.jitterFn()
 		}
@@ -68,6 +79,16 @@ func (c *GetSetCache) GetOrSetWithExpiry(k interface{}, setFn SetFnWithExpiry) (
 	})
 }
 
+func (c *GetSetCache) Remove(k interface{}) {
+	c.removals.Add(1)
+	c.lru.Remove(k)
+}
+
+func (c *GetSetCache) Purge() {
+	c.removals.Add(1)
+	c.lru.Purge()
+}
+
 func NewJitterFn(jitter time.Duration) JitterFn {
 	if jitter <= 0 {
 		return func() time.Duration {
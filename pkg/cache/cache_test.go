@@ -184,3 +184,42 @@ func TestCacheGetSetWithExpiry(t *testing.T) {
 	}
 	slowSpy.ResetCalled()
 }
+
+func TestCacheRemove(t *testing.T) {
+	c := cache.NewCache(10, time.Hour, cache.NewJitterFn(time.Millisecond))
+	get := func(k string, v int) int {
+		t.Helper()
+		actual, err := c.GetOrSet(k, func() (interface{}, error) { return v, nil })
+		if err != nil {
+			t.Fatal("GetOrSet", err)
+		}
+		return actual.(int)
+	}
+
+	get("a", 1)
+	get("b", 1)
+	c.Remove("a")
+	if v := get("a", 2); v != 2 {
+		t.Errorf("got removed a=%d, expected 2", v)
+	}
+	if v := get("b", 2); v != 1 {
+		t.Errorf("got b=%d, expected cached 1", v)
+	}
+
+	c.Purge()
+	if v := get("b", 3); v != 3 {
+		t.Errorf("got purged b=%d, expected 3", v)
+	}
+
+	// a value set while removing may be stale, so it is not cached
+	_, err := c.GetOrSet("c", func() (interface{}, error) {
+		c.Remove("c")
+		return 1, nil
+	})
+	if err != nil {
+		t.Fatal("GetOrSet", err)
+	}
+	if v := get("c", 2); v != 2 {
+		t.Errorf("got c=%d set while removing, expected 2", v)
+	}
+}
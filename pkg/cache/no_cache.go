@@ -12,3 +12,7 @@ func (m *noCache) GetOrSetWithExpiry(_ interface{}, setFn SetFnWithExpiry) (v in
 	v, _, err = setFn()
 	return v, err
 }
+
+func (m *noCache) Remove(_ interface{}) {}
+
+func (m *noCache) Purge() {}
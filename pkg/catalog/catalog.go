@@ -8,6 +8,7 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/base64"
+	"encoding/hex"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -30,6 +31,8 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/branch"
 	"github.com/treeverse/lakefs/pkg/graveler/committed"
+	"github.com/treeverse/lakefs/pkg/graveler/lifecycle"
+	"github.com/treeverse/lakefs/pkg/graveler/objectlock"
 	"github.com/treeverse/lakefs/pkg/graveler/ref"
 	"github.com/treeverse/lakefs/pkg/graveler/retention"
 	"github.com/treeverse/lakefs/pkg/graveler/settings"
@@ -230,6 +233,10 @@ type Catalog struct {
 	KVStoreLimited        kv.Store
 	addressProvider       *ident.HexAddressProvider
 	deleteSensor          *graveler.DeleteSensor
+	lifecycleManager      *lifecycle.Manager
+	objectLockManager     *objectlock.Manager
+	settingManager        *settings.Manager
+	notifier              Notifier
 	UGCPrepareMaxFileSize int64
 	UGCPrepareInterval    time.Duration
 	signingKey            config.SecureString
@@ -367,13 +374,16 @@ func New(ctx context.Context, cfg Config) (*Catalog, error) {
 		},
 		cfg.Config.StorageConfig(),
 	)
-	gcManager := retention.NewGarbageCollectionManager(tierFSParams.Adapter, refManager, baseCfg.Committed.BlockStoragePrefix)
 	settingManager := settings.NewManager(refManager, cfg.KVStore)
 	if cfg.SettingsManagerOption != nil {
 		cfg.SettingsManagerOption(settingManager)
 	}
+	settingManager.WatchChanges(ctx)
+	objectLockManager := objectlock.NewManager(settingManager, cfg.KVStore)
+	gcManager := retention.NewGarbageCollectionManager(tierFSParams.Adapter, refManager, objectLockManager, baseCfg.Committed.BlockStoragePrefix)
 
 	protectedBranchesManager := branch.NewProtectionManager(settingManager)
+	lifecycleManager := lifecycle.NewManager(settingManager)
 	stagingManager := staging.NewManager(ctx, cfg.KVStore, storeLimiter, baseCfg.Graveler.BatchDBIOTransactionMarkers, executor)
 	var deleteSensor *graveler.DeleteSensor
 	if baseCfg.Graveler.CompactionSensorThreshold > 0 {
@@ -388,6 +398,7 @@ func New(ctx context.Context, cfg Config) (*Catalog, error) {
 		deleteSensor = graveler.NewDeleteSensor(baseCfg.Graveler.CompactionSensorThreshold, cb)
 	}
 	gStore := graveler.NewGraveler(committedManager, stagingManager, refManager, gcManager, protectedBranchesManager, deleteSensor)
+	gStore.SetObjectLockManager(objectLockManager)
 
 	// The size of the workPool is determined by the number of workers and the number of desired pending tasks for each worker.
 	workPool := pond.New(sharedWorkers, sharedWorkers*pendingTasksPerWorker, pond.Context(ctx))
@@ -405,6 +416,9 @@ func New(ctx context.Context, cfg Config) (*Catalog, error) {
 		KVStoreLimited:        storeLimiter,
 		addressProvider:       addressProvider,
 		deleteSensor:          deleteSensor,
+		lifecycleManager:      lifecycleManager,
+		objectLockManager:     objectLockManager,
+		settingManager:        settingManager,
 		signingKey:            cfg.Config.StorageConfig().SigningKey(),
 	}, nil
 }
@@ -1081,6 +1095,48 @@ func (c *Catalog) UpdateEntryUserMetadata(ctx context.Context, repositoryID, bra
 	return c.Store.Update(ctx, repository, branchID, key, updater)
 }
 
+// SetEntryMetadataValue sets metadataKey of the current entry for a path in repository branch to metadataValue,
+// keeping the rest of its metadata. An empty metadataValue removes the key.
+func (c *Catalog) SetEntryMetadataValue(ctx context.Context, repositoryID, branch, path, metadataKey, metadataValue string) error {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return err
+	}
+
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+
+	key := graveler.Key(path)
+	updater := graveler.ValueUpdateFunc(func(value *graveler.Value) (*graveler.Value, error) {
+		if value == nil {
+			return nil, fmt.Errorf("set metadata on %s/%s/%s: %w",
+				repositoryID, branchID, path, graveler.ErrNotFound)
+		}
+		entry, err := ValueToEntry(value)
+		if err != nil {
+			return nil, err
+		}
+		metadata := make(map[string]string, len(entry.Metadata)+1)
+		for k, v := range entry.Metadata {
+			metadata[k] = v
+		}
+		if metadataValue == "" {
+			delete(metadata, metadataKey)
+		} else {
+			metadata[metadataKey] = metadataValue
+		}
+		entry.Metadata = metadata
+		return EntryToValue(entry)
+	})
+	return c.Store.Update(ctx, repository, branchID, key, updater)
+}
+
 func newEntryFromCatalogEntry(entry DBEntry) *Entry {
 	ent := &Entry{
 		Address:      entry.PhysicalAddress,
@@ -1140,7 +1196,11 @@ func (c *Catalog) CreateEntry(ctx context.Context, repositoryID string, branch s
 	if err != nil {
 		return err
 	}
-	return c.Store.Set(ctx, repository, branchID, key, *value, opts...)
+	if err := c.Store.Set(ctx, repository, branchID, key, *value, opts...); err != nil {
+		return err
+	}
+	c.notifyEntryCreated(ctx, repository, branchID, &entry)
+	return nil
 }
 
 func (c *Catalog) DeleteEntry(ctx context.Context, repositoryID string, branch string, path string, opts ...graveler.SetOptionsFunc) error {
@@ -1158,7 +1218,11 @@ func (c *Catalog) DeleteEntry(ctx context.Context, repositoryID string, branch s
 		return err
 	}
 	key := graveler.Key(p)
-	return c.Store.Delete(ctx, repository, branchID, key, opts...)
+	if err := c.Store.Delete(ctx, repository, branchID, key, opts...); err != nil {
+		return err
+	}
+	c.notifyEntriesRemoved(ctx, repository, branchID, []string{path})
+	return nil
 }
 
 func (c *Catalog) DeleteEntries(ctx context.Context, repositoryID string, branch string, paths []string, opts ...graveler.SetOptionsFunc) error {
@@ -1186,7 +1250,11 @@ func (c *Catalog) DeleteEntries(ctx context.Context, repositoryID string, branch
 	for i := range paths {
 		keys[i] = graveler.Key(paths[i])
 	}
-	return c.Store.DeleteBatch(ctx, repository, branchID, keys, opts...)
+	if err := c.Store.DeleteBatch(ctx, repository, branchID, keys, opts...); err != nil {
+		return err
+	}
+	c.notifyEntriesRemoved(ctx, repository, branchID, paths)
+	return nil
 }
 
 func (c *Catalog) ListEntries(ctx context.Context, repositoryID string, reference string, prefix string, after string, delimiter string, limit int) ([]*DBEntry, bool, error) {
@@ -2043,6 +2111,51 @@ func (c *Catalog) FindMergeBase(ctx context.Context, repositoryID string, destin
 	return fromCommit.CommitID.String(), toCommit.CommitID.String(), c.addressProvider.ContentAddress(baseCommit), nil
 }
 
+// PreviewMerge returns what merging sourceRef into destinationBranch with strategy and opts would
+// change, and a page of up to limit conflicting paths after 'after'.  Counts are only returned
+// for the first page.
+func (c *Catalog) PreviewMerge(ctx context.Context, repositoryID string, destinationBranch string, sourceRef string, strategy string, limit int, after string, opts ...graveler.SetOptionsFunc) (*MergePreview, bool, error) {
+	destination := graveler.BranchID(destinationBranch)
+	source := graveler.Ref(sourceRef)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "destination", Value: destination, Fn: graveler.ValidateBranchID},
+		{Name: "source", Value: source, Fn: graveler.ValidateRef},
+		{Name: "strategy", Value: strategy, Fn: graveler.ValidateRequiredStrategy},
+	}); err != nil {
+		return nil, false, err
+	}
+
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	preview, err := c.Store.PreviewMerge(ctx, repository, destination, source, strategy, graveler.Key(after), limit, opts...)
+	if err != nil {
+		return nil, false, err
+	}
+	res := &MergePreview{
+		SourceCommitID:      preview.SourceCommitID.String(),
+		DestinationCommitID: preview.DestinationCommitID.String(),
+		BaseCommitID:        preview.BaseCommitID.String(),
+		Added:               preview.Summary.Count[graveler.DiffTypeAdded],
+		Changed:             preview.Summary.Count[graveler.DiffTypeChanged],
+		Removed:             preview.Summary.Count[graveler.DiffTypeRemoved],
+		Conflicts:           preview.Summary.Count[graveler.DiffTypeConflict],
+		Results:             make([]MergePreviewConflict, 0, len(preview.Conflicts)),
+	}
+	for _, conflict := range preview.Conflicts {
+		res.Results = append(res.Results, MergePreviewConflict{
+			Path:                conflict.Key.String(),
+			BaseIdentity:        hex.EncodeToString(conflict.BaseIdentity),
+			SourceIdentity:      hex.EncodeToString(conflict.SourceIdentity),
+			DestinationIdentity: hex.EncodeToString(conflict.DestIdentity),
+		})
+	}
+	return res, preview.HasMore, nil
+}
+
 func (c *Catalog) DumpRepositorySubmit(ctx context.Context, repositoryID string) (string, error) {
 	repository, err := c.getRepository(ctx, repositoryID)
 	if err != nil {
@@ -2775,6 +2888,10 @@ func (c *Catalog) CopyEntry(ctx context.Context, srcRepository, srcRef, srcPath,
 	if err != nil {
 		return nil, err
 	}
+	// the object store needs the customer key to copy the data
+	if srcEntry.IsCustomerKeyEncrypted() {
+		return nil, fmt.Errorf("%s: %w", srcPath, ErrCustomerKeyEncrypted)
+	}
 
 	// load repositories information for storage namespace
 	destRepo, err := c.GetRepository(ctx, destRepository)
@@ -2794,18 +2911,30 @@ func (c *Catalog) CopyEntry(ctx context.Context, srcRepository, srcRef, srcPath,
 		}
 	}
 
-	// copy data to a new physical address
 	dstEntry := *srcEntry
 	dstEntry.Path = destPath
-	dstEntry.AddressType = AddressTypeRelative
-	dstEntry.PhysicalAddress = c.PathProvider.NewPath()
-
 	if replaceSrcMetadata {
 		dstEntry.Metadata = metadata
 	} else {
 		dstEntry.Metadata = srcEntry.Metadata
 	}
 
+	// data outside the storage namespaces of both repositories is managed by neither, like imported data: link it.
+	// Data in the storage namespace of the source repository is never linked, even though the destination can read
+	// it, as garbage collection of the source repository does not know of references from other repositories.
+	if srcRepository != destRepository && isExternalAddress(srcEntry, srcRepo) && isExternalAddress(srcEntry, destRepo) {
+		dstEntry.CreationDate = time.Now()
+		err = c.CreateEntry(ctx, destRepository, destBranch, dstEntry, opts...)
+		if err != nil {
+			return nil, err
+		}
+		return &dstEntry, nil
+	}
+
+	// copy data to a new physical address
+	dstEntry.AddressType = AddressTypeRelative
+	dstEntry.PhysicalAddress = c.PathProvider.NewPath()
+
 	srcObject := block.ObjectPointer{
 		StorageID:        srcRepo.StorageID,
 		StorageNamespace: srcRepo.StorageNamespace,
@@ -2836,6 +2965,13 @@ func (c *Catalog) CopyEntry(ctx context.Context, srcRepository, srcRef, srcPath,
 	return &dstEntry, nil
 }
 
+// isExternalAddress returns whether the data of entry is outside the storage namespace of repository, so garbage
+// collection of repository never deletes it
+func isExternalAddress(entry *DBEntry, repository *Repository) bool {
+	return entry.AddressType == AddressTypeFull &&
+		!strings.HasPrefix(entry.PhysicalAddress, normalizeStorageNamespace(repository.StorageNamespace))
+}
+
 func (c *Catalog) DeleteExpiredImports(ctx context.Context) {
 	repos, err := c.listRepositoriesHelper(ctx)
 	if err != nil {
@@ -3159,6 +3295,148 @@ func (c *Catalog) UpdatePullRequest(ctx context.Context, repositoryID string, pu
 	return c.Store.UpdatePullRequest(ctx, repository, pullID, request)
 }
 
+// ReviewPullRequest reviews commitID, which must be the current head of the source branch
+func (c *Catalog) ReviewPullRequest(ctx context.Context, repositoryID string, pullRequestID string, reviewer string, state string, comment string, commitID string) error {
+	pullID := graveler.PullRequestID(pullRequestID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "pullRequestID", Value: pullID, Fn: graveler.ValidatePullRequestID},
+		{Name: "reviewer", Value: reviewer, Fn: validator.ValidateRequiredString},
+		{Name: "commitID", Value: commitID, Fn: validator.ValidateRequiredString},
+	}); err != nil {
+		return err
+	}
+	reviewState, ok := graveler.PullRequestReviewState_value[strings.ToUpper(state)]
+	if !ok {
+		return fmt.Errorf("%s: %w", state, graveler.ErrInvalidPullRequestReview)
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	return c.Store.ReviewPullRequest(ctx, repository, pullID, &graveler.PullRequestReview{
+		Reviewer: reviewer,
+		State:    graveler.PullRequestReviewState(reviewState),
+		Comment:  comment,
+		CommitID: graveler.CommitID(commitID),
+	})
+}
+
+func (c *Catalog) GetPullRequestComment(ctx context.Context, repositoryID string, pullRequestID string, commentID string) (*graveler.PullRequestComment, error) {
+	pullID := graveler.PullRequestID(pullRequestID)
+	cid := graveler.PullRequestCommentID(commentID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "pullRequestID", Value: pullID, Fn: graveler.ValidatePullRequestID},
+		{Name: "commentID", Value: cid, Fn: graveler.ValidatePullRequestCommentID},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	return c.Store.GetPullRequestComment(ctx, repository, pullID, cid)
+}
+
+// CreatePullRequestComment adds a comment by author to a pull request. The comment discusses the object at
+// path, or the whole pull request if path is empty. A non-empty replyTo adds the comment to the thread of
+// that comment. Returns the new comment ID.
+func (c *Catalog) CreatePullRequestComment(ctx context.Context, repositoryID string, pullRequestID string, author, body, path, replyTo string) (string, error) {
+	pullID := graveler.PullRequestID(pullRequestID)
+	validations := []validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "pullRequestID", Value: pullID, Fn: graveler.ValidatePullRequestID},
+		{Name: "author", Value: author, Fn: validator.ValidateRequiredString},
+	}
+	if replyTo != "" {
+		validations = append(validations, validator.ValidateArg{Name: "replyTo", Value: graveler.PullRequestCommentID(replyTo), Fn: graveler.ValidatePullRequestCommentID})
+	}
+	if err := validator.Validate(validations); err != nil {
+		return "", err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return "", err
+	}
+	commentID, err := c.Store.CreatePullRequestComment(ctx, repository, pullID, &graveler.PullRequestComment{
+		Author:  author,
+		Body:    body,
+		Path:    path,
+		ReplyTo: graveler.PullRequestCommentID(replyTo),
+	})
+	if err != nil {
+		return "", err
+	}
+	return commentID.String(), nil
+}
+
+func (c *Catalog) ListPullRequestComments(ctx context.Context, repositoryID string, pullRequestID string, limit int, after string) ([]*graveler.PullRequestComment, bool, error) {
+	pullID := graveler.PullRequestID(pullRequestID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "pullRequestID", Value: pullID, Fn: graveler.ValidatePullRequestID},
+	}); err != nil {
+		return nil, false, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, false, err
+	}
+
+	// normalize limit
+	if limit < 0 || limit > ListPullsLimitMax {
+		limit = ListPullsLimitMax
+	}
+	it, err := c.Store.ListPullRequestComments(ctx, repository, pullID)
+	if err != nil {
+		return nil, false, err
+	}
+	defer it.Close()
+
+	afterID := graveler.PullRequestCommentID(after)
+	it.SeekGE(afterID)
+	var comments []*graveler.PullRequestComment
+	for it.Next() {
+		v := it.Value()
+		if v.ID == afterID {
+			continue
+		}
+		comments = append(comments, v)
+		if len(comments) >= limit+1 {
+			break
+		}
+	}
+	if err := it.Err(); err != nil {
+		return nil, false, err
+	}
+	// return results (optionally trimmed) and hasMore
+	hasMore := false
+	if len(comments) > limit {
+		hasMore = true
+		comments = comments[:limit]
+	}
+	return comments, hasMore, nil
+}
+
+func (c *Catalog) UpdatePullRequestComment(ctx context.Context, repositoryID string, pullRequestID string, commentID string, update *graveler.UpdatePullRequestComment) error {
+	pullID := graveler.PullRequestID(pullRequestID)
+	cid := graveler.PullRequestCommentID(commentID)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "pullRequestID", Value: pullID, Fn: graveler.ValidatePullRequestID},
+		{Name: "commentID", Value: cid, Fn: graveler.ValidatePullRequestCommentID},
+		{Name: "editor", Value: update.Editor, Fn: validator.ValidateRequiredString},
+	}); err != nil {
+		return err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	return c.Store.UpdatePullRequestComment(ctx, repository, pullID, cid, update)
+}
+
 func newCatalogEntryFromEntry(commonPrefix bool, path string, ent *Entry) DBEntry {
 	b := NewDBEntryBuilder().
 		CommonLevel(commonPrefix).
@@ -0,0 +1,220 @@
+package catalog
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Shopify/go-lua"
+	lualibs "github.com/treeverse/lakefs/pkg/actions/lua"
+	luautil "github.com/treeverse/lakefs/pkg/actions/lua/util"
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+const (
+	// ConflictResolverNewestWins resolves a conflict by taking the object modified last
+	ConflictResolverNewestWins = "newest-wins"
+	// ConflictResolverLargerWins resolves a conflict by taking the larger object
+	ConflictResolverLargerWins = "larger-wins"
+	// ConflictResolverLua resolves a conflict by calling a 'resolve' function defined in a Lua script
+	ConflictResolverLua = "lua"
+
+	luaResolveFunction = "resolve"
+
+	// DefaultLuaConflictResolverTimeout is the time a Lua resolver may run to resolve a single conflict
+	DefaultLuaConflictResolverTimeout = time.Second
+	// luaDeadlineCheckInstructions is the number of Lua instructions run between checks of the deadline
+	luaDeadlineCheckInstructions = 10_000
+)
+
+// ConflictResolverConfig configures the resolvers returned by NewConflictResolver
+type ConflictResolverConfig struct {
+	// LuaEnabled allows ConflictResolverLua, which runs scripts given by the caller of the merge
+	LuaEnabled bool
+	// LuaTimeout bounds the time a Lua resolver runs on each conflict, DefaultLuaConflictResolverTimeout if 0
+	LuaTimeout time.Duration
+}
+
+// NewConflictResolver returns the merge conflict resolver registered under name.
+// script is the Lua source code used by ConflictResolverLua, and is ignored by the other resolvers.
+func NewConflictResolver(ctx context.Context, name, script string, cfg ConflictResolverConfig) (graveler.ConflictResolver, error) {
+	switch name {
+	case ConflictResolverNewestWins:
+		return &entryConflictResolver{
+			name: name,
+			sourceWins: func(source, dest *Entry) bool {
+				return !source.LastModified.AsTime().Before(dest.LastModified.AsTime())
+			},
+		}, nil
+	case ConflictResolverLargerWins:
+		return &entryConflictResolver{
+			name: name,
+			sourceWins: func(source, dest *Entry) bool {
+				return source.Size >= dest.Size
+			},
+		}, nil
+	case ConflictResolverLua:
+		if !cfg.LuaEnabled {
+			return nil, fmt.Errorf("%s: %w", name, ErrConflictResolverDisabled)
+		}
+		timeout := cfg.LuaTimeout
+		if timeout <= 0 {
+			timeout = DefaultLuaConflictResolverTimeout
+		}
+		return newLuaConflictResolver(ctx, script, timeout)
+	default:
+		return nil, fmt.Errorf("%s: %w", name, ErrUnknownConflictResolver)
+	}
+}
+
+// entryConflictResolver picks the source or destination entry by comparing the two.  Conflicts
+// where one of the sides deleted the object cannot be compared and are left unresolved.
+type entryConflictResolver struct {
+	name string
+	// sourceWins reports whether source should be taken over dest.  Ties should favor source.
+	sourceWins func(source, dest *Entry) bool
+}
+
+func (r *entryConflictResolver) Name() string {
+	return r.name
+}
+
+func (r *entryConflictResolver) ResolveConflict(_ context.Context, conflict graveler.MergeConflict) (*graveler.Value, error) {
+	if conflict.Source == nil || conflict.Dest == nil {
+		return nil, graveler.ErrConflictFound
+	}
+	source, err := ValueToEntry(conflict.Source)
+	if err != nil {
+		return nil, fmt.Errorf("source entry: %w", err)
+	}
+	dest, err := ValueToEntry(conflict.Dest)
+	if err != nil {
+		return nil, fmt.Errorf("destination entry: %w", err)
+	}
+	if r.sourceWins(source, dest) {
+		return conflict.Source, nil
+	}
+	return conflict.Dest, nil
+}
+
+// luaConflictResolver calls the 'resolve' function of a Lua script for every conflict.  The
+// function receives a table with the conflicting path and its base, source and destination
+// entries (nil when missing on that side) and returns one of "source", "destination" or
+// "remove".  Any other return value leaves the conflict unresolved.  Loading the script and
+// each call of the function fail once they run longer than timeout.
+type luaConflictResolver struct {
+	mu       sync.Mutex
+	l        *lua.State
+	timeout  time.Duration
+	deadline time.Time
+	ctx      context.Context
+}
+
+func newLuaConflictResolver(ctx context.Context, script string, timeout time.Duration) (*luaConflictResolver, error) {
+	if script == "" {
+		return nil, fmt.Errorf("missing lua script: %w", ErrInvalidConflictResolver)
+	}
+	l := lua.NewState()
+	lualibs.OpenSafe(l, ctx, lualibs.OpenSafeConfig{}, &strings.Builder{})
+	r := &luaConflictResolver{l: l, timeout: timeout}
+	// the hook raises an error in the running script, which fails the call that runs it
+	lua.SetDebugHook(l, func(l *lua.State, _ lua.Debug) {
+		if r.ctx != nil && r.ctx.Err() != nil {
+			lua.Errorf(l, "lua resolver: %s", r.ctx.Err().Error())
+		}
+		if time.Now().After(r.deadline) {
+			lua.Errorf(l, "lua resolver: timed out after %s", r.timeout.String())
+		}
+	}, lua.MaskCount, luaDeadlineCheckInstructions)
+	r.ctx = ctx
+	r.deadline = time.Now().Add(timeout)
+	if err := lua.DoString(l, script); err != nil {
+		return nil, fmt.Errorf("load lua resolver: %w: %s", ErrInvalidConflictResolver, err)
+	}
+	l.Global(luaResolveFunction)
+	isFunction := l.IsFunction(-1)
+	l.Pop(1)
+	if !isFunction {
+		return nil, fmt.Errorf("lua resolver must define a '%s' function: %w", luaResolveFunction, ErrInvalidConflictResolver)
+	}
+	return r, nil
+}
+
+func (r *luaConflictResolver) Name() string {
+	return ConflictResolverLua
+}
+
+func (r *luaConflictResolver) ResolveConflict(ctx context.Context, conflict graveler.MergeConflict) (*graveler.Value, error) {
+	base, err := luaConflictEntry(conflict.Base)
+	if err != nil {
+		return nil, fmt.Errorf("base entry: %w", err)
+	}
+	source, err := luaConflictEntry(conflict.Source)
+	if err != nil {
+		return nil, fmt.Errorf("source entry: %w", err)
+	}
+	dest, err := luaConflictEntry(conflict.Dest)
+	if err != nil {
+		return nil, fmt.Errorf("destination entry: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.ctx = ctx
+	r.deadline = time.Now().Add(r.timeout)
+	r.l.Global(luaResolveFunction)
+	luautil.DeepPush(r.l, map[string]interface{}{
+		"path":        conflict.Key.String(),
+		"base":        base,
+		"source":      source,
+		"destination": dest,
+	})
+	if err := r.l.ProtectedCall(1, 1, 0); err != nil {
+		return nil, fmt.Errorf("lua resolver: %w", err)
+	}
+	resolution, _ := r.l.ToString(-1)
+	r.l.Pop(1)
+
+	switch resolution {
+	case graveler.ConflictResolutionSource:
+		if conflict.Source == nil {
+			return nil, nil
+		}
+		return conflict.Source, nil
+	case graveler.ConflictResolutionDest:
+		if conflict.Dest == nil {
+			return nil, nil
+		}
+		return conflict.Dest, nil
+	case "remove":
+		return nil, nil
+	default:
+		return nil, graveler.ErrConflictFound
+	}
+}
+
+// luaConflictEntry converts a graveler value into the table passed to the Lua resolver, nil
+// values are passed as nil
+func luaConflictEntry(value *graveler.Value) (interface{}, error) {
+	if value == nil {
+		return nil, nil
+	}
+	entry, err := ValueToEntry(value)
+	if err != nil {
+		return nil, err
+	}
+	metadata := make(map[string]interface{}, len(entry.Metadata))
+	for k, v := range entry.Metadata {
+		metadata[k] = v
+	}
+	return map[string]interface{}{
+		"physical_address": entry.Address,
+		"last_modified":    entry.LastModified.AsTime().Format(time.RFC3339),
+		"size":             entry.Size,
+		"checksum":         entry.ETag,
+		"content_type":     entry.ContentType,
+		"metadata":         metadata,
+	}, nil
+}
@@ -0,0 +1,143 @@
+package catalog_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func conflictEntryValue(t *testing.T, address string, size int64, lastModified time.Time) *graveler.Value {
+	t.Helper()
+	v, err := catalog.EntryToValue(&catalog.Entry{
+		Address:      address,
+		LastModified: timestamppb.New(lastModified),
+		Size:         size,
+		ETag:         address,
+	})
+	require.NoError(t, err)
+	return v
+}
+
+func TestNewConflictResolver(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	older := conflictEntryValue(t, "older", 100, now.Add(-time.Hour))
+	newer := conflictEntryValue(t, "newer", 10, now)
+
+	tests := []struct {
+		name     string
+		script   string
+		conflict graveler.MergeConflict
+		expected *graveler.Value
+		err      error
+	}{
+		{
+			name:     catalog.ConflictResolverNewestWins,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: older, Dest: newer},
+			expected: newer,
+		},
+		{
+			name:     catalog.ConflictResolverLargerWins,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: older, Dest: newer},
+			expected: older,
+		},
+		{
+			name:     catalog.ConflictResolverNewestWins,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: older},
+			err:      graveler.ErrConflictFound,
+		},
+		{
+			name: catalog.ConflictResolverLua,
+			script: `function resolve(conflict)
+				if conflict.destination == nil then
+					return "source"
+				end
+				if conflict.source.size < conflict.destination.size then
+					return "source"
+				end
+				return "destination"
+			end`,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: newer, Dest: older},
+			expected: newer,
+		},
+		{
+			name:     catalog.ConflictResolverLua,
+			script:   `function resolve(conflict) return "remove" end`,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: newer, Dest: older},
+			expected: nil,
+		},
+		{
+			name:     catalog.ConflictResolverLua,
+			script:   `function resolve(conflict) return nil end`,
+			conflict: graveler.MergeConflict{Key: graveler.Key("a"), Source: newer, Dest: older},
+			err:      graveler.ErrConflictFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resolver, err := catalog.NewConflictResolver(ctx, tt.name, tt.script, catalog.ConflictResolverConfig{LuaEnabled: true})
+			require.NoError(t, err)
+			require.Equal(t, tt.name, resolver.Name())
+			value, err := resolver.ResolveConflict(ctx, tt.conflict)
+			if tt.err != nil {
+				require.True(t, errors.Is(err, tt.err), "expected %v, got %v", tt.err, err)
+				return
+			}
+			require.NoError(t, err)
+			require.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestNewConflictResolver_Invalid(t *testing.T) {
+	ctx := context.Background()
+	cfg := catalog.ConflictResolverConfig{LuaEnabled: true}
+	_, err := catalog.NewConflictResolver(ctx, "no-such-resolver", "", cfg)
+	require.ErrorIs(t, err, catalog.ErrUnknownConflictResolver)
+
+	_, err = catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, "", cfg)
+	require.ErrorIs(t, err, catalog.ErrInvalidConflictResolver)
+
+	_, err = catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, "x = 1", cfg)
+	require.ErrorIs(t, err, catalog.ErrInvalidConflictResolver)
+
+	_, err = catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, `function resolve(conflict) return "source" end`, catalog.ConflictResolverConfig{})
+	require.ErrorIs(t, err, catalog.ErrConflictResolverDisabled)
+}
+
+func TestNewConflictResolver_LuaTimeout(t *testing.T) {
+	ctx := context.Background()
+	cfg := catalog.ConflictResolverConfig{LuaEnabled: true, LuaTimeout: 50 * time.Millisecond}
+
+	// loading the script is bounded too
+	_, err := catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, `while true do end`, cfg)
+	require.ErrorIs(t, err, catalog.ErrInvalidConflictResolver)
+
+	resolver, err := catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, `function resolve(conflict) while true do end end`, cfg)
+	require.NoError(t, err)
+	now := time.Now()
+	conflict := graveler.MergeConflict{
+		Key:    graveler.Key("a"),
+		Source: conflictEntryValue(t, "source", 1, now),
+		Dest:   conflictEntryValue(t, "dest", 1, now),
+	}
+	start := time.Now()
+	_, err = resolver.ResolveConflict(ctx, conflict)
+	require.ErrorContains(t, err, "timed out")
+	require.Less(t, time.Since(start), 5*time.Second)
+
+	// a canceled merge stops the script
+	cfg.LuaTimeout = time.Hour
+	resolver, err = catalog.NewConflictResolver(ctx, catalog.ConflictResolverLua, `function resolve(conflict) while true do end end`, cfg)
+	require.NoError(t, err)
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	_, err = resolver.ResolveConflict(canceledCtx, conflict)
+	require.ErrorContains(t, err, context.Canceled.Error())
+}
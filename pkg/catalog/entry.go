@@ -47,3 +47,15 @@ func MustEntryToValue(entry *Entry) *graveler.Value {
 	}
 	return v
 }
+
+// WithEntryCondition returns a set option that writes the entry only if check passes for the entry currently at its
+// path, nil if there is none
+func WithEntryCondition(check func(entry *Entry) error) graveler.SetOptionsFunc {
+	return graveler.WithCondition(func(currentValue *graveler.Value) error {
+		entry, err := ValueToEntry(currentValue)
+		if err != nil {
+			return err
+		}
+		return check(entry)
+	})
+}
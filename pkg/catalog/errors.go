@@ -19,4 +19,14 @@ var (
 
 	ErrFeatureNotSupported = errors.New("feature not supported")
 	ErrNonEmptyRepository  = errors.New("non empty repository")
+
+	ErrUnknownConflictResolver  = fmt.Errorf("unknown conflict resolver: %w", graveler.ErrInvalidValue)
+	ErrInvalidConflictResolver  = fmt.Errorf("invalid conflict resolver: %w", graveler.ErrInvalidValue)
+	ErrConflictResolverDisabled = fmt.Errorf("conflict resolver disabled: %w", graveler.ErrInvalidValue)
+
+	ErrLifecycleBranchChanged = fmt.Errorf("branch changed during lifecycle expiration: %w", graveler.ErrConflictFound)
+
+	// ErrCustomerKeyEncrypted is returned when reading or copying the data of an object encrypted with a customer
+	// key, which only the S3 gateway can read given that key
+	ErrCustomerKeyEncrypted = fmt.Errorf("object is encrypted with a customer key: %w", graveler.ErrInvalidValue)
 )
@@ -330,6 +330,26 @@ func (g *FakeGraveler) UpdatePullRequest(context.Context, *graveler.RepositoryRe
 	panic("implement me")
 }
 
+func (g *FakeGraveler) ReviewPullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, *graveler.PullRequestReview) error {
+	panic("implement me")
+}
+
+func (g *FakeGraveler) GetPullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, graveler.PullRequestCommentID) (*graveler.PullRequestComment, error) {
+	panic("implement me")
+}
+
+func (g *FakeGraveler) CreatePullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, *graveler.PullRequestComment) (graveler.PullRequestCommentID, error) {
+	panic("implement me")
+}
+
+func (g *FakeGraveler) ListPullRequestComments(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID) (graveler.PullCommentsIterator, error) {
+	panic("implement me")
+}
+
+func (g *FakeGraveler) UpdatePullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, graveler.PullRequestCommentID, *graveler.UpdatePullRequestComment) error {
+	panic("implement me")
+}
+
 type FakeValueIterator struct {
 	Data  []*graveler.ValueRecord
 	Index int
@@ -0,0 +1,504 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
+)
+
+const (
+	LifecycleCommitter     = "lakefs-lifecycle"
+	LifecycleCommitMessage = "Expire objects by lifecycle rules"
+	// LifecycleRulesMetadataKey is the commit metadata key listing the IDs of the rules that expired objects
+	LifecycleRulesMetadataKey = "lifecycle_rules"
+
+	lifecyclePartition = "lifecycle"
+	// lifecycleReportMaxPaths limits the expired paths listed for each branch in a run result
+	lifecycleReportMaxPaths = 1000
+	lifecycleListBatchSize  = 1000
+)
+
+// LifecycleBranchResult reports the objects the lifecycle rules expired on a branch
+type LifecycleBranchResult struct {
+	Branch string
+	// ExpiredUncommitted lists (up to lifecycleReportMaxPaths) the paths of uncommitted objects that were reset
+	ExpiredUncommitted      []string
+	ExpiredUncommittedCount int
+	// ExpiredCommitted lists (up to lifecycleReportMaxPaths) the paths of committed objects that were deleted
+	ExpiredCommitted      []string
+	ExpiredCommittedCount int
+	// CommitID is the commit deleting the expired committed objects, if any
+	CommitID string
+	// Skipped is the reason committed objects were not expired
+	Skipped string
+	Error   string
+}
+
+type LifecycleRunResult struct {
+	Repository string
+	DryRun     bool
+	RunTime    time.Time
+	Branches   []*LifecycleBranchResult
+}
+
+func (r *LifecycleBranchResult) addUncommitted(path string) {
+	r.ExpiredUncommittedCount++
+	if len(r.ExpiredUncommitted) < lifecycleReportMaxPaths {
+		r.ExpiredUncommitted = append(r.ExpiredUncommitted, path)
+	}
+}
+
+func (r *LifecycleBranchResult) addCommitted(path string) {
+	r.ExpiredCommittedCount++
+	if len(r.ExpiredCommitted) < lifecycleReportMaxPaths {
+		r.ExpiredCommitted = append(r.ExpiredCommitted, path)
+	}
+}
+
+func (c *Catalog) GetLifecycleRules(ctx context.Context, repositoryID string) (*graveler.LifecycleRules, *string, error) {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.lifecycleManager.GetRules(ctx, repository)
+}
+
+func (c *Catalog) SetLifecycleRules(ctx context.Context, repositoryID string, rules *graveler.LifecycleRules, lastKnownChecksum *string) error {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if repository.ReadOnly {
+		return graveler.ErrReadOnlyRepository
+	}
+	return c.lifecycleManager.SetRules(ctx, repository, rules, lastKnownChecksum)
+}
+
+func (c *Catalog) DeleteLifecycleRules(ctx context.Context, repositoryID string) error {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if repository.ReadOnly {
+		return graveler.ErrReadOnlyRepository
+	}
+	return c.lifecycleManager.DeleteRules(ctx, repository)
+}
+
+// RunLifecycle applies the lifecycle rules of the repository to each of its branches.  Uncommitted objects older
+// than a rule's uncommitted expiration are reset.  Committed objects older than a rule's committed expiration are
+// deleted, and the deletions committed, unless the branch has other uncommitted changes.  On dryRun nothing changes,
+// and the result reports what would have expired.  Failures on a branch are reported in its result.
+func (c *Catalog) RunLifecycle(ctx context.Context, repositoryID string, dryRun bool) (*LifecycleRunResult, error) {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	if repository.ReadOnly {
+		return nil, graveler.ErrReadOnlyRepository
+	}
+	return c.runLifecycle(ctx, repository, dryRun)
+}
+
+func (c *Catalog) runLifecycle(ctx context.Context, repository *graveler.RepositoryRecord, dryRun bool) (*LifecycleRunResult, error) {
+	now := time.Now()
+	result := &LifecycleRunResult{
+		Repository: repository.RepositoryID.String(),
+		DryRun:     dryRun,
+		RunTime:    now,
+	}
+	rules, _, err := c.lifecycleManager.GetRules(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	if len(rules.GetRules()) == 0 {
+		return result, nil
+	}
+
+	it, err := c.Store.ListBranches(ctx, repository)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	for it.Next() {
+		branchID := it.Value().BranchID
+		branchRules, err := c.lifecycleManager.BranchRules(rules, branchID)
+		if err != nil {
+			return nil, err
+		}
+		if len(branchRules) == 0 {
+			continue
+		}
+		branchResult := &LifecycleBranchResult{Branch: branchID.String()}
+		if err := c.expireBranch(ctx, repository, branchID, branchRules, now, dryRun, branchResult); err != nil {
+			branchResult.Error = err.Error()
+		}
+		result.Branches = append(result.Branches, branchResult)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+func (c *Catalog) expireBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, rules []*graveler.LifecycleRule, now time.Time, dryRun bool, result *LifecycleBranchResult) error {
+	if err := c.expireUncommitted(ctx, repository, branchID, rules, now, dryRun, result); err != nil {
+		return err
+	}
+	hasCommittedRules := false
+	for _, rule := range rules {
+		if rule.GetCommittedExpirationDays() > 0 {
+			hasCommittedRules = true
+		}
+	}
+	if !hasCommittedRules {
+		return nil
+	}
+	// the expiration commit must hold only the expiration deletes
+	dirty, err := c.hasUncommittedChanges(ctx, repository, branchID, dryRun, result)
+	if err != nil {
+		return err
+	}
+	if dirty {
+		result.Skipped = "branch has uncommitted changes"
+		return nil
+	}
+	return c.expireCommitted(ctx, repository, branchID, rules, now, dryRun, result)
+}
+
+// expireUncommitted resets the uncommitted objects that expired by rules
+func (c *Catalog) expireUncommitted(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, rules []*graveler.LifecycleRule, now time.Time, dryRun bool, result *LifecycleBranchResult) error {
+	var uncommittedRules []*graveler.LifecycleRule
+	for _, rule := range rules {
+		if rule.GetUncommittedExpirationHours() > 0 {
+			uncommittedRules = append(uncommittedRules, rule)
+		}
+	}
+	if len(uncommittedRules) == 0 {
+		return nil
+	}
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	// identities of the expired paths, so that only the values that expired are reset
+	var (
+		expired    []string
+		identities [][]byte
+	)
+	for it.Next() {
+		v := it.Value()
+		// removed objects have no entry; resetting them would restore data
+		if v.Value == nil {
+			continue
+		}
+		entry, err := ValueToEntry(v.Value)
+		if err != nil {
+			return err
+		}
+		if entry.LastModified == nil {
+			continue
+		}
+		path := string(v.Key)
+		lastModified := entry.LastModified.AsTime()
+		for _, rule := range uncommittedRules {
+			cutoff := now.Add(-time.Duration(rule.GetUncommittedExpirationHours()) * time.Hour)
+			if strings.HasPrefix(path, rule.GetPrefix()) && lastModified.Before(cutoff) {
+				expired = append(expired, path)
+				identities = append(identities, v.Value.Identity)
+				break
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	for i, path := range expired {
+		if !dryRun {
+			err := c.Store.ResetKey(ctx, repository, branchID, graveler.Key(path), graveler.WithCondition(valueIdentityCondition(identities[i])))
+			if errors.Is(err, graveler.ErrPreconditionFailed) {
+				// written since it expired
+				continue
+			}
+			if err != nil {
+				return fmt.Errorf("reset %s: %w", path, err)
+			}
+		}
+		result.addUncommitted(path)
+	}
+	return nil
+}
+
+// valueIdentityCondition returns a condition that passes only a value with identity
+func valueIdentityCondition(identity []byte) graveler.ConditionFunc {
+	return func(currentValue *graveler.Value) error {
+		if currentValue == nil || !bytes.Equal(currentValue.Identity, identity) {
+			return graveler.ErrPreconditionFailed
+		}
+		return nil
+	}
+}
+
+// hasUncommittedChanges reports whether the branch has uncommitted changes.  On dryRun it ignores the uncommitted
+// objects the run would have reset.
+func (c *Catalog) hasUncommittedChanges(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, dryRun bool, result *LifecycleBranchResult) (bool, error) {
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return false, err
+	}
+	defer it.Close()
+	for it.Next() {
+		if dryRun && result.ExpiredUncommittedCount <= lifecycleReportMaxPaths && slices.Contains(result.ExpiredUncommitted, string(it.Value().Key)) {
+			continue
+		}
+		return true, nil
+	}
+	return false, it.Err()
+}
+
+// expireCommitted deletes and commits the committed objects that expired by rules
+func (c *Catalog) expireCommitted(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, rules []*graveler.LifecycleRule, now time.Time, dryRun bool, result *LifecycleBranchResult) error {
+	branch, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return err
+	}
+	expiredRules := make(map[string]struct{})
+	expired := make(map[string]struct{})
+	var batch, staged []graveler.Key
+	deleteBatch := func() error {
+		if dryRun || len(batch) == 0 {
+			batch = batch[:0]
+			return nil
+		}
+		// a failed batch may have staged some of its deletes
+		staged = append(staged, batch...)
+		err := c.Store.DeleteBatch(ctx, repository, branchID, batch)
+		batch = batch[:0]
+		return err
+	}
+	rollback := func(err error) error {
+		return c.rollbackExpiration(ctx, repository, branchID, staged, err)
+	}
+	for _, rule := range rules {
+		if rule.GetCommittedExpirationDays() <= 0 {
+			continue
+		}
+		cutoff := now.AddDate(0, 0, -int(rule.GetCommittedExpirationDays()))
+		// list the branch commit, so that deleting does not change the listing
+		iter, err := c.Store.List(ctx, repository, graveler.Ref(branch.CommitID), lifecycleListBatchSize)
+		if err != nil {
+			return err
+		}
+		it := NewPrefixIterator(NewValueToEntryIterator(iter), Path(rule.GetPrefix()))
+		for it.Next() {
+			v := it.Value()
+			path := v.Path.String()
+			if _, ok := expired[path]; ok {
+				continue
+			}
+			if v.Entry.LastModified == nil || !v.Entry.LastModified.AsTime().Before(cutoff) {
+				continue
+			}
+			// like S3, lifecycle rules do not expire locked objects
+			locked, err := c.objectLockManager.IsLocked(ctx, repository, branchID, graveler.Key(path))
+			if err != nil {
+				it.Close()
+				return rollback(err)
+			}
+			if locked {
+				continue
+			}
+			expired[path] = struct{}{}
+			expiredRules[rule.GetId()] = struct{}{}
+			result.addCommitted(path)
+			batch = append(batch, graveler.Key(path))
+			if len(batch) >= graveler.DeleteKeysMaxSize {
+				if err := deleteBatch(); err != nil {
+					it.Close()
+					return rollback(err)
+				}
+			}
+		}
+		err = it.Err()
+		it.Close()
+		if err != nil {
+			return rollback(err)
+		}
+	}
+	if err := deleteBatch(); err != nil {
+		return rollback(err)
+	}
+	if dryRun || len(expired) == 0 {
+		return nil
+	}
+
+	// users may write to the branch while it expires; commit only the expiration deletes
+	if err := c.checkExpirationStaged(ctx, repository, branchID, expired); err != nil {
+		return rollback(err)
+	}
+	ruleIDs := make([]string, 0, len(expiredRules))
+	for _, rule := range rules {
+		if _, ok := expiredRules[rule.GetId()]; ok {
+			ruleIDs = append(ruleIDs, rule.GetId())
+		}
+	}
+	commitID, err := c.Store.Commit(ctx, repository, branchID, graveler.CommitParams{
+		Committer: LifecycleCommitter,
+		Message:   LifecycleCommitMessage,
+		Metadata:  graveler.Metadata{LifecycleRulesMetadataKey: strings.Join(ruleIDs, ",")},
+	})
+	if err != nil {
+		return rollback(err)
+	}
+	result.CommitID = commitID.String()
+	return nil
+}
+
+// checkExpirationStaged returns ErrLifecycleBranchChanged unless the uncommitted changes of the branch are exactly
+// the deletes of the expired paths
+func (c *Catalog) checkExpirationStaged(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, expired map[string]struct{}) error {
+	it, err := c.Store.DiffUncommitted(ctx, repository, branchID)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	staged := 0
+	for it.Next() {
+		v := it.Value()
+		if _, ok := expired[string(v.Key)]; !ok || v.Type != graveler.DiffTypeRemoved {
+			return ErrLifecycleBranchChanged
+		}
+		staged++
+	}
+	if err := it.Err(); err != nil {
+		return err
+	}
+	if staged != len(expired) {
+		return ErrLifecycleBranchChanged
+	}
+	return nil
+}
+
+// rollbackExpiration resets the deletes of keys staged by a failed expiration, leaving other uncommitted changes
+// on the branch in place
+func (c *Catalog) rollbackExpiration(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, keys []graveler.Key, err error) error {
+	for _, key := range keys {
+		// reset only the deletes still staged, not objects written since
+		resetErr := c.Store.ResetKey(ctx, repository, branchID, key, graveler.WithCondition(deletedCondition))
+		if resetErr != nil && !errors.Is(resetErr, graveler.ErrPreconditionFailed) {
+			c.log(ctx).WithError(resetErr).WithFields(logging.Fields{
+				"repository": repository.RepositoryID,
+				"branch":     branchID,
+				"key":        key,
+			}).Error("Failed to reset lifecycle expiration delete")
+		}
+	}
+	return err
+}
+
+// deletedCondition passes only a deleted value
+func deletedCondition(currentValue *graveler.Value) error {
+	if currentValue != nil {
+		return graveler.ErrPreconditionFailed
+	}
+	return nil
+}
+
+// StartLifecycle runs the lifecycle rules of all repositories every interval, until ctx is cancelled.  Each
+// repository run is claimed in the KV store, so that running several lakeFS instances runs each repository once.
+func (c *Catalog) StartLifecycle(ctx context.Context, interval time.Duration, dryRun bool, logger logging.Logger) {
+	if interval == 0 {
+		return
+	}
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := c.runLifecycleAll(ctx, interval, dryRun, logger); err != nil {
+					logger.WithError(err).Error("Failed to run lifecycle rules")
+				}
+			}
+		}
+	}()
+}
+
+func (c *Catalog) runLifecycleAll(ctx context.Context, interval time.Duration, dryRun bool, logger logging.Logger) error {
+	it, err := c.Store.ListRepositories(ctx)
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		repository := it.Value()
+		if repository.ReadOnly {
+			continue
+		}
+		log := logger.WithField("repository", repository.RepositoryID)
+		claimed, err := c.claimLifecycleRun(ctx, repository.RepositoryID, interval)
+		if err != nil {
+			log.WithError(err).Error("Failed to claim lifecycle run")
+			continue
+		}
+		if !claimed {
+			continue
+		}
+		result, err := c.runLifecycle(ctx, repository, dryRun)
+		if err != nil {
+			log.WithError(err).Error("Failed to run lifecycle rules")
+			continue
+		}
+		for _, branch := range result.Branches {
+			log.WithFields(logging.Fields{
+				"branch":              branch.Branch,
+				"dry_run":             dryRun,
+				"expired_uncommitted": branch.ExpiredUncommittedCount,
+				"expired_committed":   branch.ExpiredCommittedCount,
+				"commit_id":           branch.CommitID,
+				"skipped":             branch.Skipped,
+				"error":               branch.Error,
+			}).Info("Lifecycle rules run")
+		}
+	}
+	return it.Err()
+}
+
+// claimLifecycleRun records the time of a lifecycle run of the repository.  It returns false if another run
+// was recorded within the last half interval.
+func (c *Catalog) claimLifecycleRun(ctx context.Context, repositoryID graveler.RepositoryID, interval time.Duration) (bool, error) {
+	key := []byte(repositoryID)
+	now := time.Now()
+	var predicate kv.Predicate
+	current, err := c.KVStore.Get(ctx, []byte(lifecyclePartition), key)
+	switch {
+	case errors.Is(err, kv.ErrNotFound):
+	case err != nil:
+		return false, err
+	default:
+		lastRun, err := time.Parse(time.RFC3339Nano, string(current.Value))
+		if err == nil && now.Sub(lastRun) < interval/2 {
+			return false, nil
+		}
+		predicate = current.Predicate
+	}
+	err = c.KVStore.SetIf(ctx, []byte(lifecyclePartition), key, []byte(now.Format(time.RFC3339Nano)), predicate)
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
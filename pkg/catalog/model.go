@@ -39,6 +39,16 @@ type DBEntry struct {
 	ContentType     string
 }
 
+// SSECustomerKeyMD5MetadataKey is the metadata key recording the MD5 of the customer key that the data of an object
+// written through the S3 gateway is encrypted with
+const SSECustomerKeyMD5MetadataKey = "X-Amz-Server-Side-Encryption-Customer-Key-Md5"
+
+// IsCustomerKeyEncrypted returns whether the data of the entry is encrypted with a customer key
+func (e *DBEntry) IsCustomerKeyEncrypted() bool {
+	_, ok := e.Metadata[SSECustomerKeyMD5MetadataKey]
+	return ok
+}
+
 type CommitLog struct {
 	Reference    string
 	Committer    string
@@ -73,6 +83,24 @@ type PullRequest struct {
 	ClosedDate        *time.Time
 }
 
+type MergePreviewConflict struct {
+	Path                string
+	BaseIdentity        string
+	SourceIdentity      string
+	DestinationIdentity string
+}
+
+type MergePreview struct {
+	SourceCommitID      string
+	DestinationCommitID string
+	BaseCommitID        string
+	Added               int
+	Changed             int
+	Removed             int
+	Conflicts           int
+	Results             []MergePreviewConflict
+}
+
 // AddressType is the type of entry address
 type AddressType int32
 
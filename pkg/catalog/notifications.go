@@ -0,0 +1,87 @@
+package catalog
+
+import (
+	"context"
+	"errors"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+// NotificationSettingKey is the repository setting holding the notification configuration
+const NotificationSettingKey = "notification_configuration"
+
+var ErrNotificationsDisabled = errors.New("notifications are disabled")
+
+// Notifier is notified on changes to the objects of branches, and sends them to the notification targets
+// configured on the repository.  Calls must not block on the delivery of notifications.
+type Notifier interface {
+	// ValidateConfiguration checks that the targets of a repository notification configuration can be delivered to
+	ValidateConfiguration(cfg *graveler.NotificationConfiguration) error
+	EntryCreated(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, entry *DBEntry)
+	EntriesRemoved(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, paths []string)
+}
+
+// SetNotifier sets the notifier of object changes.  Without a notifier, notification configurations cannot be set.
+func (c *Catalog) SetNotifier(notifier Notifier) {
+	c.notifier = notifier
+}
+
+func (c *Catalog) GetNotificationConfiguration(ctx context.Context, repositoryID string) (*graveler.NotificationConfiguration, *string, error) {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	cfg := &graveler.NotificationConfiguration{}
+	checksum, err := c.settingManager.GetLatest(ctx, repository, NotificationSettingKey, cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return cfg, checksum, nil
+}
+
+// SetNotificationConfiguration replaces the notification configuration of the repository.  An empty configuration
+// stops all notifications.
+func (c *Catalog) SetNotificationConfiguration(ctx context.Context, repositoryID string, cfg *graveler.NotificationConfiguration, lastKnownChecksum *string) error {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if repository.ReadOnly {
+		return graveler.ErrReadOnlyRepository
+	}
+	if len(cfg.GetTargets()) > 0 {
+		if c.notifier == nil {
+			return ErrNotificationsDisabled
+		}
+		if err := c.notifier.ValidateConfiguration(cfg); err != nil {
+			return err
+		}
+	}
+	return c.settingManager.Save(ctx, repository, NotificationSettingKey, cfg, lastKnownChecksum)
+}
+
+// CachedNotificationConfiguration returns the notification configuration of the repository, possibly from a
+// slightly stale cache.  It is used to route events without loading the configuration on each write.
+func (c *Catalog) CachedNotificationConfiguration(ctx context.Context, repository *graveler.RepositoryRecord) (*graveler.NotificationConfiguration, error) {
+	cfg := &graveler.NotificationConfiguration{}
+	err := c.settingManager.Get(ctx, repository, NotificationSettingKey, cfg)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+func (c *Catalog) notifyEntryCreated(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, entry *DBEntry) {
+	if c.notifier != nil {
+		c.notifier.EntryCreated(ctx, repository, branchID, entry)
+	}
+}
+
+func (c *Catalog) notifyEntriesRemoved(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, paths []string) {
+	if c.notifier != nil {
+		c.notifier.EntriesRemoved(ctx, repository, branchID, paths)
+	}
+}
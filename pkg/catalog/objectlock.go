@@ -0,0 +1,118 @@
+package catalog
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/objectlock"
+	"github.com/treeverse/lakefs/pkg/validator"
+)
+
+// ErrUncommittedObject is returned when locking an object that is not committed on its branch, as only committed
+// versions of objects can be locked
+var ErrUncommittedObject = fmt.Errorf("object has uncommitted changes: %w", graveler.ErrInvalidValue)
+
+func (c *Catalog) GetObjectLockConfiguration(ctx context.Context, repositoryID string) (*graveler.ObjectLockConfiguration, *string, error) {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, nil, err
+	}
+	return c.objectLockManager.GetConfiguration(ctx, repository)
+}
+
+// SetObjectLockConfiguration saves the object lock configuration of the repository.  Object lock cannot be disabled
+// once it is enabled.
+func (c *Catalog) SetObjectLockConfiguration(ctx context.Context, repositoryID string, cfg *graveler.ObjectLockConfiguration, lastKnownChecksum *string) error {
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return err
+	}
+	if repository.ReadOnly {
+		return graveler.ErrReadOnlyRepository
+	}
+	return c.objectLockManager.SetConfiguration(ctx, repository, cfg, lastKnownChecksum)
+}
+
+// GetObjectLock returns the lock of the object at path on branch, ErrNotFound if it was never locked
+func (c *Catalog) GetObjectLock(ctx context.Context, repositoryID, branch, path string) (*graveler.ObjectLockData, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return nil, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, err
+	}
+	return c.objectLockManager.GetLock(ctx, repository, branchID, graveler.Key(path))
+}
+
+// SetObjectRetention sets the retention period of the committed object at path on branch, or removes it if
+// retention is nil.
+func (c *Catalog) SetObjectRetention(ctx context.Context, repositoryID, branch, path string, retention *objectlock.Retention, bypassGovernance bool) error {
+	repository, object, err := c.lockableObject(ctx, repositoryID, branch, path)
+	if err != nil {
+		return err
+	}
+	return c.objectLockManager.SetRetention(ctx, repository, object, retention, bypassGovernance)
+}
+
+// SetObjectLegalHold places or removes a legal hold on the committed object at path on branch
+func (c *Catalog) SetObjectLegalHold(ctx context.Context, repositoryID, branch, path string, hold bool) error {
+	repository, object, err := c.lockableObject(ctx, repositoryID, branch, path)
+	if err != nil {
+		return err
+	}
+	return c.objectLockManager.SetLegalHold(ctx, repository, object, hold)
+}
+
+// lockableObject returns the version of the object at path on branch that a lock applies to.  The object must be
+// committed, and unchanged since the branch HEAD commit.
+func (c *Catalog) lockableObject(ctx context.Context, repositoryID, branch, path string) (*graveler.RepositoryRecord, objectlock.Object, error) {
+	branchID := graveler.BranchID(branch)
+	if err := validator.Validate([]validator.ValidateArg{
+		{Name: "repository", Value: repositoryID, Fn: graveler.ValidateRepositoryID},
+		{Name: "branch", Value: branchID, Fn: graveler.ValidateBranchID},
+		{Name: "path", Value: Path(path), Fn: ValidatePath},
+	}); err != nil {
+		return nil, objectlock.Object{}, err
+	}
+	repository, err := c.getRepository(ctx, repositoryID)
+	if err != nil {
+		return nil, objectlock.Object{}, err
+	}
+	if repository.ReadOnly {
+		return nil, objectlock.Object{}, graveler.ErrReadOnlyRepository
+	}
+	key := graveler.Key(path)
+	b, err := c.Store.GetBranch(ctx, repository, branchID)
+	if err != nil {
+		return nil, objectlock.Object{}, err
+	}
+	current, err := c.Store.Get(ctx, repository, graveler.Ref(branchID), key)
+	if err != nil {
+		return nil, objectlock.Object{}, err
+	}
+	committed, err := c.Store.Get(ctx, repository, graveler.Ref(b.CommitID), key)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return nil, objectlock.Object{}, fmt.Errorf("%s: %w", path, ErrUncommittedObject)
+	}
+	if err != nil {
+		return nil, objectlock.Object{}, err
+	}
+	if !bytes.Equal(current.Identity, committed.Identity) {
+		return nil, objectlock.Object{}, fmt.Errorf("%s: %w", path, ErrUncommittedObject)
+	}
+	return repository, objectlock.Object{
+		BranchID: branchID,
+		Key:      key,
+		CommitID: b.CommitID,
+		Identity: committed.Identity,
+	}, nil
+}
@@ -84,6 +84,15 @@ type Database struct {
 		EnableLogging bool `mapstructure:"enable_logging"`
 	} `mapstructure:"local"`
 
+	SQLite *struct {
+		// Path - Path of the database file
+		Path string `mapstructure:"path"`
+		// SyncWrites - Sync ensures data written to disk on each write, and not only on checkpoints
+		SyncWrites bool `mapstructure:"sync_writes"`
+		// BusyTimeout - How long to wait for the database to be unlocked by other connections
+		BusyTimeout time.Duration `mapstructure:"busy_timeout"`
+	} `mapstructure:"sqlite"`
+
 	Postgres *struct {
 		ConnectionString      SecureString  `mapstructure:"connection_string"`
 		MaxOpenConnections    int32         `mapstructure:"max_open_connections"`
@@ -91,6 +100,8 @@ type Database struct {
 		ConnectionMaxLifetime time.Duration `mapstructure:"connection_max_lifetime"`
 		ScanPageSize          int           `mapstructure:"scan_page_size"`
 		Metrics               bool          `mapstructure:"metrics"`
+		// NotifyChanges - Notify lakeFS instances of changes through LISTEN/NOTIFY, to invalidate their caches
+		NotifyChanges bool `mapstructure:"notify_changes"`
 	}
 
 	DynamoDB *struct {
@@ -125,6 +136,11 @@ type Database struct {
 
 		// Maximum amount of connections to DDB. 0 means no limit.
 		MaxConnections int `mapstructure:"max_connections"`
+
+		// ChangeLog - Append changes to a change log in the table, polled by lakeFS instances to invalidate their caches
+		ChangeLog             bool          `mapstructure:"change_log"`
+		ChangeLogPollInterval time.Duration `mapstructure:"change_log_poll_interval"`
+		ChangeLogRetention    time.Duration `mapstructure:"change_log_retention"`
 	} `mapstructure:"dynamodb"`
 
 	CosmosDB *struct {
@@ -144,6 +160,31 @@ type ApproximatelyCorrectOwnership struct {
 	Acquire time.Duration `mapstructure:"acquire"`
 }
 
+// Notifications configures the delivery of repository bucket notifications.  Repositories send notifications to
+// the sinks defined here, referenced by their name.
+type Notifications struct {
+	Enabled   bool `mapstructure:"enabled"`
+	Workers   int  `mapstructure:"workers"`
+	QueueSize int  `mapstructure:"queue_size"`
+	// MaxAttempts to deliver a notification before it is dead-lettered
+	MaxAttempts   int           `mapstructure:"max_attempts"`
+	RetryInterval time.Duration `mapstructure:"retry_interval"`
+	// DeadLetterRetention is the time undelivered notifications are kept, forever when 0
+	DeadLetterRetention time.Duration               `mapstructure:"dead_letter_retention"`
+	Sinks               map[string]NotificationSink `mapstructure:"sinks"`
+}
+
+// NotificationSink is a destination of notifications: a "webhook" URL, a "file" to append to, or a "kafka" topic
+// produced to through a Kafka REST proxy.
+type NotificationSink struct {
+	Type    string                  `mapstructure:"type"`
+	URL     string                  `mapstructure:"url"`
+	Path    string                  `mapstructure:"path"`
+	Topic   string                  `mapstructure:"topic"`
+	Timeout time.Duration           `mapstructure:"timeout"`
+	Headers map[string]SecureString `mapstructure:"headers"`
+}
+
 // AdapterConfig configures a blockstore adapter.
 type AdapterConfig interface {
 	BlockstoreType() string
@@ -464,6 +505,12 @@ type BaseConfig struct {
 			RateLimit int `mapstructure:"rate_limit"`
 		} `mapstructure:"background"`
 		MaxBatchDelay time.Duration `mapstructure:"max_batch_delay"`
+		// LuaConflictResolver controls merges resolving conflicts with a Lua script given by the caller
+		LuaConflictResolver struct {
+			Enabled bool `mapstructure:"enabled"`
+			// Timeout bounds the time the script runs on each conflict
+			Timeout time.Duration `mapstructure:"timeout"`
+		} `mapstructure:"lua_conflict_resolver"`
 		// Parameters for tuning performance of concurrent branch
 		// update operations.  These do not affect correctness or
 		// liveness.  Internally this is "*most correct* branch
@@ -507,6 +554,12 @@ type BaseConfig struct {
 		Enabled       bool          `mapstructure:"enabled"`
 		FlushInterval time.Duration `mapstructure:"flush_interval"`
 	} `mapstructure:"usage_report"`
+	Lifecycle struct {
+		Enabled  bool          `mapstructure:"enabled"`
+		Interval time.Duration `mapstructure:"interval"`
+		DryRun   bool          `mapstructure:"dry_run"`
+	} `mapstructure:"lifecycle"`
+	Notifications Notifications `mapstructure:"notifications"`
 }
 
 func (c *BaseConfig) GetVersionContext() string {
@@ -560,6 +613,24 @@ func UnmarshalKey(key string, rawVal any) error {
 	return viper.UnmarshalKey(key, rawVal, decoderConfig())
 }
 
+// ReadDatabaseFile returns the database section of the configuration file filename.  Defaults
+// are not applied, so the file must hold all settings of the database.
+func ReadDatabaseFile(filename string) (*Database, error) {
+	v := viper.New()
+	v.SetConfigFile(filename)
+	if err := v.ReadInConfig(); err != nil {
+		return nil, err
+	}
+	var db Database
+	if err := v.UnmarshalKey("database", &db, decoderConfig()); err != nil {
+		return nil, err
+	}
+	if db.Type == "" {
+		return nil, fmt.Errorf("%s: database type: %w", filename, ErrMissingRequiredKeys)
+	}
+	return &db, nil
+}
+
 func decoderConfig() viper.DecoderConfigOption {
 	hook := viper.DecodeHook(
 		mapstructure.ComposeDecodeHookFunc(
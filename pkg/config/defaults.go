@@ -141,6 +141,10 @@ func setBaseDefaults(cfgType string) {
 	viper.SetDefault("database.local.prefetch_size", 256)
 	viper.SetDefault("database.local.sync_writes", true)
 
+	viper.SetDefault("database.sqlite.path", "~/lakefs/metadata.db")
+	viper.SetDefault("database.sqlite.sync_writes", true)
+	viper.SetDefault("database.sqlite.busy_timeout", "5s")
+
 	viper.SetDefault("database.dynamodb.table_name", "kvstore")
 	viper.SetDefault("database.dynamodb.scan_limit", 1024)
 	viper.SetDefault("database.dynamodb.max_attempts", 10)
@@ -150,6 +154,7 @@ func setBaseDefaults(cfgType string) {
 	viper.SetDefault("database.postgres.connection_max_lifetime", "5m")
 
 	viper.SetDefault("graveler.ensure_readable_root_namespace", true)
+	viper.SetDefault("graveler.lua_conflict_resolver.timeout", "1s")
 	viper.SetDefault("graveler.repository_cache.size", 1000)
 	viper.SetDefault("graveler.repository_cache.expiry", 5*time.Second)
 	viper.SetDefault("graveler.repository_cache.jitter", 2*time.Second)
@@ -191,6 +196,15 @@ func setBaseDefaults(cfgType string) {
 
 	viper.SetDefault("usage_report.enabled", true)
 	viper.SetDefault("usage_report.flush_interval", 5*time.Minute)
+
+	viper.SetDefault("lifecycle.enabled", true)
+	viper.SetDefault("lifecycle.interval", time.Hour)
+
+	viper.SetDefault("notifications.workers", 4)
+	viper.SetDefault("notifications.queue_size", 10_000)
+	viper.SetDefault("notifications.max_attempts", 5)
+	viper.SetDefault("notifications.retry_interval", time.Second)
+	viper.SetDefault("notifications.dead_letter_retention", 7*24*time.Hour)
 }
 
 func SetLoggingDefaults() {
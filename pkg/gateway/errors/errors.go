@@ -76,6 +76,7 @@ const (
 	ErrInvalidPolicyDocument
 	ErrInvalidObjectState
 	ErrMalformedXML
+	ErrMaxMessageLengthExceeded
 	ErrMissingContentLength
 	ErrMissingContentMD5
 	ErrMissingRequestBodyError
@@ -132,6 +133,19 @@ const (
 	ErrBadRequest
 	ErrKeyTooLongError
 	ErrInvalidAPIVersion
+	ErrInvalidTag
+	ErrInvalidExpressionType
+	ErrSelectParse
+	ErrExpressionTooLong
+	ErrInvalidRequestParameter
+	ErrInvalidLifecycleRule
+	ErrOperationAborted
+	ErrNoSuchLifecycleConfiguration
+	ErrInvalidNotificationConfiguration
+	ErrObjectLocked
+	ErrObjectLockConfigurationNotFound
+	ErrNoSuchObjectLockConfiguration
+	ErrInvalidObjectLockRequest
 	// Add new error codes here.
 
 	// SSE-S3 related API errors
@@ -154,6 +168,7 @@ const (
 
 	ErrNoAccessKey
 	ErrInvalidToken
+	ErrExpiredToken
 
 	// Bucket notification related errors.
 	ErrEventNotification
@@ -314,6 +329,11 @@ var Codes = errorCodeMap{
 		Description:    "The XML you provided was not well-formed or did not validate against our published schema.",
 		HTTPStatusCode: http.StatusBadRequest,
 	},
+	ErrMaxMessageLengthExceeded: {
+		Code:           "MaxMessageLengthExceeded",
+		Description:    "Your request was too big.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrMissingContentLength: {
 		Code:           "MissingContentLength",
 		Description:    "You must provide the Content-Length HTTP header.",
@@ -739,6 +759,11 @@ var Codes = errorCodeMap{
 		Description:    "The security token included in the request is invalid",
 		HTTPStatusCode: http.StatusForbidden,
 	},
+	ErrExpiredToken: {
+		Code:           "ExpiredToken",
+		Description:    "The provided token has expired.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	// S3 extensions.
 	ErrContentSHA256Mismatch: {
@@ -756,6 +781,71 @@ var Codes = errorCodeMap{
 		Description:    "Invalid version found in the request",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTag: {
+		Code:           "InvalidTag",
+		Description:    "The tag provided was not a valid tag.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidExpressionType: {
+		Code:           "InvalidExpressionType",
+		Description:    "The ExpressionType is invalid. Only SQL expressions are supported.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrSelectParse: {
+		Code:           "ParseSelectFailure",
+		Description:    "The SQL expression could not be parsed.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrExpressionTooLong: {
+		Code:           "ExpressionTooLong",
+		Description:    "The SQL expression is too long: The maximum byte-length for the SQL expression is 256 KB.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidRequestParameter: {
+		Code:           "InvalidRequestParameter",
+		Description:    "The value of a parameter in the SelectRequest element is invalid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidLifecycleRule: {
+		Code:           "InvalidArgument",
+		Description:    "The lifecycle configuration is not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrOperationAborted: {
+		Code:           "OperationAborted",
+		Description:    "A conflicting conditional operation is currently in progress against this resource. Try again.",
+		HTTPStatusCode: http.StatusConflict,
+	},
+	ErrNoSuchLifecycleConfiguration: {
+		Code:           "NoSuchLifecycleConfiguration",
+		Description:    "The lifecycle configuration does not exist.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidNotificationConfiguration: {
+		Code:           "InvalidArgument",
+		Description:    "The notification configuration is not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrObjectLocked: {
+		Code:           "AccessDenied",
+		Description:    "Access Denied because object protected by object lock.",
+		HTTPStatusCode: http.StatusForbidden,
+	},
+	ErrObjectLockConfigurationNotFound: {
+		Code:           "ObjectLockConfigurationNotFoundError",
+		Description:    "Object Lock configuration does not exist for this bucket.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrNoSuchObjectLockConfiguration: {
+		Code:           "NoSuchObjectLockConfiguration",
+		Description:    "The specified object does not have an Object Lock configuration.",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidObjectLockRequest: {
+		Code:           "InvalidRequest",
+		Description:    "The Object Lock request is not valid.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 
 	// LakeFS errors
 	ERRLakeFSNotSupported: {
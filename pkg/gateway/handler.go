@@ -95,6 +95,7 @@ func NewHandler(region string, catalog *catalog.Catalog, multipartTracker multip
 		sc:                 sc,
 		ServerErrorHandler: nil,
 		operationHandlers: map[operations.OperationID]http.Handler{
+			operations.OperationIDDeleteBucket:         RepoOperationHandler(sc, &operations.DeleteBucket{}),
 			operations.OperationIDDeleteObject:         PathOperationHandler(sc, &operations.DeleteObject{}),
 			operations.OperationIDDeleteObjects:        RepoOperationHandler(sc, &operations.DeleteObjects{}),
 			operations.OperationIDGetObject:            PathOperationHandler(sc, &operations.GetObject{}),
@@ -164,7 +165,7 @@ func OperationHandler(sc *ServerContext, handler operations.AuthenticatedOperati
 			_ = o.EncodeError(w, req, err, gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authorize(w, req, sc.authService, perms)
+		authOp := authorize(w, req, sc.authService, perms, auth.NewRequestContext(req, "", nil))
 		if authOp == nil {
 			return
 		}
@@ -183,7 +184,7 @@ func RepoOperationHandler(sc *ServerContext, handler operations.RepoOperationHan
 			_ = o.EncodeError(w, req, err, gatewayerrors.ErrAccessDenied.ToAPIErr())
 			return
 		}
-		authOp := authorize(w, req, sc.authService, perms)
+		authOp := authorize(w, req, sc.authService, perms, auth.NewRequestContext(req, "", nil))
 		if authOp == nil {
 			return
 		}
@@ -218,7 +219,9 @@ func PathOperationHandler(sc *ServerContext, handler operations.PathOperationHan
 			return
 		}
 
-		authOp := authorize(w, req, sc.authService, perms)
+		reqCtx := auth.NewRequestContext(req, "", operations.AmzMetaUserMetadata(req))
+		reqCtx.ResolveBranch = operations.RequestBranchResolver(req, sc.catalog, repo.Name, refID)
+		authOp := authorize(w, req, sc.authService, perms, reqCtx)
 		if authOp == nil {
 			return
 		}
@@ -245,7 +248,7 @@ func PathOperationHandler(sc *ServerContext, handler operations.PathOperationHan
 	})
 }
 
-func authorize(w http.ResponseWriter, req *http.Request, authService auth.GatewayService, perms permissions.Node) *operations.AuthorizedOperation {
+func authorize(w http.ResponseWriter, req *http.Request, authService auth.GatewayService, perms permissions.Node, reqCtx *auth.RequestContext) *operations.AuthorizedOperation {
 	ctx := req.Context()
 	o := ctx.Value(ContextKeyOperation).(*operations.Operation)
 	user, err := auth.GetUser(ctx)
@@ -271,6 +274,7 @@ func authorize(w http.ResponseWriter, req *http.Request, authService auth.Gatewa
 	authResp, err := authService.Authorize(req.Context(), &auth.AuthorizationRequest{
 		Username:            username,
 		RequiredPermissions: perms,
+		RequestContext:      reqCtx,
 	})
 	if err != nil {
 		o.Log(req).WithError(err).Error("failed to authorize")
@@ -73,6 +73,7 @@ func AuthenticationHandler(authService auth.GatewayService, next http.Handler) h
 		}
 		ctx = logging.AddFields(ctx, logging.Fields{logging.UserFieldKey: user.Username})
 		ctx = auth.WithUser(ctx, user)
+		ctx = auth.WithCredential(ctx, creds)
 		ctx = context.WithValue(ctx, ContextKeyAuthContext, authContext)
 		req = req.WithContext(ctx)
 		next.ServeHTTP(w, req)
@@ -198,6 +199,7 @@ func EnrichWithRepositoryOrFallback(c *catalog.Catalog, authService auth.Gateway
 				RequiredPermissions: permissions.Node{
 					Permission: permissions.Permission{Action: permissions.ListRepositoriesAction, Resource: "*"},
 				},
+				RequestContext: auth.NewRequestContext(req, "", nil),
 			})
 			if authErr != nil || authResp.Error != nil || !authResp.Allowed {
 				_ = o.EncodeError(w, req, err, gatewayerrors.ErrAccessDenied.ToAPIErr())
@@ -323,7 +325,7 @@ func pathBasedOperationID(method string) operations.OperationID {
 func repositoryBasedOperationID(method string) operations.OperationID {
 	switch method {
 	case http.MethodDelete:
-		return operations.OperationIDUnsupportedOperation
+		return operations.OperationIDDeleteBucket
 	case http.MethodPut:
 		return operations.OperationIDPutBucket
 	case http.MethodHead:
@@ -27,6 +27,7 @@ const StorageClassHeader = "x-amz-storage-class"
 type OperationID string
 
 const (
+	OperationIDDeleteBucket  OperationID = "delete_bucket"
 	OperationIDDeleteObject  OperationID = "delete_object"
 	OperationIDDeleteObjects OperationID = "delete_objects"
 	OperationIDGetObject     OperationID = "get_object"
@@ -115,12 +116,21 @@ func (o *Operation) EncodeResponse(w http.ResponseWriter, req *http.Request, ent
 	}
 }
 
+// maxXMLBodySize is the maximal size of an XML request body: room for a DeleteObjects request of as many keys as S3
+// allows, or for a SelectObjectContent request of the longest expression S3 allows, with their characters escaped
+const maxXMLBodySize = 8 << 20
+
+var errXMLBodyTooLarge = errors.New("XML body too large")
+
 func DecodeXMLBody(reader io.Reader, entity interface{}) error {
-	body := reader
+	body := io.LimitReader(reader, maxXMLBodySize+1)
 	content, err := io.ReadAll(body)
 	if err != nil {
 		return err
 	}
+	if len(content) > maxXMLBodySize {
+		return errXMLBodyTooLarge
+	}
 	err = xml.Unmarshal(content, entity)
 	if err != nil {
 		return err
@@ -128,6 +138,14 @@ func DecodeXMLBody(reader io.Reader, entity interface{}) error {
 	return nil
 }
 
+// decodeXMLErrorCode returns the error code of a request whose XML body DecodeXMLBody failed to decode
+func decodeXMLErrorCode(err error) gwerrors.APIErrorCode {
+	if errors.Is(err, errXMLBodyTooLarge) {
+		return gwerrors.ErrMaxMessageLengthExceeded
+	}
+	return gwerrors.ErrMalformedXML
+}
+
 // SetHeader sets a header on the response while preserving its case
 func (o *Operation) SetHeader(w http.ResponseWriter, key, value string) {
 	w.Header()[key] = []string{value}
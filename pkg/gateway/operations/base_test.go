@@ -0,0 +1,37 @@
+package operations
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+)
+
+func TestDecodeXMLBody(t *testing.T) {
+	t.Run("decode", func(t *testing.T) {
+		var decoded serde.Delete
+		body := `<Delete><Object><Key>a</Key></Object><Object><Key>b</Key></Object></Delete>`
+		if err := DecodeXMLBody(strings.NewReader(body), &decoded); err != nil {
+			t.Fatalf("DecodeXMLBody() error = %v", err)
+		}
+		if len(decoded.Object) != 2 {
+			t.Errorf("DecodeXMLBody() decoded %d objects, expected 2", len(decoded.Object))
+		}
+	})
+
+	t.Run("too large", func(t *testing.T) {
+		var decoded serde.Delete
+		var body bytes.Buffer
+		body.WriteString("<Delete>")
+		for body.Len() <= maxXMLBodySize {
+			body.WriteString("<Object><Key>key</Key></Object>")
+		}
+		body.WriteString("</Delete>")
+		err := DecodeXMLBody(&body, &decoded)
+		if !errors.Is(err, errXMLBodyTooLarge) {
+			t.Fatalf("DecodeXMLBody() error = %v, expected %v", err, errXMLBodyTooLarge)
+		}
+	})
+}
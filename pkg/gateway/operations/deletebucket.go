@@ -0,0 +1,37 @@
+package operations
+
+import (
+	"net/http"
+
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/permissions"
+)
+
+// DeleteBucket handles S3 Delete Bucket sub-resource operations.  Deleting the
+// bucket itself is not supported: repositories are deleted through the lakeFS API.
+type DeleteBucket struct{}
+
+func (controller *DeleteBucket) RequiredPermissions(req *http.Request, repoID string) (permissions.Node, error) {
+	if req.URL.Query().Has(LifecycleQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.SetLifecycleRulesAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
+	return permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.DeleteRepositoryAction,
+			Resource: permissions.RepoArn(repoID),
+		},
+	}, nil
+}
+
+func (controller *DeleteBucket) Handle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	if req.URL.Query().Has(LifecycleQueryParam) {
+		handleDeleteBucketLifecycle(w, req, o)
+		return
+	}
+	_ = o.EncodeError(w, req, nil, gatewayerrors.ERRLakeFSNotSupported.ToAPIErr())
+}
@@ -13,7 +13,16 @@ import (
 
 type DeleteObject struct{}
 
-func (controller *DeleteObject) RequiredPermissions(_ *http.Request, repoID, _, path string) (permissions.Node, error) {
+func (controller *DeleteObject) RequiredPermissions(req *http.Request, repoID, _, path string) (permissions.Node, error) {
+	if req.URL.Query().Has("tagging") {
+		// deleting the tags of an object updates it
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.WriteObjectAction,
+				Resource: permissions.ObjectArn(repoID, path),
+			},
+		}, nil
+	}
 	return permissions.Node{
 		Permission: permissions.Permission{
 			Action:   permissions.DeleteObjectAction,
@@ -61,7 +70,7 @@ func (controller *DeleteObject) HandleAbortMultipartUpload(w http.ResponseWriter
 }
 
 func (controller *DeleteObject) Handle(w http.ResponseWriter, req *http.Request, o *PathOperation) {
-	if o.HandleUnsupported(w, req, "tagging", "acl", "torrent") {
+	if o.HandleUnsupported(w, req, "acl", "torrent") {
 		return
 	}
 	if o.Repository.ReadOnly {
@@ -73,6 +82,15 @@ func (controller *DeleteObject) Handle(w http.ResponseWriter, req *http.Request,
 		controller.HandleAbortMultipartUpload(w, req, o)
 		return
 	}
+	if query.Has("tagging") {
+		handleDeleteObjectTagging(w, req, o)
+		return
+	}
+	if query.Has(QueryParamVersionID) {
+		// versions are commits, which cannot be deleted
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	}
 
 	o.Incr("delete_object", o.Principal, o.Repository.Name, o.Reference)
 	lg := o.Log(req).WithField("key", o.Path)
@@ -83,6 +101,9 @@ func (controller *DeleteObject) Handle(w http.ResponseWriter, req *http.Request,
 	case errors.Is(err, graveler.ErrWriteToProtectedBranch):
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrWriteToProtectedBranch))
 		return
+	case errors.Is(err, graveler.ErrObjectLocked):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrObjectLocked))
+		return
 	case errors.Is(err, graveler.ErrReadOnlyRepository):
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrReadOnlyRepository))
 		return
@@ -41,6 +41,10 @@ func (controller *DeleteObjects) Handle(w http.ResponseWriter, req *http.Request
 
 	decodedXML := &serde.Delete{}
 	err := DecodeXMLBody(req.Body, decodedXML)
+	if errors.Is(err, errXMLBodyTooLarge) {
+		_ = o.EncodeError(w, req, err, gerrors.Codes.ToAPIErr(gerrors.ErrMaxMessageLengthExceeded))
+		return
+	}
 	if err != nil {
 		_ = o.EncodeError(w, req, err, gerrors.Codes.ToAPIErr(gerrors.ErrBadRequest))
 		return
@@ -59,6 +63,17 @@ func (controller *DeleteObjects) Handle(w http.ResponseWriter, req *http.Request
 		errs          []serde.DeleteError
 	)
 	for _, obj := range decodedXML.Object {
+		if obj.VersionID != "" {
+			// versions are commits, which cannot be deleted
+			notImplemented := gerrors.Codes.ToAPIErr(gerrors.ErrNotImplemented)
+			errs = append(errs, serde.DeleteError{
+				Code:      notImplemented.Code,
+				Key:       obj.Key,
+				Message:   notImplemented.Description,
+				VersionID: obj.VersionID,
+			})
+			continue
+		}
 		resolvedPath, err := path.ResolvePath(obj.Key)
 		if err != nil {
 			errs = append(errs, serde.DeleteError{
@@ -69,6 +84,8 @@ func (controller *DeleteObjects) Handle(w http.ResponseWriter, req *http.Request
 			continue
 		}
 		// authorize this object deletion
+		reqCtx := auth.NewRequestContext(req, "", nil)
+		reqCtx.ResolveBranch = RequestBranchResolver(req, o.Catalog, o.Repository.Name, resolvedPath.Ref)
 		authResp, err := o.Auth.Authorize(req.Context(), &auth.AuthorizationRequest{
 			Username: o.Principal,
 			RequiredPermissions: permissions.Node{
@@ -77,6 +94,7 @@ func (controller *DeleteObjects) Handle(w http.ResponseWriter, req *http.Request
 					Resource: permissions.ObjectArn(o.Repository.Name, resolvedPath.Path),
 				},
 			},
+			RequestContext: reqCtx,
 		})
 		if err != nil || !authResp.Allowed {
 			errs = append(errs, serde.DeleteError{
@@ -136,10 +154,11 @@ func (controller *DeleteObjects) batchDelete(ctx context.Context, log logging.Lo
 	var result serde.DeleteResult
 	batchErr := o.Catalog.DeleteEntries(ctx, o.Repository.Name, ref, pathsToDelete)
 	deleteErrs := graveler.NewMapDeleteErrors(batchErr)
-	for _, key := range keysToDelete {
-		// err will set to the specific error if possible, fallback to the batch delete error
-		err := deleteErrs[key]
-		if err == nil {
+	for i, key := range keysToDelete {
+		// err will set to the specific error if possible, fallback to the batch delete error unless it failed
+		// specific keys
+		err := deleteErrs[pathsToDelete[i]]
+		if err == nil && len(deleteErrs) == 0 {
 			err = batchErr
 		}
 		updateDeleteResult(&result, quiet, log, key, err)
@@ -168,6 +187,13 @@ func checkForDeleteError(log logging.Logger, key string, err error) *serde.Delet
 			Key:     key,
 			Message: fmt.Sprintf("error deleting object: %s", apiErr.Description),
 		}
+	case errors.Is(err, graveler.ErrObjectLocked):
+		apiErr := gerrors.Codes.ToAPIErr(gerrors.ErrObjectLocked)
+		return &serde.DeleteError{
+			Code:    apiErr.Code,
+			Key:     key,
+			Message: fmt.Sprintf("error deleting object: %s", apiErr.Description),
+		}
 	case errors.Is(err, graveler.ErrReadOnlyRepository):
 		apiErr := gerrors.Codes.ToAPIErr(gerrors.ErrReadOnlyRepository)
 		return &serde.DeleteError{
@@ -29,7 +29,24 @@ const (
 
 type GetObject struct{}
 
-func (controller *GetObject) RequiredPermissions(_ *http.Request, repoID, _, path string) (permissions.Node, error) {
+func (controller *GetObject) RequiredPermissions(req *http.Request, repoID, _, path string) (permissions.Node, error) {
+	query := req.URL.Query()
+	if query.Has(RetentionQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.GetObjectRetentionAction,
+				Resource: permissions.ObjectArn(repoID, path),
+			},
+		}, nil
+	}
+	if query.Has(LegalHoldQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.GetObjectLegalHoldAction,
+				Resource: permissions.ObjectArn(repoID, path),
+			},
+		}, nil
+	}
 	return permissions.Node{
 		Permission: permissions.Permission{
 			Action:   permissions.ReadObjectAction,
@@ -39,7 +56,7 @@ func (controller *GetObject) RequiredPermissions(_ *http.Request, repoID, _, pat
 }
 
 func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o *PathOperation) {
-	if o.HandleUnsupported(w, req, "torrent", "acl", "retention", "legal-hold", "lambdaArn") {
+	if o.HandleUnsupported(w, req, "torrent", "acl", "lambdaArn") {
 		return
 	}
 	userAgent := req.Header.Get("User-Agent")
@@ -56,7 +73,17 @@ func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o
 	}
 
 	if _, exists := query["tagging"]; exists {
-		o.EncodeResponse(w, req, serde.Tagging{}, http.StatusOK)
+		handleGetObjectTagging(w, req, o)
+		return
+	}
+
+	if query.Has(RetentionQueryParam) {
+		handleGetObjectRetention(w, req, o)
+		return
+	}
+
+	if query.Has(LegalHoldQueryParam) {
+		handleGetObjectLegalHold(w, req, o)
 		return
 	}
 
@@ -67,13 +94,17 @@ func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o
 	}
 
 	beforeMeta := time.Now()
-	entry, err := o.Catalog.GetEntry(ctx, o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	entry, versionID, err := getEntryVersion(req, o)
 	metaTook := time.Since(beforeMeta)
 	o.Log(req).
 		WithField("took", metaTook).
 		WithError(err).
 		Debug("metadata operation to retrieve object done")
 
+	if errors.Is(err, errNoSuchVersion) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	}
 	if errors.Is(err, graveler.ErrNotFound) {
 		// TODO: create distinction between missing repo & missing key
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
@@ -83,11 +114,26 @@ func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
 		return
 	}
+	if errors.Is(err, graveler.ErrCommitDatesOutOfOrder) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
 	if err != nil {
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
 		return
 	}
 
+	if !checkReadPreconditions(w, req, o, entry) {
+		return
+	}
+
+	customerKey, err := objectCustomerKey(req.Header, sseCustomerKeyHeaders, entry.Metadata)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("invalid customer key")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(sseErrorCode(err)))
+		return
+	}
+
 	// TODO: the rest of https://docs.aws.amazon.com/en_pv/AmazonS3/latest/API/API_GetObject.html
 	// range query
 	var data io.ReadCloser
@@ -114,9 +160,11 @@ func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o
 		StorageNamespace: o.Repository.StorageNamespace,
 		IdentifierType:   entry.AddressType.ToIdentifierType(),
 		Identifier:       entry.PhysicalAddress,
+		CustomerKey:      customerKey,
 	}
 
-	if redirect {
+	// a pre-signed URL cannot pass the customer key
+	if redirect && customerKey == nil {
 		preSignedURL, _, err := o.BlockStore.GetPreSignedURL(ctx, objectPointer, block.PreSignModeRead, "")
 		if err != nil {
 			code := gatewayerrors.ErrInternalError
@@ -159,6 +207,11 @@ func (controller *GetObject) Handle(w http.ResponseWriter, req *http.Request, o
 	o.SetHeader(w, "X-Frame-Options", "SAMEORIGIN")
 	o.SetHeader(w, "Content-Security-Policy", "default-src 'none'")
 	amzMetaWriteHeaders(w, entry.Metadata)
+	amzTaggingWriteHeaders(w, entry.Metadata)
+	sseWriteHeaders(w, entry.Metadata)
+	if versionID != "" {
+		o.SetHeader(w, amzVersionIDHeader, versionID)
+	}
 	w.WriteHeader(statusCode)
 
 	defer func() {
@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"net/http"
 
-	"github.com/treeverse/lakefs/pkg/catalog"
 	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/httputil"
@@ -25,13 +24,22 @@ func (controller *HeadObject) RequiredPermissions(_ *http.Request, repoID, _, pa
 
 func (controller *HeadObject) Handle(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 	o.Incr("stat_object", o.Principal, o.Repository.Name, o.Reference)
-	entry, err := o.Catalog.GetEntry(req.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	entry, versionID, err := getEntryVersion(req, o)
+	if errors.Is(err, errNoSuchVersion) {
+		o.Log(req).WithError(err).Debug("version not found")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	}
 	if errors.Is(err, graveler.ErrNotFound) {
 		// TODO: create distinction between missing repo & missing key
 		o.Log(req).Debug("path not found")
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
 		return
 	}
+	if errors.Is(err, graveler.ErrCommitDatesOutOfOrder) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
 	if err != nil {
 		o.Log(req).WithError(err).Error("failed querying path")
 		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
@@ -43,6 +51,16 @@ func (controller *HeadObject) Handle(w http.ResponseWriter, req *http.Request, o
 		return
 	}
 
+	if !checkReadPreconditions(w, req, o, entry) {
+		return
+	}
+
+	if _, err := objectCustomerKey(req.Header, sseCustomerKeyHeaders, entry.Metadata); err != nil {
+		o.Log(req).WithError(err).Debug("invalid customer key")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(sseErrorCode(err)))
+		return
+	}
+
 	// range query
 	var rng httputil.Range
 	var rngErr error
@@ -65,6 +83,11 @@ func (controller *HeadObject) Handle(w http.ResponseWriter, req *http.Request, o
 	o.SetHeader(w, "Content-Type", entry.ContentType)
 
 	amzMetaWriteHeaders(w, entry.Metadata)
+	amzTaggingWriteHeaders(w, entry.Metadata)
+	sseWriteHeaders(w, entry.Metadata)
+	if versionID != "" {
+		o.SetHeader(w, amzVersionIDHeader, versionID)
+	}
 	if rangeSpec != "" && rngErr == nil {
 		o.SetHeader(w, "Content-Length", fmt.Sprintf("%d", rng.Size()))
 		o.SetHeader(w, "Content-Range", fmt.Sprintf("bytes %d-%d/%d", rng.StartOffset, rng.EndOffset, entry.Size))
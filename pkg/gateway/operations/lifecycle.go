@@ -0,0 +1,180 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/path"
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+const (
+	LifecycleQueryParam = "lifecycle"
+
+	lifecycleStatusEnabled  = "Enabled"
+	lifecycleStatusDisabled = "Disabled"
+	// lifecycleAllBranches is the branch part of the prefix of rules that apply to all branches
+	lifecycleAllBranches = "*"
+)
+
+var errInvalidLifecycleRule = errors.New("invalid lifecycle rule")
+
+// lifecycleRuleFromS3 converts an S3 lifecycle rule.  The rule prefix is a branch pattern followed by the path
+// prefix, e.g. "main/tmp/" or "*/logs/"; an empty prefix applies to all objects of all branches.  Expiration days
+// expire committed objects.
+func lifecycleRuleFromS3(rule serde.LifecycleRule) (*graveler.LifecycleRule, error) {
+	var prefix string
+	switch {
+	case rule.Filter != nil && (rule.Filter.Tag != nil || rule.Filter.And != nil):
+		return nil, fmt.Errorf("%w: tag filters are not supported", errInvalidLifecycleRule)
+	case rule.Filter != nil:
+		prefix = rule.Filter.Prefix
+	case rule.Prefix != nil:
+		prefix = *rule.Prefix
+	}
+	result := &graveler.LifecycleRule{Id: rule.ID}
+	if prefix != "" {
+		branchPattern, pathPrefix, found := strings.Cut(prefix, path.Separator)
+		if !found || branchPattern == "" {
+			return nil, fmt.Errorf("%w: prefix '%s' does not start with a branch", errInvalidLifecycleRule, prefix)
+		}
+		if branchPattern != lifecycleAllBranches {
+			result.BranchPattern = branchPattern
+		}
+		result.Prefix = pathPrefix
+	}
+	switch rule.Status {
+	case lifecycleStatusEnabled:
+		result.Enabled = true
+	case lifecycleStatusDisabled:
+	default:
+		return nil, fmt.Errorf("%w: status '%s'", errInvalidLifecycleRule, rule.Status)
+	}
+	if rule.Expiration != nil {
+		if rule.Expiration.Date != "" {
+			return nil, fmt.Errorf("%w: expiration date is not supported", errInvalidLifecycleRule)
+		}
+		result.CommittedExpirationDays = int32(rule.Expiration.Days) //nolint:gosec
+	}
+	return result, nil
+}
+
+func lifecycleRuleToS3(rule *graveler.LifecycleRule) serde.LifecycleRule {
+	var prefix string
+	if rule.GetBranchPattern() != "" || rule.GetPrefix() != "" {
+		branchPattern := rule.GetBranchPattern()
+		if branchPattern == "" {
+			branchPattern = lifecycleAllBranches
+		}
+		prefix = branchPattern + path.Separator + rule.GetPrefix()
+	}
+	result := serde.LifecycleRule{
+		ID:     rule.GetId(),
+		Filter: &serde.LifecycleFilter{Prefix: prefix},
+		Status: lifecycleStatusDisabled,
+	}
+	if rule.GetEnabled() {
+		result.Status = lifecycleStatusEnabled
+	}
+	// rules that only expire uncommitted objects have no S3 equivalent action
+	if rule.GetCommittedExpirationDays() > 0 {
+		result.Expiration = &serde.LifecycleExpiration{Days: int(rule.GetCommittedExpirationDays())}
+	}
+	return result
+}
+
+func lifecycleErrorCode(err error) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, graveler.ErrInvalidValue), errors.Is(err, errInvalidLifecycleRule):
+		return gatewayerrors.ErrInvalidLifecycleRule
+	case errors.Is(err, graveler.ErrReadOnlyRepository):
+		return gatewayerrors.ErrReadOnlyRepository
+	default:
+		return gatewayerrors.ErrInternalError
+	}
+}
+
+// handleGetBucketLifecycle returns the lifecycle rules of the repository
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketLifecycleConfiguration.html
+func handleGetBucketLifecycle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("get_bucket_lifecycle", o.Principal, o.Repository.Name, "")
+	rules, _, err := o.Catalog.GetLifecycleRules(req.Context(), o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	if len(rules.GetRules()) == 0 {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchLifecycleConfiguration))
+		return
+	}
+	response := serde.LifecycleConfiguration{}
+	for _, rule := range rules.GetRules() {
+		response.Rules = append(response.Rules, lifecycleRuleToS3(rule))
+	}
+	o.EncodeResponse(w, req, response, http.StatusOK)
+}
+
+// handlePutBucketLifecycle replaces the lifecycle rules of the repository.  A rule keeps the uncommitted
+// expiration of the current rule with the same ID, as S3 cannot express it.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLifecycleConfiguration.html
+func handlePutBucketLifecycle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("put_bucket_lifecycle", o.Principal, o.Repository.Name, "")
+	var configuration serde.LifecycleConfiguration
+	if err := serde.DecodeXML(req.Body, &configuration); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode lifecycle configuration")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	ctx := req.Context()
+	current, checksum, err := o.Catalog.GetLifecycleRules(ctx, o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	uncommittedExpiration := make(map[string]int32, len(current.GetRules()))
+	for _, rule := range current.GetRules() {
+		uncommittedExpiration[rule.GetId()] = rule.GetUncommittedExpirationHours()
+	}
+
+	rules := &graveler.LifecycleRules{}
+	for i, s3Rule := range configuration.Rules {
+		rule, err := lifecycleRuleFromS3(s3Rule)
+		if err != nil {
+			_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidLifecycleRule))
+			return
+		}
+		// S3 rule IDs are optional
+		if rule.Id == "" {
+			rule.Id = fmt.Sprintf("rule-%d", i+1)
+		}
+		rule.UncommittedExpirationHours = uncommittedExpiration[rule.GetId()]
+		rules.Rules = append(rules.Rules, rule)
+	}
+	err = o.Catalog.SetLifecycleRules(ctx, o.Repository.Name, rules, checksum)
+	if errors.Is(err, graveler.ErrPreconditionFailed) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrOperationAborted))
+		return
+	}
+	if err != nil {
+		o.Log(req).WithError(err).Debug("could not set lifecycle rules")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(lifecycleErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleDeleteBucketLifecycle removes the lifecycle rules of the repository
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketLifecycle.html
+func handleDeleteBucketLifecycle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("delete_bucket_lifecycle", o.Principal, o.Repository.Name, "")
+	err := o.Catalog.DeleteLifecycleRules(req.Context(), o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(lifecycleErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusNoContent)
+}
@@ -37,8 +37,32 @@ const (
 type ListObjects struct{}
 
 func (controller *ListObjects) RequiredPermissions(req *http.Request, repoID string) (permissions.Node, error) {
-	// check if we're listing files in a branch, or listing branches
 	params := req.URL.Query()
+	if params.Has(LifecycleQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.GetLifecycleRulesAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
+	if params.Has(NotificationQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.ReadNotificationConfigurationAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
+	if params.Has(ObjectLockQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.GetObjectLockConfigurationAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
+	// check if we're listing files in a branch, or listing branches
 	delimiter := params.Get("delimiter")
 	prefix := params.Get("prefix")
 	if delimiter == "/" && !strings.Contains(prefix, "/") {
@@ -360,9 +384,9 @@ func (controller *ListObjects) ListV1(w http.ResponseWriter, req *http.Request,
 
 func (controller *ListObjects) Handle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
 	if o.HandleUnsupported(w, req, "inventory", "metrics", "publicAccessBlock", "ownershipControls",
-		"intelligent-tiering", "analytics", "policy", "lifecycle", "encryption", "object-lock", "replication",
-		"notification", "events", "acl", "cors", "website", "accelerate",
-		"requestPayment", "logging", "tagging", "versions", "policyStatus") {
+		"intelligent-tiering", "analytics", "policy", "encryption", "replication",
+		"events", "acl", "cors", "website", "accelerate",
+		"requestPayment", "logging", "tagging", "policyStatus") {
 		return
 	}
 	query := req.URL.Query()
@@ -382,11 +406,27 @@ func (controller *ListObjects) Handle(w http.ResponseWriter, req *http.Request,
 		handleListMultipartUploads(w, req, o)
 		return
 	}
+	if query.Has("versions") {
+		controller.ListVersions(w, req, o)
+		return
+	}
 	// getbucketversioing support
 	if query.Has("versioning") {
 		o.EncodeXMLBytes(w, req, []byte(serde.VersioningResponse), http.StatusOK)
 		return
 	}
+	if query.Has(LifecycleQueryParam) {
+		handleGetBucketLifecycle(w, req, o)
+		return
+	}
+	if query.Has(NotificationQueryParam) {
+		handleGetBucketNotification(w, req, o)
+		return
+	}
+	if query.Has(ObjectLockQueryParam) {
+		handleGetBucketObjectLock(w, req, o)
+		return
+	}
 	o.Incr("list_objects", o.Principal, o.Repository.Name, "")
 
 	// parse request parameters
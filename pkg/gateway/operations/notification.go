@@ -0,0 +1,147 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+const NotificationQueryParam = "notification"
+
+func notificationFilterRulesFromS3(filter *serde.NotificationFilter) []*graveler.NotificationFilterRule {
+	if filter == nil {
+		return nil
+	}
+	rules := make([]*graveler.NotificationFilterRule, 0, len(filter.Key.FilterRules))
+	for _, rule := range filter.Key.FilterRules {
+		// S3 filter rule names are case-insensitive
+		rules = append(rules, &graveler.NotificationFilterRule{Name: strings.ToLower(rule.Name), Value: rule.Value})
+	}
+	return rules
+}
+
+func notificationFilterToS3(rules []*graveler.NotificationFilterRule) *serde.NotificationFilter {
+	if len(rules) == 0 {
+		return nil
+	}
+	filter := &serde.NotificationFilter{}
+	for _, rule := range rules {
+		filter.Key.FilterRules = append(filter.Key.FilterRules, serde.NotificationFilterRule{Name: rule.GetName(), Value: rule.GetValue()})
+	}
+	return filter
+}
+
+// notificationConfigurationFromS3 converts an S3 notification configuration.  The topic, queue or function ARN of
+// each configuration references a sink configured on the lakeFS server.
+func notificationConfigurationFromS3(configuration *serde.NotificationConfiguration) *graveler.NotificationConfiguration {
+	result := &graveler.NotificationConfiguration{}
+	add := func(id, arn string, kind graveler.NotificationTargetKind, events []string, filter *serde.NotificationFilter) {
+		// S3 configuration IDs are optional
+		if id == "" {
+			id = fmt.Sprintf("notification-%d", len(result.Targets)+1)
+		}
+		result.Targets = append(result.Targets, &graveler.NotificationTarget{
+			Id:          id,
+			Arn:         arn,
+			Kind:        kind,
+			Events:      events,
+			FilterRules: notificationFilterRulesFromS3(filter),
+		})
+	}
+	for _, c := range configuration.TopicConfigurations {
+		add(c.ID, c.Topic, graveler.NotificationTargetKind_TOPIC, c.Events, c.Filter)
+	}
+	for _, c := range configuration.QueueConfigurations {
+		add(c.ID, c.Queue, graveler.NotificationTargetKind_QUEUE, c.Events, c.Filter)
+	}
+	for _, c := range configuration.CloudFunctionConfigurations {
+		add(c.ID, c.CloudFunction, graveler.NotificationTargetKind_CLOUD_FUNCTION, c.Events, c.Filter)
+	}
+	return result
+}
+
+func notificationConfigurationToS3(configuration *graveler.NotificationConfiguration) serde.NotificationConfiguration {
+	result := serde.NotificationConfiguration{}
+	for _, target := range configuration.GetTargets() {
+		filter := notificationFilterToS3(target.GetFilterRules())
+		switch target.GetKind() {
+		case graveler.NotificationTargetKind_TOPIC:
+			result.TopicConfigurations = append(result.TopicConfigurations, serde.TopicConfiguration{
+				ID: target.GetId(), Topic: target.GetArn(), Events: target.GetEvents(), Filter: filter,
+			})
+		case graveler.NotificationTargetKind_CLOUD_FUNCTION:
+			result.CloudFunctionConfigurations = append(result.CloudFunctionConfigurations, serde.CloudFunctionConfiguration{
+				ID: target.GetId(), CloudFunction: target.GetArn(), Events: target.GetEvents(), Filter: filter,
+			})
+		default:
+			result.QueueConfigurations = append(result.QueueConfigurations, serde.QueueConfiguration{
+				ID: target.GetId(), Queue: target.GetArn(), Events: target.GetEvents(), Filter: filter,
+			})
+		}
+	}
+	return result
+}
+
+func notificationErrorCode(err error) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, catalog.ErrNotificationsDisabled):
+		return gatewayerrors.ErrNotImplemented
+	case errors.Is(err, graveler.ErrInvalidValue):
+		return gatewayerrors.ErrInvalidNotificationConfiguration
+	case errors.Is(err, graveler.ErrPreconditionFailed):
+		return gatewayerrors.ErrOperationAborted
+	case errors.Is(err, graveler.ErrReadOnlyRepository):
+		return gatewayerrors.ErrReadOnlyRepository
+	default:
+		return gatewayerrors.ErrInternalError
+	}
+}
+
+// handleGetBucketNotification returns the notification configuration of the repository, empty if it has none
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketNotificationConfiguration.html
+func handleGetBucketNotification(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("get_bucket_notification", o.Principal, o.Repository.Name, "")
+	configuration, _, err := o.Catalog.GetNotificationConfiguration(req.Context(), o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.EncodeResponse(w, req, notificationConfigurationToS3(configuration), http.StatusOK)
+}
+
+// handlePutBucketNotification replaces the notification configuration of the repository.  An empty configuration
+// stops all notifications.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketNotificationConfiguration.html
+func handlePutBucketNotification(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("put_bucket_notification", o.Principal, o.Repository.Name, "")
+	var configuration serde.NotificationConfiguration
+	if err := serde.DecodeXML(req.Body, &configuration); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode notification configuration")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	ctx := req.Context()
+	_, checksum, err := o.Catalog.GetNotificationConfiguration(ctx, o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	notificationConfiguration := notificationConfigurationFromS3(&serde.NotificationConfiguration{
+		TopicConfigurations:         configuration.TopicConfigurations,
+		QueueConfigurations:         configuration.QueueConfigurations,
+		CloudFunctionConfigurations: configuration.CloudFunctionConfigurations,
+	})
+	err = o.Catalog.SetNotificationConfiguration(ctx, o.Repository.Name, notificationConfiguration, checksum)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("could not set notification configuration")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(notificationErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
@@ -0,0 +1,243 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/objectlock"
+	"github.com/treeverse/lakefs/pkg/permissions"
+)
+
+const (
+	ObjectLockQueryParam = "object-lock"
+	RetentionQueryParam  = "retention"
+	LegalHoldQueryParam  = "legal-hold"
+
+	amzBypassGovernanceRetentionHeader = "X-Amz-Bypass-Governance-Retention"
+
+	objectLockEnabled = "Enabled"
+	legalHoldOn       = "ON"
+	legalHoldOff      = "OFF"
+)
+
+// amzObjectLockHeaders lock objects as they are written, which is not supported: only committed objects can be locked
+var amzObjectLockHeaders = []string{
+	"X-Amz-Object-Lock-Mode",
+	"X-Amz-Object-Lock-Retain-Until-Date",
+	"X-Amz-Object-Lock-Legal-Hold",
+}
+
+func hasObjectLockHeaders(req *http.Request) bool {
+	for _, header := range amzObjectLockHeaders {
+		if req.Header.Get(header) != "" {
+			return true
+		}
+	}
+	return false
+}
+
+func bypassGovernanceRetention(req *http.Request) bool {
+	bypass, _ := strconv.ParseBool(req.Header.Get(amzBypassGovernanceRetentionHeader))
+	return bypass
+}
+
+// objectRetentionPermissions returns the permissions required to set the retention of the object at path, which
+// include bypassing governance mode retention if requested
+func objectRetentionPermissions(req *http.Request, repoID, path string) permissions.Node {
+	node := permissions.Node{
+		Permission: permissions.Permission{
+			Action:   permissions.SetObjectRetentionAction,
+			Resource: permissions.ObjectArn(repoID, path),
+		},
+	}
+	if !bypassGovernanceRetention(req) {
+		return node
+	}
+	return permissions.Node{
+		Type: permissions.NodeTypeAnd,
+		Nodes: []permissions.Node{
+			node,
+			{
+				Permission: permissions.Permission{
+					Action:   permissions.BypassGovernanceRetentionAction,
+					Resource: permissions.ObjectArn(repoID, path),
+				},
+			},
+		},
+	}
+}
+
+func objectLockErrorCode(err error) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, graveler.ErrNotFound):
+		return gatewayerrors.ErrNoSuchKey
+	case errors.Is(err, graveler.ErrObjectLocked):
+		return gatewayerrors.ErrObjectLocked
+	case errors.Is(err, graveler.ErrReadOnlyRepository):
+		return gatewayerrors.ErrReadOnlyRepository
+	case errors.Is(err, graveler.ErrPreconditionFailed):
+		return gatewayerrors.ErrOperationAborted
+	case errors.Is(err, graveler.ErrInvalidValue):
+		return gatewayerrors.ErrInvalidObjectLockRequest
+	default:
+		return gatewayerrors.ErrInternalError
+	}
+}
+
+// handleGetBucketObjectLock returns the object lock configuration of the repository
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLockConfiguration.html
+func handleGetBucketObjectLock(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("get_bucket_object_lock", o.Principal, o.Repository.Name, "")
+	configuration, _, err := o.Catalog.GetObjectLockConfiguration(req.Context(), o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	if !configuration.GetEnabled() {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrObjectLockConfigurationNotFound))
+		return
+	}
+	o.EncodeResponse(w, req, serde.ObjectLockConfiguration{ObjectLockEnabled: objectLockEnabled}, http.StatusOK)
+}
+
+// handlePutBucketObjectLock enables object lock on the repository.  Default retention rules are not supported.
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLockConfiguration.html
+func handlePutBucketObjectLock(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("put_bucket_object_lock", o.Principal, o.Repository.Name, "")
+	var configuration serde.ObjectLockConfiguration
+	if err := serde.DecodeXML(req.Body, &configuration); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode object lock configuration")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	if configuration.ObjectLockEnabled != objectLockEnabled {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	if configuration.Rule != nil {
+		o.Log(req).Debug("default retention rules are not supported")
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNotImplemented))
+		return
+	}
+	ctx := req.Context()
+	_, checksum, err := o.Catalog.GetObjectLockConfiguration(ctx, o.Repository.Name)
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	err = o.Catalog.SetObjectLockConfiguration(ctx, o.Repository.Name, &graveler.ObjectLockConfiguration{Enabled: true}, checksum)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("could not set object lock configuration")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(objectLockErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// getObjectLock returns the lock of the object, nil if it was never locked
+func getObjectLock(w http.ResponseWriter, req *http.Request, o *PathOperation) (*graveler.ObjectLockData, bool) {
+	lock, err := o.Catalog.GetObjectLock(req.Context(), o.Repository.Name, o.Reference, o.Path)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return nil, true
+	}
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(objectLockErrorCode(err)))
+		return nil, false
+	}
+	return lock, true
+}
+
+// handleGetObjectRetention returns the retention period of the object
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectRetention.html
+func handleGetObjectRetention(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("get_object_retention", o.Principal, o.Repository.Name, o.Reference)
+	lock, ok := getObjectLock(w, req, o)
+	if !ok {
+		return
+	}
+	if lock.GetRetainUntil() == nil {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchObjectLockConfiguration))
+		return
+	}
+	o.EncodeResponse(w, req, serde.Retention{
+		Mode:            lock.GetMode().String(),
+		RetainUntilDate: serde.Timestamp(lock.GetRetainUntil().AsTime()),
+	}, http.StatusOK)
+}
+
+// handlePutObjectRetention sets the retention period of the committed object, an empty retention removes it
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectRetention.html
+func handlePutObjectRetention(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("put_object_retention", o.Principal, o.Repository.Name, o.Reference)
+	var body serde.Retention
+	if err := serde.DecodeXML(req.Body, &body); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode retention")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	var retention *objectlock.Retention
+	if body.Mode != "" || body.RetainUntilDate != "" {
+		mode, ok := graveler.ObjectLockMode_value[body.Mode]
+		if !ok {
+			_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+			return
+		}
+		retainUntil, err := time.Parse(time.RFC3339, body.RetainUntilDate)
+		if err != nil {
+			o.Log(req).WithError(err).Debug("could not parse retain until date")
+			_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+			return
+		}
+		retention = &objectlock.Retention{Mode: graveler.ObjectLockMode(mode), RetainUntil: retainUntil}
+	}
+	err := o.Catalog.SetObjectRetention(req.Context(), o.Repository.Name, o.Reference, o.Path, retention, bypassGovernanceRetention(req))
+	if err != nil {
+		o.Log(req).WithError(err).Debug("could not set object retention")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(objectLockErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// handleGetObjectLegalHold returns the legal hold status of the object
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetObjectLegalHold.html
+func handleGetObjectLegalHold(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("get_object_legal_hold", o.Principal, o.Repository.Name, o.Reference)
+	lock, ok := getObjectLock(w, req, o)
+	if !ok {
+		return
+	}
+	status := legalHoldOff
+	if lock.GetLegalHold() {
+		status = legalHoldOn
+	}
+	o.EncodeResponse(w, req, serde.LegalHold{Status: status}, http.StatusOK)
+}
+
+// handlePutObjectLegalHold places or removes a legal hold on the committed object
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutObjectLegalHold.html
+func handlePutObjectLegalHold(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("put_object_legal_hold", o.Principal, o.Repository.Name, o.Reference)
+	var body serde.LegalHold
+	if err := serde.DecodeXML(req.Body, &body); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode legal hold")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	if body.Status != legalHoldOn && body.Status != legalHoldOff {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	err := o.Catalog.SetObjectLegalHold(req.Context(), o.Repository.Name, o.Reference, o.Path, body.Status == legalHoldOn)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("could not set object legal hold")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(objectLockErrorCode(err)))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
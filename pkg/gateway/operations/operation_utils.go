@@ -3,6 +3,7 @@ package operations
 import (
 	"net/http"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/treeverse/lakefs/pkg/catalog"
@@ -23,6 +24,33 @@ func amzMetaAsMetadata(req *http.Request) catalog.Metadata {
 	return metadata
 }
 
+// AmzMetaUserMetadata returns the amazon user metadata request headers, keyed by the lower-cased metadata name
+func AmzMetaUserMetadata(req *http.Request) map[string]string {
+	metadata := make(map[string]string)
+	for k := range req.Header {
+		if name, ok := strings.CutPrefix(k, amzMetaHeaderPrefix); ok {
+			metadata[strings.ToLower(name)] = req.Header.Get(k)
+		}
+	}
+	return metadata
+}
+
+// RequestBranchResolver returns a function that returns the branch a request on ref operates on, for policy conditions
+// on the branch: ref if it is a branch of the repository, unless the request reads an object version or copies an
+// object, whose source the branch does not describe. It returns the empty string for any other request.
+func RequestBranchResolver(req *http.Request, c *catalog.Catalog, repositoryID, ref string) func() string {
+	if req.URL.Query().Has(QueryParamVersionID) || req.Header.Get(CopySourceHeader) != "" {
+		return func() string { return "" }
+	}
+	return sync.OnceValue(func() string {
+		exists, err := c.BranchExists(req.Context(), repositoryID, ref)
+		if err != nil || !exists {
+			return ""
+		}
+		return ref
+	})
+}
+
 // amzMetaWriteHeaders set amazon user metadata on http response
 func amzMetaWriteHeaders(w http.ResponseWriter, metadata catalog.Metadata) {
 	h := w.Header()
@@ -41,7 +69,7 @@ func shouldReplaceMetadata(req *http.Request) bool {
 	return req.Header.Get(amzMetadataDirectiveHeaderPrefix) == "REPLACE"
 }
 
-func (o *PathOperation) finishUpload(req *http.Request, mTime *time.Time, checksum, physicalAddress string, size int64, relative bool, metadata map[string]string, contentType string, allowOverwrite bool) error {
+func (o *PathOperation) finishUpload(req *http.Request, mTime *time.Time, checksum, physicalAddress string, size int64, relative bool, metadata map[string]string, contentType string, opts ...graveler.SetOptionsFunc) error {
 	var writeTime time.Time
 	if mTime == nil {
 		writeTime = time.Now()
@@ -60,7 +88,7 @@ func (o *PathOperation) finishUpload(req *http.Request, mTime *time.Time, checks
 		ContentType(contentType).
 		Build()
 
-	err := o.Catalog.CreateEntry(req.Context(), o.Repository.Name, o.Reference, entry, graveler.WithIfAbsent(!allowOverwrite))
+	err := o.Catalog.CreateEntry(req.Context(), o.Repository.Name, o.Reference, entry, opts...)
 	if err != nil {
 		o.Log(req).WithError(err).Error("could not update metadata")
 		return err
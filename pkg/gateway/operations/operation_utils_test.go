@@ -0,0 +1,66 @@
+package operations
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/spf13/viper"
+	configfactory "github.com/treeverse/lakefs/modules/config/factory"
+	"github.com/treeverse/lakefs/pkg/block"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	"github.com/treeverse/lakefs/pkg/config"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+	"github.com/treeverse/lakefs/pkg/testutil"
+	"github.com/treeverse/lakefs/pkg/upload"
+)
+
+func TestRequestBranchResolver(t *testing.T) {
+	ctx := context.Background()
+	viper.Set(config.BlockstoreTypeKey, block.BlockstoreTypeMem)
+	cfg := &configfactory.ConfigImpl{}
+	_, err := config.NewConfig("", cfg)
+	testutil.MustDo(t, "config", err)
+	c, err := catalog.New(ctx, catalog.Config{
+		Config:       cfg,
+		KVStore:      kvtest.GetStore(ctx, t),
+		PathProvider: upload.DefaultPathProvider,
+	})
+	testutil.MustDo(t, "build catalog", err)
+	t.Cleanup(func() {
+		_ = c.Close()
+	})
+	_, err = c.CreateRepository(ctx, "repo1", "", "mem://repo1", "main", false)
+	testutil.MustDo(t, "create repository", err)
+	commitID, err := c.GetBranchReference(ctx, "repo1", "main")
+	testutil.MustDo(t, "get branch reference", err)
+
+	testCases := []struct {
+		name     string
+		ref      string
+		query    string
+		headers  map[string]string
+		expected string
+	}{
+		{name: "branch", ref: "main", expected: "main"},
+		{name: "missing branch", ref: "other"},
+		{name: "ancestor of branch", ref: "main~0"},
+		{name: "committed branch", ref: "main@"},
+		{name: "commit", ref: commitID},
+		{name: "object version", ref: "main", query: "?versionId=" + commitID},
+		{name: "copy", ref: "main", headers: map[string]string{CopySourceHeader: "repo1/main/source"}},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/repo1/"+tc.ref+"/path"+tc.query, nil)
+			for k, v := range tc.headers {
+				req.Header.Set(k, v)
+			}
+			branch := RequestBranchResolver(req, c, "repo1", tc.ref)()
+			if branch != tc.expected {
+				t.Errorf("RequestBranchResolver() = %q, expected %q", branch, tc.expected)
+			}
+		})
+	}
+}
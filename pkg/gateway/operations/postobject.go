@@ -10,7 +10,6 @@ import (
 	"time"
 
 	"github.com/treeverse/lakefs/pkg/block"
-	"github.com/treeverse/lakefs/pkg/catalog"
 	gatewayErrors "github.com/treeverse/lakefs/pkg/gateway/errors"
 	"github.com/treeverse/lakefs/pkg/gateway/multipart"
 	"github.com/treeverse/lakefs/pkg/gateway/path"
@@ -28,10 +27,14 @@ const (
 
 type PostObject struct{}
 
-func (controller *PostObject) RequiredPermissions(_ *http.Request, repoID, _, path string) (permissions.Node, error) {
+func (controller *PostObject) RequiredPermissions(req *http.Request, repoID, _, path string) (permissions.Node, error) {
+	action := permissions.WriteObjectAction
+	if req.URL.Query().Has(SelectQueryParam) {
+		action = permissions.ReadObjectAction
+	}
 	return permissions.Node{
 		Permission: permissions.Permission{
-			Action:   permissions.WriteObjectAction,
+			Action:   action,
 			Resource: permissions.ObjectArn(repoID, path),
 		},
 	}, nil
@@ -50,15 +53,32 @@ func (controller *PostObject) HandleCreateMultipartUpload(w http.ResponseWriter,
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNoSuchBucket))
 		return
 	}
+	metadata := amzMetaAsMetadata(req)
+	if err := amzTaggingAsMetadata(req, metadata); err != nil {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInvalidTag))
+		return
+	}
+	sse, err := parseServerSideEncryption(req.Header)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("invalid server-side encryption")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(sseErrorCode(err)))
+		return
+	}
+	sseAsMetadata(sse, metadata)
 	address := o.PathProvider.NewPath()
 	storageClass := StorageClassFromHeader(req.Header)
-	opts := block.CreateMultiPartUploadOpts{StorageClass: storageClass}
+	opts := block.CreateMultiPartUploadOpts{StorageClass: storageClass, ServerSideEncryption: sse}
 	resp, err := o.BlockStore.CreateMultiPartUpload(req.Context(), block.ObjectPointer{
 		StorageID:        o.Repository.StorageID,
 		StorageNamespace: o.Repository.StorageNamespace,
 		IdentifierType:   block.IdentifierTypeRelative,
 		Identifier:       address,
 	}, req, opts)
+	if errors.Is(err, block.ErrOperationNotSupported) {
+		o.Log(req).WithError(err).Debug("server-side encryption not supported by block adapter")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+		return
+	}
 	if err != nil {
 		o.Log(req).WithError(err).Error("could not create multipart upload")
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
@@ -69,7 +89,7 @@ func (controller *PostObject) HandleCreateMultipartUpload(w http.ResponseWriter,
 		Path:            o.Path,
 		CreationDate:    time.Now(),
 		PhysicalAddress: address,
-		Metadata:        map[string]string(amzMetaAsMetadata(req)),
+		Metadata:        map[string]string(metadata),
 		ContentType:     req.Header.Get("Content-Type"),
 	}
 	err = o.MultipartTracker.Create(req.Context(), mpu)
@@ -79,6 +99,7 @@ func (controller *PostObject) HandleCreateMultipartUpload(w http.ResponseWriter,
 		return
 	}
 	o.SetHeaders(w, resp.ServerSideHeader)
+	sseWriteHeaders(w, metadata)
 	o.EncodeResponse(w, req, &serde.InitiateMultipartUploadResult{
 		Bucket:   o.Repository.Name,
 		Key:      path.WithRef(o.Path, o.Reference),
@@ -96,22 +117,16 @@ func (controller *PostObject) HandleCompleteMultipartUpload(w http.ResponseWrite
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
 		return
 	}
-	// check and validate whether if-none-match header provided
-	allowOverwrite, err := o.checkIfAbsent(req)
-	if err != nil {
-		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+	// before writing body, ensure preconditions - graveler checks them again when writing the entry
+	setOpts, err := writePreconditions(req, o)
+	if errors.Is(err, graveler.ErrPreconditionFailed) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
 		return
 	}
-	// before writing body, ensure preconditions - this means we essentially check for object existence twice:
-	// once here, before uploading the body to save resources and time,
-	// and then graveler will check again when passed a SetOptions.
-	if !allowOverwrite {
-		_, err := o.Catalog.GetEntry(req.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
-		if err == nil {
-			// In case object exists in catalog, no error returns
-			_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
-			return
-		}
+	if err != nil {
+		o.Log(req).WithError(err).Error("could not check preconditions")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
+		return
 	}
 	objName := multiPart.PhysicalAddress
 	req = req.WithContext(logging.AddFields(req.Context(), logging.Fields{logging.PhysicalAddressFieldKey: objName}))
@@ -144,7 +159,7 @@ func (controller *PostObject) HandleCompleteMultipartUpload(w http.ResponseWrite
 		return
 	}
 	checksum := strings.Split(resp.ETag, "-")[0]
-	err = o.finishUpload(req, resp.MTime, checksum, objName, resp.ContentLength, true, multiPart.Metadata, multiPart.ContentType, allowOverwrite)
+	err = o.finishUpload(req, resp.MTime, checksum, objName, resp.ContentLength, true, multiPart.Metadata, multiPart.ContentType, setOpts...)
 	if errors.Is(err, graveler.ErrPreconditionFailed) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
 		return
@@ -153,6 +168,10 @@ func (controller *PostObject) HandleCompleteMultipartUpload(w http.ResponseWrite
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrWriteToProtectedBranch))
 		return
 	}
+	if errors.Is(err, graveler.ErrObjectLocked) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrObjectLocked))
+		return
+	}
 	if errors.Is(err, graveler.ErrReadOnlyRepository) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrReadOnlyRepository))
 		return
@@ -174,6 +193,7 @@ func (controller *PostObject) HandleCompleteMultipartUpload(w http.ResponseWrite
 		location = fmt.Sprintf("%s://%s/%s/%s/%s", scheme, req.Host, o.Repository.Name, o.Reference, o.Path)
 	}
 	o.SetHeaders(w, resp.ServerSideHeader)
+	sseWriteHeaders(w, multiPart.Metadata)
 	o.EncodeResponse(w, req, &serde.CompleteMultipartUploadResult{
 		Location: location,
 		Bucket:   o.Repository.Name,
@@ -191,17 +211,22 @@ func normalizeMultipartUploadCompletion(list *block.MultipartUploadCompletion) {
 }
 
 func (controller *PostObject) Handle(w http.ResponseWriter, req *http.Request, o *PathOperation) {
-	if o.HandleUnsupported(w, req, "select", "restore") {
+	if o.HandleUnsupported(w, req, "restore") {
+		return
+	}
+	query := req.URL.Query()
+	// SelectObjectContent only reads, it is allowed on read-only repositories
+	if query.Has(SelectQueryParam) {
+		handleSelectObjectContent(w, req, o)
 		return
 	}
 	if o.Repository.ReadOnly {
 		_ = o.EncodeError(w, req, nil, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrReadOnlyRepository))
 		return
 	}
-	// POST is only supported for CreateMultipartUpload/CompleteMultipartUpload
+	// POST is otherwise only supported for CreateMultipartUpload/CompleteMultipartUpload
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CreateMultipartUpload.html
 	// https://docs.aws.amazon.com/AmazonS3/latest/API/API_CompleteMultipartUpload.html
-	query := req.URL.Query()
 	switch {
 	case query.Has(CreateMultipartUploadQueryParam):
 		controller.HandleCreateMultipartUpload(w, req, o)
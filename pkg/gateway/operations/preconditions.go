@@ -0,0 +1,174 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/httputil"
+)
+
+const (
+	IfMatchHeader           = "If-Match"
+	IfNoneMatchHeader       = "If-None-Match"
+	IfModifiedSinceHeader   = "If-Modified-Since"
+	IfUnmodifiedSinceHeader = "If-Unmodified-Since"
+
+	CopySourceIfMatchHeader           = "x-amz-copy-source-if-match"
+	CopySourceIfNoneMatchHeader       = "x-amz-copy-source-if-none-match"
+	CopySourceIfModifiedSinceHeader   = "x-amz-copy-source-if-modified-since"
+	CopySourceIfUnmodifiedSinceHeader = "x-amz-copy-source-if-unmodified-since"
+)
+
+var errNotModified = errors.New("not modified")
+
+// preconditions are the conditional headers (RFC 7232) of a request on an object
+type preconditions struct {
+	ifMatch           string
+	ifNoneMatch       string
+	ifModifiedSince   *time.Time
+	ifUnmodifiedSince *time.Time
+}
+
+// requestPreconditions returns the preconditions a request puts on its object
+func requestPreconditions(h http.Header) preconditions {
+	return newPreconditions(h.Get(IfMatchHeader), h.Get(IfNoneMatchHeader), h.Get(IfModifiedSinceHeader), h.Get(IfUnmodifiedSinceHeader))
+}
+
+// copySourcePreconditions returns the preconditions a copy request puts on its source object
+func copySourcePreconditions(h http.Header) preconditions {
+	return newPreconditions(h.Get(CopySourceIfMatchHeader), h.Get(CopySourceIfNoneMatchHeader), h.Get(CopySourceIfModifiedSinceHeader), h.Get(CopySourceIfUnmodifiedSinceHeader))
+}
+
+func newPreconditions(ifMatch, ifNoneMatch, ifModifiedSince, ifUnmodifiedSince string) preconditions {
+	return preconditions{
+		ifMatch:           strings.TrimSpace(ifMatch),
+		ifNoneMatch:       strings.TrimSpace(ifNoneMatch),
+		ifModifiedSince:   parseHTTPDate(ifModifiedSince),
+		ifUnmodifiedSince: parseHTTPDate(ifUnmodifiedSince),
+	}
+}
+
+// parseHTTPDate returns the time of an HTTP date header value, nil if it is missing or invalid - RFC 7232 ignores
+// conditions with invalid dates
+func parseHTTPDate(s string) *time.Time {
+	if s == "" {
+		return nil
+	}
+	t, err := http.ParseTime(s)
+	if err != nil {
+		return nil
+	}
+	return &t
+}
+
+func (p preconditions) isEmpty() bool {
+	return p.ifMatch == "" && p.ifNoneMatch == "" && p.ifModifiedSince == nil && p.ifUnmodifiedSince == nil
+}
+
+// evaluate checks the preconditions against an object with checksum and lastModified, or against a missing object
+// if exists is false, in the order of RFC 7232 section 6.  read selects GET and HEAD semantics, where a failed
+// If-None-Match or If-Modified-Since returns errNotModified; writes ignore If-Modified-Since.  Any other failure
+// returns graveler.ErrPreconditionFailed.
+func (p preconditions) evaluate(exists bool, checksum string, lastModified time.Time, read bool) error {
+	switch {
+	case p.ifMatch != "":
+		if !exists || !etagListMatches(p.ifMatch, checksum) {
+			return graveler.ErrPreconditionFailed
+		}
+	case p.ifUnmodifiedSince != nil:
+		if exists && modifiedSince(lastModified, *p.ifUnmodifiedSince) {
+			return graveler.ErrPreconditionFailed
+		}
+	}
+	switch {
+	case p.ifNoneMatch != "":
+		if exists && etagListMatches(p.ifNoneMatch, checksum) {
+			if read {
+				return errNotModified
+			}
+			return graveler.ErrPreconditionFailed
+		}
+	case read && p.ifModifiedSince != nil:
+		if exists && !modifiedSince(lastModified, *p.ifModifiedSince) {
+			return errNotModified
+		}
+	}
+	return nil
+}
+
+// evaluateEntry evaluates the preconditions against entry, nil for a missing object
+func (p preconditions) evaluateEntry(entry *catalog.DBEntry, read bool) error {
+	if entry == nil {
+		return p.evaluate(false, "", time.Time{}, read)
+	}
+	return p.evaluate(true, entry.Checksum, entry.CreationDate, read)
+}
+
+// etagListMatches returns true if the comma separated entity tags of a conditional header match checksum.  "*"
+// matches any checksum, and weak tags compare as strong ones: object ETags are always strong.
+func etagListMatches(list, checksum string) bool {
+	if list == "*" {
+		return true
+	}
+	etag := strings.Trim(httputil.ETag(checksum), `"`)
+	for _, tag := range strings.Split(list, ",") {
+		tag = strings.TrimPrefix(strings.TrimSpace(tag), "W/")
+		if strings.Trim(tag, `"`) == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// modifiedSince returns true if lastModified is after t, at the one second resolution of HTTP dates
+func modifiedSince(lastModified, t time.Time) bool {
+	return lastModified.Truncate(time.Second).After(t)
+}
+
+// checkReadPreconditions evaluates the preconditions of a GET or HEAD request on entry.  It responds with 304 or
+// 412 and returns false when they fail.
+func checkReadPreconditions(w http.ResponseWriter, req *http.Request, o *PathOperation, entry *catalog.DBEntry) bool {
+	err := requestPreconditions(req.Header).evaluateEntry(entry, true)
+	switch {
+	case errors.Is(err, errNotModified):
+		o.SetHeader(w, "ETag", httputil.ETag(entry.Checksum))
+		o.SetHeader(w, "Last-Modified", httputil.HeaderTimestamp(entry.CreationDate))
+		w.WriteHeader(http.StatusNotModified)
+		return false
+	case err != nil:
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrPreconditionFailed))
+		return false
+	}
+	return true
+}
+
+// writePreconditions returns the set options that make a write to the path of o conditional on the preconditions
+// of req.  It also checks them against the current object, so that a write bound to fail is rejected before its
+// data is uploaded; graveler then checks them again atomically with the write.
+func writePreconditions(req *http.Request, o *PathOperation) ([]graveler.SetOptionsFunc, error) {
+	p := requestPreconditions(req.Header)
+	if p.isEmpty() {
+		return nil, nil
+	}
+	entry, err := o.Catalog.GetEntry(req.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
+	if errors.Is(err, graveler.ErrNotFound) {
+		entry = nil
+	} else if err != nil {
+		return nil, err
+	}
+	if err := p.evaluateEntry(entry, false); err != nil {
+		return nil, err
+	}
+	condition := catalog.WithEntryCondition(func(entry *catalog.Entry) error {
+		if entry == nil {
+			return p.evaluate(false, "", time.Time{}, false)
+		}
+		return p.evaluate(true, entry.ETag, entry.LastModified.AsTime(), false)
+	})
+	return []graveler.SetOptionsFunc{condition}, nil
+}
@@ -0,0 +1,88 @@
+package operations
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+func TestPreconditionsEvaluate(t *testing.T) {
+	const (
+		checksum      = "d41d8cd98f00b204e9800998ecf8427e"
+		otherChecksum = "098f6bcd4621d373cade4e832627b4f6"
+	)
+	lastModified := time.Date(2025, 1, 1, 12, 0, 0, 500_000_000, time.UTC)
+	before := lastModified.Add(-time.Hour).Format(http.TimeFormat)
+	after := lastModified.Add(time.Hour).Format(http.TimeFormat)
+	same := lastModified.Format(http.TimeFormat)
+
+	testCases := []struct {
+		name        string
+		headers     map[string]string
+		exists      bool
+		read        bool
+		expectedErr error
+	}{
+		{name: "none", exists: true, read: true},
+		{name: "if-match", headers: map[string]string{IfMatchHeader: `"` + checksum + `"`}, exists: true, read: true},
+		{name: "if-match list", headers: map[string]string{IfMatchHeader: `"` + otherChecksum + `", W/"` + checksum + `"`}, exists: true, read: true},
+		{name: "if-match any", headers: map[string]string{IfMatchHeader: "*"}, exists: true},
+		{name: "if-match other", headers: map[string]string{IfMatchHeader: `"` + otherChecksum + `"`}, exists: true, read: true, expectedErr: graveler.ErrPreconditionFailed},
+		{name: "if-match missing", headers: map[string]string{IfMatchHeader: "*"}, expectedErr: graveler.ErrPreconditionFailed},
+		{name: "if-unmodified-since", headers: map[string]string{IfUnmodifiedSinceHeader: after}, exists: true, read: true},
+		{name: "if-unmodified-since same second", headers: map[string]string{IfUnmodifiedSinceHeader: same}, exists: true, read: true},
+		{name: "if-unmodified-since modified", headers: map[string]string{IfUnmodifiedSinceHeader: before}, exists: true, read: true, expectedErr: graveler.ErrPreconditionFailed},
+		{name: "if-unmodified-since invalid date", headers: map[string]string{IfUnmodifiedSinceHeader: "yesterday"}, exists: true, read: true},
+		{
+			name:    "if-match overrides if-unmodified-since",
+			headers: map[string]string{IfMatchHeader: `"` + checksum + `"`, IfUnmodifiedSinceHeader: before},
+			exists:  true,
+			read:    true,
+		},
+		{
+			name:        "if-match fails regardless of if-unmodified-since",
+			headers:     map[string]string{IfMatchHeader: `"` + otherChecksum + `"`, IfUnmodifiedSinceHeader: after},
+			exists:      true,
+			read:        true,
+			expectedErr: graveler.ErrPreconditionFailed,
+		},
+		{name: "if-none-match get", headers: map[string]string{IfNoneMatchHeader: `"` + checksum + `"`}, exists: true, read: true, expectedErr: errNotModified},
+		{name: "if-none-match put", headers: map[string]string{IfNoneMatchHeader: `"` + checksum + `"`}, exists: true, expectedErr: graveler.ErrPreconditionFailed},
+		{name: "if-none-match any get", headers: map[string]string{IfNoneMatchHeader: "*"}, exists: true, read: true, expectedErr: errNotModified},
+		{name: "if-none-match any put", headers: map[string]string{IfNoneMatchHeader: "*"}, exists: true, expectedErr: graveler.ErrPreconditionFailed},
+		{name: "if-none-match any put missing", headers: map[string]string{IfNoneMatchHeader: "*"}},
+		{name: "if-none-match other get", headers: map[string]string{IfNoneMatchHeader: `"` + otherChecksum + `"`}, exists: true, read: true},
+		{name: "if-none-match other put", headers: map[string]string{IfNoneMatchHeader: `"` + otherChecksum + `"`}, exists: true},
+		{name: "if-modified-since get", headers: map[string]string{IfModifiedSinceHeader: before}, exists: true, read: true},
+		{name: "if-modified-since get not modified", headers: map[string]string{IfModifiedSinceHeader: after}, exists: true, read: true, expectedErr: errNotModified},
+		{name: "if-modified-since put ignored", headers: map[string]string{IfModifiedSinceHeader: after}, exists: true},
+		{
+			name:    "if-none-match overrides if-modified-since",
+			headers: map[string]string{IfNoneMatchHeader: `"` + otherChecksum + `"`, IfModifiedSinceHeader: after},
+			exists:  true,
+			read:    true,
+		},
+		{
+			name:        "if-match before if-none-match",
+			headers:     map[string]string{IfMatchHeader: `"` + otherChecksum + `"`, IfNoneMatchHeader: `"` + checksum + `"`},
+			exists:      true,
+			read:        true,
+			expectedErr: graveler.ErrPreconditionFailed,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			h := http.Header{}
+			for k, v := range tc.headers {
+				h.Set(k, v)
+			}
+			err := requestPreconditions(h).evaluate(tc.exists, checksum, lastModified, tc.read)
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("evaluate() error = %v, expected %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
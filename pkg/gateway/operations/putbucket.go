@@ -12,7 +12,26 @@ import (
 // create a new repo), but *does* detect whether the repo already exists.
 type PutBucket struct{}
 
-func (controller *PutBucket) RequiredPermissions(_ *http.Request, repoID string) (permissions.Node, error) {
+func (controller *PutBucket) RequiredPermissions(req *http.Request, repoID string) (permissions.Node, error) {
+	if req.URL.Query().Has(LifecycleQueryParam) {
+		return permissions.LifecycleRulesNode(repoID), nil
+	}
+	if req.URL.Query().Has(NotificationQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.WriteNotificationConfigurationAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
+	if req.URL.Query().Has(ObjectLockQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.SetObjectLockConfigurationAction,
+				Resource: permissions.RepoArn(repoID),
+			},
+		}, nil
+	}
 	return permissions.Node{
 		Permission: permissions.Permission{
 			// Mimic S3, which requires s3:CreateBucket to call
@@ -27,7 +46,19 @@ func (controller *PutBucket) RequiredPermissions(_ *http.Request, repoID string)
 func (controller *PutBucket) Handle(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
 	if o.HandleUnsupported(w, req, "cors", "metrics", "website", "logging", "accelerate",
 		"requestPayment", "acl", "publicAccessBlock", "ownershipControls", "intelligent-tiering", "analytics",
-		"lifecycle", "replication", "encryption", "policy", "object-lock", "tagging", "versioning") {
+		"replication", "encryption", "policy", "tagging", "versioning") {
+		return
+	}
+	if req.URL.Query().Has(LifecycleQueryParam) {
+		handlePutBucketLifecycle(w, req, o)
+		return
+	}
+	if req.URL.Query().Has(NotificationQueryParam) {
+		handlePutBucketNotification(w, req, o)
+		return
+	}
+	if req.URL.Query().Has(ObjectLockQueryParam) {
+		handlePutBucketObjectLock(w, req, o)
 		return
 	}
 
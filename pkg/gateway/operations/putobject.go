@@ -20,7 +20,6 @@ import (
 )
 
 const (
-	IfNoneMatchHeader     = "If-None-Match"
 	CopySourceHeader      = "x-amz-copy-source"
 	CopySourceRangeHeader = "x-amz-copy-source-range"
 	QueryParamUploadID    = "uploadId"
@@ -35,6 +34,18 @@ func IsUploadPartRequest(query url.Values) bool {
 type PutObject struct{}
 
 func (controller *PutObject) RequiredPermissions(req *http.Request, repoID, _, destPath string) (permissions.Node, error) {
+	query := req.URL.Query()
+	if query.Has(RetentionQueryParam) {
+		return objectRetentionPermissions(req, repoID, destPath), nil
+	}
+	if query.Has(LegalHoldQueryParam) {
+		return permissions.Node{
+			Permission: permissions.Permission{
+				Action:   permissions.SetObjectLegalHoldAction,
+				Resource: permissions.ObjectArn(repoID, destPath),
+			},
+		}, nil
+	}
 	copySource := req.Header.Get(CopySourceHeader)
 	if len(copySource) == 0 {
 		return permissions.Node{
@@ -78,10 +89,16 @@ func extractEntryFromCopyReq(w http.ResponseWriter, req *http.Request, o *PathOp
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInvalidCopySource))
 		return nil
 	}
+	if err := copySourcePreconditions(req.Header).evaluateEntry(ent, true); err != nil {
+		o.Log(req).WithError(err).Debug("copy source precondition failed")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
+		return nil
+	}
 	return ent
 }
 
 func getPathFromSource(copySource string) (path.ResolvedAbsolutePath, error) {
+	copySource, _ = splitCopySourceVersion(copySource)
 	copySourceDecoded, err := url.QueryUnescape(copySource)
 	if err != nil {
 		copySourceDecoded = copySource
@@ -109,11 +126,46 @@ func handleCopy(w http.ResponseWriter, req *http.Request, o *PathOperation, copy
 	}
 
 	ctx := req.Context()
+	if err := resolveCopySourceVersion(ctx, o, copySource, &srcPath); err != nil {
+		o.Log(req).WithError(err).Error("could not resolve copy source version")
+		_ = o.EncodeError(w, req, err, copySourceVersionAPIErr(err))
+		return
+	}
+
+	srcEntry := extractEntryFromCopyReq(w, req, o, srcPath)
+	if srcEntry == nil {
+		return // operation already failed
+	}
+	// the object store copies data with the encryption settings of the block adapter
+	if hasSSEMetadata(srcEntry.Metadata) || req.Header.Get(amzServerSideEncryptionHeader) != "" ||
+		req.Header.Get(amzSSECustomerAlgorithmHeader) != "" || req.Header.Get(amzCopySourceSSECustomerAlgorithmHeader) != "" {
+		o.Log(req).Debug("copying encrypted objects is not supported")
+		_ = o.EncodeError(w, req, nil, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+		return
+	}
+	setOpts, err := writePreconditions(req, o)
+	if errors.Is(err, graveler.ErrPreconditionFailed) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
+		return
+	}
+	if err != nil {
+		o.Log(req).WithError(err).Error("could not check preconditions")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
+		return
+	}
 
 	metadata := amzMetaAsMetadata(req)
 	replaceMetadata := shouldReplaceMetadata(req)
 
-	entry, err := o.Catalog.CopyEntry(ctx, srcPath.Repo, srcPath.Reference, srcPath.Path, repository, branch, o.Path, replaceMetadata, metadata)
+	entry, err := o.Catalog.CopyEntry(ctx, srcPath.Repo, srcPath.Reference, srcPath.Path, repository, branch, o.Path, replaceMetadata, metadata, setOpts...)
+	if errors.Is(err, graveler.ErrPreconditionFailed) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
+		return
+	}
+	if errors.Is(err, graveler.ErrObjectLocked) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrObjectLocked))
+		return
+	}
 	if err != nil {
 		o.Log(req).WithError(err).Error("could create a copy")
 		apiErr := gatewayErrors.Codes.ToAPIErrWithInternalError(gatewayErrors.ErrInvalidCopyDest, err)
@@ -165,6 +217,11 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 			_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInvalidCopySource))
 			return
 		}
+		if err := resolveCopySourceVersion(req.Context(), o, copySource, &resolvedCopySource); err != nil {
+			o.Log(req).WithField("copy_source", copySource).WithError(err).Error("could not resolve copy source version")
+			_ = o.EncodeError(w, req, err, copySourceVersionAPIErr(err))
+			return
+		}
 		ent := extractEntryFromCopyReq(w, req, o, resolvedCopySource)
 		if ent == nil {
 			return // operation already failed
@@ -188,11 +245,23 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 			return
 		}
 
+		srcCustomerKey, err := objectCustomerKey(req.Header, copySourceSSECustomerKeyHeaders, ent.Metadata)
+		if err != nil {
+			o.Log(req).WithField("copy_source", copySource).WithError(err).Debug("invalid copy source customer key")
+			_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(sseErrorCode(err)))
+			return
+		}
+		customerKey, ok := partCustomerKey(w, req, o, multiPart.Metadata)
+		if !ok {
+			return
+		}
+
 		src := block.ObjectPointer{
 			StorageID:        srcRepo.StorageID,
 			StorageNamespace: srcRepo.StorageNamespace,
 			IdentifierType:   ent.AddressType.ToIdentifierType(),
 			Identifier:       ent.PhysicalAddress,
+			CustomerKey:      srcCustomerKey,
 		}
 
 		dst := block.ObjectPointer{
@@ -200,6 +269,7 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 			StorageNamespace: o.Repository.StorageNamespace,
 			IdentifierType:   block.IdentifierTypeRelative,
 			Identifier:       multiPart.PhysicalAddress,
+			CustomerKey:      customerKey,
 		}
 
 		var resp *block.UploadPartResponse
@@ -229,6 +299,7 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 			return
 		}
 
+		sseWriteHeaders(w, multiPart.Metadata)
 		o.EncodeResponse(w, req, &serde.CopyObjectResult{
 			LastModified: serde.Timestamp(time.Now()),
 			ETag:         httputil.ETag(resp.ETag),
@@ -236,12 +307,17 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 		return
 	}
 
+	customerKey, ok := partCustomerKey(w, req, o, multiPart.Metadata)
+	if !ok {
+		return
+	}
 	byteSize := req.ContentLength
 	resp, err := o.BlockStore.UploadPart(req.Context(), block.ObjectPointer{
 		StorageID:        o.Repository.StorageID,
 		StorageNamespace: o.Repository.StorageNamespace,
 		IdentifierType:   block.IdentifierTypeRelative,
 		Identifier:       multiPart.PhysicalAddress,
+		CustomerKey:      customerKey,
 	},
 		byteSize, req.Body, uploadID, partNumber)
 	if err != nil {
@@ -251,10 +327,27 @@ func handleUploadPart(w http.ResponseWriter, req *http.Request, o *PathOperation
 		return
 	}
 	o.SetHeaders(w, resp.ServerSideHeader)
+	sseWriteHeaders(w, multiPart.Metadata)
 	o.SetHeader(w, "ETag", httputil.ETag(resp.ETag))
 	w.WriteHeader(http.StatusOK)
 }
 
+// partCustomerKey returns the customer key to upload a part of a multipart upload, nil if the upload is not encrypted
+// with a customer key.  It encodes an error and returns false if the request does not pass the key of the upload.
+func partCustomerKey(w http.ResponseWriter, req *http.Request, o *PathOperation, uploadMetadata map[string]string) ([]byte, bool) {
+	key, err := objectCustomerKey(req.Header, sseCustomerKeyHeaders, uploadMetadata)
+	if err == nil {
+		return key, true
+	}
+	o.Log(req).WithError(err).Debug("invalid part customer key")
+	code := sseErrorCode(err)
+	if errors.Is(err, errSSEEncryptedObject) {
+		code = gatewayErrors.ErrSSEMultipartEncrypted
+	}
+	_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(code))
+	return nil, false
+}
+
 func (controller *PutObject) Handle(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 	if o.HandleUnsupported(w, req, "torrent", "acl") {
 		return
@@ -285,6 +378,12 @@ func (controller *PutObject) Handle(w http.ResponseWriter, req *http.Request, o
 		return
 	}
 
+	if hasObjectLockHeaders(req) {
+		o.Log(req).Debug("locking objects as they are written is not supported")
+		_ = o.EncodeError(w, req, nil, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+		return
+	}
+
 	// check if this is a copy operation (i.e. https://docs.aws.amazon.com/AmazonS3/latest/API/API_CopyObject.html)
 	// A copy operation is identified by the existence of an "x-amz-copy-source" header
 	copySource := req.Header.Get(CopySourceHeader)
@@ -299,8 +398,17 @@ func (controller *PutObject) Handle(w http.ResponseWriter, req *http.Request, o
 	}
 
 	if query.Has("tagging") {
-		o.Log(req).Debug("put-object-tagging isn't supported yet")
-		o.EncodeError(w, req, nil, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ERRLakeFSNotSupported))
+		handlePutObjectTagging(w, req, o)
+		return
+	}
+
+	if query.Has(RetentionQueryParam) {
+		handlePutObjectRetention(w, req, o)
+		return
+	}
+
+	if query.Has(LegalHoldQueryParam) {
+		handlePutObjectLegalHold(w, req, o)
 		return
 	}
 
@@ -311,23 +419,29 @@ func (controller *PutObject) Handle(w http.ResponseWriter, req *http.Request, o
 func handlePut(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 	o.Incr("put_object", o.Principal, o.Repository.Name, o.Reference)
 	storageClass := StorageClassFromHeader(req.Header)
-	opts := block.PutOpts{StorageClass: storageClass}
-	// check and validate whether if-none-match header provided
-	allowOverwrite, err := o.checkIfAbsent(req)
+	sse, err := parseServerSideEncryption(req.Header)
 	if err != nil {
-		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+		o.Log(req).WithError(err).Debug("invalid server-side encryption")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(sseErrorCode(err)))
 		return
 	}
-	// before writing body, ensure preconditions - this means we essentially check for object existence twice:
-	// once here, before uploading the body to save resources and time,
-	// and then graveler will check again when passed a SetOptions.
-	if !allowOverwrite {
-		_, err := o.Catalog.GetEntry(req.Context(), o.Repository.Name, o.Reference, o.Path, catalog.GetEntryParams{})
-		if err == nil {
-			// In case object exists in catalog, no error returns
-			_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
-			return
-		}
+	opts := block.PutOpts{StorageClass: storageClass, ServerSideEncryption: sse}
+	metadata := amzMetaAsMetadata(req)
+	if err := amzTaggingAsMetadata(req, metadata); err != nil {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInvalidTag))
+		return
+	}
+	sseAsMetadata(sse, metadata)
+	// before writing body, ensure preconditions - graveler checks them again when writing the entry
+	setOpts, err := writePreconditions(req, o)
+	if errors.Is(err, graveler.ErrPreconditionFailed) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
+		return
+	}
+	if err != nil {
+		o.Log(req).WithError(err).Error("could not check preconditions")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
+		return
 	}
 	objectPointer := block.ObjectPointer{
 		StorageID:        o.Repository.StorageID,
@@ -336,6 +450,11 @@ func handlePut(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 		Identifier:       o.PathProvider.NewPath(),
 	}
 	blob, err := upload.WriteBlob(req.Context(), o.BlockStore, objectPointer, req.Body, req.ContentLength, opts)
+	if errors.Is(err, block.ErrOperationNotSupported) {
+		o.Log(req).WithError(err).Debug("server-side encryption not supported by block adapter")
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrNotImplemented))
+		return
+	}
 	if err != nil {
 		o.Log(req).WithError(err).Error("could not write request body to block adapter")
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
@@ -343,9 +462,8 @@ func handlePut(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 	}
 
 	// write metadata
-	metadata := amzMetaAsMetadata(req)
 	contentType := req.Header.Get("Content-Type")
-	err = o.finishUpload(req, &blob.CreationDate, blob.Checksum, blob.PhysicalAddress, blob.Size, true, metadata, contentType, allowOverwrite)
+	err = o.finishUpload(req, &blob.CreationDate, blob.Checksum, blob.PhysicalAddress, blob.Size, true, metadata, contentType, setOpts...)
 	if errors.Is(err, graveler.ErrPreconditionFailed) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrPreconditionFailed))
 		return
@@ -354,6 +472,10 @@ func handlePut(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrWriteToProtectedBranch))
 		return
 	}
+	if errors.Is(err, graveler.ErrObjectLocked) {
+		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrObjectLocked))
+		return
+	}
 	if errors.Is(err, graveler.ErrReadOnlyRepository) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrReadOnlyRepository))
 		return
@@ -362,17 +484,7 @@ func handlePut(w http.ResponseWriter, req *http.Request, o *PathOperation) {
 		_ = o.EncodeError(w, req, err, gatewayErrors.Codes.ToAPIErr(gatewayErrors.ErrInternalError))
 		return
 	}
+	sseWriteHeaders(w, metadata)
 	o.SetHeader(w, "ETag", httputil.ETag(blob.Checksum))
 	w.WriteHeader(http.StatusOK)
 }
-
-func (o *PathOperation) checkIfAbsent(req *http.Request) (bool, error) {
-	headerValue := req.Header.Get(IfNoneMatchHeader)
-	if headerValue == "" {
-		return true, nil
-	}
-	if headerValue == "*" {
-		return false, nil
-	}
-	return false, gatewayErrors.ErrNotImplemented
-}
@@ -0,0 +1,118 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/treeverse/lakefs/pkg/block"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/s3select"
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+const (
+	SelectQueryParam     = "select"
+	selectTypeQueryParam = "select-type"
+	// selectTypeSQL is the only select-type S3 defines
+	selectTypeSQL = "2"
+)
+
+// selectObject is the object of a SelectObjectContent request, read from the block adapter
+type selectObject struct {
+	blockStore block.Adapter
+	pointer    block.ObjectPointer
+	size       int64
+}
+
+func (s *selectObject) Size() int64 {
+	return s.size
+}
+
+func (s *selectObject) Reader(ctx context.Context) (io.ReadCloser, error) {
+	return s.blockStore.Get(ctx, s.pointer)
+}
+
+func (s *selectObject) RangeReader(ctx context.Context, start, end int64) (io.ReadCloser, error) {
+	return s.blockStore.GetRange(ctx, s.pointer, start, end)
+}
+
+// selectErrorCode returns the error code of a select request that failed validation
+func selectErrorCode(err error) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, s3select.ErrInvalidExpressionType):
+		return gatewayerrors.ErrInvalidExpressionType
+	case errors.Is(err, s3select.ErrParse):
+		return gatewayerrors.ErrSelectParse
+	case errors.Is(err, s3select.ErrExpressionTooLong):
+		return gatewayerrors.ErrExpressionTooLong
+	case errors.Is(err, s3select.ErrUnsupported):
+		return gatewayerrors.ErrNotImplemented
+	default:
+		return gatewayerrors.ErrInvalidRequestParameter
+	}
+}
+
+// handleSelectObjectContent filters the content of a CSV, JSON or Parquet object with an SQL expression
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_SelectObjectContent.html
+func handleSelectObjectContent(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("select_object", o.Principal, o.Repository.Name, o.Reference)
+	if req.URL.Query().Get(selectTypeQueryParam) != selectTypeSQL {
+		_ = o.EncodeError(w, req, nil, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidRequestParameter))
+		return
+	}
+	var selectRequest s3select.Request
+	if err := DecodeXMLBody(req.Body, &selectRequest); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode select request")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(decodeXMLErrorCode(err)))
+		return
+	}
+	selector, err := s3select.NewSelector(&selectRequest)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("invalid select request")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(selectErrorCode(err)))
+		return
+	}
+
+	entry, _, err := getEntryVersion(req, o)
+	switch {
+	case errors.Is(err, errNoSuchVersion), errors.Is(err, catalog.ErrExpired):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	case errors.Is(err, graveler.ErrNotFound):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return
+	case errors.Is(err, graveler.ErrCommitDatesOutOfOrder):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	case err != nil:
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	customerKey, err := objectCustomerKey(req.Header, sseCustomerKeyHeaders, entry.Metadata)
+	if err != nil {
+		o.Log(req).WithError(err).Debug("invalid customer key")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(sseErrorCode(err)))
+		return
+	}
+
+	obj := &selectObject{
+		blockStore: o.BlockStore,
+		pointer: block.ObjectPointer{
+			StorageID:        o.Repository.StorageID,
+			StorageNamespace: o.Repository.StorageNamespace,
+			IdentifierType:   entry.AddressType.ToIdentifierType(),
+			Identifier:       entry.PhysicalAddress,
+			CustomerKey:      customerKey,
+		},
+		size: entry.Size,
+	}
+	// errors from here on are reported in the event stream
+	o.SetHeader(w, "Content-Type", "application/octet-stream")
+	w.WriteHeader(http.StatusOK)
+	if err := selector.Run(req.Context(), w, obj); err != nil {
+		o.Log(req).WithError(err).Debug("select object content failed")
+	}
+}
@@ -0,0 +1,218 @@
+package operations
+
+import (
+	"crypto/md5" //nolint:gosec
+	"encoding/base64"
+	"errors"
+	"net/http"
+
+	"github.com/treeverse/lakefs/pkg/block"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+)
+
+const (
+	amzServerSideEncryptionHeader = "X-Amz-Server-Side-Encryption"
+	amzSSEKMSKeyIDHeader          = "X-Amz-Server-Side-Encryption-Aws-Kms-Key-Id"
+	amzSSECustomerAlgorithmHeader = "X-Amz-Server-Side-Encryption-Customer-Algorithm"
+	amzSSECustomerKeyHeader       = "X-Amz-Server-Side-Encryption-Customer-Key"
+	amzSSECustomerKeyMD5Header    = catalog.SSECustomerKeyMD5MetadataKey
+
+	amzCopySourceSSECustomerAlgorithmHeader = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Algorithm"
+	amzCopySourceSSECustomerKeyHeader       = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key"
+	amzCopySourceSSECustomerKeyMD5Header    = "X-Amz-Copy-Source-Server-Side-Encryption-Customer-Key-Md5"
+
+	// sseCustomerAlgorithm is the only algorithm supported for customer provided keys
+	sseCustomerAlgorithm = "AES256"
+)
+
+// amzSSEMetadataKeys are the entry metadata keys holding the encryption of the object, named after the response
+// headers that return them.  Customer keys are never stored, only their MD5 to check the keys used to read.
+var amzSSEMetadataKeys = []string{
+	amzServerSideEncryptionHeader,
+	amzSSEKMSKeyIDHeader,
+	amzSSECustomerAlgorithmHeader,
+	amzSSECustomerKeyMD5Header,
+}
+
+var (
+	errInvalidEncryptionMethod      = errors.New("invalid encryption method")
+	errIncompatibleEncryptionMethod = errors.New("both customer key and server-side encryption specified")
+	errInvalidSSECustomerAlgorithm  = errors.New("invalid customer key algorithm")
+	errInvalidSSECustomerKey        = errors.New("invalid customer key")
+	errMissingSSECustomerKey        = errors.New("missing customer key")
+	errMissingSSECustomerKeyMD5     = errors.New("missing customer key MD5")
+	errSSECustomerKeyMD5Mismatch    = errors.New("customer key MD5 mismatch")
+	errSSEEncryptedObject           = errors.New("object encrypted with a customer key")
+	errInvalidSSECustomerParameters = errors.New("customer key does not match the object")
+	errInvalidEncryptionParameters  = errors.New("object not encrypted with a customer key")
+)
+
+// customerKeyHeaders names the request headers that pass a customer key
+type customerKeyHeaders struct {
+	algorithm string
+	key       string
+	keyMD5    string
+}
+
+var (
+	sseCustomerKeyHeaders = customerKeyHeaders{
+		algorithm: amzSSECustomerAlgorithmHeader,
+		key:       amzSSECustomerKeyHeader,
+		keyMD5:    amzSSECustomerKeyMD5Header,
+	}
+	copySourceSSECustomerKeyHeaders = customerKeyHeaders{
+		algorithm: amzCopySourceSSECustomerAlgorithmHeader,
+		key:       amzCopySourceSSECustomerKeyHeader,
+		keyMD5:    amzCopySourceSSECustomerKeyMD5Header,
+	}
+)
+
+func customerKeyMD5(key []byte) string {
+	sum := md5.Sum(key) //nolint:gosec
+	return base64.StdEncoding.EncodeToString(sum[:])
+}
+
+// parseCustomerKey returns the customer key passed on the headers names, nil if none was passed
+func parseCustomerKey(header http.Header, names customerKeyHeaders) ([]byte, error) {
+	algorithm := header.Get(names.algorithm)
+	encodedKey := header.Get(names.key)
+	keyMD5 := header.Get(names.keyMD5)
+	if algorithm == "" && encodedKey == "" && keyMD5 == "" {
+		return nil, nil
+	}
+	if algorithm != sseCustomerAlgorithm {
+		return nil, errInvalidSSECustomerAlgorithm
+	}
+	if encodedKey == "" {
+		return nil, errMissingSSECustomerKey
+	}
+	key, err := base64.StdEncoding.DecodeString(encodedKey)
+	if err != nil || len(key) != block.CustomerKeySize {
+		return nil, errInvalidSSECustomerKey
+	}
+	if keyMD5 == "" {
+		return nil, errMissingSSECustomerKeyMD5
+	}
+	if keyMD5 != customerKeyMD5(key) {
+		return nil, errSSECustomerKeyMD5Mismatch
+	}
+	return key, nil
+}
+
+// parseServerSideEncryption returns the encryption requested by the headers of a write, nil if none was requested
+func parseServerSideEncryption(header http.Header) (*block.ServerSideEncryption, error) {
+	key, err := parseCustomerKey(header, sseCustomerKeyHeaders)
+	if err != nil {
+		return nil, err
+	}
+	mode := block.ServerSideEncryptionMode(header.Get(amzServerSideEncryptionHeader))
+	kmsKeyID := header.Get(amzSSEKMSKeyIDHeader)
+	if key != nil {
+		if mode != "" || kmsKeyID != "" {
+			return nil, errIncompatibleEncryptionMethod
+		}
+		return &block.ServerSideEncryption{Mode: block.ServerSideEncryptionCustomerKey, CustomerKey: key}, nil
+	}
+	switch mode {
+	case block.ServerSideEncryptionKMS:
+		return &block.ServerSideEncryption{Mode: mode, KMSKeyID: kmsKeyID}, nil
+	case block.ServerSideEncryptionAES256:
+		if kmsKeyID != "" {
+			return nil, errInvalidEncryptionMethod
+		}
+		return &block.ServerSideEncryption{Mode: mode}, nil
+	case "":
+		if kmsKeyID != "" {
+			return nil, errInvalidEncryptionMethod
+		}
+		return nil, nil
+	default:
+		return nil, errInvalidEncryptionMethod
+	}
+}
+
+// sseAsMetadata records the encryption of an object in its metadata
+func sseAsMetadata(sse *block.ServerSideEncryption, metadata catalog.Metadata) {
+	if sse == nil {
+		return
+	}
+	switch sse.Mode {
+	case block.ServerSideEncryptionCustomerKey:
+		metadata[amzSSECustomerAlgorithmHeader] = sseCustomerAlgorithm
+		metadata[amzSSECustomerKeyMD5Header] = customerKeyMD5(sse.CustomerKey)
+	default:
+		metadata[amzServerSideEncryptionHeader] = string(sse.Mode)
+		if sse.KMSKeyID != "" {
+			metadata[amzSSEKMSKeyIDHeader] = sse.KMSKeyID
+		}
+	}
+}
+
+// hasSSEMetadata returns true if metadata records that the object is encrypted
+func hasSSEMetadata(metadata map[string]string) bool {
+	for _, k := range amzSSEMetadataKeys {
+		if _, ok := metadata[k]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// sseWriteHeaders sets the encryption of an object recorded in its metadata on http response
+func sseWriteHeaders(w http.ResponseWriter, metadata map[string]string) {
+	h := w.Header()
+	for _, k := range amzSSEMetadataKeys {
+		if v, ok := metadata[k]; ok {
+			h.Set(k, v)
+		}
+	}
+}
+
+// objectCustomerKey returns the customer key passed on the headers names to read an object with metadata, nil if it
+// is not encrypted with a customer key.  The key must be the one the object was written with.
+func objectCustomerKey(header http.Header, names customerKeyHeaders, metadata map[string]string) ([]byte, error) {
+	key, err := parseCustomerKey(header, names)
+	if err != nil {
+		return nil, err
+	}
+	keyMD5, encrypted := metadata[amzSSECustomerKeyMD5Header]
+	switch {
+	case encrypted && key == nil:
+		return nil, errSSEEncryptedObject
+	case encrypted && customerKeyMD5(key) != keyMD5:
+		return nil, errInvalidSSECustomerParameters
+	case !encrypted && key != nil:
+		return nil, errInvalidEncryptionParameters
+	}
+	return key, nil
+}
+
+func sseErrorCode(err error) gatewayerrors.APIErrorCode {
+	switch {
+	case errors.Is(err, errInvalidEncryptionMethod):
+		return gatewayerrors.ErrInvalidEncryptionMethod
+	case errors.Is(err, errIncompatibleEncryptionMethod):
+		return gatewayerrors.ErrIncompatibleEncryptionMethod
+	case errors.Is(err, errInvalidSSECustomerAlgorithm):
+		return gatewayerrors.ErrInvalidSSECustomerAlgorithm
+	case errors.Is(err, errInvalidSSECustomerKey):
+		return gatewayerrors.ErrInvalidSSECustomerKey
+	case errors.Is(err, errMissingSSECustomerKey):
+		return gatewayerrors.ErrMissingSSECustomerKey
+	case errors.Is(err, errMissingSSECustomerKeyMD5):
+		return gatewayerrors.ErrMissingSSECustomerKeyMD5
+	case errors.Is(err, errSSECustomerKeyMD5Mismatch):
+		return gatewayerrors.ErrSSECustomerKeyMD5Mismatch
+	case errors.Is(err, errSSEEncryptedObject):
+		return gatewayerrors.ErrSSEEncryptedObject
+	case errors.Is(err, errInvalidSSECustomerParameters):
+		return gatewayerrors.ErrInvalidSSECustomerParameters
+	case errors.Is(err, errInvalidEncryptionParameters):
+		return gatewayerrors.ErrInvalidEncryptionParameters
+	case errors.Is(err, block.ErrOperationNotSupported):
+		return gatewayerrors.ErrNotImplemented
+	default:
+		return gatewayerrors.ErrInternalError
+	}
+}
@@ -0,0 +1,176 @@
+package operations
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+const (
+	amzTaggingHeader      = "X-Amz-Tagging"
+	amzTaggingCountHeader = "X-Amz-Tagging-Count"
+	// amzTaggingMetadataKey is the entry metadata key holding the object tags, URL query encoded
+	amzTaggingMetadataKey = "X-Amz-Tagging"
+
+	maxObjectTags     = 10
+	maxTagKeyLength   = 128
+	maxTagValueLength = 256
+)
+
+var errInvalidTagging = errors.New("invalid tagging")
+
+// parseTagging parses URL query encoded tags, the format of the x-amz-tagging header
+func parseTagging(s string) ([]serde.Tag, error) {
+	var tags []serde.Tag
+	for _, pair := range strings.Split(s, "&") {
+		if pair == "" {
+			continue
+		}
+		k, v, _ := strings.Cut(pair, "=")
+		key, err := url.QueryUnescape(k)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tag key: %w", errInvalidTagging, err)
+		}
+		value, err := url.QueryUnescape(v)
+		if err != nil {
+			return nil, fmt.Errorf("%w: tag value: %w", errInvalidTagging, err)
+		}
+		tags = append(tags, serde.Tag{Key: key, Value: value})
+	}
+	return tags, validateTags(tags)
+}
+
+// encodeTagging returns tags URL query encoded, sorted by key
+func encodeTagging(tags []serde.Tag) string {
+	sorted := slices.Clone(tags)
+	slices.SortFunc(sorted, func(a, b serde.Tag) int { return strings.Compare(a.Key, b.Key) })
+	var sb strings.Builder
+	for i, tag := range sorted {
+		if i > 0 {
+			sb.WriteByte('&')
+		}
+		sb.WriteString(url.QueryEscape(tag.Key))
+		sb.WriteByte('=')
+		sb.WriteString(url.QueryEscape(tag.Value))
+	}
+	return sb.String()
+}
+
+// validateTags checks tags against the limits S3 puts on object tags
+func validateTags(tags []serde.Tag) error {
+	if len(tags) > maxObjectTags {
+		return fmt.Errorf("%w: more than %d tags", errInvalidTagging, maxObjectTags)
+	}
+	keys := make(map[string]struct{}, len(tags))
+	for _, tag := range tags {
+		if tag.Key == "" || utf8.RuneCountInString(tag.Key) > maxTagKeyLength {
+			return fmt.Errorf("%w: tag key length must be between 1 and %d", errInvalidTagging, maxTagKeyLength)
+		}
+		if utf8.RuneCountInString(tag.Value) > maxTagValueLength {
+			return fmt.Errorf("%w: tag value length must be at most %d", errInvalidTagging, maxTagValueLength)
+		}
+		if _, ok := keys[tag.Key]; ok {
+			return fmt.Errorf("%w: duplicate tag key '%s'", errInvalidTagging, tag.Key)
+		}
+		keys[tag.Key] = struct{}{}
+	}
+	return nil
+}
+
+// entryTags returns the tags stored in the metadata of an entry
+func entryTags(metadata catalog.Metadata) ([]serde.Tag, error) {
+	return parseTagging(metadata[amzTaggingMetadataKey])
+}
+
+// amzTaggingAsMetadata adds the tags of the x-amz-tagging request header, if any, to metadata
+func amzTaggingAsMetadata(req *http.Request, metadata catalog.Metadata) error {
+	tags, err := parseTagging(req.Header.Get(amzTaggingHeader))
+	if err != nil {
+		return err
+	}
+	if len(tags) > 0 {
+		metadata[amzTaggingMetadataKey] = encodeTagging(tags)
+	}
+	return nil
+}
+
+// amzTaggingWriteHeaders sets the number of object tags on http response, if it has any
+func amzTaggingWriteHeaders(w http.ResponseWriter, metadata catalog.Metadata) {
+	tags, err := entryTags(metadata)
+	if err == nil && len(tags) > 0 {
+		w.Header().Set(amzTaggingCountHeader, strconv.Itoa(len(tags)))
+	}
+}
+
+func handleGetObjectTagging(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("get_object_tagging", o.Principal, o.Repository.Name, o.Reference)
+	entry, _, err := getEntryVersion(req, o)
+	if errors.Is(err, errNoSuchVersion) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion))
+		return
+	}
+	if errors.Is(err, graveler.ErrNotFound) {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+		return
+	}
+	if err != nil {
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	tags, err := entryTags(entry.Metadata)
+	if err != nil {
+		o.Log(req).WithError(err).Error("could not parse object tags")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.EncodeResponse(w, req, serde.Tagging{TagSet: serde.TagSet{Tag: tags}}, http.StatusOK)
+}
+
+func handlePutObjectTagging(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("put_object_tagging", o.Principal, o.Repository.Name, o.Reference)
+	var tagging serde.Tagging
+	if err := serde.DecodeXML(req.Body, &tagging); err != nil {
+		o.Log(req).WithError(err).Debug("could not decode tagging")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrMalformedXML))
+		return
+	}
+	if err := validateTags(tagging.TagSet.Tag); err != nil {
+		o.Log(req).WithError(err).Debug("invalid tags")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidTag))
+		return
+	}
+	setObjectTagging(w, req, o, encodeTagging(tagging.TagSet.Tag), http.StatusOK)
+}
+
+func handleDeleteObjectTagging(w http.ResponseWriter, req *http.Request, o *PathOperation) {
+	o.Incr("delete_object_tagging", o.Principal, o.Repository.Name, o.Reference)
+	setObjectTagging(w, req, o, "", http.StatusNoContent)
+}
+
+// setObjectTagging replaces the tags of the object with the encoded tagging, removing them if empty
+func setObjectTagging(w http.ResponseWriter, req *http.Request, o *PathOperation, tagging string, statusCode int) {
+	err := o.Catalog.SetEntryMetadataValue(req.Context(), o.Repository.Name, o.Reference, o.Path, amzTaggingMetadataKey, tagging)
+	switch {
+	case errors.Is(err, graveler.ErrNotFound):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchKey))
+	case errors.Is(err, graveler.ErrWriteToProtectedBranch):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrWriteToProtectedBranch))
+	case errors.Is(err, graveler.ErrReadOnlyRepository):
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrReadOnlyRepository))
+	case err != nil:
+		o.Log(req).WithError(err).Error("could not set object tags")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+	default:
+		w.WriteHeader(statusCode)
+	}
+}
@@ -0,0 +1,347 @@
+package operations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"maps"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/catalog"
+	gatewayerrors "github.com/treeverse/lakefs/pkg/gateway/errors"
+	"github.com/treeverse/lakefs/pkg/gateway/path"
+	"github.com/treeverse/lakefs/pkg/gateway/serde"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/httputil"
+	"github.com/treeverse/lakefs/pkg/logging"
+)
+
+const (
+	QueryParamVersionID       = "versionId"
+	QueryParamVersionIDMarker = "version-id-marker"
+	amzVersionIDHeader        = "X-Amz-Version-Id"
+
+	// StagingVersionID is the version ID of the uncommitted changes to an object on a branch. The version ID of
+	// any other version of an object is the ID of the commit that changed it.
+	StagingVersionID = "staging"
+)
+
+var errNoSuchVersion = errors.New("no such version")
+
+// objectVersion is a version of an object: the object as changed by a commit, or by the uncommitted changes to a
+// branch. entry is nil if the change deleted the object.
+type objectVersion struct {
+	id           string
+	entry        *catalog.DBEntry
+	lastModified time.Time
+}
+
+// versionReference returns the reference to read the requested version of the object from, and its version ID.
+// Without a version ID in the request, that is the reference of the request.
+func versionReference(req *http.Request, o *PathOperation) (string, string, error) {
+	versionID := req.URL.Query().Get(QueryParamVersionID)
+	if versionID == "" || versionID == StagingVersionID {
+		return o.Reference, versionID, nil
+	}
+	reference, err := commitVersionReference(req.Context(), o.Catalog, o.Repository.Name, versionID)
+	return reference, versionID, err
+}
+
+// getEntryVersion returns the requested version of the object, and its version ID. It fails with errNoSuchVersion if
+// a version ID was requested and the object does not exist in that version.
+func getEntryVersion(req *http.Request, o *PathOperation) (*catalog.DBEntry, string, error) {
+	reference, versionID, err := versionReference(req, o)
+	if err != nil {
+		return nil, versionID, err
+	}
+	entry, err := o.Catalog.GetEntry(req.Context(), o.Repository.Name, reference, o.Path, catalog.GetEntryParams{})
+	if versionID != "" && errors.Is(err, graveler.ErrNotFound) {
+		return nil, versionID, fmt.Errorf("%w: %s: %w", errNoSuchVersion, versionID, err)
+	}
+	return entry, versionID, err
+}
+
+// commitVersionReference returns the commit of a version ID. Only commit IDs are version IDs: branches, tags and
+// other ref expressions are not.
+func commitVersionReference(ctx context.Context, c *catalog.Catalog, repository, versionID string) (string, error) {
+	commit, err := c.GetCommit(ctx, repository, versionID)
+	if errors.Is(err, graveler.ErrNotFound) || errors.Is(err, graveler.ErrInvalidRef) || (err == nil && commit.Reference != versionID) {
+		return "", fmt.Errorf("%w: %s", errNoSuchVersion, versionID)
+	}
+	if err != nil {
+		return "", err
+	}
+	return commit.Reference, nil
+}
+
+// splitCopySourceVersion splits the version ID off an x-amz-copy-source header value, "source?versionId=id"
+func splitCopySourceVersion(copySource string) (string, string) {
+	source, query, found := strings.Cut(copySource, "?")
+	if !found {
+		return copySource, ""
+	}
+	values, err := url.ParseQuery(query)
+	if err != nil || !values.Has(QueryParamVersionID) {
+		return copySource, ""
+	}
+	return source, values.Get(QueryParamVersionID)
+}
+
+// resolveCopySourceVersion sets the reference of the copy source to the commit of the version it requests, if any
+func resolveCopySourceVersion(ctx context.Context, o *PathOperation, copySource string, source *path.ResolvedAbsolutePath) error {
+	_, versionID := splitCopySourceVersion(copySource)
+	if versionID == "" || versionID == StagingVersionID {
+		return nil
+	}
+	reference, err := commitVersionReference(ctx, o.Catalog, source.Repo, versionID)
+	if err != nil {
+		return err
+	}
+	source.Reference = reference
+	return nil
+}
+
+func copySourceVersionAPIErr(err error) gatewayerrors.APIError {
+	if errors.Is(err, errNoSuchVersion) {
+		return gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrNoSuchVersion)
+	}
+	return gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError)
+}
+
+// sameVersion returns true if the entries are the same version of an object
+func sameVersion(a, b *catalog.DBEntry) bool {
+	return a.PhysicalAddress == b.PhysicalAddress &&
+		a.Checksum == b.Checksum &&
+		a.ContentType == b.ContentType &&
+		maps.Equal(a.Metadata, b.Metadata)
+}
+
+// objectVersions returns up to limit versions of entry on ref, latest first, after afterVersionID if set.
+// headCommit is the commit of ref, and ref is a branch if the two differ. It also returns whether there are more
+// versions.
+func objectVersions(ctx context.Context, o *RepoOperation, ref, headCommit string, entry *catalog.DBEntry, afterVersionID string, limit int) ([]objectVersion, bool, error) {
+	var versions []objectVersion
+	if afterVersionID == "" && ref != headCommit {
+		committed, err := o.Catalog.GetEntry(ctx, o.Repository.Name, headCommit, entry.Path, catalog.GetEntryParams{})
+		if err != nil && !errors.Is(err, graveler.ErrNotFound) {
+			return nil, false, err
+		}
+		if committed == nil || !sameVersion(committed, entry) {
+			versions = append(versions, objectVersion{id: StagingVersionID, entry: entry, lastModified: entry.CreationDate})
+		}
+	}
+
+	params := catalog.LogParams{
+		PathList: []catalog.PathRecord{{Path: catalog.Path(entry.Path)}},
+		Amount:   limit - len(versions),
+	}
+	if afterVersionID != "" && afterVersionID != StagingVersionID {
+		params.FromReference = afterVersionID
+	}
+	commits, hasMore, err := o.Catalog.ListCommits(ctx, o.Repository.Name, headCommit, params)
+	if err != nil {
+		return nil, false, err
+	}
+	for _, commit := range commits {
+		version := objectVersion{id: commit.Reference, lastModified: commit.CreationDate}
+		version.entry, err = o.Catalog.GetEntry(ctx, o.Repository.Name, commit.Reference, entry.Path, catalog.GetEntryParams{})
+		if err != nil && !errors.Is(err, graveler.ErrNotFound) {
+			return nil, false, err
+		}
+		if version.entry != nil {
+			version.lastModified = version.entry.CreationDate
+		}
+		versions = append(versions, version)
+	}
+	return versions, hasMore, nil
+}
+
+// ListVersions lists the versions of the objects on a ref. The uncommitted changes to an object on a branch are its
+// latest version, followed by a version for each commit that changed it. Objects deleted from the ref are not listed.
+func (controller *ListObjects) ListVersions(w http.ResponseWriter, req *http.Request, o *RepoOperation) {
+	o.Incr("list_object_versions", o.Principal, o.Repository.Name, "")
+	ctx := req.Context()
+	params := req.URL.Query()
+	keyMarker := params.Get(QueryParamKeyMarker)
+	maxKeys := ListObjectMaxKeys
+	if maxKeysParam := params.Get("max-keys"); maxKeysParam != "" {
+		parsedKeys, err := strconv.Atoi(maxKeysParam)
+		if err != nil || parsedKeys < 0 {
+			_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInvalidMaxKeys))
+			return
+		}
+		maxKeys = min(parsedKeys, ListObjectMaxKeys)
+	}
+	resp := &serde.ListVersionsResult{
+		Name:            o.Repository.Name,
+		Prefix:          params.Get("prefix"),
+		KeyMarker:       keyMarker,
+		VersionIDMarker: params.Get(QueryParamVersionIDMarker),
+		MaxKeys:         maxKeys,
+		Delimiter:       params.Get(QueryParamDelimiter),
+		Versions:        make([]serde.ObjectVersion, 0),
+		DeleteMarkers:   make([]serde.DeleteMarkerEntry, 0),
+		CommonPrefixes:  make([]serde.CommonPrefixes, 0),
+	}
+
+	prefix, err := path.ResolvePath(resp.Prefix)
+	if err != nil {
+		o.Log(req).WithError(err).WithField("path", resp.Prefix).Error("could not resolve path for prefix")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+		return
+	}
+	if !prefix.WithPath {
+		// list branches, the same as listing objects
+		branches, hasMore, err := o.Catalog.ListBranches(ctx, o.Repository.Name, prefix.Ref, maxKeys, keyMarker)
+		if err != nil {
+			o.Log(req).WithError(err).Error("could not list branches")
+			_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+		var lastKey string
+		resp.CommonPrefixes, lastKey = controller.serializeBranches(branches)
+		if hasMore {
+			resp.IsTruncated = true
+			resp.NextKeyMarker = lastKey
+		}
+		o.EncodeResponse(w, req, resp, http.StatusOK)
+		return
+	}
+
+	var marker path.ResolvedPath
+	if keyMarker != "" {
+		marker, err = path.ResolvePath(keyMarker)
+		if err != nil || marker.Ref != prefix.Ref {
+			o.Log(req).WithError(err).WithFields(logging.Fields{
+				"ref":        prefix.Ref,
+				"key_marker": keyMarker,
+			}).Error("invalid key marker - doesnt start with ref")
+			_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrBadRequest))
+			return
+		}
+	}
+
+	err = listVersions(ctx, o, prefix, marker.Path, resp)
+	if err != nil {
+		o.Log(req).WithError(err).WithFields(logging.Fields{
+			"ref":  prefix.Ref,
+			"path": prefix.Path,
+		}).Error("could not list object versions")
+		_ = o.EncodeError(w, req, err, gatewayerrors.Codes.ToAPIErr(gatewayerrors.ErrInternalError))
+		return
+	}
+	o.EncodeResponse(w, req, resp, http.StatusOK)
+}
+
+// listVersions adds to resp the versions of the objects under prefix, after the object at markerPath. If resp has a
+// version ID marker, it starts with the versions of the object at markerPath after that version.
+func listVersions(ctx context.Context, o *RepoOperation, prefix path.ResolvedPath, markerPath string, resp *serde.ListVersionsResult) error {
+	ref := prefix.Ref
+	headCommit, err := o.Catalog.GetBranchReference(ctx, o.Repository.Name, ref)
+	if errors.Is(err, graveler.ErrNotFound) {
+		// not a branch - list the versions up to a commit
+		var commit *catalog.CommitLog
+		commit, err = o.Catalog.GetCommit(ctx, o.Repository.Name, ref)
+		if err == nil {
+			headCommit = commit.Reference
+		}
+	}
+	if errors.Is(err, graveler.ErrNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	count := 0
+	// addVersions adds versions of the object at p, and returns false if they are truncated
+	addVersions := func(p string, versions []objectVersion, hasMore, latest bool) bool {
+		key := path.WithRef(p, ref)
+		for i, version := range versions {
+			if version.entry == nil {
+				resp.DeleteMarkers = append(resp.DeleteMarkers, serde.DeleteMarkerEntry{
+					Key:          key,
+					VersionID:    version.id,
+					IsLatest:     latest && i == 0,
+					LastModified: serde.Timestamp(version.lastModified),
+				})
+				continue
+			}
+			resp.Versions = append(resp.Versions, serde.ObjectVersion{
+				Key:          key,
+				VersionID:    version.id,
+				IsLatest:     latest && i == 0,
+				LastModified: serde.Timestamp(version.lastModified),
+				ETag:         httputil.ETag(version.entry.Checksum),
+				Size:         version.entry.Size,
+				StorageClass: "STANDARD",
+			})
+		}
+		count += len(versions)
+		if hasMore && len(versions) > 0 {
+			resp.IsTruncated = true
+			resp.NextKeyMarker = key
+			resp.NextVersionIDMarker = versions[len(versions)-1].id
+			return false
+		}
+		return true
+	}
+
+	after := markerPath
+	if resp.VersionIDMarker != "" && markerPath != "" && resp.MaxKeys > 0 {
+		entry, err := o.Catalog.GetEntry(ctx, o.Repository.Name, ref, markerPath, catalog.GetEntryParams{})
+		if err != nil && !errors.Is(err, graveler.ErrNotFound) {
+			return err
+		}
+		if entry != nil {
+			versions, hasMore, err := objectVersions(ctx, o, ref, headCommit, entry, resp.VersionIDMarker, resp.MaxKeys)
+			if err != nil {
+				return err
+			}
+			if !addVersions(entry.Path, versions, hasMore, false) {
+				return nil
+			}
+		}
+	}
+	for {
+		if count >= resp.MaxKeys {
+			resp.IsTruncated = true
+			resp.NextKeyMarker = path.WithRef(after, ref)
+			return nil
+		}
+		entries, hasMore, err := o.Catalog.ListEntries(ctx, o.Repository.Name, ref, prefix.Path, after, resp.Delimiter, resp.MaxKeys-count)
+		if errors.Is(err, graveler.ErrNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		for _, entry := range entries {
+			if count >= resp.MaxKeys {
+				resp.IsTruncated = true
+				resp.NextKeyMarker = path.WithRef(after, ref)
+				return nil
+			}
+			after = entry.Path
+			if entry.CommonLevel {
+				resp.CommonPrefixes = append(resp.CommonPrefixes, serde.CommonPrefixes{Prefix: path.WithRef(entry.Path, ref)})
+				count++
+				continue
+			}
+			versions, more, err := objectVersions(ctx, o, ref, headCommit, entry, "", resp.MaxKeys-count)
+			if err != nil {
+				return err
+			}
+			if !addVersions(entry.Path, versions, more, true) {
+				return nil
+			}
+		}
+		if !hasMore {
+			return nil
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package s3select
+
+import (
+	"errors"
+)
+
+var (
+	ErrInvalidRequest        = errors.New("invalid select request")
+	ErrInvalidExpressionType = errors.New("invalid expression type")
+	ErrParse                 = errors.New("parse SQL expression")
+	ErrExpressionTooLong     = errors.New("SQL expression too long")
+	ErrUnsupported           = errors.New("not supported")
+	ErrEvaluation            = errors.New("evaluate SQL expression")
+	ErrInvalidInput          = errors.New("invalid input")
+)
+
+const internalErrorCode = "InternalError"
+
+// errorCode returns the S3 error code reported in the error message of an event stream for err
+func errorCode(err error) string {
+	switch {
+	case errors.Is(err, ErrEvaluation):
+		return "EvaluatorInvalidArguments"
+	case errors.Is(err, ErrInvalidInput):
+		return "InvalidInput"
+	case errors.Is(err, ErrUnsupported):
+		return "NotImplemented"
+	default:
+		return internalErrorCode
+	}
+}
+
+// errorMessage returns the message reported in the error message of an event stream for err.  Internal errors
+// report a generic message, as their text may describe the server and its storage.
+func errorMessage(err error) string {
+	if errorCode(err) == internalErrorCode {
+		return "We encountered an internal error. Please try again."
+	}
+	return err.Error()
+}
@@ -0,0 +1,696 @@
+package s3select
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+type scalarFunction func(args []any) (any, error)
+
+var scalarFunctions = map[string]scalarFunction{
+	"LOWER":            stringFunction(strings.ToLower),
+	"UPPER":            stringFunction(strings.ToUpper),
+	"TRIM":             stringFunction(func(s string) string { return strings.Trim(s, " ") }),
+	"CHAR_LENGTH":      charLength,
+	"CHARACTER_LENGTH": charLength,
+	"SUBSTRING":        substring,
+	"COALESCE":         coalesce,
+	"NULLIF":           nullIf,
+}
+
+// evaluator evaluates the expressions of a query over records
+type evaluator struct {
+	query *query
+	// accumulators hold the state of the aggregate functions of the query
+	accumulators map[*aggregate]*accumulator
+	// aggregated is set once the accumulators are final, and evaluating an aggregate returns its result
+	aggregated bool
+}
+
+func newEvaluator(q *query) *evaluator {
+	ev := &evaluator{
+		query:        q,
+		accumulators: make(map[*aggregate]*accumulator, len(q.aggregates)),
+	}
+	for _, agg := range q.aggregates {
+		ev.accumulators[agg] = &accumulator{}
+	}
+	return ev
+}
+
+// match returns true if rec passes the WHERE clause of the query
+func (ev *evaluator) match(rec *Record) (bool, error) {
+	if ev.query.where == nil {
+		return true, nil
+	}
+	v, err := ev.eval(ev.query.where, rec)
+	if err != nil {
+		return false, err
+	}
+	b, ok := v.(bool)
+	return ok && b, nil
+}
+
+// project returns the names and values the query selects from rec
+func (ev *evaluator) project(rec *Record) ([]string, []any, error) {
+	if ev.query.projections == nil {
+		return rec.Names, rec.Values, nil
+	}
+	names := make([]string, len(ev.query.projections))
+	values := make([]any, len(ev.query.projections))
+	for i, proj := range ev.query.projections {
+		v, err := ev.eval(proj.x, rec)
+		if err != nil {
+			return nil, nil, err
+		}
+		names[i] = projectionName(proj, i)
+		values[i] = v
+	}
+	return names, values, nil
+}
+
+// accumulate adds rec to the aggregate functions of the query
+func (ev *evaluator) accumulate(rec *Record) error {
+	for _, agg := range ev.query.aggregates {
+		var v any = true // COUNT(*) counts every record
+		if agg.arg != nil {
+			var err error
+			if v, err = ev.eval(agg.arg, rec); err != nil {
+				return err
+			}
+		}
+		if err := ev.accumulators[agg].add(agg.name, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// aggregateResult returns the names and values the aggregate query selects
+func (ev *evaluator) aggregateResult() ([]string, []any, error) {
+	ev.aggregated = true
+	return ev.project(&Record{})
+}
+
+func projectionName(proj projection, i int) string {
+	if proj.name != "" {
+		return proj.name
+	}
+	if ref, ok := proj.x.(*columnRef); ok {
+		return ref.path[len(ref.path)-1].name
+	}
+	return positionalName(i)
+}
+
+func (ev *evaluator) eval(x expr, rec *Record) (any, error) {
+	switch x := x.(type) {
+	case *literal:
+		return x.value, nil
+	case *columnRef:
+		v, _ := lookup(rec, x.path)
+		return v, nil
+	case *aggregate:
+		if !ev.aggregated {
+			return nil, fmt.Errorf("%w: aggregate function %s", ErrEvaluation, x.name)
+		}
+		return ev.accumulators[x].result(x.name), nil
+	case *unaryExpr:
+		return ev.evalUnary(x, rec)
+	case *binaryExpr:
+		return ev.evalBinary(x, rec)
+	case *likeExpr:
+		return ev.evalLike(x, rec)
+	case *betweenExpr:
+		return ev.evalBetween(x, rec)
+	case *inExpr:
+		return ev.evalIn(x, rec)
+	case *isExpr:
+		return ev.evalIs(x, rec)
+	case *castExpr:
+		v, err := ev.eval(x.x, rec)
+		if err != nil {
+			return nil, err
+		}
+		return cast(v, x.typ)
+	case *funcCall:
+		args, err := ev.evalList(x.args, rec)
+		if err != nil {
+			return nil, err
+		}
+		return scalarFunctions[x.name](args)
+	default:
+		return nil, fmt.Errorf("%w: unknown expression %T", ErrEvaluation, x)
+	}
+}
+
+func (ev *evaluator) evalList(xs []expr, rec *Record) ([]any, error) {
+	values := make([]any, len(xs))
+	for i, x := range xs {
+		v, err := ev.eval(x, rec)
+		if err != nil {
+			return nil, err
+		}
+		values[i] = v
+	}
+	return values, nil
+}
+
+// lookup returns the value at path in rec, and whether it exists
+func lookup(rec *Record, path []pathElem) (any, bool) {
+	var v any = rec
+	for _, elem := range path {
+		r, ok := v.(*Record)
+		if !ok {
+			return nil, false
+		}
+		if v, ok = r.Get(elem.name, elem.quoted); !ok {
+			return nil, false
+		}
+	}
+	return v, true
+}
+
+func (ev *evaluator) evalUnary(x *unaryExpr, rec *Record) (any, error) {
+	v, err := ev.eval(x.x, rec)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	if x.op == "NOT" {
+		b, ok := v.(bool)
+		if !ok {
+			return nil, fmt.Errorf("%w: NOT of a non boolean value", ErrEvaluation)
+		}
+		return !b, nil
+	}
+	switch n := toNumber(v).(type) {
+	case int64:
+		return -n, nil
+	case float64:
+		return -n, nil
+	default:
+		return nil, fmt.Errorf("%w: negation of a non numeric value", ErrEvaluation)
+	}
+}
+
+func (ev *evaluator) evalBinary(x *binaryExpr, rec *Record) (any, error) {
+	if x.op == "AND" || x.op == "OR" {
+		return ev.evalLogical(x, rec)
+	}
+	a, err := ev.eval(x.x, rec)
+	if err != nil {
+		return nil, err
+	}
+	b, err := ev.eval(x.y, rec)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil || b == nil {
+		return nil, nil
+	}
+	switch x.op {
+	case "||":
+		return toString(a) + toString(b), nil
+	case "+", "-", "*", "/", "%":
+		return arithmetic(x.op, a, b)
+	}
+	c, ok := compare(a, b)
+	if !ok {
+		return nil, nil
+	}
+	switch x.op {
+	case "=":
+		return c == 0, nil
+	case "<>":
+		return c != 0, nil
+	case "<":
+		return c < 0, nil
+	case "<=":
+		return c <= 0, nil
+	case ">":
+		return c > 0, nil
+	case ">=":
+		return c >= 0, nil
+	}
+	return nil, fmt.Errorf("%w: unknown operator %s", ErrEvaluation, x.op)
+}
+
+// evalLogical evaluates AND and OR in three-valued logic, where nil is unknown
+func (ev *evaluator) evalLogical(x *binaryExpr, rec *Record) (any, error) {
+	a, err := ev.eval(x.x, rec)
+	if err != nil {
+		return nil, err
+	}
+	// short circuit
+	if ab, ok := a.(bool); ok && ab == (x.op == "OR") {
+		return ab, nil
+	}
+	b, err := ev.eval(x.y, rec)
+	if err != nil {
+		return nil, err
+	}
+	ab, aok := a.(bool)
+	bb, bok := b.(bool)
+	if a != nil && !aok || b != nil && !bok {
+		return nil, fmt.Errorf("%w: %s of a non boolean value", ErrEvaluation, x.op)
+	}
+	if bok && bb == (x.op == "OR") {
+		return bb, nil
+	}
+	if !aok || !bok {
+		return nil, nil
+	}
+	if x.op == "OR" {
+		return ab || bb, nil
+	}
+	return ab && bb, nil
+}
+
+func (ev *evaluator) evalLike(x *likeExpr, rec *Record) (any, error) {
+	values, err := ev.evalList([]expr{x.x, x.pattern}, rec)
+	if err != nil {
+		return nil, err
+	}
+	if values[0] == nil || values[1] == nil {
+		return nil, nil
+	}
+	var escape rune
+	if x.escape != nil {
+		e, err := ev.eval(x.escape, rec)
+		if err != nil {
+			return nil, err
+		}
+		s, ok := e.(string)
+		if !ok || utf8.RuneCountInString(s) != 1 {
+			return nil, fmt.Errorf("%w: LIKE ESCAPE must be a single character", ErrEvaluation)
+		}
+		escape, _ = utf8.DecodeRuneInString(s)
+	}
+	matched := like([]rune(toString(values[0])), []rune(toString(values[1])), escape)
+	return matched != x.not, nil
+}
+
+// likeToken is a LIKE pattern element: a literal character, or likeAny (_) or likeAnySequence (%)
+type likeToken struct {
+	wildcard rune
+	c        rune
+}
+
+const (
+	likeAny         = '_'
+	likeAnySequence = '%'
+)
+
+// likeTokens splits pattern into tokens, resolving escape (if not 0) so that the escaped character is a literal
+func likeTokens(pattern []rune, escape rune) []likeToken {
+	tokens := make([]likeToken, 0, len(pattern))
+	for i := 0; i < len(pattern); i++ {
+		switch c := pattern[i]; {
+		case escape != 0 && c == escape && i+1 < len(pattern):
+			i++
+			tokens = append(tokens, likeToken{c: pattern[i]})
+		case c == likeAny || c == likeAnySequence:
+			tokens = append(tokens, likeToken{wildcard: c})
+		default:
+			tokens = append(tokens, likeToken{c: c})
+		}
+	}
+	return tokens
+}
+
+// like matches s against a LIKE pattern, where % matches any sequence, _ any character and escape (if not 0)
+// escapes the following character.  It backtracks only to the last %, so it runs in O(len(s) * len(pattern)).
+func like(s, pattern []rune, escape rune) bool {
+	tokens := likeTokens(pattern, escape)
+	var (
+		si, ti int
+		// position of the token after the last % and of s it matched up to, -1 before any %
+		starTi, starSi = -1, 0
+	)
+	for si < len(s) {
+		switch {
+		case ti < len(tokens) && tokens[ti].wildcard == likeAnySequence:
+			starTi, starSi = ti+1, si
+			ti++
+		case ti < len(tokens) && (tokens[ti].wildcard == likeAny || (tokens[ti].wildcard == 0 && tokens[ti].c == s[si])):
+			si++
+			ti++
+		case starTi >= 0:
+			// extend the sequence the last % matches by one character
+			starSi++
+			si, ti = starSi, starTi
+		default:
+			return false
+		}
+	}
+	for ti < len(tokens) && tokens[ti].wildcard == likeAnySequence {
+		ti++
+	}
+	return ti == len(tokens)
+}
+
+func (ev *evaluator) evalBetween(x *betweenExpr, rec *Record) (any, error) {
+	values, err := ev.evalList([]expr{x.x, x.low, x.high}, rec)
+	if err != nil {
+		return nil, err
+	}
+	low, lok := compare(values[0], values[1])
+	high, hok := compare(values[0], values[2])
+	if !lok || !hok {
+		return nil, nil
+	}
+	return (low >= 0 && high <= 0) != x.not, nil
+}
+
+func (ev *evaluator) evalIn(x *inExpr, rec *Record) (any, error) {
+	v, err := ev.eval(x.x, rec)
+	if err != nil || v == nil {
+		return nil, err
+	}
+	list, err := ev.evalList(x.list, rec)
+	if err != nil {
+		return nil, err
+	}
+	for _, item := range list {
+		if c, ok := compare(v, item); ok && c == 0 {
+			return !x.not, nil
+		}
+	}
+	return x.not, nil
+}
+
+func (ev *evaluator) evalIs(x *isExpr, rec *Record) (any, error) {
+	var result bool
+	if ref, ok := x.x.(*columnRef); ok {
+		v, found := lookup(rec, ref.path)
+		result = !found || !x.missing && v == nil
+	} else {
+		v, err := ev.eval(x.x, rec)
+		if err != nil {
+			return nil, err
+		}
+		result = !x.missing && v == nil
+	}
+	return result != x.not, nil
+}
+
+// toNumber returns v as an int64 or float64, parsing strings.  It returns nil if v is not a number.
+func toNumber(v any) any {
+	switch v := v.(type) {
+	case int64, float64:
+		return v
+	case string:
+		s := strings.TrimSpace(v)
+		if n, err := strconv.ParseInt(s, 10, 64); err == nil {
+			return n
+		}
+		if f, err := strconv.ParseFloat(s, 64); err == nil {
+			return f
+		}
+	}
+	return nil
+}
+
+func toFloat(n any) float64 {
+	if i, ok := n.(int64); ok {
+		return float64(i)
+	}
+	return n.(float64)
+}
+
+func toString(v any) string {
+	switch v := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'f', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	default:
+		return string(appendJSON(nil, v))
+	}
+}
+
+// compare returns the order of a and b.  Strings compare with numbers as the numbers they hold, as CSV values are
+// always strings.  It returns false if the values are not comparable.
+func compare(a, b any) (int, bool) {
+	if a == nil || b == nil {
+		return 0, false
+	}
+	_, aString := a.(string)
+	_, bString := b.(string)
+	if aString && bString {
+		return strings.Compare(a.(string), b.(string)), true
+	}
+	if ab, ok := a.(bool); ok {
+		bb, ok := b.(bool)
+		if !ok {
+			return 0, false
+		}
+		switch {
+		case ab == bb:
+			return 0, true
+		case bb:
+			return -1, true
+		default:
+			return 1, true
+		}
+	}
+	an, bn := toNumber(a), toNumber(b)
+	if an == nil || bn == nil {
+		return 0, false
+	}
+	ai, aInt := an.(int64)
+	bi, bInt := bn.(int64)
+	if aInt && bInt {
+		return cmpOrdered(ai, bi), true
+	}
+	return cmpOrdered(toFloat(an), toFloat(bn)), true
+}
+
+func cmpOrdered[T int64 | float64](a, b T) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func arithmetic(op string, a, b any) (any, error) {
+	an, bn := toNumber(a), toNumber(b)
+	if an == nil || bn == nil {
+		return nil, fmt.Errorf("%w: arithmetic on a non numeric value", ErrEvaluation)
+	}
+	ai, aInt := an.(int64)
+	bi, bInt := bn.(int64)
+	if aInt && bInt {
+		switch op {
+		case "+":
+			return ai + bi, nil
+		case "-":
+			return ai - bi, nil
+		case "*":
+			return ai * bi, nil
+		case "/", "%":
+			if bi == 0 {
+				return nil, fmt.Errorf("%w: division by zero", ErrEvaluation)
+			}
+			if op == "%" {
+				return ai % bi, nil
+			}
+			if ai%bi == 0 {
+				return ai / bi, nil
+			}
+		}
+	}
+	af, bf := toFloat(an), toFloat(bn)
+	switch op {
+	case "+":
+		return af + bf, nil
+	case "-":
+		return af - bf, nil
+	case "*":
+		return af * bf, nil
+	case "/":
+		if bf == 0 {
+			return nil, fmt.Errorf("%w: division by zero", ErrEvaluation)
+		}
+		return af / bf, nil
+	default:
+		if bf == 0 {
+			return nil, fmt.Errorf("%w: division by zero", ErrEvaluation)
+		}
+		return math.Mod(af, bf), nil
+	}
+}
+
+func cast(v any, typ string) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch typ {
+	case "STRING":
+		return toString(v), nil
+	case "BOOL":
+		switch v := v.(type) {
+		case bool:
+			return v, nil
+		case string:
+			if b, err := strconv.ParseBool(strings.TrimSpace(v)); err == nil {
+				return b, nil
+			}
+		}
+	case "INT":
+		switch n := toNumber(v).(type) {
+		case int64:
+			return n, nil
+		case float64:
+			return int64(n), nil
+		}
+		if b, ok := v.(bool); ok {
+			if b {
+				return int64(1), nil
+			}
+			return int64(0), nil
+		}
+	case "FLOAT":
+		if n := toNumber(v); n != nil {
+			return toFloat(n), nil
+		}
+	}
+	return nil, fmt.Errorf("%w: cannot cast '%s' as %s", ErrEvaluation, toString(v), typ)
+}
+
+func stringFunction(f func(string) string) scalarFunction {
+	return func(args []any) (any, error) {
+		if len(args) != 1 {
+			return nil, fmt.Errorf("%w: expected a single argument", ErrEvaluation)
+		}
+		if args[0] == nil {
+			return nil, nil
+		}
+		return f(toString(args[0])), nil
+	}
+}
+
+func charLength(args []any) (any, error) {
+	if len(args) != 1 {
+		return nil, fmt.Errorf("%w: CHAR_LENGTH expects a single argument", ErrEvaluation)
+	}
+	if args[0] == nil {
+		return nil, nil
+	}
+	return int64(utf8.RuneCountInString(toString(args[0]))), nil
+}
+
+// substring returns the substring of args[0] from the 1-based position args[1], of length args[2] if given
+func substring(args []any) (any, error) {
+	if args[0] == nil || args[1] == nil || len(args) > 2 && args[2] == nil {
+		return nil, nil
+	}
+	s := []rune(toString(args[0]))
+	start, ok := toNumber(args[1]).(int64)
+	if !ok {
+		return nil, fmt.Errorf("%w: SUBSTRING start must be an integer", ErrEvaluation)
+	}
+	end := int64(len(s)) + 1
+	if len(args) > 2 {
+		length, ok := toNumber(args[2]).(int64)
+		if !ok || length < 0 {
+			return nil, fmt.Errorf("%w: SUBSTRING length must be a non-negative integer", ErrEvaluation)
+		}
+		end = min(start+length, end)
+	}
+	start = max(start, 1)
+	if start >= end {
+		return "", nil
+	}
+	return string(s[start-1 : end-1]), nil
+}
+
+func coalesce(args []any) (any, error) {
+	for _, arg := range args {
+		if arg != nil {
+			return arg, nil
+		}
+	}
+	return nil, nil
+}
+
+func nullIf(args []any) (any, error) {
+	if len(args) != 2 {
+		return nil, fmt.Errorf("%w: NULLIF expects two arguments", ErrEvaluation)
+	}
+	if c, ok := compare(args[0], args[1]); ok && c == 0 {
+		return nil, nil
+	}
+	return args[0], nil
+}
+
+// accumulator holds the state of an aggregate function
+type accumulator struct {
+	count int64
+	sum   any
+	value any
+}
+
+func (a *accumulator) add(name string, v any) error {
+	if v == nil {
+		return nil
+	}
+	a.count++
+	switch name {
+	case "SUM", "AVG":
+		n := toNumber(v)
+		if n == nil {
+			return fmt.Errorf("%w: %s of a non numeric value", ErrEvaluation, name)
+		}
+		if a.sum == nil {
+			a.sum = n
+			return nil
+		}
+		sum, err := arithmetic("+", a.sum, n)
+		if err != nil {
+			return err
+		}
+		a.sum = sum
+	case "MIN", "MAX":
+		if a.value == nil {
+			a.value = v
+			return nil
+		}
+		c, ok := compare(v, a.value)
+		if !ok {
+			return fmt.Errorf("%w: %s of values that cannot be compared", ErrEvaluation, name)
+		}
+		if name == "MIN" && c < 0 || name == "MAX" && c > 0 {
+			a.value = v
+		}
+	}
+	return nil
+}
+
+func (a *accumulator) result(name string) any {
+	switch name {
+	case "COUNT":
+		return a.count
+	case "SUM":
+		return a.sum
+	case "AVG":
+		if a.count == 0 {
+			return nil
+		}
+		return toFloat(a.sum) / float64(a.count)
+	default:
+		return a.value
+	}
+}
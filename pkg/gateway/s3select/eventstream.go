@@ -0,0 +1,96 @@
+package s3select
+
+import (
+	"encoding/xml"
+	"io"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+)
+
+const (
+	eventRecords  = "Records"
+	eventProgress = "Progress"
+	eventStats    = "Stats"
+	eventEnd      = "End"
+
+	contentTypeOctetStream = "application/octet-stream"
+	contentTypeXML         = "text/xml"
+)
+
+// statsDetails are the byte counts reported by Progress and Stats events
+type statsDetails struct {
+	BytesScanned   int64 `xml:"BytesScanned"`
+	BytesProcessed int64 `xml:"BytesProcessed"`
+	BytesReturned  int64 `xml:"BytesReturned"`
+}
+
+type statsPayload struct {
+	XMLName xml.Name `xml:"Stats"`
+	statsDetails
+}
+
+type progressPayload struct {
+	XMLName xml.Name `xml:"Progress"`
+	statsDetails
+}
+
+// eventWriter writes the messages of the event stream of a SelectObjectContent response
+type eventWriter struct {
+	w       io.Writer
+	encoder *eventstream.Encoder
+}
+
+func newEventWriter(w io.Writer) *eventWriter {
+	return &eventWriter{w: w, encoder: eventstream.NewEncoder()}
+}
+
+func (e *eventWriter) event(eventType, contentType string, payload []byte) error {
+	var headers eventstream.Headers
+	headers.Set(":message-type", eventstream.StringValue("event"))
+	headers.Set(":event-type", eventstream.StringValue(eventType))
+	if contentType != "" {
+		headers.Set(":content-type", eventstream.StringValue(contentType))
+	}
+	return e.encoder.Encode(e.w, eventstream.Message{Headers: headers, Payload: payload})
+}
+
+func (e *eventWriter) records(payload []byte) error {
+	return e.event(eventRecords, contentTypeOctetStream, payload)
+}
+
+func (e *eventWriter) progress(p *progress) error {
+	payload, err := xml.Marshal(progressPayload{statsDetails: p.details()})
+	if err != nil {
+		return err
+	}
+	return e.event(eventProgress, contentTypeXML, payload)
+}
+
+func (e *eventWriter) stats(p *progress) error {
+	payload, err := xml.Marshal(statsPayload{statsDetails: p.details()})
+	if err != nil {
+		return err
+	}
+	return e.event(eventStats, contentTypeXML, payload)
+}
+
+func (e *eventWriter) end() error {
+	return e.event(eventEnd, "", nil)
+}
+
+// error writes an error message, which ends the event stream
+func (e *eventWriter) error(code, message string) error {
+	var headers eventstream.Headers
+	headers.Set(":message-type", eventstream.StringValue("error"))
+	headers.Set(":error-code", eventstream.StringValue(code))
+	headers.Set(":error-message", eventstream.StringValue(message))
+	return e.encoder.Encode(e.w, eventstream.Message{Headers: headers})
+}
+
+func (p *progress) details() statsDetails {
+	return statsDetails{
+		BytesScanned:   p.scanned,
+		BytesProcessed: p.processed,
+		BytesReturned:  p.returned,
+	}
+}
@@ -0,0 +1,259 @@
+package s3select
+
+import (
+	"compress/bzip2"
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"unicode/utf8"
+)
+
+// recordReader reads the records of an object
+type recordReader interface {
+	// Read returns the next record, or io.EOF after the last one
+	Read() (*Record, error)
+	Close() error
+}
+
+// progress counts the bytes scanned, processed and returned by a select
+type progress struct {
+	scanned   int64
+	processed int64
+	returned  int64
+}
+
+type countingReader struct {
+	io.Reader
+	count *int64
+}
+
+func (c *countingReader) Read(p []byte) (int, error) {
+	n, err := c.Reader.Read(p)
+	*c.count += int64(n)
+	return n, err
+}
+
+func openRecordReader(ctx context.Context, in *InputSerialization, obj Object, p *progress) (recordReader, error) {
+	if in.Parquet != nil {
+		return newParquetReader(ctx, obj, p)
+	}
+	body, err := obj.Reader(ctx)
+	if err != nil {
+		return nil, err
+	}
+	var r io.Reader = &countingReader{Reader: body, count: &p.scanned}
+	switch in.CompressionType {
+	case CompressionGZIP:
+		gz, err := gzip.NewReader(r)
+		if err != nil {
+			_ = body.Close()
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+		}
+		r = gz
+	case CompressionBZIP2:
+		r = bzip2.NewReader(r)
+	}
+	r = &countingReader{Reader: r, count: &p.processed}
+	if in.CSV != nil {
+		return newCSVReader(r, body, in.CSV), nil
+	}
+	return newJSONReader(r, body, in.JSON), nil
+}
+
+type csvReader struct {
+	reader *csv.Reader
+	closer io.Closer
+	header []string
+	err    error
+}
+
+func newCSVReader(r io.Reader, closer io.Closer, in *CSVInput) *csvReader {
+	reader := csv.NewReader(r)
+	reader.Comma, _ = utf8.DecodeRuneInString(in.FieldDelimiter)
+	if in.Comments != "" {
+		reader.Comment, _ = utf8.DecodeRuneInString(in.Comments)
+	}
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	c := &csvReader{reader: reader, closer: closer}
+	if in.FileHeaderInfo != FileHeaderInfoNone {
+		header, err := c.read()
+		switch {
+		case errors.Is(err, io.EOF):
+		case err != nil:
+			c.err = err
+		case in.FileHeaderInfo == FileHeaderInfoUse:
+			c.header = header
+		}
+	}
+	return c
+}
+
+func (c *csvReader) read() ([]string, error) {
+	fields, err := c.reader.Read()
+	var parseErr *csv.ParseError
+	if errors.As(err, &parseErr) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	return fields, err
+}
+
+func (c *csvReader) Read() (*Record, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	fields, err := c.read()
+	if err != nil {
+		return nil, err
+	}
+	rec := &Record{
+		Names:  make([]string, len(fields)),
+		Values: make([]any, len(fields)),
+	}
+	for i, field := range fields {
+		if i < len(c.header) {
+			rec.Names[i] = c.header[i]
+		} else {
+			rec.Names[i] = positionalName(i)
+		}
+		rec.Values[i] = field
+	}
+	return rec, nil
+}
+
+func (c *csvReader) Close() error {
+	return c.closer.Close()
+}
+
+type jsonReader struct {
+	decoder  *json.Decoder
+	closer   io.Closer
+	document bool
+	// inArray is set while reading the elements of a top-level array of a document
+	inArray bool
+}
+
+func newJSONReader(r io.Reader, closer io.Closer, in *JSONInput) *jsonReader {
+	decoder := json.NewDecoder(r)
+	decoder.UseNumber()
+	return &jsonReader{
+		decoder:  decoder,
+		closer:   closer,
+		document: in.Type == JSONTypeDocument,
+	}
+}
+
+func (j *jsonReader) Read() (*Record, error) {
+	for {
+		tok, err := j.token()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case j.document && !j.inArray && tok == json.Delim('['):
+			j.inArray = true
+			continue
+		case j.inArray && tok == json.Delim(']'):
+			j.inArray = false
+			continue
+		}
+		v, err := j.value(tok)
+		if err != nil {
+			return nil, err
+		}
+		if rec, ok := v.(*Record); ok {
+			return rec, nil
+		}
+		return &Record{Names: []string{positionalName(0)}, Values: []any{v}}, nil
+	}
+}
+
+func (j *jsonReader) token() (json.Token, error) {
+	tok, err := j.decoder.Token()
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	if errors.Is(err, io.EOF) && j.inArray {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, io.ErrUnexpectedEOF)
+	}
+	return tok, err
+}
+
+// value decodes the value that starts with tok, keeping the order of object fields
+func (j *jsonReader) value(tok json.Token) (any, error) {
+	switch tok := tok.(type) {
+	case json.Delim:
+		switch tok {
+		case '{':
+			rec := &Record{}
+			for j.decoder.More() {
+				key, err := j.innerToken()
+				if err != nil {
+					return nil, err
+				}
+				v, err := j.next()
+				if err != nil {
+					return nil, err
+				}
+				rec.Append(key.(string), v)
+			}
+			return rec, j.end()
+		case '[':
+			list := make([]any, 0)
+			for j.decoder.More() {
+				v, err := j.next()
+				if err != nil {
+					return nil, err
+				}
+				list = append(list, v)
+			}
+			return list, j.end()
+		default:
+			return nil, fmt.Errorf("%w: unexpected '%s'", ErrInvalidInput, tok)
+		}
+	case json.Number:
+		if n, err := strconv.ParseInt(tok.String(), 10, 64); err == nil {
+			return n, nil
+		}
+		f, err := tok.Float64()
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+		}
+		return f, nil
+	default:
+		// nil, bool and string
+		return tok, nil
+	}
+}
+
+// next decodes the next value inside an object or an array
+func (j *jsonReader) next() (any, error) {
+	tok, err := j.innerToken()
+	if err != nil {
+		return nil, err
+	}
+	return j.value(tok)
+}
+
+// end reads the delimiter that ends an object or an array
+func (j *jsonReader) end() error {
+	_, err := j.innerToken()
+	return err
+}
+
+func (j *jsonReader) innerToken() (json.Token, error) {
+	tok, err := j.token()
+	if errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, io.ErrUnexpectedEOF)
+	}
+	return tok, err
+}
+
+func (j *jsonReader) Close() error {
+	return j.closer.Close()
+}
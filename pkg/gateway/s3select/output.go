@@ -0,0 +1,118 @@
+package s3select
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// recordWriter serializes the selected records into the payload of Records events
+type recordWriter interface {
+	// Append appends the record of names and values to buf
+	Append(buf []byte, names []string, values []any) []byte
+}
+
+func newRecordWriter(out *OutputSerialization) recordWriter {
+	if out.CSV != nil {
+		return &csvWriter{CSVOutput: *out.CSV}
+	}
+	return &jsonWriter{delimiter: out.JSON.RecordDelimiter}
+}
+
+type csvWriter struct {
+	CSVOutput
+}
+
+func (c *csvWriter) Append(buf []byte, _ []string, values []any) []byte {
+	for i, v := range values {
+		if i > 0 {
+			buf = append(buf, c.FieldDelimiter...)
+		}
+		var s string
+		switch v.(type) {
+		case *Record, []any:
+			s = string(appendJSON(nil, v))
+		default:
+			s = toString(v)
+		}
+		if c.QuoteFields == QuoteFieldsAlways || c.needsQuotes(s) {
+			buf = append(buf, c.QuoteCharacter...)
+			buf = append(buf, strings.ReplaceAll(s, c.QuoteCharacter, c.QuoteEscapeCharacter+c.QuoteCharacter)...)
+			buf = append(buf, c.QuoteCharacter...)
+		} else {
+			buf = append(buf, s...)
+		}
+	}
+	return append(buf, c.RecordDelimiter...)
+}
+
+func (c *csvWriter) needsQuotes(s string) bool {
+	return strings.Contains(s, c.FieldDelimiter) ||
+		strings.Contains(s, c.QuoteCharacter) ||
+		strings.Contains(s, c.RecordDelimiter) ||
+		strings.ContainsAny(s, "\r\n")
+}
+
+type jsonWriter struct {
+	delimiter string
+}
+
+func (j *jsonWriter) Append(buf []byte, names []string, values []any) []byte {
+	buf = appendJSONObject(buf, names, values)
+	return append(buf, j.delimiter...)
+}
+
+// appendJSON appends the JSON encoding of v to buf, keeping the order of the fields of records
+func appendJSON(buf []byte, v any) []byte {
+	switch v := v.(type) {
+	case nil:
+		return append(buf, "null"...)
+	case bool:
+		return strconv.AppendBool(buf, v)
+	case int64:
+		return strconv.AppendInt(buf, v, 10)
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return append(buf, "null"...)
+		}
+		return strconv.AppendFloat(buf, v, 'f', -1, 64)
+	case string:
+		return appendJSONString(buf, v)
+	case []any:
+		buf = append(buf, '[')
+		for i, item := range v {
+			if i > 0 {
+				buf = append(buf, ',')
+			}
+			buf = appendJSON(buf, item)
+		}
+		return append(buf, ']')
+	case *Record:
+		return appendJSONObject(buf, v.Names, v.Values)
+	default:
+		return appendJSONString(buf, toString(v))
+	}
+}
+
+func appendJSONObject(buf []byte, names []string, values []any) []byte {
+	buf = append(buf, '{')
+	for i, name := range names {
+		if i > 0 {
+			buf = append(buf, ',')
+		}
+		buf = appendJSONString(buf, name)
+		buf = append(buf, ':')
+		buf = appendJSON(buf, values[i])
+	}
+	return append(buf, '}')
+}
+
+func appendJSONString(buf []byte, s string) []byte {
+	var b bytes.Buffer
+	enc := json.NewEncoder(&b)
+	enc.SetEscapeHTML(false)
+	_ = enc.Encode(s) // encoding a string cannot fail
+	return append(buf, bytes.TrimSuffix(b.Bytes(), []byte("\n"))...)
+}
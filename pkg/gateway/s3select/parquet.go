@@ -0,0 +1,199 @@
+package s3select
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/xitongsys/parquet-go/common"
+	"github.com/xitongsys/parquet-go/parquet"
+	"github.com/xitongsys/parquet-go/reader"
+	"github.com/xitongsys/parquet-go/source"
+)
+
+const (
+	// parquetReadAhead is the size of the ranges read from a Parquet object
+	parquetReadAhead = 1024 * 1024
+	// parquetBatchSize is the number of rows read from each column at once
+	parquetBatchSize = 1024
+)
+
+var errParquetReadOnly = errors.New("parquet object is read only")
+
+// parquetFile is a source.ParquetFile that reads ranges of an object
+type parquetFile struct {
+	ctx      context.Context
+	obj      Object
+	progress *progress
+	offset   int64
+	// buf holds the object data read ahead from bufOffset
+	buf       []byte
+	bufOffset int64
+}
+
+func (f *parquetFile) Read(p []byte) (int, error) {
+	size := f.obj.Size()
+	if f.offset >= size {
+		return 0, io.EOF
+	}
+	if f.offset < f.bufOffset || f.offset >= f.bufOffset+int64(len(f.buf)) {
+		end := min(f.offset+parquetReadAhead, size)
+		body, err := f.obj.RangeReader(f.ctx, f.offset, end-1)
+		if err != nil {
+			return 0, err
+		}
+		buf, err := io.ReadAll(body)
+		_ = body.Close()
+		if err != nil {
+			return 0, err
+		}
+		if len(buf) == 0 {
+			return 0, io.ErrUnexpectedEOF
+		}
+		f.progress.scanned += int64(len(buf))
+		f.progress.processed += int64(len(buf))
+		f.buf = buf
+		f.bufOffset = f.offset
+	}
+	n := copy(p, f.buf[f.offset-f.bufOffset:])
+	f.offset += int64(n)
+	return n, nil
+}
+
+func (f *parquetFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += f.offset
+	case io.SeekEnd:
+		offset += f.obj.Size()
+	default:
+		return 0, fmt.Errorf("seek whence %d: %w", whence, ErrInvalidInput)
+	}
+	if offset < 0 {
+		return 0, fmt.Errorf("seek to negative offset %d: %w", offset, ErrInvalidInput)
+	}
+	f.offset = offset
+	return offset, nil
+}
+
+func (f *parquetFile) Write([]byte) (int, error) {
+	return 0, errParquetReadOnly
+}
+
+func (f *parquetFile) Close() error {
+	return nil
+}
+
+// Open returns a new file on the same object, the name is ignored
+func (f *parquetFile) Open(string) (source.ParquetFile, error) {
+	return &parquetFile{
+		ctx:      f.ctx,
+		obj:      f.obj,
+		progress: f.progress,
+	}, nil
+}
+
+func (f *parquetFile) Create(string) (source.ParquetFile, error) {
+	return nil, errParquetReadOnly
+}
+
+// parquetReader reads the records of a Parquet object column by column, in batches of rows.  Only top-level
+// non-repeated columns are read.
+type parquetReader struct {
+	reader  *reader.ParquetReader
+	columns []int64
+	names   []string
+	// batch holds the values read of each column, pos is the index of the next row in it
+	batch     [][]any
+	pos       int
+	remaining int64
+}
+
+func newParquetReader(ctx context.Context, obj Object, p *progress) (pr *parquetReader, err error) {
+	defer recoverParquet(&err)
+	file := &parquetFile{ctx: ctx, obj: obj, progress: p}
+	r, err := reader.NewParquetColumnReader(file, 1)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+	}
+	pr = &parquetReader{
+		reader:    r,
+		remaining: r.GetNumRows(),
+	}
+	schema := r.SchemaHandler
+	for i, path := range schema.ValueColumns {
+		parts := strings.Split(schema.InPathToExPath[path], common.PAR_GO_PATH_DELIMITER)
+		element := schema.SchemaElements[schema.MapIndex[path]]
+		if len(parts) != 2 || element.GetRepetitionType() == parquet.FieldRepetitionType_REPEATED {
+			continue
+		}
+		pr.columns = append(pr.columns, int64(i))
+		pr.names = append(pr.names, parts[1])
+	}
+	return pr, nil
+}
+
+// recoverParquet turns a panic of the Parquet reader on malformed input into an error
+func recoverParquet(err *error) {
+	if r := recover(); r != nil {
+		*err = fmt.Errorf("%w: malformed Parquet object: %v", ErrInvalidInput, r)
+	}
+}
+
+func (p *parquetReader) Read() (rec *Record, err error) {
+	defer recoverParquet(&err)
+	if len(p.batch) == 0 || p.pos >= len(p.batch[0]) {
+		if p.remaining <= 0 {
+			return nil, io.EOF
+		}
+		n := min(p.remaining, parquetBatchSize)
+		p.batch = make([][]any, len(p.columns))
+		for i, column := range p.columns {
+			values, _, _, err := p.reader.ReadColumnByIndex(column, n)
+			if err != nil {
+				return nil, fmt.Errorf("%w: %s", ErrInvalidInput, err)
+			}
+			if int64(len(values)) != n {
+				return nil, fmt.Errorf("%w: column %s has %d values, expected %d", ErrInvalidInput, p.names[i], len(values), n)
+			}
+			p.batch[i] = values
+		}
+		if len(p.columns) == 0 {
+			p.batch = [][]any{make([]any, n)}
+		}
+		p.pos = 0
+		p.remaining -= n
+	}
+	rec = &Record{
+		Names:  p.names,
+		Values: make([]any, len(p.columns)),
+	}
+	for i := range p.columns {
+		rec.Values[i] = parquetValue(p.batch[i][p.pos])
+	}
+	p.pos++
+	return rec, nil
+}
+
+func parquetValue(v any) any {
+	switch v := v.(type) {
+	case nil, bool, int64, float64, string:
+		return v
+	case int32:
+		return int64(v)
+	case float32:
+		return float64(v)
+	case []byte:
+		return string(v)
+	default:
+		return fmt.Sprint(v)
+	}
+}
+
+func (p *parquetReader) Close() error {
+	p.reader.ReadStop()
+	return nil
+}
@@ -0,0 +1,55 @@
+package s3select
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Record is a record read from an object: named values, in order.  Values are nil, bool, int64, float64, string,
+// []any or a nested *Record.
+type Record struct {
+	Names  []string
+	Values []any
+}
+
+func (r *Record) Append(name string, value any) {
+	r.Names = append(r.Names, name)
+	r.Values = append(r.Values, value)
+}
+
+// Get returns the value named name.  Names of unquoted identifiers match case-insensitively, and positional names
+// _1, _2, ... match the values by position.
+func (r *Record) Get(name string, caseSensitive bool) (any, bool) {
+	for i, n := range r.Names {
+		if n == name {
+			return r.Values[i], true
+		}
+	}
+	if !caseSensitive {
+		for i, n := range r.Names {
+			if strings.EqualFold(n, name) {
+				return r.Values[i], true
+			}
+		}
+	}
+	if i, ok := positionalIndex(name); ok && i < len(r.Values) {
+		return r.Values[i], true
+	}
+	return nil, false
+}
+
+// positionalIndex returns the index of a positional name _N
+func positionalIndex(name string) (int, bool) {
+	if !strings.HasPrefix(name, "_") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(name[1:])
+	if err != nil || n < 1 {
+		return 0, false
+	}
+	return n - 1, true
+}
+
+func positionalName(i int) string {
+	return "_" + strconv.Itoa(i+1)
+}
@@ -0,0 +1,196 @@
+package s3select
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	CompressionNone  = "NONE"
+	CompressionGZIP  = "GZIP"
+	CompressionBZIP2 = "BZIP2"
+
+	FileHeaderInfoUse    = "USE"
+	FileHeaderInfoIgnore = "IGNORE"
+	FileHeaderInfoNone   = "NONE"
+
+	JSONTypeDocument = "DOCUMENT"
+	JSONTypeLines    = "LINES"
+
+	QuoteFieldsAlways   = "ALWAYS"
+	QuoteFieldsAsNeeded = "ASNEEDED"
+
+	expressionTypeSQL = "SQL"
+
+	// MaxExpressionLength is the maximal length in bytes of the SQL expression, as in S3
+	MaxExpressionLength = 256 * 1024
+)
+
+// Request is the body of a SelectObjectContent request
+type Request struct {
+	XMLName             xml.Name            `xml:"SelectObjectContentRequest"`
+	Expression          string              `xml:"Expression"`
+	ExpressionType      string              `xml:"ExpressionType"`
+	InputSerialization  InputSerialization  `xml:"InputSerialization"`
+	OutputSerialization OutputSerialization `xml:"OutputSerialization"`
+	RequestProgress     RequestProgress     `xml:"RequestProgress"`
+	ScanRange           *ScanRange          `xml:"ScanRange"`
+}
+
+type InputSerialization struct {
+	CompressionType string        `xml:"CompressionType"`
+	CSV             *CSVInput     `xml:"CSV"`
+	JSON            *JSONInput    `xml:"JSON"`
+	Parquet         *ParquetInput `xml:"Parquet"`
+}
+
+type CSVInput struct {
+	FileHeaderInfo             string `xml:"FileHeaderInfo"`
+	Comments                   string `xml:"Comments"`
+	QuoteEscapeCharacter       string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter            string `xml:"RecordDelimiter"`
+	FieldDelimiter             string `xml:"FieldDelimiter"`
+	QuoteCharacter             string `xml:"QuoteCharacter"`
+	AllowQuotedRecordDelimiter bool   `xml:"AllowQuotedRecordDelimiter"`
+}
+
+type JSONInput struct {
+	Type string `xml:"Type"`
+}
+
+type ParquetInput struct{}
+
+type OutputSerialization struct {
+	CSV  *CSVOutput  `xml:"CSV"`
+	JSON *JSONOutput `xml:"JSON"`
+}
+
+type CSVOutput struct {
+	QuoteFields          string `xml:"QuoteFields"`
+	QuoteEscapeCharacter string `xml:"QuoteEscapeCharacter"`
+	RecordDelimiter      string `xml:"RecordDelimiter"`
+	FieldDelimiter       string `xml:"FieldDelimiter"`
+	QuoteCharacter       string `xml:"QuoteCharacter"`
+}
+
+type JSONOutput struct {
+	RecordDelimiter string `xml:"RecordDelimiter"`
+}
+
+type RequestProgress struct {
+	Enabled bool `xml:"Enabled"`
+}
+
+type ScanRange struct {
+	Start *int64 `xml:"Start"`
+	End   *int64 `xml:"End"`
+}
+
+// validate checks the request and fills in the defaults of its serialization formats
+func (r *Request) validate() error {
+	if !strings.EqualFold(r.ExpressionType, expressionTypeSQL) {
+		return fmt.Errorf("%w: '%s'", ErrInvalidExpressionType, r.ExpressionType)
+	}
+	if len(r.Expression) > MaxExpressionLength {
+		return fmt.Errorf("%w: %d bytes", ErrExpressionTooLong, len(r.Expression))
+	}
+	if r.ScanRange != nil {
+		return fmt.Errorf("%w: ScanRange", ErrUnsupported)
+	}
+	if err := r.InputSerialization.validate(); err != nil {
+		return err
+	}
+	return r.OutputSerialization.validate()
+}
+
+func (in *InputSerialization) validate() error {
+	formats := 0
+	for _, set := range []bool{in.CSV != nil, in.JSON != nil, in.Parquet != nil} {
+		if set {
+			formats++
+		}
+	}
+	if formats != 1 {
+		return fmt.Errorf("%w: InputSerialization must have exactly one of CSV, JSON or Parquet", ErrInvalidRequest)
+	}
+
+	in.CompressionType = strings.ToUpper(in.CompressionType)
+	switch in.CompressionType {
+	case "":
+		in.CompressionType = CompressionNone
+	case CompressionNone, CompressionGZIP, CompressionBZIP2:
+	default:
+		return fmt.Errorf("%w: CompressionType '%s'", ErrInvalidRequest, in.CompressionType)
+	}
+	if in.Parquet != nil && in.CompressionType != CompressionNone {
+		return fmt.Errorf("%w: CompressionType of Parquet input must be NONE", ErrInvalidRequest)
+	}
+
+	switch {
+	case in.CSV != nil:
+		return in.CSV.validate()
+	case in.JSON != nil:
+		in.JSON.Type = strings.ToUpper(in.JSON.Type)
+		if in.JSON.Type != JSONTypeDocument && in.JSON.Type != JSONTypeLines {
+			return fmt.Errorf("%w: JSON Type '%s'", ErrInvalidRequest, in.JSON.Type)
+		}
+	}
+	return nil
+}
+
+func (c *CSVInput) validate() error {
+	c.FileHeaderInfo = strings.ToUpper(c.FileHeaderInfo)
+	switch c.FileHeaderInfo {
+	case "":
+		c.FileHeaderInfo = FileHeaderInfoNone
+	case FileHeaderInfoUse, FileHeaderInfoIgnore, FileHeaderInfoNone:
+	default:
+		return fmt.Errorf("%w: FileHeaderInfo '%s'", ErrInvalidRequest, c.FileHeaderInfo)
+	}
+	c.FieldDelimiter = defaultString(c.FieldDelimiter, ",")
+	c.RecordDelimiter = defaultString(c.RecordDelimiter, "\n")
+	c.QuoteCharacter = defaultString(c.QuoteCharacter, `"`)
+	c.QuoteEscapeCharacter = defaultString(c.QuoteEscapeCharacter, `"`)
+	if utf8.RuneCountInString(c.FieldDelimiter) != 1 {
+		return fmt.Errorf("%w: FieldDelimiter must be a single character", ErrInvalidRequest)
+	}
+	if utf8.RuneCountInString(c.Comments) > 1 {
+		return fmt.Errorf("%w: Comments must be a single character", ErrInvalidRequest)
+	}
+	if c.RecordDelimiter != "\n" && c.RecordDelimiter != "\r\n" {
+		return fmt.Errorf("%w: RecordDelimiter other than newline", ErrUnsupported)
+	}
+	if c.QuoteCharacter != `"` || c.QuoteEscapeCharacter != `"` {
+		return fmt.Errorf("%w: QuoteCharacter and QuoteEscapeCharacter other than '\"'", ErrUnsupported)
+	}
+	return nil
+}
+
+func (out *OutputSerialization) validate() error {
+	switch {
+	case out.CSV != nil && out.JSON != nil, out.CSV == nil && out.JSON == nil:
+		return fmt.Errorf("%w: OutputSerialization must have exactly one of CSV or JSON", ErrInvalidRequest)
+	case out.CSV != nil:
+		c := out.CSV
+		c.QuoteFields = strings.ToUpper(defaultString(c.QuoteFields, QuoteFieldsAsNeeded))
+		if c.QuoteFields != QuoteFieldsAlways && c.QuoteFields != QuoteFieldsAsNeeded {
+			return fmt.Errorf("%w: QuoteFields '%s'", ErrInvalidRequest, c.QuoteFields)
+		}
+		c.FieldDelimiter = defaultString(c.FieldDelimiter, ",")
+		c.RecordDelimiter = defaultString(c.RecordDelimiter, "\n")
+		c.QuoteCharacter = defaultString(c.QuoteCharacter, `"`)
+		c.QuoteEscapeCharacter = defaultString(c.QuoteEscapeCharacter, c.QuoteCharacter)
+	default:
+		out.JSON.RecordDelimiter = defaultString(out.JSON.RecordDelimiter, "\n")
+	}
+	return nil
+}
+
+func defaultString(s, def string) string {
+	if s == "" {
+		return def
+	}
+	return s
+}
@@ -0,0 +1,136 @@
+// Package s3select implements S3 Select: filtering the content of CSV, JSON and Parquet objects with a subset of
+// SQL, and streaming the results as an AWS event stream.
+package s3select
+
+import (
+	"context"
+	"errors"
+	"io"
+)
+
+// recordsFlushSize is the size of the records buffered before they are sent in a Records event
+const recordsFlushSize = 64 * 1024
+
+// Object is the object a select reads
+type Object interface {
+	Size() int64
+	// Reader returns a reader of the whole object
+	Reader(ctx context.Context) (io.ReadCloser, error)
+	// RangeReader returns a reader of the object bytes from start to end, inclusive
+	RangeReader(ctx context.Context, start, end int64) (io.ReadCloser, error)
+}
+
+// Selector runs the query of a select request
+type Selector struct {
+	request *Request
+	query   *query
+}
+
+// NewSelector validates req and parses its expression
+func NewSelector(req *Request) (*Selector, error) {
+	if err := req.validate(); err != nil {
+		return nil, err
+	}
+	q, err := parseQuery(req.Expression)
+	if err != nil {
+		return nil, err
+	}
+	return &Selector{request: req, query: q}, nil
+}
+
+// Run selects the records of obj and writes them to w as an event stream.  Errors found after the stream started
+// are also reported in an error message of the stream.
+func (s *Selector) Run(ctx context.Context, w io.Writer, obj Object) error {
+	events := newEventWriter(w)
+	if err := s.run(ctx, events, obj); err != nil {
+		_ = events.error(errorCode(err), errorMessage(err))
+		return err
+	}
+	return nil
+}
+
+func (s *Selector) run(ctx context.Context, events *eventWriter, obj Object) error {
+	p := &progress{}
+	records, err := openRecordReader(ctx, &s.request.InputSerialization, obj, p)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = records.Close() }()
+
+	ev := newEvaluator(s.query)
+	out := newRecordWriter(&s.request.OutputSerialization)
+	aggregate := len(s.query.aggregates) > 0
+	var (
+		buf   []byte
+		count int64
+	)
+	flush := func() error {
+		if len(buf) == 0 {
+			return nil
+		}
+		if err := events.records(buf); err != nil {
+			return err
+		}
+		p.returned += int64(len(buf))
+		buf = buf[:0]
+		if s.request.RequestProgress.Enabled {
+			return events.progress(p)
+		}
+		return nil
+	}
+	for aggregate || s.query.limit < 0 || count < s.query.limit {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rec, err := records.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return err
+		}
+		match, err := ev.match(rec)
+		if err != nil {
+			return err
+		}
+		if !match {
+			continue
+		}
+		if aggregate {
+			if err := ev.accumulate(rec); err != nil {
+				return err
+			}
+			continue
+		}
+		names, values, err := ev.project(rec)
+		if err != nil {
+			return err
+		}
+		buf = out.Append(buf, names, values)
+		count++
+		if len(buf) >= recordsFlushSize {
+			if err := flush(); err != nil {
+				return err
+			}
+		}
+	}
+	if aggregate && s.query.limit != 0 {
+		names, values, err := ev.aggregateResult()
+		if err != nil {
+			return err
+		}
+		buf = out.Append(buf, names, values)
+	}
+	if err := flush(); err != nil {
+		return err
+	}
+	if s.request.RequestProgress.Enabled {
+		if err := events.progress(p); err != nil {
+			return err
+		}
+	}
+	if err := events.stats(p); err != nil {
+		return err
+	}
+	return events.end()
+}
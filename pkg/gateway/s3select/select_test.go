@@ -0,0 +1,436 @@
+package s3select_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/aws/aws-sdk-go-v2/aws/protocol/eventstream"
+	"github.com/treeverse/lakefs/pkg/gateway/s3select"
+	"github.com/xitongsys/parquet-go-source/buffer"
+	"github.com/xitongsys/parquet-go/writer"
+)
+
+type memObject []byte
+
+func (m memObject) Size() int64 {
+	return int64(len(m))
+}
+
+func (m memObject) Reader(context.Context) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m)), nil
+}
+
+func (m memObject) RangeReader(_ context.Context, start, end int64) (io.ReadCloser, error) {
+	return io.NopCloser(bytes.NewReader(m[start : end+1])), nil
+}
+
+type selectResult struct {
+	records      string
+	events       []string
+	errorCode    string
+	errorMessage string
+}
+
+func runSelect(t *testing.T, req *s3select.Request, obj memObject) (selectResult, error) {
+	t.Helper()
+	selector, err := s3select.NewSelector(req)
+	if err != nil {
+		return selectResult{}, err
+	}
+	var out bytes.Buffer
+	runErr := selector.Run(context.Background(), &out, obj)
+
+	var result selectResult
+	decoder := eventstream.NewDecoder()
+	for out.Len() > 0 {
+		msg, err := decoder.Decode(&out, nil)
+		if err != nil {
+			t.Fatalf("Decode event stream: %s", err)
+		}
+		if code := msg.Headers.Get(":error-code"); code != nil {
+			result.errorCode = code.String()
+			if message := msg.Headers.Get(":error-message"); message != nil {
+				result.errorMessage = message.String()
+			}
+			continue
+		}
+		eventType := msg.Headers.Get(":event-type").String()
+		result.events = append(result.events, eventType)
+		if eventType == "Records" {
+			result.records += string(msg.Payload)
+		}
+	}
+	return result, runErr
+}
+
+const csvData = `name,age,city
+alice,31,Haifa
+bob,25,"Tel Aviv"
+carol,,Haifa
+dave,47,"Be'er Sheva, South"
+`
+
+const jsonLines = `{"name":"alice","age":31,"address":{"city":"Haifa"},"tags":["a","b"]}
+{"name":"bob","age":25,"address":{"city":"Tel Aviv"}}
+{"name":"carol","age":null,"address":{"city":"Haifa"}}
+`
+
+func csvRequest(expression string) *s3select.Request {
+	return &s3select.Request{
+		Expression:     expression,
+		ExpressionType: "SQL",
+		InputSerialization: s3select.InputSerialization{
+			CSV: &s3select.CSVInput{FileHeaderInfo: s3select.FileHeaderInfoUse},
+		},
+		OutputSerialization: s3select.OutputSerialization{CSV: &s3select.CSVOutput{}},
+	}
+}
+
+func jsonRequest(expression string) *s3select.Request {
+	return &s3select.Request{
+		Expression:          expression,
+		ExpressionType:      "SQL",
+		InputSerialization:  s3select.InputSerialization{JSON: &s3select.JSONInput{Type: s3select.JSONTypeLines}},
+		OutputSerialization: s3select.OutputSerialization{JSON: &s3select.JSONOutput{}},
+	}
+}
+
+func TestSelect(t *testing.T) {
+	tests := []struct {
+		name string
+		req  *s3select.Request
+		obj  string
+		want string
+	}{
+		{
+			name: "csv select all",
+			req:  csvRequest("SELECT * FROM S3Object"),
+			obj:  csvData,
+			want: "alice,31,Haifa\nbob,25,Tel Aviv\ncarol,,Haifa\ndave,47,\"Be'er Sheva, South\"\n",
+		},
+		{
+			name: "csv where and projection",
+			req:  csvRequest("SELECT s.name, s.age + 1 AS next FROM S3Object s WHERE s.city = 'Haifa' AND s.age > 30"),
+			obj:  csvData,
+			want: "alice,32\n",
+		},
+		{
+			name: "csv is null and like",
+			req:  csvRequest("SELECT name FROM S3Object WHERE age = '' OR city LIKE 'Tel%'"),
+			obj:  csvData,
+			want: "bob\ncarol\n",
+		},
+		{
+			name: "csv like wildcards and escape",
+			req:  csvRequest("SELECT name FROM S3Object WHERE name LIKE '_a%' OR city LIKE '%!, S%' ESCAPE '!'"),
+			obj:  csvData,
+			want: "carol\ndave\n",
+		},
+		{
+			name: "csv like backtracking",
+			req:  csvRequest("SELECT name FROM S3Object WHERE city LIKE '%a%a%' OR city LIKE '%e%e%r%' OR name LIKE 'a%e'"),
+			obj:  csvData,
+			want: "alice\ncarol\ndave\n",
+		},
+		{
+			name: "csv positional columns",
+			req: func() *s3select.Request {
+				req := csvRequest("SELECT _1 FROM S3Object WHERE _2 BETWEEN 25 AND 40")
+				req.InputSerialization.CSV.FileHeaderInfo = s3select.FileHeaderInfoIgnore
+				return req
+			}(),
+			obj:  csvData,
+			want: "alice\nbob\n",
+		},
+		{
+			name: "csv limit",
+			req:  csvRequest("SELECT name FROM S3Object LIMIT 2"),
+			obj:  csvData,
+			want: "alice\nbob\n",
+		},
+		{
+			name: "csv aggregates",
+			req:  csvRequest("SELECT COUNT(*), SUM(CAST(age AS INT)), MAX(name) FROM S3Object WHERE city IN ('Haifa', 'Tel Aviv') AND age <> ''"),
+			obj:  csvData,
+			want: "2,56,bob\n",
+		},
+		{
+			name: "csv quote always",
+			req: func() *s3select.Request {
+				req := csvRequest("SELECT UPPER(name), city FROM S3Object WHERE name = 'dave'")
+				req.OutputSerialization.CSV.QuoteFields = s3select.QuoteFieldsAlways
+				req.OutputSerialization.CSV.FieldDelimiter = ";"
+				return req
+			}(),
+			obj:  csvData,
+			want: "\"DAVE\";\"Be'er Sheva, South\"\n",
+		},
+		{
+			name: "json lines nested",
+			req:  jsonRequest("SELECT s.name, s.address.city FROM S3Object[*] s WHERE s.age IS NOT NULL"),
+			obj:  jsonLines,
+			want: "{\"name\":\"alice\",\"city\":\"Haifa\"}\n{\"name\":\"bob\",\"city\":\"Tel Aviv\"}\n",
+		},
+		{
+			name: "json is missing",
+			req:  jsonRequest("SELECT name FROM S3Object WHERE tags IS MISSING"),
+			obj:  jsonLines,
+			want: "{\"name\":\"bob\"}\n{\"name\":\"carol\"}\n",
+		},
+		{
+			name: "json select all keeps field order",
+			req:  jsonRequest("SELECT * FROM S3Object WHERE name = 'alice'"),
+			obj:  jsonLines,
+			want: "{\"name\":\"alice\",\"age\":31,\"address\":{\"city\":\"Haifa\"},\"tags\":[\"a\",\"b\"]}\n",
+		},
+		{
+			name: "json document array",
+			req: func() *s3select.Request {
+				req := jsonRequest("SELECT AVG(v) AS avg FROM S3Object")
+				req.InputSerialization.JSON.Type = s3select.JSONTypeDocument
+				return req
+			}(),
+			obj:  `[{"v": 1}, {"v": 2}, {"v": 4.5}]`,
+			want: "{\"avg\":2.5}\n",
+		},
+		{
+			name: "json to csv",
+			req: func() *s3select.Request {
+				req := jsonRequest("SELECT name, address FROM S3Object LIMIT 1")
+				req.OutputSerialization = s3select.OutputSerialization{CSV: &s3select.CSVOutput{}}
+				return req
+			}(),
+			obj:  jsonLines,
+			want: "alice,\"{\"\"city\"\":\"\"Haifa\"\"}\"\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runSelect(t, tt.req, memObject(tt.obj))
+			if err != nil {
+				t.Fatalf("Select: %s", err)
+			}
+			if result.records != tt.want {
+				t.Errorf("Select records: %q, expected %q", result.records, tt.want)
+			}
+			if n := len(result.events); n < 2 || result.events[n-2] != "Stats" || result.events[n-1] != "End" {
+				t.Errorf("Select events: %v, expected to end with Stats, End", result.events)
+			}
+		})
+	}
+}
+
+func TestSelect_GZIP(t *testing.T) {
+	var compressed bytes.Buffer
+	gz := gzip.NewWriter(&compressed)
+	if _, err := gz.Write([]byte(csvData)); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	req := csvRequest("SELECT name FROM S3Object WHERE city = 'Haifa'")
+	req.InputSerialization.CompressionType = s3select.CompressionGZIP
+	req.RequestProgress.Enabled = true
+	result, err := runSelect(t, req, compressed.Bytes())
+	if err != nil {
+		t.Fatalf("Select: %s", err)
+	}
+	if result.records != "alice\ncarol\n" {
+		t.Errorf("Select records: %q, expected %q", result.records, "alice\ncarol\n")
+	}
+	expectedEvents := []string{"Records", "Progress", "Progress", "Stats", "End"}
+	if len(result.events) != len(expectedEvents) {
+		t.Fatalf("Select events: %v, expected %v", result.events, expectedEvents)
+	}
+	for i := range expectedEvents {
+		if result.events[i] != expectedEvents[i] {
+			t.Fatalf("Select events: %v, expected %v", result.events, expectedEvents)
+		}
+	}
+}
+
+type parquetRow struct {
+	Name  string   `parquet:"name=name, type=BYTE_ARRAY, convertedtype=UTF8"`
+	Size  int32    `parquet:"name=size, type=INT32"`
+	Score *float64 `parquet:"name=score, type=DOUBLE, repetitiontype=OPTIONAL"`
+}
+
+func TestSelect_Parquet(t *testing.T) {
+	file := buffer.NewBufferFile()
+	pw, err := writer.NewParquetWriter(file, new(parquetRow), 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const rows = 3000
+	for i := 0; i < rows; i++ {
+		row := parquetRow{Name: "row", Size: int32(i)}
+		if i%2 == 0 {
+			score := float64(i) / 2
+			row.Score = &score
+		}
+		if err := pw.Write(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := pw.WriteStop(); err != nil {
+		t.Fatal(err)
+	}
+
+	req := &s3select.Request{
+		Expression:          "SELECT COUNT(*) AS n, COUNT(score) AS scores, MAX(score) AS max FROM S3Object WHERE size >= 1000",
+		ExpressionType:      "SQL",
+		InputSerialization:  s3select.InputSerialization{Parquet: &s3select.ParquetInput{}},
+		OutputSerialization: s3select.OutputSerialization{JSON: &s3select.JSONOutput{}},
+	}
+	result, err := runSelect(t, req, file.Bytes())
+	if err != nil {
+		t.Fatalf("Select: %s", err)
+	}
+	const expected = "{\"n\":2000,\"scores\":1000,\"max\":1499}\n"
+	if result.records != expected {
+		t.Errorf("Select records: %q, expected %q", result.records, expected)
+	}
+}
+
+func TestSelect_Errors(t *testing.T) {
+	tests := []struct {
+		name      string
+		req       *s3select.Request
+		obj       string
+		wantErr   error
+		errorCode string
+	}{
+		{
+			name: "expression type",
+			req: func() *s3select.Request {
+				req := csvRequest("SELECT * FROM S3Object")
+				req.ExpressionType = "GraphQL"
+				return req
+			}(),
+			wantErr: s3select.ErrInvalidExpressionType,
+		},
+		{
+			name:    "no input format",
+			req:     &s3select.Request{Expression: "SELECT * FROM S3Object", ExpressionType: "SQL", OutputSerialization: s3select.OutputSerialization{JSON: &s3select.JSONOutput{}}},
+			wantErr: s3select.ErrInvalidRequest,
+		},
+		{
+			name:    "syntax",
+			req:     csvRequest("SELECT name FROM S3Object WHERE"),
+			wantErr: s3select.ErrParse,
+		},
+		{
+			name:    "aggregate in where",
+			req:     csvRequest("SELECT name FROM S3Object WHERE COUNT(*) > 1"),
+			wantErr: s3select.ErrParse,
+		},
+		{
+			name:    "unknown table",
+			req:     csvRequest("SELECT name FROM objects"),
+			wantErr: s3select.ErrParse,
+		},
+		{
+			name:    "deeply nested",
+			req:     csvRequest("SELECT name FROM S3Object WHERE " + strings.Repeat("(", 100_000) + "1" + strings.Repeat(")", 100_000) + " = 1"),
+			wantErr: s3select.ErrParse,
+		},
+		{
+			name:    "deeply nested negation",
+			req:     csvRequest("SELECT name FROM S3Object WHERE " + strings.Repeat("NOT ", 10_000) + "TRUE"),
+			wantErr: s3select.ErrParse,
+		},
+		{
+			name: "nested within the limit",
+			req:  csvRequest("SELECT name FROM S3Object WHERE " + strings.Repeat("(", 100) + "city" + strings.Repeat(")", 100) + " = 'Haifa'"),
+			obj:  csvData,
+		},
+		{
+			name:    "expression too long",
+			req:     csvRequest("SELECT name FROM S3Object WHERE name = '" + strings.Repeat("a", s3select.MaxExpressionLength) + "'"),
+			wantErr: s3select.ErrExpressionTooLong,
+		},
+		{
+			name:      "cast failure",
+			req:       csvRequest("SELECT CAST(city AS INT) FROM S3Object"),
+			obj:       csvData,
+			wantErr:   s3select.ErrEvaluation,
+			errorCode: "EvaluatorInvalidArguments",
+		},
+		{
+			name:      "malformed json",
+			req:       jsonRequest("SELECT * FROM S3Object"),
+			obj:       "{\"name\": ",
+			wantErr:   s3select.ErrInvalidInput,
+			errorCode: "InvalidInput",
+		},
+		{
+			name: "malformed parquet",
+			req: &s3select.Request{
+				Expression:          "SELECT * FROM S3Object",
+				ExpressionType:      "SQL",
+				InputSerialization:  s3select.InputSerialization{Parquet: &s3select.ParquetInput{}},
+				OutputSerialization: s3select.OutputSerialization{JSON: &s3select.JSONOutput{}},
+			},
+			obj:       "not a parquet file",
+			wantErr:   s3select.ErrInvalidInput,
+			errorCode: "InvalidInput",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := runSelect(t, tt.req, memObject(tt.obj))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("Select error: %v, expected %v", err, tt.wantErr)
+			}
+			if result.errorCode != tt.errorCode {
+				t.Errorf("Select error code: %q, expected %q", result.errorCode, tt.errorCode)
+			}
+		})
+	}
+}
+
+type failingObject struct{ memObject }
+
+func (failingObject) Reader(context.Context) (io.ReadCloser, error) {
+	return nil, errors.New("open s3://internal-bucket/data/object: access denied")
+}
+
+func TestSelect_InternalErrorMessage(t *testing.T) {
+	selector, err := s3select.NewSelector(csvRequest("SELECT * FROM S3Object"))
+	if err != nil {
+		t.Fatalf("NewSelector: %s", err)
+	}
+	var out bytes.Buffer
+	if err := selector.Run(context.Background(), &out, failingObject{memObject(csvData)}); err == nil {
+		t.Fatal("Select expected error")
+	}
+	msg, err := eventstream.NewDecoder().Decode(&out, nil)
+	if err != nil {
+		t.Fatalf("Decode event stream: %s", err)
+	}
+	if code := msg.Headers.Get(":error-code"); code == nil || code.String() != "InternalError" {
+		t.Fatalf("Select error code: %v, expected InternalError", code)
+	}
+	if message := msg.Headers.Get(":error-message").String(); strings.Contains(message, "internal-bucket") {
+		t.Errorf("Select error message %q reveals the internal error", message)
+	}
+}
+
+func TestSelect_LikeLongInput(t *testing.T) {
+	// a pattern of many % against a long value that does not match must not backtrack exponentially
+	value := strings.Repeat("a", 10_000)
+	pattern := strings.Repeat("%a", 30) + "%b"
+	result, err := runSelect(t, jsonRequest("SELECT s.v FROM S3Object s WHERE s.v LIKE '"+pattern+"'"), memObject(`{"v":"`+value+`"}`))
+	if err != nil {
+		t.Fatalf("Select: %s", err)
+	}
+	if result.records != "" {
+		t.Errorf("Select records: %q, expected none", result.records)
+	}
+}
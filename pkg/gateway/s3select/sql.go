@@ -0,0 +1,867 @@
+package s3select
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// The SQL subset of S3 Select:
+//
+//	SELECT * | expr [[AS] name], ... FROM S3Object[[*]] [[AS] alias] [WHERE expr] [LIMIT n]
+//
+// Expressions support literals, column references (optionally prefixed by the alias and nested with '.'),
+// arithmetic, ||, comparisons, AND, OR, NOT, [NOT] LIKE, [NOT] BETWEEN, [NOT] IN, IS [NOT] NULL, IS [NOT] MISSING,
+// CAST, the scalar functions in scalarFunctions and the aggregate functions COUNT, SUM, AVG, MIN and MAX.
+
+type tokenKind int
+
+const (
+	tokenEOF tokenKind = iota
+	tokenIdent
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenSymbol
+)
+
+type token struct {
+	kind tokenKind
+	text string
+	pos  int
+}
+
+func (t token) String() string {
+	if t.kind == tokenEOF {
+		return "end of expression"
+	}
+	return fmt.Sprintf("'%s' at %d", t.text, t.pos)
+}
+
+const singleCharSymbols = "=<>+-*/%(),.[];"
+
+var twoCharSymbols = []string{"<=", ">=", "<>", "!=", "||"}
+
+func tokenize(s string) ([]token, error) {
+	var tokens []token
+	runes := []rune(s)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case unicode.IsSpace(r):
+			i++
+		case r == '_' || unicode.IsLetter(r):
+			start := i
+			for i < len(runes) && (runes[i] == '_' || unicode.IsLetter(runes[i]) || unicode.IsDigit(runes[i])) {
+				i++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, text: string(runes[start:i]), pos: start})
+		case r == '"' || r == '\'':
+			start := i
+			text, n, ok := scanQuoted(runes[i:], r)
+			if !ok {
+				return nil, fmt.Errorf("%w: unterminated quote at %d", ErrParse, start)
+			}
+			i += n
+			kind := tokenString
+			if r == '"' {
+				kind = tokenQuotedIdent
+			}
+			tokens = append(tokens, token{kind: kind, text: text, pos: start})
+		case unicode.IsDigit(r) || r == '.' && i+1 < len(runes) && unicode.IsDigit(runes[i+1]):
+			start := i
+			for i < len(runes) && (unicode.IsDigit(runes[i]) || runes[i] == '.') {
+				i++
+			}
+			if i < len(runes) && (runes[i] == 'e' || runes[i] == 'E') {
+				i++
+				if i < len(runes) && (runes[i] == '+' || runes[i] == '-') {
+					i++
+				}
+				for i < len(runes) && unicode.IsDigit(runes[i]) {
+					i++
+				}
+			}
+			tokens = append(tokens, token{kind: tokenNumber, text: string(runes[start:i]), pos: start})
+		default:
+			text := string(r)
+			if i+1 < len(runes) {
+				for _, sym := range twoCharSymbols {
+					if string(runes[i:i+2]) == sym {
+						text = sym
+						break
+					}
+				}
+			}
+			if len(text) == 1 && !strings.ContainsRune(singleCharSymbols, r) {
+				return nil, fmt.Errorf("%w: unexpected character '%c' at %d", ErrParse, r, i)
+			}
+			tokens = append(tokens, token{kind: tokenSymbol, text: text, pos: i})
+			i += len([]rune(text))
+		}
+	}
+	return append(tokens, token{kind: tokenEOF, pos: len(runes)}), nil
+}
+
+// scanQuoted scans a string quoted by quote, where a doubled quote escapes it.  It returns the unquoted string and
+// the number of runes scanned.
+func scanQuoted(runes []rune, quote rune) (string, int, bool) {
+	var sb strings.Builder
+	for i := 1; i < len(runes); i++ {
+		if runes[i] != quote {
+			sb.WriteRune(runes[i])
+			continue
+		}
+		if i+1 < len(runes) && runes[i+1] == quote {
+			sb.WriteRune(quote)
+			i++
+			continue
+		}
+		return sb.String(), i + 1, true
+	}
+	return "", 0, false
+}
+
+type expr interface{}
+
+type literal struct {
+	value any
+}
+
+// pathElem is an element of a column reference; quoted elements match case-sensitively
+type pathElem struct {
+	name   string
+	quoted bool
+}
+
+type columnRef struct {
+	path []pathElem
+}
+
+type unaryExpr struct {
+	op string
+	x  expr
+}
+
+type binaryExpr struct {
+	op   string
+	x, y expr
+}
+
+type likeExpr struct {
+	x, pattern, escape expr
+	not                bool
+}
+
+type betweenExpr struct {
+	x, low, high expr
+	not          bool
+}
+
+type inExpr struct {
+	x    expr
+	list []expr
+	not  bool
+}
+
+type isExpr struct {
+	x       expr
+	missing bool
+	not     bool
+}
+
+type castExpr struct {
+	x   expr
+	typ string
+}
+
+type funcCall struct {
+	name string
+	args []expr
+}
+
+// aggregate is an aggregate function call, arg is nil for COUNT(*)
+type aggregate struct {
+	name string
+	arg  expr
+}
+
+type projection struct {
+	x    expr
+	name string
+}
+
+type query struct {
+	// projections are the selected expressions, nil for SELECT *
+	projections []projection
+	where       expr
+	// limit is the maximal number of records returned, -1 for no limit
+	limit      int64
+	aggregates []*aggregate
+}
+
+var castTypes = map[string]string{
+	"INT": "INT", "INTEGER": "INT", "BIGINT": "INT", "SMALLINT": "INT",
+	"FLOAT": "FLOAT", "REAL": "FLOAT", "DOUBLE": "FLOAT", "DECIMAL": "FLOAT", "NUMERIC": "FLOAT",
+	"STRING": "STRING", "VARCHAR": "STRING", "CHAR": "STRING",
+	"BOOL": "BOOL", "BOOLEAN": "BOOL",
+}
+
+var aggregateFunctions = map[string]struct{}{"COUNT": {}, "SUM": {}, "AVG": {}, "MIN": {}, "MAX": {}}
+
+// reservedWords cannot be used as an alias without AS
+var reservedWords = map[string]struct{}{
+	"SELECT": {}, "FROM": {}, "WHERE": {}, "LIMIT": {}, "AS": {}, "AND": {}, "OR": {}, "NOT": {}, "LIKE": {},
+	"BETWEEN": {}, "IN": {}, "IS": {}, "NULL": {}, "MISSING": {}, "TRUE": {}, "FALSE": {}, "ESCAPE": {},
+}
+
+// maxExpressionDepth is the maximal nesting depth of an expression, bounding the recursion of parsing it
+const maxExpressionDepth = 128
+
+type parser struct {
+	tokens []token
+	pos    int
+	alias  string
+	// depth is the nesting depth of the expression being parsed
+	depth int
+	// allowAggregates is set while parsing the projections, inAggregate while parsing the argument of an aggregate
+	allowAggregates bool
+	inAggregate     bool
+	aggregates      []*aggregate
+}
+
+func parseQuery(s string) (*query, error) {
+	tokens, err := tokenize(s)
+	if err != nil {
+		return nil, err
+	}
+	p := &parser{tokens: tokens}
+	return p.parseQuery()
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.pos]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.pos]
+	if t.kind != tokenEOF {
+		p.pos++
+	}
+	return t
+}
+
+func (p *parser) isKeyword(kw string) bool {
+	t := p.peek()
+	return t.kind == tokenIdent && strings.EqualFold(t.text, kw)
+}
+
+func (p *parser) acceptKeyword(kw string) bool {
+	if p.isKeyword(kw) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectKeyword(kw string) error {
+	if !p.acceptKeyword(kw) {
+		return p.unexpected("expected " + kw)
+	}
+	return nil
+}
+
+func (p *parser) isSymbol(sym string) bool {
+	t := p.peek()
+	return t.kind == tokenSymbol && t.text == sym
+}
+
+func (p *parser) acceptSymbol(sym string) bool {
+	if p.isSymbol(sym) {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expectSymbol(sym string) error {
+	if !p.acceptSymbol(sym) {
+		return p.unexpected("expected '" + sym + "'")
+	}
+	return nil
+}
+
+func (p *parser) unexpected(msg string) error {
+	return fmt.Errorf("%w: %s, found %s", ErrParse, msg, p.peek())
+}
+
+func (p *parser) parseQuery() (*query, error) {
+	if err := p.expectKeyword("SELECT"); err != nil {
+		return nil, err
+	}
+	// the alias is defined after the projections that use it: find it before parsing them
+	if err := p.scanAlias(); err != nil {
+		return nil, err
+	}
+	q := &query{limit: -1}
+	if !p.acceptSymbol("*") {
+		p.allowAggregates = true
+		for {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			proj := projection{x: x}
+			if p.acceptKeyword("AS") {
+				t := p.next()
+				if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+					p.pos--
+					return nil, p.unexpected("expected a name")
+				}
+				proj.name = t.text
+			} else if t := p.peek(); t.kind == tokenQuotedIdent || t.kind == tokenIdent && !isReserved(t.text) {
+				proj.name = p.next().text
+			}
+			q.projections = append(q.projections, proj)
+			if !p.acceptSymbol(",") {
+				break
+			}
+		}
+		p.allowAggregates = false
+	}
+	if len(p.aggregates) > 0 {
+		for _, proj := range q.projections {
+			if hasColumnRefOutsideAggregate(proj.x) {
+				return nil, fmt.Errorf("%w: cannot mix aggregate and non-aggregate projections", ErrParse)
+			}
+		}
+	}
+	q.aggregates = p.aggregates
+
+	if err := p.parseFrom(); err != nil {
+		return nil, err
+	}
+	if p.acceptKeyword("WHERE") {
+		where, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		q.where = where
+	}
+	if p.acceptKeyword("LIMIT") {
+		t := p.next()
+		n, err := strconv.ParseInt(t.text, 10, 64)
+		if t.kind != tokenNumber || err != nil || n < 0 {
+			p.pos--
+			return nil, p.unexpected("expected a non-negative integer LIMIT")
+		}
+		q.limit = n
+	}
+	p.acceptSymbol(";")
+	if p.peek().kind != tokenEOF {
+		return nil, p.unexpected("expected end of expression")
+	}
+	return q, nil
+}
+
+// scanAlias looks ahead for the FROM clause, and sets the alias it defines
+func (p *parser) scanAlias() error {
+	start := p.pos
+	defer func() { p.pos = start }()
+	depth := 0
+	for ; p.peek().kind != tokenEOF; p.pos++ {
+		switch {
+		case p.isSymbol("("):
+			depth++
+		case p.isSymbol(")"):
+			depth--
+		case depth == 0 && p.isKeyword("FROM"):
+			return p.parseFrom()
+		}
+	}
+	return p.unexpected("expected FROM")
+}
+
+func (p *parser) parseFrom() error {
+	if err := p.expectKeyword("FROM"); err != nil {
+		return err
+	}
+	if !p.acceptKeyword("S3Object") {
+		return p.unexpected("expected S3Object")
+	}
+	if p.acceptSymbol("[") {
+		if err := p.expectSymbol("*"); err != nil {
+			return err
+		}
+		if err := p.expectSymbol("]"); err != nil {
+			return err
+		}
+	}
+	if p.isSymbol(".") {
+		return fmt.Errorf("%w: paths in FROM clause", ErrUnsupported)
+	}
+	p.alias = ""
+	if p.acceptKeyword("AS") {
+		t := p.next()
+		if t.kind != tokenIdent {
+			p.pos--
+			return p.unexpected("expected an alias")
+		}
+		p.alias = t.text
+	} else if t := p.peek(); t.kind == tokenIdent && !isReserved(t.text) {
+		p.alias = p.next().text
+	}
+	return nil
+}
+
+func isReserved(word string) bool {
+	_, ok := reservedWords[strings.ToUpper(word)]
+	return ok
+}
+
+// nest enters a nested expression, failing if it is nested too deep. The caller calls unnest once it is parsed.
+func (p *parser) nest() error {
+	p.depth++
+	if p.depth > maxExpressionDepth {
+		return fmt.Errorf("%w: expression nested deeper than %d at %s", ErrParse, maxExpressionDepth, p.peek())
+	}
+	return nil
+}
+
+func (p *parser) unnest() {
+	p.depth--
+}
+
+func (p *parser) parseExpr() (expr, error) {
+	if err := p.nest(); err != nil {
+		return nil, err
+	}
+	defer p.unnest()
+	return p.parseOr()
+}
+
+func (p *parser) parseOr() (expr, error) {
+	x, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("OR") {
+		y, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: "OR", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseAnd() (expr, error) {
+	x, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+	for p.acceptKeyword("AND") {
+		y, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: "AND", x: x, y: y}
+	}
+	return x, nil
+}
+
+func (p *parser) parseNot() (expr, error) {
+	if p.acceptKeyword("NOT") {
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		defer p.unnest()
+		x, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "NOT", x: x}, nil
+	}
+	return p.parsePredicate()
+}
+
+func (p *parser) parsePredicate() (expr, error) {
+	x, err := p.parseAdditive()
+	if err != nil {
+		return nil, err
+	}
+	for _, op := range []string{"=", "<>", "!=", "<", "<=", ">", ">="} {
+		if p.acceptSymbol(op) {
+			y, err := p.parseAdditive()
+			if err != nil {
+				return nil, err
+			}
+			if op == "!=" {
+				op = "<>"
+			}
+			return &binaryExpr{op: op, x: x, y: y}, nil
+		}
+	}
+	if p.acceptKeyword("IS") {
+		not := p.acceptKeyword("NOT")
+		switch {
+		case p.acceptKeyword("NULL"):
+			return &isExpr{x: x, not: not}, nil
+		case p.acceptKeyword("MISSING"):
+			return &isExpr{x: x, missing: true, not: not}, nil
+		default:
+			return nil, p.unexpected("expected NULL or MISSING")
+		}
+	}
+	not := p.acceptKeyword("NOT")
+	switch {
+	case p.acceptKeyword("LIKE"):
+		pattern, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		like := &likeExpr{x: x, pattern: pattern, not: not}
+		if p.acceptKeyword("ESCAPE") {
+			if like.escape, err = p.parseAdditive(); err != nil {
+				return nil, err
+			}
+		}
+		return like, nil
+	case p.acceptKeyword("BETWEEN"):
+		low, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectKeyword("AND"); err != nil {
+			return nil, err
+		}
+		high, err := p.parseAdditive()
+		if err != nil {
+			return nil, err
+		}
+		return &betweenExpr{x: x, low: low, high: high, not: not}, nil
+	case p.acceptKeyword("IN"):
+		list, err := p.parseArgs()
+		if err != nil {
+			return nil, err
+		}
+		return &inExpr{x: x, list: list, not: not}, nil
+	case not:
+		return nil, p.unexpected("expected LIKE, BETWEEN or IN")
+	}
+	return x, nil
+}
+
+func (p *parser) parseAdditive() (expr, error) {
+	x, err := p.parseMultiplicative()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch {
+		case p.acceptSymbol("+"):
+			op = "+"
+		case p.acceptSymbol("-"):
+			op = "-"
+		case p.acceptSymbol("||"):
+			op = "||"
+		default:
+			return x, nil
+		}
+		y, err := p.parseMultiplicative()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) parseMultiplicative() (expr, error) {
+	x, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for {
+		var op string
+		switch {
+		case p.acceptSymbol("*"):
+			op = "*"
+		case p.acceptSymbol("/"):
+			op = "/"
+		case p.acceptSymbol("%"):
+			op = "%"
+		default:
+			return x, nil
+		}
+		y, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		x = &binaryExpr{op: op, x: x, y: y}
+	}
+}
+
+func (p *parser) parseUnary() (expr, error) {
+	if p.acceptSymbol("-") {
+		if err := p.nest(); err != nil {
+			return nil, err
+		}
+		defer p.unnest()
+		x, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &unaryExpr{op: "-", x: x}, nil
+	}
+	p.acceptSymbol("+")
+	return p.parsePrimary()
+}
+
+func (p *parser) parsePrimary() (expr, error) {
+	t := p.peek()
+	switch t.kind {
+	case tokenString:
+		p.next()
+		return &literal{value: t.text}, nil
+	case tokenNumber:
+		p.next()
+		if n, err := strconv.ParseInt(t.text, 10, 64); err == nil {
+			return &literal{value: n}, nil
+		}
+		f, err := strconv.ParseFloat(t.text, 64)
+		if err != nil {
+			p.pos--
+			return nil, p.unexpected("invalid number")
+		}
+		return &literal{value: f}, nil
+	case tokenSymbol:
+		if p.acceptSymbol("(") {
+			x, err := p.parseExpr()
+			if err != nil {
+				return nil, err
+			}
+			return x, p.expectSymbol(")")
+		}
+	case tokenQuotedIdent:
+		return p.parseColumnRef()
+	case tokenIdent:
+		switch strings.ToUpper(t.text) {
+		case "NULL", "MISSING":
+			p.next()
+			return &literal{value: nil}, nil
+		case "TRUE":
+			p.next()
+			return &literal{value: true}, nil
+		case "FALSE":
+			p.next()
+			return &literal{value: false}, nil
+		case "CAST":
+			if p.isCall() {
+				return p.parseCast()
+			}
+		}
+		if isReserved(t.text) {
+			break
+		}
+		if p.isCall() {
+			return p.parseFunction()
+		}
+		return p.parseColumnRef()
+	}
+	return nil, p.unexpected("expected an expression")
+}
+
+// isCall returns true if the next token is followed by an opening parenthesis
+func (p *parser) isCall() bool {
+	t := p.tokens[p.pos+1]
+	return t.kind == tokenSymbol && t.text == "("
+}
+
+func (p *parser) parseColumnRef() (expr, error) {
+	var path []pathElem
+	for {
+		t := p.next()
+		if t.kind != tokenIdent && t.kind != tokenQuotedIdent {
+			p.pos--
+			return nil, p.unexpected("expected a column name")
+		}
+		path = append(path, pathElem{name: t.text, quoted: t.kind == tokenQuotedIdent})
+		if !p.acceptSymbol(".") {
+			break
+		}
+	}
+	// strip the alias of the object
+	if len(path) > 1 && !path[0].quoted &&
+		(p.alias != "" && strings.EqualFold(path[0].name, p.alias) || strings.EqualFold(path[0].name, "S3Object")) {
+		path = path[1:]
+	}
+	return &columnRef{path: path}, nil
+}
+
+func (p *parser) parseCast() (expr, error) {
+	p.next() // CAST
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	x, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	if err := p.expectKeyword("AS"); err != nil {
+		return nil, err
+	}
+	t := p.next()
+	typ, ok := castTypes[strings.ToUpper(t.text)]
+	if t.kind != tokenIdent || !ok {
+		p.pos--
+		return nil, p.unexpected("expected a type")
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return &castExpr{x: x, typ: typ}, nil
+}
+
+func (p *parser) parseFunction() (expr, error) {
+	t := p.next()
+	name := strings.ToUpper(t.text)
+	if _, ok := aggregateFunctions[name]; ok {
+		return p.parseAggregate(name)
+	}
+	if _, ok := scalarFunctions[name]; !ok {
+		p.pos--
+		return nil, fmt.Errorf("%w: unknown function %s", ErrParse, t)
+	}
+	if name == "SUBSTRING" {
+		return p.parseSubstring()
+	}
+	args, err := p.parseArgs()
+	if err != nil {
+		return nil, err
+	}
+	return &funcCall{name: name, args: args}, nil
+}
+
+// parseSubstring parses both SUBSTRING(s, start[, length]) and SUBSTRING(s FROM start [FOR length])
+func (p *parser) parseSubstring() (expr, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	s, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	args := []expr{s}
+	fromForm := p.acceptKeyword("FROM")
+	if !fromForm {
+		if err := p.expectSymbol(","); err != nil {
+			return nil, err
+		}
+	}
+	start, err := p.parseExpr()
+	if err != nil {
+		return nil, err
+	}
+	args = append(args, start)
+	if fromForm && p.acceptKeyword("FOR") || !fromForm && p.acceptSymbol(",") {
+		length, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, length)
+	}
+	if err := p.expectSymbol(")"); err != nil {
+		return nil, err
+	}
+	return &funcCall{name: "SUBSTRING", args: args}, nil
+}
+
+func (p *parser) parseAggregate(name string) (expr, error) {
+	if !p.allowAggregates {
+		return nil, fmt.Errorf("%w: aggregate function %s outside of the projections", ErrParse, name)
+	}
+	if p.inAggregate {
+		return nil, fmt.Errorf("%w: nested aggregate function %s", ErrParse, name)
+	}
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	agg := &aggregate{name: name}
+	if name == "COUNT" && p.acceptSymbol("*") {
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+	} else {
+		p.inAggregate = true
+		arg, err := p.parseExpr()
+		p.inAggregate = false
+		if err != nil {
+			return nil, err
+		}
+		if err := p.expectSymbol(")"); err != nil {
+			return nil, err
+		}
+		agg.arg = arg
+	}
+	p.aggregates = append(p.aggregates, agg)
+	return agg, nil
+}
+
+func (p *parser) parseArgs() ([]expr, error) {
+	if err := p.expectSymbol("("); err != nil {
+		return nil, err
+	}
+	var args []expr
+	if p.acceptSymbol(")") {
+		return args, nil
+	}
+	for {
+		x, err := p.parseExpr()
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, x)
+		if !p.acceptSymbol(",") {
+			break
+		}
+	}
+	return args, p.expectSymbol(")")
+}
+
+// hasColumnRefOutsideAggregate returns true if x references columns not as an argument of an aggregate function
+func hasColumnRefOutsideAggregate(x expr) bool {
+	switch x := x.(type) {
+	case *columnRef:
+		return true
+	case *aggregate:
+		return false
+	case *unaryExpr:
+		return hasColumnRefOutsideAggregate(x.x)
+	case *binaryExpr:
+		return hasColumnRefOutsideAggregate(x.x) || hasColumnRefOutsideAggregate(x.y)
+	case *castExpr:
+		return hasColumnRefOutsideAggregate(x.x)
+	case *isExpr:
+		return hasColumnRefOutsideAggregate(x.x)
+	case *likeExpr:
+		return anyColumnRefOutsideAggregate(x.x, x.pattern, x.escape)
+	case *betweenExpr:
+		return anyColumnRefOutsideAggregate(x.x, x.low, x.high)
+	case *inExpr:
+		return hasColumnRefOutsideAggregate(x.x) || anyColumnRefOutsideAggregate(x.list...)
+	case *funcCall:
+		return anyColumnRefOutsideAggregate(x.args...)
+	}
+	return false
+}
+
+func anyColumnRefOutsideAggregate(xs ...expr) bool {
+	for _, x := range xs {
+		if x != nil && hasColumnRefOutsideAggregate(x) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,33 @@
+package serde
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+// S3Namespace is the XML namespace of S3 request and response bodies
+const S3Namespace = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+// DecodeXML decodes the XML document of r into v, reading every element as in the S3 namespace. Clients do not
+// always set the S3 namespace on request bodies, or set another one, and S3 accepts them.
+func DecodeXML(r io.Reader, v any) error {
+	return xml.NewTokenDecoder(s3NamespaceReader{d: xml.NewDecoder(r)}).Decode(v)
+}
+
+// s3NamespaceReader reads the tokens of d with the name space of every element replaced by the S3 namespace
+type s3NamespaceReader struct {
+	d *xml.Decoder
+}
+
+func (r s3NamespaceReader) Token() (xml.Token, error) {
+	tok, err := r.d.Token()
+	switch t := tok.(type) {
+	case xml.StartElement:
+		t.Name.Space = S3Namespace
+		return t, err
+	case xml.EndElement:
+		t.Name.Space = S3Namespace
+		return t, err
+	}
+	return tok, err
+}
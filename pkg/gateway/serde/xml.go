@@ -3,7 +3,7 @@ package serde
 import "encoding/xml"
 
 const (
-	VersioningResponse = `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"/>`
+	VersioningResponse = `<VersioningConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Status>Enabled</Status></VersioningConfiguration>`
 )
 
 type Error struct {
@@ -174,6 +174,39 @@ type ListMultipartUploadsOutput struct {
 	MaxUploads         int32    `xml:"MaxUploads,omitempty"`
 }
 
+type ObjectVersion struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+	ETag         string `xml:"ETag"`
+	Size         int64  `xml:"Size"`
+	StorageClass string `xml:"StorageClass"`
+}
+
+type DeleteMarkerEntry struct {
+	Key          string `xml:"Key"`
+	VersionID    string `xml:"VersionId"`
+	IsLatest     bool   `xml:"IsLatest"`
+	LastModified string `xml:"LastModified"`
+}
+
+type ListVersionsResult struct {
+	XMLName             xml.Name            `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ListVersionsResult"`
+	Name                string              `xml:"Name"`
+	Prefix              string              `xml:"Prefix"`
+	KeyMarker           string              `xml:"KeyMarker"`
+	VersionIDMarker     string              `xml:"VersionIdMarker"`
+	NextKeyMarker       string              `xml:"NextKeyMarker,omitempty"`
+	NextVersionIDMarker string              `xml:"NextVersionIdMarker,omitempty"`
+	MaxKeys             int                 `xml:"MaxKeys"`
+	Delimiter           string              `xml:"Delimiter,omitempty"`
+	IsTruncated         bool                `xml:"IsTruncated"`
+	Versions            []ObjectVersion     `xml:"Version"`
+	DeleteMarkers       []DeleteMarkerEntry `xml:"DeleteMarker"`
+	CommonPrefixes      []CommonPrefixes    `xml:"CommonPrefixes"`
+}
+
 type VersioningConfiguration struct {
 	Enabled bool `xml:"Enabled,omitempty"`
 }
@@ -192,6 +225,104 @@ type Tagging struct {
 	TagSet  TagSet   `xml:"TagSet"`
 }
 
+type LifecycleAnd struct {
+	Prefix string `xml:"Prefix,omitempty"`
+	Tags   []Tag  `xml:"Tag"`
+}
+
+type LifecycleFilter struct {
+	Prefix string        `xml:"Prefix"`
+	Tag    *Tag          `xml:"Tag,omitempty"`
+	And    *LifecycleAnd `xml:"And,omitempty"`
+}
+
+type LifecycleExpiration struct {
+	Days int    `xml:"Days,omitempty"`
+	Date string `xml:"Date,omitempty"`
+}
+
+type LifecycleRule struct {
+	ID     string           `xml:"ID,omitempty"`
+	Filter *LifecycleFilter `xml:"Filter,omitempty"`
+	// Prefix is the deprecated rule prefix, used when the rule has no Filter
+	Prefix     *string              `xml:"Prefix,omitempty"`
+	Status     string               `xml:"Status"`
+	Expiration *LifecycleExpiration `xml:"Expiration,omitempty"`
+}
+
+type LifecycleConfiguration struct {
+	XMLName xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LifecycleConfiguration"`
+	Rules   []LifecycleRule `xml:"Rule"`
+}
+
+type NotificationFilterRule struct {
+	Name  string `xml:"Name"`
+	Value string `xml:"Value"`
+}
+
+type NotificationKeyFilter struct {
+	FilterRules []NotificationFilterRule `xml:"FilterRule"`
+}
+
+type NotificationFilter struct {
+	Key NotificationKeyFilter `xml:"S3Key"`
+}
+
+type TopicConfiguration struct {
+	ID     string              `xml:"Id,omitempty"`
+	Topic  string              `xml:"Topic"`
+	Events []string            `xml:"Event"`
+	Filter *NotificationFilter `xml:"Filter,omitempty"`
+}
+
+type QueueConfiguration struct {
+	ID     string              `xml:"Id,omitempty"`
+	Queue  string              `xml:"Queue"`
+	Events []string            `xml:"Event"`
+	Filter *NotificationFilter `xml:"Filter,omitempty"`
+}
+
+type CloudFunctionConfiguration struct {
+	ID            string              `xml:"Id,omitempty"`
+	CloudFunction string              `xml:"CloudFunction"`
+	Events        []string            `xml:"Event"`
+	Filter        *NotificationFilter `xml:"Filter,omitempty"`
+}
+
+type NotificationConfiguration struct {
+	XMLName                     xml.Name                     `xml:"http://s3.amazonaws.com/doc/2006-03-01/ NotificationConfiguration"`
+	TopicConfigurations         []TopicConfiguration         `xml:"TopicConfiguration"`
+	QueueConfigurations         []QueueConfiguration         `xml:"QueueConfiguration"`
+	CloudFunctionConfigurations []CloudFunctionConfiguration `xml:"CloudFunctionConfiguration"`
+}
+
+type DefaultRetention struct {
+	Mode  string `xml:"Mode,omitempty"`
+	Days  int    `xml:"Days,omitempty"`
+	Years int    `xml:"Years,omitempty"`
+}
+
+type ObjectLockRule struct {
+	DefaultRetention *DefaultRetention `xml:"DefaultRetention,omitempty"`
+}
+
+type ObjectLockConfiguration struct {
+	XMLName           xml.Name        `xml:"http://s3.amazonaws.com/doc/2006-03-01/ ObjectLockConfiguration"`
+	ObjectLockEnabled string          `xml:"ObjectLockEnabled,omitempty"`
+	Rule              *ObjectLockRule `xml:"Rule,omitempty"`
+}
+
+type Retention struct {
+	XMLName         xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ Retention"`
+	Mode            string   `xml:"Mode,omitempty"`
+	RetainUntilDate string   `xml:"RetainUntilDate,omitempty"`
+}
+
+type LegalHold struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LegalHold"`
+	Status  string   `xml:"Status"`
+}
+
 type LocationResponse struct {
 	XMLName  xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ LocationConstraint"`
 	Location string   `xml:",chardata"`
@@ -45,3 +45,39 @@ func TestMarshal(t *testing.T) {
 		t.Fatalf("expected a buckets array")
 	}
 }
+
+func TestDecodeXML(t *testing.T) {
+	testCases := []struct {
+		name string
+		body string
+	}{
+		{name: "s3 namespace", body: `<Tagging xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><TagSet><Tag><Key>k</Key><Value>v</Value></Tag></TagSet></Tagging>`},
+		{name: "no namespace", body: `<Tagging><TagSet><Tag><Key>k</Key><Value>v</Value></Tag></TagSet></Tagging>`},
+		{name: "other namespace", body: `<Tagging xmlns="urn:example"><TagSet><Tag><Key>k</Key><Value>v</Value></Tag></TagSet></Tagging>`},
+		{name: "prefixed", body: `<s3:Tagging xmlns:s3="http://s3.amazonaws.com/doc/2006-03-01/"><s3:TagSet><s3:Tag><s3:Key>k</s3:Key><s3:Value>v</s3:Value></s3:Tag></s3:TagSet></s3:Tagging>`},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var tagging serde.Tagging
+			if err := serde.DecodeXML(strings.NewReader(tc.body), &tagging); err != nil {
+				t.Fatalf("DecodeXML() error = %s", err)
+			}
+			if len(tagging.TagSet.Tag) != 1 || tagging.TagSet.Tag[0] != (serde.Tag{Key: "k", Value: "v"}) {
+				t.Errorf("DecodeXML() tags = %+v, expected k=v", tagging.TagSet.Tag)
+			}
+		})
+	}
+
+	t.Run("other element", func(t *testing.T) {
+		var tagging serde.Tagging
+		if err := serde.DecodeXML(strings.NewReader(`<LegalHold><Status>ON</Status></LegalHold>`), &tagging); err == nil {
+			t.Error("DecodeXML() expected error decoding another element")
+		}
+	})
+	t.Run("malformed", func(t *testing.T) {
+		var tagging serde.Tagging
+		if err := serde.DecodeXML(strings.NewReader(`<Tagging><TagSet></Tagging>`), &tagging); err == nil {
+			t.Error("DecodeXML() expected error on malformed document")
+		}
+	})
+}
@@ -8,6 +8,7 @@ import (
 	"net/http"
 	"regexp"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	"github.com/treeverse/lakefs/pkg/auth/model"
@@ -109,6 +110,9 @@ func Equal(sig1, sig2 []byte) bool {
 }
 
 func (c *chainedAuthenticator) Verify(creds *model.Credential) error {
+	if creds.IsExpired(time.Now()) {
+		return gwErrors.ErrExpiredToken
+	}
 	return c.chosen.Verify(creds)
 }
 
@@ -211,3 +211,41 @@ func TestAWSSigVerify(t *testing.T) {
 		})
 	}
 }
+
+func TestChainedAuthenticatorExpiredCredentials(t *testing.T) {
+	req := http.Request{
+		Method: http.MethodGet,
+		Host:   domain,
+		URL:    &url.URL{Scheme: "s3", Host: "my-bucket", Path: "my-branch/file"},
+		Header: MakeHeader(map[string]string{
+			"Date":       date.Format(http.TimeFormat),
+			"x-amz-date": date.Format("20060102T150405Z"),
+		}),
+	}
+	signedReq := MakeV4Signer(keyID, secretKey, location)(req)
+
+	testCases := []struct {
+		name           string
+		expirationDate time.Time
+		expectedErr    error
+	}{
+		{name: "no expiration"},
+		{name: "not expired", expirationDate: time.Now().Add(time.Hour)},
+		{name: "expired", expirationDate: time.Now().Add(-time.Second), expectedErr: gwErrors.ErrExpiredToken},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			authenticator := sig.ChainedAuthenticator(sig.NewV4Authenticator(signedReq))
+			if _, err := authenticator.Parse(); err != nil {
+				t.Fatalf("Parse() failed: %s", err)
+			}
+			err := authenticator.Verify(&model.Credential{
+				BaseCredential:   model.BaseCredential{AccessKeyID: keyID, SecretAccessKey: secretKey},
+				CredentialLimits: model.CredentialLimits{ExpirationDate: tc.expirationDate},
+			})
+			if !errors.Is(err, tc.expectedErr) {
+				t.Errorf("Verify() error = %v, expected %v", err, tc.expectedErr)
+			}
+		})
+	}
+}
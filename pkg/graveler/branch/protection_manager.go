@@ -3,6 +3,7 @@ package branch
 import (
 	"context"
 	"errors"
+	"slices"
 	"time"
 
 	"github.com/gobwas/glob"
@@ -42,29 +43,46 @@ func (m *ProtectionManager) SetRules(ctx context.Context, repository *graveler.R
 }
 
 func (m *ProtectionManager) IsBlocked(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, action graveler.BranchProtectionBlockedAction) (bool, error) {
+	blocked := false
+	err := m.forEachMatchingRule(ctx, repository, branchID, func(blockedActions *graveler.BranchProtectionBlockedActions) {
+		if slices.Contains(blockedActions.GetValue(), action) {
+			blocked = true
+		}
+	})
+	return blocked, err
+}
+
+func (m *ProtectionManager) RequiredApprovals(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (int, error) {
+	required := 0
+	err := m.forEachMatchingRule(ctx, repository, branchID, func(blockedActions *graveler.BranchProtectionBlockedActions) {
+		if !slices.Contains(blockedActions.GetValue(), graveler.BranchProtectionBlockedAction_MERGE_WITHOUT_APPROVAL) {
+			return
+		}
+		required = max(required, int(blockedActions.GetRequiredApprovals()), 1)
+	})
+	return required, err
+}
+
+// forEachMatchingRule calls f with the blocked actions of every rule matching branchID
+func (m *ProtectionManager) forEachMatchingRule(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, f func(*graveler.BranchProtectionBlockedActions)) error {
 	rules := &graveler.BranchProtectionRules{}
 	err := m.settingManager.Get(ctx, repository, ProtectionSettingKey, rules)
 	if errors.Is(err, graveler.ErrNotFound) {
-		return false, nil
+		return nil
 	}
 	if err != nil {
-		return false, err
+		return err
 	}
 	for pattern, blockedActions := range rules.BranchPatternToBlockedActions {
 		matcher, err := m.matchers.GetOrSet(pattern, func() (v interface{}, err error) {
 			return glob.Compile(pattern)
 		})
 		if err != nil {
-			return false, err
-		}
-		if !matcher.(glob.Glob).Match(string(branchID)) {
-			continue
+			return err
 		}
-		for _, c := range blockedActions.GetValue() {
-			if c == action {
-				return true, nil
-			}
+		if matcher.(glob.Glob).Match(string(branchID)) {
+			f(blockedActions)
 		}
 	}
-	return false, nil
+	return nil
 }
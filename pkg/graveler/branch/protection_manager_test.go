@@ -135,6 +135,32 @@ func TestIsBlocked(t *testing.T) {
 	}
 }
 
+func TestRequiredApprovals(t *testing.T) {
+	ctx := context.Background()
+	bpm := prepareTest(t, ctx)
+	mergeWithoutApproval := []graveler.BranchProtectionBlockedAction{graveler.BranchProtectionBlockedAction_MERGE_WITHOUT_APPROVAL}
+	testutil.Must(t, bpm.SetRules(ctx, repository, &graveler.BranchProtectionRules{
+		BranchPatternToBlockedActions: map[string]*graveler.BranchProtectionBlockedActions{
+			"main":     {Value: []graveler.BranchProtectionBlockedAction{graveler.BranchProtectionBlockedAction_COMMIT}},
+			"main*":    {Value: mergeWithoutApproval, RequiredApprovals: 2},
+			"mai*":     {Value: mergeWithoutApproval, RequiredApprovals: 3},
+			"stable_*": {Value: mergeWithoutApproval},
+		},
+	}, nil))
+
+	tests := map[string]int{
+		"main":          3,
+		"main2":         3,
+		"stable_branch": 1,
+		"dev":           0,
+	}
+	for branchID, expected := range tests {
+		required, err := bpm.RequiredApprovals(ctx, repository, graveler.BranchID(branchID))
+		testutil.Must(t, err)
+		require.Equal(t, expected, required, "branch %s", branchID)
+	}
+}
+
 func prepareTest(t *testing.T, ctx context.Context) *branch.ProtectionManager {
 	ctrl := gomock.NewController(t)
 	refManager := mock.NewMockRefManager(ctrl)
@@ -248,6 +248,8 @@ func (c *committedManager) Merge(ctx context.Context, storageID graveler.Storage
 
 	mctx := mergeContext{
 		strategy:      strategy,
+		rules:         options.MergeStrategyRules,
+		resolver:      options.ConflictResolver,
 		storageID:     storageID,
 		ns:            ns,
 		destinationID: destination,
@@ -262,6 +264,8 @@ type mergeContext struct {
 	srcIt         Iterator
 	baseIt        Iterator
 	strategy      graveler.MergeStrategy
+	rules         graveler.MergeStrategyRules
+	resolver      graveler.ConflictResolver
 	storageID     graveler.StorageID
 	ns            graveler.StorageNamespace
 	destinationID graveler.MetaRangeID
@@ -309,7 +313,7 @@ func (c *committedManager) merge(ctx context.Context, mctx mergeContext) (gravel
 		}
 	}()
 
-	err = Merge(ctx, mwWriter, baseIt, srcIt, destIt, mctx.strategy)
+	err = merge(ctx, mwWriter, baseIt, srcIt, destIt, mctx.strategy, mctx.rules, mctx.resolver)
 	if err != nil {
 		if !errors.Is(err, graveler.ErrUserVisible) {
 			err = fmt.Errorf("merge ns=%s id=%s: %w", mctx.ns, mctx.destinationID, err)
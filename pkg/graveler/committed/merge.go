@@ -19,6 +19,13 @@ type merger struct {
 	dest                 Iterator
 	haveSource, haveDest bool
 	strategy             graveler.MergeStrategy
+	rules                graveler.MergeStrategyRules
+	resolver             graveler.ConflictResolver
+}
+
+// strategyFor returns the strategy applied to a conflict on key
+func (m *merger) strategyFor(key graveler.Key) graveler.MergeStrategy {
+	return m.rules.StrategyFor(key, m.strategy)
 }
 
 // getNextGEKey moves base iterator from its current position to the next greater equal value
@@ -96,6 +103,32 @@ func (m *merger) writeRecord(writeValue *graveler.ValueRecord) error {
 	return nil
 }
 
+// resolveConflict hands a conflicting key to the configured resolver and writes the resolved
+// value, if any.  base, source and dest are nil when the key does not exist on that side.
+func (m *merger) resolveConflict(key graveler.Key, base, source, dest *graveler.ValueRecord) error {
+	if m.resolver == nil {
+		return graveler.ErrConflictFound
+	}
+	conflict := graveler.MergeConflict{Key: key}
+	if base != nil && bytes.Equal(base.Key, key) {
+		conflict.Base = base.Value
+	}
+	if source != nil {
+		conflict.Source = source.Value
+	}
+	if dest != nil {
+		conflict.Dest = dest.Value
+	}
+	value, err := m.resolver.ResolveConflict(m.ctx, conflict)
+	if err != nil {
+		return fmt.Errorf("resolve conflict on %s: %w", key, err)
+	}
+	if value == nil { // resolved by leaving the key out
+		return nil
+	}
+	return m.writeRecord(&graveler.ValueRecord{Key: key, Value: value})
+}
+
 func (m *merger) destBeforeSource(destValue *graveler.ValueRecord) error {
 	baseValue, err := m.getNextGEKey(destValue.Key)
 	if err != nil {
@@ -105,12 +138,18 @@ func (m *merger) destBeforeSource(destValue *graveler.ValueRecord) error {
 		m.haveDest = m.dest.Next()
 	} else {
 		if baseValue != nil && bytes.Equal(destValue.Key, baseValue.Key) { // deleted by source changed by dest
-			switch m.strategy {
+			switch m.strategyFor(destValue.Key) {
 			case graveler.MergeStrategyDest:
 				break
 			case graveler.MergeStrategySrc:
 				m.haveDest = m.dest.Next()
 				return nil
+			case graveler.MergeStrategyResolve:
+				if err := m.resolveConflict(destValue.Key, baseValue, nil, destValue); err != nil {
+					return err
+				}
+				m.haveDest = m.dest.Next()
+				return nil
 			default: // graveler.MergeStrategyNone
 				return graveler.ErrConflictFound
 			}
@@ -134,12 +173,18 @@ func (m *merger) sourceBeforeDest(sourceValue *graveler.ValueRecord) error {
 		m.haveSource = m.source.Next()
 	} else {
 		if baseValue != nil && bytes.Equal(sourceValue.Key, baseValue.Key) { // deleted by dest and changed by source
-			switch m.strategy {
+			switch m.strategyFor(sourceValue.Key) {
 			case graveler.MergeStrategyDest:
 				m.haveSource = m.source.Next()
 				return nil
 			case graveler.MergeStrategySrc:
 				break
+			case graveler.MergeStrategyResolve:
+				if err := m.resolveConflict(sourceValue.Key, baseValue, sourceValue, nil); err != nil {
+					return err
+				}
+				m.haveSource = m.source.Next()
+				return nil
 			default: // graveler.MergeStrategyNone
 				return graveler.ErrConflictFound
 			}
@@ -195,13 +240,28 @@ func (m *merger) handleAll(iter Iterator, strategyToInclude graveler.MergeStrate
 			if baseValue == nil || !bytes.Equal(baseValue.Identity, iterValue.Identity) {
 				shouldWriteRecord := true
 				if baseValue != nil && bytes.Equal(baseValue.Key, iterValue.Key) { // deleted by one changed by iter
-					if m.strategy == graveler.MergeStrategyNone { // conflict is only reported if no strategy is selected
+					strategy := m.strategyFor(iterValue.Key)
+					switch strategy {
+					case graveler.MergeStrategyNone: // conflict is only reported if no strategy is selected
 						return graveler.ErrConflictFound
-					}
-					// In case of conflict, if the strategy favors the given iter we
-					// still want to write the record. Otherwise, it will be ignored.
-					if m.strategy != strategyToInclude {
+					case graveler.MergeStrategyResolve:
+						// the resolver decides what to write, given the side iter stands for
+						var err error
+						if strategyToInclude == graveler.MergeStrategySrc {
+							err = m.resolveConflict(iterValue.Key, baseValue, iterValue, nil)
+						} else {
+							err = m.resolveConflict(iterValue.Key, baseValue, nil, iterValue)
+						}
+						if err != nil {
+							return err
+						}
 						shouldWriteRecord = false
+					default:
+						// In case of conflict, if the strategy favors the given iter we
+						// still want to write the record. Otherwise, it will be ignored.
+						if strategy != strategyToInclude {
+							shouldWriteRecord = false
+						}
 					}
 				}
 				if shouldWriteRecord {
@@ -299,8 +359,8 @@ func (m *merger) handleBothRanges(sourceRange *Range, destRange *Range) error {
 	return nil
 }
 
-func (m *merger) handleConflict(sourceValue *graveler.ValueRecord, destValue *graveler.ValueRecord) error {
-	switch m.strategy {
+func (m *merger) handleConflict(baseValue, sourceValue, destValue *graveler.ValueRecord) error {
+	switch m.strategyFor(sourceValue.Key) {
 	case graveler.MergeStrategyDest:
 		err := m.writeRecord(destValue)
 		if err != nil {
@@ -311,6 +371,10 @@ func (m *merger) handleConflict(sourceValue *graveler.ValueRecord, destValue *gr
 		if err != nil {
 			return fmt.Errorf("write record: %w", err)
 		}
+	case graveler.MergeStrategyResolve:
+		if err := m.resolveConflict(sourceValue.Key, baseValue, sourceValue, destValue); err != nil {
+			return err
+		}
 	default: // graveler.MergeStrategyNone
 		return graveler.ErrConflictFound
 	}
@@ -341,7 +405,7 @@ func (m *merger) handleBothKeys(sourceValue *graveler.ValueRecord, destValue *gr
 				case bytes.Equal(destValue.Identity, baseValue.Identity):
 					err = m.writeRecord(sourceValue)
 				default: // both changed the same key
-					return m.handleConflict(sourceValue, destValue)
+					return m.handleConflict(baseValue, sourceValue, destValue)
 				}
 				if err != nil {
 					return fmt.Errorf("write record: %w", err)
@@ -350,7 +414,7 @@ func (m *merger) handleBothKeys(sourceValue *graveler.ValueRecord, destValue *gr
 				m.haveDest = m.dest.Next()
 				return nil
 			} else { // both added the same key with different identity
-				return m.handleConflict(sourceValue, destValue)
+				return m.handleConflict(nil, sourceValue, destValue)
 			}
 		}
 		// record hasn't changed or both added the same record
@@ -491,6 +555,21 @@ func (m *merger) validWritingRange(it Iterator) bool {
 }
 
 func Merge(ctx context.Context, writer MetaRangeWriter, base Iterator, source Iterator, destination Iterator, strategy graveler.MergeStrategy) error {
+	return merge(ctx, writer, base, source, destination, strategy, nil, nil)
+}
+
+// MergeWithResolver merges like Merge, handing every conflict to resolver instead of failing
+func MergeWithResolver(ctx context.Context, writer MetaRangeWriter, base Iterator, source Iterator, destination Iterator, resolver graveler.ConflictResolver) error {
+	return merge(ctx, writer, base, source, destination, graveler.MergeStrategyResolve, nil, resolver)
+}
+
+// MergeWithRules merges like Merge, applying the strategy of the first rule matching each
+// conflicting key and strategy to keys no rule matches
+func MergeWithRules(ctx context.Context, writer MetaRangeWriter, base Iterator, source Iterator, destination Iterator, strategy graveler.MergeStrategy, rules graveler.MergeStrategyRules) error {
+	return merge(ctx, writer, base, source, destination, strategy, rules, nil)
+}
+
+func merge(ctx context.Context, writer MetaRangeWriter, base Iterator, source Iterator, destination Iterator, strategy graveler.MergeStrategy, rules graveler.MergeStrategyRules, resolver graveler.ConflictResolver) error {
 	m := merger{
 		ctx:      ctx,
 		logger:   logging.FromContext(ctx),
@@ -499,6 +578,8 @@ func Merge(ctx context.Context, writer MetaRangeWriter, base Iterator, source It
 		source:   source,
 		dest:     destination,
 		strategy: strategy,
+		rules:    rules,
+		resolver: resolver,
 	}
 	return m.merge()
 }
@@ -1777,6 +1777,120 @@ func runMergeTests(tests testCases, t *testing.T) {
 	}
 }
 
+// testResolver resolves conflicts to the side it prefers, falling back to the other side when
+// the preferred one deleted the key, and records the conflicts it saw.
+type testResolver struct {
+	preferSource bool
+	conflicts    []graveler.MergeConflict
+}
+
+func (r *testResolver) Name() string {
+	return "test"
+}
+
+func (r *testResolver) ResolveConflict(_ context.Context, conflict graveler.MergeConflict) (*graveler.Value, error) {
+	r.conflicts = append(r.conflicts, conflict)
+	if r.preferSource && conflict.Source != nil || conflict.Dest == nil {
+		return conflict.Source, nil
+	}
+	return conflict.Dest, nil
+}
+
+func TestMergeWithResolver(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// 'a' changed on both sides, 'b' deleted on dest and changed on source, 'c' unchanged
+	newIterators := func() (committed.Iterator, committed.Iterator, committed.Iterator) {
+		base := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "base", MinKey: committed.Key("a"), MaxKey: committed.Key("c"), Count: 3}).
+			AddValueRecords(makeV("a", "base:a"), makeV("b", "base:b"), makeV("c", "base:c"))
+		source := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a"), MaxKey: committed.Key("c"), Count: 3}).
+			AddValueRecords(makeV("a", "source:a"), makeV("b", "source:b"), makeV("c", "base:c"))
+		dest := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a"), MaxKey: committed.Key("c"), Count: 2}).
+			AddValueRecords(makeV("a", "dest:a"), makeV("c", "base:c"))
+		return base, source, dest
+	}
+
+	t.Run("prefer_source", func(t *testing.T) {
+		writer := mock.NewMockMetaRangeWriter(ctrl)
+		gomock.InOrder(
+			writer.EXPECT().WriteRecord(newRecordMatcher("a", "source:a")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("b", "source:b")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("c", "base:c")),
+		)
+		resolver := &testResolver{preferSource: true}
+		base, source, dest := newIterators()
+		err := committed.MergeWithResolver(context.Background(), writer, base, source, dest, resolver)
+		assert.NoError(t, err)
+		assert.Len(t, resolver.conflicts, 2)
+		assert.Equal(t, []byte("base:a"), resolver.conflicts[0].Base.Identity)
+		assert.Nil(t, resolver.conflicts[1].Dest)
+	})
+
+	t.Run("prefer_dest", func(t *testing.T) {
+		writer := mock.NewMockMetaRangeWriter(ctrl)
+		gomock.InOrder(
+			writer.EXPECT().WriteRecord(newRecordMatcher("a", "dest:a")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("b", "source:b")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("c", "base:c")),
+		)
+		resolver := &testResolver{}
+		base, source, dest := newIterators()
+		err := committed.MergeWithResolver(context.Background(), writer, base, source, dest, resolver)
+		assert.NoError(t, err)
+		assert.Len(t, resolver.conflicts, 2)
+	})
+}
+
+func TestMergeWithRules(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	// every key changed differently on both sides
+	newIterators := func() (committed.Iterator, committed.Iterator, committed.Iterator) {
+		base := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "base", MinKey: committed.Key("a/1"), MaxKey: committed.Key("c/1"), Count: 3}).
+			AddValueRecords(makeV("a/1", "base:a"), makeV("b/1", "base:b"), makeV("c/1", "base:c"))
+		source := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "source", MinKey: committed.Key("a/1"), MaxKey: committed.Key("c/1"), Count: 3}).
+			AddValueRecords(makeV("a/1", "source:a"), makeV("b/1", "source:b"), makeV("c/1", "source:c"))
+		dest := testutil.NewFakeIterator().
+			AddRange(&committed.Range{ID: "dest", MinKey: committed.Key("a/1"), MaxKey: committed.Key("c/1"), Count: 3}).
+			AddValueRecords(makeV("a/1", "dest:a"), makeV("b/1", "dest:b"), makeV("c/1", "dest:c"))
+		return base, source, dest
+	}
+
+	ruleA, err := graveler.NewMergeStrategyRule("a/", graveler.MergeStrategySrcWinsStr)
+	assert.NoError(t, err)
+	ruleB, err := graveler.NewMergeStrategyRule("b/*", graveler.MergeStrategyDestWinsStr)
+	assert.NoError(t, err)
+	rules := graveler.MergeStrategyRules{ruleA, ruleB}
+
+	t.Run("rules", func(t *testing.T) {
+		writer := mock.NewMockMetaRangeWriter(ctrl)
+		gomock.InOrder(
+			writer.EXPECT().WriteRecord(newRecordMatcher("a/1", "source:a")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("b/1", "dest:b")),
+			writer.EXPECT().WriteRecord(newRecordMatcher("c/1", "source:c")),
+		)
+		base, source, dest := newIterators()
+		err := committed.MergeWithRules(context.Background(), writer, base, source, dest, graveler.MergeStrategySrc, rules)
+		assert.NoError(t, err)
+	})
+
+	t.Run("unmatched_conflict", func(t *testing.T) {
+		// keys no rule matches fall back to the merge strategy
+		writer := mock.NewMockMetaRangeWriter(ctrl)
+		writer.EXPECT().WriteRecord(gomock.Any()).AnyTimes()
+		base, source, dest := newIterators()
+		err := committed.MergeWithRules(context.Background(), writer, base, source, dest, graveler.MergeStrategyNone, rules)
+		assert.ErrorIs(t, err, graveler.ErrConflictFound)
+	})
+}
+
 func TestMergeCancelContext(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	defer ctrl.Finish()
@@ -0,0 +1,85 @@
+package graveler
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// conflictRecorder wraps a ConflictResolver and keeps every resolution it makes, so that
+// they can be written into the merge commit metadata for later audit.
+type conflictRecorder struct {
+	resolver    ConflictResolver
+	mu          sync.Mutex
+	resolutions []ConflictResolution
+}
+
+func newConflictRecorder(resolver ConflictResolver) *conflictRecorder {
+	return &conflictRecorder{resolver: resolver}
+}
+
+func (r *conflictRecorder) Name() string {
+	return r.resolver.Name()
+}
+
+func (r *conflictRecorder) ResolveConflict(ctx context.Context, conflict MergeConflict) (*Value, error) {
+	value, err := r.resolver.ResolveConflict(ctx, conflict)
+	if err != nil {
+		return nil, err
+	}
+	resolution := ConflictResolution{Key: conflict.Key.String()}
+	switch {
+	case value == nil:
+		resolution.Resolution = ConflictResolutionRemoved
+	case conflict.Source != nil && bytes.Equal(value.Identity, conflict.Source.Identity):
+		resolution.Resolution = ConflictResolutionSource
+	case conflict.Dest != nil && bytes.Equal(value.Identity, conflict.Dest.Identity):
+		resolution.Resolution = ConflictResolutionDest
+	default:
+		resolution.Resolution = ConflictResolutionValue
+	}
+	if value != nil {
+		resolution.Identity = fmt.Sprintf("%x", value.Identity)
+	}
+	r.mu.Lock()
+	r.resolutions = append(r.resolutions, resolution)
+	r.mu.Unlock()
+	return value, nil
+}
+
+// Resolutions returns the resolutions recorded so far
+func (r *conflictRecorder) Resolutions() []ConflictResolution {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]ConflictResolution(nil), r.resolutions...)
+}
+
+// setMetadata records the resolver and its resolutions on merge commit metadata
+func (r *conflictRecorder) setMetadata(metadata Metadata) error {
+	resolutions := r.Resolutions()
+	if resolutions == nil {
+		resolutions = []ConflictResolution{}
+	}
+	data, err := json.Marshal(resolutions)
+	if err != nil {
+		return fmt.Errorf("marshal conflict resolutions: %w", err)
+	}
+	metadata[MergeResolverMetadataKey] = r.Name()
+	metadata[MergeResolutionsMetadataKey] = string(data)
+	return nil
+}
+
+// MergeResolutions returns the conflict resolutions recorded on a merge commit, if any
+func MergeResolutions(metadata Metadata) ([]ConflictResolution, error) {
+	data, ok := metadata[MergeResolutionsMetadataKey]
+	if !ok {
+		return nil, nil
+	}
+	var resolutions []ConflictResolution
+	if err := json.Unmarshal([]byte(data), &resolutions); err != nil {
+		return nil, fmt.Errorf("unmarshal conflict resolutions: %w", err)
+	}
+	return resolutions, nil
+}
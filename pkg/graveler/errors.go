@@ -19,12 +19,14 @@ var (
 	ErrWriteToProtectedBranch       = wrapError(ErrProtectedBranch, "cannot write to protected branch")
 	ErrReadingFromStore             = errors.New("cannot read from store")
 	ErrCommitToProtectedBranch      = wrapError(ErrProtectedBranch, "cannot commit to protected branch")
+	ErrMergeToProtectedBranch       = wrapError(ErrProtectedBranch, "merge to protected branch requires an approved pull request")
 	ErrInvalidValue                 = fmt.Errorf("invalid value: %w", ErrInvalid)
 	ErrInvalidMergeBase             = fmt.Errorf("only 2 commits allowed in FindMergeBase: %w", ErrInvalidValue)
 	ErrNoCommitGeneration           = errors.New("no commit generation")
 	ErrNoMergeBase                  = errors.New("no merge base")
 	ErrInvalidMergeStrategy         = wrapError(ErrUserVisible, "invalid merge strategy")
 	ErrInvalidRef                   = fmt.Errorf("ref: %w", ErrInvalidValue)
+	ErrCommitDatesOutOfOrder        = fmt.Errorf("commit creation dates out of order: %w", ErrInvalidRef)
 	ErrInvalidCommitID              = fmt.Errorf("commit id: %w", ErrInvalidValue)
 	ErrInvalidBranchID              = fmt.Errorf("branch id: %w", ErrInvalidValue)
 	ErrInvalidTagID                 = fmt.Errorf("tag id: %w", ErrInvalidValue)
@@ -66,6 +68,17 @@ var (
 	ErrPullRequestExists            = fmt.Errorf("pull request already exists: %w", ErrNotUnique)
 	ErrInvalidPullRequestStatus     = fmt.Errorf("invalid pull request status: %w", ErrInvalid)
 	ErrInvalidPullRequestID         = fmt.Errorf("pull request id: %w", ErrInvalidValue)
+	ErrPullRequestNotOpen           = fmt.Errorf("pull request not open: %w", ErrInvalidValue)
+	ErrPullRequestSelfReview        = fmt.Errorf("pull request author cannot approve or request changes: %w", ErrInvalidValue)
+	ErrPullRequestSourceMoved       = fmt.Errorf("pull request source branch moved since the reviewed commit: %w", ErrPreconditionFailed)
+	ErrInvalidPullRequestReview     = fmt.Errorf("invalid pull request review state: %w", ErrInvalidValue)
+	ErrPullRequestCommentNotFound   = fmt.Errorf("pull request comment %w", ErrNotFound)
+	ErrInvalidPullRequestCommentID  = fmt.Errorf("pull request comment id: %w", ErrInvalidValue)
+	ErrInvalidPullRequestComment    = fmt.Errorf("invalid pull request comment: %w", ErrInvalidValue)
+	ErrPullRequestCommentNotAuthor  = wrapError(ErrUserVisible, "only the author can edit a pull request comment")
+	ErrObjectLocked                 = wrapError(ErrUserVisible, "object is locked by a retention period or legal hold")
+	ErrBranchHasLockedObjects       = wrapError(ErrObjectLocked, "branch has objects locked by a retention period or legal hold")
+	ErrObjectLockNotEnabled         = fmt.Errorf("object lock is not enabled for the repository: %w", ErrInvalidValue)
 )
 
 // wrappedError is an error for wrapping another error while ignoring its message.
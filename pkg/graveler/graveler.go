@@ -66,11 +66,15 @@ const (
 	RefModTypeCaret  RefModType = '^'
 	RefModTypeAt     RefModType = '@'
 	RefModTypeDollar RefModType = '$'
+	// RefModTypeTime selects the commit of the ref at a point in time, written ref@{time}
+	RefModTypeTime RefModType = '{'
 )
 
 type RefModifier struct {
 	Type  RefModType
 	Value int
+	// Time is the point in time of a RefModTypeTime modifier
+	Time time.Time
 }
 
 // RawRef is a parsed Ref that includes 'BaseRef' that holds the branch/tag/hash and a list of
@@ -126,11 +130,15 @@ const (
 	MergeStrategyNone MergeStrategy = iota
 	MergeStrategyDest
 	MergeStrategySrc
+	MergeStrategyResolve
 	MergeStrategyNoneStr     = "default"
 	MergeStrategyDestWinsStr = "dest-wins"
 	MergeStrategySrcWinsStr  = "source-wins"
+	MergeStrategyResolveStr  = "resolve"
 
-	MergeStrategyMetadataKey = ".lakefs.merge.strategy"
+	MergeStrategyMetadataKey    = ".lakefs.merge.strategy"
+	MergeResolverMetadataKey    = ".lakefs.merge.resolver"
+	MergeResolutionsMetadataKey = ".lakefs.merge.resolutions"
 )
 
 // mergeStrategyString String representation for MergeStrategy consts. Pay attention to the order!
@@ -138,6 +146,40 @@ var mergeStrategyString = []string{
 	MergeStrategyNoneStr,
 	MergeStrategyDestWinsStr,
 	MergeStrategySrcWinsStr,
+	MergeStrategyResolveStr,
+}
+
+// MergeConflict describes a single key that was changed differently on the source and the
+// destination of a merge.  Base, Source and Dest are nil when the key does not exist on that side.
+type MergeConflict struct {
+	Key    Key
+	Base   *Value
+	Source *Value
+	Dest   *Value
+}
+
+// ConflictResolver resolves merge conflicts one key at a time
+type ConflictResolver interface {
+	// Name identifies the resolver in the merge commit metadata
+	Name() string
+	// ResolveConflict returns the value to write for the conflicting key, or nil to leave the
+	// key out of the merge result.  Returning ErrConflictFound leaves the conflict unresolved
+	// and fails the merge.
+	ResolveConflict(ctx context.Context, conflict MergeConflict) (*Value, error)
+}
+
+const (
+	ConflictResolutionSource  = "source"
+	ConflictResolutionDest    = "destination"
+	ConflictResolutionRemoved = "removed"
+	ConflictResolutionValue   = "value"
+)
+
+// ConflictResolution records how a ConflictResolver resolved a single key
+type ConflictResolution struct {
+	Key        string `json:"key"`
+	Resolution string `json:"resolution"`
+	Identity   string `json:"identity,omitempty"`
 }
 
 // MetaRangeAddress is the URI of a metarange file.
@@ -186,8 +228,14 @@ func WithStageOnly(v bool) GetOptionsFunc {
 	}
 }
 
+// ConditionFunc checks the current value of a key before it is set, nil if the key does not exist.
+// Returning an error fails the set with that error.
+type ConditionFunc func(currentValue *Value) error
+
 type SetOptions struct {
 	IfAbsent bool
+	// Condition when set, is checked against the current value of the key and the set happens only if it passes.
+	Condition ConditionFunc
 	// MaxTries set number of times we try to perform the operation before we fail with BranchWriteMaxTries.
 	// By default, 0 - we try BranchWriteMaxTries
 	MaxTries int
@@ -200,6 +248,12 @@ type SetOptions struct {
 	// SquashMerge causes merge commits to be "squashed", losing parent
 	// information about the merged-from branch.
 	SquashMerge bool
+	// ConflictResolver when set, resolves merge conflicts key by key instead of failing the merge.
+	ConflictResolver ConflictResolver
+	// MergeStrategyRules override the merge strategy for conflicts on matching keys.
+	MergeStrategyRules MergeStrategyRules
+	// PullRequestID is the pull request a merge comes through, checked against branch protection approval rules.
+	PullRequestID PullRequestID
 }
 
 type SetOptionsFunc func(opts *SetOptions)
@@ -218,6 +272,12 @@ func WithIfAbsent(v bool) SetOptionsFunc {
 	}
 }
 
+func WithCondition(f ConditionFunc) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.Condition = f
+	}
+}
+
 func WithForce(v bool) SetOptionsFunc {
 	return func(opts *SetOptions) {
 		opts.Force = v
@@ -242,6 +302,24 @@ func WithSquashMerge(v bool) SetOptionsFunc {
 	}
 }
 
+func WithConflictResolver(v ConflictResolver) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.ConflictResolver = v
+	}
+}
+
+func WithMergeStrategyRules(v MergeStrategyRules) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.MergeStrategyRules = v
+	}
+}
+
+func WithPullRequest(v PullRequestID) SetOptionsFunc {
+	return func(opts *SetOptions) {
+		opts.PullRequestID = v
+	}
+}
+
 // ListOptions controls list request defaults
 type ListOptions struct {
 	// Shows entities marked as hidden
@@ -304,6 +382,10 @@ type RangeID string
 // ImportID represents an import process id in the ref-store
 type ImportID string
 
+// PullRequestMetadataKey records on a merge commit the pull request it merged, linking the commit to the
+// pull request reviews and comments
+const PullRequestMetadataKey = ".lakefs.pull_request"
+
 type PullRequestID string
 
 func (id PullRequestID) String() string {
@@ -327,6 +409,28 @@ type PullRequest struct {
 	MergedCommitID *string
 	// ClosedDate - Closing date of pull request. Relevant only for closed or merged PRs
 	ClosedDate *time.Time
+	// Reviews - all reviews of the pull request, oldest first
+	Reviews []PullRequestReview
+}
+
+type PullRequestReview struct {
+	Reviewer     string
+	State        PullRequestReviewState
+	Comment      string
+	CreationDate time.Time
+	// CommitID - head of the source branch when reviewed
+	CommitID CommitID
+}
+
+// ReviewDecisions returns the latest approval or change request of each reviewer, ignoring comments
+func (pr *PullRequest) ReviewDecisions() map[string]PullRequestReview {
+	decisions := make(map[string]PullRequestReview)
+	for _, review := range pr.Reviews {
+		if review.State != PullRequestReviewState_COMMENTED {
+			decisions[review.Reviewer] = review
+		}
+	}
+	return decisions
 }
 
 type PullRequestRecord struct {
@@ -341,6 +445,38 @@ type UpdatePullRequest struct {
 	MergedCommitID *string
 }
 
+type PullRequestCommentID string
+
+func (id PullRequestCommentID) String() string {
+	return string(id)
+}
+
+// PullCommentUpdateFunc Used to pass validation call back to ref manager for UpdatePullRequestComment flow
+type PullCommentUpdateFunc func(comment *PullRequestComment) (*PullRequestComment, error)
+
+type PullRequestComment struct {
+	ID     PullRequestCommentID
+	Author string
+	Body   string
+	// Path - object path in the pull request diff the comment discusses, empty for the whole pull request
+	Path string
+	// ReplyTo - first comment of the thread, empty for the first comment of a thread
+	ReplyTo      PullRequestCommentID
+	CreationDate time.Time
+	// UpdateDate - last edit date. Relevant only for edited comments
+	UpdateDate *time.Time
+	// Resolved - whether the thread is resolved. Relevant only for the first comment of a thread
+	Resolved   bool
+	ResolvedBy string
+}
+
+type UpdatePullRequestComment struct {
+	// Editor - user making the update, only the author may change the body
+	Editor   string
+	Body     *string
+	Resolved *bool
+}
+
 type ImportStatus struct {
 	ID          ImportID
 	Completed   bool
@@ -525,6 +661,9 @@ type BranchRecord struct {
 // BranchUpdateFunc Used to pass validation call back to ref manager for UpdateBranch flow
 type BranchUpdateFunc func(*Branch) (*Branch, error)
 
+// BranchCommitUpdateFunc is a BranchUpdateFunc that also returns the new commit of the updated branch
+type BranchCommitUpdateFunc func(*Branch) (*Branch, *Commit, error)
+
 // ValueUpdateFunc Used to pass validation call back to staging manager for UpdateValue flow
 type ValueUpdateFunc func(*Value) (*Value, error)
 
@@ -698,7 +837,8 @@ type VersionController interface {
 	// Reset throws all staged data on the repository / branch
 	Reset(ctx context.Context, repository *RepositoryRecord, branchID BranchID, opts ...SetOptionsFunc) error
 
-	// ResetKey throws all staged data under the specified key on the repository / branch
+	// ResetKey throws all staged data under the specified key on the repository / branch.  With a condition option
+	// it resets only if the uncommitted value of the key passes the condition, failing otherwise.
 	ResetKey(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key, opts ...SetOptionsFunc) error
 
 	// ResetPrefix throws all staged data starting with the given prefix on the repository / branch
@@ -713,6 +853,9 @@ type VersionController interface {
 	// Merge merges 'source' into 'destination' and returns the commit id for the created merge commit.
 	Merge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, commitParams CommitParams, strategy string, opts ...SetOptionsFunc) (CommitID, error)
 
+	// PreviewMerge returns the changes and a page of the conflicts merging 'source' into 'destination' with 'strategy' would produce, without merging
+	PreviewMerge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, strategy string, after Key, amount int, opts ...SetOptionsFunc) (*MergePreview, error)
+
 	// Import creates a merge-commit in the destination branch using the source MetaRangeID, overriding any destination
 	// range keys that have the same prefix as the source range keys.
 	Import(ctx context.Context, repository *RepositoryRecord, destination BranchID, source MetaRangeID, commitParams CommitParams, prefixes []Prefix, opts ...SetOptionsFunc) (CommitID, error)
@@ -823,6 +966,22 @@ type Collaborator interface {
 
 	// UpdatePullRequest update pull request in the given repository
 	UpdatePullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, update *UpdatePullRequest) error
+
+	// ReviewPullRequest adds a review of review.CommitID to an open pull request, if it is still the
+	// source branch head
+	ReviewPullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, review *PullRequestReview) error
+
+	// GetPullRequestComment returns a pull request comment by ID
+	GetPullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID) (*PullRequestComment, error)
+
+	// CreatePullRequestComment adds a comment to a pull request, starting a new thread or replying to one
+	CreatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, comment *PullRequestComment) (PullRequestCommentID, error)
+
+	// ListPullRequestComments lists the comments of a pull request, oldest first
+	ListPullRequestComments(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID) (PullCommentsIterator, error)
+
+	// UpdatePullRequestComment edits a pull request comment or resolves its thread
+	UpdatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID, update *UpdatePullRequestComment) error
 }
 
 // Internal structures used by Graveler
@@ -906,6 +1065,14 @@ type PullsIterator interface {
 	Close()
 }
 
+type PullCommentsIterator interface {
+	Next() bool
+	SeekGE(id PullRequestCommentID)
+	Value() *PullRequestComment
+	Err() error
+	Close()
+}
+
 // These are the more complex internal components that compose the functionality of the Graveler
 
 // RefManager handles references: branches, commits, probably tags in the future
@@ -950,6 +1117,11 @@ type RefManager interface {
 	// BranchUpdate Conditional set of branch with validation callback
 	BranchUpdate(ctx context.Context, repository *RepositoryRecord, branchID BranchID, f BranchUpdateFunc) error
 
+	// CommitBranchUpdate is BranchUpdate for updates that add a commit: the commit returned by the callback becomes the
+	// commit of the updated branch, and both are written in a single transaction where the kv store supports one.  It
+	// returns the ID of the commit.
+	CommitBranchUpdate(ctx context.Context, repository *RepositoryRecord, branchID BranchID, f BranchCommitUpdateFunc) (CommitID, error)
+
 	// DeleteBranch deletes the branch
 	DeleteBranch(ctx context.Context, repository *RepositoryRecord, branchID BranchID) error
 
@@ -1012,9 +1184,18 @@ type RefManager interface {
 
 	CreatePullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, pullRequest *PullRequest) error
 
+	// DeletePullRequest deletes a pull request and its comments
 	DeletePullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID) error
 
 	UpdatePullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, f PullUpdateFunc) error
+
+	GetPullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID) (*PullRequestComment, error)
+
+	ListPullRequestComments(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID) (PullCommentsIterator, error)
+
+	CreatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, comment *PullRequestComment) error
+
+	UpdatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID, f PullCommentUpdateFunc) error
 }
 
 // CommittedManager reads and applies committed snapshots
@@ -1173,6 +1354,7 @@ type Graveler struct {
 	RefManager               RefManager
 	StagingManager           StagingManager
 	protectedBranchesManager ProtectedBranchesManager
+	objectLockManager        ObjectLockManager
 	garbageCollectionManager GarbageCollectionManager
 	// logger *without context* to be used for logging.  It should be
 	// avoided in favour of g.log(ctx) in any operation where context is
@@ -1193,6 +1375,7 @@ func NewGraveler(committedManager CommittedManager, stagingManager StagingManage
 		StagingManager:           stagingManager,
 		BranchUpdateBackOff:      branchUpdateBackOff,
 		protectedBranchesManager: protectedBranchesManager,
+		objectLockManager:        ObjectLockNoOp{},
 		garbageCollectionManager: gcManager,
 		logger:                   logging.ContextUnavailable().WithField("service_name", "graveler_graveler"),
 		deleteSensor:             deleteSensor,
@@ -1593,6 +1776,9 @@ func (g *Graveler) DeleteBranch(ctx context.Context, repository *RepositoryRecor
 	if repository.DefaultBranchID == branchID {
 		return ErrDeleteDefaultBranch
 	}
+	if err := g.checkNoLocks(ctx, repository, branchID); err != nil {
+		return err
+	}
 	branch, err := g.RefManager.GetBranch(ctx, repository, branchID)
 	if err != nil {
 		return err
@@ -1820,9 +2006,15 @@ func (g *Graveler) Set(ctx context.Context, repository *RepositoryRecord, branch
 	if repository.ReadOnly && !options.Force {
 		return ErrReadOnlyRepository
 	}
+	if err := g.checkNotLocked(ctx, repository, branchID, key); err != nil {
+		return err
+	}
 
 	log := g.log(ctx).WithFields(logging.Fields{"key": key, "operation": "set"})
 	err = g.safeBranchWrite(ctx, log, repository, branchID, safeBranchWriteOptions{MaxTries: options.MaxTries}, func(branch *Branch) error {
+		if options.Condition != nil {
+			return g.setIfCondition(ctx, repository, branch, branchID, key, value, options)
+		}
 		if !options.IfAbsent {
 			return g.StagingManager.Set(ctx, branch.StagingToken, key, &value, false)
 		}
@@ -1847,6 +2039,47 @@ func (g *Graveler) Set(ctx context.Context, repository *RepositoryRecord, branch
 	return err
 }
 
+// setIfCondition stages value for key only if the current value of key passes options.Condition (and is absent
+// when options.IfAbsent is set).  The condition is checked again against the staged value as part of the staging
+// update, so a concurrent write to the key fails the set with ErrPreconditionFailed.
+func (g *Graveler) setIfCondition(ctx context.Context, repository *RepositoryRecord, branch *Branch, branchID BranchID, key Key, value Value, options *SetOptions) error {
+	check := func(currentValue *Value) error {
+		if options.IfAbsent && currentValue != nil {
+			return ErrPreconditionFailed
+		}
+		return options.Condition(currentValue)
+	}
+
+	currentValue, err := g.Get(ctx, repository, Ref(branchID), key)
+	if errors.Is(err, ErrNotFound) {
+		currentValue = nil
+	} else if err != nil {
+		return err
+	}
+	if err := check(currentValue); err != nil {
+		return err
+	}
+
+	err = g.StagingManager.Update(ctx, branch.StagingToken, key, func(stagedValue *Value) (*Value, error) {
+		if stagedValue == nil {
+			// nothing staged, the value checked above is current
+			return &value, nil
+		}
+		if stagedValue.Identity == nil {
+			// tombstone
+			stagedValue = nil
+		}
+		if err := check(stagedValue); err != nil {
+			return nil, err
+		}
+		return &value, nil
+	})
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		return fmt.Errorf("%w: concurrent write: %w", ErrPreconditionFailed, err)
+	}
+	return err
+}
+
 // safeBranchWrite repeatedly attempts to perform stagingOperation, retrying
 // if the staging token changes during the write.  It never backs off.
 func (g *Graveler) safeBranchWrite(ctx context.Context, log logging.Logger, repository *RepositoryRecord, branchID BranchID,
@@ -1949,6 +2182,9 @@ func (g *Graveler) Delete(ctx context.Context, repository *RepositoryRecord, bra
 	if repository.ReadOnly && !options.Force {
 		return ErrReadOnlyRepository
 	}
+	if err := g.checkNotLocked(ctx, repository, branchID, key); err != nil {
+		return err
+	}
 
 	log := g.log(ctx).WithFields(logging.Fields{"key": key, "operation": "delete"})
 	err = g.safeBranchWrite(ctx, log, repository, branchID,
@@ -1978,10 +2214,21 @@ func (g *Graveler) DeleteBatch(ctx context.Context, repository *RepositoryRecord
 		return fmt.Errorf("keys length (%d) passed the maximum allowed(%d): %w", len(keys), DeleteKeysMaxSize, ErrInvalidValue)
 	}
 
+	// locked keys fail without failing the rest of the batch
+	var lockedErr *multierror.Error
+	unlockedKeys := make([]Key, 0, len(keys))
+	for _, key := range keys {
+		if err := g.checkNotLocked(ctx, repository, branchID, key); err != nil {
+			lockedErr = multierror.Append(lockedErr, &DeleteError{Key: key, Err: err})
+			continue
+		}
+		unlockedKeys = append(unlockedKeys, key)
+	}
+
 	var m *multierror.Error
 	log := g.log(ctx).WithField("operation", "delete_keys")
 	err = g.safeBranchWrite(ctx, log, repository, branchID, safeBranchWriteOptions{}, func(branch *Branch) error {
-		for _, key := range keys {
+		for _, key := range unlockedKeys {
 			err := g.deleteUnsafe(ctx, repository, key, BranchRecord{branchID, branch})
 			if err != nil {
 				m = multierror.Append(m, &DeleteError{Key: key, Err: err})
@@ -1989,9 +2236,73 @@ func (g *Graveler) DeleteBatch(ctx context.Context, repository *RepositoryRecord
 		}
 		return m.ErrorOrNil()
 	}, "delete_keys")
+	if lockedErr != nil {
+		var merr *multierror.Error
+		if err != nil && !errors.As(err, &merr) {
+			return err
+		}
+		return multierror.Append(lockedErr, merr.WrappedErrors()...).ErrorOrNil()
+	}
 	return err
 }
 
+// checkNoLocks returns ErrBranchHasLockedObjects if any object on branchID is locked
+func (g *Graveler) checkNoLocks(ctx context.Context, repository *RepositoryRecord, branchID BranchID) error {
+	hasLocks, err := g.objectLockManager.HasLocks(ctx, repository, branchID)
+	if err != nil {
+		return err
+	}
+	if hasLocks {
+		return ErrBranchHasLockedObjects
+	}
+	return nil
+}
+
+// checkNotLocked returns ErrObjectLocked if the object key on branchID is locked
+func (g *Graveler) checkNotLocked(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key) error {
+	locked, err := g.objectLockManager.IsLocked(ctx, repository, branchID, key)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return ErrObjectLocked
+	}
+	return nil
+}
+
+// checkChangesNotLocked returns ErrObjectLocked if any key under prefix changed by the diff returned by changes is
+// locked on branchID.  The diff is only computed if the branch has locked objects.
+func (g *Graveler) checkChangesNotLocked(ctx context.Context, repository *RepositoryRecord, branchID BranchID, prefix Key, changes func() (DiffIterator, error)) error {
+	hasLocks, err := g.objectLockManager.HasLocks(ctx, repository, branchID)
+	if err != nil || !hasLocks {
+		return err
+	}
+	it, err := changes()
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	it.SeekGE(prefix)
+	for it.Next() {
+		key := it.Value().Key
+		if !bytes.HasPrefix(key, prefix) {
+			break
+		}
+		if err := g.checkNotLocked(ctx, repository, branchID, key); err != nil {
+			return fmt.Errorf("%s: %w", key, err)
+		}
+	}
+	return it.Err()
+}
+
+// checkMetaRangeChangesNotLocked returns ErrObjectLocked if any key that differs between metaRangeID, the current
+// metarange of branchID, and newMetaRangeID is locked on branchID
+func (g *Graveler) checkMetaRangeChangesNotLocked(ctx context.Context, repository *RepositoryRecord, branchID BranchID, metaRangeID, newMetaRangeID MetaRangeID) error {
+	return g.checkChangesNotLocked(ctx, repository, branchID, nil, func() (DiffIterator, error) {
+		return g.CommittedManager.Diff(ctx, repository.StorageID, repository.StorageNamespace, metaRangeID, newMetaRangeID)
+	})
+}
+
 func (g *Graveler) deleteUnsafe(ctx context.Context, repository *RepositoryRecord, key Key, branchRecord BranchRecord) error {
 	// First attempt to update on staging token
 	err := g.deleteAndNotify(ctx, repository.RepositoryID, branchRecord, key, true)
@@ -2173,7 +2484,7 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 		return "", err
 	}
 
-	err = g.retryBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
+	newCommitID, err = g.retryCommitBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, *Commit, error) {
 		if branch.CommitID != "" {
 			commit.Parents = CommitParents{branch.CommitID}
 		}
@@ -2189,7 +2500,7 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 				Commit:     commit,
 			})
 			if err != nil {
-				return nil, &HookAbortError{
+				return nil, nil, &HookAbortError{
 					EventType: EventTypePreCommit,
 					RunID:     preRunID,
 					Err:       err,
@@ -2202,7 +2513,7 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 		if branch.CommitID != "" {
 			branchCommit, err := g.RefManager.GetCommit(ctx, repository, branch.CommitID)
 			if err != nil {
-				return nil, fmt.Errorf("get commit: %w", err)
+				return nil, nil, fmt.Errorf("get commit: %w", err)
 			}
 			branchMetaRangeID = branchCommit.MetaRangeID
 			parentGeneration = int32(branchCommit.Generation)
@@ -2211,10 +2522,10 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 		if params.SourceMetaRange != nil {
 			empty, err := g.isSealedEmpty(ctx, repository, branch)
 			if err != nil {
-				return nil, fmt.Errorf("checking empty sealed: %w", err)
+				return nil, nil, fmt.Errorf("checking empty sealed: %w", err)
 			}
 			if !empty {
-				return nil, ErrCommitMetaRangeDirtyBranch
+				return nil, nil, ErrCommitMetaRangeDirtyBranch
 			}
 			commit.MetaRangeID = *params.SourceMetaRange
 		} else {
@@ -2222,7 +2533,7 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 			if err != nil {
 				// we can fail early in no changes and we don't allow empty commits.
 				if !errors.Is(err, ErrNoChanges) || !params.AllowEmpty {
-					return nil, err
+					return nil, nil, err
 				}
 				// in case we allow empty commits, we need to pass empty iterator to the commit manager.
 				changes = NewEmptyValueIterator()
@@ -2231,20 +2542,13 @@ func (g *Graveler) Commit(ctx context.Context, repository *RepositoryRecord, bra
 			// returns err if the commit is empty (no changes)
 			commit.MetaRangeID, _, err = g.CommittedManager.Commit(ctx, repository.StorageID, storageNamespace, branchMetaRangeID, changes, params.AllowEmpty)
 			if err != nil {
-				return nil, fmt.Errorf("commit: %w", err)
+				return nil, nil, fmt.Errorf("commit: %w", err)
 			}
 		}
 		sealedToDrop = branch.SealedTokens
 
-		// add commit
-		newCommitID, err = g.RefManager.AddCommit(ctx, repository, commit)
-		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
-		}
-
-		branch.CommitID = newCommitID
 		branch.SealedTokens = make([]StagingToken, 0)
-		return branch, nil
+		return branch, &commit, nil
 	}, "commit")
 	if err != nil {
 		return "", err
@@ -2289,6 +2593,24 @@ func (g *Graveler) CreateCommitRecord(ctx context.Context, repository *Repositor
 // BranchUpdateMaxInterval.  It returns the number of times it tried --
 // between 1 and BranchUpdateMaxTries.
 func (g *Graveler) retryBranchUpdate(ctx context.Context, repository *RepositoryRecord, branchID BranchID, f BranchUpdateFunc, operation string) error {
+	return g.retryUpdate(ctx, repository, branchID, operation, func() error {
+		return g.RefManager.BranchUpdate(ctx, repository, branchID, f)
+	})
+}
+
+// retryCommitBranchUpdate is retryBranchUpdate for CommitBranchUpdate, returning the ID of the commit added to the
+// branch.
+func (g *Graveler) retryCommitBranchUpdate(ctx context.Context, repository *RepositoryRecord, branchID BranchID, f BranchCommitUpdateFunc, operation string) (CommitID, error) {
+	var commitID CommitID
+	err := g.retryUpdate(ctx, repository, branchID, operation, func() error {
+		var err error
+		commitID, err = g.RefManager.CommitBranchUpdate(ctx, repository, branchID, f)
+		return err
+	})
+	return commitID, err
+}
+
+func (g *Graveler) retryUpdate(ctx context.Context, repository *RepositoryRecord, branchID BranchID, operation string, update func() error) error {
 	tries := 0
 	defer func() {
 		g.monitorRetries(ctx, tries-1, repository.RepositoryID, branchID, operation)
@@ -2296,7 +2618,7 @@ func (g *Graveler) retryBranchUpdate(ctx context.Context, repository *Repository
 	err := backoff.Retry(func() error {
 		// TODO(eden) issue 3586 - if the branch commit id hasn't changed, update the fields instead of fail
 		tries += 1
-		err := g.RefManager.BranchUpdate(ctx, repository, branchID, f)
+		err := update()
 		if errors.Is(err, kv.ErrPredicateFailed) && tries < BranchUpdateMaxTries {
 			g.log(ctx).WithField("try", tries).
 				WithField("branchID", branchID).
@@ -2463,6 +2785,9 @@ func (g *Graveler) ResetHard(ctx context.Context, repository *RepositoryRecord,
 	if repository.ReadOnly && !options.Force {
 		return ErrReadOnlyRepository
 	}
+	if err := g.checkNoLocks(ctx, repository, branchID); err != nil {
+		return err
+	}
 
 	// TODO(ariels): up to here.  Verify staging is empty!
 	err = g.retryBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
@@ -2517,6 +2842,13 @@ func (g *Graveler) Reset(ctx context.Context, repository *RepositoryRecord, bran
 		return ErrReadOnlyRepository
 	}
 
+	err = g.checkChangesNotLocked(ctx, repository, branchID, nil, func() (DiffIterator, error) {
+		return g.DiffUncommitted(ctx, repository, branchID)
+	})
+	if err != nil {
+		return err
+	}
+
 	tokensToDrop := make([]StagingToken, 0)
 	err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
 		// Save current branch tokens for drop
@@ -2575,6 +2907,47 @@ func (g *Graveler) resetKey(ctx context.Context, repository *RepositoryRecord, b
 	return nil
 }
 
+// resetKeyIfCondition resets key like resetKey, only if its staged value still passes condition.  The condition is
+// checked against the staged value as part of the staging update, so a concurrent write to the key is not reset and
+// fails the reset with ErrPreconditionFailed.
+func (g *Graveler) resetKeyIfCondition(ctx context.Context, repository *RepositoryRecord, branchID BranchID, branch *Branch, key Key, uncommittedValue *Value, condition ConditionFunc) error {
+	committed, err := g.Get(ctx, repository, branch.CommitID.Ref(), key)
+	switch {
+	case errors.Is(err, ErrNotFound):
+		if uncommittedValue == nil {
+			return nil
+		}
+		// not committed => override with a tombstone
+		committed = new(Value)
+	case err != nil:
+		return err
+	case uncommittedValue != nil && bytes.Equal(committed.Identity, uncommittedValue.Identity):
+		return nil // No change
+	}
+
+	err = g.StagingManager.Update(ctx, branch.StagingToken, key, func(stagedValue *Value) (*Value, error) {
+		if stagedValue == nil {
+			// nothing staged, the value checked by the caller is current
+			return committed, nil
+		}
+		if stagedValue.Identity == nil {
+			// tombstone
+			stagedValue = nil
+		}
+		if err := condition(stagedValue); err != nil {
+			return nil, err
+		}
+		return committed, nil
+	})
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		return fmt.Errorf("%w: concurrent write: %w", ErrPreconditionFailed, err)
+	}
+	if err == nil && committed.Identity == nil && g.deleteSensor != nil {
+		g.deleteSensor.CountDelete(ctx, repository.RepositoryID, branchID, branch.StagingToken)
+	}
+	return err
+}
+
 func (g *Graveler) ResetKey(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key, opts ...SetOptionsFunc) error {
 	isProtected, err := g.protectedBranchesManager.IsBlocked(ctx, repository, branchID, BranchProtectionBlockedAction_STAGING_WRITE)
 	if err != nil {
@@ -2589,6 +2962,10 @@ func (g *Graveler) ResetKey(ctx context.Context, repository *RepositoryRecord, b
 		return ErrReadOnlyRepository
 	}
 
+	if err := g.checkNotLocked(ctx, repository, branchID, key); err != nil {
+		return err
+	}
+
 	branch, err := g.RefManager.GetBranch(ctx, repository, branchID)
 	if err != nil {
 		return fmt.Errorf("getting branch: %w", err)
@@ -2611,6 +2988,13 @@ func (g *Graveler) ResetKey(ctx context.Context, repository *RepositoryRecord, b
 		}
 	}
 
+	if options.Condition != nil {
+		if err := options.Condition(uncommittedValue); err != nil {
+			return err
+		}
+		return g.resetKeyIfCondition(ctx, repository, branchID, branch, key, uncommittedValue, options.Condition)
+	}
+
 	err = g.resetKey(ctx, repository, branchID, branch, key, uncommittedValue, branch.StagingToken)
 	if err != nil {
 		if !errors.Is(err, ErrNotFound) { // Not found in staging => ignore
@@ -2639,6 +3023,13 @@ func (g *Graveler) ResetPrefix(ctx context.Context, repository *RepositoryRecord
 		return ErrReadOnlyRepository
 	}
 
+	err = g.checkChangesNotLocked(ctx, repository, branchID, key, func() (DiffIterator, error) {
+		return g.DiffUncommitted(ctx, repository, branchID)
+	})
+	if err != nil {
+		return err
+	}
+
 	// New sealed tokens list after change includes current staging token
 	newSealedTokens := make([]StagingToken, 0)
 	newStagingToken := GenerateStagingToken(repository.RepositoryID, branchID)
@@ -2727,23 +3118,23 @@ func (g *Graveler) Revert(ctx context.Context, repository *RepositoryRecord, bra
 		return "", err
 	}
 
-	err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
+	newCommitID, err = g.RefManager.CommitBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, *Commit, error) {
 		if empty, err := g.isSealedEmpty(ctx, repository, branch); err != nil {
-			return nil, err
+			return nil, nil, err
 		} else if !empty {
-			return nil, fmt.Errorf("%s: %w", branchID, ErrDirtyBranch)
+			return nil, nil, fmt.Errorf("%s: %w", branchID, ErrDirtyBranch)
 		}
 		var parentMetaRangeID MetaRangeID
 		if len(commitRecord.Parents) > 0 {
 			parentCommit, err := g.dereferenceCommit(ctx, repository, commitRecord.Parents[parentNumber].Ref())
 			if err != nil {
-				return nil, fmt.Errorf("get commit from ref %s: %w", commitRecord.Parents[parentNumber], err)
+				return nil, nil, fmt.Errorf("get commit from ref %s: %w", commitRecord.Parents[parentNumber], err)
 			}
 			parentMetaRangeID = parentCommit.MetaRangeID
 		}
 		branchCommit, err := g.dereferenceCommit(ctx, repository, branch.CommitID.Ref())
 		if err != nil {
-			return nil, fmt.Errorf("get commit from ref %s: %w", branch.CommitID, err)
+			return nil, nil, fmt.Errorf("get commit from ref %s: %w", branch.CommitID, err)
 		}
 		// merge from the parent to the top of the branch, with the given ref as the merge base:
 		metaRangeID, err := g.CommittedManager.Merge(
@@ -2752,10 +3143,13 @@ func (g *Graveler) Revert(ctx context.Context, repository *RepositoryRecord, bra
 			if !errors.Is(err, ErrUserVisible) {
 				err = fmt.Errorf("merge: %w", err)
 			}
-			return nil, err
+			return nil, nil, err
 		}
 		if (metaRangeID == branchCommit.MetaRangeID) && !commitParams.AllowEmpty {
-			return nil, ErrNoChanges
+			return nil, nil, ErrNoChanges
+		}
+		if err := g.checkMetaRangeChangesNotLocked(ctx, repository, branchID, branchCommit.MetaRangeID, metaRangeID); err != nil {
+			return nil, nil, err
 		}
 		commit = NewCommit()
 		commit.Committer = commitParams.Committer
@@ -2778,7 +3172,7 @@ func (g *Graveler) Revert(ctx context.Context, repository *RepositoryRecord, bra
 				Commit:     commit,
 			})
 			if err != nil {
-				return nil, &HookAbortError{
+				return nil, nil, &HookAbortError{
 					EventType: EventTypePreRevert,
 					RunID:     preRunID,
 					Err:       err,
@@ -2786,15 +3180,9 @@ func (g *Graveler) Revert(ctx context.Context, repository *RepositoryRecord, bra
 			}
 		}
 
-		newCommitID, err = g.RefManager.AddCommit(ctx, repository, commit)
-		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
-		}
-
 		tokensToDrop = branch.SealedTokens
 		branch.SealedTokens = []StagingToken{}
-		branch.CommitID = newCommitID
-		return branch, nil
+		return branch, &commit, nil
 	})
 	if err != nil {
 		return "", fmt.Errorf("update branch: %w", err)
@@ -2872,16 +3260,16 @@ func (g *Graveler) CherryPick(ctx context.Context, repository *RepositoryRecord,
 		commitID     CommitID
 		tokensToDrop []StagingToken
 	)
-	err = g.RefManager.BranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, error) {
+	commitID, err = g.RefManager.CommitBranchUpdate(ctx, repository, branchID, func(branch *Branch) (*Branch, *Commit, error) {
 		if empty, err := g.isSealedEmpty(ctx, repository, branch); err != nil {
-			return nil, err
+			return nil, nil, err
 		} else if !empty {
-			return nil, fmt.Errorf("%s: %w", branchID, ErrDirtyBranch)
+			return nil, nil, fmt.Errorf("%s: %w", branchID, ErrDirtyBranch)
 		}
 
 		branchCommit, err := g.dereferenceCommit(ctx, repository, branch.CommitID.Ref())
 		if err != nil {
-			return nil, fmt.Errorf("get commit from ref %s: %w", branch.CommitID, err)
+			return nil, nil, fmt.Errorf("get commit from ref %s: %w", branch.CommitID, err)
 		}
 		// merge from the parent to the top of the branch, with the given ref as the merge base:
 		metaRangeID, err := g.CommittedManager.Merge(ctx, repository.StorageID, repository.StorageNamespace, branchCommit.MetaRangeID, commitRecord.MetaRangeID, parentMetaRangeID, MergeStrategyNone)
@@ -2889,7 +3277,10 @@ func (g *Graveler) CherryPick(ctx context.Context, repository *RepositoryRecord,
 			if !errors.Is(err, ErrUserVisible) {
 				err = fmt.Errorf("merge: %w", err)
 			}
-			return nil, err
+			return nil, nil, err
+		}
+		if err := g.checkMetaRangeChangesNotLocked(ctx, repository, branchID, branchCommit.MetaRangeID, metaRangeID); err != nil {
+			return nil, nil, err
 		}
 		commit = NewCommit()
 		commit.Committer = committer
@@ -2921,7 +3312,7 @@ func (g *Graveler) CherryPick(ctx context.Context, repository *RepositoryRecord,
 				Commit:     commit,
 			})
 			if err != nil {
-				return nil, &HookAbortError{
+				return nil, nil, &HookAbortError{
 					EventType: EventTypePreCherryPick,
 					RunID:     preRunID,
 					Err:       err,
@@ -2929,15 +3320,9 @@ func (g *Graveler) CherryPick(ctx context.Context, repository *RepositoryRecord,
 			}
 		}
 
-		commitID, err = g.RefManager.AddCommit(ctx, repository, commit)
-		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
-		}
-
 		tokensToDrop = branch.SealedTokens
 		branch.SealedTokens = []StagingToken{}
-		branch.CommitID = commitID
-		return branch, nil
+		return branch, &commit, nil
 	})
 	if err != nil {
 		return "", fmt.Errorf("update branch: %w", err)
@@ -2981,6 +3366,22 @@ func applyCommitOverrides(commit *Commit, commitOverrides *CommitOverrides) {
 	}
 }
 
+// mergeStrategyFor returns the strategy of a merge with options.  A resolver replaces the
+// strategy, it cannot be combined with one.
+func mergeStrategyFor(strategy string, options *SetOptions) (MergeStrategy, error) {
+	mergeStrategy, err := ParseMergeStrategy(strategy)
+	if err != nil {
+		return 0, err
+	}
+	if options.ConflictResolver == nil {
+		return mergeStrategy, nil
+	}
+	if mergeStrategy != MergeStrategyNone {
+		return 0, ErrInvalidMergeStrategy
+	}
+	return MergeStrategyResolve, nil
+}
+
 func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, commitParams CommitParams, strategy string, opts ...SetOptionsFunc) (CommitID, error) {
 	options := NewSetOptions(opts)
 	if repository.ReadOnly && !options.Force {
@@ -2993,8 +3394,20 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 		commitID CommitID
 	)
 
+	requiredApprovals, err := g.protectedBranchesManager.RequiredApprovals(ctx, repository, destination)
+	if err != nil {
+		return "", err
+	}
+	var pullRequest *PullRequest
+	if options.PullRequestID != "" {
+		pullRequest, err = g.RefManager.GetPullRequest(ctx, repository, options.PullRequestID)
+		if err != nil {
+			return "", err
+		}
+	}
+
 	storageNamespace := repository.StorageNamespace
-	err := g.prepareForCommitIDUpdate(ctx, repository, destination, "merge")
+	err = g.prepareForCommitIDUpdate(ctx, repository, destination, "merge")
 	if err != nil {
 		return "", err
 	}
@@ -3016,17 +3429,17 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 	// No retries on any failure during the merge. If the branch changed, it's either that commit is in progress, commit occurred,
 	// or some other branch changing operation. If commit is in-progress, then staging area wasn't empty after we checked so not retrying is ok.
 	// If another commit/merge succeeded, then the user should decide whether to retry the merge.
-	err = g.retryBranchUpdate(ctx, repository, destination, func(branch *Branch) (*Branch, error) {
+	commitID, err = g.retryCommitBranchUpdate(ctx, repository, destination, func(branch *Branch) (*Branch, *Commit, error) {
 		empty, err := g.isSealedEmpty(ctx, repository, branch)
 		if err != nil {
-			return nil, fmt.Errorf("check if staging empty: %w", err)
+			return nil, nil, fmt.Errorf("check if staging empty: %w", err)
 		}
 		if !empty {
-			return nil, fmt.Errorf("%s: %w", destination, ErrDirtyBranch)
+			return nil, nil, fmt.Errorf("%s: %w", destination, ErrDirtyBranch)
 		}
 		fromCommit, toCommit, baseCommit, err := g.FindMergeBase(ctx, repository, source, Ref(destination))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		lg.WithFields(logging.Fields{
 			"source_meta_range":      fromCommit.MetaRangeID,
@@ -3034,24 +3447,34 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 			"base_meta_range":        baseCommit.MetaRangeID,
 		}).Trace("Merge")
 
-		var mergeStrategy MergeStrategy
-		switch strategy {
-		case MergeStrategyDestWinsStr:
-			mergeStrategy = MergeStrategyDest
-		case MergeStrategySrcWinsStr:
-			mergeStrategy = MergeStrategySrc
-		case "":
-			mergeStrategy = MergeStrategyNone
-		default:
-			return nil, ErrInvalidMergeStrategy
+		if requiredApprovals > 0 {
+			err := checkMergeApprovals(destination, source, fromCommit.CommitID, options.PullRequestID, pullRequest, requiredApprovals)
+			if err != nil {
+				return nil, nil, err
+			}
+		}
+
+		mergeStrategy, err := mergeStrategyFor(strategy, options)
+		if err != nil {
+			return nil, nil, err
 		}
 
-		metaRangeID, err := g.CommittedManager.Merge(ctx, repository.StorageID, storageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID, mergeStrategy, opts...)
+		mergeOpts := opts
+		var recorder *conflictRecorder
+		if options.ConflictResolver != nil {
+			recorder = newConflictRecorder(options.ConflictResolver)
+			mergeOpts = append(append([]SetOptionsFunc{}, opts...), WithConflictResolver(recorder))
+		}
+
+		metaRangeID, err := g.CommittedManager.Merge(ctx, repository.StorageID, storageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID, mergeStrategy, mergeOpts...)
 		if err != nil {
 			if !errors.Is(err, ErrUserVisible) {
 				err = fmt.Errorf("merge in CommitManager: %w", err)
 			}
-			return nil, err
+			return nil, nil, err
+		}
+		if err := g.checkMetaRangeChangesNotLocked(ctx, repository, destination, toCommit.MetaRangeID, metaRangeID); err != nil {
+			return nil, nil, err
 		}
 		commit = NewCommit()
 		commit.Committer = commitParams.Committer
@@ -3068,25 +3491,53 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 			commit.Generation = fromCommit.Generation + 1
 		}
 		metadata[MergeStrategyMetadataKey] = mergeStrategyString[mergeStrategy]
+		if recorder != nil {
+			if err := recorder.setMetadata(metadata); err != nil {
+				return nil, nil, err
+			}
+		}
+		if len(options.MergeStrategyRules) > 0 {
+			if err := options.MergeStrategyRules.setMetadata(metadata); err != nil {
+				return nil, nil, err
+			}
+		}
 		commit.Metadata = metadata
+		// pre-merge hooks get the ID of the merge commit, so it is written before them and written again with the
+		// branch
 		commitID, err = g.RefManager.AddCommit(ctx, repository, commit)
 		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
+			return nil, nil, fmt.Errorf("add commit: %w", err)
+		}
+		if pullRequest != nil && !repository.ReadOnly {
+			prRunID := g.hooks.NewRunID()
+			record := pullRequestHookRecord(EventTypePreMergePullRequest, prRunID, repository, options.PullRequestID, pullRequest)
+			record.SourceRef = fromCommit.CommitID.Ref()
+			record.MergeSource = source
+			record.Commit = commit
+			record.CommitID = commitID
+			if err := g.hooks.PreMergePullRequestHook(ctx, record); err != nil {
+				return nil, nil, &HookAbortError{
+					EventType: EventTypePreMergePullRequest,
+					RunID:     prRunID,
+					Err:       err,
+				}
+			}
 		}
 		if !repository.ReadOnly {
 			preRunID = g.hooks.NewRunID()
 			err = g.hooks.PreMergeHook(ctx, HookRecord{
-				EventType:   EventTypePreMerge,
-				RunID:       preRunID,
-				Repository:  repository,
-				BranchID:    destination,
-				SourceRef:   fromCommit.CommitID.Ref(), // comment id we merge from
-				MergeSource: source,                    // the requested source to merge from (branch/tag/ref)
-				Commit:      commit,
-				CommitID:    commitID,
+				EventType:     EventTypePreMerge,
+				RunID:         preRunID,
+				Repository:    repository,
+				BranchID:      destination,
+				SourceRef:     fromCommit.CommitID.Ref(), // comment id we merge from
+				MergeSource:   source,                    // the requested source to merge from (branch/tag/ref)
+				Commit:        commit,
+				CommitID:      commitID,
+				PullRequestID: options.PullRequestID,
 			})
 			if err != nil {
-				return nil, &HookAbortError{
+				return nil, nil, &HookAbortError{
 					EventType: EventTypePreMerge,
 					RunID:     preRunID,
 					Err:       err,
@@ -3095,8 +3546,7 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 		}
 		tokensToDrop = branch.SealedTokens
 		branch.SealedTokens = []StagingToken{}
-		branch.CommitID = commitID
-		return branch, nil
+		return branch, &commit, nil
 	}, "merge")
 	if err != nil {
 		return "", fmt.Errorf("update branch %s: %w", destination, err)
@@ -3106,15 +3556,16 @@ func (g *Graveler) Merge(ctx context.Context, repository *RepositoryRecord, dest
 	if !repository.ReadOnly {
 		postRunID := g.hooks.NewRunID()
 		err = g.hooks.PostMergeHook(ctx, HookRecord{
-			EventType:   EventTypePostMerge,
-			RunID:       postRunID,
-			Repository:  repository,
-			BranchID:    destination,
-			SourceRef:   commitID.Ref(), // commit id we merge from
-			MergeSource: source,         // the requested source to merge from (branch/tag/ref)
-			Commit:      commit,
-			CommitID:    commitID,
-			PreRunID:    preRunID,
+			EventType:     EventTypePostMerge,
+			RunID:         postRunID,
+			Repository:    repository,
+			BranchID:      destination,
+			SourceRef:     commitID.Ref(), // commit id we merge from
+			MergeSource:   source,         // the requested source to merge from (branch/tag/ref)
+			Commit:        commit,
+			CommitID:      commitID,
+			PreRunID:      preRunID,
+			PullRequestID: options.PullRequestID,
 		})
 		if err != nil {
 			g.log(ctx).
@@ -3176,17 +3627,17 @@ func (g *Graveler) Import(ctx context.Context, repository *RepositoryRecord, des
 	// No retries on any failure during the merge. If the branch changed, it's either that commit is in progress, commit occurred,
 	// or some other branch changing operation. If commit is in-progress, then staging area wasn't empty after we checked so not retrying is ok.
 	// If another commit/merge succeeded, then the user should decide whether to retry the merge.
-	err = g.retryBranchUpdate(ctx, repository, destination, func(branch *Branch) (*Branch, error) {
+	commitID, err = g.retryCommitBranchUpdate(ctx, repository, destination, func(branch *Branch) (*Branch, *Commit, error) {
 		empty, err := g.isSealedEmpty(ctx, repository, branch)
 		if err != nil {
-			return nil, fmt.Errorf("is staging empty %s: %w", destination, err)
+			return nil, nil, fmt.Errorf("is staging empty %s: %w", destination, err)
 		}
 		if !empty {
-			return nil, fmt.Errorf("%s: %w", destination, ErrDirtyBranch)
+			return nil, nil, fmt.Errorf("%s: %w", destination, ErrDirtyBranch)
 		}
 		toCommit, err := g.dereferenceCommit(ctx, repository, Ref(destination))
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 
 		g.log(ctx).WithFields(logging.Fields{
@@ -3201,7 +3652,10 @@ func (g *Graveler) Import(ctx context.Context, repository *RepositoryRecord, des
 			if !errors.Is(err, ErrUserVisible) {
 				err = fmt.Errorf("merge in CommitManager: %w", err)
 			}
-			return nil, err
+			return nil, nil, err
+		}
+		if err := g.checkMetaRangeChangesNotLocked(ctx, repository, destination, toCommit.MetaRangeID, metaRangeID); err != nil {
+			return nil, nil, err
 		}
 		commit = NewCommit()
 		commit.Committer = commitParams.Committer
@@ -3225,7 +3679,7 @@ func (g *Graveler) Import(ctx context.Context, repository *RepositoryRecord, des
 				Commit:     commit,
 			})
 			if err != nil {
-				return nil, &HookAbortError{
+				return nil, nil, &HookAbortError{
 					EventType: EventTypePreCommit,
 					RunID:     preRunID,
 					Err:       err,
@@ -3233,15 +3687,9 @@ func (g *Graveler) Import(ctx context.Context, repository *RepositoryRecord, des
 			}
 		}
 
-		commitID, err = g.RefManager.AddCommit(ctx, repository, commit)
-		if err != nil {
-			return nil, fmt.Errorf("add commit: %w", err)
-		}
-
 		tokensToDrop = branch.SealedTokens
 		branch.SealedTokens = []StagingToken{}
-		branch.CommitID = commitID
-		return branch, nil
+		return branch, &commit, nil
 	}, "import")
 	if err != nil {
 		return "", fmt.Errorf("update branch %s: %w", destination, err)
@@ -3420,6 +3868,14 @@ func (g *Graveler) SetHooksHandler(handler HooksHandler) {
 	}
 }
 
+func (g *Graveler) SetObjectLockManager(manager ObjectLockManager) {
+	if manager == nil {
+		g.objectLockManager = ObjectLockNoOp{}
+	} else {
+		g.objectLockManager = manager
+	}
+}
+
 func (g *Graveler) LoadCommits(ctx context.Context, repository *RepositoryRecord, metaRangeID MetaRangeID, opts ...SetOptionsFunc) error {
 	options := NewSetOptions(opts)
 	if repository.ReadOnly && !options.Force {
@@ -3605,7 +4061,44 @@ func (g *Graveler) GetPullRequest(ctx context.Context, repository *RepositoryRec
 }
 
 func (g *Graveler) CreatePullRequest(ctx context.Context, repository *RepositoryRecord, record *PullRequestRecord) error {
-	return g.RefManager.CreatePullRequest(ctx, repository, record.ID, &record.PullRequest)
+	var preRunID string
+	if !repository.ReadOnly {
+		preRunID = g.hooks.NewRunID()
+		err := g.hooks.PreCreatePullRequestHook(ctx, pullRequestHookRecord(EventTypePreCreatePullRequest, preRunID, repository, record.ID, &record.PullRequest))
+		if err != nil {
+			return &HookAbortError{
+				EventType: EventTypePreCreatePullRequest,
+				RunID:     preRunID,
+				Err:       err,
+			}
+		}
+	}
+	err := g.RefManager.CreatePullRequest(ctx, repository, record.ID, &record.PullRequest)
+	if err != nil {
+		return err
+	}
+
+	if !repository.ReadOnly {
+		postRunID := g.hooks.NewRunID()
+		postRecord := pullRequestHookRecord(EventTypePostCreatePullRequest, postRunID, repository, record.ID, &record.PullRequest)
+		postRecord.PreRunID = preRunID
+		g.hooks.PostCreatePullRequestHook(ctx, postRecord)
+	}
+	return nil
+}
+
+// pullRequestHookRecord returns the hook record of a pull request event. Actions are read from the
+// source branch and match the destination branch, like merge actions.
+func pullRequestHookRecord(eventType EventType, runID string, repository *RepositoryRecord, pullRequestID PullRequestID, pullRequest *PullRequest) HookRecord {
+	return HookRecord{
+		RunID:         runID,
+		EventType:     eventType,
+		Repository:    repository,
+		SourceRef:     Ref(pullRequest.Source),
+		BranchID:      BranchID(pullRequest.Destination),
+		PullRequestID: pullRequestID,
+		PullRequest:   pullRequest,
+	}
 }
 
 func (g *Graveler) ListPullRequests(ctx context.Context, repository *RepositoryRecord) (PullsIterator, error) {
@@ -3625,35 +4118,166 @@ func isPullClosed(status PullRequestStatus) bool {
 }
 
 func (g *Graveler) UpdatePullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, update *UpdatePullRequest) error {
-	pr, err := g.RefManager.GetPullRequest(ctx, repository, pullRequestID)
-	if err != nil {
-		return err
-	}
-
-	err = g.RefManager.UpdatePullRequest(ctx, repository, pullRequestID, func(request *PullRequest) (*PullRequest, error) {
+	// changes apply to the stored pull request, keeping reviews added since it was read.  Whether
+	// this update closes the pull request is decided from the stored status, so that of concurrent
+	// updates only the one that closes it runs the post-close hook.
+	var (
+		pr     *PullRequest
+		closed bool
+	)
+	err := g.RefManager.UpdatePullRequest(ctx, repository, pullRequestID, func(request *PullRequest) (*PullRequest, error) {
+		wasOpen := request.Status == PullRequestStatus_OPEN
 		if update.Title != nil {
-			pr.Title = *update.Title
+			request.Title = *update.Title
 		}
 		if update.Description != nil {
-			pr.Description = *update.Description
+			request.Description = *update.Description
 		}
 		if update.Status != nil {
 			status, err := pullRequestStatusFromString(*update.Status)
 			if err != nil {
 				return nil, err
 			}
-			pr.Status = status
+			request.Status = status
 			if isPullClosed(status) {
 				now := time.Now()
-				pr.ClosedDate = &now
+				request.ClosedDate = &now
 			}
 		}
 		if update.MergedCommitID != nil {
-			pr.MergedCommitID = update.MergedCommitID
+			request.MergedCommitID = update.MergedCommitID
 		}
-		return pr, nil
+		pr = request
+		closed = wasOpen && isPullClosed(request.Status)
+		return request, nil
+	})
+	if err != nil {
+		return err
+	}
+
+	if closed && !repository.ReadOnly {
+		g.hooks.PostClosePullRequestHook(ctx, pullRequestHookRecord(EventTypePostClosePullRequest, g.hooks.NewRunID(), repository, pullRequestID, pr))
+	}
+	return nil
+}
+
+func (g *Graveler) ReviewPullRequest(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, review *PullRequestReview) error {
+	return g.RefManager.UpdatePullRequest(ctx, repository, pullRequestID, func(request *PullRequest) (*PullRequest, error) {
+		if request.Status != PullRequestStatus_OPEN {
+			return nil, fmt.Errorf("review %s pull request: %w", strings.ToLower(request.Status.String()), ErrPullRequestNotOpen)
+		}
+		if review.Reviewer == request.Author && review.State != PullRequestReviewState_COMMENTED {
+			return nil, ErrPullRequestSelfReview
+		}
+		// the review holds for the commit the reviewer saw, only while it is still the source head
+		source, err := g.RefManager.GetBranch(ctx, repository, BranchID(request.Source))
+		if err != nil {
+			return nil, err
+		}
+		if source.CommitID != review.CommitID {
+			return nil, fmt.Errorf("reviewed %s, source head is %s: %w", review.CommitID, source.CommitID, ErrPullRequestSourceMoved)
+		}
+		r := *review
+		r.CreationDate = time.Now()
+		request.Reviews = append(request.Reviews, r)
+		return request, nil
+	})
+}
+
+func (g *Graveler) GetPullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID) (*PullRequestComment, error) {
+	return g.RefManager.GetPullRequestComment(ctx, repository, pullRequestID, commentID)
+}
+
+func (g *Graveler) ListPullRequestComments(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID) (PullCommentsIterator, error) {
+	if _, err := g.RefManager.GetPullRequest(ctx, repository, pullRequestID); err != nil {
+		return nil, err
+	}
+	return g.RefManager.ListPullRequestComments(ctx, repository, pullRequestID)
+}
+
+// CreatePullRequestComment adds a comment to a pull request in any status, so discussion can go on after
+// a merge. A reply belongs to the thread of the comment it replies to and discusses the same path.
+func (g *Graveler) CreatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, comment *PullRequestComment) (PullRequestCommentID, error) {
+	if comment.Body == "" {
+		return "", fmt.Errorf("empty body: %w", ErrInvalidPullRequestComment)
+	}
+	if _, err := g.RefManager.GetPullRequest(ctx, repository, pullRequestID); err != nil {
+		return "", err
+	}
+	c := *comment
+	if c.ReplyTo != "" {
+		thread, err := g.RefManager.GetPullRequestComment(ctx, repository, pullRequestID, c.ReplyTo)
+		if err != nil {
+			return "", err
+		}
+		// replies to a reply join the thread of the replied comment
+		if thread.ReplyTo != "" {
+			c.ReplyTo = thread.ReplyTo
+		}
+		c.Path = thread.Path
+	}
+	// xid is ordered by creation time, listing comments oldest first
+	c.ID = PullRequestCommentID(xid.New().String())
+	c.CreationDate = time.Now()
+	c.UpdateDate = nil
+	c.Resolved = false
+	c.ResolvedBy = ""
+	if err := g.RefManager.CreatePullRequestComment(ctx, repository, pullRequestID, &c); err != nil {
+		return "", err
+	}
+	return c.ID, nil
+}
+
+func (g *Graveler) UpdatePullRequestComment(ctx context.Context, repository *RepositoryRecord, pullRequestID PullRequestID, commentID PullRequestCommentID, update *UpdatePullRequestComment) error {
+	return g.RefManager.UpdatePullRequestComment(ctx, repository, pullRequestID, commentID, func(comment *PullRequestComment) (*PullRequestComment, error) {
+		if update.Body != nil {
+			if comment.Author != update.Editor {
+				return nil, ErrPullRequestCommentNotAuthor
+			}
+			if *update.Body == "" {
+				return nil, fmt.Errorf("empty body: %w", ErrInvalidPullRequestComment)
+			}
+			comment.Body = *update.Body
+			now := time.Now()
+			comment.UpdateDate = &now
+		}
+		if update.Resolved != nil {
+			if comment.ReplyTo != "" {
+				return nil, fmt.Errorf("comment %s is a reply, resolve thread %s: %w", comment.ID, comment.ReplyTo, ErrInvalidPullRequestComment)
+			}
+			comment.Resolved = *update.Resolved
+			comment.ResolvedBy = ""
+			if comment.Resolved {
+				comment.ResolvedBy = update.Editor
+			}
+		}
+		return comment, nil
 	})
-	return err
+}
+
+// checkMergeApprovals verifies that a merge of sourceCommitID into a destination requiring approvals comes
+// through pr, an open pull request from source into destination, with at least required approvals of
+// sourceCommitID and no pending change requests.
+func checkMergeApprovals(destination BranchID, source Ref, sourceCommitID CommitID, pullRequestID PullRequestID, pr *PullRequest, required int) error {
+	if pr == nil {
+		return fmt.Errorf("%s requires %d approvals: %w", destination, required, ErrMergeToProtectedBranch)
+	}
+	if pr.Status != PullRequestStatus_OPEN || pr.Destination != destination.String() || pr.Source != source.String() {
+		return fmt.Errorf("pull request %s does not merge %s into %s: %w", pullRequestID, source, destination, ErrMergeToProtectedBranch)
+	}
+	approvals := 0
+	for reviewer, decision := range pr.ReviewDecisions() {
+		if decision.State == PullRequestReviewState_CHANGES_REQUESTED {
+			return fmt.Errorf("%s requested changes: %w", reviewer, ErrMergeToProtectedBranch)
+		}
+		if decision.CommitID == sourceCommitID {
+			approvals++
+		}
+	}
+	if approvals < required {
+		return fmt.Errorf("%s requires %d approvals of %s, got %d: %w", destination, required, sourceCommitID, approvals, ErrMergeToProtectedBranch)
+	}
+	return nil
 }
 
 func tagsToValueIterator(src TagIterator) ValueIterator {
@@ -3876,6 +4500,35 @@ type ProtectedBranchesManager interface {
 	SetRules(ctx context.Context, repository *RepositoryRecord, rules *BranchProtectionRules, lastKnownChecksum *string) error
 	// IsBlocked returns whether the action is blocked by any branch protection rule matching the given branch.
 	IsBlocked(ctx context.Context, repository *RepositoryRecord, branchID BranchID, action BranchProtectionBlockedAction) (bool, error)
+	// RequiredApprovals returns the number of pull request approvals needed to merge into the given branch,
+	// 0 if merges into it do not require approval.
+	RequiredApprovals(ctx context.Context, repository *RepositoryRecord, branchID BranchID) (int, error)
+}
+
+// ObjectLockManager reports the objects locked by a retention period or a legal hold.  Locked objects cannot be
+// overwritten or deleted on their branch.
+type ObjectLockManager interface {
+	// IsLocked returns whether the object key on the given branch is locked.
+	IsLocked(ctx context.Context, repository *RepositoryRecord, branchID BranchID, key Key) (bool, error)
+	// HasLocks returns whether any object on the given branch is locked.
+	HasLocks(ctx context.Context, repository *RepositoryRecord, branchID BranchID) (bool, error)
+	// LockedCommits returns the commits holding the locked versions of objects of the repository.
+	LockedCommits(ctx context.Context, repository *RepositoryRecord) ([]CommitID, error)
+}
+
+// ObjectLockNoOp is an ObjectLockManager of repositories without locked objects
+type ObjectLockNoOp struct{}
+
+func (ObjectLockNoOp) IsLocked(context.Context, *RepositoryRecord, BranchID, Key) (bool, error) {
+	return false, nil
+}
+
+func (ObjectLockNoOp) HasLocks(context.Context, *RepositoryRecord, BranchID) (bool, error) {
+	return false, nil
+}
+
+func (ObjectLockNoOp) LockedCommits(context.Context, *RepositoryRecord) ([]CommitID, error) {
+	return nil, nil
 }
 
 // NewRepoInstanceID Returns a new unique identifier for the repository instance
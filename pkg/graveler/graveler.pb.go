@@ -68,11 +68,110 @@ func (RepositoryState) EnumDescriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{0}
 }
 
+type NotificationTargetKind int32
+
+const (
+	NotificationTargetKind_QUEUE          NotificationTargetKind = 0
+	NotificationTargetKind_TOPIC          NotificationTargetKind = 1
+	NotificationTargetKind_CLOUD_FUNCTION NotificationTargetKind = 2
+)
+
+// Enum value maps for NotificationTargetKind.
+var (
+	NotificationTargetKind_name = map[int32]string{
+		0: "QUEUE",
+		1: "TOPIC",
+		2: "CLOUD_FUNCTION",
+	}
+	NotificationTargetKind_value = map[string]int32{
+		"QUEUE":          0,
+		"TOPIC":          1,
+		"CLOUD_FUNCTION": 2,
+	}
+)
+
+func (x NotificationTargetKind) Enum() *NotificationTargetKind {
+	p := new(NotificationTargetKind)
+	*p = x
+	return p
+}
+
+func (x NotificationTargetKind) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (NotificationTargetKind) Descriptor() protoreflect.EnumDescriptor {
+	return file_graveler_graveler_proto_enumTypes[1].Descriptor()
+}
+
+func (NotificationTargetKind) Type() protoreflect.EnumType {
+	return &file_graveler_graveler_proto_enumTypes[1]
+}
+
+func (x NotificationTargetKind) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use NotificationTargetKind.Descriptor instead.
+func (NotificationTargetKind) EnumDescriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{1}
+}
+
+type ObjectLockMode int32
+
+const (
+	// GOVERNANCE retention may be shortened or removed by users allowed to bypass it
+	ObjectLockMode_GOVERNANCE ObjectLockMode = 0
+	// COMPLIANCE retention may only be extended
+	ObjectLockMode_COMPLIANCE ObjectLockMode = 1
+)
+
+// Enum value maps for ObjectLockMode.
+var (
+	ObjectLockMode_name = map[int32]string{
+		0: "GOVERNANCE",
+		1: "COMPLIANCE",
+	}
+	ObjectLockMode_value = map[string]int32{
+		"GOVERNANCE": 0,
+		"COMPLIANCE": 1,
+	}
+)
+
+func (x ObjectLockMode) Enum() *ObjectLockMode {
+	p := new(ObjectLockMode)
+	*p = x
+	return p
+}
+
+func (x ObjectLockMode) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (ObjectLockMode) Descriptor() protoreflect.EnumDescriptor {
+	return file_graveler_graveler_proto_enumTypes[2].Descriptor()
+}
+
+func (ObjectLockMode) Type() protoreflect.EnumType {
+	return &file_graveler_graveler_proto_enumTypes[2]
+}
+
+func (x ObjectLockMode) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use ObjectLockMode.Descriptor instead.
+func (ObjectLockMode) EnumDescriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{2}
+}
+
 type BranchProtectionBlockedAction int32
 
 const (
 	BranchProtectionBlockedAction_STAGING_WRITE BranchProtectionBlockedAction = 0
 	BranchProtectionBlockedAction_COMMIT        BranchProtectionBlockedAction = 1
+	// MERGE_WITHOUT_APPROVAL blocks merges that do not come through an approved pull request
+	BranchProtectionBlockedAction_MERGE_WITHOUT_APPROVAL BranchProtectionBlockedAction = 2
 )
 
 // Enum value maps for BranchProtectionBlockedAction.
@@ -80,10 +179,12 @@ var (
 	BranchProtectionBlockedAction_name = map[int32]string{
 		0: "STAGING_WRITE",
 		1: "COMMIT",
+		2: "MERGE_WITHOUT_APPROVAL",
 	}
 	BranchProtectionBlockedAction_value = map[string]int32{
-		"STAGING_WRITE": 0,
-		"COMMIT":        1,
+		"STAGING_WRITE":          0,
+		"COMMIT":                 1,
+		"MERGE_WITHOUT_APPROVAL": 2,
 	}
 )
 
@@ -98,11 +199,11 @@ func (x BranchProtectionBlockedAction) String() string {
 }
 
 func (BranchProtectionBlockedAction) Descriptor() protoreflect.EnumDescriptor {
-	return file_graveler_graveler_proto_enumTypes[1].Descriptor()
+	return file_graveler_graveler_proto_enumTypes[3].Descriptor()
 }
 
 func (BranchProtectionBlockedAction) Type() protoreflect.EnumType {
-	return &file_graveler_graveler_proto_enumTypes[1]
+	return &file_graveler_graveler_proto_enumTypes[3]
 }
 
 func (x BranchProtectionBlockedAction) Number() protoreflect.EnumNumber {
@@ -111,7 +212,7 @@ func (x BranchProtectionBlockedAction) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use BranchProtectionBlockedAction.Descriptor instead.
 func (BranchProtectionBlockedAction) EnumDescriptor() ([]byte, []int) {
-	return file_graveler_graveler_proto_rawDescGZIP(), []int{1}
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{3}
 }
 
 type PullRequestStatus int32
@@ -147,11 +248,11 @@ func (x PullRequestStatus) String() string {
 }
 
 func (PullRequestStatus) Descriptor() protoreflect.EnumDescriptor {
-	return file_graveler_graveler_proto_enumTypes[2].Descriptor()
+	return file_graveler_graveler_proto_enumTypes[4].Descriptor()
 }
 
 func (PullRequestStatus) Type() protoreflect.EnumType {
-	return &file_graveler_graveler_proto_enumTypes[2]
+	return &file_graveler_graveler_proto_enumTypes[4]
 }
 
 func (x PullRequestStatus) Number() protoreflect.EnumNumber {
@@ -160,7 +261,56 @@ func (x PullRequestStatus) Number() protoreflect.EnumNumber {
 
 // Deprecated: Use PullRequestStatus.Descriptor instead.
 func (PullRequestStatus) EnumDescriptor() ([]byte, []int) {
-	return file_graveler_graveler_proto_rawDescGZIP(), []int{2}
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{4}
+}
+
+type PullRequestReviewState int32
+
+const (
+	PullRequestReviewState_COMMENTED         PullRequestReviewState = 0
+	PullRequestReviewState_APPROVED          PullRequestReviewState = 1
+	PullRequestReviewState_CHANGES_REQUESTED PullRequestReviewState = 2
+)
+
+// Enum value maps for PullRequestReviewState.
+var (
+	PullRequestReviewState_name = map[int32]string{
+		0: "COMMENTED",
+		1: "APPROVED",
+		2: "CHANGES_REQUESTED",
+	}
+	PullRequestReviewState_value = map[string]int32{
+		"COMMENTED":         0,
+		"APPROVED":          1,
+		"CHANGES_REQUESTED": 2,
+	}
+)
+
+func (x PullRequestReviewState) Enum() *PullRequestReviewState {
+	p := new(PullRequestReviewState)
+	*p = x
+	return p
+}
+
+func (x PullRequestReviewState) String() string {
+	return protoimpl.X.EnumStringOf(x.Descriptor(), protoreflect.EnumNumber(x))
+}
+
+func (PullRequestReviewState) Descriptor() protoreflect.EnumDescriptor {
+	return file_graveler_graveler_proto_enumTypes[5].Descriptor()
+}
+
+func (PullRequestReviewState) Type() protoreflect.EnumType {
+	return &file_graveler_graveler_proto_enumTypes[5]
+}
+
+func (x PullRequestReviewState) Number() protoreflect.EnumNumber {
+	return protoreflect.EnumNumber(x)
+}
+
+// Deprecated: Use PullRequestReviewState.Descriptor instead.
+func (PullRequestReviewState) EnumDescriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{5}
 }
 
 type RepositoryData struct {
@@ -551,27 +701,36 @@ func (x *GarbageCollectionRules) GetBranchRetentionDays() map[string]int32 {
 	return nil
 }
 
-type BranchProtectionBlockedActions struct {
-	state         protoimpl.MessageState          `protogen:"open.v1"`
-	Value         []BranchProtectionBlockedAction `protobuf:"varint,1,rep,packed,name=value,proto3,enum=io.treeverse.lakefs.graveler.BranchProtectionBlockedAction" json:"value,omitempty"`
-	unknownFields protoimpl.UnknownFields
-	sizeCache     protoimpl.SizeCache
-}
-
-func (x *BranchProtectionBlockedActions) Reset() {
-	*x = BranchProtectionBlockedActions{}
+type LifecycleRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// prefix of the object paths the rule applies to
+	Prefix string `protobuf:"bytes,2,opt,name=prefix,proto3" json:"prefix,omitempty"`
+	// branch_pattern is a glob of the branches the rule applies to, all branches if empty
+	BranchPattern string `protobuf:"bytes,3,opt,name=branch_pattern,json=branchPattern,proto3" json:"branch_pattern,omitempty"`
+	Enabled       bool   `protobuf:"varint,4,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	// uncommitted_expiration_hours removes uncommitted objects last modified more than this many hours ago, 0 never
+	UncommittedExpirationHours int32 `protobuf:"varint,5,opt,name=uncommitted_expiration_hours,json=uncommittedExpirationHours,proto3" json:"uncommitted_expiration_hours,omitempty"`
+	// committed_expiration_days deletes and commits objects last modified more than this many days ago, 0 never
+	CommittedExpirationDays int32 `protobuf:"varint,6,opt,name=committed_expiration_days,json=committedExpirationDays,proto3" json:"committed_expiration_days,omitempty"`
+	unknownFields           protoimpl.UnknownFields
+	sizeCache               protoimpl.SizeCache
+}
+
+func (x *LifecycleRule) Reset() {
+	*x = LifecycleRule{}
 	mi := &file_graveler_graveler_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BranchProtectionBlockedActions) String() string {
+func (x *LifecycleRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BranchProtectionBlockedActions) ProtoMessage() {}
+func (*LifecycleRule) ProtoMessage() {}
 
-func (x *BranchProtectionBlockedActions) ProtoReflect() protoreflect.Message {
+func (x *LifecycleRule) ProtoReflect() protoreflect.Message {
 	mi := &file_graveler_graveler_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -583,39 +742,74 @@ func (x *BranchProtectionBlockedActions) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BranchProtectionBlockedActions.ProtoReflect.Descriptor instead.
-func (*BranchProtectionBlockedActions) Descriptor() ([]byte, []int) {
+// Deprecated: Use LifecycleRule.ProtoReflect.Descriptor instead.
+func (*LifecycleRule) Descriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{5}
 }
 
-func (x *BranchProtectionBlockedActions) GetValue() []BranchProtectionBlockedAction {
+func (x *LifecycleRule) GetId() string {
 	if x != nil {
-		return x.Value
+		return x.Id
 	}
-	return nil
+	return ""
 }
 
-type BranchProtectionRules struct {
-	state                         protoimpl.MessageState                     `protogen:"open.v1"`
-	BranchPatternToBlockedActions map[string]*BranchProtectionBlockedActions `protobuf:"bytes,1,rep,name=branch_pattern_to_blocked_actions,json=branchPatternToBlockedActions,proto3" json:"branch_pattern_to_blocked_actions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+func (x *LifecycleRule) GetPrefix() string {
+	if x != nil {
+		return x.Prefix
+	}
+	return ""
 }
 
-func (x *BranchProtectionRules) Reset() {
-	*x = BranchProtectionRules{}
+func (x *LifecycleRule) GetBranchPattern() string {
+	if x != nil {
+		return x.BranchPattern
+	}
+	return ""
+}
+
+func (x *LifecycleRule) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+func (x *LifecycleRule) GetUncommittedExpirationHours() int32 {
+	if x != nil {
+		return x.UncommittedExpirationHours
+	}
+	return 0
+}
+
+func (x *LifecycleRule) GetCommittedExpirationDays() int32 {
+	if x != nil {
+		return x.CommittedExpirationDays
+	}
+	return 0
+}
+
+type LifecycleRules struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Rules         []*LifecycleRule       `protobuf:"bytes,1,rep,name=rules,proto3" json:"rules,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LifecycleRules) Reset() {
+	*x = LifecycleRules{}
 	mi := &file_graveler_graveler_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *BranchProtectionRules) String() string {
+func (x *LifecycleRules) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*BranchProtectionRules) ProtoMessage() {}
+func (*LifecycleRules) ProtoMessage() {}
 
-func (x *BranchProtectionRules) ProtoReflect() protoreflect.Message {
+func (x *LifecycleRules) ProtoReflect() protoreflect.Message {
 	mi := &file_graveler_graveler_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -627,41 +821,41 @@ func (x *BranchProtectionRules) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use BranchProtectionRules.ProtoReflect.Descriptor instead.
-func (*BranchProtectionRules) Descriptor() ([]byte, []int) {
+// Deprecated: Use LifecycleRules.ProtoReflect.Descriptor instead.
+func (*LifecycleRules) Descriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{6}
 }
 
-func (x *BranchProtectionRules) GetBranchPatternToBlockedActions() map[string]*BranchProtectionBlockedActions {
+func (x *LifecycleRules) GetRules() []*LifecycleRule {
 	if x != nil {
-		return x.BranchPatternToBlockedActions
+		return x.Rules
 	}
 	return nil
 }
 
-type StagedEntryData struct {
-	state         protoimpl.MessageState `protogen:"open.v1"`
-	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
-	Identity      []byte                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
-	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+type NotificationFilterRule struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// name is "prefix" or "suffix"
+	Name          string `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Value         string `protobuf:"bytes,2,opt,name=value,proto3" json:"value,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *StagedEntryData) Reset() {
-	*x = StagedEntryData{}
+func (x *NotificationFilterRule) Reset() {
+	*x = NotificationFilterRule{}
 	mi := &file_graveler_graveler_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *StagedEntryData) String() string {
+func (x *NotificationFilterRule) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*StagedEntryData) ProtoMessage() {}
+func (*NotificationFilterRule) ProtoMessage() {}
 
-func (x *StagedEntryData) ProtoReflect() protoreflect.Message {
+func (x *NotificationFilterRule) ProtoReflect() protoreflect.Message {
 	mi := &file_graveler_graveler_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -673,55 +867,53 @@ func (x *StagedEntryData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use StagedEntryData.ProtoReflect.Descriptor instead.
-func (*StagedEntryData) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotificationFilterRule.ProtoReflect.Descriptor instead.
+func (*NotificationFilterRule) Descriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{7}
 }
 
-func (x *StagedEntryData) GetKey() []byte {
-	if x != nil {
-		return x.Key
-	}
-	return nil
-}
-
-func (x *StagedEntryData) GetIdentity() []byte {
+func (x *NotificationFilterRule) GetName() string {
 	if x != nil {
-		return x.Identity
+		return x.Name
 	}
-	return nil
+	return ""
 }
 
-func (x *StagedEntryData) GetData() []byte {
+func (x *NotificationFilterRule) GetValue() string {
 	if x != nil {
-		return x.Data
+		return x.Value
 	}
-	return nil
+	return ""
 }
 
-// message data model to track address tokens
-type LinkAddressData struct {
+type NotificationTarget struct {
 	state protoimpl.MessageState `protogen:"open.v1"`
-	// xid physical address that includes the creation date
-	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	Id    string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// arn identifies the server configured sink events are sent to
+	Arn  string                 `protobuf:"bytes,2,opt,name=arn,proto3" json:"arn,omitempty"`
+	Kind NotificationTargetKind `protobuf:"varint,3,opt,name=kind,proto3,enum=io.treeverse.lakefs.graveler.NotificationTargetKind" json:"kind,omitempty"`
+	// events are the names of the events sent to the target, may end with a "*" wildcard
+	Events []string `protobuf:"bytes,4,rep,name=events,proto3" json:"events,omitempty"`
+	// filter_rules match the keys of the events, a key is the branch followed by the object path
+	FilterRules   []*NotificationFilterRule `protobuf:"bytes,5,rep,name=filter_rules,json=filterRules,proto3" json:"filter_rules,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *LinkAddressData) Reset() {
-	*x = LinkAddressData{}
+func (x *NotificationTarget) Reset() {
+	*x = NotificationTarget{}
 	mi := &file_graveler_graveler_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *LinkAddressData) String() string {
+func (x *NotificationTarget) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*LinkAddressData) ProtoMessage() {}
+func (*NotificationTarget) ProtoMessage() {}
 
-func (x *LinkAddressData) ProtoReflect() protoreflect.Message {
+func (x *NotificationTarget) ProtoReflect() protoreflect.Message {
 	mi := &file_graveler_graveler_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -733,45 +925,67 @@ func (x *LinkAddressData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use LinkAddressData.ProtoReflect.Descriptor instead.
-func (*LinkAddressData) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotificationTarget.ProtoReflect.Descriptor instead.
+func (*NotificationTarget) Descriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{8}
 }
 
-func (x *LinkAddressData) GetAddress() string {
+func (x *NotificationTarget) GetId() string {
 	if x != nil {
-		return x.Address
+		return x.Id
 	}
 	return ""
 }
 
-type ImportStatusData struct {
+func (x *NotificationTarget) GetArn() string {
+	if x != nil {
+		return x.Arn
+	}
+	return ""
+}
+
+func (x *NotificationTarget) GetKind() NotificationTargetKind {
+	if x != nil {
+		return x.Kind
+	}
+	return NotificationTargetKind_QUEUE
+}
+
+func (x *NotificationTarget) GetEvents() []string {
+	if x != nil {
+		return x.Events
+	}
+	return nil
+}
+
+func (x *NotificationTarget) GetFilterRules() []*NotificationFilterRule {
+	if x != nil {
+		return x.FilterRules
+	}
+	return nil
+}
+
+type NotificationConfiguration struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
-	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
-	Completed     bool                   `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
-	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	Progress      int64                  `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
-	MetarangeId   string                 `protobuf:"bytes,5,opt,name=metarange_id,json=metarangeId,proto3" json:"metarange_id,omitempty"`
-	Commit        *CommitData            `protobuf:"bytes,6,opt,name=commit,proto3" json:"commit,omitempty"`
-	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	Targets       []*NotificationTarget  `protobuf:"bytes,1,rep,name=targets,proto3" json:"targets,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImportStatusData) Reset() {
-	*x = ImportStatusData{}
+func (x *NotificationConfiguration) Reset() {
+	*x = NotificationConfiguration{}
 	mi := &file_graveler_graveler_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImportStatusData) String() string {
+func (x *NotificationConfiguration) String() string {
 	return protoimpl.X.MessageStringOf(x)
 }
 
-func (*ImportStatusData) ProtoMessage() {}
+func (*NotificationConfiguration) ProtoMessage() {}
 
-func (x *ImportStatusData) ProtoReflect() protoreflect.Message {
+func (x *NotificationConfiguration) ProtoReflect() protoreflect.Message {
 	mi := &file_graveler_graveler_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
@@ -783,40 +997,433 @@ func (x *ImportStatusData) ProtoReflect() protoreflect.Message {
 	return mi.MessageOf(x)
 }
 
-// Deprecated: Use ImportStatusData.ProtoReflect.Descriptor instead.
-func (*ImportStatusData) Descriptor() ([]byte, []int) {
+// Deprecated: Use NotificationConfiguration.ProtoReflect.Descriptor instead.
+func (*NotificationConfiguration) Descriptor() ([]byte, []int) {
 	return file_graveler_graveler_proto_rawDescGZIP(), []int{9}
 }
 
-func (x *ImportStatusData) GetId() string {
+func (x *NotificationConfiguration) GetTargets() []*NotificationTarget {
 	if x != nil {
-		return x.Id
+		return x.Targets
 	}
-	return ""
+	return nil
 }
 
-func (x *ImportStatusData) GetCompleted() bool {
-	if x != nil {
-		return x.Completed
-	}
-	return false
+type ObjectLockConfiguration struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// enabled allows locking objects of the repository, once enabled it cannot be disabled
+	Enabled       bool `protobuf:"varint,1,opt,name=enabled,proto3" json:"enabled,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
 }
 
-func (x *ImportStatusData) GetUpdatedAt() *timestamppb.Timestamp {
-	if x != nil {
-		return x.UpdatedAt
-	}
-	return nil
+func (x *ObjectLockConfiguration) Reset() {
+	*x = ObjectLockConfiguration{}
+	mi := &file_graveler_graveler_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
 }
 
-func (x *ImportStatusData) GetProgress() int64 {
-	if x != nil {
-		return x.Progress
-	}
-	return 0
+func (x *ObjectLockConfiguration) String() string {
+	return protoimpl.X.MessageStringOf(x)
 }
 
-func (x *ImportStatusData) GetMetarangeId() string {
+func (*ObjectLockConfiguration) ProtoMessage() {}
+
+func (x *ObjectLockConfiguration) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectLockConfiguration.ProtoReflect.Descriptor instead.
+func (*ObjectLockConfiguration) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ObjectLockConfiguration) GetEnabled() bool {
+	if x != nil {
+		return x.Enabled
+	}
+	return false
+}
+
+type ObjectLockData struct {
+	state    protoimpl.MessageState `protogen:"open.v1"`
+	BranchId string                 `protobuf:"bytes,1,opt,name=branch_id,json=branchId,proto3" json:"branch_id,omitempty"`
+	Key      []byte                 `protobuf:"bytes,2,opt,name=key,proto3" json:"key,omitempty"`
+	// commit_id is the commit holding the locked version of the object
+	CommitId string `protobuf:"bytes,3,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	// identity of the locked version of the object
+	Identity []byte         `protobuf:"bytes,4,opt,name=identity,proto3" json:"identity,omitempty"`
+	Mode     ObjectLockMode `protobuf:"varint,5,opt,name=mode,proto3,enum=io.treeverse.lakefs.graveler.ObjectLockMode" json:"mode,omitempty"`
+	// retain_until is the end of the retention period, unset without retention
+	RetainUntil   *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=retain_until,json=retainUntil,proto3" json:"retain_until,omitempty"`
+	LegalHold     bool                   `protobuf:"varint,7,opt,name=legal_hold,json=legalHold,proto3" json:"legal_hold,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ObjectLockData) Reset() {
+	*x = ObjectLockData{}
+	mi := &file_graveler_graveler_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ObjectLockData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ObjectLockData) ProtoMessage() {}
+
+func (x *ObjectLockData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ObjectLockData.ProtoReflect.Descriptor instead.
+func (*ObjectLockData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ObjectLockData) GetBranchId() string {
+	if x != nil {
+		return x.BranchId
+	}
+	return ""
+}
+
+func (x *ObjectLockData) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *ObjectLockData) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+func (x *ObjectLockData) GetIdentity() []byte {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *ObjectLockData) GetMode() ObjectLockMode {
+	if x != nil {
+		return x.Mode
+	}
+	return ObjectLockMode_GOVERNANCE
+}
+
+func (x *ObjectLockData) GetRetainUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.RetainUntil
+	}
+	return nil
+}
+
+func (x *ObjectLockData) GetLegalHold() bool {
+	if x != nil {
+		return x.LegalHold
+	}
+	return false
+}
+
+type BranchProtectionBlockedActions struct {
+	state protoimpl.MessageState          `protogen:"open.v1"`
+	Value []BranchProtectionBlockedAction `protobuf:"varint,1,rep,packed,name=value,proto3,enum=io.treeverse.lakefs.graveler.BranchProtectionBlockedAction" json:"value,omitempty"`
+	// required_approvals is the number of approvals MERGE_WITHOUT_APPROVAL requires, at least 1
+	RequiredApprovals int32 `protobuf:"varint,2,opt,name=required_approvals,json=requiredApprovals,proto3" json:"required_approvals,omitempty"`
+	unknownFields     protoimpl.UnknownFields
+	sizeCache         protoimpl.SizeCache
+}
+
+func (x *BranchProtectionBlockedActions) Reset() {
+	*x = BranchProtectionBlockedActions{}
+	mi := &file_graveler_graveler_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchProtectionBlockedActions) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchProtectionBlockedActions) ProtoMessage() {}
+
+func (x *BranchProtectionBlockedActions) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchProtectionBlockedActions.ProtoReflect.Descriptor instead.
+func (*BranchProtectionBlockedActions) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *BranchProtectionBlockedActions) GetValue() []BranchProtectionBlockedAction {
+	if x != nil {
+		return x.Value
+	}
+	return nil
+}
+
+func (x *BranchProtectionBlockedActions) GetRequiredApprovals() int32 {
+	if x != nil {
+		return x.RequiredApprovals
+	}
+	return 0
+}
+
+type BranchProtectionRules struct {
+	state                         protoimpl.MessageState                     `protogen:"open.v1"`
+	BranchPatternToBlockedActions map[string]*BranchProtectionBlockedActions `protobuf:"bytes,1,rep,name=branch_pattern_to_blocked_actions,json=branchPatternToBlockedActions,proto3" json:"branch_pattern_to_blocked_actions,omitempty" protobuf_key:"bytes,1,opt,name=key" protobuf_val:"bytes,2,opt,name=value"`
+	unknownFields                 protoimpl.UnknownFields
+	sizeCache                     protoimpl.SizeCache
+}
+
+func (x *BranchProtectionRules) Reset() {
+	*x = BranchProtectionRules{}
+	mi := &file_graveler_graveler_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *BranchProtectionRules) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*BranchProtectionRules) ProtoMessage() {}
+
+func (x *BranchProtectionRules) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use BranchProtectionRules.ProtoReflect.Descriptor instead.
+func (*BranchProtectionRules) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *BranchProtectionRules) GetBranchPatternToBlockedActions() map[string]*BranchProtectionBlockedActions {
+	if x != nil {
+		return x.BranchPatternToBlockedActions
+	}
+	return nil
+}
+
+type StagedEntryData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Key           []byte                 `protobuf:"bytes,1,opt,name=key,proto3" json:"key,omitempty"`
+	Identity      []byte                 `protobuf:"bytes,2,opt,name=identity,proto3" json:"identity,omitempty"`
+	Data          []byte                 `protobuf:"bytes,3,opt,name=data,proto3" json:"data,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *StagedEntryData) Reset() {
+	*x = StagedEntryData{}
+	mi := &file_graveler_graveler_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *StagedEntryData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*StagedEntryData) ProtoMessage() {}
+
+func (x *StagedEntryData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use StagedEntryData.ProtoReflect.Descriptor instead.
+func (*StagedEntryData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *StagedEntryData) GetKey() []byte {
+	if x != nil {
+		return x.Key
+	}
+	return nil
+}
+
+func (x *StagedEntryData) GetIdentity() []byte {
+	if x != nil {
+		return x.Identity
+	}
+	return nil
+}
+
+func (x *StagedEntryData) GetData() []byte {
+	if x != nil {
+		return x.Data
+	}
+	return nil
+}
+
+// message data model to track address tokens
+type LinkAddressData struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// xid physical address that includes the creation date
+	Address       string `protobuf:"bytes,1,opt,name=address,proto3" json:"address,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *LinkAddressData) Reset() {
+	*x = LinkAddressData{}
+	mi := &file_graveler_graveler_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *LinkAddressData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*LinkAddressData) ProtoMessage() {}
+
+func (x *LinkAddressData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use LinkAddressData.ProtoReflect.Descriptor instead.
+func (*LinkAddressData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *LinkAddressData) GetAddress() string {
+	if x != nil {
+		return x.Address
+	}
+	return ""
+}
+
+type ImportStatusData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Completed     bool                   `protobuf:"varint,2,opt,name=completed,proto3" json:"completed,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Progress      int64                  `protobuf:"varint,4,opt,name=progress,proto3" json:"progress,omitempty"`
+	MetarangeId   string                 `protobuf:"bytes,5,opt,name=metarange_id,json=metarangeId,proto3" json:"metarange_id,omitempty"`
+	Commit        *CommitData            `protobuf:"bytes,6,opt,name=commit,proto3" json:"commit,omitempty"`
+	Error         string                 `protobuf:"bytes,7,opt,name=error,proto3" json:"error,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ImportStatusData) Reset() {
+	*x = ImportStatusData{}
+	mi := &file_graveler_graveler_proto_msgTypes[16]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ImportStatusData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ImportStatusData) ProtoMessage() {}
+
+func (x *ImportStatusData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[16]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ImportStatusData.ProtoReflect.Descriptor instead.
+func (*ImportStatusData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{16}
+}
+
+func (x *ImportStatusData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *ImportStatusData) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *ImportStatusData) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *ImportStatusData) GetProgress() int64 {
+	if x != nil {
+		return x.Progress
+	}
+	return 0
+}
+
+func (x *ImportStatusData) GetMetarangeId() string {
 	if x != nil {
 		return x.MetarangeId
 	}
@@ -846,7 +1453,7 @@ type RepoMetadata struct {
 
 func (x *RepoMetadata) Reset() {
 	*x = RepoMetadata{}
-	mi := &file_graveler_graveler_proto_msgTypes[10]
+	mi := &file_graveler_graveler_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -858,7 +1465,7 @@ func (x *RepoMetadata) String() string {
 func (*RepoMetadata) ProtoMessage() {}
 
 func (x *RepoMetadata) ProtoReflect() protoreflect.Message {
-	mi := &file_graveler_graveler_proto_msgTypes[10]
+	mi := &file_graveler_graveler_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -871,7 +1478,7 @@ func (x *RepoMetadata) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use RepoMetadata.ProtoReflect.Descriptor instead.
 func (*RepoMetadata) Descriptor() ([]byte, []int) {
-	return file_graveler_graveler_proto_rawDescGZIP(), []int{10}
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *RepoMetadata) GetMetadata() map[string]string {
@@ -894,14 +1501,15 @@ type PullRequestData struct {
 	// commit_id relevant only for merged PRs
 	CommitId *string `protobuf:"bytes,9,opt,name=commit_id,json=commitId,proto3,oneof" json:"commit_id,omitempty"`
 	// closed_at relevant only for merged or closed PRs
-	ClosedAt      *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=closed_at,json=closedAt,proto3,oneof" json:"closed_at,omitempty"`
+	ClosedAt      *timestamppb.Timestamp   `protobuf:"bytes,10,opt,name=closed_at,json=closedAt,proto3,oneof" json:"closed_at,omitempty"`
+	Reviews       []*PullRequestReviewData `protobuf:"bytes,11,rep,name=reviews,proto3" json:"reviews,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
 
 func (x *PullRequestData) Reset() {
 	*x = PullRequestData{}
-	mi := &file_graveler_graveler_proto_msgTypes[11]
+	mi := &file_graveler_graveler_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -913,7 +1521,7 @@ func (x *PullRequestData) String() string {
 func (*PullRequestData) ProtoMessage() {}
 
 func (x *PullRequestData) ProtoReflect() protoreflect.Message {
-	mi := &file_graveler_graveler_proto_msgTypes[11]
+	mi := &file_graveler_graveler_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -926,7 +1534,7 @@ func (x *PullRequestData) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PullRequestData.ProtoReflect.Descriptor instead.
 func (*PullRequestData) Descriptor() ([]byte, []int) {
-	return file_graveler_graveler_proto_rawDescGZIP(), []int{11}
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *PullRequestData) GetId() string {
@@ -999,6 +1607,210 @@ func (x *PullRequestData) GetClosedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *PullRequestData) GetReviews() []*PullRequestReviewData {
+	if x != nil {
+		return x.Reviews
+	}
+	return nil
+}
+
+type PullRequestReviewData struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Reviewer  string                 `protobuf:"bytes,1,opt,name=reviewer,proto3" json:"reviewer,omitempty"`
+	State     PullRequestReviewState `protobuf:"varint,2,opt,name=state,proto3,enum=io.treeverse.lakefs.graveler.PullRequestReviewState" json:"state,omitempty"`
+	Comment   string                 `protobuf:"bytes,3,opt,name=comment,proto3" json:"comment,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// commit_id is the head of the source branch when reviewed
+	CommitId      string `protobuf:"bytes,5,opt,name=commit_id,json=commitId,proto3" json:"commit_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequestReviewData) Reset() {
+	*x = PullRequestReviewData{}
+	mi := &file_graveler_graveler_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestReviewData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestReviewData) ProtoMessage() {}
+
+func (x *PullRequestReviewData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestReviewData.ProtoReflect.Descriptor instead.
+func (*PullRequestReviewData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *PullRequestReviewData) GetReviewer() string {
+	if x != nil {
+		return x.Reviewer
+	}
+	return ""
+}
+
+func (x *PullRequestReviewData) GetState() PullRequestReviewState {
+	if x != nil {
+		return x.State
+	}
+	return PullRequestReviewState_COMMENTED
+}
+
+func (x *PullRequestReviewData) GetComment() string {
+	if x != nil {
+		return x.Comment
+	}
+	return ""
+}
+
+func (x *PullRequestReviewData) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestReviewData) GetCommitId() string {
+	if x != nil {
+		return x.CommitId
+	}
+	return ""
+}
+
+type PullRequestCommentData struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	PullRequestId string                 `protobuf:"bytes,2,opt,name=pull_request_id,json=pullRequestId,proto3" json:"pull_request_id,omitempty"`
+	Author        string                 `protobuf:"bytes,3,opt,name=author,proto3" json:"author,omitempty"`
+	Body          string                 `protobuf:"bytes,4,opt,name=body,proto3" json:"body,omitempty"`
+	// path is the object in the pull request diff the comment discusses, empty for the whole pull request
+	Path string `protobuf:"bytes,5,opt,name=path,proto3" json:"path,omitempty"`
+	// reply_to is the first comment of the thread, empty for the first comment of a thread
+	ReplyTo   string                 `protobuf:"bytes,6,opt,name=reply_to,json=replyTo,proto3" json:"reply_to,omitempty"`
+	CreatedAt *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	// updated_at relevant only for edited comments
+	UpdatedAt *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3,oneof" json:"updated_at,omitempty"`
+	Resolved  bool                   `protobuf:"varint,9,opt,name=resolved,proto3" json:"resolved,omitempty"`
+	// resolved_by relevant only for resolved threads
+	ResolvedBy    string `protobuf:"bytes,10,opt,name=resolved_by,json=resolvedBy,proto3" json:"resolved_by,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *PullRequestCommentData) Reset() {
+	*x = PullRequestCommentData{}
+	mi := &file_graveler_graveler_proto_msgTypes[20]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *PullRequestCommentData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*PullRequestCommentData) ProtoMessage() {}
+
+func (x *PullRequestCommentData) ProtoReflect() protoreflect.Message {
+	mi := &file_graveler_graveler_proto_msgTypes[20]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use PullRequestCommentData.ProtoReflect.Descriptor instead.
+func (*PullRequestCommentData) Descriptor() ([]byte, []int) {
+	return file_graveler_graveler_proto_rawDescGZIP(), []int{20}
+}
+
+func (x *PullRequestCommentData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetPullRequestId() string {
+	if x != nil {
+		return x.PullRequestId
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetAuthor() string {
+	if x != nil {
+		return x.Author
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetBody() string {
+	if x != nil {
+		return x.Body
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetPath() string {
+	if x != nil {
+		return x.Path
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetReplyTo() string {
+	if x != nil {
+		return x.ReplyTo
+	}
+	return ""
+}
+
+func (x *PullRequestCommentData) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestCommentData) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *PullRequestCommentData) GetResolved() bool {
+	if x != nil {
+		return x.Resolved
+	}
+	return false
+}
+
+func (x *PullRequestCommentData) GetResolvedBy() string {
+	if x != nil {
+		return x.ResolvedBy
+	}
+	return ""
+}
+
 var File_graveler_graveler_proto protoreflect.FileDescriptor
 
 const file_graveler_graveler_proto_rawDesc = "" +
@@ -1045,9 +1857,41 @@ const file_graveler_graveler_proto_rawDesc = "" +
 	"\x15branch_retention_days\x18\x02 \x03(\v2M.io.treeverse.lakefs.graveler.GarbageCollectionRules.BranchRetentionDaysEntryR\x13branchRetentionDays\x1aF\n" +
 	"\x18BranchRetentionDaysEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"s\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xf6\x01\n" +
+	"\rLifecycleRule\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x16\n" +
+	"\x06prefix\x18\x02 \x01(\tR\x06prefix\x12%\n" +
+	"\x0ebranch_pattern\x18\x03 \x01(\tR\rbranchPattern\x12\x18\n" +
+	"\aenabled\x18\x04 \x01(\bR\aenabled\x12@\n" +
+	"\x1cuncommitted_expiration_hours\x18\x05 \x01(\x05R\x1auncommittedExpirationHours\x12:\n" +
+	"\x19committed_expiration_days\x18\x06 \x01(\x05R\x17committedExpirationDays\"S\n" +
+	"\x0eLifecycleRules\x12A\n" +
+	"\x05rules\x18\x01 \x03(\v2+.io.treeverse.lakefs.graveler.LifecycleRuleR\x05rules\"B\n" +
+	"\x16NotificationFilterRule\x12\x12\n" +
+	"\x04name\x18\x01 \x01(\tR\x04name\x12\x14\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value\"\xf1\x01\n" +
+	"\x12NotificationTarget\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12\x10\n" +
+	"\x03arn\x18\x02 \x01(\tR\x03arn\x12H\n" +
+	"\x04kind\x18\x03 \x01(\x0e24.io.treeverse.lakefs.graveler.NotificationTargetKindR\x04kind\x12\x16\n" +
+	"\x06events\x18\x04 \x03(\tR\x06events\x12W\n" +
+	"\ffilter_rules\x18\x05 \x03(\v24.io.treeverse.lakefs.graveler.NotificationFilterRuleR\vfilterRules\"g\n" +
+	"\x19NotificationConfiguration\x12J\n" +
+	"\atargets\x18\x01 \x03(\v20.io.treeverse.lakefs.graveler.NotificationTargetR\atargets\"3\n" +
+	"\x17ObjectLockConfiguration\x12\x18\n" +
+	"\aenabled\x18\x01 \x01(\bR\aenabled\"\x98\x02\n" +
+	"\x0eObjectLockData\x12\x1b\n" +
+	"\tbranch_id\x18\x01 \x01(\tR\bbranchId\x12\x10\n" +
+	"\x03key\x18\x02 \x01(\fR\x03key\x12\x1b\n" +
+	"\tcommit_id\x18\x03 \x01(\tR\bcommitId\x12\x1a\n" +
+	"\bidentity\x18\x04 \x01(\fR\bidentity\x12@\n" +
+	"\x04mode\x18\x05 \x01(\x0e2,.io.treeverse.lakefs.graveler.ObjectLockModeR\x04mode\x12=\n" +
+	"\fretain_until\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\vretainUntil\x12\x1d\n" +
+	"\n" +
+	"legal_hold\x18\a \x01(\bR\tlegalHold\"\xa2\x01\n" +
 	"\x1eBranchProtectionBlockedActions\x12Q\n" +
-	"\x05value\x18\x01 \x03(\x0e2;.io.treeverse.lakefs.graveler.BranchProtectionBlockedActionR\x05value\"\xcb\x02\n" +
+	"\x05value\x18\x01 \x03(\x0e2;.io.treeverse.lakefs.graveler.BranchProtectionBlockedActionR\x05value\x12-\n" +
+	"\x12required_approvals\x18\x02 \x01(\x05R\x11requiredApprovals\"\xcb\x02\n" +
 	"\x15BranchProtectionRules\x12\xa0\x01\n" +
 	"!branch_pattern_to_blocked_actions\x18\x01 \x03(\v2V.io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntryR\x1dbranchPatternToBlockedActions\x1a\x8e\x01\n" +
 	"\"BranchPatternToBlockedActionsEntry\x12\x10\n" +
@@ -1072,7 +1916,7 @@ const file_graveler_graveler_proto_rawDesc = "" +
 	"\bmetadata\x18\x01 \x03(\v28.io.treeverse.lakefs.graveler.RepoMetadata.MetadataEntryR\bmetadata\x1a;\n" +
 	"\rMetadataEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\xc5\x03\n" +
+	"\x05value\x18\x02 \x01(\tR\x05value:\x028\x01\"\x94\x04\n" +
 	"\x0fPullRequestData\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\tR\x02id\x12G\n" +
 	"\x06status\x18\x02 \x01(\x0e2/.io.treeverse.lakefs.graveler.PullRequestStatusR\x06status\x129\n" +
@@ -1085,25 +1929,63 @@ const file_graveler_graveler_proto_rawDesc = "" +
 	"\x12destination_branch\x18\b \x01(\tR\x11destinationBranch\x12 \n" +
 	"\tcommit_id\x18\t \x01(\tH\x00R\bcommitId\x88\x01\x01\x12<\n" +
 	"\tclosed_at\x18\n" +
-	" \x01(\v2\x1a.google.protobuf.TimestampH\x01R\bclosedAt\x88\x01\x01B\f\n" +
+	" \x01(\v2\x1a.google.protobuf.TimestampH\x01R\bclosedAt\x88\x01\x01\x12M\n" +
+	"\areviews\x18\v \x03(\v23.io.treeverse.lakefs.graveler.PullRequestReviewDataR\areviewsB\f\n" +
 	"\n" +
 	"_commit_idB\f\n" +
 	"\n" +
-	"_closed_at*.\n" +
+	"_closed_at\"\xf1\x01\n" +
+	"\x15PullRequestReviewData\x12\x1a\n" +
+	"\breviewer\x18\x01 \x01(\tR\breviewer\x12J\n" +
+	"\x05state\x18\x02 \x01(\x0e24.io.treeverse.lakefs.graveler.PullRequestReviewStateR\x05state\x12\x18\n" +
+	"\acomment\x18\x03 \x01(\tR\acomment\x129\n" +
+	"\n" +
+	"created_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12\x1b\n" +
+	"\tcommit_id\x18\x05 \x01(\tR\bcommitId\"\xf2\x02\n" +
+	"\x16PullRequestCommentData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12&\n" +
+	"\x0fpull_request_id\x18\x02 \x01(\tR\rpullRequestId\x12\x16\n" +
+	"\x06author\x18\x03 \x01(\tR\x06author\x12\x12\n" +
+	"\x04body\x18\x04 \x01(\tR\x04body\x12\x12\n" +
+	"\x04path\x18\x05 \x01(\tR\x04path\x12\x19\n" +
+	"\breply_to\x18\x06 \x01(\tR\areplyTo\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x12>\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampH\x00R\tupdatedAt\x88\x01\x01\x12\x1a\n" +
+	"\bresolved\x18\t \x01(\bR\bresolved\x12\x1f\n" +
+	"\vresolved_by\x18\n" +
+	" \x01(\tR\n" +
+	"resolvedByB\r\n" +
+	"\v_updated_at*.\n" +
 	"\x0fRepositoryState\x12\n" +
 	"\n" +
 	"\x06ACTIVE\x10\x00\x12\x0f\n" +
-	"\vIN_DELETION\x10\x01*>\n" +
+	"\vIN_DELETION\x10\x01*B\n" +
+	"\x16NotificationTargetKind\x12\t\n" +
+	"\x05QUEUE\x10\x00\x12\t\n" +
+	"\x05TOPIC\x10\x01\x12\x12\n" +
+	"\x0eCLOUD_FUNCTION\x10\x02*0\n" +
+	"\x0eObjectLockMode\x12\x0e\n" +
+	"\n" +
+	"GOVERNANCE\x10\x00\x12\x0e\n" +
+	"\n" +
+	"COMPLIANCE\x10\x01*Z\n" +
 	"\x1dBranchProtectionBlockedAction\x12\x11\n" +
 	"\rSTAGING_WRITE\x10\x00\x12\n" +
 	"\n" +
-	"\x06COMMIT\x10\x01*5\n" +
+	"\x06COMMIT\x10\x01\x12\x1a\n" +
+	"\x16MERGE_WITHOUT_APPROVAL\x10\x02*5\n" +
 	"\x11PullRequestStatus\x12\b\n" +
 	"\x04OPEN\x10\x00\x12\n" +
 	"\n" +
 	"\x06CLOSED\x10\x01\x12\n" +
 	"\n" +
-	"\x06MERGED\x10\x02B&Z$github.com/treeverse/lakefs/gravelerb\x06proto3"
+	"\x06MERGED\x10\x02*L\n" +
+	"\x16PullRequestReviewState\x12\r\n" +
+	"\tCOMMENTED\x10\x00\x12\f\n" +
+	"\bAPPROVED\x10\x01\x12\x15\n" +
+	"\x11CHANGES_REQUESTED\x10\x02B&Z$github.com/treeverse/lakefs/gravelerb\x06proto3"
 
 var (
 	file_graveler_graveler_proto_rawDescOnce sync.Once
@@ -1117,50 +1999,73 @@ func file_graveler_graveler_proto_rawDescGZIP() []byte {
 	return file_graveler_graveler_proto_rawDescData
 }
 
-var file_graveler_graveler_proto_enumTypes = make([]protoimpl.EnumInfo, 3)
-var file_graveler_graveler_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_graveler_graveler_proto_enumTypes = make([]protoimpl.EnumInfo, 6)
+var file_graveler_graveler_proto_msgTypes = make([]protoimpl.MessageInfo, 25)
 var file_graveler_graveler_proto_goTypes = []any{
 	(RepositoryState)(0),                   // 0: io.treeverse.lakefs.graveler.RepositoryState
-	(BranchProtectionBlockedAction)(0),     // 1: io.treeverse.lakefs.graveler.BranchProtectionBlockedAction
-	(PullRequestStatus)(0),                 // 2: io.treeverse.lakefs.graveler.PullRequestStatus
-	(*RepositoryData)(nil),                 // 3: io.treeverse.lakefs.graveler.RepositoryData
-	(*BranchData)(nil),                     // 4: io.treeverse.lakefs.graveler.BranchData
-	(*TagData)(nil),                        // 5: io.treeverse.lakefs.graveler.TagData
-	(*CommitData)(nil),                     // 6: io.treeverse.lakefs.graveler.CommitData
-	(*GarbageCollectionRules)(nil),         // 7: io.treeverse.lakefs.graveler.GarbageCollectionRules
-	(*BranchProtectionBlockedActions)(nil), // 8: io.treeverse.lakefs.graveler.BranchProtectionBlockedActions
-	(*BranchProtectionRules)(nil),          // 9: io.treeverse.lakefs.graveler.BranchProtectionRules
-	(*StagedEntryData)(nil),                // 10: io.treeverse.lakefs.graveler.StagedEntryData
-	(*LinkAddressData)(nil),                // 11: io.treeverse.lakefs.graveler.LinkAddressData
-	(*ImportStatusData)(nil),               // 12: io.treeverse.lakefs.graveler.ImportStatusData
-	(*RepoMetadata)(nil),                   // 13: io.treeverse.lakefs.graveler.RepoMetadata
-	(*PullRequestData)(nil),                // 14: io.treeverse.lakefs.graveler.PullRequestData
-	nil,                                    // 15: io.treeverse.lakefs.graveler.CommitData.MetadataEntry
-	nil,                                    // 16: io.treeverse.lakefs.graveler.GarbageCollectionRules.BranchRetentionDaysEntry
-	nil,                                    // 17: io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry
-	nil,                                    // 18: io.treeverse.lakefs.graveler.RepoMetadata.MetadataEntry
-	(*timestamppb.Timestamp)(nil),          // 19: google.protobuf.Timestamp
+	(NotificationTargetKind)(0),            // 1: io.treeverse.lakefs.graveler.NotificationTargetKind
+	(ObjectLockMode)(0),                    // 2: io.treeverse.lakefs.graveler.ObjectLockMode
+	(BranchProtectionBlockedAction)(0),     // 3: io.treeverse.lakefs.graveler.BranchProtectionBlockedAction
+	(PullRequestStatus)(0),                 // 4: io.treeverse.lakefs.graveler.PullRequestStatus
+	(PullRequestReviewState)(0),            // 5: io.treeverse.lakefs.graveler.PullRequestReviewState
+	(*RepositoryData)(nil),                 // 6: io.treeverse.lakefs.graveler.RepositoryData
+	(*BranchData)(nil),                     // 7: io.treeverse.lakefs.graveler.BranchData
+	(*TagData)(nil),                        // 8: io.treeverse.lakefs.graveler.TagData
+	(*CommitData)(nil),                     // 9: io.treeverse.lakefs.graveler.CommitData
+	(*GarbageCollectionRules)(nil),         // 10: io.treeverse.lakefs.graveler.GarbageCollectionRules
+	(*LifecycleRule)(nil),                  // 11: io.treeverse.lakefs.graveler.LifecycleRule
+	(*LifecycleRules)(nil),                 // 12: io.treeverse.lakefs.graveler.LifecycleRules
+	(*NotificationFilterRule)(nil),         // 13: io.treeverse.lakefs.graveler.NotificationFilterRule
+	(*NotificationTarget)(nil),             // 14: io.treeverse.lakefs.graveler.NotificationTarget
+	(*NotificationConfiguration)(nil),      // 15: io.treeverse.lakefs.graveler.NotificationConfiguration
+	(*ObjectLockConfiguration)(nil),        // 16: io.treeverse.lakefs.graveler.ObjectLockConfiguration
+	(*ObjectLockData)(nil),                 // 17: io.treeverse.lakefs.graveler.ObjectLockData
+	(*BranchProtectionBlockedActions)(nil), // 18: io.treeverse.lakefs.graveler.BranchProtectionBlockedActions
+	(*BranchProtectionRules)(nil),          // 19: io.treeverse.lakefs.graveler.BranchProtectionRules
+	(*StagedEntryData)(nil),                // 20: io.treeverse.lakefs.graveler.StagedEntryData
+	(*LinkAddressData)(nil),                // 21: io.treeverse.lakefs.graveler.LinkAddressData
+	(*ImportStatusData)(nil),               // 22: io.treeverse.lakefs.graveler.ImportStatusData
+	(*RepoMetadata)(nil),                   // 23: io.treeverse.lakefs.graveler.RepoMetadata
+	(*PullRequestData)(nil),                // 24: io.treeverse.lakefs.graveler.PullRequestData
+	(*PullRequestReviewData)(nil),          // 25: io.treeverse.lakefs.graveler.PullRequestReviewData
+	(*PullRequestCommentData)(nil),         // 26: io.treeverse.lakefs.graveler.PullRequestCommentData
+	nil,                                    // 27: io.treeverse.lakefs.graveler.CommitData.MetadataEntry
+	nil,                                    // 28: io.treeverse.lakefs.graveler.GarbageCollectionRules.BranchRetentionDaysEntry
+	nil,                                    // 29: io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry
+	nil,                                    // 30: io.treeverse.lakefs.graveler.RepoMetadata.MetadataEntry
+	(*timestamppb.Timestamp)(nil),          // 31: google.protobuf.Timestamp
 }
 var file_graveler_graveler_proto_depIdxs = []int32{
-	19, // 0: io.treeverse.lakefs.graveler.RepositoryData.creation_date:type_name -> google.protobuf.Timestamp
+	31, // 0: io.treeverse.lakefs.graveler.RepositoryData.creation_date:type_name -> google.protobuf.Timestamp
 	0,  // 1: io.treeverse.lakefs.graveler.RepositoryData.state:type_name -> io.treeverse.lakefs.graveler.RepositoryState
-	19, // 2: io.treeverse.lakefs.graveler.CommitData.creation_date:type_name -> google.protobuf.Timestamp
-	15, // 3: io.treeverse.lakefs.graveler.CommitData.metadata:type_name -> io.treeverse.lakefs.graveler.CommitData.MetadataEntry
-	16, // 4: io.treeverse.lakefs.graveler.GarbageCollectionRules.branch_retention_days:type_name -> io.treeverse.lakefs.graveler.GarbageCollectionRules.BranchRetentionDaysEntry
-	1,  // 5: io.treeverse.lakefs.graveler.BranchProtectionBlockedActions.value:type_name -> io.treeverse.lakefs.graveler.BranchProtectionBlockedAction
-	17, // 6: io.treeverse.lakefs.graveler.BranchProtectionRules.branch_pattern_to_blocked_actions:type_name -> io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry
-	19, // 7: io.treeverse.lakefs.graveler.ImportStatusData.updated_at:type_name -> google.protobuf.Timestamp
-	6,  // 8: io.treeverse.lakefs.graveler.ImportStatusData.commit:type_name -> io.treeverse.lakefs.graveler.CommitData
-	18, // 9: io.treeverse.lakefs.graveler.RepoMetadata.metadata:type_name -> io.treeverse.lakefs.graveler.RepoMetadata.MetadataEntry
-	2,  // 10: io.treeverse.lakefs.graveler.PullRequestData.status:type_name -> io.treeverse.lakefs.graveler.PullRequestStatus
-	19, // 11: io.treeverse.lakefs.graveler.PullRequestData.created_at:type_name -> google.protobuf.Timestamp
-	19, // 12: io.treeverse.lakefs.graveler.PullRequestData.closed_at:type_name -> google.protobuf.Timestamp
-	8,  // 13: io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry.value:type_name -> io.treeverse.lakefs.graveler.BranchProtectionBlockedActions
-	14, // [14:14] is the sub-list for method output_type
-	14, // [14:14] is the sub-list for method input_type
-	14, // [14:14] is the sub-list for extension type_name
-	14, // [14:14] is the sub-list for extension extendee
-	0,  // [0:14] is the sub-list for field type_name
+	31, // 2: io.treeverse.lakefs.graveler.CommitData.creation_date:type_name -> google.protobuf.Timestamp
+	27, // 3: io.treeverse.lakefs.graveler.CommitData.metadata:type_name -> io.treeverse.lakefs.graveler.CommitData.MetadataEntry
+	28, // 4: io.treeverse.lakefs.graveler.GarbageCollectionRules.branch_retention_days:type_name -> io.treeverse.lakefs.graveler.GarbageCollectionRules.BranchRetentionDaysEntry
+	11, // 5: io.treeverse.lakefs.graveler.LifecycleRules.rules:type_name -> io.treeverse.lakefs.graveler.LifecycleRule
+	1,  // 6: io.treeverse.lakefs.graveler.NotificationTarget.kind:type_name -> io.treeverse.lakefs.graveler.NotificationTargetKind
+	13, // 7: io.treeverse.lakefs.graveler.NotificationTarget.filter_rules:type_name -> io.treeverse.lakefs.graveler.NotificationFilterRule
+	14, // 8: io.treeverse.lakefs.graveler.NotificationConfiguration.targets:type_name -> io.treeverse.lakefs.graveler.NotificationTarget
+	2,  // 9: io.treeverse.lakefs.graveler.ObjectLockData.mode:type_name -> io.treeverse.lakefs.graveler.ObjectLockMode
+	31, // 10: io.treeverse.lakefs.graveler.ObjectLockData.retain_until:type_name -> google.protobuf.Timestamp
+	3,  // 11: io.treeverse.lakefs.graveler.BranchProtectionBlockedActions.value:type_name -> io.treeverse.lakefs.graveler.BranchProtectionBlockedAction
+	29, // 12: io.treeverse.lakefs.graveler.BranchProtectionRules.branch_pattern_to_blocked_actions:type_name -> io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry
+	31, // 13: io.treeverse.lakefs.graveler.ImportStatusData.updated_at:type_name -> google.protobuf.Timestamp
+	9,  // 14: io.treeverse.lakefs.graveler.ImportStatusData.commit:type_name -> io.treeverse.lakefs.graveler.CommitData
+	30, // 15: io.treeverse.lakefs.graveler.RepoMetadata.metadata:type_name -> io.treeverse.lakefs.graveler.RepoMetadata.MetadataEntry
+	4,  // 16: io.treeverse.lakefs.graveler.PullRequestData.status:type_name -> io.treeverse.lakefs.graveler.PullRequestStatus
+	31, // 17: io.treeverse.lakefs.graveler.PullRequestData.created_at:type_name -> google.protobuf.Timestamp
+	31, // 18: io.treeverse.lakefs.graveler.PullRequestData.closed_at:type_name -> google.protobuf.Timestamp
+	25, // 19: io.treeverse.lakefs.graveler.PullRequestData.reviews:type_name -> io.treeverse.lakefs.graveler.PullRequestReviewData
+	5,  // 20: io.treeverse.lakefs.graveler.PullRequestReviewData.state:type_name -> io.treeverse.lakefs.graveler.PullRequestReviewState
+	31, // 21: io.treeverse.lakefs.graveler.PullRequestReviewData.created_at:type_name -> google.protobuf.Timestamp
+	31, // 22: io.treeverse.lakefs.graveler.PullRequestCommentData.created_at:type_name -> google.protobuf.Timestamp
+	31, // 23: io.treeverse.lakefs.graveler.PullRequestCommentData.updated_at:type_name -> google.protobuf.Timestamp
+	18, // 24: io.treeverse.lakefs.graveler.BranchProtectionRules.BranchPatternToBlockedActionsEntry.value:type_name -> io.treeverse.lakefs.graveler.BranchProtectionBlockedActions
+	25, // [25:25] is the sub-list for method output_type
+	25, // [25:25] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_graveler_graveler_proto_init() }
@@ -1168,14 +2073,15 @@ func file_graveler_graveler_proto_init() {
 	if File_graveler_graveler_proto != nil {
 		return
 	}
-	file_graveler_graveler_proto_msgTypes[11].OneofWrappers = []any{}
+	file_graveler_graveler_proto_msgTypes[18].OneofWrappers = []any{}
+	file_graveler_graveler_proto_msgTypes[20].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_graveler_graveler_proto_rawDesc), len(file_graveler_graveler_proto_rawDesc)),
-			NumEnums:      3,
-			NumMessages:   16,
+			NumEnums:      6,
+			NumMessages:   25,
 			NumExtensions: 0,
 			NumServices:   0,
 		},
@@ -30,6 +30,8 @@ type Hooks struct {
 	CommitID         graveler.CommitID
 	Commit           graveler.Commit
 	TagID            graveler.TagID
+	PullRequestID    graveler.PullRequestID
+	PullRequest      *graveler.PullRequest
 }
 
 var ErrGravelerUpdate = errors.New("test update error")
@@ -183,6 +185,46 @@ func (h *Hooks) PostCherryPickHook(_ context.Context, record graveler.HookRecord
 	return h.Errs["PostCherryPickHook"]
 }
 
+func (h *Hooks) PreCreatePullRequestHook(_ context.Context, record graveler.HookRecord) error {
+	h.Called = append(h.Called, "PreCreatePullRequestHook")
+	h.RepositoryID = record.Repository.RepositoryID
+	h.BranchID = record.BranchID
+	h.SourceRef = record.SourceRef
+	h.PullRequestID = record.PullRequestID
+	h.PullRequest = record.PullRequest
+	return h.Errs["PreCreatePullRequestHook"]
+}
+
+func (h *Hooks) PostCreatePullRequestHook(_ context.Context, record graveler.HookRecord) {
+	h.Called = append(h.Called, "PostCreatePullRequestHook")
+	h.RepositoryID = record.Repository.RepositoryID
+	h.BranchID = record.BranchID
+	h.SourceRef = record.SourceRef
+	h.PullRequestID = record.PullRequestID
+	h.PullRequest = record.PullRequest
+}
+
+func (h *Hooks) PreMergePullRequestHook(_ context.Context, record graveler.HookRecord) error {
+	h.Called = append(h.Called, "PreMergePullRequestHook")
+	h.RepositoryID = record.Repository.RepositoryID
+	h.BranchID = record.BranchID
+	h.SourceRef = record.SourceRef
+	h.CommitID = record.CommitID
+	h.Commit = record.Commit
+	h.PullRequestID = record.PullRequestID
+	h.PullRequest = record.PullRequest
+	return h.Errs["PreMergePullRequestHook"]
+}
+
+func (h *Hooks) PostClosePullRequestHook(_ context.Context, record graveler.HookRecord) {
+	h.Called = append(h.Called, "PostClosePullRequestHook")
+	h.RepositoryID = record.Repository.RepositoryID
+	h.BranchID = record.BranchID
+	h.SourceRef = record.SourceRef
+	h.PullRequestID = record.PullRequestID
+	h.PullRequest = record.PullRequest
+}
+
 func (h *Hooks) NewRunID() string {
 	return ""
 }
@@ -460,6 +502,180 @@ func TestGraveler_Set(t *testing.T) {
 	}
 }
 
+func TestGraveler_SetCondition(t *testing.T) {
+	newSetVal := &graveler.ValueRecord{Key: []byte("some/key"), Value: &graveler.Value{Data: []byte("newValue"), Identity: []byte("newIdentity")}}
+	committedVal := &graveler.Value{Identity: []byte("committedIdentity"), Data: []byte("committedValue")}
+	stagedVal := &graveler.Value{Identity: []byte("stagedIdentity"), Data: []byte("stagedValue")}
+	// identityIs passes only if the current value has identity, or is absent for an empty identity
+	identityIs := func(identity string) graveler.ConditionFunc {
+		return func(currentValue *graveler.Value) error {
+			if currentValue == nil && identity == "" || currentValue != nil && string(currentValue.Identity) == identity {
+				return nil
+			}
+			return graveler.ErrPreconditionFailed
+		}
+	}
+	tests := []struct {
+		name         string
+		condition    graveler.ConditionFunc
+		ifAbsent     bool
+		expectedErr  error
+		committedMgr *testutil.CommittedFake
+		stagingMgr   *testutil.StagingFake
+	}{
+		{
+			name:         "absent",
+			condition:    identityIs(""),
+			committedMgr: &testutil.CommittedFake{Err: graveler.ErrNotFound},
+			stagingMgr:   &testutil.StagingFake{},
+		},
+		{
+			name:         "committed value passes",
+			condition:    identityIs("committedIdentity"),
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{},
+		},
+		{
+			name:         "committed value fails",
+			condition:    identityIs("otherIdentity"),
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{},
+		},
+		{
+			name:         "committed value with if absent",
+			condition:    identityIs("committedIdentity"),
+			ifAbsent:     true,
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{},
+		},
+		{
+			name:         "staged value passes",
+			condition:    identityIs("stagedIdentity"),
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}}},
+		},
+		{
+			name:         "staged value fails",
+			condition:    identityIs("committedIdentity"),
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}}},
+		},
+		{
+			name:         "staged tombstone",
+			condition:    identityIs(""),
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": nil}}},
+		},
+		{
+			name:         "concurrent write",
+			condition:    identityIs("committedIdentity"),
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{UpdateErr: kv.ErrPredicateFailed},
+		},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refMgr := &testutil.RefsFake{
+				RefType:      graveler.ReferenceTypeBranch,
+				CommitID:     "commit1",
+				StagingToken: "st",
+				Branch:       &graveler.Branch{CommitID: "commit1", StagingToken: "st"},
+				Commits:      map[graveler.CommitID]*graveler.Commit{"commit1": {}},
+			}
+			store := newGraveler(t, tt.committedMgr, tt.stagingMgr, refMgr, nil, testutil.NewProtectedBranchesManagerFake())
+			err := store.Set(ctx, repository, "branch-1", newSetVal.Key, *newSetVal.Value, graveler.WithCondition(tt.condition), graveler.WithIfAbsent(tt.ifAbsent))
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(t, newSetVal, tt.stagingMgr.LastSetValueRecord)
+			} else {
+				require.Nil(t, tt.stagingMgr.LastSetValueRecord)
+			}
+		})
+	}
+}
+
+func TestGraveler_ResetKeyCondition(t *testing.T) {
+	key := graveler.Key("some/key")
+	committedVal := &graveler.Value{Identity: []byte("committedIdentity"), Data: []byte("committedValue")}
+	stagedVal := &graveler.Value{Identity: []byte("stagedIdentity"), Data: []byte("stagedValue")}
+	identityIs := func(identity string) graveler.ConditionFunc {
+		return func(currentValue *graveler.Value) error {
+			if currentValue != nil && string(currentValue.Identity) == identity {
+				return nil
+			}
+			return graveler.ErrPreconditionFailed
+		}
+	}
+	tests := []struct {
+		name          string
+		condition     graveler.ConditionFunc
+		expectedErr   error
+		expectedValue *graveler.Value
+		committedMgr  *testutil.CommittedFake
+		stagingMgr    *testutil.StagingFake
+	}{
+		{
+			name:          "staged value passes",
+			condition:     identityIs("stagedIdentity"),
+			expectedValue: committedVal,
+			committedMgr:  &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:    &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}}},
+		},
+		{
+			name:          "uncommitted value passes",
+			condition:     identityIs("stagedIdentity"),
+			expectedValue: &graveler.Value{},
+			committedMgr:  &testutil.CommittedFake{Err: graveler.ErrNotFound},
+			stagingMgr:    &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}}},
+		},
+		{
+			name:         "staged value fails",
+			condition:    identityIs("otherIdentity"),
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr:   &testutil.StagingFake{Values: map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}}},
+		},
+		{
+			name:         "concurrent write",
+			condition:    identityIs("stagedIdentity"),
+			expectedErr:  graveler.ErrPreconditionFailed,
+			committedMgr: &testutil.CommittedFake{ValuesByKey: map[string]*graveler.Value{"some/key": committedVal}},
+			stagingMgr: &testutil.StagingFake{
+				Values:    map[string]map[string]*graveler.Value{"st": {"some/key": stagedVal}},
+				UpdateErr: kv.ErrPredicateFailed,
+			},
+		},
+	}
+	ctx := context.Background()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			refMgr := &testutil.RefsFake{
+				RefType:      graveler.ReferenceTypeBranch,
+				CommitID:     "commit1",
+				StagingToken: "st",
+				Branch:       &graveler.Branch{CommitID: "commit1", StagingToken: "st"},
+				Commits:      map[graveler.CommitID]*graveler.Commit{"commit1": {}},
+				Refs: map[graveler.Ref]*graveler.ResolvedRef{
+					"commit1": {Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: "commit1"}}},
+				},
+			}
+			store := newGraveler(t, tt.committedMgr, tt.stagingMgr, refMgr, nil, testutil.NewProtectedBranchesManagerFake())
+			err := store.ResetKey(ctx, repository, "branch-1", key, graveler.WithCondition(tt.condition))
+			require.ErrorIs(t, err, tt.expectedErr)
+			if tt.expectedErr == nil {
+				require.Equal(t, &graveler.ValueRecord{Key: key, Value: tt.expectedValue}, tt.stagingMgr.LastSetValueRecord)
+			} else {
+				require.Nil(t, tt.stagingMgr.LastSetValueRecord)
+			}
+		})
+	}
+}
+
 func TestGravelerSet_Advanced(t *testing.T) {
 	ctrl := gomock.NewController(t)
 	ctx := context.Background()
@@ -2175,6 +2391,114 @@ func TestGravelerDelete(t *testing.T) {
 	}
 }
 
+func TestGraveler_ObjectLock(t *testing.T) {
+	ctx := context.Background()
+	const branchID = graveler.BranchID("branch-1")
+	newLockedGraveler := func(stagingManager *testutil.StagingFake) *graveler.Graveler {
+		committedManager := &testutil.CommittedFake{
+			ValuesByKey: map[string]*graveler.Value{"key": {}, "locked": {}},
+		}
+		refManager := &testutil.RefsFake{
+			Branch:  &graveler.Branch{CommitID: "c1", StagingToken: "token"},
+			Commits: map[graveler.CommitID]*graveler.Commit{"c1": {}},
+		}
+		g := graveler.NewGraveler(committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake(), nil)
+		g.SetObjectLockManager(testutil.NewObjectLockManagerFake(map[graveler.BranchID][]string{branchID: {"locked"}}))
+		return g
+	}
+
+	t.Run("set", func(t *testing.T) {
+		stagingManager := &testutil.StagingFake{}
+		g := newLockedGraveler(stagingManager)
+		err := g.Set(ctx, repository, branchID, graveler.Key("locked"), graveler.Value{Identity: []byte("new")})
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+		require.Nil(t, stagingManager.LastSetValueRecord)
+		require.NoError(t, g.Set(ctx, repository, "branch-2", graveler.Key("locked"), graveler.Value{Identity: []byte("new")}))
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		stagingManager := &testutil.StagingFake{Err: graveler.ErrNotFound}
+		g := newLockedGraveler(stagingManager)
+		err := g.Delete(ctx, repository, branchID, graveler.Key("locked"))
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+		require.Nil(t, stagingManager.LastSetValueRecord)
+	})
+
+	t.Run("delete batch", func(t *testing.T) {
+		stagingManager := &testutil.StagingFake{Err: graveler.ErrNotFound}
+		g := newLockedGraveler(stagingManager)
+		err := g.DeleteBatch(ctx, repository, branchID, []graveler.Key{graveler.Key("locked"), graveler.Key("key")})
+		var deleteErr *graveler.DeleteError
+		require.ErrorAs(t, err, &deleteErr)
+		require.Equal(t, graveler.Key("locked"), deleteErr.Key)
+		require.ErrorIs(t, deleteErr.Err, graveler.ErrObjectLocked)
+		require.Equal(t, &graveler.ValueRecord{Key: graveler.Key("key"), Value: nil}, stagingManager.LastSetValueRecord)
+	})
+
+	t.Run("delete branch", func(t *testing.T) {
+		g := newLockedGraveler(&testutil.StagingFake{})
+		err := g.DeleteBranch(ctx, repository, branchID)
+		require.ErrorIs(t, err, graveler.ErrBranchHasLockedObjects)
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+	})
+
+	t.Run("reset hard", func(t *testing.T) {
+		g := newLockedGraveler(&testutil.StagingFake{})
+		err := g.ResetHard(ctx, repository, branchID, "c0")
+		require.ErrorIs(t, err, graveler.ErrBranchHasLockedObjects)
+	})
+
+	t.Run("reset key", func(t *testing.T) {
+		stagingManager := &testutil.StagingFake{}
+		g := newLockedGraveler(stagingManager)
+		err := g.ResetKey(ctx, repository, branchID, graveler.Key("locked"))
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+		require.Nil(t, stagingManager.LastSetValueRecord)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		const sourceCommitID = graveler.CommitID("c2")
+		for _, key := range []string{"key", "locked"} {
+			t.Run(key, func(t *testing.T) {
+				committedManager := &testutil.CommittedFake{
+					MetaRangeID: "mr2",
+					DiffIterator: testutil.NewDiffIter([]graveler.Diff{
+						{Key: graveler.Key(key), Type: graveler.DiffTypeChanged, Value: &graveler.Value{Identity: []byte("new")}},
+					}),
+				}
+				refManager := &testutil.RefsFake{
+					CommitID: sourceCommitID,
+					Branch:   &graveler.Branch{CommitID: "c1", StagingToken: "token"},
+					Refs: map[graveler.Ref]*graveler.ResolvedRef{
+						graveler.Ref(branchID): {
+							Type: graveler.ReferenceTypeBranch,
+							BranchRecord: graveler.BranchRecord{
+								BranchID: branchID,
+								Branch:   &graveler.Branch{CommitID: "c1", StagingToken: "token"},
+							},
+						},
+					},
+					Commits: map[graveler.CommitID]*graveler.Commit{
+						"c1":           {MetaRangeID: "mr1"},
+						sourceCommitID: {MetaRangeID: "mr2"},
+					},
+				}
+				stagingManager := &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}
+				g := graveler.NewGraveler(committedManager, stagingManager, refManager, nil, testutil.NewProtectedBranchesManagerFake(), nil)
+				g.SetObjectLockManager(testutil.NewObjectLockManagerFake(map[graveler.BranchID][]string{branchID: {"locked"}}))
+				_, err := g.Merge(ctx, repository, branchID, sourceCommitID.Ref(), graveler.CommitParams{Committer: "committer", Message: "merge"}, "")
+				if key == "locked" {
+					require.ErrorIs(t, err, graveler.ErrObjectLocked)
+					require.Empty(t, refManager.AddedCommit.MetaRangeID)
+					return
+				}
+				require.NoError(t, err)
+				require.Equal(t, graveler.MetaRangeID("mr2"), refManager.AddedCommit.MetaRangeID)
+			})
+		}
+	})
+}
+
 func TestGraveler_PrepareCommitHook(t *testing.T) {
 	// prepare graveler
 	const expectedRangeID = graveler.MetaRangeID("expectedRangeID")
@@ -2514,6 +2838,128 @@ func TestGraveler_PreMergeHook(t *testing.T) {
 	}
 }
 
+func TestGraveler_PullRequestHooks(t *testing.T) {
+	const expectedRangeID = graveler.MetaRangeID("expectedRangeID")
+	const sourceCommitID = graveler.CommitID("sourceCommitID")
+	const destinationCommitID = graveler.CommitID("destinationCommitID")
+	const pullRequestID = graveler.PullRequestID("pullRequestID")
+	errSomethingBad := errors.New("first error")
+	ctx := context.Background()
+	newRefManager := func() *testutil.RefsFake {
+		return &testutil.RefsFake{
+			CommitID: sourceCommitID,
+			Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "st1"},
+			Refs: map[graveler.Ref]*graveler.ResolvedRef{
+				"main": {
+					Type: graveler.ReferenceTypeBranch,
+					BranchRecord: graveler.BranchRecord{
+						BranchID: "main",
+						Branch:   &graveler.Branch{CommitID: destinationCommitID, StagingToken: "st2"},
+					},
+				},
+				"feature": {
+					Type: graveler.ReferenceTypeBranch,
+					BranchRecord: graveler.BranchRecord{
+						BranchID: "feature",
+						Branch:   &graveler.Branch{CommitID: sourceCommitID, StagingToken: "st3"},
+					},
+				},
+			},
+			Commits: map[graveler.CommitID]*graveler.Commit{
+				sourceCommitID:      {MetaRangeID: expectedRangeID},
+				destinationCommitID: {MetaRangeID: expectedRangeID},
+			},
+		}
+	}
+	pullRequest := graveler.PullRequest{Title: "title", Source: "feature", Destination: "main"}
+
+	t.Run("create", func(t *testing.T) {
+		refManager := newRefManager()
+		g := newGraveler(t, &testutil.CommittedFake{}, &testutil.StagingFake{}, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		h := &Hooks{Errs: map[string]error{}}
+		g.SetHooksHandler(h)
+		err := g.CreatePullRequest(ctx, repository, &graveler.PullRequestRecord{ID: pullRequestID, PullRequest: pullRequest})
+		require.NoError(t, err)
+		require.Equal(t, []string{"PreCreatePullRequestHook", "PostCreatePullRequestHook"}, h.Called)
+		require.Equal(t, pullRequestID, h.PullRequestID)
+		require.Equal(t, graveler.BranchID("main"), h.BranchID)
+		require.Equal(t, graveler.Ref("feature"), h.SourceRef)
+		require.Equal(t, "title", h.PullRequest.Title)
+	})
+
+	t.Run("create hook error", func(t *testing.T) {
+		refManager := newRefManager()
+		g := newGraveler(t, &testutil.CommittedFake{}, &testutil.StagingFake{}, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		h := &Hooks{Errs: map[string]error{"PreCreatePullRequestHook": errSomethingBad}}
+		g.SetHooksHandler(h)
+		err := g.CreatePullRequest(ctx, repository, &graveler.PullRequestRecord{ID: pullRequestID, PullRequest: pullRequest})
+		var hookErr *graveler.HookAbortError
+		require.ErrorAs(t, err, &hookErr)
+		require.ErrorIs(t, err, errSomethingBad)
+		require.Equal(t, []string{"PreCreatePullRequestHook"}, h.Called)
+		require.Empty(t, refManager.PullRequests)
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		for _, hookErr := range []error{nil, errSomethingBad} {
+			refManager := newRefManager()
+			refManager.PullRequests = map[graveler.PullRequestID]*graveler.PullRequest{pullRequestID: &pullRequest}
+			g := newGraveler(t, &testutil.CommittedFake{MetaRangeID: expectedRangeID}, &testutil.StagingFake{ValueIterator: testutil.NewValueIteratorFake(nil)}, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+			h := &Hooks{Errs: map[string]error{"PreMergePullRequestHook": hookErr}}
+			g.SetHooksHandler(h)
+			_, err := g.Merge(ctx, repository, "main", "feature", graveler.CommitParams{Committer: "committer", Message: "merge"}, "",
+				graveler.WithPullRequest(pullRequestID))
+			require.ErrorIs(t, err, hookErr)
+			if hookErr != nil {
+				require.Equal(t, []string{"PreMergePullRequestHook"}, h.Called)
+				continue
+			}
+			require.Equal(t, []string{"PreMergePullRequestHook", "PreMergeHook", "PostMergeHook"}, h.Called)
+			require.Equal(t, pullRequestID, h.PullRequestID)
+		}
+	})
+
+	t.Run("close", func(t *testing.T) {
+		refManager := newRefManager()
+		refManager.PullRequests = map[graveler.PullRequestID]*graveler.PullRequest{pullRequestID: &pullRequest}
+		g := newGraveler(t, &testutil.CommittedFake{}, &testutil.StagingFake{}, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		h := &Hooks{Errs: map[string]error{}}
+		g.SetHooksHandler(h)
+		status := "closed"
+		require.NoError(t, g.UpdatePullRequest(ctx, repository, pullRequestID, &graveler.UpdatePullRequest{Status: &status}))
+		require.Equal(t, []string{"PostClosePullRequestHook"}, h.Called)
+		require.Equal(t, graveler.PullRequestStatus_CLOSED, h.PullRequest.Status)
+
+		// closing a closed pull request does not fire the hook again
+		require.NoError(t, g.UpdatePullRequest(ctx, repository, pullRequestID, &graveler.UpdatePullRequest{Status: &status}))
+		require.Equal(t, []string{"PostClosePullRequestHook"}, h.Called)
+	})
+
+	t.Run("close after concurrent close", func(t *testing.T) {
+		refManager := &concurrentCloseRefs{RefsFake: newRefManager()}
+		refManager.PullRequests = map[graveler.PullRequestID]*graveler.PullRequest{pullRequestID: &pullRequest}
+		g := newGraveler(t, &testutil.CommittedFake{}, &testutil.StagingFake{}, refManager, nil, testutil.NewProtectedBranchesManagerFake())
+		h := &Hooks{Errs: map[string]error{}}
+		g.SetHooksHandler(h)
+		status := "closed"
+		require.NoError(t, g.UpdatePullRequest(ctx, repository, pullRequestID, &graveler.UpdatePullRequest{Status: &status}))
+		require.Empty(t, h.Called)
+	})
+}
+
+// concurrentCloseRefs closes the pull request just before applying an update, as a concurrent
+// request closing it would
+type concurrentCloseRefs struct {
+	*testutil.RefsFake
+}
+
+func (r *concurrentCloseRefs) UpdatePullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, f graveler.PullUpdateFunc) error {
+	pr := *r.PullRequests[pullRequestID]
+	pr.Status = graveler.PullRequestStatus_CLOSED
+	r.PullRequests[pullRequestID] = &pr
+	return r.RefsFake.UpdatePullRequest(ctx, repository, pullRequestID, f)
+}
+
 func TestGraveler_CreateTag(t *testing.T) {
 	// prepare graveler
 	const commitID = graveler.CommitID("commitID")
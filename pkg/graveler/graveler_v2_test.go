@@ -349,6 +349,7 @@ func TestGravelerMerge(t *testing.T) {
 	}
 	t.Run("merge successful", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
+		test.ProtectedBranchesManager.EXPECT().RequiredApprovals(ctx, repository, branch1ID).Return(0, nil)
 		firstUpdateBranch(test)
 		emptyStagingTokenCombo(test, 2)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(3).Return(&commit1, nil)
@@ -364,15 +365,15 @@ func TestGravelerMerge(t *testing.T) {
 			require.Equal(t, mr4ID, commit.MetaRangeID)
 			return commit4ID, nil
 		}).Times(1)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
 				require.NotEmpty(t, updatedBranch.StagingToken)
-				require.Equal(t, commit4ID, updatedBranch.CommitID)
-				return nil
+				require.Equal(t, mr4ID, commit.MetaRangeID)
+				return commit4ID, nil
 			}).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
@@ -387,6 +388,7 @@ func TestGravelerMerge(t *testing.T) {
 
 	t.Run("merge dirty destination while updating tokens", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
+		test.ProtectedBranchesManager.EXPECT().RequiredApprovals(ctx, repository, branch1ID).Return(0, nil)
 		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
 			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
 				branchTest := branch1
@@ -418,6 +420,7 @@ func TestGravelerMerge(t *testing.T) {
 
 	t.Run("merge dirty compacted", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
+		test.ProtectedBranchesManager.EXPECT().RequiredApprovals(ctx, repository, branch3ID).Return(0, nil)
 
 		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch3ID, gomock.Any()).
 			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
@@ -443,6 +446,7 @@ func TestGravelerMerge(t *testing.T) {
 
 	t.Run("merge successful with branchUpdate retry", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
+		test.ProtectedBranchesManager.EXPECT().RequiredApprovals(ctx, repository, branch1ID).Return(0, nil)
 		testutil.ShortenBranchUpdateBackOff(test.Sut)
 
 		firstUpdateBranch(test)
@@ -460,19 +464,17 @@ func TestGravelerMerge(t *testing.T) {
 			require.Equal(t, mr4ID, commit.MetaRangeID)
 			return commit4ID, nil
 		}).Times(1)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
-				return kv.ErrPredicateFailed
-			}).Times(graveler.BranchUpdateMaxTries - 1)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			Return(graveler.CommitID(""), kv.ErrPredicateFailed).Times(graveler.BranchUpdateMaxTries - 1)
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
 				require.NotEmpty(t, updatedBranch.StagingToken)
-				require.Equal(t, commit4ID, updatedBranch.CommitID)
-				return nil
+				require.Equal(t, mr4ID, commit.MetaRangeID)
+				return commit4ID, nil
 			}).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
@@ -487,16 +489,15 @@ func TestGravelerMerge(t *testing.T) {
 
 	t.Run("merge fails due to BranchUpdate retries exhaustion", func(t *testing.T) {
 		test := testutil.InitGravelerTest(t)
+		test.ProtectedBranchesManager.EXPECT().RequiredApprovals(ctx, repository, branch1ID).Return(0, nil)
 		testutil.ShortenBranchUpdateBackOff(test.Sut)
 
 		firstUpdateBranch(test)
 		emptyStagingTokenCombo(test, 1)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(1).Return(&commit1, nil)
 		test.CommittedManager.EXPECT().List(ctx, repository.StorageID, repository.StorageNamespace, mr1ID).Times(1).Return(testutils.NewFakeValueIterator(nil), nil)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
-				return kv.ErrPredicateFailed
-			}).Times(graveler.BranchUpdateMaxTries)
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			Return(graveler.CommitID(""), kv.ErrPredicateFailed).Times(graveler.BranchUpdateMaxTries)
 
 		val, err := test.Sut.Merge(ctx, repository, branch1ID, graveler.Ref(branch2ID), graveler.CommitParams{Metadata: graveler.Metadata{}}, "")
 
@@ -540,7 +541,7 @@ func TestGravelerRevert(t *testing.T) {
 		}}))
 	}
 
-	setupSuccessfulRevertExpectations := func(test *testutil.GravelerTest) {
+	setupSuccessfulRevertExpectations := func(test *testutil.GravelerTest, requireCommit func(commit *graveler.Commit)) {
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(3).Return(&commit1, nil)
 		test.CommittedManager.EXPECT().List(ctx, repository.StorageID, repository.StorageNamespace, mr1ID).Times(2).Return(testutils.NewFakeValueIterator(nil), nil)
 		test.RefManager.EXPECT().ParseRef(graveler.Ref(commit2ID)).Times(1).Return(rawRefCommit2, nil)
@@ -552,15 +553,15 @@ func TestGravelerRevert(t *testing.T) {
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit4ID).Times(1).Return(&commit4, nil)
 		test.CommittedManager.EXPECT().Merge(ctx, repository.StorageID, repository.StorageNamespace, mr1ID, mr4ID, mr2ID, graveler.MergeStrategyNone, gomock.Any()).Times(1).Return(mr3ID, nil)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
 				require.NotEmpty(t, updatedBranch.StagingToken)
-				require.Equal(t, commit3ID, updatedBranch.CommitID)
-				return nil
+				requireCommit(commit)
+				return commit3ID, nil
 			}).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
@@ -572,11 +573,9 @@ func TestGravelerRevert(t *testing.T) {
 		firstUpdateBranch(test)
 		emptyStagingTokenCombo(test, 2)
 
-		setupSuccessfulRevertExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupSuccessfulRevertExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
-			return commit3ID, nil
-		}).Times(1)
+		})
 
 		val, err := test.Sut.Revert(ctx, repository, branch1ID, graveler.Ref(commit2ID), 0, graveler.CommitParams{}, &graveler.CommitOverrides{})
 
@@ -605,13 +604,11 @@ func TestGravelerRevert(t *testing.T) {
 			},
 		}
 
-		setupSuccessfulRevertExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupSuccessfulRevertExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
 			require.Equal(t, commitOverrides.Message, commit.Message)
 			require.Equal(t, commitOverrides.Metadata, commit.Metadata)
-			return commit3ID, nil
-		}).Times(1)
+		})
 
 		val, err := test.Sut.Revert(ctx, repository, branch1ID, graveler.Ref(commit2ID), 0, commitParams, &commitOverrides)
 
@@ -639,13 +636,11 @@ func TestGravelerRevert(t *testing.T) {
 			},
 		}
 
-		setupSuccessfulRevertExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupSuccessfulRevertExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
 			require.Equal(t, "original message", commit.Message)
 			require.Equal(t, commitOverrides.Metadata, commit.Metadata)
-			return commit3ID, nil
-		}).Times(1)
+		})
 
 		val, err := test.Sut.Revert(ctx, repository, branch1ID, graveler.Ref(commit2ID), 0, commitParams, &commitOverrides)
 
@@ -666,11 +661,9 @@ func TestGravelerRevert(t *testing.T) {
 			},
 		}
 
-		setupSuccessfulRevertExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupSuccessfulRevertExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
-			return commit3ID, nil
-		}).Times(1)
+		})
 
 		val, err := test.Sut.Revert(ctx, repository, branch1ID, graveler.Ref(commit2ID), 0, commitParams, nil)
 
@@ -690,13 +683,14 @@ func TestGravelerRevert(t *testing.T) {
 		test.RefManager.EXPECT().ParseRef(graveler.Ref(commit2ID)).Times(1).Return(rawRefCommit2, nil)
 		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit2).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit2ID}}}, nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.True(t, errors.Is(err, graveler.ErrDirtyBranch))
 				require.Nil(t, updatedBranch)
-				return err
+				require.Nil(t, commit)
+				return "", err
 			}).Times(1)
 
 		val, err := test.Sut.Revert(ctx, repository, branch1ID, graveler.Ref(commit2ID), 0, graveler.CommitParams{}, &graveler.CommitOverrides{})
@@ -733,7 +727,7 @@ func TestGravelerCherryPick(t *testing.T) {
 		}}))
 	}
 
-	setupCherryPickExpectations := func(test *testutil.GravelerTest) {
+	setupCherryPickExpectations := func(test *testutil.GravelerTest, requireCommit func(commit *graveler.Commit)) {
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(3).Return(&commit1, nil)
 		test.CommittedManager.EXPECT().List(ctx, repository.StorageID, repository.StorageNamespace, mr1ID).Times(2).Return(testutils.NewFakeValueIterator(nil), nil)
 		test.RefManager.EXPECT().ParseRef(graveler.Ref(commit2ID)).Times(1).Return(rawRefCommit2, nil)
@@ -745,15 +739,15 @@ func TestGravelerCherryPick(t *testing.T) {
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit2ID).Times(1).Return(&commit2, nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit4ID).Times(1).Return(&commit4, nil)
 		test.CommittedManager.EXPECT().Merge(ctx, repository.StorageID, repository.StorageNamespace, mr1ID, mr2ID, mr4ID, graveler.MergeStrategyNone, []graveler.SetOptionsFunc{}).Times(1).Return(mr3ID, nil)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
 				require.NotEmpty(t, updatedBranch.StagingToken)
-				require.Equal(t, commit3ID, updatedBranch.CommitID)
-				return nil
+				requireCommit(commit)
+				return commit3ID, nil
 			}).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
@@ -764,11 +758,9 @@ func TestGravelerCherryPick(t *testing.T) {
 		firstUpdateBranch(test)
 		emptyStagingTokenCombo(test, 2)
 
-		setupCherryPickExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupCherryPickExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
-			return commit3ID, nil
-		}).Times(1)
+		})
 
 		parent := 1
 		val, err := test.Sut.CherryPick(ctx, repository, branch1ID, graveler.Ref(commit2ID), &parent, "tester", &graveler.CommitOverrides{})
@@ -789,15 +781,13 @@ func TestGravelerCherryPick(t *testing.T) {
 			},
 		}
 
-		setupCherryPickExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupCherryPickExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
 			require.Equal(t, commitOverrides.Message, commit.Message)
 			for k, v := range commitOverrides.Metadata {
 				require.Equal(t, v, commit.Metadata[k])
 			}
-			return commit3ID, nil
-		}).Times(1)
+		})
 		parent := 1
 		val, err := test.Sut.CherryPick(ctx, repository, branch1ID, graveler.Ref(commit2ID), &parent, "tester", &commitOverrides)
 
@@ -816,13 +806,11 @@ func TestGravelerCherryPick(t *testing.T) {
 			},
 		}
 
-		setupCherryPickExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupCherryPickExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
 			require.Equal(t, "", commit.Message)
 			require.Equal(t, commitOverrides.Metadata, commit.Metadata)
-			return commit3ID, nil
-		}).Times(1)
+		})
 		parent := 1
 		val, err := test.Sut.CherryPick(ctx, repository, branch1ID, graveler.Ref(commit2ID), &parent, "tester", &commitOverrides)
 
@@ -836,11 +824,9 @@ func TestGravelerCherryPick(t *testing.T) {
 		firstUpdateBranch(test)
 		emptyStagingTokenCombo(test, 2)
 
-		setupCherryPickExpectations(test)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
+		setupCherryPickExpectations(test, func(commit *graveler.Commit) {
 			require.Equal(t, mr3ID, commit.MetaRangeID)
-			return commit3ID, nil
-		}).Times(1)
+		})
 		parent := 1
 		val, err := test.Sut.CherryPick(ctx, repository, branch1ID, graveler.Ref(commit2ID), &parent, "tester", nil)
 
@@ -870,13 +856,13 @@ func TestGravelerCommit_v2(t *testing.T) {
 				return nil
 			}).Times(1)
 
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
-				updatedBranch, err := f(&updatedSealedBranch)
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
+				updatedBranch, commit, err := f(&updatedSealedBranch)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
-				require.NotEqual(t, commit1ID, updatedBranch.CommitID)
-				return nil
+				require.Equal(t, graveler.CommitParents{commit1ID}, commit.Parents)
+				return "", nil
 			}).Times(1)
 
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(1).Return(&commit1, nil)
@@ -884,7 +870,6 @@ func TestGravelerCommit_v2(t *testing.T) {
 		test.StagingManager.EXPECT().List(ctx, stagingToken2, gomock.Any()).Times(1).Return(testutils.NewFakeValueIterator([]*graveler.ValueRecord{}))
 		test.StagingManager.EXPECT().List(ctx, stagingToken3, gomock.Any()).Times(1).Return(testutils.NewFakeValueIterator([]*graveler.ValueRecord{}))
 		test.CommittedManager.EXPECT().Commit(ctx, repository.StorageID, repository.StorageNamespace, mr1ID, gomock.Any(), false, []graveler.SetOptionsFunc{}).Times(1).Return(graveler.MetaRangeID(""), graveler.DiffSummary{}, nil)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).Return(graveler.CommitID(""), nil)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Return(nil)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Return(nil)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken3).Return(nil)
@@ -912,14 +897,15 @@ func TestGravelerCommit_v2(t *testing.T) {
 				return nil
 			}).Times(1)
 
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
-				updatedBranch, err := f(&updatedSealedBranch)
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
+				updatedBranch, commit, err := f(&updatedSealedBranch)
 				require.Error(t, err)
 				require.True(t, errors.Is(err, graveler.ErrNoChanges))
 				require.Nil(t, updatedBranch)
-				return err
-			}).Times(1).Return(graveler.ErrNoChanges)
+				require.Nil(t, commit)
+				return "", err
+			}).Times(1)
 
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(1).Return(&commit1, nil)
 		test.StagingManager.EXPECT().List(ctx, stagingToken1, gomock.Any()).Times(1).Return(testutils.NewFakeValueIterator([]*graveler.ValueRecord{}))
@@ -951,7 +937,7 @@ func TestGravelerCommit_v2(t *testing.T) {
 				return nil
 			}).Times(1)
 
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).Times(graveler.BranchUpdateMaxTries).Return(kv.ErrPredicateFailed)
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).Times(graveler.BranchUpdateMaxTries).Return(graveler.CommitID(""), kv.ErrPredicateFailed)
 
 		val, err := test.Sut.Commit(ctx, repository, branch1ID, graveler.CommitParams{})
 
@@ -979,25 +965,25 @@ func TestGravelerCommit_v2(t *testing.T) {
 			}).Times(1)
 
 		// Second branch update
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				// This branch update should have NO sealed tokens to check we will not return ErrNoChanges
 				b := graveler.Branch{
 					CommitID:     commit1ID,
 					StagingToken: "new-staging-token",
 					SealedTokens: []graveler.StagingToken{},
 				}
-				updatedBranch, err := f(&b)
+				updatedBranch, commit, err := f(&b)
 				require.NoError(t, err)
 				// After commit, sealed tokens should be cleared
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
-				return nil
+				require.Equal(t, graveler.MetaRangeID("new-mr"), commit.MetaRangeID)
+				return "new-commit", nil
 			}).Times(1)
 
 		// Set up expectations for the commit process
 		test.CommittedManager.EXPECT().Commit(ctx, repository.StorageID, repository.StorageNamespace, mr1ID, gomock.Any(), true, []graveler.SetOptionsFunc{}).Times(1).Return(graveler.MetaRangeID("new-mr"), graveler.DiffSummary{}, nil)
 		test.ProtectedBranchesManager.EXPECT().IsBlocked(ctx, repository, branch1ID, graveler.BranchProtectionBlockedAction_COMMIT).Return(false, nil)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).Return(graveler.CommitID("new-commit"), nil)
 		test.RefManager.EXPECT().GetCommit(ctx, repository, commit1ID).Times(1).Return(&commit1, nil)
 
 		// Call commit with allow empty
@@ -1064,19 +1050,15 @@ func TestGravelerImport(t *testing.T) {
 		test.RefManager.EXPECT().ParseRef(graveler.Ref(branch1ID)).Times(1).Return(rawRefCommit1, nil)
 		test.RefManager.EXPECT().ResolveRawRef(ctx, repository, rawRefCommit1).Times(1).Return(&graveler.ResolvedRef{Type: graveler.ReferenceTypeCommit, BranchRecord: graveler.BranchRecord{Branch: &graveler.Branch{CommitID: commit1ID}}}, nil)
 		test.CommittedManager.EXPECT().Import(ctx, repository.StorageID, repository.StorageNamespace, mr1ID, mr2ID, nil, []graveler.SetOptionsFunc{}).Times(1).Return(mr4ID, nil)
-		test.RefManager.EXPECT().AddCommit(ctx, repository, gomock.Any()).DoAndReturn(func(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
-			require.Equal(t, mr4ID, commit.MetaRangeID)
-			return commit4ID, nil
-		}).Times(1)
-		test.RefManager.EXPECT().BranchUpdate(ctx, repository, branch1ID, gomock.Any()).
-			Do(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchUpdateFunc) error {
+		test.RefManager.EXPECT().CommitBranchUpdate(ctx, repository, branch1ID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 				branchTest := &graveler.Branch{StagingToken: stagingToken4, CommitID: commit1ID, SealedTokens: []graveler.StagingToken{stagingToken1, stagingToken2, stagingToken3}}
-				updatedBranch, err := f(branchTest)
+				updatedBranch, commit, err := f(branchTest)
 				require.NoError(t, err)
 				require.Equal(t, []graveler.StagingToken{}, updatedBranch.SealedTokens)
 				require.NotEmpty(t, updatedBranch.StagingToken)
-				require.Equal(t, commit4ID, updatedBranch.CommitID)
-				return nil
+				require.Equal(t, mr4ID, commit.MetaRangeID)
+				return commit4ID, nil
 			}).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken1).Times(1)
 		test.StagingManager.EXPECT().DropAsync(ctx, stagingToken2).Times(1)
@@ -1155,11 +1137,6 @@ func TestGraveler_UpdatePullRequest(t *testing.T) {
 						require.Equal(t, expectedPr, *newPr)
 						return nil
 					}).Times(1)
-				test.RefManager.EXPECT().GetPullRequest(ctx, repository, pullID).
-					DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, id graveler.PullRequestID) (*graveler.PullRequest, error) {
-						require.Equal(t, pullID, id)
-						return &pr, nil
-					}).Times(1)
 			}
 			test := testutil.InitGravelerTest(t)
 			updatePullRequest(test)
@@ -1168,6 +1145,23 @@ func TestGraveler_UpdatePullRequest(t *testing.T) {
 		})
 	}
 
+	t.Run("keeps concurrent reviews", func(t *testing.T) {
+		// a review added concurrently is already in the stored pull request
+		stored := pr
+		stored.Reviews = []graveler.PullRequestReview{{Reviewer: "reviewer", State: graveler.PullRequestReviewState_CHANGES_REQUESTED}}
+		test := testutil.InitGravelerTest(t)
+		test.RefManager.EXPECT().UpdatePullRequest(ctx, repository, pullID, gomock.Any()).
+			DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.PullRequestID, f graveler.PullUpdateFunc) error {
+				newPr, err := f(&stored)
+				require.NoError(t, err)
+				require.Equal(t, "new title", newPr.Title)
+				require.Equal(t, stored.Reviews, newPr.Reviews)
+				return nil
+			}).Times(1)
+		err := test.Sut.UpdatePullRequest(ctx, repository, pullID, &graveler.UpdatePullRequest{Title: swag.String("new title")})
+		require.NoError(t, err)
+	})
+
 	t.Run("invalid status", func(t *testing.T) {
 		updatePullRequest := func(test *testutil.GravelerTest) {
 			test.RefManager.EXPECT().UpdatePullRequest(ctx, repository, pullID, gomock.Any()).
@@ -1178,11 +1172,6 @@ func TestGraveler_UpdatePullRequest(t *testing.T) {
 					require.Nil(t, newPr)
 					return err
 				}).Times(1)
-			test.RefManager.EXPECT().GetPullRequest(ctx, repository, pullID).
-				DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, id graveler.PullRequestID) (*graveler.PullRequest, error) {
-					require.Equal(t, pullID, id)
-					return &pr, nil
-				}).Times(1)
 		}
 		test := testutil.InitGravelerTest(t)
 		updatePullRequest(test)
@@ -1190,3 +1179,83 @@ func TestGraveler_UpdatePullRequest(t *testing.T) {
 		require.ErrorIs(t, err, graveler.ErrInvalidPullRequestStatus)
 	})
 }
+
+func TestGraveler_ReviewPullRequest(t *testing.T) {
+	ctx := context.Background()
+	pullID := graveler.PullRequestID(xid.New().String())
+	pr := graveler.PullRequest{
+		CreationDate: time.Now(),
+		Status:       graveler.PullRequestStatus_OPEN,
+		Title:        "title",
+		Author:       "author",
+		Source:       branch1ID.String(),
+		Destination:  branch2ID.String(),
+	}
+
+	testCases := []struct {
+		Name   string
+		pr     graveler.PullRequest
+		review graveler.PullRequestReview
+		err    error
+	}{
+		{
+			Name:   "approve",
+			pr:     pr,
+			review: graveler.PullRequestReview{Reviewer: "reviewer", State: graveler.PullRequestReviewState_APPROVED, CommitID: commit1ID},
+		},
+		{
+			Name:   "author comment",
+			pr:     pr,
+			review: graveler.PullRequestReview{Reviewer: "author", State: graveler.PullRequestReviewState_COMMENTED, Comment: "comment", CommitID: commit1ID},
+		},
+		{
+			Name:   "author approve",
+			pr:     pr,
+			review: graveler.PullRequestReview{Reviewer: "author", State: graveler.PullRequestReviewState_APPROVED, CommitID: commit1ID},
+			err:    graveler.ErrPullRequestSelfReview,
+		},
+		{
+			Name:   "source moved",
+			pr:     pr,
+			review: graveler.PullRequestReview{Reviewer: "reviewer", State: graveler.PullRequestReviewState_APPROVED, CommitID: commit2ID},
+			err:    graveler.ErrPullRequestSourceMoved,
+		},
+		{
+			Name: "closed",
+			pr: func() graveler.PullRequest {
+				closed := pr
+				closed.Status = graveler.PullRequestStatus_CLOSED
+				return closed
+			}(),
+			review: graveler.PullRequestReview{Reviewer: "reviewer", State: graveler.PullRequestReviewState_APPROVED, CommitID: commit1ID},
+			err:    graveler.ErrPullRequestNotOpen,
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.Name, func(t *testing.T) {
+			test := testutil.InitGravelerTest(t)
+			current := tt.pr
+			test.RefManager.EXPECT().GetBranch(ctx, repository, branch1ID).MaxTimes(1).Return(&branch1, nil)
+			test.RefManager.EXPECT().UpdatePullRequest(ctx, repository, pullID, gomock.Any()).
+				DoAndReturn(func(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.PullRequestID, f graveler.PullUpdateFunc) error {
+					newPr, err := f(&current)
+					if err != nil {
+						return err
+					}
+					require.Len(t, newPr.Reviews, 1)
+					require.Equal(t, tt.review.Reviewer, newPr.Reviews[0].Reviewer)
+					require.Equal(t, tt.review.State, newPr.Reviews[0].State)
+					require.Equal(t, tt.review.Comment, newPr.Reviews[0].Comment)
+					require.Equal(t, commit1ID, newPr.Reviews[0].CommitID)
+					return nil
+				}).Times(1)
+			err := test.Sut.ReviewPullRequest(ctx, repository, pullID, &tt.review)
+			if tt.err != nil {
+				require.ErrorIs(t, err, tt.err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
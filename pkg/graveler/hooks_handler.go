@@ -29,6 +29,11 @@ const (
 	EventTypePreCherryPick    EventType = "pre-cherry-pick"
 	EventTypePostCherryPick   EventType = "post-cherry-pick"
 
+	EventTypePreCreatePullRequest  EventType = "pre-create-pull-request"
+	EventTypePostCreatePullRequest EventType = "post-create-pull-request"
+	EventTypePreMergePullRequest   EventType = "pre-merge-pull-request"
+	EventTypePostClosePullRequest  EventType = "post-close-pull-request"
+
 	UnixYear3000 = 32500915200
 )
 
@@ -50,7 +55,11 @@ func (e EventType) Valid() bool {
 		EventTypePreRevert,
 		EventTypePostRevert,
 		EventTypePreCherryPick,
-		EventTypePostCherryPick:
+		EventTypePostCherryPick,
+		EventTypePreCreatePullRequest,
+		EventTypePostCreatePullRequest,
+		EventTypePreMergePullRequest,
+		EventTypePostClosePullRequest:
 		// Supported events
 		return true
 	}
@@ -78,6 +87,10 @@ type HookRecord struct {
 	TagID TagID
 	// Exists only in merge actions. Contains the requested source to merge from (branch/tag/ref) as requested in the merge request
 	MergeSource Ref
+	// Exists only in pull request actions, and in merge actions of merges through a pull request
+	PullRequestID PullRequestID
+	// Exists only in pull request actions. In post-close actions will contain the closed or merged status
+	PullRequest *PullRequest
 }
 
 type HooksHandler interface {
@@ -98,6 +111,10 @@ type HooksHandler interface {
 	PostRevertHook(ctx context.Context, record HookRecord) error
 	PreCherryPickHook(ctx context.Context, record HookRecord) error
 	PostCherryPickHook(ctx context.Context, record HookRecord) error
+	PreCreatePullRequestHook(ctx context.Context, record HookRecord) error
+	PostCreatePullRequestHook(ctx context.Context, record HookRecord)
+	PreMergePullRequestHook(ctx context.Context, record HookRecord) error
+	PostClosePullRequestHook(ctx context.Context, record HookRecord)
 	// NewRunID TODO (niro): WA for now until KV feature complete
 	NewRunID() string
 }
@@ -168,6 +185,20 @@ func (h *HooksNoOp) PostCherryPickHook(context.Context, HookRecord) error {
 	return nil
 }
 
+func (h *HooksNoOp) PreCreatePullRequestHook(context.Context, HookRecord) error {
+	return nil
+}
+
+func (h *HooksNoOp) PostCreatePullRequestHook(context.Context, HookRecord) {
+}
+
+func (h *HooksNoOp) PreMergePullRequestHook(context.Context, HookRecord) error {
+	return nil
+}
+
+func (h *HooksNoOp) PostClosePullRequestHook(context.Context, HookRecord) {
+}
+
 func (h *HooksNoOp) NewRunID() string {
 	return NewRunID()
 }
@@ -0,0 +1,111 @@
+// Package lifecycle manages the lifecycle rules of a repository: rules that expire objects under a prefix, either by
+// resetting uncommitted changes or by deleting and committing committed objects.
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gobwas/glob"
+	"github.com/treeverse/lakefs/pkg/cache"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/settings"
+)
+
+const SettingKey = "lifecycle_rules"
+
+const (
+	matcherCacheSize   = 10_000
+	matcherCacheExpiry = 1 * time.Hour
+	matcherCacheJitter = 1 * time.Minute
+)
+
+var ErrInvalidRule = fmt.Errorf("lifecycle rule: %w", graveler.ErrInvalidValue)
+
+type Manager struct {
+	settingManager *settings.Manager
+	matchers       cache.Cache
+}
+
+func NewManager(settingManager *settings.Manager) *Manager {
+	return &Manager{settingManager: settingManager, matchers: cache.NewCache(matcherCacheSize, matcherCacheExpiry, cache.NewJitterFn(matcherCacheJitter))}
+}
+
+func (m *Manager) GetRules(ctx context.Context, repository *graveler.RepositoryRecord) (*graveler.LifecycleRules, *string, error) {
+	rules := &graveler.LifecycleRules{}
+	checksum, err := m.settingManager.GetLatest(ctx, repository, SettingKey, rules)
+	if err != nil {
+		return nil, nil, err
+	}
+	return rules, checksum, nil
+}
+
+// SetRules validates and saves rules, replacing the current rules of the repository
+func (m *Manager) SetRules(ctx context.Context, repository *graveler.RepositoryRecord, rules *graveler.LifecycleRules, lastKnownChecksum *string) error {
+	if err := Validate(rules); err != nil {
+		return err
+	}
+	return m.settingManager.Save(ctx, repository, SettingKey, rules, lastKnownChecksum)
+}
+
+// DeleteRules removes all rules of the repository
+func (m *Manager) DeleteRules(ctx context.Context, repository *graveler.RepositoryRecord) error {
+	return m.settingManager.Save(ctx, repository, SettingKey, &graveler.LifecycleRules{}, nil)
+}
+
+// BranchRules returns the enabled rules that apply to branchID
+func (m *Manager) BranchRules(rules *graveler.LifecycleRules, branchID graveler.BranchID) ([]*graveler.LifecycleRule, error) {
+	var matching []*graveler.LifecycleRule
+	for _, rule := range rules.GetRules() {
+		if !rule.GetEnabled() {
+			continue
+		}
+		match, err := m.matchBranch(rule.GetBranchPattern(), branchID)
+		if err != nil {
+			return nil, err
+		}
+		if match {
+			matching = append(matching, rule)
+		}
+	}
+	return matching, nil
+}
+
+func (m *Manager) matchBranch(pattern string, branchID graveler.BranchID) (bool, error) {
+	if pattern == "" {
+		return true, nil
+	}
+	matcher, err := m.matchers.GetOrSet(pattern, func() (v interface{}, err error) {
+		return glob.Compile(pattern)
+	})
+	if err != nil {
+		return false, err
+	}
+	return matcher.(glob.Glob).Match(string(branchID)), nil
+}
+
+// Validate checks that every rule has a unique ID, a valid branch pattern and at least one expiration
+func Validate(rules *graveler.LifecycleRules) error {
+	ids := make(map[string]struct{}, len(rules.GetRules()))
+	for _, rule := range rules.GetRules() {
+		id := rule.GetId()
+		if id == "" {
+			return fmt.Errorf("missing id: %w", ErrInvalidRule)
+		}
+		if _, ok := ids[id]; ok {
+			return fmt.Errorf("duplicate id %s: %w", id, ErrInvalidRule)
+		}
+		ids[id] = struct{}{}
+		if rule.GetUncommittedExpirationHours() < 0 || rule.GetCommittedExpirationDays() < 0 {
+			return fmt.Errorf("rule %s: negative expiration: %w", id, ErrInvalidRule)
+		}
+		if rule.GetUncommittedExpirationHours() == 0 && rule.GetCommittedExpirationDays() == 0 {
+			return fmt.Errorf("rule %s: no expiration: %w", id, ErrInvalidRule)
+		}
+		if _, err := glob.Compile(rule.GetBranchPattern()); err != nil {
+			return fmt.Errorf("rule %s: branch pattern %s: %w", id, rule.GetBranchPattern(), ErrInvalidRule)
+		}
+	}
+	return nil
+}
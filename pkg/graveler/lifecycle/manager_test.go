@@ -0,0 +1,113 @@
+package lifecycle_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/lifecycle"
+	"github.com/treeverse/lakefs/pkg/graveler/mock"
+	"github.com/treeverse/lakefs/pkg/graveler/settings"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+)
+
+var repository = &graveler.RepositoryRecord{
+	RepositoryID: "example-repo",
+	Repository: &graveler.Repository{
+		StorageNamespace: "mem://my-storage",
+		DefaultBranchID:  "main",
+	},
+}
+
+func TestSetAndGet(t *testing.T) {
+	ctx := context.Background()
+	m := prepareTest(t, ctx)
+	rules, eTag, err := m.GetRules(ctx, repository)
+	require.NoError(t, err)
+	require.Empty(t, rules.GetRules())
+	require.Equal(t, "", *eTag)
+
+	err = m.SetRules(ctx, repository, &graveler.LifecycleRules{
+		Rules: []*graveler.LifecycleRule{
+			{Id: "tmp", Prefix: "tmp/", Enabled: true, UncommittedExpirationHours: 24},
+		},
+	}, eTag)
+	require.NoError(t, err)
+
+	rules, _, err = m.GetRules(ctx, repository)
+	require.NoError(t, err)
+	require.Len(t, rules.GetRules(), 1)
+	require.Equal(t, "tmp", rules.GetRules()[0].GetId())
+	require.Equal(t, int32(24), rules.GetRules()[0].GetUncommittedExpirationHours())
+
+	require.NoError(t, m.DeleteRules(ctx, repository))
+	rules, _, err = m.GetRules(ctx, repository)
+	require.NoError(t, err)
+	require.Empty(t, rules.GetRules())
+}
+
+func TestSetInvalid(t *testing.T) {
+	ctx := context.Background()
+	m := prepareTest(t, ctx)
+	tests := []struct {
+		name  string
+		rules []*graveler.LifecycleRule
+	}{
+		{name: "missing id", rules: []*graveler.LifecycleRule{{CommittedExpirationDays: 1}}},
+		{name: "duplicate id", rules: []*graveler.LifecycleRule{{Id: "a", CommittedExpirationDays: 1}, {Id: "a", CommittedExpirationDays: 2}}},
+		{name: "no expiration", rules: []*graveler.LifecycleRule{{Id: "a"}}},
+		{name: "negative expiration", rules: []*graveler.LifecycleRule{{Id: "a", CommittedExpirationDays: 1, UncommittedExpirationHours: -1}}},
+		{name: "bad pattern", rules: []*graveler.LifecycleRule{{Id: "a", BranchPattern: "[", CommittedExpirationDays: 1}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := m.SetRules(ctx, repository, &graveler.LifecycleRules{Rules: tt.rules}, nil)
+			if !errors.Is(err, lifecycle.ErrInvalidRule) {
+				t.Fatalf("expected ErrInvalidRule, got %v", err)
+			}
+		})
+	}
+}
+
+func TestBranchRules(t *testing.T) {
+	ctx := context.Background()
+	m := prepareTest(t, ctx)
+	rules := &graveler.LifecycleRules{
+		Rules: []*graveler.LifecycleRule{
+			{Id: "all", Enabled: true, CommittedExpirationDays: 30},
+			{Id: "dev", BranchPattern: "dev-*", Enabled: true, UncommittedExpirationHours: 1},
+			{Id: "disabled", Enabled: false, CommittedExpirationDays: 1},
+		},
+	}
+
+	tests := []struct {
+		branch   graveler.BranchID
+		expected []string
+	}{
+		{branch: "main", expected: []string{"all"}},
+		{branch: "dev-1", expected: []string{"all", "dev"}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.branch), func(t *testing.T) {
+			matching, err := m.BranchRules(rules, tt.branch)
+			require.NoError(t, err)
+			ids := make([]string, 0, len(matching))
+			for _, rule := range matching {
+				ids = append(ids, rule.GetId())
+			}
+			require.Equal(t, tt.expected, ids)
+		})
+	}
+}
+
+func prepareTest(t *testing.T, ctx context.Context) *lifecycle.Manager {
+	ctrl := gomock.NewController(t)
+	refManager := mock.NewMockRefManager(ctrl)
+	refManager.EXPECT().GetRepository(ctx, gomock.Any()).AnyTimes().Return(repository, nil)
+	kvStore := kvtest.GetStore(ctx, t)
+	m := settings.NewManager(refManager, kvStore)
+	return lifecycle.NewManager(m)
+}
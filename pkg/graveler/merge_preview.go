@@ -0,0 +1,211 @@
+package graveler
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/pkg/ident"
+)
+
+// MergePreviewConflict is a key changed differently on the merge source and destination.
+// Identities are empty when the key does not exist on that side.
+type MergePreviewConflict struct {
+	Key            Key
+	BaseIdentity   []byte
+	SourceIdentity []byte
+	DestIdentity   []byte
+}
+
+// MergePreview describes the outcome of merging a source into a destination, without merging
+type MergePreview struct {
+	SourceCommitID      CommitID
+	DestinationCommitID CommitID
+	BaseCommitID        CommitID
+	// Summary counts the keys the merge adds, changes and removes on the destination, and the
+	// keys in conflict
+	Summary DiffSummary
+	// Conflicts holds a single page of the conflicting keys, ordered by key
+	Conflicts []MergePreviewConflict
+	HasMore   bool
+}
+
+// PreviewMerge compares source with destination from their merge base like Merge does, and
+// returns a page of up to amount conflicts with keys after 'after'. A negative amount returns all.
+// Conflicts are decided by strategy, and by the merge strategy rules and conflict resolver of
+// opts, as they are by Merge: only conflicts Merge would fail on are returned.  The summary
+// counts the whole merge, and is only computed for the first page, when after is empty.
+func (g *Graveler) PreviewMerge(ctx context.Context, repository *RepositoryRecord, destination BranchID, source Ref, strategy string, after Key, amount int, opts ...SetOptionsFunc) (*MergePreview, error) {
+	options := NewSetOptions(opts)
+	mergeStrategy, err := mergeStrategyFor(strategy, options)
+	if err != nil {
+		return nil, err
+	}
+	fromCommit, toCommit, baseCommit, err := g.FindMergeBase(ctx, repository, source, Ref(destination))
+	if err != nil {
+		return nil, err
+	}
+	preview := &MergePreview{
+		SourceCommitID:      fromCommit.CommitID,
+		DestinationCommitID: toCommit.CommitID,
+		BaseCommitID:        CommitID(ident.NewHexAddressProvider().ContentAddress(baseCommit)),
+		Summary:             DiffSummary{Count: make(map[DiffType]int)},
+	}
+	it, err := g.CommittedManager.Compare(ctx, repository.StorageID, repository.StorageNamespace, toCommit.MetaRangeID, fromCommit.MetaRangeID, baseCommit.MetaRangeID)
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	summarize := len(after) == 0
+	if !summarize {
+		it.SeekGE(after)
+	}
+	p := &mergePreviewer{
+		g:          g,
+		ctx:        ctx,
+		repository: repository,
+		strategy:   mergeStrategy,
+		options:    options,
+		dest:       toCommit.MetaRangeID,
+		base:       baseCommit.MetaRangeID,
+	}
+	for it.Next() {
+		d := it.Value()
+		if bytes.Compare(d.Key, after) <= 0 {
+			continue
+		}
+		typ, changes := d.Type, true
+		var conflict *MergePreviewConflict
+		if typ == DiffTypeConflict {
+			conflict, typ, changes, err = p.previewConflict(d)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if summarize && changes {
+			preview.Summary.Count[typ]++
+		}
+		if conflict == nil {
+			continue
+		}
+		if amount >= 0 && len(preview.Conflicts) >= amount {
+			preview.HasMore = true
+			if !summarize {
+				break
+			}
+			continue
+		}
+		preview.Conflicts = append(preview.Conflicts, *conflict)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return preview, nil
+}
+
+type mergePreviewer struct {
+	g          *Graveler
+	ctx        context.Context
+	repository *RepositoryRecord
+	strategy   MergeStrategy
+	options    *SetOptions
+	dest       MetaRangeID
+	base       MetaRangeID
+}
+
+// previewConflict returns how merging changes the destination on the conflicting key of d,
+// if it does, and the conflict if the merge fails on it.
+func (p *mergePreviewer) previewConflict(d *Diff) (*MergePreviewConflict, DiffType, bool, error) {
+	// a conflict on a key removed by the source carries the destination value
+	var sourceValue *Value
+	if d.Value != nil && !bytes.Equal(d.Value.Identity, d.LeftIdentity) {
+		sourceValue = d.Value
+	}
+	destValue, err := p.get(p.dest, d.Key, d.LeftIdentity != nil)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	switch p.options.MergeStrategyRules.StrategyFor(d.Key, p.strategy) {
+	case MergeStrategyDest:
+		return nil, 0, false, nil
+	case MergeStrategySrc:
+		typ, changes := mergeOutcome(destValue, sourceValue)
+		return nil, typ, changes, nil
+	case MergeStrategyResolve:
+		resolved, err := p.resolve(d.Key, sourceValue, destValue)
+		if err == nil {
+			typ, changes := mergeOutcome(destValue, resolved)
+			return nil, typ, changes, nil
+		}
+		if !errors.Is(err, ErrConflictFound) {
+			return nil, 0, false, err
+		}
+	}
+	baseValue, err := p.get(p.base, d.Key, true)
+	if err != nil {
+		return nil, 0, false, err
+	}
+	conflict := &MergePreviewConflict{Key: d.Key.Copy()}
+	if baseValue != nil {
+		conflict.BaseIdentity = baseValue.Identity
+	}
+	if sourceValue != nil {
+		conflict.SourceIdentity = sourceValue.Identity
+	}
+	if destValue != nil {
+		conflict.DestIdentity = destValue.Identity
+	}
+	return conflict, DiffTypeConflict, true, nil
+}
+
+func (p *mergePreviewer) resolve(key Key, sourceValue, destValue *Value) (*Value, error) {
+	if p.options.ConflictResolver == nil {
+		return nil, ErrConflictFound
+	}
+	baseValue, err := p.get(p.base, key, true)
+	if err != nil {
+		return nil, err
+	}
+	resolved, err := p.options.ConflictResolver.ResolveConflict(p.ctx, MergeConflict{
+		Key:    key,
+		Base:   baseValue,
+		Source: sourceValue,
+		Dest:   destValue,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("resolve conflict on %s: %w", key, err)
+	}
+	return resolved, nil
+}
+
+// get returns the value of key on metaRangeID, nil if it does not exist there
+func (p *mergePreviewer) get(metaRangeID MetaRangeID, key Key, exists bool) (*Value, error) {
+	if !exists {
+		return nil, nil
+	}
+	value, err := p.g.CommittedManager.Get(p.ctx, p.repository.StorageID, p.repository.StorageNamespace, metaRangeID, key)
+	if errors.Is(err, ErrNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("get value %s: %w", key, err)
+	}
+	return value, nil
+}
+
+// mergeOutcome returns how writing value over destValue changes the destination, if it does
+func mergeOutcome(destValue, value *Value) (DiffType, bool) {
+	switch {
+	case destValue == nil && value == nil:
+		return 0, false
+	case destValue == nil:
+		return DiffTypeAdded, true
+	case value == nil:
+		return DiffTypeRemoved, true
+	case bytes.Equal(destValue.Identity, value.Identity):
+		return 0, false
+	default:
+		return DiffTypeChanged, true
+	}
+}
@@ -0,0 +1,95 @@
+package graveler
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/gobwas/glob"
+)
+
+const MergeStrategyRulesMetadataKey = ".lakefs.merge.strategy_rules"
+
+// globChars are the characters that turn a merge strategy rule pattern into a glob
+const globChars = "*?[{"
+
+// ParseMergeStrategy returns the MergeStrategy for its string representation. An empty string
+// is the default strategy.
+func ParseMergeStrategy(s string) (MergeStrategy, error) {
+	switch s {
+	case MergeStrategyDestWinsStr:
+		return MergeStrategyDest, nil
+	case MergeStrategySrcWinsStr:
+		return MergeStrategySrc, nil
+	case "", MergeStrategyNoneStr:
+		return MergeStrategyNone, nil
+	default:
+		return MergeStrategyNone, ErrInvalidMergeStrategy
+	}
+}
+
+// MergeStrategyRule applies Strategy to merge conflicts on keys matching Pattern.  A pattern
+// holding any of '*', '?', '[' or '{' is a glob where '*' stays within a path segment and '**'
+// crosses segments, any other pattern matches keys by prefix.
+type MergeStrategyRule struct {
+	Pattern  string
+	Strategy MergeStrategy
+	matcher  glob.Glob
+}
+
+func NewMergeStrategyRule(pattern, strategy string) (*MergeStrategyRule, error) {
+	if pattern == "" {
+		return nil, fmt.Errorf("empty pattern: %w", ErrInvalidMergeStrategy)
+	}
+	s, err := ParseMergeStrategy(strategy)
+	if err != nil {
+		return nil, fmt.Errorf("pattern %s: %w", pattern, err)
+	}
+	rule := &MergeStrategyRule{Pattern: pattern, Strategy: s}
+	if strings.ContainsAny(pattern, globChars) {
+		rule.matcher, err = glob.Compile(pattern, '/')
+		if err != nil {
+			return nil, fmt.Errorf("pattern %s: %s: %w", pattern, err, ErrInvalidMergeStrategy)
+		}
+	}
+	return rule, nil
+}
+
+// Match reports whether the rule applies to key
+func (r *MergeStrategyRule) Match(key Key) bool {
+	if r.matcher == nil {
+		return strings.HasPrefix(key.String(), r.Pattern)
+	}
+	return r.matcher.Match(key.String())
+}
+
+// MergeStrategyRules is an ordered list of rules, the first rule matching a key applies to it
+type MergeStrategyRules []*MergeStrategyRule
+
+// StrategyFor returns the strategy of the first rule matching key, or defaultStrategy if none matches
+func (r MergeStrategyRules) StrategyFor(key Key, defaultStrategy MergeStrategy) MergeStrategy {
+	for _, rule := range r {
+		if rule.Match(key) {
+			return rule.Strategy
+		}
+	}
+	return defaultStrategy
+}
+
+// setMetadata records the rules on merge commit metadata
+func (r MergeStrategyRules) setMetadata(metadata Metadata) error {
+	type ruleRecord struct {
+		Pattern  string `json:"pattern"`
+		Strategy string `json:"strategy"`
+	}
+	records := make([]ruleRecord, len(r))
+	for i, rule := range r {
+		records[i] = ruleRecord{Pattern: rule.Pattern, Strategy: mergeStrategyString[rule.Strategy]}
+	}
+	data, err := json.Marshal(records)
+	if err != nil {
+		return fmt.Errorf("marshal merge strategy rules: %w", err)
+	}
+	metadata[MergeStrategyRulesMetadataKey] = string(data)
+	return nil
+}
@@ -0,0 +1,62 @@
+package graveler
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestMergeStrategyRules_StrategyFor(t *testing.T) {
+	var rules MergeStrategyRules
+	for _, r := range []struct{ pattern, strategy string }{
+		{pattern: "**/_delta_log/**", strategy: MergeStrategyDestWinsStr},
+		{pattern: "models/", strategy: MergeStrategySrcWinsStr},
+		{pattern: "models/*.tmp", strategy: MergeStrategyDestWinsStr},
+		{pattern: "raw/", strategy: MergeStrategyNoneStr},
+	} {
+		rule, err := NewMergeStrategyRule(r.pattern, r.strategy)
+		if err != nil {
+			t.Fatalf("NewMergeStrategyRule(%s, %s): %s", r.pattern, r.strategy, err)
+		}
+		rules = append(rules, rule)
+	}
+
+	tests := []struct {
+		key      string
+		expected MergeStrategy
+	}{
+		{key: "tables/t1/_delta_log/0001.json", expected: MergeStrategyDest},
+		{key: "models/m1.bin", expected: MergeStrategySrc},
+		{key: "models/m1.tmp", expected: MergeStrategySrc}, // first matching rule wins
+		{key: "raw/file", expected: MergeStrategyNone},
+		{key: "other/file", expected: MergeStrategyResolve},
+		{key: "modelsx/file", expected: MergeStrategyResolve},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got := rules.StrategyFor(Key(tt.key), MergeStrategyResolve)
+			if got != tt.expected {
+				t.Errorf("StrategyFor(%s) = %d, expected %d", tt.key, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestNewMergeStrategyRule_Invalid(t *testing.T) {
+	tests := []struct {
+		name     string
+		pattern  string
+		strategy string
+	}{
+		{name: "empty pattern", pattern: "", strategy: MergeStrategySrcWinsStr},
+		{name: "unknown strategy", pattern: "a/", strategy: "newest"},
+		{name: "bad glob", pattern: "a/[", strategy: MergeStrategySrcWinsStr},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := NewMergeStrategyRule(tt.pattern, tt.strategy)
+			if !errors.Is(err, ErrInvalidMergeStrategy) {
+				t.Errorf("NewMergeStrategyRule(%s, %s) err=%v, expected %s", tt.pattern, tt.strategy, err, ErrInvalidMergeStrategy)
+			}
+		})
+	}
+}
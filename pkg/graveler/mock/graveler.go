@@ -13,6 +13,58 @@ import (
 	graveler "github.com/treeverse/lakefs/pkg/graveler"
 )
 
+// MockConflictResolver is a mock of ConflictResolver interface.
+type MockConflictResolver struct {
+	ctrl     *gomock.Controller
+	recorder *MockConflictResolverMockRecorder
+}
+
+// MockConflictResolverMockRecorder is the mock recorder for MockConflictResolver.
+type MockConflictResolverMockRecorder struct {
+	mock *MockConflictResolver
+}
+
+// NewMockConflictResolver creates a new mock instance.
+func NewMockConflictResolver(ctrl *gomock.Controller) *MockConflictResolver {
+	mock := &MockConflictResolver{ctrl: ctrl}
+	mock.recorder = &MockConflictResolverMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockConflictResolver) EXPECT() *MockConflictResolverMockRecorder {
+	return m.recorder
+}
+
+// Name mocks base method.
+func (m *MockConflictResolver) Name() string {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Name")
+	ret0, _ := ret[0].(string)
+	return ret0
+}
+
+// Name indicates an expected call of Name.
+func (mr *MockConflictResolverMockRecorder) Name() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Name", reflect.TypeOf((*MockConflictResolver)(nil).Name))
+}
+
+// ResolveConflict mocks base method.
+func (m *MockConflictResolver) ResolveConflict(ctx context.Context, conflict graveler.MergeConflict) (*graveler.Value, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ResolveConflict", ctx, conflict)
+	ret0, _ := ret[0].(*graveler.Value)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ResolveConflict indicates an expected call of ResolveConflict.
+func (mr *MockConflictResolverMockRecorder) ResolveConflict(ctx, conflict interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ResolveConflict", reflect.TypeOf((*MockConflictResolver)(nil).ResolveConflict), ctx, conflict)
+}
+
 // MockKeyValueStore is a mock of KeyValueStore interface.
 type MockKeyValueStore struct {
 	ctrl     *gomock.Controller
@@ -766,6 +818,26 @@ func (mr *MockVersionControllerMockRecorder) ParseRef(ref interface{}) *gomock.C
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ParseRef", reflect.TypeOf((*MockVersionController)(nil).ParseRef), ref)
 }
 
+// PreviewMerge mocks base method.
+func (m *MockVersionController) PreviewMerge(ctx context.Context, repository *graveler.RepositoryRecord, destination graveler.BranchID, source graveler.Ref, strategy string, after graveler.Key, amount int, opts ...graveler.SetOptionsFunc) (*graveler.MergePreview, error) {
+	m.ctrl.T.Helper()
+	varargs := []interface{}{ctx, repository, destination, source, strategy, after, amount}
+	for _, a := range opts {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "PreviewMerge", varargs...)
+	ret0, _ := ret[0].(*graveler.MergePreview)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// PreviewMerge indicates an expected call of PreviewMerge.
+func (mr *MockVersionControllerMockRecorder) PreviewMerge(ctx, repository, destination, source, strategy, after, amount interface{}, opts ...interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	varargs := append([]interface{}{ctx, repository, destination, source, strategy, after, amount}, opts...)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PreviewMerge", reflect.TypeOf((*MockVersionController)(nil).PreviewMerge), varargs...)
+}
+
 // Reset mocks base method.
 func (m *MockVersionController) Reset(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, opts ...graveler.SetOptionsFunc) error {
 	m.ctrl.T.Helper()
@@ -1278,6 +1350,21 @@ func (mr *MockCollaboratorMockRecorder) CreatePullRequest(ctx, repository, pullR
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockCollaborator)(nil).CreatePullRequest), ctx, repository, pullRequest)
 }
 
+// CreatePullRequestComment mocks base method.
+func (m *MockCollaborator) CreatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, comment *graveler.PullRequestComment) (graveler.PullRequestCommentID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequestComment", ctx, repository, pullRequestID, comment)
+	ret0, _ := ret[0].(graveler.PullRequestCommentID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreatePullRequestComment indicates an expected call of CreatePullRequestComment.
+func (mr *MockCollaboratorMockRecorder) CreatePullRequestComment(ctx, repository, pullRequestID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestComment", reflect.TypeOf((*MockCollaborator)(nil).CreatePullRequestComment), ctx, repository, pullRequestID, comment)
+}
+
 // GetPullRequest mocks base method.
 func (m *MockCollaborator) GetPullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID) (*graveler.PullRequest, error) {
 	m.ctrl.T.Helper()
@@ -1293,6 +1380,36 @@ func (mr *MockCollaboratorMockRecorder) GetPullRequest(ctx, repository, pullRequ
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockCollaborator)(nil).GetPullRequest), ctx, repository, pullRequestID)
 }
 
+// GetPullRequestComment mocks base method.
+func (m *MockCollaborator) GetPullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, commentID graveler.PullRequestCommentID) (*graveler.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestComment", ctx, repository, pullRequestID, commentID)
+	ret0, _ := ret[0].(*graveler.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestComment indicates an expected call of GetPullRequestComment.
+func (mr *MockCollaboratorMockRecorder) GetPullRequestComment(ctx, repository, pullRequestID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestComment", reflect.TypeOf((*MockCollaborator)(nil).GetPullRequestComment), ctx, repository, pullRequestID, commentID)
+}
+
+// ListPullRequestComments mocks base method.
+func (m *MockCollaborator) ListPullRequestComments(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID) (graveler.PullCommentsIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestComments", ctx, repository, pullRequestID)
+	ret0, _ := ret[0].(graveler.PullCommentsIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestComments indicates an expected call of ListPullRequestComments.
+func (mr *MockCollaboratorMockRecorder) ListPullRequestComments(ctx, repository, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockCollaborator)(nil).ListPullRequestComments), ctx, repository, pullRequestID)
+}
+
 // ListPullRequests mocks base method.
 func (m *MockCollaborator) ListPullRequests(ctx context.Context, repository *graveler.RepositoryRecord) (graveler.PullsIterator, error) {
 	m.ctrl.T.Helper()
@@ -1308,6 +1425,20 @@ func (mr *MockCollaboratorMockRecorder) ListPullRequests(ctx, repository interfa
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequests", reflect.TypeOf((*MockCollaborator)(nil).ListPullRequests), ctx, repository)
 }
 
+// ReviewPullRequest mocks base method.
+func (m *MockCollaborator) ReviewPullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, review *graveler.PullRequestReview) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReviewPullRequest", ctx, repository, pullRequestID, review)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ReviewPullRequest indicates an expected call of ReviewPullRequest.
+func (mr *MockCollaboratorMockRecorder) ReviewPullRequest(ctx, repository, pullRequestID, review interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReviewPullRequest", reflect.TypeOf((*MockCollaborator)(nil).ReviewPullRequest), ctx, repository, pullRequestID, review)
+}
+
 // UpdatePullRequest mocks base method.
 func (m *MockCollaborator) UpdatePullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, update *graveler.UpdatePullRequest) error {
 	m.ctrl.T.Helper()
@@ -1322,6 +1453,20 @@ func (mr *MockCollaboratorMockRecorder) UpdatePullRequest(ctx, repository, pullR
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequest", reflect.TypeOf((*MockCollaborator)(nil).UpdatePullRequest), ctx, repository, pullRequestID, update)
 }
 
+// UpdatePullRequestComment mocks base method.
+func (m *MockCollaborator) UpdatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, commentID graveler.PullRequestCommentID, update *graveler.UpdatePullRequestComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePullRequestComment", ctx, repository, pullRequestID, commentID, update)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePullRequestComment indicates an expected call of UpdatePullRequestComment.
+func (mr *MockCollaboratorMockRecorder) UpdatePullRequestComment(ctx, repository, pullRequestID, commentID, update interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestComment", reflect.TypeOf((*MockCollaborator)(nil).UpdatePullRequestComment), ctx, repository, pullRequestID, commentID, update)
+}
+
 // MockRepositoryIterator is a mock of RepositoryIterator interface.
 type MockRepositoryIterator struct {
 	ctrl     *gomock.Controller
@@ -2034,6 +2179,95 @@ func (mr *MockPullsIteratorMockRecorder) Value() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Value", reflect.TypeOf((*MockPullsIterator)(nil).Value))
 }
 
+// MockPullCommentsIterator is a mock of PullCommentsIterator interface.
+type MockPullCommentsIterator struct {
+	ctrl     *gomock.Controller
+	recorder *MockPullCommentsIteratorMockRecorder
+}
+
+// MockPullCommentsIteratorMockRecorder is the mock recorder for MockPullCommentsIterator.
+type MockPullCommentsIteratorMockRecorder struct {
+	mock *MockPullCommentsIterator
+}
+
+// NewMockPullCommentsIterator creates a new mock instance.
+func NewMockPullCommentsIterator(ctrl *gomock.Controller) *MockPullCommentsIterator {
+	mock := &MockPullCommentsIterator{ctrl: ctrl}
+	mock.recorder = &MockPullCommentsIteratorMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPullCommentsIterator) EXPECT() *MockPullCommentsIteratorMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockPullCommentsIterator) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockPullCommentsIteratorMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockPullCommentsIterator)(nil).Close))
+}
+
+// Err mocks base method.
+func (m *MockPullCommentsIterator) Err() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Err")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Err indicates an expected call of Err.
+func (mr *MockPullCommentsIteratorMockRecorder) Err() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Err", reflect.TypeOf((*MockPullCommentsIterator)(nil).Err))
+}
+
+// Next mocks base method.
+func (m *MockPullCommentsIterator) Next() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Next")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// Next indicates an expected call of Next.
+func (mr *MockPullCommentsIteratorMockRecorder) Next() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Next", reflect.TypeOf((*MockPullCommentsIterator)(nil).Next))
+}
+
+// SeekGE mocks base method.
+func (m *MockPullCommentsIterator) SeekGE(id graveler.PullRequestCommentID) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "SeekGE", id)
+}
+
+// SeekGE indicates an expected call of SeekGE.
+func (mr *MockPullCommentsIteratorMockRecorder) SeekGE(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SeekGE", reflect.TypeOf((*MockPullCommentsIterator)(nil).SeekGE), id)
+}
+
+// Value mocks base method.
+func (m *MockPullCommentsIterator) Value() *graveler.PullRequestComment {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Value")
+	ret0, _ := ret[0].(*graveler.PullRequestComment)
+	return ret0
+}
+
+// Value indicates an expected call of Value.
+func (mr *MockPullCommentsIteratorMockRecorder) Value() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Value", reflect.TypeOf((*MockPullCommentsIterator)(nil).Value))
+}
+
 // MockRefManager is a mock of RefManager interface.
 type MockRefManager struct {
 	ctrl     *gomock.Controller
@@ -2086,6 +2320,21 @@ func (mr *MockRefManagerMockRecorder) BranchUpdate(ctx, repository, branchID, f
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BranchUpdate", reflect.TypeOf((*MockRefManager)(nil).BranchUpdate), ctx, repository, branchID, f)
 }
 
+// CommitBranchUpdate mocks base method.
+func (m *MockRefManager) CommitBranchUpdate(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CommitBranchUpdate", ctx, repository, branchID, f)
+	ret0, _ := ret[0].(graveler.CommitID)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CommitBranchUpdate indicates an expected call of CommitBranchUpdate.
+func (mr *MockRefManagerMockRecorder) CommitBranchUpdate(ctx, repository, branchID, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CommitBranchUpdate", reflect.TypeOf((*MockRefManager)(nil).CommitBranchUpdate), ctx, repository, branchID, f)
+}
+
 // CreateBareRepository mocks base method.
 func (m *MockRefManager) CreateBareRepository(ctx context.Context, repositoryID graveler.RepositoryID, repository graveler.Repository) (*graveler.RepositoryRecord, error) {
 	m.ctrl.T.Helper()
@@ -2143,6 +2392,20 @@ func (mr *MockRefManagerMockRecorder) CreatePullRequest(ctx, repository, pullReq
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequest", reflect.TypeOf((*MockRefManager)(nil).CreatePullRequest), ctx, repository, pullRequestID, pullRequest)
 }
 
+// CreatePullRequestComment mocks base method.
+func (m *MockRefManager) CreatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, comment *graveler.PullRequestComment) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreatePullRequestComment", ctx, repository, pullRequestID, comment)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// CreatePullRequestComment indicates an expected call of CreatePullRequestComment.
+func (mr *MockRefManagerMockRecorder) CreatePullRequestComment(ctx, repository, pullRequestID, comment interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreatePullRequestComment", reflect.TypeOf((*MockRefManager)(nil).CreatePullRequestComment), ctx, repository, pullRequestID, comment)
+}
+
 // CreateRepository mocks base method.
 func (m *MockRefManager) CreateRepository(ctx context.Context, repositoryID graveler.RepositoryID, repository graveler.Repository) (*graveler.RepositoryRecord, error) {
 	m.ctrl.T.Helper()
@@ -2357,6 +2620,21 @@ func (mr *MockRefManagerMockRecorder) GetPullRequest(ctx, repository, pullID int
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequest", reflect.TypeOf((*MockRefManager)(nil).GetPullRequest), ctx, repository, pullID)
 }
 
+// GetPullRequestComment mocks base method.
+func (m *MockRefManager) GetPullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, commentID graveler.PullRequestCommentID) (*graveler.PullRequestComment, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetPullRequestComment", ctx, repository, pullRequestID, commentID)
+	ret0, _ := ret[0].(*graveler.PullRequestComment)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetPullRequestComment indicates an expected call of GetPullRequestComment.
+func (mr *MockRefManagerMockRecorder) GetPullRequestComment(ctx, repository, pullRequestID, commentID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetPullRequestComment", reflect.TypeOf((*MockRefManager)(nil).GetPullRequestComment), ctx, repository, pullRequestID, commentID)
+}
+
 // GetRepository mocks base method.
 func (m *MockRefManager) GetRepository(ctx context.Context, repositoryID graveler.RepositoryID) (*graveler.RepositoryRecord, error) {
 	m.ctrl.T.Helper()
@@ -2432,6 +2710,21 @@ func (mr *MockRefManagerMockRecorder) ListCommits(ctx, repository interface{}) *
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListCommits", reflect.TypeOf((*MockRefManager)(nil).ListCommits), ctx, repository)
 }
 
+// ListPullRequestComments mocks base method.
+func (m *MockRefManager) ListPullRequestComments(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID) (graveler.PullCommentsIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPullRequestComments", ctx, repository, pullRequestID)
+	ret0, _ := ret[0].(graveler.PullCommentsIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPullRequestComments indicates an expected call of ListPullRequestComments.
+func (mr *MockRefManagerMockRecorder) ListPullRequestComments(ctx, repository, pullRequestID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPullRequestComments", reflect.TypeOf((*MockRefManager)(nil).ListPullRequestComments), ctx, repository, pullRequestID)
+}
+
 // ListPullRequests mocks base method.
 func (m *MockRefManager) ListPullRequests(ctx context.Context, repository *graveler.RepositoryRecord) (graveler.PullsIterator, error) {
 	m.ctrl.T.Helper()
@@ -2578,6 +2871,20 @@ func (mr *MockRefManagerMockRecorder) UpdatePullRequest(ctx, repository, pullReq
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequest", reflect.TypeOf((*MockRefManager)(nil).UpdatePullRequest), ctx, repository, pullRequestID, f)
 }
 
+// UpdatePullRequestComment mocks base method.
+func (m *MockRefManager) UpdatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, commentID graveler.PullRequestCommentID, f graveler.PullCommentUpdateFunc) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdatePullRequestComment", ctx, repository, pullRequestID, commentID, f)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdatePullRequestComment indicates an expected call of UpdatePullRequestComment.
+func (mr *MockRefManagerMockRecorder) UpdatePullRequestComment(ctx, repository, pullRequestID, commentID, f interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdatePullRequestComment", reflect.TypeOf((*MockRefManager)(nil).UpdatePullRequestComment), ctx, repository, pullRequestID, commentID, f)
+}
+
 // MockCommittedManager is a mock of CommittedManager interface.
 type MockCommittedManager struct {
 	ctrl     *gomock.Controller
@@ -3210,6 +3517,21 @@ func (mr *MockProtectedBranchesManagerMockRecorder) IsBlocked(ctx, repository, b
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "IsBlocked", reflect.TypeOf((*MockProtectedBranchesManager)(nil).IsBlocked), ctx, repository, branchID, action)
 }
 
+// RequiredApprovals mocks base method.
+func (m *MockProtectedBranchesManager) RequiredApprovals(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (int, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RequiredApprovals", ctx, repository, branchID)
+	ret0, _ := ret[0].(int)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// RequiredApprovals indicates an expected call of RequiredApprovals.
+func (mr *MockProtectedBranchesManagerMockRecorder) RequiredApprovals(ctx, repository, branchID interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RequiredApprovals", reflect.TypeOf((*MockProtectedBranchesManager)(nil).RequiredApprovals), ctx, repository, branchID)
+}
+
 // SetRules mocks base method.
 func (m *MockProtectedBranchesManager) SetRules(ctx context.Context, repository *graveler.RepositoryRecord, rules *graveler.BranchProtectionRules, lastKnownChecksum *string) error {
 	m.ctrl.T.Helper()
@@ -17,6 +17,7 @@ const (
 	settingsPrefix         = "settings"
 	importsPrefix          = "imports"
 	repoMetadataPrefix     = "repo-metadata"
+	objectLocksPrefix      = "object_locks"
 )
 
 //nolint:gochecknoinits
@@ -25,6 +26,7 @@ func init() {
 	kv.MustRegisterType("*", "branches", (&BranchData{}).ProtoReflect().Type())
 	kv.MustRegisterType("*", "commits", (&CommitData{}).ProtoReflect().Type())
 	kv.MustRegisterType("*", "tags", (&TagData{}).ProtoReflect().Type())
+	kv.MustRegisterType("*", objectLocksPrefix, (&ObjectLockData{}).ProtoReflect().Type())
 	kv.MustRegisterType("*", "*", (&StagedEntryData{}).ProtoReflect().Type())
 }
 
@@ -76,6 +78,18 @@ func RepoMetadataPath() string {
 	return repoMetadataPrefix
 }
 
+// ObjectLocksPath is the path of the locks of objects on branchID, or of all locks if branchID is empty
+func ObjectLocksPath(branchID BranchID) string {
+	if branchID == "" {
+		return objectLocksPrefix
+	}
+	return kv.FormatPath(objectLocksPrefix, branchID.String())
+}
+
+func ObjectLockPath(branchID BranchID, key Key) string {
+	return kv.FormatPath(objectLocksPrefix, branchID.String(), key.String())
+}
+
 func CommitFromProto(pb *CommitData) *Commit {
 	parents := make([]CommitID, 0)
 	for _, parent := range pb.Parents {
@@ -237,6 +251,15 @@ func PullRequestFromProto(pb *PullRequestData) *PullRequestRecord {
 		pbTime := pb.ClosedAt.AsTime()
 		pr.ClosedDate = &pbTime
 	}
+	for _, review := range pb.Reviews {
+		pr.Reviews = append(pr.Reviews, PullRequestReview{
+			Reviewer:     review.Reviewer,
+			State:        review.State,
+			Comment:      review.Comment,
+			CreationDate: review.CreatedAt.AsTime(),
+			CommitID:     CommitID(review.CommitId),
+		})
+	}
 	return pr
 }
 
@@ -255,6 +278,51 @@ func ProtoFromPullRequest(pullID PullRequestID, pull *PullRequest) *PullRequestD
 	if pull.ClosedDate != nil {
 		prData.ClosedAt = timestamppb.New(*pull.ClosedDate)
 	}
+	for _, review := range pull.Reviews {
+		prData.Reviews = append(prData.Reviews, &PullRequestReviewData{
+			Reviewer:  review.Reviewer,
+			State:     review.State,
+			Comment:   review.Comment,
+			CreatedAt: timestamppb.New(review.CreationDate),
+			CommitId:  review.CommitID.String(),
+		})
+	}
 
 	return prData
 }
+
+func PullRequestCommentFromProto(pb *PullRequestCommentData) *PullRequestComment {
+	comment := &PullRequestComment{
+		ID:           PullRequestCommentID(pb.Id),
+		Author:       pb.Author,
+		Body:         pb.Body,
+		Path:         pb.Path,
+		ReplyTo:      PullRequestCommentID(pb.ReplyTo),
+		CreationDate: pb.CreatedAt.AsTime(),
+		Resolved:     pb.Resolved,
+		ResolvedBy:   pb.ResolvedBy,
+	}
+	if pb.UpdatedAt != nil {
+		pbTime := pb.UpdatedAt.AsTime()
+		comment.UpdateDate = &pbTime
+	}
+	return comment
+}
+
+func ProtoFromPullRequestComment(pullID PullRequestID, comment *PullRequestComment) *PullRequestCommentData {
+	commentData := &PullRequestCommentData{
+		Id:            comment.ID.String(),
+		PullRequestId: pullID.String(),
+		Author:        comment.Author,
+		Body:          comment.Body,
+		Path:          comment.Path,
+		ReplyTo:       comment.ReplyTo.String(),
+		CreatedAt:     timestamppb.New(comment.CreationDate),
+		Resolved:      comment.Resolved,
+		ResolvedBy:    comment.ResolvedBy,
+	}
+	if comment.UpdateDate != nil {
+		commentData.UpdatedAt = timestamppb.New(*comment.UpdateDate)
+	}
+	return commentData
+}
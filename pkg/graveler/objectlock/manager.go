@@ -0,0 +1,257 @@
+// Package objectlock manages the object locks of a repository: retention periods and legal holds that stop objects
+// from being overwritten or deleted on their branch, and the versions they lock from being garbage collected.
+package objectlock
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/settings"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+const SettingKey = "object_lock"
+
+var ErrInvalidRetention = fmt.Errorf("retention: %w", graveler.ErrInvalidValue)
+
+// Object identifies the version of an object on a branch that a lock applies to
+type Object struct {
+	BranchID graveler.BranchID
+	Key      graveler.Key
+	// CommitID is a commit holding the version of the object
+	CommitID graveler.CommitID
+	Identity []byte
+}
+
+// Retention is the retention period of a locked object
+type Retention struct {
+	Mode        graveler.ObjectLockMode
+	RetainUntil time.Time
+}
+
+type Manager struct {
+	settingManager *settings.Manager
+	store          kv.Store
+	// enabled holds the partitions of repositories known to have object lock enabled, as the configuration read
+	// from settingManager may be cached from before it was enabled
+	enabled sync.Map
+}
+
+func NewManager(settingManager *settings.Manager, store kv.Store) *Manager {
+	return &Manager{settingManager: settingManager, store: store}
+}
+
+func (m *Manager) GetConfiguration(ctx context.Context, repository *graveler.RepositoryRecord) (*graveler.ObjectLockConfiguration, *string, error) {
+	configuration := &graveler.ObjectLockConfiguration{}
+	checksum, err := m.settingManager.GetLatest(ctx, repository, SettingKey, configuration)
+	if err != nil {
+		return nil, nil, err
+	}
+	return configuration, checksum, nil
+}
+
+// SetConfiguration saves the object lock configuration of the repository.  Object lock cannot be disabled once it is
+// enabled.
+func (m *Manager) SetConfiguration(ctx context.Context, repository *graveler.RepositoryRecord, configuration *graveler.ObjectLockConfiguration, lastKnownChecksum *string) error {
+	if !configuration.GetEnabled() {
+		current, _, err := m.GetConfiguration(ctx, repository)
+		if err != nil {
+			return err
+		}
+		if current.GetEnabled() {
+			return fmt.Errorf("object lock cannot be disabled: %w", graveler.ErrInvalidValue)
+		}
+	}
+	err := m.settingManager.Save(ctx, repository, SettingKey, configuration, lastKnownChecksum)
+	if err != nil {
+		return err
+	}
+	if configuration.GetEnabled() {
+		m.enabled.Store(graveler.RepoPartition(repository), true)
+	}
+	return nil
+}
+
+// isEnabled returns whether object lock is enabled for the repository.  Repositories without it have no locks, and
+// need no further lookups.
+func (m *Manager) isEnabled(ctx context.Context, repository *graveler.RepositoryRecord) (bool, error) {
+	partition := graveler.RepoPartition(repository)
+	if _, ok := m.enabled.Load(partition); ok {
+		return true, nil
+	}
+	configuration := &graveler.ObjectLockConfiguration{}
+	err := m.settingManager.Get(ctx, repository, SettingKey, configuration)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	if configuration.GetEnabled() {
+		m.enabled.Store(partition, true)
+	}
+	return configuration.GetEnabled(), nil
+}
+
+// GetLock returns the lock of the object key on branchID, ErrNotFound if it was never locked
+func (m *Manager) GetLock(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key) (*graveler.ObjectLockData, error) {
+	lock := &graveler.ObjectLockData{}
+	_, err := kv.GetMsg(ctx, m.store, graveler.RepoPartition(repository), []byte(graveler.ObjectLockPath(branchID, key)), lock)
+	if errors.Is(err, kv.ErrNotFound) {
+		return nil, graveler.ErrNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return lock, nil
+}
+
+// SetRetention sets the retention period of the object, or removes it if retention is nil.  The retention period of
+// a COMPLIANCE lock can only be extended.  The retention period of a GOVERNANCE lock can be shortened or removed
+// only with bypassGovernance.
+func (m *Manager) SetRetention(ctx context.Context, repository *graveler.RepositoryRecord, object Object, retention *Retention, bypassGovernance bool) error {
+	now := time.Now()
+	if retention != nil && !retention.RetainUntil.After(now) {
+		return fmt.Errorf("%w: retain until date must be in the future", ErrInvalidRetention)
+	}
+	return m.update(ctx, repository, object, func(lock *graveler.ObjectLockData) error {
+		if isRetained(lock, now) {
+			currentUntil := lock.GetRetainUntil().AsTime()
+			shortened := retention == nil || retention.RetainUntil.Before(currentUntil)
+			switch {
+			case lock.GetMode() == graveler.ObjectLockMode_COMPLIANCE &&
+				(shortened || retention.Mode != graveler.ObjectLockMode_COMPLIANCE):
+				return fmt.Errorf("compliance retention can only be extended: %w", graveler.ErrObjectLocked)
+			case lock.GetMode() == graveler.ObjectLockMode_GOVERNANCE && shortened && !bypassGovernance:
+				return fmt.Errorf("governance retention can only be shortened when bypassed: %w", graveler.ErrObjectLocked)
+			}
+		}
+		if retention == nil {
+			lock.Mode = graveler.ObjectLockMode_GOVERNANCE
+			lock.RetainUntil = nil
+			return nil
+		}
+		lock.Mode = retention.Mode
+		lock.RetainUntil = timestamppb.New(retention.RetainUntil)
+		return nil
+	})
+}
+
+// SetLegalHold places or removes a legal hold on the object
+func (m *Manager) SetLegalHold(ctx context.Context, repository *graveler.RepositoryRecord, object Object, hold bool) error {
+	return m.update(ctx, repository, object, func(lock *graveler.ObjectLockData) error {
+		lock.LegalHold = hold
+		return nil
+	})
+}
+
+// update calls f with the current lock of the object and saves the result.  An active lock keeps locking the version
+// it was placed on, other locks are placed on the version of object.
+func (m *Manager) update(ctx context.Context, repository *graveler.RepositoryRecord, object Object, f func(lock *graveler.ObjectLockData) error) error {
+	enabled, err := m.isEnabled(ctx, repository)
+	if err != nil {
+		return err
+	}
+	if !enabled {
+		return graveler.ErrObjectLockNotEnabled
+	}
+	partition := graveler.RepoPartition(repository)
+	path := []byte(graveler.ObjectLockPath(object.BranchID, object.Key))
+	lock := &graveler.ObjectLockData{}
+	predicate, err := kv.GetMsg(ctx, m.store, partition, path, lock)
+	if err != nil && !errors.Is(err, kv.ErrNotFound) {
+		return err
+	}
+	if !isActive(lock, time.Now()) {
+		lock = &graveler.ObjectLockData{
+			BranchId: object.BranchID.String(),
+			Key:      object.Key,
+			CommitId: object.CommitID.String(),
+			Identity: object.Identity,
+		}
+	}
+	if err := f(lock); err != nil {
+		return err
+	}
+	err = kv.SetMsgIf(ctx, m.store, partition, path, lock, predicate)
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		return graveler.ErrPreconditionFailed
+	}
+	return err
+}
+
+func (m *Manager) IsLocked(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key) (bool, error) {
+	enabled, err := m.isEnabled(ctx, repository)
+	if err != nil || !enabled {
+		return false, err
+	}
+	lock, err := m.GetLock(ctx, repository, branchID, key)
+	if errors.Is(err, graveler.ErrNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return isActive(lock, time.Now()), nil
+}
+
+func (m *Manager) HasLocks(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) (bool, error) {
+	hasLocks := false
+	err := m.forEachActiveLock(ctx, repository, branchID, func(*graveler.ObjectLockData) bool {
+		hasLocks = true
+		return false
+	})
+	return hasLocks, err
+}
+
+func (m *Manager) LockedCommits(ctx context.Context, repository *graveler.RepositoryRecord) ([]graveler.CommitID, error) {
+	var commitIDs []graveler.CommitID
+	seen := make(map[string]struct{})
+	err := m.forEachActiveLock(ctx, repository, "", func(lock *graveler.ObjectLockData) bool {
+		if _, ok := seen[lock.GetCommitId()]; !ok {
+			seen[lock.GetCommitId()] = struct{}{}
+			commitIDs = append(commitIDs, graveler.CommitID(lock.GetCommitId()))
+		}
+		return true
+	})
+	return commitIDs, err
+}
+
+// forEachActiveLock calls f with the active locks of objects on branchID, or of all branches if branchID is empty,
+// until f returns false
+func (m *Manager) forEachActiveLock(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, f func(*graveler.ObjectLockData) bool) error {
+	enabled, err := m.isEnabled(ctx, repository)
+	if err != nil || !enabled {
+		return err
+	}
+	prefix := []byte(kv.FormatPath(graveler.ObjectLocksPath(branchID), ""))
+	it, err := kv.NewPrimaryIterator(ctx, m.store, (&graveler.ObjectLockData{}).ProtoReflect().Type(),
+		graveler.RepoPartition(repository), prefix, kv.IteratorOptionsFrom(prefix))
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	now := time.Now()
+	for it.Next() {
+		lock := it.Entry().Value.(*graveler.ObjectLockData)
+		if isActive(lock, now) && !f(lock) {
+			break
+		}
+	}
+	return it.Err()
+}
+
+// isRetained returns whether the retention period of lock has not ended
+func isRetained(lock *graveler.ObjectLockData, now time.Time) bool {
+	return lock.GetRetainUntil() != nil && lock.GetRetainUntil().AsTime().After(now)
+}
+
+// isActive returns whether lock locks its object
+func isActive(lock *graveler.ObjectLockData, now time.Time) bool {
+	return lock.GetLegalHold() || isRetained(lock, now)
+}
@@ -0,0 +1,202 @@
+package objectlock_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/graveler/mock"
+	"github.com/treeverse/lakefs/pkg/graveler/objectlock"
+	"github.com/treeverse/lakefs/pkg/graveler/settings"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+)
+
+var repository = &graveler.RepositoryRecord{
+	RepositoryID: "example-repo",
+	Repository: &graveler.Repository{
+		StorageNamespace: "mem://my-storage",
+		DefaultBranchID:  "main",
+	},
+}
+
+var object = objectlock.Object{
+	BranchID: "main",
+	Key:      graveler.Key("data/file.csv"),
+	CommitID: "c1",
+	Identity: []byte("identity-1"),
+}
+
+func TestConfiguration(t *testing.T) {
+	ctx := context.Background()
+	m := prepareTest(t, ctx)
+
+	err := m.SetLegalHold(ctx, repository, object, true)
+	require.ErrorIs(t, err, graveler.ErrObjectLockNotEnabled)
+	locked, err := m.IsLocked(ctx, repository, object.BranchID, object.Key)
+	require.NoError(t, err)
+	require.False(t, locked)
+
+	configuration, checksum, err := m.GetConfiguration(ctx, repository)
+	require.NoError(t, err)
+	require.False(t, configuration.GetEnabled())
+	require.NoError(t, m.SetConfiguration(ctx, repository, &graveler.ObjectLockConfiguration{Enabled: true}, checksum))
+
+	configuration, checksum, err = m.GetConfiguration(ctx, repository)
+	require.NoError(t, err)
+	require.True(t, configuration.GetEnabled())
+	err = m.SetConfiguration(ctx, repository, &graveler.ObjectLockConfiguration{Enabled: false}, checksum)
+	require.ErrorIs(t, err, graveler.ErrInvalidValue)
+}
+
+func TestLegalHold(t *testing.T) {
+	ctx := context.Background()
+	m := prepareEnabledTest(t, ctx)
+
+	require.NoError(t, m.SetLegalHold(ctx, repository, object, true))
+	requireLocked(t, ctx, m, true)
+	lock, err := m.GetLock(ctx, repository, object.BranchID, object.Key)
+	require.NoError(t, err)
+	require.True(t, lock.GetLegalHold())
+	require.Equal(t, object.CommitID.String(), lock.GetCommitId())
+	require.Equal(t, object.Identity, lock.GetIdentity())
+
+	hasLocks, err := m.HasLocks(ctx, repository, "main")
+	require.NoError(t, err)
+	require.True(t, hasLocks)
+	hasLocks, err = m.HasLocks(ctx, repository, "main2")
+	require.NoError(t, err)
+	require.False(t, hasLocks)
+
+	require.NoError(t, m.SetLegalHold(ctx, repository, object, false))
+	requireLocked(t, ctx, m, false)
+	hasLocks, err = m.HasLocks(ctx, repository, "main")
+	require.NoError(t, err)
+	require.False(t, hasLocks)
+}
+
+func TestRetention(t *testing.T) {
+	ctx := context.Background()
+	hour := time.Now().Add(time.Hour)
+	day := time.Now().Add(24 * time.Hour)
+
+	t.Run("past", func(t *testing.T) {
+		m := prepareEnabledTest(t, ctx)
+		err := m.SetRetention(ctx, repository, object, &objectlock.Retention{RetainUntil: time.Now().Add(-time.Hour)}, false)
+		require.ErrorIs(t, err, objectlock.ErrInvalidRetention)
+		requireLocked(t, ctx, m, false)
+	})
+
+	t.Run("governance", func(t *testing.T) {
+		m := prepareEnabledTest(t, ctx)
+		require.NoError(t, m.SetRetention(ctx, repository, object, &objectlock.Retention{Mode: graveler.ObjectLockMode_GOVERNANCE, RetainUntil: day}, false))
+		requireLocked(t, ctx, m, true)
+
+		err := m.SetRetention(ctx, repository, object, &objectlock.Retention{Mode: graveler.ObjectLockMode_GOVERNANCE, RetainUntil: hour}, false)
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+		err = m.SetRetention(ctx, repository, object, nil, false)
+		require.ErrorIs(t, err, graveler.ErrObjectLocked)
+
+		require.NoError(t, m.SetRetention(ctx, repository, object, &objectlock.Retention{Mode: graveler.ObjectLockMode_GOVERNANCE, RetainUntil: hour}, true))
+		requireLocked(t, ctx, m, true)
+		require.NoError(t, m.SetRetention(ctx, repository, object, nil, true))
+		requireLocked(t, ctx, m, false)
+	})
+
+	t.Run("compliance", func(t *testing.T) {
+		m := prepareEnabledTest(t, ctx)
+		require.NoError(t, m.SetRetention(ctx, repository, object, &objectlock.Retention{Mode: graveler.ObjectLockMode_COMPLIANCE, RetainUntil: hour}, false))
+		requireLocked(t, ctx, m, true)
+
+		for _, retention := range []*objectlock.Retention{
+			nil,
+			{Mode: graveler.ObjectLockMode_COMPLIANCE, RetainUntil: hour.Add(-time.Minute)},
+			{Mode: graveler.ObjectLockMode_GOVERNANCE, RetainUntil: day},
+		} {
+			err := m.SetRetention(ctx, repository, object, retention, true)
+			require.ErrorIs(t, err, graveler.ErrObjectLocked)
+		}
+		require.NoError(t, m.SetRetention(ctx, repository, object, &objectlock.Retention{Mode: graveler.ObjectLockMode_COMPLIANCE, RetainUntil: day}, false))
+		lock, err := m.GetLock(ctx, repository, object.BranchID, object.Key)
+		require.NoError(t, err)
+		require.Equal(t, graveler.ObjectLockMode_COMPLIANCE, lock.GetMode())
+		require.WithinDuration(t, day, lock.GetRetainUntil().AsTime(), time.Millisecond)
+	})
+}
+
+func TestActiveLockKeepsVersion(t *testing.T) {
+	ctx := context.Background()
+	m := prepareEnabledTest(t, ctx)
+	require.NoError(t, m.SetLegalHold(ctx, repository, object, true))
+
+	newVersion := object
+	newVersion.CommitID = "c2"
+	newVersion.Identity = []byte("identity-2")
+	require.NoError(t, m.SetRetention(ctx, repository, newVersion, &objectlock.Retention{RetainUntil: time.Now().Add(time.Hour)}, false))
+	lock, err := m.GetLock(ctx, repository, object.BranchID, object.Key)
+	require.NoError(t, err)
+	require.Equal(t, "c1", lock.GetCommitId())
+
+	// once the lock is released, a new lock applies to the new version
+	require.NoError(t, m.SetLegalHold(ctx, repository, object, false))
+	require.NoError(t, m.SetRetention(ctx, repository, object, nil, true))
+	require.NoError(t, m.SetLegalHold(ctx, repository, newVersion, true))
+	lock, err = m.GetLock(ctx, repository, object.BranchID, object.Key)
+	require.NoError(t, err)
+	require.Equal(t, "c2", lock.GetCommitId())
+	require.Equal(t, newVersion.Identity, lock.GetIdentity())
+}
+
+func TestLockedCommits(t *testing.T) {
+	ctx := context.Background()
+	m := prepareEnabledTest(t, ctx)
+	objects := []objectlock.Object{
+		{BranchID: "main", Key: graveler.Key("a"), CommitID: "c1"},
+		{BranchID: "main", Key: graveler.Key("b"), CommitID: "c1"},
+		{BranchID: "dev", Key: graveler.Key("a"), CommitID: "c2"},
+		{BranchID: "dev", Key: graveler.Key("b"), CommitID: "c3"},
+	}
+	for _, o := range objects {
+		require.NoError(t, m.SetLegalHold(ctx, repository, o, true))
+	}
+	require.NoError(t, m.SetLegalHold(ctx, repository, objects[3], false))
+
+	commitIDs, err := m.LockedCommits(ctx, repository)
+	require.NoError(t, err)
+	require.ElementsMatch(t, []graveler.CommitID{"c1", "c2"}, commitIDs)
+}
+
+func TestGetLockNotFound(t *testing.T) {
+	ctx := context.Background()
+	m := prepareEnabledTest(t, ctx)
+	_, err := m.GetLock(ctx, repository, object.BranchID, object.Key)
+	if !errors.Is(err, graveler.ErrNotFound) {
+		t.Fatalf("GetLock() error = %v, expected %v", err, graveler.ErrNotFound)
+	}
+}
+
+func requireLocked(t *testing.T, ctx context.Context, m *objectlock.Manager, expected bool) {
+	t.Helper()
+	locked, err := m.IsLocked(ctx, repository, object.BranchID, object.Key)
+	require.NoError(t, err)
+	require.Equal(t, expected, locked)
+}
+
+func prepareEnabledTest(t *testing.T, ctx context.Context) *objectlock.Manager {
+	t.Helper()
+	m := prepareTest(t, ctx)
+	require.NoError(t, m.SetConfiguration(ctx, repository, &graveler.ObjectLockConfiguration{Enabled: true}, nil))
+	return m
+}
+
+func prepareTest(t *testing.T, ctx context.Context) *objectlock.Manager {
+	ctrl := gomock.NewController(t)
+	refManager := mock.NewMockRefManager(ctrl)
+	refManager.EXPECT().GetRepository(ctx, gomock.Any()).AnyTimes().Return(repository, nil)
+	kvStore := kvtest.GetStore(ctx, t)
+	m := settings.NewManager(refManager, kvStore)
+	return objectlock.NewManager(m, kvStore)
+}
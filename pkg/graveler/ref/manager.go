@@ -211,18 +211,28 @@ func (m *Manager) CreateRepository(ctx context.Context, repositoryID graveler.Re
 		RepositoryID: repositoryID,
 		Repository:   &repository,
 	}
-	// If branch creation fails - this commit will become dangling. This is a known issue that can be resolved via garbage collection
-	commitID, err := m.addCommit(ctx, graveler.RepoPartition(repo), firstCommit)
+	// The first commit and the default branch are created in a single transaction where the kv store supports
+	// one.  Otherwise, if branch creation fails - the commit will become dangling. This is a known issue that can be
+	// resolved via garbage collection
+	commitID := graveler.CommitID(m.addressProvider.ContentAddress(firstCommit))
+	// commits are written based on their content hash, so an existing commit is the same commit
+	commitOp, err := kv.TxnSetMsg([]byte(graveler.CommitPath(commitID)), graveler.ProtoFromCommit(commitID, &firstCommit))
 	if err != nil {
 		return nil, err
 	}
-
 	branch := graveler.Branch{
 		CommitID:     commitID,
 		StagingToken: graveler.GenerateStagingToken(repositoryID, repository.DefaultBranchID),
 		SealedTokens: nil,
 	}
-	err = m.createBranch(ctx, graveler.RepoPartition(repo), repository.DefaultBranchID, branch)
+	branchOp, err := kv.TxnSetMsgIf([]byte(graveler.BranchPath(repository.DefaultBranchID)), protoFromBranch(repository.DefaultBranchID, &branch), nil)
+	if err != nil {
+		return nil, err
+	}
+	err = kv.Transact(ctx, m.kvStore, []byte(graveler.RepoPartition(repo)), []kv.TxnOp{commitOp, branchOp})
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		err = graveler.ErrBranchExists
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -446,6 +456,14 @@ func (m *Manager) SetBranch(ctx context.Context, repository *graveler.Repository
 }
 
 func (m *Manager) BranchUpdate(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, f graveler.BranchUpdateFunc) error {
+	_, err := m.CommitBranchUpdate(ctx, repository, branchID, func(branch *graveler.Branch) (*graveler.Branch, *graveler.Commit, error) {
+		newBranch, err := f(branch)
+		return newBranch, nil, err
+	})
+	return err
+}
+
+func (m *Manager) CommitBranchUpdate(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, f graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
 	// TODO(ariels): Get request ID in a nicer way.
 	requestIDPtr := httputil.RequestIDFromContext(ctx)
 	// Grab ownership if configured.  Also check we actually have a
@@ -464,18 +482,40 @@ func (m *Manager) BranchUpdate(ctx context.Context, repository *graveler.Reposit
 	}
 	b, pred, err := m.getBranchWithPredicate(ctx, repository, branchID)
 	if err != nil {
-		return err
+		return "", err
 	}
 
 	// clone the branch information to avoid mutating the shared result returned by batch executor
 	b = b.Clone()
 
-	newBranch, err := f(b)
+	newBranch, commit, err := f(b)
 	// return on error or nothing to update
 	if err != nil || newBranch == nil {
-		return err
+		return "", err
+	}
+	branchKey := []byte(graveler.BranchPath(branchID))
+	if commit == nil {
+		return "", kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repository), branchKey, protoFromBranch(branchID, newBranch), pred)
+	}
+
+	// The commit and the branch that references it are written in a single transaction where the kv store supports
+	// one.  Otherwise the commit is written first, and a failed branch update leaves it dangling.
+	commitID := graveler.CommitID(m.addressProvider.ContentAddress(*commit))
+	// commits are written based on their content hash, so an existing commit is the same commit
+	commitOp, err := kv.TxnSetMsg([]byte(graveler.CommitPath(commitID)), graveler.ProtoFromCommit(commitID, commit))
+	if err != nil {
+		return "", err
+	}
+	newBranch.CommitID = commitID
+	branchOp, err := kv.TxnSetMsgIf(branchKey, protoFromBranch(branchID, newBranch), pred)
+	if err != nil {
+		return "", err
+	}
+	err = kv.Transact(ctx, m.kvStore, []byte(graveler.RepoPartition(repository)), []kv.TxnOp{commitOp, branchOp})
+	if err != nil {
+		return "", err
 	}
-	return kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repository), []byte(graveler.BranchPath(branchID)), protoFromBranch(branchID, newBranch), pred)
+	return commitID, nil
 }
 
 func (m *Manager) DeleteBranch(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID) error {
@@ -628,6 +668,8 @@ func (m *Manager) addCommit(ctx context.Context, repoPartition string, commit gr
 	return graveler.CommitID(commitID), nil
 }
 
+// AddCommit writes commit, addressed by its content, without updating any branch.  CommitBranchUpdate writes a new
+// commit together with the branch that references it.
 func (m *Manager) AddCommit(ctx context.Context, repository *graveler.RepositoryRecord, commit graveler.Commit) (graveler.CommitID, error) {
 	return m.addCommit(ctx, graveler.RepoPartition(repository), commit)
 }
@@ -805,11 +847,39 @@ func (m *Manager) DeletePullRequest(ctx context.Context, repository *graveler.Re
 		return fmt.Errorf("delete secondary index by src-dest (key %s): %w", secondaryKey, err)
 	}
 
+	// Delete comments before the primary key, so a failed delete can be retried
+	if err = m.deletePullRequestComments(ctx, repository, pullRequestID); err != nil {
+		return err
+	}
+
 	// Delete primary key
 	pullKey := PullRequestPath(pullRequestID)
 	return m.kvStore.Delete(ctx, []byte(graveler.RepoPartition(repository)), []byte(pullKey))
 }
 
+func (m *Manager) deletePullRequestComments(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID) error {
+	repoPartition := []byte(graveler.RepoPartition(repository))
+	it, err := kv.ScanPrefix(ctx, m.kvStore, repoPartition, []byte(PullRequestCommentPath(pullRequestID, "")), nil)
+	if err != nil {
+		return err
+	}
+	var keys [][]byte
+	for it.Next() {
+		keys = append(keys, it.Entry().Key)
+	}
+	err = it.Err()
+	it.Close()
+	if err != nil {
+		return err
+	}
+	for _, key := range keys {
+		if err := m.kvStore.Delete(ctx, repoPartition, key); err != nil {
+			return fmt.Errorf("delete pull request comment (key %s): %w", key, err)
+		}
+	}
+	return nil
+}
+
 func (m *Manager) UpdatePullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, f graveler.PullUpdateFunc) error {
 	b, pred, err := m.getPullWithPredicate(ctx, repository, pullRequestID)
 	if err != nil {
@@ -822,3 +892,53 @@ func (m *Manager) UpdatePullRequest(ctx context.Context, repository *graveler.Re
 	}
 	return kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repository), []byte(PullRequestPath(pullRequestID)), graveler.ProtoFromPullRequest(pullRequestID, newPull), pred)
 }
+
+// pullCommentsPrefix keeps comments apart from the pull requests they belong to, so that listing
+// pull requests does not iterate over their comments
+const pullCommentsPrefix = "pull_comments"
+
+func PullRequestCommentPath(pullID graveler.PullRequestID, commentID graveler.PullRequestCommentID) string {
+	return kv.FormatPath(pullCommentsPrefix, pullID.String(), commentID.String())
+}
+
+func (m *Manager) getPullCommentWithPredicate(ctx context.Context, repository *graveler.RepositoryRecord, pullID graveler.PullRequestID, commentID graveler.PullRequestCommentID) (*graveler.PullRequestComment, kv.Predicate, error) {
+	data := graveler.PullRequestCommentData{}
+	pred, err := kv.GetMsg(ctx, m.kvStore, graveler.RepoPartition(repository), []byte(PullRequestCommentPath(pullID, commentID)), &data)
+	if errors.Is(err, kv.ErrNotFound) {
+		err = graveler.ErrPullRequestCommentNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return graveler.PullRequestCommentFromProto(&data), pred, nil
+}
+
+func (m *Manager) GetPullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullID graveler.PullRequestID, commentID graveler.PullRequestCommentID) (*graveler.PullRequestComment, error) {
+	comment, _, err := m.getPullCommentWithPredicate(ctx, repository, pullID, commentID)
+	return comment, err
+}
+
+func (m *Manager) ListPullRequestComments(ctx context.Context, repository *graveler.RepositoryRecord, pullID graveler.PullRequestID) (graveler.PullCommentsIterator, error) {
+	return NewPullCommentsIterator(ctx, m.kvStore, repository, pullID)
+}
+
+func (m *Manager) CreatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullID graveler.PullRequestID, comment *graveler.PullRequestComment) error {
+	err := kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repository), []byte(PullRequestCommentPath(pullID, comment.ID)), graveler.ProtoFromPullRequestComment(pullID, comment), nil)
+	if errors.Is(err, kv.ErrPredicateFailed) {
+		err = graveler.ErrNotUnique
+	}
+	return err
+}
+
+func (m *Manager) UpdatePullRequestComment(ctx context.Context, repository *graveler.RepositoryRecord, pullID graveler.PullRequestID, commentID graveler.PullRequestCommentID, f graveler.PullCommentUpdateFunc) error {
+	comment, pred, err := m.getPullCommentWithPredicate(ctx, repository, pullID, commentID)
+	if err != nil {
+		return err
+	}
+	newComment, err := f(comment)
+	// return on error or nothing to update
+	if err != nil || newComment == nil {
+		return err
+	}
+	return kv.SetMsgIf(ctx, m.kvStore, graveler.RepoPartition(repository), []byte(PullRequestCommentPath(pullID, commentID)), graveler.ProtoFromPullRequestComment(pullID, newComment), pred)
+}
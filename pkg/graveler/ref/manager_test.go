@@ -214,6 +214,35 @@ func TestManager_GetRepository(t *testing.T) {
 	})
 }
 
+func TestManager_CreateRepositoryBranchExists(t *testing.T) {
+	ctx := context.Background()
+	r, kvStore := testRefManager(t)
+	repository := graveler.Repository{
+		StorageID:        "sid",
+		StorageNamespace: "s3://foo",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+		InstanceUID:      "instance-uid",
+	}
+	repoRecord := &graveler.RepositoryRecord{RepositoryID: "example-repo", Repository: &repository}
+	err := r.CreateBranch(ctx, repoRecord, repository.DefaultBranchID, graveler.Branch{CommitID: "c1", StagingToken: "s1"})
+	testutil.Must(t, err)
+
+	_, err = r.CreateRepository(ctx, repoRecord.RepositoryID, repository)
+	if !errors.Is(err, graveler.ErrBranchExists) {
+		t.Fatalf("CreateRepository err=%v, expected %s", err, graveler.ErrBranchExists)
+	}
+	// the first commit is created in the same transaction as the branch
+	it, err := kv.NewPrimaryIterator(ctx, kvStore, (&graveler.CommitData{}).ProtoReflect().Type(),
+		graveler.RepoPartition(repoRecord), []byte(graveler.CommitPath("")), kv.IteratorOptionsFrom([]byte("")))
+	testutil.Must(t, err)
+	defer it.Close()
+	if it.Next() {
+		t.Fatalf("found commit %s after failed CreateRepository", it.Entry().Key)
+	}
+	testutil.Must(t, it.Err())
+}
+
 func TestManager_ListRepositories(t *testing.T) {
 	r, _ := testRefManager(t)
 	repoIDs := []graveler.RepositoryID{"a", "aa", "b", "c", "e", "d"}
@@ -551,6 +580,59 @@ func TestManager_BranchUpdate(t *testing.T) {
 	}
 }
 
+func TestManager_CommitBranchUpdate(t *testing.T) {
+	ctx := context.Background()
+	r, _ := testRefManager(t)
+	const branchID = "branch1"
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageID:        "sid",
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+	})
+	testutil.Must(t, err)
+	testutil.Must(t, r.SetBranch(ctx, repository, branchID, graveler.Branch{CommitID: "c1", StagingToken: "s1"}))
+
+	t.Run("success", func(t *testing.T) {
+		commitID, err := r.CommitBranchUpdate(ctx, repository, branchID, func(branch *graveler.Branch) (*graveler.Branch, *graveler.Commit, error) {
+			commit := graveler.NewCommit()
+			commit.Message = "success"
+			commit.Parents = graveler.CommitParents{branch.CommitID}
+			branch.StagingToken = "s2"
+			return branch, &commit, nil
+		})
+		require.NoError(t, err)
+
+		b, err := r.GetBranch(ctx, repository, branchID)
+		require.NoError(t, err)
+		require.Equal(t, commitID, b.CommitID)
+		require.Equal(t, graveler.StagingToken("s2"), b.StagingToken)
+		commit, err := r.GetCommit(ctx, repository, commitID)
+		require.NoError(t, err)
+		require.Equal(t, "success", commit.Message)
+	})
+
+	t.Run("branch changed during update", func(t *testing.T) {
+		var commitID graveler.CommitID
+		_, err := r.CommitBranchUpdate(ctx, repository, branchID, func(branch *graveler.Branch) (*graveler.Branch, *graveler.Commit, error) {
+			commit := graveler.NewCommit()
+			commit.Message = "branch changed"
+			commit.Parents = graveler.CommitParents{branch.CommitID}
+			commitID = graveler.CommitID(ident.NewHexAddressProvider().ContentAddress(commit))
+			testutil.Must(t, r.SetBranch(ctx, repository, branchID, graveler.Branch{CommitID: "c3", StagingToken: "s3"}))
+			return branch, &commit, nil
+		})
+		require.ErrorIs(t, err, kv.ErrPredicateFailed)
+
+		b, err := r.GetBranch(ctx, repository, branchID)
+		require.NoError(t, err)
+		require.Equal(t, graveler.CommitID("c3"), b.CommitID)
+		// the commit is written in the same transaction as the branch
+		_, err = r.GetCommit(ctx, repository, commitID)
+		require.ErrorIs(t, err, graveler.ErrCommitNotFound)
+	})
+}
+
 func TestManager_BranchUpdateRaceCondition(t *testing.T) {
 	ctx := context.Background()
 	kvStore := kvtest.GetStore(ctx, t)
@@ -1456,6 +1538,68 @@ func TestManager_GetPullRequest(t *testing.T) {
 	})
 }
 
+func TestManager_PullRequestComments(t *testing.T) {
+	r, _ := testRefManager(t)
+	repository, err := r.CreateRepository(context.Background(), "repo1", graveler.Repository{
+		StorageID:        "sid",
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+	})
+	testutil.Must(t, err)
+	ctx := context.Background()
+	pull := graveler.PullRequest{
+		CreationDate: time.Now().UTC(),
+		Title:        "some title",
+		Source:       "dev",
+		Destination:  "main",
+	}
+	require.NoError(t, r.CreatePullRequest(ctx, repository, "pull1", &pull))
+
+	comments := []*graveler.PullRequestComment{
+		{ID: "c1", Author: "a", Body: "first", Path: "path/to/object", CreationDate: time.Now().UTC()},
+		{ID: "c2", Author: "b", Body: "second", Path: "path/to/object", ReplyTo: "c1", CreationDate: time.Now().UTC()},
+	}
+	for _, c := range comments {
+		require.NoError(t, r.CreatePullRequestComment(ctx, repository, "pull1", c))
+	}
+	require.ErrorIs(t, r.CreatePullRequestComment(ctx, repository, "pull1", comments[0]), graveler.ErrNotUnique)
+
+	// comments are not listed as pull requests
+	pulls, err := r.ListPullRequests(ctx, repository)
+	require.NoError(t, err)
+	var pullIDs []graveler.PullRequestID
+	for pulls.Next() {
+		pullIDs = append(pullIDs, pulls.Value().ID)
+	}
+	require.NoError(t, pulls.Err())
+	pulls.Close()
+	require.Equal(t, []graveler.PullRequestID{"pull1"}, pullIDs)
+
+	it, err := r.ListPullRequestComments(ctx, repository, "pull1")
+	require.NoError(t, err)
+	var listed []*graveler.PullRequestComment
+	for it.Next() {
+		listed = append(listed, it.Value())
+	}
+	require.NoError(t, it.Err())
+	it.Close()
+	require.Equal(t, comments, listed)
+
+	require.NoError(t, r.UpdatePullRequestComment(ctx, repository, "pull1", "c1", func(comment *graveler.PullRequestComment) (*graveler.PullRequestComment, error) {
+		comment.Resolved = true
+		comment.ResolvedBy = "b"
+		return comment, nil
+	}))
+	comment, err := r.GetPullRequestComment(ctx, repository, "pull1", "c1")
+	require.NoError(t, err)
+	require.True(t, comment.Resolved)
+	require.Equal(t, "b", comment.ResolvedBy)
+
+	_, err = r.GetPullRequestComment(ctx, repository, "pull2", "c1")
+	require.ErrorIs(t, err, graveler.ErrPullRequestCommentNotFound)
+}
+
 func TestManager_DeletePullRequest(t *testing.T) {
 	r, store := testRefManager(t)
 	repository, err := r.CreateRepository(context.Background(), "repo1", graveler.Repository{
@@ -1482,6 +1626,14 @@ func TestManager_DeletePullRequest(t *testing.T) {
 			},
 		}
 		require.NoError(t, r.CreatePullRequest(ctx, repository, rec.ID, &rec.PullRequest))
+		for _, commentID := range []graveler.PullRequestCommentID{"comment1", "comment2"} {
+			require.NoError(t, r.CreatePullRequestComment(ctx, repository, rec.ID, &graveler.PullRequestComment{
+				ID:           commentID,
+				Author:       "some author",
+				Body:         "some comment",
+				CreationDate: time.Now().UTC(),
+			}))
+		}
 
 		// Delete Pull request
 		err := r.DeletePullRequest(ctx, repository, rec.ID)
@@ -1490,6 +1642,15 @@ func TestManager_DeletePullRequest(t *testing.T) {
 		// Verify secondary index deleted
 		_, err = store.Get(ctx, []byte(ref.PullsPartitionKey), []byte(ref.PullBySrcDstPath(repository, rec.Source, rec.Destination)))
 		require.ErrorIs(t, err, kv.ErrNotFound)
+
+		// Verify comments deleted
+		_, err = store.Get(ctx, []byte(graveler.RepoPartition(repository)), []byte(ref.PullRequestCommentPath(rec.ID, "comment1")))
+		require.ErrorIs(t, err, kv.ErrNotFound)
+		it, err := r.ListPullRequestComments(ctx, repository, rec.ID)
+		require.NoError(t, err)
+		defer it.Close()
+		require.False(t, it.Next())
+		require.NoError(t, it.Err())
 	})
 
 	t.Run("delete_pull_request_doesnt_exists", func(t *testing.T) {
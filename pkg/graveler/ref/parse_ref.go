@@ -4,12 +4,31 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/treeverse/lakefs/pkg/graveler"
 )
 
 var modifiersRegexp = regexp.MustCompile("(^|[~^@$])[^^~@$]*")
 
+// refTimeLayouts are the supported layouts of the time in a ref@{time} modifier
+var refTimeLayouts = []string{time.RFC3339Nano, time.DateOnly}
+
+// parseRefTimeModifier parses a ref@{time} modifier, buf is the part in braces
+func parseRefTimeModifier(buf string) (graveler.RefModifier, error) {
+	for _, layout := range refTimeLayouts {
+		t, err := time.Parse(layout, buf)
+		if err == nil {
+			return graveler.RefModifier{
+				Type: graveler.RefModTypeTime,
+				Time: t,
+			}, nil
+		}
+	}
+	return graveler.RefModifier{}, fmt.Errorf("could not parse time modifier %s: %w", buf, graveler.ErrInvalidRef)
+}
+
 func parseRefModifier(buf string) (graveler.RefModifier, error) {
 	amount := 1
 	var err error
@@ -25,6 +44,9 @@ func parseRefModifier(buf string) (graveler.RefModifier, error) {
 			return graveler.RefModifier{}, graveler.ErrInvalidRef
 		}
 	case '@':
+		if strings.HasPrefix(buf, "@{") && strings.HasSuffix(buf, "}") {
+			return parseRefTimeModifier(buf[2 : len(buf)-1])
+		}
 		typ = graveler.RefModTypeAt
 		if len(buf) > 1 {
 			return graveler.RefModifier{}, graveler.ErrInvalidRef
@@ -3,6 +3,7 @@ package ref_test
 import (
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/ref"
@@ -67,6 +68,41 @@ func TestParseRef(t *testing.T) {
 			Input:       "main@1",
 			ExpectedErr: graveler.ErrInvalidRef,
 		},
+		{
+			Name:  "branch_at_time",
+			Input: "main@{2024-05-01T00:00:00Z}",
+			Expected: graveler.RawRef{
+				BaseRef: "main",
+				Modifiers: []graveler.RefModifier{
+					{
+						Type: graveler.RefModTypeTime,
+						Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					},
+				},
+			},
+		},
+		{
+			Name:  "branch_at_date_with_tilde",
+			Input: "main@{2024-05-01}~2",
+			Expected: graveler.RawRef{
+				BaseRef: "main",
+				Modifiers: []graveler.RefModifier{
+					{
+						Type: graveler.RefModTypeTime,
+						Time: time.Date(2024, 5, 1, 0, 0, 0, 0, time.UTC),
+					},
+					{
+						Type:  graveler.RefModTypeTilde,
+						Value: 2,
+					},
+				},
+			},
+		},
+		{
+			Name:        "branch_invalid_at_time",
+			Input:       "main@{yesterday}",
+			ExpectedErr: graveler.ErrInvalidRef,
+		},
 		{
 			Name:  "branch_two_caret",
 			Input: "main^^",
@@ -164,6 +200,10 @@ func TestParseRef(t *testing.T) {
 					t.Fatalf("unexpected modifier at index %d: expected value %d got %d",
 						i, cas.Expected.Modifiers[i].Value, m.Value)
 				}
+				if !m.Time.Equal(cas.Expected.Modifiers[i].Time) {
+					t.Fatalf("unexpected modifier at index %d: expected time %s got %s",
+						i, cas.Expected.Modifiers[i].Time, m.Time)
+				}
 			}
 		})
 	}
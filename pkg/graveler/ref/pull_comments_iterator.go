@@ -0,0 +1,98 @@
+package ref
+
+import (
+	"context"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/kv"
+)
+
+type PullCommentsIterator struct {
+	ctx           context.Context
+	it            kv.MessageIterator
+	err           error
+	value         *graveler.PullRequestComment
+	repoPartition string
+	pullID        graveler.PullRequestID
+	store         kv.Store
+	closed        bool
+}
+
+func NewPullCommentsIterator(ctx context.Context, store kv.Store, repo *graveler.RepositoryRecord, pullID graveler.PullRequestID) (*PullCommentsIterator, error) {
+	repoPartition := graveler.RepoPartition(repo)
+	it, err := kv.NewPrimaryIterator(ctx, store, (&graveler.PullRequestCommentData{}).ProtoReflect().Type(),
+		repoPartition,
+		[]byte(PullRequestCommentPath(pullID, "")), kv.IteratorOptionsFrom([]byte("")))
+	if err != nil {
+		return nil, err
+	}
+	return &PullCommentsIterator{
+		ctx:           ctx,
+		it:            it,
+		store:         store,
+		repoPartition: repoPartition,
+		pullID:        pullID,
+		closed:        false,
+	}, nil
+}
+
+func (i *PullCommentsIterator) Next() bool {
+	if i.Err() != nil || i.closed {
+		return false
+	}
+	if !i.it.Next() {
+		i.value = nil
+		return false
+	}
+	e := i.it.Entry()
+	if e == nil {
+		i.err = graveler.ErrReadingFromStore
+		return false
+	}
+	comment, ok := e.Value.(*graveler.PullRequestCommentData)
+	if !ok {
+		i.err = graveler.ErrReadingFromStore
+		return false
+	}
+	i.value = graveler.PullRequestCommentFromProto(comment)
+	return true
+}
+
+func (i *PullCommentsIterator) SeekGE(id graveler.PullRequestCommentID) {
+	if i.Err() != nil {
+		return
+	}
+	i.Close()
+	it, err := kv.NewPrimaryIterator(i.ctx, i.store, (&graveler.PullRequestCommentData{}).ProtoReflect().Type(),
+		i.repoPartition,
+		[]byte(PullRequestCommentPath(i.pullID, "")), kv.IteratorOptionsFrom([]byte(PullRequestCommentPath(i.pullID, id))))
+	i.it = it
+	i.err = err
+	i.value = nil
+	i.closed = err != nil
+}
+
+func (i *PullCommentsIterator) Value() *graveler.PullRequestComment {
+	if i.Err() != nil {
+		return nil
+	}
+	return i.value
+}
+
+func (i *PullCommentsIterator) Err() error {
+	if i.err != nil {
+		return i.err
+	}
+	if !i.closed {
+		return i.it.Err()
+	}
+	return nil
+}
+
+func (i *PullCommentsIterator) Close() {
+	if i.closed {
+		return
+	}
+	i.it.Close()
+	i.closed = true
+}
@@ -3,7 +3,9 @@ package ref
 import (
 	"context"
 	"errors"
+	"fmt"
 	"regexp"
+	"time"
 
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/ident"
@@ -101,6 +103,13 @@ func ResolveRawRef(ctx context.Context, store Store, addressProvider ident.Addre
 				}
 				baseCommit = commit.Parents[0]
 			}
+		case graveler.RefModTypeTime:
+			commitID, err := commitAt(ctx, store, repository, baseCommit, mod.Time)
+			if err != nil {
+				return nil, err
+			}
+			baseCommit = commitID
+
 		case graveler.RefModTypeCaret:
 			if mod.Value == 0 {
 				// ^0 = the commit itself
@@ -215,3 +224,37 @@ func revResolveTag(ctx context.Context, store Store, _ ident.AddressProvider, re
 		},
 	}, nil
 }
+
+// commitAt returns the first commit made at or before t on the first-parent history of commitID.  It approximates
+// the commit the branch pointed to at t by the creation dates of commits: branch resets are not recorded, and the
+// dates may be set by clients.  Commits dated after their first-parent child make the approximation wrong, so
+// reading one of them, up to the parent of the returned commit, fails with ErrCommitDatesOutOfOrder.
+func commitAt(ctx context.Context, store Store, repository *graveler.RepositoryRecord, commitID graveler.CommitID, t time.Time) (graveler.CommitID, error) {
+	var (
+		child *graveler.Commit
+		found graveler.CommitID
+	)
+	for {
+		commit, err := store.GetCommit(ctx, repository, commitID)
+		if err != nil {
+			return "", err
+		}
+		if child != nil && commit.CreationDate.After(child.CreationDate) {
+			return "", fmt.Errorf("commit %s dated after its child: %w", commitID, graveler.ErrCommitDatesOutOfOrder)
+		}
+		if found != "" {
+			return found, nil
+		}
+		if !commit.CreationDate.After(t) {
+			found = commitID
+		}
+		if len(commit.Parents) == 0 {
+			if found == "" {
+				return "", graveler.ErrNotFound
+			}
+			return found, nil
+		}
+		child = commit
+		commitID = commit.Parents[0]
+	}
+}
@@ -237,6 +237,31 @@ func TestResolveRawRef(t *testing.T) {
 			Ref:              graveler.Ref(commitCommitID[:5] + "~2"),
 			ExpectedCommitID: commitLog[13],
 		},
+		{
+			Name:             "branch_at_time",
+			Ref:              graveler.Ref("branch1@{2020-12-01T15:10:00Z}"),
+			ExpectedCommitID: commitLog[9],
+		},
+		{
+			Name:             "branch_at_time_between_commits",
+			Ref:              graveler.Ref("branch1@{2020-12-01T17:10:30+02:00}"),
+			ExpectedCommitID: commitLog[9],
+		},
+		{
+			Name:             "branch_at_time_after_head",
+			Ref:              graveler.Ref("branch1@{2021-01-01}"),
+			ExpectedCommitID: branch1CommitID,
+		},
+		{
+			Name:             "tag_at_time_with_modifier",
+			Ref:              graveler.Ref("v1.0@{2020-12-01T15:05:00Z}~1"),
+			ExpectedCommitID: commitLog[15],
+		},
+		{
+			Name:        "branch_at_time_before_history",
+			Ref:         graveler.Ref("branch1@{2020-12-01}"),
+			ExpectedErr: graveler.ErrNotFound,
+		},
 		{
 			Name:        "commit_prefix_with_modifier_too_big",
 			Ref:         graveler.Ref(commitCommitID + "~200"),
@@ -276,6 +301,71 @@ func TestResolveRawRef(t *testing.T) {
 	}
 }
 
+func TestResolveRawRef_TimeDatesOutOfOrder(t *testing.T) {
+	r, _ := testRefManager(t)
+	ctx := context.Background()
+	repository, err := r.CreateRepository(ctx, "repo1", graveler.Repository{
+		StorageID:        "sid",
+		StorageNamespace: "s3://",
+		CreationDate:     time.Now(),
+		DefaultBranchID:  "main",
+	})
+	testutil.Must(t, err)
+
+	addCommit := func(date string, parents ...graveler.CommitID) graveler.CommitID {
+		ts, err := time.Parse(time.RFC3339, date)
+		testutil.MustDo(t, "parse date", err)
+		cid, err := r.AddCommit(ctx, repository, graveler.Commit{
+			Message:      date,
+			Committer:    "tester",
+			MetaRangeID:  "deadbeef1",
+			CreationDate: ts,
+			Parents:      parents,
+		})
+		testutil.MustDo(t, "add commit", err)
+		return cid
+	}
+	c1 := addCommit("2020-12-01T15:00:00Z")
+	// c2 is dated after its child c3
+	c2 := addCommit("2020-12-01T15:10:00Z", c1)
+	c3 := addCommit("2020-12-01T15:05:00Z", c2)
+	c4 := addCommit("2020-12-01T15:20:00Z", c3)
+
+	resolve := func(ref graveler.Ref) (graveler.CommitID, error) {
+		rawRef, err := r.ParseRef(ref)
+		testutil.MustDo(t, "parse ref", err)
+		resolved, err := r.ResolveRawRef(ctx, repository, rawRef)
+		if err != nil {
+			return "", err
+		}
+		return resolved.CommitID, nil
+	}
+	for _, tc := range []struct {
+		ref      graveler.Ref
+		expected graveler.CommitID
+	}{
+		{ref: graveler.Ref(c4) + "@{2020-12-01T15:30:00Z}", expected: c4},
+		{ref: graveler.Ref(c2) + "@{2020-12-01T15:02:00Z}", expected: c1},
+	} {
+		commitID, err := resolve(tc.ref)
+		testutil.MustDo(t, string(tc.ref), err)
+		if commitID != tc.expected {
+			t.Errorf("resolve %s = %s, expected %s", tc.ref, commitID, tc.expected)
+		}
+	}
+	// reading c2 after c3 shows the dates are out of order, whether c3 or an older commit is returned
+	for _, ref := range []graveler.Ref{
+		graveler.Ref(c4) + "@{2020-12-01T15:07:00Z}",
+		graveler.Ref(c4) + "@{2020-12-01T15:02:00Z}",
+		graveler.Ref(c3) + "@{2020-12-01T15:30:00Z}",
+	} {
+		_, err = resolve(ref)
+		if !errors.Is(err, graveler.ErrCommitDatesOutOfOrder) {
+			t.Errorf("resolve %s: err=%v, expected %v", ref, err, graveler.ErrCommitDatesOutOfOrder)
+		}
+	}
+}
+
 func TestResolveRef_SameDate(t *testing.T) {
 	r, _ := testRefManager(t)
 	ctx := context.Background()
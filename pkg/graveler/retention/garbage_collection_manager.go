@@ -31,6 +31,7 @@ const (
 type GarbageCollectionManager struct {
 	blockAdapter                block.Adapter
 	refManager                  graveler.RefManager
+	objectLockManager           graveler.ObjectLockManager
 	committedBlockStoragePrefix string
 }
 
@@ -107,10 +108,16 @@ func (r *repositoryCommitGetter) Get(ctx context.Context, id graveler.CommitID)
 	return r.refManager.GetCommit(ctx, r.repository, id)
 }
 
-func NewGarbageCollectionManager(blockAdapter block.Adapter, refManager graveler.RefManager, committedBlockStoragePrefix string) *GarbageCollectionManager {
+// NewGarbageCollectionManager returns a GarbageCollectionManager.  Commits holding objects locked by
+// objectLockManager are never collected; a nil objectLockManager locks no objects.
+func NewGarbageCollectionManager(blockAdapter block.Adapter, refManager graveler.RefManager, objectLockManager graveler.ObjectLockManager, committedBlockStoragePrefix string) *GarbageCollectionManager {
+	if objectLockManager == nil {
+		objectLockManager = graveler.ObjectLockNoOp{}
+	}
 	return &GarbageCollectionManager{
 		blockAdapter:                blockAdapter,
 		refManager:                  refManager,
+		objectLockManager:           objectLockManager,
 		committedBlockStoragePrefix: committedBlockStoragePrefix,
 	}
 }
@@ -184,6 +191,21 @@ func (m *GarbageCollectionManager) SaveGarbageCollectionCommits(ctx context.Cont
 	if err != nil {
 		return "", fmt.Errorf("find expired commits: %w", err)
 	}
+	// keep the commits holding locked objects, so their physical addresses are not collected
+	lockedCommits, err := m.objectLockManager.LockedCommits(ctx, repository)
+	if err != nil {
+		return "", fmt.Errorf("find locked commits: %w", err)
+	}
+	for _, commitID := range lockedCommits {
+		if _, ok := gcCommits[commitID]; ok {
+			continue
+		}
+		commit, err := commitGetter.Get(ctx, commitID)
+		if err != nil {
+			return "", fmt.Errorf("get locked commit %s: %w", commitID, err)
+		}
+		gcCommits[commitID] = commit.MetaRangeID
+	}
 	b := &strings.Builder{}
 	csvWriter := csv.NewWriter(b)
 	// (TODO) - remove expired column from the CSV file and from the GC logic
@@ -42,7 +42,7 @@ func TestGarbageCollectionManager_GetUncommittedLocation(t *testing.T) {
 
 	for _, tc := range cases {
 		t.Run(tc.Name, func(t *testing.T) {
-			gc := retention.NewGarbageCollectionManager(blockAdapter, refMgr, prefix)
+			gc := retention.NewGarbageCollectionManager(blockAdapter, refMgr, nil, prefix)
 			location, err := gc.GetUncommittedLocation(runID, tc.StorageID, ns)
 			require.NoError(t, err)
 			require.Equal(t, path, location)
@@ -83,7 +83,7 @@ func TestGarbageCollectionManager_SaveGarbageCollectionUncommitted(t *testing.T)
 					InstanceUID:      uuid.New().String(),
 				},
 			}
-			gc := retention.NewGarbageCollectionManager(blockAdapter, refMgr, prefix)
+			gc := retention.NewGarbageCollectionManager(blockAdapter, refMgr, nil, prefix)
 			location, err := gc.GetUncommittedLocation(runID, tc.StorageID, ns)
 			require.NoError(t, err)
 			filename := "uncommitted_test_file"
@@ -1,10 +1,13 @@
 package settings
 
 import (
+	"bytes"
 	"context"
 	"crypto/sha256"
 	"encoding/hex"
 	"errors"
+	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/go-openapi/swag"
@@ -19,6 +22,8 @@ import (
 const (
 	cacheSize          = 100_000
 	defaultCacheExpiry = 3 * time.Second
+
+	defaultWatchIdleTimeout = 10 * time.Minute
 )
 
 type cacheKey struct {
@@ -29,10 +34,34 @@ type cacheKey struct {
 // Manager is a key-value store for Graveler repository-level settings.
 // Each setting is stored under a key, and can be any proto.Message.
 // Fetched settings are cached using cache.Cache with a default expiry time of 1 second. Hence, the store is eventually consistent.
+// After WatchChanges, settings are removed from the cache as soon as the store reports they changed.
 type Manager struct {
 	store      kv.Store
 	refManager graveler.RefManager
 	cache      cache.Cache
+
+	watching         atomic.Bool
+	watchMu          sync.Mutex
+	watchCtx         context.Context
+	watchIdleTimeout time.Duration
+	lastPruned       time.Time
+	// watched maps the partitions of repositories whose settings are watched to their *repositoryWatch
+	watched sync.Map
+}
+
+// repositoryWatch is the watch of the settings of a repository
+type repositoryWatch struct {
+	repositoryID graveler.RepositoryID
+	cancel       context.CancelFunc
+	// lastUsed is when settings were last read, in Unix nanoseconds
+	lastUsed atomic.Int64
+	// keys holds the keys of settings read, which may be cached
+	keys sync.Map
+}
+
+func (w *repositoryWatch) use(key string, now time.Time) {
+	w.lastUsed.Store(now.UnixNano())
+	w.keys.Store(key, struct{}{})
 }
 
 type ManagerOption func(m *Manager)
@@ -47,11 +76,20 @@ func (m *Manager) WithCache(cache cache.Cache) {
 	m.cache = cache
 }
 
+// WithWatchIdleTimeout sets how long the settings of a repository are watched after they were last read
+func WithWatchIdleTimeout(timeout time.Duration) ManagerOption {
+	return func(m *Manager) {
+		m.watchIdleTimeout = timeout
+	}
+}
+
 func NewManager(refManager graveler.RefManager, store kv.Store, options ...ManagerOption) *Manager {
 	m := &Manager{
 		refManager: refManager,
 		store:      store,
 		cache:      cache.NewCache(cacheSize, defaultCacheExpiry, cache.NewJitterFn(defaultCacheExpiry)),
+
+		watchIdleTimeout: defaultWatchIdleTimeout,
 	}
 	for _, o := range options {
 		o(m)
@@ -59,6 +97,89 @@ func NewManager(refManager graveler.RefManager, store kv.Store, options ...Manag
 	return m
 }
 
+// WatchChanges removes settings of repositories from the cache when they change, until ctx is done.
+// It does nothing if the store cannot watch: cached settings then expire as usual.  Repositories
+// whose settings were not read for the watch idle timeout stop being watched.
+func (m *Manager) WatchChanges(ctx context.Context) {
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+	m.watchCtx = ctx
+	m.watching.Store(true)
+}
+
+// watchRepository watches the settings of repository, if changes are watched and it is not watched
+// yet, and records that key was read
+func (m *Manager) watchRepository(ctx context.Context, repository *graveler.RepositoryRecord, key string) {
+	if !m.watching.Load() {
+		return
+	}
+	now := time.Now()
+	partition := graveler.RepoPartition(repository)
+	if w, ok := m.watched.Load(partition); ok {
+		w.(*repositoryWatch).use(key, now)
+		return
+	}
+	m.watchMu.Lock()
+	defer m.watchMu.Unlock()
+	if m.watchCtx == nil || m.watchCtx.Err() != nil {
+		return
+	}
+	if w, ok := m.watched.Load(partition); ok {
+		w.(*repositoryWatch).use(key, now)
+		return
+	}
+	if now.Sub(m.lastPruned) > m.watchIdleTimeout {
+		m.pruneWatches(now)
+		m.lastPruned = now
+	}
+
+	prefix := []byte(graveler.SettingsPath(""))
+	repositoryID := repository.RepositoryID
+	watchCtx, cancel := context.WithCancel(m.watchCtx)
+	err := kv.Watch(watchCtx, m.store, []byte(partition), prefix, func(event kv.WatchEvent) {
+		if event.Key == nil {
+			m.cache.Purge()
+			return
+		}
+		m.cache.Remove(cacheKey{
+			RepositoryID: repositoryID,
+			Key:          string(bytes.TrimPrefix(event.Key, prefix)),
+		})
+	})
+	if err != nil {
+		cancel()
+		if errors.Is(err, kv.ErrWatchNotSupported) {
+			logging.FromContext(ctx).Debug("KV store does not watch changes, cached settings will expire")
+			m.watching.Store(false)
+			return
+		}
+		logging.FromContext(ctx).WithError(err).WithField("repo", repositoryID).Warn("Failed to watch repository settings")
+		return
+	}
+	w := &repositoryWatch{repositoryID: repositoryID, cancel: cancel}
+	w.use(key, now)
+	m.watched.Store(partition, w)
+}
+
+// pruneWatches stops watching repositories whose settings were not read for the watch idle
+// timeout, such as deleted repositories.  Their cached settings are removed, as they are no
+// longer removed on change.  Called with watchMu held.
+func (m *Manager) pruneWatches(now time.Time) {
+	m.watched.Range(func(partition, value any) bool {
+		w := value.(*repositoryWatch)
+		if now.Sub(time.Unix(0, w.lastUsed.Load())) <= m.watchIdleTimeout {
+			return true
+		}
+		m.watched.Delete(partition)
+		w.cancel()
+		w.keys.Range(func(key, _ any) bool {
+			m.cache.Remove(cacheKey{RepositoryID: w.repositoryID, Key: key.(string)})
+			return true
+		})
+		return true
+	})
+}
+
 // Save persists the given setting under the given repository and key. Overrides settings key in KV Store.
 // The setting is persisted only if the current version of the setting matches the given checksum.
 // If lastKnownChecksum is the empty string, the setting is persisted only if it does not exist.
@@ -143,6 +264,7 @@ func (m *Manager) Get(ctx context.Context, repository *graveler.RepositoryRecord
 		RepositoryID: repository.RepositoryID,
 		Key:          key,
 	}
+	m.watchRepository(ctx, repository, key)
 	tmp := proto.Clone(dst)
 	setting, err := m.cache.GetOrSet(k, func() (v interface{}, err error) {
 		_, err = m.GetLatest(ctx, repository, key, tmp)
@@ -2,7 +2,9 @@ package settings_test
 
 import (
 	"context"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/go-openapi/swag"
 	"github.com/go-test/deep"
@@ -12,6 +14,7 @@ import (
 	"github.com/treeverse/lakefs/pkg/graveler"
 	"github.com/treeverse/lakefs/pkg/graveler/mock"
 	"github.com/treeverse/lakefs/pkg/graveler/settings"
+	"github.com/treeverse/lakefs/pkg/kv"
 	"github.com/treeverse/lakefs/pkg/kv/kvtest"
 	"github.com/treeverse/lakefs/pkg/testutil"
 )
@@ -45,6 +48,14 @@ func (m *mockCache) GetOrSetWithExpiry(k interface{}, setFn cache.SetFnWithExpir
 	panic("Not implemented.")
 }
 
+func (m *mockCache) Remove(k interface{}) {
+	delete(m.c, k)
+}
+
+func (m *mockCache) Purge() {
+	m.c = make(map[interface{}]interface{})
+}
+
 func TestNonExistent(t *testing.T) {
 	ctx := context.Background()
 	m := prepareTest(t, ctx, nil, nil)
@@ -93,6 +104,83 @@ func TestSaveAndGet(t *testing.T) {
 	}
 }
 
+func TestWatchChanges(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kvStore := kvtest.GetStore(ctx, t)
+	m := settings.NewManager(nil, kvStore, settings.WithCache(cache.NewCache(100, time.Hour, cache.NewJitterFn(0))))
+	m.WatchChanges(ctx)
+	// other is another lakeFS instance sharing the store
+	other := settings.NewManager(nil, kvStore, settings.WithCache(cache.NoCache))
+
+	firstSettings := newSetting(5, 6, "hello")
+	testutil.Must(t, other.Save(ctx, repository, "settingKey", firstSettings, nil))
+	gotSettings := &settings.ExampleSettings{}
+	testutil.Must(t, m.Get(ctx, repository, "settingKey", gotSettings))
+	if diff := deep.Equal(firstSettings, gotSettings); diff != nil {
+		t.Fatal("got unexpected settings:", diff)
+	}
+
+	// the change removes the cached settings, so the new settings are returned
+	secondSettings := newSetting(15, 16, "hi")
+	testutil.Must(t, other.Save(ctx, repository, "settingKey", secondSettings, nil))
+	gotSettings = &settings.ExampleSettings{}
+	testutil.Must(t, m.Get(ctx, repository, "settingKey", gotSettings))
+	if diff := deep.Equal(secondSettings, gotSettings); diff != nil {
+		t.Fatal("got unexpected settings:", diff)
+	}
+}
+
+// countingWatcher counts the active watches of a store
+type countingWatcher struct {
+	kv.Store
+	active atomic.Int32
+}
+
+func (w *countingWatcher) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	if err := kv.Watch(ctx, w.Store, partitionKey, prefix, fn); err != nil {
+		return err
+	}
+	w.active.Add(1)
+	context.AfterFunc(ctx, func() { w.active.Add(-1) })
+	return nil
+}
+
+func TestWatchChangesIdle(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	kvStore := &countingWatcher{Store: kvtest.GetStore(ctx, t)}
+	const idleTimeout = 10 * time.Millisecond
+	m := settings.NewManager(nil, kvStore,
+		settings.WithCache(cache.NewCache(100, time.Hour, cache.NewJitterFn(0))),
+		settings.WithWatchIdleTimeout(idleTimeout))
+	m.WatchChanges(ctx)
+	other := settings.NewManager(nil, kvStore, settings.WithCache(cache.NoCache))
+	otherRepository := &graveler.RepositoryRecord{
+		RepositoryID: "other-repo",
+		Repository:   repository.Repository,
+	}
+
+	firstSettings := newSetting(5, 6, "hello")
+	testutil.Must(t, other.Save(ctx, repository, "settingKey", firstSettings, nil))
+	testutil.Must(t, m.Get(ctx, repository, "settingKey", &settings.ExampleSettings{}))
+	require.EqualValues(t, 1, kvStore.active.Load())
+
+	// watching another repository after the idle timeout stops watching the first one
+	time.Sleep(2 * idleTimeout)
+	testutil.Must(t, m.Get(ctx, otherRepository, "settingKey", &settings.ExampleSettings{}))
+	require.Eventually(t, func() bool { return kvStore.active.Load() == 1 }, time.Second, time.Millisecond)
+
+	// its cached settings were removed, as they would no longer be removed on change
+	secondSettings := newSetting(15, 16, "hi")
+	testutil.Must(t, other.Save(ctx, repository, "settingKey", secondSettings, nil))
+	gotSettings := &settings.ExampleSettings{}
+	testutil.Must(t, m.Get(ctx, repository, "settingKey", gotSettings))
+	if diff := deep.Equal(secondSettings, gotSettings); diff != nil {
+		t.Fatal("got unexpected settings:", diff)
+	}
+}
+
 func TestGetLatest(t *testing.T) {
 	ctx := context.Background()
 	m := prepareTest(t, ctx, nil, nil)
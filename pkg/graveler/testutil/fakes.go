@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"slices"
 	"sort"
 	"time"
 
@@ -253,6 +254,7 @@ type RefsFake struct {
 	StagingToken        graveler.StagingToken
 	SealedTokens        []graveler.StagingToken
 	BaseMetaRangeID     graveler.MetaRangeID
+	PullRequests        map[graveler.PullRequestID]*graveler.PullRequest
 }
 
 func (m *RefsFake) CreateBranch(_ context.Context, _ *graveler.RepositoryRecord, _ graveler.BranchID, branch graveler.Branch) error {
@@ -362,6 +364,18 @@ func (m *RefsFake) BranchUpdate(_ context.Context, _ *graveler.RepositoryRecord,
 	return err
 }
 
+func (m *RefsFake) CommitBranchUpdate(ctx context.Context, repository *graveler.RepositoryRecord, _ graveler.BranchID, update graveler.BranchCommitUpdateFunc) (graveler.CommitID, error) {
+	var commitID graveler.CommitID
+	_, commit, err := update(m.Branch)
+	if err == nil && commit != nil {
+		commitID, err = m.AddCommit(ctx, repository, *commit)
+	}
+	if m.UpdateErr != nil {
+		return "", m.UpdateErr
+	}
+	return commitID, err
+}
+
 func (m *RefsFake) DeleteBranch(context.Context, *graveler.RepositoryRecord, graveler.BranchID) error {
 	return nil
 }
@@ -875,6 +889,31 @@ func (p ProtectedBranchesManagerFake) IsBlocked(_ context.Context, _ *graveler.R
 	return false, nil
 }
 
+func (p ProtectedBranchesManagerFake) RequiredApprovals(context.Context, *graveler.RepositoryRecord, graveler.BranchID) (int, error) {
+	return 0, nil
+}
+
+type ObjectLockManagerFake struct {
+	// LockedKeys maps branches to their locked keys
+	LockedKeys map[graveler.BranchID][]string
+}
+
+func NewObjectLockManagerFake(lockedKeys map[graveler.BranchID][]string) *ObjectLockManagerFake {
+	return &ObjectLockManagerFake{LockedKeys: lockedKeys}
+}
+
+func (o *ObjectLockManagerFake) IsLocked(_ context.Context, _ *graveler.RepositoryRecord, branchID graveler.BranchID, key graveler.Key) (bool, error) {
+	return slices.Contains(o.LockedKeys[branchID], key.String()), nil
+}
+
+func (o *ObjectLockManagerFake) HasLocks(_ context.Context, _ *graveler.RepositoryRecord, branchID graveler.BranchID) (bool, error) {
+	return len(o.LockedKeys[branchID]) > 0, nil
+}
+
+func (o *ObjectLockManagerFake) LockedCommits(context.Context, *graveler.RepositoryRecord) ([]graveler.CommitID, error) {
+	return nil, nil
+}
+
 func (m *RefsFake) GetRepositoryMetadata(_ context.Context, _ graveler.RepositoryID) (graveler.RepositoryMetadata, error) {
 	// TODO implement me
 	panic("implement me")
@@ -890,22 +929,60 @@ func (m *RefsFake) CreateCommitRecord(_ context.Context, _ *graveler.RepositoryR
 	panic("implement me")
 }
 
-func (m *RefsFake) GetPullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID) (*graveler.PullRequest, error) {
+func (m *RefsFake) GetPullRequest(_ context.Context, _ *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID) (*graveler.PullRequest, error) {
+	pr, ok := m.PullRequests[pullRequestID]
+	if !ok {
+		return nil, graveler.ErrPullRequestNotFound
+	}
+	prCopy := *pr
+	return &prCopy, nil
+}
+
+func (m *RefsFake) CreatePullRequest(_ context.Context, _ *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, pullRequest *graveler.PullRequest) error {
+	if _, ok := m.PullRequests[pullRequestID]; ok {
+		return graveler.ErrPullRequestExists
+	}
+	if m.PullRequests == nil {
+		m.PullRequests = make(map[graveler.PullRequestID]*graveler.PullRequest)
+	}
+	prCopy := *pullRequest
+	m.PullRequests[pullRequestID] = &prCopy
+	return nil
+}
+
+func (m *RefsFake) DeletePullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID) error {
+	panic("implement me")
+}
+
+func (m *RefsFake) UpdatePullRequest(ctx context.Context, repository *graveler.RepositoryRecord, pullRequestID graveler.PullRequestID, f graveler.PullUpdateFunc) error {
+	pr, err := m.GetPullRequest(ctx, repository, pullRequestID)
+	if err != nil {
+		return err
+	}
+	newPR, err := f(pr)
+	if err != nil || newPR == nil {
+		return err
+	}
+	m.PullRequests[pullRequestID] = newPR
+	return nil
+}
+
+func (m *RefsFake) ListPullRequests(context.Context, *graveler.RepositoryRecord) (graveler.PullsIterator, error) {
 	panic("implement me")
 }
 
-func (m *RefsFake) CreatePullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, *graveler.PullRequest) error {
+func (m *RefsFake) GetPullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, graveler.PullRequestCommentID) (*graveler.PullRequestComment, error) {
 	panic("implement me")
 }
 
-func (m *RefsFake) DeletePullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID) error {
+func (m *RefsFake) ListPullRequestComments(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID) (graveler.PullCommentsIterator, error) {
 	panic("implement me")
 }
 
-func (m *RefsFake) UpdatePullRequest(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, graveler.PullUpdateFunc) error {
+func (m *RefsFake) CreatePullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, *graveler.PullRequestComment) error {
 	panic("implement me")
 }
 
-func (m *RefsFake) ListPullRequests(context.Context, *graveler.RepositoryRecord) (graveler.PullsIterator, error) {
+func (m *RefsFake) UpdatePullRequestComment(context.Context, *graveler.RepositoryRecord, graveler.PullRequestID, graveler.PullRequestCommentID, graveler.PullCommentUpdateFunc) error {
 	panic("implement me")
 }
@@ -94,6 +94,17 @@ func ValidatePullRequestID(v interface{}) error {
 	return err
 }
 
+func ValidatePullRequestCommentID(v interface{}) error {
+	s, ok := v.(PullRequestCommentID)
+	if !ok {
+		panic(ErrInvalidType)
+	}
+	if _, err := xid.FromString(s.String()); err != nil {
+		return ErrInvalidPullRequestCommentID
+	}
+	return nil
+}
+
 func isControlCodeOrSpace(r rune) bool {
 	const space = 0x20
 	return r <= space
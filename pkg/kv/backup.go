@@ -0,0 +1,371 @@
+package kv
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+)
+
+// A backup archive is a gzip stream of:
+//
+//	magic     "lakefs-kv-backup"
+//	header    JSON of BackupHeader
+//	entries   partition key, key and value of every entry, in partition and key order
+//	end       an empty partition key
+//	trailer   JSON of BackupTrailer
+//
+// Every field is preceded by its length as a uvarint.  The checksum in the trailer is a
+// SHA-256 of the encoded entries, which is also the checksum Copy reports for the store.
+
+const (
+	// BackupFormatVersion is the version of the archives written by WriteBackup.  ReadBackup
+	// reads archives of this version and older.
+	BackupFormatVersion = 1
+
+	backupMagic = "lakefs-kv-backup"
+
+	// maxBackupFieldSize bounds the length of fields read from an archive, to fail on a
+	// corrupted length rather than allocate it
+	maxBackupFieldSize = 1 << 30
+)
+
+var (
+	ErrBackupCorrupted           = errors.New("backup archive corrupted")
+	ErrBackupUnsupportedVersion  = errors.New("backup archive version not supported")
+	ErrBackupInconsistent        = errors.New("store changed while backing up")
+	ErrRestoreTargetNotEmpty     = errors.New("restore target store not empty")
+	ErrRestoreVerificationFailed = errors.New("restore verification failed")
+)
+
+// BackupHeader opens a backup archive
+type BackupHeader struct {
+	Version   int       `json:"version"`
+	CreatedAt time.Time `json:"created_at"`
+	// Source describes the backed up store, such as its driver
+	Source string `json:"source,omitempty"`
+}
+
+// BackupTrailer closes a backup archive
+type BackupTrailer struct {
+	Partitions int    `json:"partitions"`
+	Entries    int64  `json:"entries"`
+	Checksum   string `json:"checksum"`
+}
+
+// BackupManifest describes a backup archive
+type BackupManifest struct {
+	BackupHeader
+	BackupTrailer
+}
+
+type BackupOptions struct {
+	// Source describes the backed up store in the header
+	Source string
+	// Verify checksums the store before backing up and compares it to the archive once
+	// written, to fail if the store changed while backing up
+	Verify bool
+}
+
+type RestoreOptions struct {
+	// Verify compares the target store to the archive once restored
+	Verify bool
+}
+
+// appendEntryFields appends the encoding of an entry to buf
+func appendEntryFields(buf []byte, partitionKey, key, value []byte) []byte {
+	for _, field := range [][]byte{partitionKey, key, value} {
+		buf = binary.AppendUvarint(buf, uint64(len(field)))
+		buf = append(buf, field...)
+	}
+	return buf
+}
+
+// WriteBackup writes an archive of all entries of all partitions of s to w.  It does not take
+// a snapshot of s: partitions are read one after the other, so writers must be stopped.  With
+// opts.Verify, it checksums every partition before backing it up and the whole store after,
+// and fails with ErrBackupInconsistent if either differs from the archive.
+func WriteBackup(ctx context.Context, s Store, w io.Writer, opts BackupOptions) (*BackupManifest, error) {
+	partitions, err := ListPartitions(ctx, s)
+	if err != nil {
+		return nil, fmt.Errorf("list partitions: %w", err)
+	}
+	var initialSums map[string][]byte
+	if opts.Verify {
+		initialSums, err = partitionChecksums(ctx, s, partitions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	manifest := &BackupManifest{
+		BackupHeader: BackupHeader{
+			Version:   BackupFormatVersion,
+			CreatedAt: time.Now().UTC(),
+			Source:    opts.Source,
+		},
+	}
+	zw := gzip.NewWriter(w)
+	if err := writeBackupHeader(zw, manifest.BackupHeader); err != nil {
+		return nil, err
+	}
+	sum := sha256.New()
+	var buf []byte
+	for _, partitionKey := range partitions {
+		it, err := s.Scan(ctx, partitionKey, ScanOptions{})
+		if err != nil {
+			return nil, fmt.Errorf("scan partition %s: %w", partitionKey, err)
+		}
+		n := int64(0)
+		partitionSum := sha256.New()
+		for it.Next() {
+			entry := it.Entry()
+			buf = appendEntryFields(buf[:0], partitionKey, entry.Key, entry.Value)
+			_, _ = sum.Write(buf)
+			_, _ = partitionSum.Write(buf)
+			if _, err := zw.Write(buf); err != nil {
+				it.Close()
+				return nil, err
+			}
+			n++
+		}
+		err = it.Err()
+		it.Close()
+		if err != nil {
+			return nil, fmt.Errorf("scan partition %s: %w", partitionKey, err)
+		}
+		if opts.Verify && !bytes.Equal(partitionSum.Sum(nil), initialSums[string(partitionKey)]) {
+			return nil, fmt.Errorf("partition %s changed, stop lakeFS and back up again: %w", partitionKey, ErrBackupInconsistent)
+		}
+		// partitions may have been emptied since listed
+		if n > 0 {
+			manifest.Partitions++
+			manifest.Entries += n
+		}
+	}
+	manifest.Checksum = hex.EncodeToString(sum.Sum(nil))
+	if err := writeBackupTrailer(zw, manifest.BackupTrailer); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	if !opts.Verify {
+		return manifest, nil
+	}
+
+	checksum, err := storeChecksum(ctx, s)
+	if err != nil {
+		return manifest, fmt.Errorf("verify: %w", err)
+	}
+	if checksum != manifest.Checksum {
+		return manifest, fmt.Errorf("stop lakeFS and back up again: %w", ErrBackupInconsistent)
+	}
+	return manifest, nil
+}
+
+func writeBackupHeader(w io.Writer, header BackupHeader) error {
+	data, err := json.Marshal(header)
+	if err != nil {
+		return err
+	}
+	buf := binary.AppendUvarint(nil, uint64(len(backupMagic)))
+	buf = append(buf, backupMagic...)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	buf = append(buf, data...)
+	_, err = w.Write(buf)
+	return err
+}
+
+func writeBackupTrailer(w io.Writer, trailer BackupTrailer) error {
+	data, err := json.Marshal(trailer)
+	if err != nil {
+		return err
+	}
+	// an empty partition key ends the entries
+	buf := binary.AppendUvarint(nil, 0)
+	buf = binary.AppendUvarint(buf, uint64(len(data)))
+	buf = append(buf, data...)
+	_, err = w.Write(buf)
+	return err
+}
+
+// storeChecksum returns the checksum of all entries of all partitions of s, as in a backup
+func storeChecksum(ctx context.Context, s Store) (string, error) {
+	partitions, err := ListPartitions(ctx, s)
+	if err != nil {
+		return "", fmt.Errorf("list partitions: %w", err)
+	}
+	sum := sha256.New()
+	for _, partitionKey := range partitions {
+		if _, _, err := partitionChecksum(ctx, s, partitionKey, sum); err != nil {
+			return "", fmt.Errorf("checksum partition %s: %w", partitionKey, err)
+		}
+	}
+	return hex.EncodeToString(sum.Sum(nil)), nil
+}
+
+// ReadBackup reads the archive in r, calling fn with each entry unless fn is nil.  Entries
+// are passed to fn before the archive is verified: read it once without fn to verify it
+// first.  It fails with ErrBackupCorrupted if the archive does not match its checksum.
+func ReadBackup(r io.Reader, fn func(entry *Entry) error) (*BackupManifest, error) {
+	zr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBackupCorrupted, err)
+	}
+	defer func() { _ = zr.Close() }()
+	br := bufio.NewReader(zr)
+
+	magic, err := readBackupField(br)
+	if err != nil {
+		return nil, err
+	}
+	if string(magic) != backupMagic {
+		return nil, fmt.Errorf("%w: not a backup archive", ErrBackupCorrupted)
+	}
+	manifest := &BackupManifest{}
+	if err := readBackupJSON(br, &manifest.BackupHeader); err != nil {
+		return nil, err
+	}
+	if manifest.Version < 1 || manifest.Version > BackupFormatVersion {
+		return nil, fmt.Errorf("version %d: %w", manifest.Version, ErrBackupUnsupportedVersion)
+	}
+
+	sum := sha256.New()
+	var (
+		buf           []byte
+		lastPartition []byte
+		partitions    int
+		entries       int64
+	)
+	for {
+		partitionKey, err := readBackupField(br)
+		if err != nil {
+			return nil, err
+		}
+		if len(partitionKey) == 0 {
+			break
+		}
+		key, err := readBackupField(br)
+		if err != nil {
+			return nil, err
+		}
+		value, err := readBackupField(br)
+		if err != nil {
+			return nil, err
+		}
+		buf = appendEntryFields(buf[:0], partitionKey, key, value)
+		_, _ = sum.Write(buf)
+		if !bytes.Equal(partitionKey, lastPartition) {
+			partitions++
+			lastPartition = partitionKey
+		}
+		entries++
+		if fn != nil {
+			if err := fn(&Entry{PartitionKey: partitionKey, Key: key, Value: value}); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if err := readBackupJSON(br, &manifest.BackupTrailer); err != nil {
+		return nil, err
+	}
+	if _, err := br.ReadByte(); !errors.Is(err, io.EOF) {
+		return nil, fmt.Errorf("%w: data after trailer", ErrBackupCorrupted)
+	}
+	checksum := hex.EncodeToString(sum.Sum(nil))
+	if checksum != manifest.Checksum || partitions != manifest.Partitions || entries != manifest.Entries {
+		return nil, fmt.Errorf("%w: checksum %s of %d entries in %d partitions, expected %s of %d entries in %d partitions",
+			ErrBackupCorrupted, checksum, entries, partitions, manifest.Checksum, manifest.Entries, manifest.Partitions)
+	}
+	return manifest, nil
+}
+
+func readBackupField(r *bufio.Reader) ([]byte, error) {
+	size, err := binary.ReadUvarint(r)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBackupCorrupted, err)
+	}
+	if size > maxBackupFieldSize {
+		return nil, fmt.Errorf("%w: field of %d bytes", ErrBackupCorrupted, size)
+	}
+	field := make([]byte, size)
+	if _, err := io.ReadFull(r, field); err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrBackupCorrupted, err)
+	}
+	return field, nil
+}
+
+func readBackupJSON(r *bufio.Reader, v any) error {
+	data, err := readBackupField(r)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("%w: %s", ErrBackupCorrupted, err)
+	}
+	return nil
+}
+
+// RestoreBackup restores the archive in r to target, which must hold no entries.  The
+// archive is verified before anything is written, so a corrupted archive fails without
+// changing target.  Entries of MetadataPartitionKey, which mark the store as set up, are
+// written last, so that a failed restore does not leave a store that appears set up.
+// With opts.Verify, target is compared to the archive once restored.
+func RestoreBackup(ctx context.Context, r io.ReadSeeker, target Store, opts RestoreOptions) (*BackupManifest, error) {
+	partitions, err := ListPartitions(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("list target partitions: %w", err)
+	}
+	if len(partitions) > 0 {
+		return nil, fmt.Errorf("%d partitions: %w", len(partitions), ErrRestoreTargetNotEmpty)
+	}
+	if _, err := ReadBackup(r, nil); err != nil {
+		return nil, err
+	}
+	if _, err := r.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	set := func(entry *Entry) error {
+		if err := target.Set(ctx, entry.PartitionKey, entry.Key, entry.Value); err != nil {
+			return fmt.Errorf("set partition %s key %s: %w", entry.PartitionKey, entry.Key, err)
+		}
+		return nil
+	}
+	var metadata []*Entry
+	manifest, err := ReadBackup(r, func(entry *Entry) error {
+		if string(entry.PartitionKey) == MetadataPartitionKey {
+			metadata = append(metadata, entry)
+			return nil
+		}
+		return set(entry)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range metadata {
+		if err := set(entry); err != nil {
+			return nil, err
+		}
+	}
+	if !opts.Verify {
+		return manifest, nil
+	}
+
+	checksum, err := storeChecksum(ctx, target)
+	if err != nil {
+		return manifest, fmt.Errorf("verify: %w", err)
+	}
+	if checksum != manifest.Checksum {
+		return manifest, fmt.Errorf("target checksum %s: %w", checksum, ErrRestoreVerificationFailed)
+	}
+	return manifest, nil
+}
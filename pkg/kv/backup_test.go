@@ -0,0 +1,183 @@
+package kv_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/kv"
+)
+
+func TestBackupRestore(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 3, 25)
+	require.NoError(t, source.Set(ctx, []byte("partition-empty-value"), []byte("key"), []byte{}))
+
+	var archive bytes.Buffer
+	manifest, err := kv.WriteBackup(ctx, source, &archive, kv.BackupOptions{Source: "mem", Verify: true})
+	require.NoError(t, err)
+	require.Equal(t, kv.BackupFormatVersion, manifest.Version)
+	require.Equal(t, "mem", manifest.Source)
+	require.Equal(t, 4, manifest.Partitions)
+	require.EqualValues(t, 76, manifest.Entries)
+
+	restored, err := kv.RestoreBackup(ctx, bytes.NewReader(archive.Bytes()), target, kv.RestoreOptions{Verify: true})
+	require.NoError(t, err)
+	require.Equal(t, manifest.Checksum, restored.Checksum)
+	require.Equal(t, manifest.CreatedAt.Unix(), restored.CreatedAt.Unix())
+
+	// the backup checksum is the checksum copy reports
+	_, copyTarget := openCopyStores(t)
+	report, err := kv.Copy(ctx, source, copyTarget, kv.CopyOptions{Verify: true})
+	require.NoError(t, err)
+	require.Equal(t, manifest.Checksum, report.SourceChecksum)
+	require.Equal(t, manifest.Checksum, report.TargetChecksum)
+
+	value, err := target.Get(ctx, []byte("partition-1"), []byte("key-007"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-key-007"), value.Value)
+}
+
+// scanHookStore calls onScan before every scan of the store it wraps
+type scanHookStore struct {
+	kv.Store
+	onScan func(ctx context.Context, partitionKey []byte) error
+}
+
+func (s scanHookStore) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
+	if err := s.onScan(ctx, partitionKey); err != nil {
+		return nil, err
+	}
+	return s.Store.Scan(ctx, partitionKey, options)
+}
+
+func (s scanHookStore) ListPartitions(ctx context.Context) ([][]byte, error) {
+	return kv.ListPartitions(ctx, s.Store)
+}
+
+func TestBackupStoreChanged(t *testing.T) {
+	ctx := context.Background()
+	source, _ := openCopyStores(t)
+	setupCopySource(t, source, 2, 5)
+
+	// lakeFS writing to a partition not yet backed up: the archive and the store match once
+	// written, but the archive does not hold a single point in time
+	scans := 0
+	store := scanHookStore{Store: source, onScan: func(ctx context.Context, partitionKey []byte) error {
+		scans++
+		if string(partitionKey) == "partition-0" && scans > 2 {
+			return source.Set(ctx, []byte("partition-1"), []byte("late"), []byte("value"))
+		}
+		return nil
+	}}
+	var archive bytes.Buffer
+	_, err := kv.WriteBackup(ctx, store, &archive, kv.BackupOptions{Verify: true})
+	require.ErrorIs(t, err, kv.ErrBackupInconsistent)
+	require.ErrorContains(t, err, "partition-1")
+}
+
+func TestRestoreTargetNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 1, 5)
+	setupCopySource(t, target, 1, 1)
+
+	var archive bytes.Buffer
+	_, err := kv.WriteBackup(ctx, source, &archive, kv.BackupOptions{})
+	require.NoError(t, err)
+	_, err = kv.RestoreBackup(ctx, bytes.NewReader(archive.Bytes()), target, kv.RestoreOptions{})
+	require.ErrorIs(t, err, kv.ErrRestoreTargetNotEmpty)
+}
+
+// failingStore fails every Set after the first sets
+type failingStore struct {
+	kv.Store
+	sets int
+}
+
+var errSetFailed = errors.New("set failed")
+
+func (s *failingStore) Set(ctx context.Context, partitionKey, key, value []byte) error {
+	if s.sets == 0 {
+		return errSetFailed
+	}
+	s.sets--
+	return s.Store.Set(ctx, partitionKey, key, value)
+}
+
+func (s *failingStore) ListPartitions(ctx context.Context) ([][]byte, error) {
+	return kv.ListPartitions(ctx, s.Store)
+}
+
+func TestRestoreMetadataLast(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 2, 5)
+	require.NoError(t, kv.SetDBSchemaVersion(ctx, source, kv.InitialMigrateVersion))
+
+	var archive bytes.Buffer
+	_, err := kv.WriteBackup(ctx, source, &archive, kv.BackupOptions{})
+	require.NoError(t, err)
+
+	// the metadata partition sorts first in the archive, but a restore failing after all
+	// other entries were written has not written it
+	_, err = kv.RestoreBackup(ctx, bytes.NewReader(archive.Bytes()), &failingStore{Store: target, sets: 10}, kv.RestoreOptions{})
+	require.ErrorIs(t, err, errSetFailed)
+	_, err = kv.GetDBSchemaVersion(ctx, target)
+	require.ErrorIs(t, err, kv.ErrNotFound)
+	_, err = target.Get(ctx, []byte("partition-1"), []byte("key-004"))
+	require.NoError(t, err)
+}
+
+func TestRestoreCorrupted(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 2, 10)
+
+	var archive bytes.Buffer
+	_, err := kv.WriteBackup(ctx, source, &archive, kv.BackupOptions{})
+	require.NoError(t, err)
+
+	// change a value inside the compressed archive
+	zr, err := gzip.NewReader(&archive)
+	require.NoError(t, err)
+	data, err := io.ReadAll(zr)
+	require.NoError(t, err)
+	data = bytes.Replace(data, []byte("value-key-005"), []byte("value-key-500"), 1)
+	var corrupted bytes.Buffer
+	zw := gzip.NewWriter(&corrupted)
+	_, err = zw.Write(data)
+	require.NoError(t, err)
+	require.NoError(t, zw.Close())
+
+	_, err = kv.RestoreBackup(ctx, bytes.NewReader(corrupted.Bytes()), target, kv.RestoreOptions{})
+	require.ErrorIs(t, err, kv.ErrBackupCorrupted)
+	partitions, err := kv.ListPartitions(ctx, target)
+	require.NoError(t, err)
+	require.Empty(t, partitions, "restored a corrupted archive")
+
+	// a truncated archive
+	_, err = kv.ReadBackup(bytes.NewReader(archive.Bytes()[:archive.Len()/2]), nil)
+	require.ErrorIs(t, err, kv.ErrBackupCorrupted)
+}
+
+func TestReadBackupUnsupportedVersion(t *testing.T) {
+	var archive bytes.Buffer
+	zw := gzip.NewWriter(&archive)
+	for _, field := range []string{"lakefs-kv-backup", `{"version":99}`} {
+		_, err := zw.Write(binary.AppendUvarint(nil, uint64(len(field))))
+		require.NoError(t, err)
+		_, err = zw.Write([]byte(field))
+		require.NoError(t, err)
+	}
+	require.NoError(t, zw.Close())
+
+	_, err := kv.ReadBackup(&archive, nil)
+	require.ErrorIs(t, err, kv.ErrBackupUnsupportedVersion)
+}
@@ -0,0 +1,250 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"hash"
+	"slices"
+)
+
+const DefaultCopyCheckpointInterval = 10_000
+
+var (
+	ErrListPartitionsNotSupported = errors.New("listing partitions not supported")
+	ErrCopyVerificationFailed     = errors.New("copy verification failed")
+	ErrCopyTargetNotEmpty         = errors.New("copy target not empty")
+	ErrCopySourceChanged          = errors.New("copy source changed while copying")
+)
+
+// ListPartitions returns the keys of all partitions of s holding entries, in byte order
+func ListPartitions(ctx context.Context, s Store) ([][]byte, error) {
+	lister, ok := unwrapStore(s).(PartitionLister)
+	if !ok {
+		return nil, ErrListPartitionsNotSupported
+	}
+	partitions, err := lister.ListPartitions(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(partitions, bytes.Compare)
+	return partitions, nil
+}
+
+// CopyCheckpoint is the last entry copied, from which to resume a copy
+type CopyCheckpoint struct {
+	PartitionKey []byte `json:"partition_key"`
+	Key          []byte `json:"key"`
+}
+
+type CopyOptions struct {
+	// Checkpoint to resume the copy after, nil to copy all entries
+	Checkpoint *CopyCheckpoint
+	// OnCheckpoint is called with the last entry copied every CheckpointInterval entries and
+	// at the end of every partition.  Failing it fails the copy.
+	OnCheckpoint func(ctx context.Context, checkpoint CopyCheckpoint) error
+	// CheckpointInterval is the number of entries copied between checkpoints, DefaultCopyCheckpointInterval if 0
+	CheckpointInterval int
+	// Verify compares the partitions of both stores after the copy, and checks that source did
+	// not change since before the copy
+	Verify bool
+}
+
+// CopyReport summarizes a copy
+type CopyReport struct {
+	// Partitions is the number of partitions in the source store
+	Partitions int `json:"partitions"`
+	// Entries is the number of entries copied, excluding those copied before the checkpoint resumed from
+	Entries int64 `json:"entries"`
+	// Verified is the number of entries compared, if verified
+	Verified int64 `json:"verified,omitempty"`
+	// SourceChecksum and TargetChecksum are SHA-256 checksums of all entries of the copied
+	// partitions in each store, in order, if verified
+	SourceChecksum string `json:"source_checksum,omitempty"`
+	TargetChecksum string `json:"target_checksum,omitempty"`
+	// Mismatched lists the partitions whose entries differ between the stores, if verified
+	Mismatched []string `json:"mismatched,omitempty"`
+	// Changed lists the partitions of the source changed while copying, if verified
+	Changed []string `json:"changed,omitempty"`
+}
+
+// Copy copies all entries of all partitions of source to target, in partition and key order.
+// Unless resuming from a checkpoint, target must hold no entries: entries it holds that source
+// does not would survive the copy.  Entries are written with Set, so resuming over a partial
+// copy is safe.  It does not follow changes made to source while copying, so writers must be
+// stopped.  If verifying, it checksums source before copying and fails with
+// ErrCopySourceChanged if source changed by the time it is verified.
+func Copy(ctx context.Context, source, target Store, opts CopyOptions) (*CopyReport, error) {
+	partitions, err := ListPartitions(ctx, source)
+	if err != nil {
+		return nil, fmt.Errorf("list source partitions: %w", err)
+	}
+	if opts.Checkpoint == nil {
+		targetPartitions, err := ListPartitions(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("list target partitions: %w", err)
+		}
+		if len(targetPartitions) > 0 {
+			return nil, fmt.Errorf("%d partitions: %w", len(targetPartitions), ErrCopyTargetNotEmpty)
+		}
+	}
+	var initialSums map[string][]byte
+	if opts.Verify {
+		initialSums, err = partitionChecksums(ctx, source, partitions)
+		if err != nil {
+			return nil, err
+		}
+	}
+	interval := opts.CheckpointInterval
+	if interval <= 0 {
+		interval = DefaultCopyCheckpointInterval
+	}
+	report := &CopyReport{Partitions: len(partitions)}
+	for _, partitionKey := range partitions {
+		var after []byte
+		if opts.Checkpoint != nil {
+			switch bytes.Compare(partitionKey, opts.Checkpoint.PartitionKey) {
+			case -1:
+				continue
+			case 0:
+				after = opts.Checkpoint.Key
+			}
+		}
+		n, err := copyPartition(ctx, source, target, partitionKey, after, interval, opts.OnCheckpoint)
+		report.Entries += n
+		if err != nil {
+			return report, fmt.Errorf("copy partition %s: %w", partitionKey, err)
+		}
+	}
+	if !opts.Verify {
+		return report, nil
+	}
+
+	sourceSum := sha256.New()
+	targetSum := sha256.New()
+	for _, partitionKey := range partitions {
+		n, sourcePartitionSum, err := partitionChecksum(ctx, source, partitionKey, sourceSum)
+		if err != nil {
+			return report, fmt.Errorf("checksum source partition %s: %w", partitionKey, err)
+		}
+		_, targetPartitionSum, err := partitionChecksum(ctx, target, partitionKey, targetSum)
+		if err != nil {
+			return report, fmt.Errorf("checksum target partition %s: %w", partitionKey, err)
+		}
+		report.Verified += n
+		if !bytes.Equal(sourcePartitionSum, targetPartitionSum) {
+			report.Mismatched = append(report.Mismatched, string(partitionKey))
+		}
+		if !bytes.Equal(sourcePartitionSum, initialSums[string(partitionKey)]) {
+			report.Changed = append(report.Changed, string(partitionKey))
+		}
+	}
+	report.SourceChecksum = hex.EncodeToString(sourceSum.Sum(nil))
+	report.TargetChecksum = hex.EncodeToString(targetSum.Sum(nil))
+	// partitions created while copying were not copied
+	finalPartitions, err := ListPartitions(ctx, source)
+	if err != nil {
+		return report, fmt.Errorf("list source partitions: %w", err)
+	}
+	for _, partitionKey := range finalPartitions {
+		if _, ok := initialSums[string(partitionKey)]; !ok {
+			report.Changed = append(report.Changed, string(partitionKey))
+		}
+	}
+	if len(report.Changed) > 0 {
+		return report, fmt.Errorf("%d partitions changed, stop lakeFS and copy again: %w", len(report.Changed), ErrCopySourceChanged)
+	}
+	if len(report.Mismatched) > 0 {
+		return report, fmt.Errorf("%d partitions differ: %w", len(report.Mismatched), ErrCopyVerificationFailed)
+	}
+	return report, nil
+}
+
+// partitionChecksums returns the checksum of the entries of each of partitions of s
+func partitionChecksums(ctx context.Context, s Store, partitions [][]byte) (map[string][]byte, error) {
+	sums := make(map[string][]byte, len(partitions))
+	for _, partitionKey := range partitions {
+		_, sum, err := partitionChecksum(ctx, s, partitionKey, nil)
+		if err != nil {
+			return nil, fmt.Errorf("checksum partition %s: %w", partitionKey, err)
+		}
+		sums[string(partitionKey)] = sum
+	}
+	return sums, nil
+}
+
+// copyPartition copies the entries of partitionKey after key after, all entries if after is nil
+func copyPartition(ctx context.Context, source, target Store, partitionKey, after []byte, interval int, onCheckpoint func(context.Context, CopyCheckpoint) error) (int64, error) {
+	it, err := source.Scan(ctx, partitionKey, ScanOptions{KeyStart: after})
+	if err != nil {
+		return 0, err
+	}
+	defer it.Close()
+
+	var (
+		copied  int64
+		lastKey []byte
+	)
+	checkpoint := func() error {
+		if onCheckpoint == nil || lastKey == nil {
+			return nil
+		}
+		return onCheckpoint(ctx, CopyCheckpoint{PartitionKey: partitionKey, Key: lastKey})
+	}
+	for it.Next() {
+		entry := it.Entry()
+		if after != nil && bytes.Equal(entry.Key, after) {
+			continue
+		}
+		value := entry.Value
+		if value == nil { // stores may return empty values as nil
+			value = []byte{}
+		}
+		if err := target.Set(ctx, partitionKey, entry.Key, value); err != nil {
+			return copied, fmt.Errorf("set key %s: %w", entry.Key, err)
+		}
+		copied++
+		lastKey = entry.Key
+		if copied%int64(interval) == 0 {
+			if err := checkpoint(); err != nil {
+				return copied, err
+			}
+		}
+	}
+	if err := it.Err(); err != nil {
+		return copied, err
+	}
+	return copied, checkpoint()
+}
+
+// partitionChecksum returns the number of entries of partitionKey and their checksum, also
+// writing them to total unless it is nil
+func partitionChecksum(ctx context.Context, s Store, partitionKey []byte, total hash.Hash) (int64, []byte, error) {
+	it, err := s.Scan(ctx, partitionKey, ScanOptions{})
+	if err != nil {
+		return 0, nil, err
+	}
+	defer it.Close()
+
+	h := sha256.New()
+	var (
+		n   int64
+		buf []byte
+	)
+	for it.Next() {
+		entry := it.Entry()
+		buf = appendEntryFields(buf[:0], partitionKey, entry.Key, entry.Value)
+		_, _ = h.Write(buf)
+		if total != nil {
+			_, _ = total.Write(buf)
+		}
+		n++
+	}
+	if err := it.Err(); err != nil {
+		return 0, nil, err
+	}
+	return n, h.Sum(nil), nil
+}
@@ -0,0 +1,176 @@
+package kv_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvparams"
+	"github.com/treeverse/lakefs/pkg/kv/local"
+	"github.com/treeverse/lakefs/pkg/kv/mem"
+)
+
+func openCopyStores(t *testing.T) (kv.Store, kv.Store) {
+	t.Helper()
+	ctx := context.Background()
+	source, err := (&mem.Driver{}).Open(ctx, kvparams.Config{})
+	require.NoError(t, err)
+	t.Cleanup(source.Close)
+	target, err := (&local.Driver{}).Open(ctx, kvparams.Config{Local: &kvparams.Local{Path: path.Join(t.TempDir(), "kv")}})
+	require.NoError(t, err)
+	t.Cleanup(target.Close)
+	return source, target
+}
+
+func setupCopySource(t *testing.T, s kv.Store, partitions, entries int) {
+	t.Helper()
+	ctx := context.Background()
+	for p := 0; p < partitions; p++ {
+		for e := 0; e < entries; e++ {
+			key := fmt.Sprintf("key-%03d", e)
+			err := s.Set(ctx, []byte(fmt.Sprintf("partition-%d", p)), []byte(key), []byte("value-"+key))
+			require.NoError(t, err)
+		}
+	}
+}
+
+func TestCopy(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 3, 25)
+
+	var checkpoints []kv.CopyCheckpoint
+	report, err := kv.Copy(ctx, source, target, kv.CopyOptions{
+		CheckpointInterval: 10,
+		OnCheckpoint: func(_ context.Context, checkpoint kv.CopyCheckpoint) error {
+			checkpoints = append(checkpoints, checkpoint)
+			return nil
+		},
+		Verify: true,
+	})
+	require.NoError(t, err)
+	require.Equal(t, 3, report.Partitions)
+	require.EqualValues(t, 75, report.Entries)
+	require.EqualValues(t, 75, report.Verified)
+	require.NotEmpty(t, report.SourceChecksum)
+	require.Equal(t, report.SourceChecksum, report.TargetChecksum)
+	require.Empty(t, report.Mismatched)
+	// two checkpoints by interval and one at the end of each partition
+	require.Len(t, checkpoints, 9)
+	require.Equal(t, kv.CopyCheckpoint{PartitionKey: []byte("partition-2"), Key: []byte("key-024")}, checkpoints[len(checkpoints)-1])
+
+	res, err := target.Get(ctx, []byte("partition-1"), []byte("key-007"))
+	require.NoError(t, err)
+	require.Equal(t, []byte("value-key-007"), res.Value)
+}
+
+func TestCopyResume(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 3, 25)
+
+	errStop := errors.New("stop")
+	var last kv.CopyCheckpoint
+	_, err := kv.Copy(ctx, source, target, kv.CopyOptions{
+		CheckpointInterval: 10,
+		OnCheckpoint: func(_ context.Context, checkpoint kv.CopyCheckpoint) error {
+			last = checkpoint
+			if string(checkpoint.PartitionKey) == "partition-1" && string(checkpoint.Key) == "key-019" {
+				return errStop
+			}
+			return nil
+		},
+	})
+	require.ErrorIs(t, err, errStop)
+
+	report, err := kv.Copy(ctx, source, target, kv.CopyOptions{Checkpoint: &last, Verify: true})
+	require.NoError(t, err)
+	// the rest of partition-1 and all of partition-2
+	require.EqualValues(t, 5+25, report.Entries)
+	require.EqualValues(t, 75, report.Verified)
+	require.Equal(t, report.SourceChecksum, report.TargetChecksum)
+}
+
+func TestCopyVerifyMismatch(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 2, 5)
+
+	report, err := kv.Copy(ctx, source, target, kv.CopyOptions{
+		// a writer changing the target while copying
+		OnCheckpoint: func(ctx context.Context, checkpoint kv.CopyCheckpoint) error {
+			if string(checkpoint.PartitionKey) != "partition-1" {
+				return nil
+			}
+			return target.Set(ctx, checkpoint.PartitionKey, []byte("extra"), []byte("value"))
+		},
+		Verify: true,
+	})
+	require.ErrorIs(t, err, kv.ErrCopyVerificationFailed)
+	require.Equal(t, []string{"partition-1"}, report.Mismatched)
+	require.NotEqual(t, report.SourceChecksum, report.TargetChecksum)
+}
+
+func TestCopySourceChanged(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 2, 5)
+
+	report, err := kv.Copy(ctx, source, target, kv.CopyOptions{
+		// lakeFS writing to the source while copying
+		OnCheckpoint: func(ctx context.Context, checkpoint kv.CopyCheckpoint) error {
+			if string(checkpoint.PartitionKey) != "partition-0" {
+				return nil
+			}
+			if err := source.Set(ctx, checkpoint.PartitionKey, []byte("late"), []byte("value")); err != nil {
+				return err
+			}
+			return source.Set(ctx, []byte("partition-9"), []byte("new"), []byte("value"))
+		},
+		Verify: true,
+	})
+	require.ErrorIs(t, err, kv.ErrCopySourceChanged)
+	require.Equal(t, []string{"partition-0", "partition-9"}, report.Changed)
+}
+
+func TestCopyTargetNotEmpty(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	setupCopySource(t, source, 2, 5)
+	require.NoError(t, target.Set(ctx, []byte("partition-9"), []byte("stale"), []byte("value")))
+
+	_, err := kv.Copy(ctx, source, target, kv.CopyOptions{})
+	require.ErrorIs(t, err, kv.ErrCopyTargetNotEmpty)
+	_, err = target.Get(ctx, []byte("partition-0"), []byte("key-000"))
+	require.ErrorIs(t, err, kv.ErrNotFound)
+
+	// resuming copies over entries already in the target
+	report, err := kv.Copy(ctx, source, target, kv.CopyOptions{Checkpoint: &kv.CopyCheckpoint{}})
+	require.NoError(t, err)
+	require.EqualValues(t, 10, report.Entries)
+}
+
+func TestCopyListPartitionsNotSupported(t *testing.T) {
+	ctx := context.Background()
+	source, target := openCopyStores(t)
+	_, err := kv.Copy(ctx, nonTransactionalStore{Store: source}, target, kv.CopyOptions{})
+	require.ErrorIs(t, err, kv.ErrListPartitionsNotSupported)
+}
+
+func TestCopyEmptyValue(t *testing.T) {
+	ctx := context.Background()
+	memStore, localStore := openCopyStores(t)
+	// the local store returns empty values as nil
+	require.NoError(t, localStore.Set(ctx, []byte("partition"), []byte("empty"), []byte{}))
+
+	report, err := kv.Copy(ctx, localStore, memStore, kv.CopyOptions{Verify: true})
+	require.NoError(t, err)
+	require.EqualValues(t, 1, report.Entries)
+	res, err := memStore.Get(ctx, []byte("partition"), []byte("empty"))
+	require.NoError(t, err)
+	require.Empty(t, res.Value)
+}
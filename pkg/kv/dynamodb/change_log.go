@@ -0,0 +1,275 @@
+package dynamodb
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	mathrand "math/rand/v2"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
+)
+
+// The change log emulates DynamoDB Streams inside the table: every write appends a record of
+// its changes to one of several change log partitions, picked at random to spread the writes
+// over DynamoDB partitions, under a key ordered by time.  Watching stores poll all change log
+// partitions for records appended by other stores, and remove records older than the
+// retention period.
+
+const (
+	// ChangeLogPartitionKey prefixes the partitions holding the change log, one per shard.
+	// They are not listed by ListPartitions, so they are not copied to other stores.
+	ChangeLogPartitionKey = "kv-internal-change-log"
+
+	// changeLogShards is the number of change log partitions
+	changeLogShards = 8
+
+	DefaultChangeLogPollInterval = time.Second
+	DefaultChangeLogRetention    = 5 * time.Minute
+
+	// changeLogLookback is how far back each poll reads, to find records appended by stores
+	// whose clock lags behind or whose write was slow to complete
+	changeLogLookback = 10 * time.Second
+)
+
+// changeRecord is the value of a change log entry
+type changeRecord struct {
+	// Source identifies the store that appended the record
+	Source  string              `json:"source"`
+	Changes []changeRecordEvent `json:"changes"`
+}
+
+type changeRecordEvent struct {
+	PartitionKey []byte `json:"partition_key"`
+	Key          []byte `json:"key"`
+	Deleted      bool   `json:"deleted,omitempty"`
+}
+
+func randomHex() string {
+	const idBytes = 8
+	b := make([]byte, idBytes)
+	_, _ = rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// changeLogPartition returns the partition key of change log shard
+func changeLogPartition(shard int) []byte {
+	return []byte(fmt.Sprintf("%s/%02x", ChangeLogPartitionKey, shard))
+}
+
+// isChangeLogPartition returns true if partitionKey is a change log partition
+func isChangeLogPartition(partitionKey []byte) bool {
+	return bytes.HasPrefix(partitionKey, []byte(ChangeLogPartitionKey+"/"))
+}
+
+// changeLogKey returns the key of a change log record appended at t.  Keys sort by time,
+// nonce avoids collisions between stores.
+func changeLogKey(t time.Time, nonce string) []byte {
+	return []byte(fmt.Sprintf("%016x/%s", t.UnixNano(), nonce))
+}
+
+// appendChangeLog appends events to the change log, if enabled.  The write they record
+// already succeeded, so failing to append is logged and not returned.
+func (s *Store) appendChangeLog(ctx context.Context, events ...kv.WatchEvent) {
+	if !s.params.ChangeLog || len(events) == 0 {
+		return
+	}
+	record := changeRecord{Source: s.id, Changes: make([]changeRecordEvent, 0, len(events))}
+	for _, event := range events {
+		record.Changes = append(record.Changes, changeRecordEvent(event))
+	}
+	// changes are delivered to watches of this store at once, and skipped when polled
+	for _, event := range events {
+		s.hub.Notify(event)
+	}
+
+	value, err := json.Marshal(record)
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to encode change log record")
+		return
+	}
+	item, err := attributevalue.MarshalMap(DynKVItem{
+		PartitionKey: changeLogPartition(mathrand.IntN(changeLogShards)),
+		ItemKey:      changeLogKey(time.Now(), randomHex()),
+		ItemValue:    value,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Error("Failed to encode change log record")
+		return
+	}
+	resp, err := s.svc.PutItem(ctx, &dynamodb.PutItemInput{
+		Item:                   item,
+		TableName:              aws.String(s.params.TableName),
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	if err != nil {
+		s.logger.WithContext(ctx).WithError(err).Warn("Failed to append to change log, other instances will not see the change until their caches expire")
+		return
+	}
+	if resp.ConsumedCapacity != nil {
+		dynamoConsumedCapacity.WithLabelValues("PutItem").Add(*resp.ConsumedCapacity.CapacityUnits)
+	}
+}
+
+func (s *Store) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	if !s.params.ChangeLog {
+		return kv.ErrWatchNotSupported
+	}
+	s.startPollingChangeLog(ctx)
+	return s.hub.Watch(ctx, partitionKey, prefix, fn)
+}
+
+func (s *Store) startPollingChangeLog(ctx context.Context) {
+	s.changeLogMu.Lock()
+	defer s.changeLogMu.Unlock()
+	if s.changeLogCancel != nil {
+		return
+	}
+	pollCtx, cancel := context.WithCancel(context.Background())
+	s.changeLogCancel = cancel
+	s.changeLogDone = make(chan struct{})
+	go s.pollChangeLog(pollCtx, logging.FromContext(ctx).WithField("store", DriverName))
+}
+
+func (s *Store) stopPollingChangeLog() {
+	s.changeLogMu.Lock()
+	defer s.changeLogMu.Unlock()
+	if s.changeLogCancel == nil {
+		return
+	}
+	s.changeLogCancel()
+	<-s.changeLogDone
+	s.changeLogCancel = nil
+}
+
+func (s *Store) pollChangeLog(ctx context.Context, log logging.Logger) {
+	defer close(s.changeLogDone)
+	interval := s.params.ChangeLogPollInterval
+	if interval <= 0 {
+		interval = DefaultChangeLogPollInterval
+	}
+	// look back over several polls, and keep records for longer than any store looks back
+	lookback := max(changeLogLookback, 2*interval)
+	retention := s.params.ChangeLogRetention
+	if retention <= 0 {
+		retention = DefaultChangeLogRetention
+	}
+	retention = max(retention, 2*lookback)
+
+	// records appended before polling started are not delivered
+	since := time.Now()
+	seen := make(map[string]struct{})
+	lastPolled := since
+	lastExpired := since
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+		now := time.Now()
+		from := now.Add(-lookback)
+		if from.Before(since) {
+			from = since
+		}
+		if err := s.readChangeLog(ctx, changeLogKey(from, ""), seen); err != nil {
+			if !errors.Is(err, context.Canceled) {
+				log.WithError(err).Warn("Failed to read change log")
+			}
+			continue
+		}
+		if now.Sub(lastPolled) > lookback {
+			// records appended before this poll looked back may have been missed
+			s.hub.NotifyMissed()
+		}
+		lastPolled = now
+		if now.Sub(lastExpired) > retention {
+			if err := s.expireChangeLog(ctx, changeLogKey(now.Add(-retention), "")); err != nil && !errors.Is(err, context.Canceled) {
+				log.WithError(err).Warn("Failed to expire change log")
+			}
+			lastExpired = now
+		}
+	}
+}
+
+// readChangeLog delivers the changes of records from key from onwards that were not seen
+// and were appended by other stores, shard by shard.  It forgets seen records before from.
+func (s *Store) readChangeLog(ctx context.Context, from []byte, seen map[string]struct{}) error {
+	for shard := range changeLogShards {
+		if err := s.readChangeLogShard(ctx, changeLogPartition(shard), from, seen); err != nil {
+			return err
+		}
+	}
+	for key := range seen {
+		if bytes.Compare([]byte(key), from) < 0 {
+			delete(seen, key)
+		}
+	}
+	return nil
+}
+
+func (s *Store) readChangeLogShard(ctx context.Context, partitionKey, from []byte, seen map[string]struct{}) error {
+	it, err := s.Scan(ctx, partitionKey, kv.ScanOptions{KeyStart: from})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		entry := it.Entry()
+		if _, ok := seen[string(entry.Key)]; ok {
+			continue
+		}
+		seen[string(entry.Key)] = struct{}{}
+		var record changeRecord
+		if err := json.Unmarshal(entry.Value, &record); err != nil {
+			s.logger.WithError(err).WithField("key", string(entry.Key)).Warn("Skipping bad change log record")
+			continue
+		}
+		if record.Source == s.id {
+			continue
+		}
+		for _, change := range record.Changes {
+			s.hub.Notify(kv.WatchEvent(change))
+		}
+	}
+	return it.Err()
+}
+
+// expireChangeLog deletes the change log records before key before from all shards
+func (s *Store) expireChangeLog(ctx context.Context, before []byte) error {
+	for shard := range changeLogShards {
+		if err := s.expireChangeLogShard(ctx, changeLogPartition(shard), before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *Store) expireChangeLogShard(ctx context.Context, partitionKey, before []byte) error {
+	it, err := s.Scan(ctx, partitionKey, kv.ScanOptions{})
+	if err != nil {
+		return err
+	}
+	defer it.Close()
+	for it.Next() {
+		key := it.Entry().Key
+		if bytes.Compare(key, before) >= 0 {
+			break
+		}
+		if err := s.deleteItem(ctx, partitionKey, key); err != nil {
+			return err
+		}
+	}
+	return it.Err()
+}
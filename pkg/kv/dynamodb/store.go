@@ -32,6 +32,13 @@ type Store struct {
 	wg     sync.WaitGroup
 	logger logging.Logger
 	cancel chan bool
+
+	// id identifies the records this store appends to the change log
+	id              string
+	hub             kv.WatchHub
+	changeLogMu     sync.Mutex
+	changeLogCancel context.CancelFunc
+	changeLogDone   chan struct{}
 }
 
 type EntriesIterator struct {
@@ -136,6 +143,7 @@ func (d *Driver) Open(ctx context.Context, kvParams kvparams.Config) (kv.Store,
 		params: params,
 		logger: logger,
 		cancel: make(chan bool),
+		id:     randomHex(),
 	}
 
 	s.StartPeriodicCheck()
@@ -344,6 +352,7 @@ func (s *Store) setWithOptionalPredicate(ctx context.Context, partitionKey, key,
 	if resp.ConsumedCapacity != nil {
 		dynamoConsumedCapacity.WithLabelValues(operation).Add(*resp.ConsumedCapacity.CapacityUnits)
 	}
+	s.appendChangeLog(ctx, kv.WatchEvent{PartitionKey: partitionKey, Key: key})
 	return nil
 }
 
@@ -354,7 +363,14 @@ func (s *Store) Delete(ctx context.Context, partitionKey, key []byte) error {
 	if len(key) == 0 {
 		return kv.ErrMissingKey
 	}
+	if err := s.deleteItem(ctx, partitionKey, key); err != nil {
+		return err
+	}
+	s.appendChangeLog(ctx, kv.WatchEvent{PartitionKey: partitionKey, Key: key, Deleted: true})
+	return nil
+}
 
+func (s *Store) deleteItem(ctx context.Context, partitionKey, key []byte) error {
 	resp, err := s.svc.DeleteItem(ctx, &dynamodb.DeleteItemInput{
 		TableName:              aws.String(s.params.TableName),
 		Key:                    s.bytesKeyToDynamoKey(partitionKey, key),
@@ -375,6 +391,167 @@ func (s *Store) Delete(ctx context.Context, partitionKey, key []byte) error {
 	return nil
 }
 
+// ListPartitions scans the entire table for the partition keys of its items, except for the
+// change log
+func (s *Store) ListPartitions(ctx context.Context) ([][]byte, error) {
+	seen := make(map[string]struct{})
+	var partitions [][]byte
+	paginator := dynamodb.NewScanPaginator(s.svc, &dynamodb.ScanInput{
+		TableName:              aws.String(s.params.TableName),
+		ProjectionExpression:   aws.String(PartitionKey),
+		ConsistentRead:         aws.Bool(true),
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	const operation = "Scan"
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			if s.isSlowDownErr(err) {
+				s.logger.WithContext(ctx).Error("scan partitions: %w", kv.ErrSlowDown)
+				dynamoSlowdown.WithLabelValues(operation).Inc()
+				err = errors.Join(err, kv.ErrSlowDown)
+			}
+			return nil, fmt.Errorf("scan partitions: %w", err)
+		}
+		if page.ConsumedCapacity != nil {
+			dynamoConsumedCapacity.WithLabelValues(operation).Add(*page.ConsumedCapacity.CapacityUnits)
+		}
+		for _, item := range page.Items {
+			attr, ok := item[PartitionKey].(*types.AttributeValueMemberB)
+			if !ok {
+				continue
+			}
+			if _, ok := seen[string(attr.Value)]; ok {
+				continue
+			}
+			seen[string(attr.Value)] = struct{}{}
+			if isChangeLogPartition(attr.Value) {
+				continue
+			}
+			partitions = append(partitions, attr.Value)
+		}
+	}
+	return partitions, nil
+}
+
+func (s *Store) Transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	if err := kv.ValidateTxnOps(partitionKey, ops); err != nil {
+		return err
+	}
+	if len(ops) == 0 {
+		return nil
+	}
+	items := make([]types.TransactWriteItem, 0, len(ops))
+	for _, op := range ops {
+		item, err := s.transactWriteItem(partitionKey, op)
+		if err != nil {
+			return err
+		}
+		items = append(items, item)
+	}
+
+	resp, err := s.svc.TransactWriteItems(ctx, &dynamodb.TransactWriteItemsInput{
+		TransactItems:          items,
+		ReturnConsumedCapacity: types.ReturnConsumedCapacityTotal,
+	})
+	const operation = "TransactWriteItems"
+	if err != nil {
+		var errCanceled *types.TransactionCanceledException
+		if errors.As(err, &errCanceled) && isPredicateCancellation(errCanceled) {
+			return kv.ErrPredicateFailed
+		}
+		if s.isSlowDownErr(err) {
+			s.logger.WithField("partition_key", partitionKey).WithContext(ctx).Error("transact write items: %w", kv.ErrSlowDown)
+			dynamoSlowdown.WithLabelValues(operation).Inc()
+			err = errors.Join(err, kv.ErrSlowDown)
+		}
+		return fmt.Errorf("transact write items: %w", err)
+	}
+	for _, c := range resp.ConsumedCapacity {
+		if c.CapacityUnits != nil {
+			dynamoConsumedCapacity.WithLabelValues(operation).Add(*c.CapacityUnits)
+		}
+	}
+	s.appendChangeLog(ctx, kv.TxnWatchEvents(partitionKey, ops)...)
+	return nil
+}
+
+// isPredicateCancellation returns true if a transaction was canceled by a failed condition or
+// by a conflicting write: both mean that the values the predicates were taken from changed.
+func isPredicateCancellation(err *types.TransactionCanceledException) bool {
+	for _, reason := range err.CancellationReasons {
+		switch aws.ToString(reason.Code) {
+		case "ConditionalCheckFailed", "TransactionConflict":
+			return true
+		}
+	}
+	return false
+}
+
+// transactWriteItem returns the item of a transaction that applies op
+func (s *Store) transactWriteItem(partitionKey []byte, op kv.TxnOp) (types.TransactWriteItem, error) {
+	var condition *expression.Expression
+	if op.Conditional {
+		var cond expression.ConditionBuilder
+		switch op.Predicate {
+		case nil:
+			cond = expression.AttributeNotExists(expression.Name(ItemValue))
+		case kv.PrecondConditionalExists:
+			cond = expression.AttributeExists(expression.Name(ItemValue))
+		default:
+			cond = expression.Name(ItemValue).Equal(expression.Value(op.Predicate.([]byte)))
+		}
+		expr, err := expression.NewBuilder().WithCondition(cond).Build()
+		if err != nil {
+			return types.TransactWriteItem{}, fmt.Errorf("build condition expression: %w", err)
+		}
+		condition = &expr
+	}
+
+	switch {
+	case !op.IsDelete():
+		marshaledItem, err := attributevalue.MarshalMap(DynKVItem{
+			PartitionKey: partitionKey,
+			ItemKey:      op.Key,
+			ItemValue:    op.Value,
+		})
+		if err != nil {
+			return types.TransactWriteItem{}, fmt.Errorf("marshal map: %w", err)
+		}
+		put := &types.Put{
+			Item:      marshaledItem,
+			TableName: aws.String(s.params.TableName),
+		}
+		if condition != nil {
+			put.ConditionExpression = condition.Condition()
+			put.ExpressionAttributeNames = condition.Names()
+			put.ExpressionAttributeValues = condition.Values()
+		}
+		return types.TransactWriteItem{Put: put}, nil
+
+	case condition != nil && op.Predicate == nil: // delete of a key that must not exist is only a check
+		return types.TransactWriteItem{ConditionCheck: &types.ConditionCheck{
+			Key:                       s.bytesKeyToDynamoKey(partitionKey, op.Key),
+			TableName:                 aws.String(s.params.TableName),
+			ConditionExpression:       condition.Condition(),
+			ExpressionAttributeNames:  condition.Names(),
+			ExpressionAttributeValues: condition.Values(),
+		}}, nil
+
+	default:
+		del := &types.Delete{
+			Key:       s.bytesKeyToDynamoKey(partitionKey, op.Key),
+			TableName: aws.String(s.params.TableName),
+		}
+		if condition != nil {
+			del.ConditionExpression = condition.Condition()
+			del.ExpressionAttributeNames = condition.Names()
+			del.ExpressionAttributeValues = condition.Values()
+		}
+		return types.TransactWriteItem{Delete: del}, nil
+	}
+}
+
 func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
 	if len(partitionKey) == 0 {
 		return nil, kv.ErrMissingPartitionKey
@@ -409,6 +586,7 @@ func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOp
 }
 
 func (s *Store) Close() {
+	s.stopPollingChangeLog()
 	s.StopPeriodicCheck()
 }
 
@@ -21,6 +21,7 @@ func TestDynamoKV(t *testing.T) {
 			AwsRegion:          "us-east-1",
 			AwsAccessKeyID:     "fakeMyKeyId",
 			AwsSecretAccessKey: "fakeSecretAccessKey",
+			ChangeLog:          true,
 		}
 
 		store, err := kv.Open(ctx, kvparams.Config{DynamoDB: testParams, Type: dynamodb.DriverName})
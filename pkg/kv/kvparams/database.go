@@ -14,6 +14,7 @@ type Config struct {
 	Postgres *Postgres
 	DynamoDB *DynamoDB
 	Local    *Local
+	SQLite   *SQLite
 	CosmosDB *CosmosDB
 }
 
@@ -28,6 +29,15 @@ type Local struct {
 	EnableLogging bool
 }
 
+type SQLite struct {
+	// Path - Path of the database file
+	Path string
+	// SyncWrites - Sync ensures data written to disk on each write, and not only on checkpoints
+	SyncWrites bool
+	// BusyTimeout - How long to wait for the database to be unlocked by other connections
+	BusyTimeout time.Duration
+}
+
 type Postgres struct {
 	ConnectionString      string
 	MaxOpenConnections    int32
@@ -35,6 +45,8 @@ type Postgres struct {
 	ConnectionMaxLifetime time.Duration
 	ScanPageSize          int
 	Metrics               bool
+	// NotifyChanges - Notify changes to the table, required to watch the store
+	NotifyChanges bool
 }
 
 type DynamoDB struct {
@@ -64,6 +76,11 @@ type DynamoDB struct {
 	AwsSecretAccessKey    string
 	HealthCheckInterval   time.Duration
 	MaxConnectionsPerHost int
+
+	// ChangeLog - Append every change to a change log partition, required to watch the store
+	ChangeLog             bool
+	ChangeLogPollInterval time.Duration
+	ChangeLogRetention    time.Duration
 }
 
 type CosmosDB struct {
@@ -93,12 +110,25 @@ func NewConfig(cfg *config.Database) (Config, error) {
 		}
 	}
 
+	if cfg.SQLite != nil {
+		sqlitePath, err := homedir.Expand(cfg.SQLite.Path)
+		if err != nil {
+			return Config{}, fmt.Errorf("parse database sqlite path '%s': %w", cfg.SQLite.Path, err)
+		}
+		p.SQLite = &SQLite{
+			Path:        sqlitePath,
+			SyncWrites:  cfg.SQLite.SyncWrites,
+			BusyTimeout: cfg.SQLite.BusyTimeout,
+		}
+	}
+
 	if cfg.Postgres != nil {
 		p.Postgres = &Postgres{
 			ConnectionString:      cfg.Postgres.ConnectionString.SecureValue(),
 			MaxIdleConnections:    cfg.Postgres.MaxIdleConnections,
 			MaxOpenConnections:    cfg.Postgres.MaxOpenConnections,
 			ConnectionMaxLifetime: cfg.Postgres.ConnectionMaxLifetime,
+			NotifyChanges:         cfg.Postgres.NotifyChanges,
 		}
 	}
 
@@ -114,6 +144,9 @@ func NewConfig(cfg *config.Database) (Config, error) {
 			HealthCheckInterval:   cfg.DynamoDB.HealthCheckInterval,
 			MaxAttempts:           cfg.DynamoDB.MaxAttempts,
 			MaxConnectionsPerHost: cfg.DynamoDB.MaxConnections,
+			ChangeLog:             cfg.DynamoDB.ChangeLog,
+			ChangeLogPollInterval: cfg.DynamoDB.ChangeLogPollInterval,
+			ChangeLogRetention:    cfg.DynamoDB.ChangeLogRetention,
 		}
 	}
 
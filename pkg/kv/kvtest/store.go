@@ -8,6 +8,7 @@ import (
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/go-multierror"
 	nanoid "github.com/matoous/go-nanoid/v2"
@@ -57,6 +58,9 @@ func DriverTest(t *testing.T, ms MakeStore) {
 	t.Run("Store_SetIf", func(t *testing.T) { testStoreSetIf(t, ms) })
 	t.Run("Store_Delete", func(t *testing.T) { testStoreDelete(t, ms) })
 	t.Run("Store_Scan", func(t *testing.T) { testStoreScan(t, ms) })
+	t.Run("Store_Transact", func(t *testing.T) { testStoreTransact(t, ms) })
+	t.Run("Store_ListPartitions", func(t *testing.T) { testStoreListPartitions(t, ms) })
+	t.Run("Store_Watch", func(t *testing.T) { testStoreWatch(t, ms) })
 	t.Run("Store_MissingArgument", func(t *testing.T) { testStoreMissingArgument(t, ms) })
 	t.Run("Store_ContextCancelled", func(t *testing.T) { testStoreContextCancelled(t, ms) })
 	t.Run("ScanPrefix", func(t *testing.T) { testScanPrefix(t, ms) })
@@ -349,6 +353,204 @@ func testStoreSetIf(t *testing.T, ms MakeStore) {
 	})
 }
 
+func testStoreTransact(t *testing.T, ms MakeStore) {
+	ctx := context.Background()
+	store := ms(t, ctx)
+	defer store.Close()
+	partitionKey := []byte(testPartitionKey)
+
+	requireValue := func(t *testing.T, key, expected []byte) {
+		t.Helper()
+		res, err := store.Get(ctx, partitionKey, key)
+		if expected == nil {
+			require.ErrorIs(t, err, kv.ErrNotFound, "key=%s", key)
+			return
+		}
+		require.NoError(t, err, "key=%s", key)
+		require.Equal(t, expected, res.Value, "key=%s", key)
+	}
+
+	t.Run("apply", func(t *testing.T) {
+		setKey, updateKey, deleteKey := uniqueKey("txn-set"), uniqueKey("txn-update"), uniqueKey("txn-delete")
+		require.NoError(t, store.Set(ctx, partitionKey, updateKey, []byte("v1")))
+		require.NoError(t, store.Set(ctx, partitionKey, deleteKey, []byte("v1")))
+		err := kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+			kv.TxnSet(setKey, []byte("v")),
+			kv.TxnSet(updateKey, []byte("v2")),
+			kv.TxnDelete(deleteKey),
+		})
+		require.NoError(t, err)
+		requireValue(t, setKey, []byte("v"))
+		requireValue(t, updateKey, []byte("v2"))
+		requireValue(t, deleteKey, nil)
+	})
+
+	t.Run("predicates", func(t *testing.T) {
+		newKey, existsKey, valueKey := uniqueKey("txn-pred-new"), uniqueKey("txn-pred-exists"), uniqueKey("txn-pred-value")
+		deleteKey, missingKey := uniqueKey("txn-pred-delete"), uniqueKey("txn-pred-missing")
+		require.NoError(t, store.Set(ctx, partitionKey, existsKey, []byte("v1")))
+		require.NoError(t, store.Set(ctx, partitionKey, valueKey, []byte("v1")))
+		require.NoError(t, store.Set(ctx, partitionKey, deleteKey, []byte("v1")))
+		err := kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+			kv.TxnSetIf(newKey, []byte("v"), nil),
+			kv.TxnSetIf(existsKey, []byte("v2"), kv.PrecondConditionalExists),
+			kv.TxnSetIf(valueKey, []byte("v2"), []byte("v1")),
+			kv.TxnDeleteIf(deleteKey, []byte("v1")),
+			kv.TxnDeleteIf(missingKey, nil),
+		})
+		require.NoError(t, err)
+		requireValue(t, newKey, []byte("v"))
+		requireValue(t, existsKey, []byte("v2"))
+		requireValue(t, valueKey, []byte("v2"))
+		requireValue(t, deleteKey, nil)
+		requireValue(t, missingKey, nil)
+	})
+
+	t.Run("fail_predicate", func(t *testing.T) {
+		predicates := map[string]kv.Predicate{
+			"nil":    nil,
+			"exists": kv.PrecondConditionalExists,
+			"value":  []byte("other"),
+		}
+		for name, pred := range predicates {
+			t.Run(name, func(t *testing.T) {
+				setKey, condKey := uniqueKey("txn-fail-set-"+name), uniqueKey("txn-fail-cond-"+name)
+				if pred != kv.PrecondConditionalExists {
+					require.NoError(t, store.Set(ctx, partitionKey, condKey, []byte("v1")))
+				}
+				err := kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+					kv.TxnSet(setKey, []byte("v")),
+					kv.TxnSetIf(condKey, []byte("v2"), pred),
+				})
+				require.ErrorIs(t, err, kv.ErrPredicateFailed)
+				if kv.SupportsTransactions(store) {
+					requireValue(t, setKey, nil)
+				}
+			})
+		}
+	})
+
+	t.Run("fail_delete_predicate", func(t *testing.T) {
+		setKey, condKey := uniqueKey("txn-fail-delete-set"), uniqueKey("txn-fail-delete-cond")
+		require.NoError(t, store.Set(ctx, partitionKey, condKey, []byte("v1")))
+		err := kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+			kv.TxnSet(setKey, []byte("v")),
+			kv.TxnDeleteIf(condKey, []byte("other")),
+		})
+		require.ErrorIs(t, err, kv.ErrPredicateFailed)
+		requireValue(t, condKey, []byte("v1"))
+		if kv.SupportsTransactions(store) {
+			requireValue(t, setKey, nil)
+		}
+	})
+
+	t.Run("invalid", func(t *testing.T) {
+		key := uniqueKey("txn-invalid")
+		err := kv.Transact(ctx, store, nil, []kv.TxnOp{kv.TxnSet(key, []byte("v"))})
+		require.ErrorIs(t, err, kv.ErrMissingPartitionKey)
+		err = kv.Transact(ctx, store, partitionKey, []kv.TxnOp{kv.TxnSet(nil, []byte("v"))})
+		require.ErrorIs(t, err, kv.ErrMissingKey)
+		err = kv.Transact(ctx, store, partitionKey, []kv.TxnOp{kv.TxnSet(key, []byte("v1")), kv.TxnDelete(key)})
+		require.ErrorIs(t, err, kv.ErrDuplicateKey)
+		ops := make([]kv.TxnOp, kv.MaxTransactionOps+1)
+		for i := range ops {
+			ops[i] = kv.TxnSet(uniqueKey(fmt.Sprintf("txn-too-big-%d", i)), []byte("v"))
+		}
+		err = kv.Transact(ctx, store, partitionKey, ops)
+		require.ErrorIs(t, err, kv.ErrBatchSizeTooBig)
+		requireValue(t, key, nil)
+	})
+}
+
+func testStoreWatch(t *testing.T, ms MakeStore) {
+	ctx := context.Background()
+	store := ms(t, ctx)
+	defer store.Close()
+
+	watchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	partitionKey := uniqueKey("watch")
+	events := make(chan kv.WatchEvent, 100) //nolint:mnd
+	err := kv.Watch(watchCtx, store, partitionKey, []byte("watched/"), func(event kv.WatchEvent) {
+		events <- event
+	})
+	if errors.Is(err, kv.ErrWatchNotSupported) {
+		t.Skip("store does not watch")
+	}
+	require.NoError(t, err)
+
+	require.NoError(t, store.Set(ctx, partitionKey, []byte("watched/a"), []byte("one")))
+	require.NoError(t, store.Set(ctx, partitionKey, []byte("other/a"), []byte("one")))
+	require.NoError(t, store.Set(ctx, uniqueKey("watch-other"), []byte("watched/a"), []byte("one")))
+	require.NoError(t, store.SetIf(ctx, partitionKey, []byte("watched/a"), []byte("two"), []byte("one")))
+	require.ErrorIs(t, store.SetIf(ctx, partitionKey, []byte("watched/a"), []byte("three"), []byte("one")), kv.ErrPredicateFailed)
+	require.NoError(t, kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+		kv.TxnSet([]byte("watched/b"), []byte("one")),
+		kv.TxnSet([]byte("other/b"), []byte("one")),
+	}))
+	require.NoError(t, store.Delete(ctx, partitionKey, []byte("watched/a")))
+
+	expected := []kv.WatchEvent{
+		{PartitionKey: partitionKey, Key: []byte("watched/a")},
+		{PartitionKey: partitionKey, Key: []byte("watched/a")},
+		{PartitionKey: partitionKey, Key: []byte("watched/b")},
+		{PartitionKey: partitionKey, Key: []byte("watched/a"), Deleted: true},
+	}
+	const timeout = 10 * time.Second
+	var received []kv.WatchEvent
+	for len(received) < len(expected) {
+		select {
+		case event := <-events:
+			if event.Key == nil {
+				// changes were missed, for instance while connecting
+				continue
+			}
+			received = append(received, event)
+		case <-time.After(timeout):
+			t.Fatalf("received %d of %d expected events: %v", len(received), len(expected), received)
+		}
+	}
+	require.Equal(t, expected, received)
+
+	// no more events after the watch ends
+	cancel()
+	require.NoError(t, store.Set(ctx, partitionKey, []byte("watched/c"), []byte("one")))
+	select {
+	case event := <-events:
+		if event.Key != nil {
+			t.Fatalf("unexpected event after watch ended: %v", event)
+		}
+	case <-time.After(100 * time.Millisecond): //nolint:mnd
+	}
+}
+
+func testStoreListPartitions(t *testing.T, ms MakeStore) {
+	ctx := context.Background()
+	store := ms(t, ctx)
+	defer store.Close()
+
+	expected := [][]byte{uniqueKey("partition-b"), uniqueKey("partition-a"), uniqueKey("partition-a-b")}
+	for _, partitionKey := range expected {
+		setupSampleData(t, ctx, store, string(partitionKey), "list", 3)
+	}
+	emptied := uniqueKey("partition-emptied")
+	require.NoError(t, store.Set(ctx, emptied, []byte("key"), []byte("value")))
+	require.NoError(t, store.Delete(ctx, emptied, []byte("key")))
+
+	partitions, err := kv.ListPartitions(ctx, store)
+	if errors.Is(err, kv.ErrListPartitionsNotSupported) {
+		t.Skip("store does not list partitions")
+	}
+	require.NoError(t, err)
+	var listed [][]byte
+	for _, partitionKey := range partitions {
+		if bytes.HasPrefix(partitionKey, uniqueKey("partition-")) {
+			listed = append(listed, partitionKey)
+		}
+	}
+	require.Equal(t, [][]byte{uniqueKey("partition-a"), uniqueKey("partition-a-b"), uniqueKey("partition-b")}, listed)
+}
+
 func testStoreScan(t *testing.T, ms MakeStore) {
 	ctx := context.Background()
 	store := ms(t, ctx)
@@ -32,6 +32,7 @@ type Store struct {
 	prefetchSize int
 	refCount     int
 	path         string
+	hub          kv.WatchHub
 }
 
 func (s *Store) Get(ctx context.Context, partitionKey, key []byte) (*kv.ValueWithPredicate, error) {
@@ -100,6 +101,7 @@ func (s *Store) Set(ctx context.Context, partitionKey, key, value []byte) error
 		return err
 	}
 	log.WithField("took", time.Since(start)).Trace("done setting value")
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
 	return nil
 }
 
@@ -157,7 +159,9 @@ func (s *Store) SetIf(ctx context.Context, partitionKey, key, value []byte, valu
 	}
 	took := time.Since(start)
 	log.WithField("took", took).Trace("operation complete")
-
+	if err == nil {
+		s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
+	}
 	return err
 }
 
@@ -187,9 +191,68 @@ func (s *Store) Delete(ctx context.Context, partitionKey, key []byte) error {
 		return err
 	}
 	log.Trace("operation complete")
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key, Deleted: true})
 	return nil
 }
 
+func (s *Store) Transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	start := time.Now()
+	log := s.logger.
+		WithField("partition_key", string(partitionKey)).
+		WithField("ops", len(ops)).
+		WithField("op", "transact").
+		WithContext(ctx)
+	log.Trace("performing operation")
+	if err := kv.ValidateTxnOps(partitionKey, ops); err != nil {
+		log.WithError(err).Warn("invalid transaction")
+		return err
+	}
+
+	err := s.db.Update(func(txn *badger.Txn) error {
+		for _, op := range ops {
+			k := composeKey(partitionKey, op.Key)
+			if op.Conditional {
+				var value []byte
+				item, err := txn.Get(k)
+				switch {
+				case err == nil:
+					value, err = item.ValueCopy(nil)
+					if err != nil {
+						log.WithError(err).Error("could not get byte value for predicate")
+						return err
+					}
+				case !errors.Is(err, badger.ErrKeyNotFound):
+					log.WithError(err).Error("could not get key for predicate")
+					return err
+				}
+				if !kv.MatchPredicate(op.Predicate, value) {
+					log.WithField("key", string(k)).Trace("predicate condition failed")
+					return kv.ErrPredicateFailed
+				}
+			}
+			var err error
+			if op.IsDelete() {
+				err = txn.Delete(k)
+			} else {
+				err = txn.Set(k, op.Value)
+			}
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if errors.Is(err, badger.ErrConflict) { // Return predicate failed on transaction conflict - to retry
+		log.WithError(err).Trace("transaction conflict")
+		err = kv.ErrPredicateFailed
+	}
+	log.WithField("took", time.Since(start)).WithError(err).Trace("operation complete")
+	if err == nil {
+		s.hub.NotifyTxn(partitionKey, ops)
+	}
+	return err
+}
+
 func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
 	log := s.logger.WithFields(logging.Fields{
 		"partition_key": string(partitionKey),
@@ -223,6 +286,47 @@ func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOp
 	}, nil
 }
 
+// ListPartitions returns the partitions of the keys in the database, composed of the partition
+// key and the key separated by the first path delimiter.
+func (s *Store) ListPartitions(ctx context.Context) ([][]byte, error) {
+	log := s.logger.WithField("op", "list_partitions").WithContext(ctx)
+	log.Trace("performing operation")
+	var partitions [][]byte
+	err := s.db.View(func(txn *badger.Txn) error {
+		opts := badger.DefaultIteratorOptions
+		opts.PrefetchValues = false
+		iter := txn.NewIterator(opts)
+		defer iter.Close()
+		for iter.Rewind(); iter.Valid(); {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			k := iter.Item().Key()
+			idx := bytes.IndexByte(k, kv.PathDelimiter[0])
+			if idx <= 0 {
+				iter.Next()
+				continue
+			}
+			partitionKey := bytes.Clone(k[:idx])
+			partitions = append(partitions, partitionKey)
+			// skip the rest of the partition: the delimiter is followed by all of its keys
+			next := partitionRange(partitionKey)
+			next[len(next)-1]++
+			iter.Seek(next)
+		}
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error("failed to list partitions")
+		return nil, err
+	}
+	return partitions, nil
+}
+
+func (s *Store) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	return s.hub.Watch(ctx, partitionKey, prefix, fn)
+}
+
 func (s *Store) Close() {
 	driverLock.Lock()
 	defer driverLock.Unlock()
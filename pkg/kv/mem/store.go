@@ -20,7 +20,8 @@ type PartitionMap map[string]kv.Entry
 type Store struct {
 	m map[string]PartitionMap
 
-	mu sync.RWMutex
+	mu  sync.RWMutex
+	hub kv.WatchHub
 }
 
 type EntriesIterator struct {
@@ -84,10 +85,10 @@ func (s *Store) Set(_ context.Context, partitionKey, key, value []byte) error {
 		return kv.ErrMissingValue
 	}
 	s.mu.Lock()
-	defer s.mu.Unlock()
-
 	s.internalSet(partitionKey, key, value)
+	s.mu.Unlock()
 
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
 	return nil
 }
 
@@ -113,6 +114,14 @@ func (s *Store) SetIf(_ context.Context, partitionKey, key, value []byte, valueP
 	if value == nil {
 		return kv.ErrMissingValue
 	}
+	if err := s.setIf(partitionKey, key, value, valuePredicate); err != nil {
+		return err
+	}
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
+	return nil
+}
+
+func (s *Store) setIf(partitionKey, key, value []byte, valuePredicate kv.Predicate) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
@@ -147,14 +156,52 @@ func (s *Store) Delete(_ context.Context, partitionKey, key []byte) error {
 	if len(key) == 0 {
 		return kv.ErrMissingKey
 	}
+	s.mu.Lock()
+	sKey := encodeKey(key)
+	_, ok := s.m[string(partitionKey)][sKey]
+	delete(s.m[string(partitionKey)], sKey)
+	s.mu.Unlock()
+
+	if ok {
+		s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key, Deleted: true})
+	}
+	return nil
+}
+
+func (s *Store) Transact(_ context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	if err := kv.ValidateTxnOps(partitionKey, ops); err != nil {
+		return err
+	}
+	if err := s.transact(partitionKey, ops); err != nil {
+		return err
+	}
+	s.hub.NotifyTxn(partitionKey, ops)
+	return nil
+}
+
+func (s *Store) transact(partitionKey []byte, ops []kv.TxnOp) error {
 	s.mu.Lock()
 	defer s.mu.Unlock()
 
-	sKey := encodeKey(key)
-	if _, ok := s.m[string(partitionKey)][sKey]; !ok {
-		return nil
+	for _, op := range ops {
+		if !op.Conditional {
+			continue
+		}
+		var value []byte
+		if curr, ok := s.m[string(partitionKey)][encodeKey(op.Key)]; ok {
+			value = curr.Value
+		}
+		if !kv.MatchPredicate(op.Predicate, value) {
+			return fmt.Errorf("key=%v: %w", op.Key, kv.ErrPredicateFailed)
+		}
+	}
+	for _, op := range ops {
+		if op.IsDelete() {
+			delete(s.m[string(partitionKey)], encodeKey(op.Key))
+		} else {
+			s.internalSet(partitionKey, op.Key, op.Value)
+		}
 	}
-	delete(s.m[string(partitionKey)], sKey)
 	return nil
 }
 
@@ -174,6 +221,23 @@ func (s *Store) Scan(_ context.Context, partitionKey []byte, options kv.ScanOpti
 	}, nil
 }
 
+func (s *Store) ListPartitions(_ context.Context) ([][]byte, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	partitions := make([][]byte, 0, len(s.m))
+	for partitionKey, entries := range s.m {
+		if len(entries) > 0 {
+			partitions = append(partitions, []byte(partitionKey))
+		}
+	}
+	return partitions, nil
+}
+
+func (s *Store) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	return s.hub.Watch(ctx, partitionKey, prefix, fn)
+}
+
 func (s *Store) Close() {}
 
 func (e *EntriesIterator) Next() bool {
@@ -89,6 +89,18 @@ func (s *StoreMetricsWrapper) Scan(ctx context.Context, partitionKey []byte, opt
 	return res, err
 }
 
+func (s *StoreMetricsWrapper) Transact(ctx context.Context, partitionKey []byte, ops []TxnOp) error {
+	const operation = "Transact"
+	timer := prometheus.NewTimer(requestDuration.WithLabelValues(s.StoreType, operation))
+	ctx = httputil.SetClientTrace(ctx, s.StoreType)
+	defer timer.ObserveDuration()
+	err := Transact(ctx, s.Store, partitionKey, ops)
+	if err != nil {
+		requestFailures.WithLabelValues(s.StoreType, operation).Inc()
+	}
+	return err
+}
+
 func (s *StoreMetricsWrapper) Close() {
 	timer := prometheus.NewTimer(requestDuration.WithLabelValues(s.StoreType, "Close"))
 	defer timer.ObserveDuration()
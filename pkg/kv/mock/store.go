@@ -181,6 +181,165 @@ func (mr *MockStoreMockRecorder) SetIf(ctx, partitionKey, key, value, valuePredi
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIf", reflect.TypeOf((*MockStore)(nil).SetIf), ctx, partitionKey, key, value, valuePredicate)
 }
 
+// MockTransactionalStore is a mock of TransactionalStore interface.
+type MockTransactionalStore struct {
+	ctrl     *gomock.Controller
+	recorder *MockTransactionalStoreMockRecorder
+}
+
+// MockTransactionalStoreMockRecorder is the mock recorder for MockTransactionalStore.
+type MockTransactionalStoreMockRecorder struct {
+	mock *MockTransactionalStore
+}
+
+// NewMockTransactionalStore creates a new mock instance.
+func NewMockTransactionalStore(ctrl *gomock.Controller) *MockTransactionalStore {
+	mock := &MockTransactionalStore{ctrl: ctrl}
+	mock.recorder = &MockTransactionalStoreMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockTransactionalStore) EXPECT() *MockTransactionalStoreMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockTransactionalStore) Close() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Close")
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockTransactionalStoreMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockTransactionalStore)(nil).Close))
+}
+
+// Delete mocks base method.
+func (m *MockTransactionalStore) Delete(ctx context.Context, partitionKey, key []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Delete", ctx, partitionKey, key)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Delete indicates an expected call of Delete.
+func (mr *MockTransactionalStoreMockRecorder) Delete(ctx, partitionKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Delete", reflect.TypeOf((*MockTransactionalStore)(nil).Delete), ctx, partitionKey, key)
+}
+
+// Get mocks base method.
+func (m *MockTransactionalStore) Get(ctx context.Context, partitionKey, key []byte) (*kv.ValueWithPredicate, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Get", ctx, partitionKey, key)
+	ret0, _ := ret[0].(*kv.ValueWithPredicate)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Get indicates an expected call of Get.
+func (mr *MockTransactionalStoreMockRecorder) Get(ctx, partitionKey, key interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Get", reflect.TypeOf((*MockTransactionalStore)(nil).Get), ctx, partitionKey, key)
+}
+
+// Scan mocks base method.
+func (m *MockTransactionalStore) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Scan", ctx, partitionKey, options)
+	ret0, _ := ret[0].(kv.EntriesIterator)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockTransactionalStoreMockRecorder) Scan(ctx, partitionKey, options interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*MockTransactionalStore)(nil).Scan), ctx, partitionKey, options)
+}
+
+// Set mocks base method.
+func (m *MockTransactionalStore) Set(ctx context.Context, partitionKey, key, value []byte) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Set", ctx, partitionKey, key, value)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Set indicates an expected call of Set.
+func (mr *MockTransactionalStoreMockRecorder) Set(ctx, partitionKey, key, value interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Set", reflect.TypeOf((*MockTransactionalStore)(nil).Set), ctx, partitionKey, key, value)
+}
+
+// SetIf mocks base method.
+func (m *MockTransactionalStore) SetIf(ctx context.Context, partitionKey, key, value []byte, valuePredicate kv.Predicate) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SetIf", ctx, partitionKey, key, value, valuePredicate)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// SetIf indicates an expected call of SetIf.
+func (mr *MockTransactionalStoreMockRecorder) SetIf(ctx, partitionKey, key, value, valuePredicate interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SetIf", reflect.TypeOf((*MockTransactionalStore)(nil).SetIf), ctx, partitionKey, key, value, valuePredicate)
+}
+
+// Transact mocks base method.
+func (m *MockTransactionalStore) Transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Transact", ctx, partitionKey, ops)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Transact indicates an expected call of Transact.
+func (mr *MockTransactionalStoreMockRecorder) Transact(ctx, partitionKey, ops interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Transact", reflect.TypeOf((*MockTransactionalStore)(nil).Transact), ctx, partitionKey, ops)
+}
+
+// MockPartitionLister is a mock of PartitionLister interface.
+type MockPartitionLister struct {
+	ctrl     *gomock.Controller
+	recorder *MockPartitionListerMockRecorder
+}
+
+// MockPartitionListerMockRecorder is the mock recorder for MockPartitionLister.
+type MockPartitionListerMockRecorder struct {
+	mock *MockPartitionLister
+}
+
+// NewMockPartitionLister creates a new mock instance.
+func NewMockPartitionLister(ctrl *gomock.Controller) *MockPartitionLister {
+	mock := &MockPartitionLister{ctrl: ctrl}
+	mock.recorder = &MockPartitionListerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockPartitionLister) EXPECT() *MockPartitionListerMockRecorder {
+	return m.recorder
+}
+
+// ListPartitions mocks base method.
+func (m *MockPartitionLister) ListPartitions(ctx context.Context) ([][]byte, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ListPartitions", ctx)
+	ret0, _ := ret[0].([][]byte)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ListPartitions indicates an expected call of ListPartitions.
+func (mr *MockPartitionListerMockRecorder) ListPartitions(ctx interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ListPartitions", reflect.TypeOf((*MockPartitionLister)(nil).ListPartitions), ctx)
+}
+
 // MockEntriesIterator is a mock of EntriesIterator interface.
 type MockEntriesIterator struct {
 	ctrl     *gomock.Controller
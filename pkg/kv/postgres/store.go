@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"hash/fnv"
 	"strconv"
+	"sync"
 
 	"github.com/IBM/pgxpoolprometheus"
 	"github.com/georgysavva/scany/v2/pgxscan"
@@ -25,6 +26,11 @@ type Store struct {
 	Params         *Params
 	TableSanitized string
 	collector      prometheus.Collector
+
+	hub          kv.WatchHub
+	listenMu     sync.Mutex
+	listenCancel context.CancelFunc
+	listenDone   chan struct{}
 }
 
 type EntriesIterator struct {
@@ -88,7 +94,7 @@ func (d *Driver) Open(ctx context.Context, kvParams kvparams.Config) (kv.Store,
 	}
 
 	params := parseStoreConfig(config.ConnConfig.RuntimeParams, kvParams.Postgres)
-	err = setupKeyValueDatabase(ctx, conn, params.TableName, params.PartitionsAmount)
+	err = setupKeyValueDatabase(ctx, conn, params.TableName, params.PartitionsAmount, params.NotifyChanges)
 	if err != nil {
 		return nil, fmt.Errorf("%w: %s", kv.ErrSetupFailed, err)
 	}
@@ -136,6 +142,7 @@ type Params struct {
 	PartitionsAmount   int
 	ScanPageSize       int
 	Metrics            bool
+	NotifyChanges      bool
 }
 
 func parseStoreConfig(runtimeParams map[string]string, pgParams *kvparams.Postgres) *Params {
@@ -144,6 +151,7 @@ func parseStoreConfig(runtimeParams map[string]string, pgParams *kvparams.Postgr
 		PartitionsAmount: DefaultPartitions,
 		ScanPageSize:     DefaultScanPageSize,
 		Metrics:          pgParams.Metrics,
+		NotifyChanges:    pgParams.NotifyChanges,
 	}
 	if tableName, ok := runtimeParams[paramTableName]; ok {
 		p.TableName = tableName
@@ -157,7 +165,7 @@ func parseStoreConfig(runtimeParams map[string]string, pgParams *kvparams.Postgr
 }
 
 // setupKeyValueDatabase setup everything required to enable kv over postgres
-func setupKeyValueDatabase(ctx context.Context, conn *pgxpool.Conn, table string, partitionsAmount int) (err error) {
+func setupKeyValueDatabase(ctx context.Context, conn *pgxpool.Conn, table string, partitionsAmount int, notifyChanges bool) (err error) {
 	var aid string
 	aid, err = generateAdvisoryLockID("lakefs:" + table)
 	if err != nil {
@@ -203,7 +211,10 @@ func setupKeyValueDatabase(ctx context.Context, conn *pgxpool.Conn, table string
 	// view of kv table to help humans select from table (same as table with _v as suffix)
 	_, err = conn.Exec(ctx, `CREATE OR REPLACE VIEW `+pgx.Identifier{table + "_v"}.Sanitize()+
 		` AS SELECT ENCODE(partition_key, 'escape') AS partition_key, ENCODE(key, 'escape') AS key, value FROM `+tableSanitize)
-	return err
+	if err != nil {
+		return err
+	}
+	return setupNotifyTrigger(ctx, conn, table, notifyChanges)
 }
 
 func generateAdvisoryLockID(name string) (string, error) {
@@ -313,6 +324,73 @@ func (s *Store) Delete(ctx context.Context, partitionKey, key []byte) error {
 	return nil
 }
 
+func (s *Store) Transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	if err := kv.ValidateTxnOps(partitionKey, ops); err != nil {
+		return err
+	}
+	err := pgx.BeginFunc(ctx, s.Pool, func(tx pgx.Tx) error {
+		for _, op := range ops {
+			if err := s.transactOp(ctx, tx, partitionKey, op); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil && !errors.Is(err, kv.ErrPredicateFailed) {
+		return fmt.Errorf("postgres transact: %w", err)
+	}
+	return err
+}
+
+// transactOp applies op in tx, returning ErrPredicateFailed if its predicate fails
+func (s *Store) transactOp(ctx context.Context, tx pgx.Tx, partitionKey []byte, op kv.TxnOp) error {
+	table := s.Params.SanitizedTableName
+	var (
+		res pgconn.CommandTag
+		err error
+	)
+	switch {
+	case !op.IsDelete() && !op.Conditional:
+		_, err = tx.Exec(ctx, `INSERT INTO `+table+`(partition_key,key,value) VALUES($1,$2,$3)
+			ON CONFLICT (partition_key,key) DO UPDATE SET value = $3`, partitionKey, op.Key, op.Value)
+		return err
+	case op.IsDelete() && !op.Conditional:
+		_, err = tx.Exec(ctx, `DELETE FROM `+table+` WHERE partition_key=$1 AND key=$2`, partitionKey, op.Key)
+		return err
+	case op.Predicate == nil && !op.IsDelete():
+		res, err = tx.Exec(ctx, `INSERT INTO `+table+`(partition_key,key,value) VALUES($1,$2,$3) ON CONFLICT DO NOTHING`, partitionKey, op.Key, op.Value)
+	case op.Predicate == nil: // delete of a key that must not exist is only a check
+		res, err = tx.Exec(ctx, `SELECT 1 WHERE NOT EXISTS (SELECT 1 FROM `+table+` WHERE partition_key=$1 AND key=$2)`, partitionKey, op.Key)
+	case op.Predicate == kv.PrecondConditionalExists && !op.IsDelete():
+		res, err = tx.Exec(ctx, `UPDATE `+table+` SET value=$3 WHERE key=$2 AND partition_key=$1`, partitionKey, op.Key, op.Value)
+	case op.Predicate == kv.PrecondConditionalExists:
+		res, err = tx.Exec(ctx, `DELETE FROM `+table+` WHERE partition_key=$1 AND key=$2`, partitionKey, op.Key)
+	case !op.IsDelete():
+		res, err = tx.Exec(ctx, `UPDATE `+table+` SET value=$3 WHERE key=$2 AND partition_key=$1 AND value=$4`, partitionKey, op.Key, op.Value, op.Predicate.([]byte))
+	default:
+		res, err = tx.Exec(ctx, `DELETE FROM `+table+` WHERE partition_key=$1 AND key=$2 AND value=$3`, partitionKey, op.Key, op.Predicate.([]byte))
+	}
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() != 1 {
+		return fmt.Errorf("key=%v: %w", op.Key, kv.ErrPredicateFailed)
+	}
+	return nil
+}
+
+func (s *Store) ListPartitions(ctx context.Context) ([][]byte, error) {
+	rows, err := s.Pool.Query(ctx, `SELECT DISTINCT partition_key FROM `+s.Params.SanitizedTableName)
+	if err != nil {
+		return nil, fmt.Errorf("postgres list partitions: %w", err)
+	}
+	partitions, err := pgx.CollectRows(rows, pgx.RowTo[[]byte])
+	if err != nil {
+		return nil, fmt.Errorf("postgres list partitions: %w", err)
+	}
+	return partitions, nil
+}
+
 func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
 	if len(partitionKey) == 0 {
 		return nil, kv.ErrMissingPartitionKey
@@ -339,6 +417,7 @@ func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOp
 }
 
 func (s *Store) Close() {
+	s.stopListening()
 	if s.collector != nil {
 		prometheus.Unregister(s.collector)
 		s.collector = nil
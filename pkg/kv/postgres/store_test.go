@@ -36,7 +36,7 @@ func TestPostgresKV(t *testing.T) {
 
 		store, err := kv.Open(ctx, kvparams.Config{
 			Type:     postgres.DriverName,
-			Postgres: &kvparams.Postgres{ConnectionString: fmt.Sprintf("%s&search_path=%s", databaseURI, url.PathEscape(schemaName)), ScanPageSize: kvtest.MaxPageSize},
+			Postgres: &kvparams.Postgres{ConnectionString: fmt.Sprintf("%s&search_path=%s", databaseURI, url.PathEscape(schemaName)), ScanPageSize: kvtest.MaxPageSize, NotifyChanges: true},
 		})
 		if err != nil {
 			t.Fatalf("failed to open kv '%s' store: %s", postgres.DriverName, err)
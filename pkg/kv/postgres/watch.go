@@ -0,0 +1,205 @@
+package postgres
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
+)
+
+const (
+	listenReconnectMinDelay = 100 * time.Millisecond
+	listenReconnectMaxDelay = 30 * time.Second
+
+	// maxNotifyPayload is below the 8000 bytes limit of postgres on the payload of a notification
+	maxNotifyPayload = 7900
+)
+
+func notifyChannel(table string) string {
+	return table + "_changes"
+}
+
+func notifyFunctionName(table string) string {
+	return table + "_notify_changes"
+}
+
+func notifyTriggerName(table string) string {
+	return table + "_notify_changes"
+}
+
+// setupNotifyTrigger creates a trigger that notifies notifyChannel of every change to the
+// table if notify is set, and drops it otherwise.  Notifications are sent on commit, so
+// listeners only see changes of committed transactions.
+func setupNotifyTrigger(ctx context.Context, conn *pgxpool.Conn, table string, notify bool) error {
+	tableSanitized := pgx.Identifier{table}.Sanitize()
+	triggerSanitized := pgx.Identifier{notifyTriggerName(table)}.Sanitize()
+	var exists bool
+	err := conn.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM pg_trigger WHERE tgname = $1 AND tgrelid = $2::regclass)`,
+		notifyTriggerName(table), tableSanitized).Scan(&exists)
+	if err != nil {
+		return err
+	}
+	if !notify {
+		if exists {
+			_, err = conn.Exec(ctx, `DROP TRIGGER IF EXISTS `+triggerSanitized+` ON `+tableSanitized)
+		}
+		return err
+	}
+
+	// payload is "<operation>:<hex partition key>:<hex key>", without the key (or the
+	// partition key) if too long to notify
+	channel := strings.ReplaceAll(notifyChannel(table), "'", "''")
+	functionSanitized := pgx.Identifier{notifyFunctionName(table)}.Sanitize()
+	_, err = conn.Exec(ctx, `CREATE OR REPLACE FUNCTION `+functionSanitized+`() RETURNS trigger AS $$
+	DECLARE
+		r RECORD;
+		payload TEXT;
+	BEGIN
+		IF TG_OP = 'DELETE' THEN r := OLD; ELSE r := NEW; END IF;
+		payload := TG_OP || ':' || encode(r.partition_key, 'hex') || ':' || encode(r.key, 'hex');
+		IF octet_length(payload) > `+fmt.Sprint(maxNotifyPayload)+` THEN
+			payload := TG_OP || ':' || encode(r.partition_key, 'hex') || ':';
+		END IF;
+		IF octet_length(payload) > `+fmt.Sprint(maxNotifyPayload)+` THEN
+			payload := TG_OP || '::';
+		END IF;
+		PERFORM pg_notify('`+channel+`', payload);
+		RETURN NULL;
+	END;
+	$$ LANGUAGE plpgsql`)
+	if err != nil || exists {
+		return err
+	}
+	_, err = conn.Exec(ctx, `CREATE TRIGGER `+triggerSanitized+` AFTER INSERT OR UPDATE OR DELETE ON `+tableSanitized+
+		` FOR EACH ROW EXECUTE FUNCTION `+functionSanitized+`()`)
+	return err
+}
+
+// parseNotification returns the event of a notification payload.  It returns false if the
+// payload does not tell the partition that changed.
+func parseNotification(payload string) (kv.WatchEvent, bool) {
+	const payloadParts = 3
+	parts := strings.SplitN(payload, ":", payloadParts)
+	if len(parts) != payloadParts || parts[1] == "" {
+		return kv.WatchEvent{}, false
+	}
+	partitionKey, err := hex.DecodeString(parts[1])
+	if err != nil {
+		return kv.WatchEvent{}, false
+	}
+	event := kv.WatchEvent{
+		PartitionKey: partitionKey,
+		Deleted:      parts[0] == "DELETE",
+	}
+	if parts[2] != "" {
+		key, err := hex.DecodeString(parts[2])
+		if err != nil {
+			return kv.WatchEvent{PartitionKey: partitionKey}, true
+		}
+		event.Key = key
+	}
+	return event, true
+}
+
+func (s *Store) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	if !s.Params.NotifyChanges {
+		return kv.ErrWatchNotSupported
+	}
+	if err := s.startListening(ctx); err != nil {
+		return err
+	}
+	return s.hub.Watch(ctx, partitionKey, prefix, fn)
+}
+
+// startListening starts listening to the notifications of the table on a connection taken
+// from the pool, if not listening yet
+func (s *Store) startListening(ctx context.Context) error {
+	s.listenMu.Lock()
+	defer s.listenMu.Unlock()
+	if s.listenCancel != nil {
+		return nil
+	}
+	conn, err := s.listenConn(ctx)
+	if err != nil {
+		return fmt.Errorf("postgres listen: %w", err)
+	}
+	listenCtx, cancel := context.WithCancel(context.Background())
+	s.listenCancel = cancel
+	s.listenDone = make(chan struct{})
+	go s.listen(listenCtx, conn, logging.FromContext(ctx).WithField("store", DriverName))
+	return nil
+}
+
+func (s *Store) listenConn(ctx context.Context) (*pgx.Conn, error) {
+	poolConn, err := s.Pool.Acquire(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// the connection listens until the store closes, so take it out of the pool
+	conn := poolConn.Hijack()
+	_, err = conn.Exec(ctx, `LISTEN `+pgx.Identifier{notifyChannel(s.Params.TableName)}.Sanitize())
+	if err != nil {
+		_ = conn.Close(ctx)
+		return nil, err
+	}
+	return conn, nil
+}
+
+func (s *Store) listen(ctx context.Context, conn *pgx.Conn, log logging.Logger) {
+	defer close(s.listenDone)
+	delay := listenReconnectMinDelay
+	for {
+		notification, err := conn.WaitForNotification(ctx)
+		if err == nil {
+			event, ok := parseNotification(notification.Payload)
+			if ok {
+				s.hub.Notify(event)
+			} else {
+				s.hub.NotifyMissed()
+			}
+			continue
+		}
+		_ = conn.Close(context.Background())
+		if ctx.Err() != nil {
+			return
+		}
+		log.WithError(err).Warn("Lost postgres notifications connection, reconnecting")
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(delay):
+			}
+			conn, err = s.listenConn(ctx)
+			if err == nil {
+				break
+			}
+			if errors.Is(err, context.Canceled) {
+				return
+			}
+			log.WithError(err).Warn("Failed to reconnect postgres notifications connection")
+			delay = min(2*delay, listenReconnectMaxDelay)
+		}
+		delay = listenReconnectMinDelay
+		// changes made while disconnected were not notified
+		s.hub.NotifyMissed()
+	}
+}
+
+func (s *Store) stopListening() {
+	s.listenMu.Lock()
+	defer s.listenMu.Unlock()
+	if s.listenCancel == nil {
+		return
+	}
+	s.listenCancel()
+	<-s.listenDone
+	s.listenCancel = nil
+}
@@ -0,0 +1,102 @@
+package sqlite
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"net/url"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvparams"
+	_ "modernc.org/sqlite"
+)
+
+const (
+	DriverName = "sqlite"
+
+	DefaultBusyTimeout  = 5 * time.Second
+	DefaultScanPageSize = 1000
+
+	tableName = "kv"
+)
+
+var (
+	driverLock = &sync.Mutex{}
+	dbMap      = make(map[string]*Store)
+)
+
+type Driver struct{}
+
+//nolint:gochecknoinits
+func init() {
+	kv.Register(DriverName, &Driver{})
+}
+
+// Open opens the database file of the given path, creating it if missing.  Stores opened on
+// the same path share the database, so that they see each other's changes when watching.
+func (d *Driver) Open(ctx context.Context, kvParams kvparams.Config) (kv.Store, error) {
+	params := kvParams.SQLite
+	if params == nil || params.Path == "" {
+		return nil, fmt.Errorf("missing %s settings: %w", DriverName, kv.ErrDriverConfiguration)
+	}
+
+	driverLock.Lock()
+	defer driverLock.Unlock()
+	store, ok := dbMap[params.Path]
+	if !ok {
+		db, err := sql.Open(DriverName, dataSourceName(params))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %s", kv.ErrDriverConfiguration, err)
+		}
+		if err := db.PingContext(ctx); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("%w: %s", kv.ErrConnectFailed, err)
+		}
+		if err := setupKeyValueDatabase(ctx, db); err != nil {
+			_ = db.Close()
+			return nil, fmt.Errorf("%w: %s", kv.ErrSetupFailed, err)
+		}
+		store = &Store{
+			db:           db,
+			path:         params.Path,
+			scanPageSize: DefaultScanPageSize,
+		}
+		dbMap[params.Path] = store
+	}
+	store.refCount++
+	return store, nil
+}
+
+// dataSourceName returns the name to open the database with.  Every connection uses the
+// write-ahead log, so that readers do not block the writer, and begins transactions with a
+// write lock, so that transactions reading before they write do not deadlock.
+func dataSourceName(params *kvparams.SQLite) string {
+	busyTimeout := params.BusyTimeout
+	if busyTimeout <= 0 {
+		busyTimeout = DefaultBusyTimeout
+	}
+	synchronous := "NORMAL"
+	if params.SyncWrites {
+		synchronous = "FULL"
+	}
+	q := url.Values{}
+	q.Add("_pragma", "busy_timeout("+strconv.FormatInt(busyTimeout.Milliseconds(), 10)+")")
+	q.Add("_pragma", "journal_mode(WAL)")
+	q.Add("_pragma", "synchronous("+synchronous+")")
+	q.Set("_txlock", "immediate")
+	return "file:" + params.Path + "?" + q.Encode()
+}
+
+// setupKeyValueDatabase setup everything required to enable kv over sqlite.  Blobs compare
+// as bytes, so keys are ordered as in other stores.
+func setupKeyValueDatabase(ctx context.Context, db *sql.DB) error {
+	_, err := db.ExecContext(ctx, `CREATE TABLE IF NOT EXISTS `+tableName+` (
+		partition_key BLOB NOT NULL,
+		key BLOB NOT NULL,
+		value BLOB NOT NULL,
+		PRIMARY KEY (partition_key, key)) WITHOUT ROWID`)
+	return err
+}
@@ -0,0 +1,386 @@
+package sqlite
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/pkg/kv"
+)
+
+type Store struct {
+	db           *sql.DB
+	path         string
+	scanPageSize int
+	refCount     int
+	hub          kv.WatchHub
+}
+
+type EntriesIterator struct {
+	ctx          context.Context
+	partitionKey []byte
+	startKey     []byte
+	includeStart bool
+	store        *Store
+	entries      []kv.Entry
+	currEntryIdx int
+	err          error
+	limit        int
+}
+
+func (s *Store) Get(ctx context.Context, partitionKey, key []byte) (*kv.ValueWithPredicate, error) {
+	if len(partitionKey) == 0 {
+		return nil, kv.ErrMissingPartitionKey
+	}
+	if len(key) == 0 {
+		return nil, kv.ErrMissingKey
+	}
+
+	row := s.db.QueryRowContext(ctx, `SELECT value FROM `+tableName+` WHERE partition_key = ? AND key = ?`, partitionKey, key)
+	var val []byte
+	err := row.Scan(&val)
+	if errors.Is(err, sql.ErrNoRows) {
+		return nil, kv.ErrNotFound
+	}
+	if err != nil {
+		return nil, fmt.Errorf("sqlite get: %w", err)
+	}
+	if val == nil {
+		val = []byte{}
+	}
+	return &kv.ValueWithPredicate{
+		Value:     val,
+		Predicate: kv.Predicate(val),
+	}, nil
+}
+
+func (s *Store) Set(ctx context.Context, partitionKey, key, value []byte) error {
+	if len(partitionKey) == 0 {
+		return kv.ErrMissingPartitionKey
+	}
+	if len(key) == 0 {
+		return kv.ErrMissingKey
+	}
+	if value == nil {
+		return kv.ErrMissingValue
+	}
+
+	_, err := s.db.ExecContext(ctx, `INSERT INTO `+tableName+`(partition_key,key,value) VALUES(?1,?2,?3)
+			ON CONFLICT (partition_key,key) DO UPDATE SET value = ?3`, partitionKey, key, value)
+	if err != nil {
+		return fmt.Errorf("sqlite set: %w", err)
+	}
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
+	return nil
+}
+
+func (s *Store) SetIf(ctx context.Context, partitionKey, key, value []byte, valuePredicate kv.Predicate) error {
+	if len(partitionKey) == 0 {
+		return kv.ErrMissingPartitionKey
+	}
+	if len(key) == 0 {
+		return kv.ErrMissingKey
+	}
+	if value == nil {
+		return kv.ErrMissingValue
+	}
+
+	var (
+		res sql.Result
+		err error
+	)
+	switch valuePredicate {
+	case nil: // use insert to make sure there was no previous value before
+		res, err = s.db.ExecContext(ctx, `INSERT INTO `+tableName+`(partition_key,key,value) VALUES(?1,?2,?3) ON CONFLICT DO NOTHING`, partitionKey, key, value)
+
+	case kv.PrecondConditionalExists: // update only if exists
+		res, err = s.db.ExecContext(ctx, `UPDATE `+tableName+` SET value=?3 WHERE partition_key=?1 AND key=?2`, partitionKey, key, value)
+
+	default: // update just in case the previous value was same as predicate value
+		res, err = s.db.ExecContext(ctx, `UPDATE `+tableName+` SET value=?3 WHERE partition_key=?1 AND key=?2 AND value=?4`, partitionKey, key, value, valuePredicate.([]byte))
+	}
+	if err != nil {
+		return fmt.Errorf("sqlite setIf: %w", err)
+	}
+	if err := checkAffected(res); err != nil {
+		return err
+	}
+	s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key})
+	return nil
+}
+
+func (s *Store) Delete(ctx context.Context, partitionKey, key []byte) error {
+	if len(partitionKey) == 0 {
+		return kv.ErrMissingPartitionKey
+	}
+	if len(key) == 0 {
+		return kv.ErrMissingKey
+	}
+	res, err := s.db.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE partition_key=? AND key=?`, partitionKey, key)
+	if err != nil {
+		return fmt.Errorf("sqlite delete: %w", err)
+	}
+	if n, err := res.RowsAffected(); err == nil && n > 0 {
+		s.hub.Notify(kv.WatchEvent{PartitionKey: partitionKey, Key: key, Deleted: true})
+	}
+	return nil
+}
+
+func (s *Store) Transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	if err := kv.ValidateTxnOps(partitionKey, ops); err != nil {
+		return err
+	}
+	err := s.transact(ctx, partitionKey, ops)
+	if err != nil && !errors.Is(err, kv.ErrPredicateFailed) {
+		return fmt.Errorf("sqlite transact: %w", err)
+	}
+	if err == nil {
+		s.hub.NotifyTxn(partitionKey, ops)
+	}
+	return err
+}
+
+func (s *Store) transact(ctx context.Context, partitionKey []byte, ops []kv.TxnOp) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if err := transactOp(ctx, tx, partitionKey, op); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+	}
+	return tx.Commit()
+}
+
+// transactOp applies op in tx, returning ErrPredicateFailed if its predicate fails
+func transactOp(ctx context.Context, tx *sql.Tx, partitionKey []byte, op kv.TxnOp) error {
+	var (
+		res sql.Result
+		err error
+	)
+	switch {
+	case !op.IsDelete() && !op.Conditional:
+		_, err = tx.ExecContext(ctx, `INSERT INTO `+tableName+`(partition_key,key,value) VALUES(?1,?2,?3)
+			ON CONFLICT (partition_key,key) DO UPDATE SET value = ?3`, partitionKey, op.Key, op.Value)
+		return err
+	case op.IsDelete() && !op.Conditional:
+		_, err = tx.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE partition_key=? AND key=?`, partitionKey, op.Key)
+		return err
+	case op.Predicate == nil && !op.IsDelete():
+		res, err = tx.ExecContext(ctx, `INSERT INTO `+tableName+`(partition_key,key,value) VALUES(?1,?2,?3) ON CONFLICT DO NOTHING`, partitionKey, op.Key, op.Value)
+	case op.Predicate == nil: // delete of a key that must not exist is only a check
+		var exists bool
+		err = tx.QueryRowContext(ctx, `SELECT EXISTS (SELECT 1 FROM `+tableName+` WHERE partition_key=? AND key=?)`, partitionKey, op.Key).Scan(&exists)
+		if err != nil {
+			return err
+		}
+		if exists {
+			return fmt.Errorf("key=%v: %w", op.Key, kv.ErrPredicateFailed)
+		}
+		return nil
+	case op.Predicate == kv.PrecondConditionalExists && !op.IsDelete():
+		res, err = tx.ExecContext(ctx, `UPDATE `+tableName+` SET value=?3 WHERE partition_key=?1 AND key=?2`, partitionKey, op.Key, op.Value)
+	case op.Predicate == kv.PrecondConditionalExists:
+		res, err = tx.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE partition_key=? AND key=?`, partitionKey, op.Key)
+	case !op.IsDelete():
+		res, err = tx.ExecContext(ctx, `UPDATE `+tableName+` SET value=?3 WHERE partition_key=?1 AND key=?2 AND value=?4`, partitionKey, op.Key, op.Value, op.Predicate.([]byte))
+	default:
+		res, err = tx.ExecContext(ctx, `DELETE FROM `+tableName+` WHERE partition_key=? AND key=? AND value=?`, partitionKey, op.Key, op.Predicate.([]byte))
+	}
+	if err != nil {
+		return err
+	}
+	if err := checkAffected(res); err != nil {
+		return fmt.Errorf("key=%v: %w", op.Key, err)
+	}
+	return nil
+}
+
+// checkAffected returns ErrPredicateFailed unless res affected a single row
+func checkAffected(res sql.Result) error {
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n != 1 {
+		return kv.ErrPredicateFailed
+	}
+	return nil
+}
+
+func (s *Store) ListPartitions(ctx context.Context) ([][]byte, error) {
+	rows, err := s.db.QueryContext(ctx, `SELECT DISTINCT partition_key FROM `+tableName+` ORDER BY partition_key`)
+	if err != nil {
+		return nil, fmt.Errorf("sqlite list partitions: %w", err)
+	}
+	defer rows.Close()
+	var partitions [][]byte
+	for rows.Next() {
+		var partitionKey []byte
+		if err := rows.Scan(&partitionKey); err != nil {
+			return nil, fmt.Errorf("sqlite list partitions: %w", err)
+		}
+		partitions = append(partitions, partitionKey)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("sqlite list partitions: %w", err)
+	}
+	return partitions, nil
+}
+
+func (s *Store) Scan(ctx context.Context, partitionKey []byte, options kv.ScanOptions) (kv.EntriesIterator, error) {
+	if len(partitionKey) == 0 {
+		return nil, kv.ErrMissingPartitionKey
+	}
+
+	// firstScanLimit based on the minimum between scanPageSize and ScanOptions batch size
+	firstScanLimit := s.scanPageSize
+	if options.BatchSize != 0 && options.BatchSize < s.scanPageSize {
+		firstScanLimit = options.BatchSize
+	}
+	it := &EntriesIterator{
+		ctx:          ctx,
+		partitionKey: partitionKey,
+		startKey:     options.KeyStart,
+		limit:        firstScanLimit,
+		store:        s,
+		includeStart: true,
+	}
+	it.runQuery(it.limit)
+	if it.err != nil {
+		return nil, it.err
+	}
+	return it, nil
+}
+
+// Watch watches changes made through this process: changes made by other processes sharing
+// the database file are not delivered.
+func (s *Store) Watch(ctx context.Context, partitionKey, prefix []byte, fn kv.WatchFunc) error {
+	return s.hub.Watch(ctx, partitionKey, prefix, fn)
+}
+
+func (s *Store) Close() {
+	driverLock.Lock()
+	defer driverLock.Unlock()
+	s.refCount--
+	if s.refCount <= 0 {
+		_ = s.db.Close()
+		delete(dbMap, s.path)
+	}
+}
+
+// Next reads the next key/value.
+func (e *EntriesIterator) Next() bool {
+	if e.err != nil || len(e.entries) == 0 {
+		return false
+	}
+	if e.currEntryIdx+1 == len(e.entries) {
+		key := e.entries[e.currEntryIdx].Key
+		e.startKey = key
+		e.includeStart = false
+		e.doubleAndCapLimit()
+		e.runQuery(e.limit)
+		if e.err != nil || len(e.entries) == 0 {
+			return false
+		}
+	}
+	e.currEntryIdx++
+	return true
+}
+
+// doubleAndCapLimit doubles the limit up to the page size of the store, so that short scans
+// read few entries and long scans read few pages
+func (e *EntriesIterator) doubleAndCapLimit() {
+	e.limit *= 2
+	if e.limit > e.store.scanPageSize {
+		e.limit = e.store.scanPageSize
+	}
+}
+
+func (e *EntriesIterator) SeekGE(key []byte) {
+	if !e.isInRange(key) {
+		e.startKey = key
+		e.includeStart = true
+		e.doubleAndCapLimit()
+		e.runQuery(e.limit)
+		return
+	}
+	for i := range e.entries {
+		if bytes.Compare(key, e.entries[i].Key) <= 0 {
+			e.currEntryIdx = i - 1
+			return
+		}
+	}
+}
+
+func (e *EntriesIterator) Entry() *kv.Entry {
+	if e.entries == nil {
+		return nil
+	}
+	return &e.entries[e.currEntryIdx]
+}
+
+// Err return the last scan error
+func (e *EntriesIterator) Err() error {
+	return e.err
+}
+
+func (e *EntriesIterator) Close() {
+	e.entries = nil
+	e.currEntryIdx = -1
+	e.err = kv.ErrClosedEntries
+}
+
+// runQuery reads the next page of entries.  Each page is read by its own query, so that an
+// open iterator does not hold a read transaction on the database.
+func (e *EntriesIterator) runQuery(scanLimit int) {
+	var (
+		rows *sql.Rows
+		err  error
+	)
+	if e.startKey == nil {
+		rows, err = e.store.db.QueryContext(e.ctx, `SELECT key,value FROM `+tableName+` WHERE partition_key=? ORDER BY key LIMIT ?`, e.partitionKey, scanLimit)
+	} else {
+		compareOp := ">="
+		if !e.includeStart {
+			compareOp = ">"
+		}
+		rows, err = e.store.db.QueryContext(e.ctx, `SELECT key,value FROM `+tableName+` WHERE partition_key=? AND key `+compareOp+` ? ORDER BY key LIMIT ?`, e.partitionKey, e.startKey, scanLimit)
+	}
+	if err != nil {
+		e.err = fmt.Errorf("sqlite scan: %w", err)
+		return
+	}
+	defer rows.Close()
+	entries := make([]kv.Entry, 0, scanLimit)
+	for rows.Next() {
+		entry := kv.Entry{PartitionKey: e.partitionKey}
+		if err := rows.Scan(&entry.Key, &entry.Value); err != nil {
+			e.err = fmt.Errorf("scanning all entries: %w", err)
+			return
+		}
+		if entry.Value == nil {
+			entry.Value = []byte{}
+		}
+		entries = append(entries, entry)
+	}
+	if err := rows.Err(); err != nil {
+		e.err = fmt.Errorf("sqlite scan: %w", err)
+		return
+	}
+	e.entries = entries
+	e.currEntryIdx = -1
+}
+
+func (e *EntriesIterator) isInRange(key []byte) bool {
+	if len(e.entries) == 0 {
+		return false
+	}
+	minKey := e.entries[0].Key
+	maxKey := e.entries[len(e.entries)-1].Key
+	return minKey != nil && maxKey != nil && bytes.Compare(key, minKey) >= 0 && bytes.Compare(key, maxKey) <= 0
+}
@@ -0,0 +1,29 @@
+package sqlite_test
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvparams"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+	"github.com/treeverse/lakefs/pkg/kv/sqlite"
+)
+
+func TestSQLiteKV(t *testing.T) {
+	kvtest.DriverTest(t, func(t testing.TB, ctx context.Context) kv.Store {
+		t.Helper()
+		store, err := kv.Open(ctx, kvparams.Config{
+			Type: sqlite.DriverName,
+			SQLite: &kvparams.SQLite{
+				Path: filepath.Join(t.TempDir(), "kv.db"),
+			},
+		})
+		if err != nil {
+			t.Fatalf("failed to open kv '%s' store: %s", sqlite.DriverName, err)
+		}
+		t.Cleanup(store.Close)
+		return store
+	})
+}
@@ -41,6 +41,7 @@ var (
 	ErrUnknownDriver       = errors.New("unknown driver")
 	ErrTableNotActive      = errors.New("table not active")
 	ErrSlowDown            = errors.New("slow down")
+	ErrDuplicateKey        = errors.New("duplicate key")
 )
 
 // Precond Type for special conditionals provided as predicates for the SetIf method
@@ -109,6 +110,24 @@ type Store interface {
 	Close()
 }
 
+// TransactionalStore is implemented by stores that can apply several writes to keys of a
+// partition atomically.  Use Transact to apply writes to any Store.
+type TransactionalStore interface {
+	Store
+
+	// Transact applies ops to keys of partitionKey in a single transaction: either all ops
+	//  are applied or none are.  It returns ErrPredicateFailed if the predicate of any
+	//  conditional op fails.  Each key may appear at most once in ops.
+	Transact(ctx context.Context, partitionKey []byte, ops []TxnOp) error
+}
+
+// PartitionLister is implemented by stores that can list their partitions.  Use ListPartitions
+// to list the partitions of any Store.
+type PartitionLister interface {
+	// ListPartitions returns the keys of all partitions holding entries, in any order
+	ListPartitions(ctx context.Context) ([][]byte, error)
+}
+
 // EntriesIterator used to enumerate over Scan results
 type EntriesIterator interface {
 	// Next should be called first before access Entry.
@@ -191,6 +210,20 @@ func Open(ctx context.Context, params kvparams.Config) (Store, error) {
 	return storeMetrics(store, params.Type), nil
 }
 
+// unwrapStore returns the store wrapped by the store wrappers of this package
+func unwrapStore(s Store) Store {
+	for {
+		switch w := s.(type) {
+		case *StoreMetricsWrapper:
+			s = w.Store
+		case *StoreLimiter:
+			s = w.Store
+		default:
+			return s
+		}
+	}
+}
+
 // Drivers returns a list of registered drive names
 func Drivers() []string {
 	driversMu.RLock()
@@ -43,6 +43,11 @@ func (s *StoreLimiter) Scan(ctx context.Context, partitionKey []byte, options Sc
 	return s.Store.Scan(ctx, partitionKey, options)
 }
 
+func (s *StoreLimiter) Transact(ctx context.Context, partitionKey []byte, ops []TxnOp) error {
+	_ = s.Limiter.Take()
+	return Transact(ctx, s.Store, partitionKey, ops)
+}
+
 func (s *StoreLimiter) Close() {
 	s.Store.Close()
 }
@@ -23,7 +23,7 @@ func (t *traceLimiter) Take() time.Time {
 func TestStoreLimiter(t *testing.T) {
 	ctx := context.Background()
 	ctrl := gomock.NewController(t)
-	store := mock.NewMockStore(ctrl)
+	store := mock.NewMockTransactionalStore(ctrl)
 
 	rec := store.EXPECT()
 	rec.Scan(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil, nil)
@@ -31,6 +31,7 @@ func TestStoreLimiter(t *testing.T) {
 	rec.Delete(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 	rec.Set(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 	rec.SetIf(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
+	rec.Transact(gomock.Any(), gomock.Any(), gomock.Any()).Times(1).Return(nil)
 	rec.Close().Times(1)
 
 	limiter := &traceLimiter{}
@@ -41,8 +42,9 @@ func TestStoreLimiter(t *testing.T) {
 	_ = sl.Delete(ctx, nil, nil)
 	_ = sl.Set(ctx, nil, nil, nil)
 	_ = sl.SetIf(ctx, nil, nil, nil, nil)
+	_ = sl.Transact(ctx, nil, nil)
 
-	const expectedCalls = 5
+	const expectedCalls = 6
 	if expectedCalls != limiter.Count {
 		t.Fatalf("Limiter called=%d, expected=%d", limiter.Count, expectedCalls)
 	}
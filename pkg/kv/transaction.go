@@ -0,0 +1,155 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// MaxTransactionOps is the largest number of ops in a transaction, the limit of the most
+// constrained driver (DynamoDB)
+const MaxTransactionOps = 100
+
+// TxnOp is a single write of a transaction
+type TxnOp struct {
+	Key []byte
+	// Value to set, nil to delete Key
+	Value []byte
+	// Conditional ops are applied only if Predicate matches the current value of Key, with
+	//  the semantics of SetIf: nil if no previous key exists, PrecondConditionalExists if it
+	//  exists, or the existing value.
+	Conditional bool
+	Predicate   Predicate
+}
+
+// IsDelete returns true if op deletes its key
+func (op TxnOp) IsDelete() bool {
+	return op.Value == nil
+}
+
+// TxnSet returns an op that sets key to value
+func TxnSet(key, value []byte) TxnOp {
+	return TxnOp{Key: key, Value: value}
+}
+
+// TxnSetIf returns an op that sets key to value if valuePredicate matches, as SetIf does
+func TxnSetIf(key, value []byte, valuePredicate Predicate) TxnOp {
+	return TxnOp{Key: key, Value: value, Conditional: true, Predicate: valuePredicate}
+}
+
+// TxnDelete returns an op that deletes key, whether or not it exists
+func TxnDelete(key []byte) TxnOp {
+	return TxnOp{Key: key}
+}
+
+// TxnDeleteIf returns an op that deletes key if valuePredicate matches
+func TxnDeleteIf(key []byte, valuePredicate Predicate) TxnOp {
+	return TxnOp{Key: key, Conditional: true, Predicate: valuePredicate}
+}
+
+// TxnSetMsg returns an op that sets key to the encoded msg
+func TxnSetMsg(key []byte, msg protoreflect.ProtoMessage) (TxnOp, error) {
+	val, err := proto.Marshal(msg)
+	if err != nil {
+		return TxnOp{}, err
+	}
+	return TxnSet(key, val), nil
+}
+
+// TxnSetMsgIf returns an op that sets key to the encoded msg if valuePredicate matches
+func TxnSetMsgIf(key []byte, msg protoreflect.ProtoMessage, valuePredicate Predicate) (TxnOp, error) {
+	val, err := proto.Marshal(msg)
+	if err != nil {
+		return TxnOp{}, err
+	}
+	return TxnSetIf(key, val, valuePredicate), nil
+}
+
+// MatchPredicate returns true if valuePredicate matches the current value of a key, nil if it
+// does not exist
+func MatchPredicate(valuePredicate Predicate, value []byte) bool {
+	switch valuePredicate {
+	case nil:
+		return value == nil
+	case PrecondConditionalExists:
+		return value != nil
+	default:
+		return value != nil && bytes.Equal(valuePredicate.([]byte), value)
+	}
+}
+
+// ValidateTxnOps checks the arguments of a transaction, common to all stores
+func ValidateTxnOps(partitionKey []byte, ops []TxnOp) error {
+	if len(partitionKey) == 0 {
+		return ErrMissingPartitionKey
+	}
+	if len(ops) > MaxTransactionOps {
+		return fmt.Errorf("%d ops: %w", len(ops), ErrBatchSizeTooBig)
+	}
+	keys := make(map[string]struct{}, len(ops))
+	for _, op := range ops {
+		if len(op.Key) == 0 {
+			return ErrMissingKey
+		}
+		if _, ok := keys[string(op.Key)]; ok {
+			return fmt.Errorf("key=%v: %w", op.Key, ErrDuplicateKey)
+		}
+		keys[string(op.Key)] = struct{}{}
+	}
+	return nil
+}
+
+// SupportsTransactions returns true if Transact applies ops to s in a single transaction.  The
+// store wrappers always implement TransactionalStore, so it checks the store they wrap.
+func SupportsTransactions(s Store) bool {
+	_, ok := unwrapStore(s).(TransactionalStore)
+	return ok
+}
+
+// Transact applies ops to keys of partitionKey.  It uses a single transaction if s is a
+// TransactionalStore, and otherwise applies ops one after the other, stopping at the first
+// failure: a failed predicate then leaves the ops before it applied.
+//
+// Graveler uses it to write a new commit together with the branch that references it.
+func Transact(ctx context.Context, s Store, partitionKey []byte, ops []TxnOp) error {
+	if ts, ok := s.(TransactionalStore); ok {
+		return ts.Transact(ctx, partitionKey, ops)
+	}
+	if err := ValidateTxnOps(partitionKey, ops); err != nil {
+		return err
+	}
+	for _, op := range ops {
+		if err := applyTxnOp(ctx, s, partitionKey, op); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func applyTxnOp(ctx context.Context, s Store, partitionKey []byte, op TxnOp) error {
+	switch {
+	case !op.IsDelete() && op.Conditional:
+		return s.SetIf(ctx, partitionKey, op.Key, op.Value, op.Predicate)
+	case !op.IsDelete():
+		return s.Set(ctx, partitionKey, op.Key, op.Value)
+	case op.Conditional:
+		var value []byte
+		res, err := s.Get(ctx, partitionKey, op.Key)
+		switch {
+		case err == nil:
+			value = res.Value
+		case !errors.Is(err, ErrNotFound):
+			return err
+		}
+		if !MatchPredicate(op.Predicate, value) {
+			return fmt.Errorf("key=%v: %w", op.Key, ErrPredicateFailed)
+		}
+		return s.Delete(ctx, partitionKey, op.Key)
+	default:
+		return s.Delete(ctx, partitionKey, op.Key)
+	}
+}
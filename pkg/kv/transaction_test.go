@@ -0,0 +1,79 @@
+package kv_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvparams"
+	"github.com/treeverse/lakefs/pkg/kv/mem"
+)
+
+// nonTransactionalStore hides the Transact method of the store it wraps
+type nonTransactionalStore struct {
+	kv.Store
+}
+
+func TestSupportsTransactions(t *testing.T) {
+	ctx := context.Background()
+	store, err := (&mem.Driver{}).Open(ctx, kvparams.Config{})
+	if err != nil {
+		t.Fatalf("failed to open mem store: %s", err)
+	}
+	defer store.Close()
+
+	if !kv.SupportsTransactions(&kv.StoreMetricsWrapper{Store: store}) {
+		t.Error("SupportsTransactions(mem) = false, expected true")
+	}
+	if kv.SupportsTransactions(nonTransactionalStore{Store: store}) {
+		t.Error("SupportsTransactions(non transactional) = true, expected false")
+	}
+	if kv.SupportsTransactions(kv.NewStoreLimiter(nonTransactionalStore{Store: store}, nil)) {
+		t.Error("SupportsTransactions(limited non transactional) = true, expected false")
+	}
+}
+
+func TestTransactFallback(t *testing.T) {
+	ctx := context.Background()
+	memStore, err := (&mem.Driver{}).Open(ctx, kvparams.Config{})
+	if err != nil {
+		t.Fatalf("failed to open mem store: %s", err)
+	}
+	defer memStore.Close()
+	store := nonTransactionalStore{Store: memStore}
+	partitionKey := []byte("partition")
+
+	if err := store.Set(ctx, partitionKey, []byte("delete"), []byte("v1")); err != nil {
+		t.Fatalf("Set: %s", err)
+	}
+	err = kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+		kv.TxnSetIf([]byte("new"), []byte("v"), nil),
+		kv.TxnDeleteIf([]byte("delete"), []byte("v1")),
+	})
+	if err != nil {
+		t.Fatalf("Transact: %s", err)
+	}
+	if _, err := store.Get(ctx, partitionKey, []byte("new")); err != nil {
+		t.Errorf("Get set key: %s", err)
+	}
+	if _, err := store.Get(ctx, partitionKey, []byte("delete")); !errors.Is(err, kv.ErrNotFound) {
+		t.Errorf("Get deleted key err=%v, expected %s", err, kv.ErrNotFound)
+	}
+
+	// ops are applied one after the other, up to the failed predicate
+	err = kv.Transact(ctx, store, partitionKey, []kv.TxnOp{
+		kv.TxnSet([]byte("applied"), []byte("v")),
+		kv.TxnDeleteIf([]byte("missing"), kv.PrecondConditionalExists),
+		kv.TxnSet([]byte("skipped"), []byte("v")),
+	})
+	if !errors.Is(err, kv.ErrPredicateFailed) {
+		t.Fatalf("Transact err=%v, expected %s", err, kv.ErrPredicateFailed)
+	}
+	if _, err := store.Get(ctx, partitionKey, []byte("applied")); err != nil {
+		t.Errorf("Get applied key: %s", err)
+	}
+	if _, err := store.Get(ctx, partitionKey, []byte("skipped")); !errors.Is(err, kv.ErrNotFound) {
+		t.Errorf("Get skipped key err=%v, expected %s", err, kv.ErrNotFound)
+	}
+}
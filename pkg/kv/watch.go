@@ -0,0 +1,162 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"sync"
+)
+
+var ErrWatchNotSupported = errors.New("watch not supported")
+
+// WatchEvent reports a change to a key
+type WatchEvent struct {
+	PartitionKey []byte
+	// Key that changed, nil if changes may have been missed: any key of the watch may have
+	// changed, for instance after the store reconnected.
+	Key []byte
+	// Deleted is true if Key was deleted, false if it was set
+	Deleted bool
+}
+
+// WatchFunc is called with every change of a watch.  It may be called concurrently from
+// goroutines of the store, and must not block.
+type WatchFunc func(event WatchEvent)
+
+// Watcher is implemented by stores that can report changes to their keys, including changes
+// made through other instances of the store when it is shared.  Use Watch to watch any Store.
+type Watcher interface {
+	// Watch calls fn after every change to a key of partitionKey that starts with prefix,
+	//  until ctx is done.  Events are delivered after the change is applied and may be
+	//  delayed, so callers should still expect to read stale values for a short while.  It
+	//  returns ErrWatchNotSupported if the store was not configured to watch.
+	Watch(ctx context.Context, partitionKey, prefix []byte, fn WatchFunc) error
+}
+
+// Watch calls fn after every change to a key of partitionKey that starts with prefix, until
+// ctx is done.  It returns ErrWatchNotSupported if s cannot watch.
+func Watch(ctx context.Context, s Store, partitionKey, prefix []byte, fn WatchFunc) error {
+	if len(partitionKey) == 0 {
+		return ErrMissingPartitionKey
+	}
+	w, ok := unwrapStore(s).(Watcher)
+	if !ok {
+		return ErrWatchNotSupported
+	}
+	return w.Watch(ctx, partitionKey, prefix, fn)
+}
+
+type watch struct {
+	ctx          context.Context
+	partitionKey []byte
+	prefix       []byte
+	fn           WatchFunc
+}
+
+// WatchHub dispatches the changes of a store to its watches.  Stores call Notify with their
+// changes and serve Watch from the hub.  The zero value is ready to use.
+type WatchHub struct {
+	mu      sync.RWMutex
+	nextID  uint64
+	watches map[uint64]*watch
+}
+
+// Watch registers fn to be called with changes to keys of partitionKey starting with prefix,
+// until ctx is done
+func (h *WatchHub) Watch(ctx context.Context, partitionKey, prefix []byte, fn WatchFunc) error {
+	if len(partitionKey) == 0 {
+		return ErrMissingPartitionKey
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	h.mu.Lock()
+	if h.watches == nil {
+		h.watches = make(map[uint64]*watch)
+	}
+	id := h.nextID
+	h.nextID++
+	h.watches[id] = &watch{
+		ctx:          ctx,
+		partitionKey: bytes.Clone(partitionKey),
+		prefix:       bytes.Clone(prefix),
+		fn:           fn,
+	}
+	h.mu.Unlock()
+
+	context.AfterFunc(ctx, func() {
+		h.mu.Lock()
+		defer h.mu.Unlock()
+		delete(h.watches, id)
+	})
+	return nil
+}
+
+// HasWatches returns true if any watch is registered
+func (h *WatchHub) HasWatches() bool {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	return len(h.watches) > 0
+}
+
+// Notify calls the watches of event's key with event
+func (h *WatchHub) Notify(event WatchEvent) {
+	for _, w := range h.matching(event) {
+		w.fn(event)
+	}
+}
+
+// NotifyMissed tells every watch that changes may have been missed
+func (h *WatchHub) NotifyMissed() {
+	h.mu.RLock()
+	watches := make([]*watch, 0, len(h.watches))
+	for _, w := range h.watches {
+		if w.ctx.Err() == nil {
+			watches = append(watches, w)
+		}
+	}
+	h.mu.RUnlock()
+	for _, w := range watches {
+		w.fn(WatchEvent{PartitionKey: w.partitionKey})
+	}
+}
+
+// NotifyTxn calls the watches of the keys of ops, applied to partitionKey
+func (h *WatchHub) NotifyTxn(partitionKey []byte, ops []TxnOp) {
+	for _, event := range TxnWatchEvents(partitionKey, ops) {
+		h.Notify(event)
+	}
+}
+
+func (h *WatchHub) matching(event WatchEvent) []*watch {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+	var watches []*watch
+	for _, w := range h.watches {
+		if w.ctx.Err() != nil {
+			// ended, to be removed
+			continue
+		}
+		if !bytes.Equal(w.partitionKey, event.PartitionKey) {
+			continue
+		}
+		if event.Key != nil && !bytes.HasPrefix(event.Key, w.prefix) {
+			continue
+		}
+		watches = append(watches, w)
+	}
+	return watches
+}
+
+// TxnWatchEvents returns the changes made by applying ops to partitionKey
+func TxnWatchEvents(partitionKey []byte, ops []TxnOp) []WatchEvent {
+	events := make([]WatchEvent, 0, len(ops))
+	for _, op := range ops {
+		if op.IsDelete() && op.Conditional && op.Predicate == nil {
+			// deleting a key that must not exist changes nothing
+			continue
+		}
+		events = append(events, WatchEvent{PartitionKey: partitionKey, Key: op.Key, Deleted: op.IsDelete()})
+	}
+	return events
+}
@@ -0,0 +1,180 @@
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/rs/xid"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// DeadLettersPartition holds the notifications that could not be delivered, keyed by time-ordered IDs.  Manage it
+// with "lakefs notifications dead-letters".
+const DeadLettersPartition = "notifications_dead_letters"
+
+const (
+	// deadLetterPruneInterval is the time between deletions of dead letters older than the retention
+	deadLetterPruneInterval = time.Hour
+	deadLetterPruneBatch    = 1000
+)
+
+//nolint:gochecknoinits
+func init() {
+	kv.MustRegisterType(DeadLettersPartition, "*", (&DeadLetterData{}).ProtoReflect().Type())
+}
+
+func (s *Service) deadLetter(ctx context.Context, d *delivery, attempts int, deliveryErr error) {
+	id := xid.New().String()
+	data := &DeadLetterData{
+		Id:           id,
+		RepositoryId: d.repository,
+		TargetId:     d.targetID,
+		Sink:         d.sink,
+		EventName:    d.eventName,
+		Key:          d.key,
+		Attempts:     int32(attempts), //nolint:gosec
+		Error:        deliveryErr.Error(),
+		CreationDate: timestamppb.New(time.Now()),
+		Payload:      d.payload,
+	}
+	// keep dead letters of requests that completed or of a service shutting down
+	ctx = context.WithoutCancel(ctx)
+	log := s.log.WithFields(logging.Fields{
+		"repository": d.repository,
+		"target_id":  d.targetID,
+		"sink":       d.sink,
+		"event":      d.eventName,
+		"key":        d.key,
+		"attempts":   attempts,
+	})
+	if err := kv.SetMsg(ctx, s.store, DeadLettersPartition, []byte(id), data); err != nil {
+		log.WithError(err).WithField("delivery_error", deliveryErr).Error("Failed to store undelivered notification")
+		return
+	}
+	log.WithError(deliveryErr).Warn("Notification dead-lettered")
+}
+
+// ListDeadLetters returns up to amount undelivered notifications, oldest first, starting after the ID after
+func (s *Service) ListDeadLetters(ctx context.Context, after string, amount int) ([]*DeadLetterData, error) {
+	it, err := s.store.Scan(ctx, []byte(DeadLettersPartition), kv.ScanOptions{KeyStart: []byte(after), BatchSize: amount})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var letters []*DeadLetterData
+	for len(letters) < amount && it.Next() {
+		entry := it.Entry()
+		if string(entry.Key) == after {
+			continue
+		}
+		data := &DeadLetterData{}
+		if err := proto.Unmarshal(entry.Value, data); err != nil {
+			return nil, err
+		}
+		letters = append(letters, data)
+	}
+	if err := it.Err(); err != nil {
+		return nil, err
+	}
+	return letters, nil
+}
+
+// GetDeadLetter returns the undelivered notification with the given ID
+func (s *Service) GetDeadLetter(ctx context.Context, id string) (*DeadLetterData, error) {
+	data := &DeadLetterData{}
+	if _, err := kv.GetMsg(ctx, s.store, DeadLettersPartition, []byte(id), data); err != nil {
+		return nil, fmt.Errorf("dead letter %s: %w", id, err)
+	}
+	return data, nil
+}
+
+// DeleteDeadLetter deletes the undelivered notification with the given ID
+func (s *Service) DeleteDeadLetter(ctx context.Context, id string) error {
+	if _, err := s.GetDeadLetter(ctx, id); err != nil {
+		return err
+	}
+	return s.store.Delete(ctx, []byte(DeadLettersPartition), []byte(id))
+}
+
+// ReplayDeadLetter sends the undelivered notification with the given ID to its sink once more, and deletes it when
+// delivered.  The notification is sent as it was, even if the target was since removed.
+func (s *Service) ReplayDeadLetter(ctx context.Context, id string) error {
+	data, err := s.GetDeadLetter(ctx, id)
+	if err != nil {
+		return err
+	}
+	sink, ok := s.sinks[data.GetSink()]
+	if !ok {
+		return fmt.Errorf("%w: unknown sink '%s'", ErrDeliveryFailed, data.GetSink())
+	}
+	if err := sink.Send(ctx, data.GetPayload()); err != nil {
+		return fmt.Errorf("%w: %w", ErrDeliveryFailed, err)
+	}
+	return s.store.Delete(ctx, []byte(DeadLettersPartition), []byte(id))
+}
+
+// PruneDeadLetters deletes the undelivered notifications created before the given time, and returns their number
+func (s *Service) PruneDeadLetters(ctx context.Context, before time.Time) (int, error) {
+	pruned := 0
+	for {
+		ids, err := s.deadLettersBefore(ctx, before)
+		if err != nil {
+			return pruned, err
+		}
+		for _, id := range ids {
+			if err := s.store.Delete(ctx, []byte(DeadLettersPartition), []byte(id)); err != nil {
+				return pruned, err
+			}
+			pruned++
+		}
+		if len(ids) < deadLetterPruneBatch {
+			return pruned, nil
+		}
+	}
+}
+
+// deadLettersBefore returns the IDs of up to deadLetterPruneBatch of the oldest dead letters created before the
+// given time
+func (s *Service) deadLettersBefore(ctx context.Context, before time.Time) ([]string, error) {
+	it, err := s.store.Scan(ctx, []byte(DeadLettersPartition), kv.ScanOptions{BatchSize: deadLetterPruneBatch})
+	if err != nil {
+		return nil, err
+	}
+	defer it.Close()
+	var ids []string
+	for len(ids) < deadLetterPruneBatch && it.Next() {
+		data := &DeadLetterData{}
+		if err := proto.Unmarshal(it.Entry().Value, data); err != nil {
+			return nil, err
+		}
+		// IDs are ordered by time, so the rest of the dead letters are newer
+		if !data.GetCreationDate().AsTime().Before(before) {
+			break
+		}
+		ids = append(ids, data.GetId())
+	}
+	return ids, it.Err()
+}
+
+// pruneDeadLetters deletes the dead letters older than the retention periodically, until ctx is done
+func (s *Service) pruneDeadLetters(ctx context.Context) {
+	ticker := time.NewTicker(deadLetterPruneInterval)
+	defer ticker.Stop()
+	for {
+		pruned, err := s.PruneDeadLetters(ctx, time.Now().Add(-s.deadLetterRetention))
+		if err != nil {
+			s.log.WithError(err).Error("Failed to prune dead letters")
+		} else if pruned > 0 {
+			s.log.WithField("pruned", pruned).Info("Pruned dead letters")
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
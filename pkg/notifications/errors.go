@@ -0,0 +1,15 @@
+package notifications
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+var (
+	ErrInvalidConfiguration = fmt.Errorf("notification configuration: %w", graveler.ErrInvalidValue)
+	ErrInvalidSink          = errors.New("invalid notification sink")
+	ErrDeliveryFailed       = errors.New("notification delivery failed")
+	ErrQueueFull            = errors.New("notification queue full")
+)
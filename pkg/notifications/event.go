@@ -0,0 +1,190 @@
+package notifications
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+// Event names, as set on notification configurations.  A configured event ending with "*" matches all events that
+// start with the part before it, e.g. "s3:ObjectCreated:*".
+const (
+	EventObjectCreatedPut    = "s3:ObjectCreated:Put"
+	EventObjectRemovedDelete = "s3:ObjectRemoved:Delete"
+	EventCommit              = "lakefs:Commit"
+	EventMerge               = "lakefs:Merge"
+
+	eventWildcard = "*"
+	// s3EventPrefix is omitted from the name of S3 events in notification records
+	s3EventPrefix = "s3:"
+
+	FilterRulePrefix = "prefix"
+	FilterRuleSuffix = "suffix"
+
+	recordEventVersion   = "2.1"
+	recordEventSource    = "lakefs:s3"
+	recordSchemaVersion  = "1.0"
+	recordBucketARNBegin = "arn:aws:s3:::"
+)
+
+var supportedEvents = []string{
+	EventObjectCreatedPut,
+	EventObjectRemovedDelete,
+	EventCommit,
+	EventMerge,
+}
+
+// Event is a change to a repository that notification targets may be notified of
+type Event struct {
+	Name       string
+	Time       time.Time
+	Repository graveler.RepositoryID
+	Branch     graveler.BranchID
+	// Path of the object, empty for commit and merge events
+	Path string
+	Size int64
+	ETag string
+	// CommitID, Message and Committer describe the commit of commit and merge events, SourceRef the merged reference
+	CommitID  string
+	Message   string
+	Committer string
+	SourceRef string
+}
+
+// Key identifies the object of the event the way the S3 gateway does: the branch followed by the object path.
+// Commit and merge events have the key of the branch root.
+func (e *Event) Key() string {
+	return e.Branch.String() + "/" + e.Path
+}
+
+// Records is the body sent to notification sinks, shaped like S3 event notifications
+type Records struct {
+	Records []Record `json:"Records"`
+}
+
+type Record struct {
+	EventVersion string       `json:"eventVersion"`
+	EventSource  string       `json:"eventSource"`
+	EventTime    string       `json:"eventTime"`
+	EventName    string       `json:"eventName"`
+	S3           RecordS3     `json:"s3"`
+	LakeFS       RecordLakeFS `json:"lakeFS"`
+}
+
+type RecordS3 struct {
+	SchemaVersion   string       `json:"s3SchemaVersion"`
+	ConfigurationID string       `json:"configurationId"`
+	Bucket          RecordBucket `json:"bucket"`
+	Object          RecordObject `json:"object"`
+}
+
+type RecordBucket struct {
+	Name string `json:"name"`
+	ARN  string `json:"arn"`
+}
+
+type RecordObject struct {
+	// Key is URL encoded, as in S3 notifications
+	Key  string `json:"key"`
+	Size int64  `json:"size,omitempty"`
+	ETag string `json:"eTag,omitempty"`
+}
+
+// RecordLakeFS holds the lakeFS details of the event, which S3 notifications do not have
+type RecordLakeFS struct {
+	Repository string `json:"repository"`
+	Branch     string `json:"branch"`
+	Path       string `json:"path,omitempty"`
+	CommitID   string `json:"commitId,omitempty"`
+	Message    string `json:"message,omitempty"`
+	Committer  string `json:"committer,omitempty"`
+	SourceRef  string `json:"sourceRef,omitempty"`
+}
+
+// encodeKey URL encodes key like S3 event notifications do, leaving path separators as is
+func encodeKey(key string) string {
+	return strings.ReplaceAll(url.QueryEscape(key), "%2F", "/")
+}
+
+// payload returns the notification body of the event for the target with configurationID
+func (e *Event) payload(configurationID string) ([]byte, error) {
+	record := Record{
+		EventVersion: recordEventVersion,
+		EventSource:  recordEventSource,
+		EventTime:    e.Time.UTC().Format(time.RFC3339Nano),
+		EventName:    strings.TrimPrefix(e.Name, s3EventPrefix),
+		S3: RecordS3{
+			SchemaVersion:   recordSchemaVersion,
+			ConfigurationID: configurationID,
+			Bucket: RecordBucket{
+				Name: e.Repository.String(),
+				ARN:  recordBucketARNBegin + e.Repository.String(),
+			},
+			Object: RecordObject{
+				Key:  encodeKey(e.Key()),
+				Size: e.Size,
+				ETag: e.ETag,
+			},
+		},
+		LakeFS: RecordLakeFS{
+			Repository: e.Repository.String(),
+			Branch:     e.Branch.String(),
+			Path:       e.Path,
+			CommitID:   e.CommitID,
+			Message:    e.Message,
+			Committer:  e.Committer,
+			SourceRef:  e.SourceRef,
+		},
+	}
+	return json.Marshal(Records{Records: []Record{record}})
+}
+
+// matchEvent reports whether the configured event pattern matches the event name
+func matchEvent(pattern, name string) bool {
+	if prefix, ok := strings.CutSuffix(pattern, eventWildcard); ok {
+		return strings.HasPrefix(name, prefix)
+	}
+	return pattern == name
+}
+
+// validateEvent checks that the configured event pattern matches a supported event
+func validateEvent(pattern string) error {
+	for _, name := range supportedEvents {
+		if matchEvent(pattern, name) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: unsupported event '%s'", ErrInvalidConfiguration, pattern)
+}
+
+// targetMatches reports whether the target is notified of the event
+func targetMatches(target *graveler.NotificationTarget, e *Event) bool {
+	matched := false
+	for _, pattern := range target.GetEvents() {
+		if matchEvent(pattern, e.Name) {
+			matched = true
+			break
+		}
+	}
+	if !matched {
+		return false
+	}
+	key := e.Key()
+	for _, rule := range target.GetFilterRules() {
+		switch rule.GetName() {
+		case FilterRulePrefix:
+			if !strings.HasPrefix(key, rule.GetValue()) {
+				return false
+			}
+		case FilterRuleSuffix:
+			if !strings.HasSuffix(key, rule.GetValue()) {
+				return false
+			}
+		}
+	}
+	return true
+}
@@ -0,0 +1,45 @@
+package notifications
+
+import (
+	"context"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/graveler"
+)
+
+// hooksHandler notifies of commits and merges once the post hooks of the wrapped handler ran
+type hooksHandler struct {
+	graveler.HooksHandler
+	service *Service
+}
+
+// HooksHandler wraps hooks, adding notifications of commits and merges
+func (s *Service) HooksHandler(hooks graveler.HooksHandler) graveler.HooksHandler {
+	return &hooksHandler{HooksHandler: hooks, service: s}
+}
+
+func (h *hooksHandler) PostCommitHook(ctx context.Context, record graveler.HookRecord) error {
+	err := h.HooksHandler.PostCommitHook(ctx, record)
+	h.service.Notify(ctx, record.Repository, commitEvent(EventCommit, record))
+	return err
+}
+
+func (h *hooksHandler) PostMergeHook(ctx context.Context, record graveler.HookRecord) error {
+	err := h.HooksHandler.PostMergeHook(ctx, record)
+	e := commitEvent(EventMerge, record)
+	e.SourceRef = record.MergeSource.String()
+	h.service.Notify(ctx, record.Repository, e)
+	return err
+}
+
+func commitEvent(name string, record graveler.HookRecord) *Event {
+	return &Event{
+		Name:       name,
+		Time:       time.Now(),
+		Repository: record.Repository.RepositoryID,
+		Branch:     record.BranchID,
+		CommitID:   record.CommitID.String(),
+		Message:    record.Commit.Message,
+		Committer:  record.Commit.Committer,
+	}
+}
@@ -0,0 +1,210 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.6
+// 	protoc        (unknown)
+// source: notifications/notifications.proto
+
+package notifications
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// message data model for a notification that could not be delivered
+type DeadLetterData struct {
+	state        protoimpl.MessageState `protogen:"open.v1"`
+	Id           string                 `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	RepositoryId string                 `protobuf:"bytes,2,opt,name=repository_id,json=repositoryId,proto3" json:"repository_id,omitempty"`
+	TargetId     string                 `protobuf:"bytes,3,opt,name=target_id,json=targetId,proto3" json:"target_id,omitempty"`
+	Sink         string                 `protobuf:"bytes,4,opt,name=sink,proto3" json:"sink,omitempty"`
+	EventName    string                 `protobuf:"bytes,5,opt,name=event_name,json=eventName,proto3" json:"event_name,omitempty"`
+	Key          string                 `protobuf:"bytes,6,opt,name=key,proto3" json:"key,omitempty"`
+	Attempts     int32                  `protobuf:"varint,7,opt,name=attempts,proto3" json:"attempts,omitempty"`
+	Error        string                 `protobuf:"bytes,8,opt,name=error,proto3" json:"error,omitempty"`
+	CreationDate *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=creation_date,json=creationDate,proto3" json:"creation_date,omitempty"`
+	// payload is the notification body sent to the sink
+	Payload       []byte `protobuf:"bytes,10,opt,name=payload,proto3" json:"payload,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeadLetterData) Reset() {
+	*x = DeadLetterData{}
+	mi := &file_notifications_notifications_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeadLetterData) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeadLetterData) ProtoMessage() {}
+
+func (x *DeadLetterData) ProtoReflect() protoreflect.Message {
+	mi := &file_notifications_notifications_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeadLetterData.ProtoReflect.Descriptor instead.
+func (*DeadLetterData) Descriptor() ([]byte, []int) {
+	return file_notifications_notifications_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *DeadLetterData) GetId() string {
+	if x != nil {
+		return x.Id
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetRepositoryId() string {
+	if x != nil {
+		return x.RepositoryId
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetTargetId() string {
+	if x != nil {
+		return x.TargetId
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetSink() string {
+	if x != nil {
+		return x.Sink
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetEventName() string {
+	if x != nil {
+		return x.EventName
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetKey() string {
+	if x != nil {
+		return x.Key
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetAttempts() int32 {
+	if x != nil {
+		return x.Attempts
+	}
+	return 0
+}
+
+func (x *DeadLetterData) GetError() string {
+	if x != nil {
+		return x.Error
+	}
+	return ""
+}
+
+func (x *DeadLetterData) GetCreationDate() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreationDate
+	}
+	return nil
+}
+
+func (x *DeadLetterData) GetPayload() []byte {
+	if x != nil {
+		return x.Payload
+	}
+	return nil
+}
+
+var File_notifications_notifications_proto protoreflect.FileDescriptor
+
+const file_notifications_notifications_proto_rawDesc = "" +
+	"\n" +
+	"!notifications/notifications.proto\x12!io.treeverse.lakefs.notifications\x1a\x1fgoogle/protobuf/timestamp.proto\"\xb4\x02\n" +
+	"\x0eDeadLetterData\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\tR\x02id\x12#\n" +
+	"\rrepository_id\x18\x02 \x01(\tR\frepositoryId\x12\x1b\n" +
+	"\ttarget_id\x18\x03 \x01(\tR\btargetId\x12\x12\n" +
+	"\x04sink\x18\x04 \x01(\tR\x04sink\x12\x1d\n" +
+	"\n" +
+	"event_name\x18\x05 \x01(\tR\teventName\x12\x10\n" +
+	"\x03key\x18\x06 \x01(\tR\x03key\x12\x1a\n" +
+	"\battempts\x18\a \x01(\x05R\battempts\x12\x14\n" +
+	"\x05error\x18\b \x01(\tR\x05error\x12?\n" +
+	"\rcreation_date\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\fcreationDate\x12\x18\n" +
+	"\apayload\x18\n" +
+	" \x01(\fR\apayloadB+Z)github.com/treeverse/lakefs/notificationsb\x06proto3"
+
+var (
+	file_notifications_notifications_proto_rawDescOnce sync.Once
+	file_notifications_notifications_proto_rawDescData []byte
+)
+
+func file_notifications_notifications_proto_rawDescGZIP() []byte {
+	file_notifications_notifications_proto_rawDescOnce.Do(func() {
+		file_notifications_notifications_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_notifications_notifications_proto_rawDesc), len(file_notifications_notifications_proto_rawDesc)))
+	})
+	return file_notifications_notifications_proto_rawDescData
+}
+
+var file_notifications_notifications_proto_msgTypes = make([]protoimpl.MessageInfo, 1)
+var file_notifications_notifications_proto_goTypes = []any{
+	(*DeadLetterData)(nil),        // 0: io.treeverse.lakefs.notifications.DeadLetterData
+	(*timestamppb.Timestamp)(nil), // 1: google.protobuf.Timestamp
+}
+var file_notifications_notifications_proto_depIdxs = []int32{
+	1, // 0: io.treeverse.lakefs.notifications.DeadLetterData.creation_date:type_name -> google.protobuf.Timestamp
+	1, // [1:1] is the sub-list for method output_type
+	1, // [1:1] is the sub-list for method input_type
+	1, // [1:1] is the sub-list for extension type_name
+	1, // [1:1] is the sub-list for extension extendee
+	0, // [0:1] is the sub-list for field type_name
+}
+
+func init() { file_notifications_notifications_proto_init() }
+func file_notifications_notifications_proto_init() {
+	if File_notifications_notifications_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_notifications_notifications_proto_rawDesc), len(file_notifications_notifications_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   1,
+			NumExtensions: 0,
+			NumServices:   0,
+		},
+		GoTypes:           file_notifications_notifications_proto_goTypes,
+		DependencyIndexes: file_notifications_notifications_proto_depIdxs,
+		MessageInfos:      file_notifications_notifications_proto_msgTypes,
+	}.Build()
+	File_notifications_notifications_proto = out.File
+	file_notifications_notifications_proto_goTypes = nil
+	file_notifications_notifications_proto_depIdxs = nil
+}
@@ -0,0 +1,227 @@
+// Package notifications sends S3 style bucket notifications of changes to repositories.  Repositories configure
+// targets: the events to send and the server configured sink to send them to.  Events are delivered
+// asynchronously, retried, and stored in a dead-letter partition of the KV store when delivery fails.
+package notifications
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cenkalti/backoff/v4"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	"github.com/treeverse/lakefs/pkg/config"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/logging"
+)
+
+// SinkARNPrefix prefixes the name of a sink to form the ARN that notification targets reference
+const SinkARNPrefix = "arn:lakefs:notifications:::"
+
+// ConfigurationSource provides the notification configuration of repositories
+type ConfigurationSource interface {
+	CachedNotificationConfiguration(ctx context.Context, repository *graveler.RepositoryRecord) (*graveler.NotificationConfiguration, error)
+}
+
+// delivery is the notification of an event to a single target
+type delivery struct {
+	repository string
+	targetID   string
+	sink       string
+	eventName  string
+	key        string
+	payload    []byte
+}
+
+type Service struct {
+	source        ConfigurationSource
+	store         kv.Store
+	sinks         map[string]Sink
+	queue         chan *delivery
+	workers       int
+	maxAttempts   int
+	retryInterval time.Duration
+	// deadLetterRetention is the time to keep dead letters, forever when 0
+	deadLetterRetention time.Duration
+	log                 logging.Logger
+	cancel              context.CancelFunc
+	wg                  sync.WaitGroup
+}
+
+func NewService(cfg config.Notifications, source ConfigurationSource, store kv.Store, log logging.Logger) (*Service, error) {
+	sinks := make(map[string]Sink, len(cfg.Sinks))
+	for name, sinkCfg := range cfg.Sinks {
+		sink, err := newSink(name, sinkCfg)
+		if err != nil {
+			return nil, err
+		}
+		sinks[name] = sink
+	}
+	return &Service{
+		source:              source,
+		store:               store,
+		sinks:               sinks,
+		queue:               make(chan *delivery, max(cfg.QueueSize, 1)),
+		workers:             max(cfg.Workers, 1),
+		maxAttempts:         max(cfg.MaxAttempts, 1),
+		retryInterval:       cfg.RetryInterval,
+		deadLetterRetention: cfg.DeadLetterRetention,
+		log:                 log,
+	}, nil
+}
+
+// Start runs the workers delivering notifications, and the pruning of dead letters older than the retention, until Stop
+func (s *Service) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+	for range s.workers {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.work(ctx)
+		}()
+	}
+	if s.deadLetterRetention > 0 {
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			s.pruneDeadLetters(ctx)
+		}()
+	}
+}
+
+// Stop stops the workers.  Notifications being retried are dead-lettered, notifications still queued are lost.
+func (s *Service) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+	s.wg.Wait()
+}
+
+func (s *Service) work(ctx context.Context) {
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case d := <-s.queue:
+			s.deliver(ctx, d)
+		}
+	}
+}
+
+func (s *Service) deliver(ctx context.Context, d *delivery) {
+	sink, ok := s.sinks[d.sink]
+	if !ok {
+		// the sink was removed from the server configuration since the target was configured
+		s.deadLetter(ctx, d, 0, fmt.Errorf("%w: unknown sink '%s'", ErrDeliveryFailed, d.sink))
+		return
+	}
+	bo := backoff.NewExponentialBackOff()
+	if s.retryInterval > 0 {
+		bo.InitialInterval = s.retryInterval
+	}
+	bo.MaxElapsedTime = 0
+	attempts := 0
+	err := backoff.Retry(func() error {
+		attempts++
+		return sink.Send(ctx, d.payload)
+	}, backoff.WithContext(backoff.WithMaxRetries(bo, uint64(s.maxAttempts-1)), ctx)) //nolint:gosec
+	if err != nil {
+		s.deadLetter(ctx, d, attempts, err)
+	}
+}
+
+// ValidateConfiguration checks that targets reference configured sinks and supported events and filters
+func (s *Service) ValidateConfiguration(cfg *graveler.NotificationConfiguration) error {
+	ids := make(map[string]struct{}, len(cfg.GetTargets()))
+	for _, target := range cfg.GetTargets() {
+		if target.GetId() == "" {
+			return fmt.Errorf("%w: missing target ID", ErrInvalidConfiguration)
+		}
+		if _, ok := ids[target.GetId()]; ok {
+			return fmt.Errorf("%w: duplicate target ID '%s'", ErrInvalidConfiguration, target.GetId())
+		}
+		ids[target.GetId()] = struct{}{}
+		sinkName, ok := strings.CutPrefix(target.GetArn(), SinkARNPrefix)
+		if !ok {
+			return fmt.Errorf("%w: target '%s' ARN '%s' does not start with %s", ErrInvalidConfiguration, target.GetId(), target.GetArn(), SinkARNPrefix)
+		}
+		if _, ok := s.sinks[sinkName]; !ok {
+			return fmt.Errorf("%w: target '%s' unknown sink '%s'", ErrInvalidConfiguration, target.GetId(), sinkName)
+		}
+		if len(target.GetEvents()) == 0 {
+			return fmt.Errorf("%w: target '%s' has no events", ErrInvalidConfiguration, target.GetId())
+		}
+		for _, event := range target.GetEvents() {
+			if err := validateEvent(event); err != nil {
+				return err
+			}
+		}
+		for _, rule := range target.GetFilterRules() {
+			if rule.GetName() != FilterRulePrefix && rule.GetName() != FilterRuleSuffix {
+				return fmt.Errorf("%w: target '%s' filter rule '%s'", ErrInvalidConfiguration, target.GetId(), rule.GetName())
+			}
+		}
+	}
+	return nil
+}
+
+// Notify queues the event for delivery to the matching targets of the repository.  It does not block: when the
+// queue is full the notifications are dead-lettered.
+func (s *Service) Notify(ctx context.Context, repository *graveler.RepositoryRecord, e *Event) {
+	cfg, err := s.source.CachedNotificationConfiguration(ctx, repository)
+	if err != nil {
+		s.log.WithError(err).WithField("repository", repository.RepositoryID).Error("Failed to get notification configuration")
+		return
+	}
+	for _, target := range cfg.GetTargets() {
+		if !targetMatches(target, e) {
+			continue
+		}
+		payload, err := e.payload(target.GetId())
+		if err != nil {
+			s.log.WithError(err).WithField("repository", repository.RepositoryID).WithField("target", target.GetId()).Error("Failed to encode notification")
+			continue
+		}
+		d := &delivery{
+			repository: repository.RepositoryID.String(),
+			targetID:   target.GetId(),
+			sink:       strings.TrimPrefix(target.GetArn(), SinkARNPrefix),
+			eventName:  e.Name,
+			key:        e.Key(),
+			payload:    payload,
+		}
+		select {
+		case s.queue <- d:
+		default:
+			s.deadLetter(ctx, d, 0, ErrQueueFull)
+		}
+	}
+}
+
+func (s *Service) EntryCreated(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, entry *catalog.DBEntry) {
+	s.Notify(ctx, repository, &Event{
+		Name:       EventObjectCreatedPut,
+		Time:       time.Now(),
+		Repository: repository.RepositoryID,
+		Branch:     branchID,
+		Path:       entry.Path,
+		Size:       entry.Size,
+		ETag:       entry.Checksum,
+	})
+}
+
+func (s *Service) EntriesRemoved(ctx context.Context, repository *graveler.RepositoryRecord, branchID graveler.BranchID, paths []string) {
+	now := time.Now()
+	for _, path := range paths {
+		s.Notify(ctx, repository, &Event{
+			Name:       EventObjectRemovedDelete,
+			Time:       now,
+			Repository: repository.RepositoryID,
+			Branch:     branchID,
+			Path:       path,
+		})
+	}
+}
@@ -0,0 +1,361 @@
+package notifications_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/treeverse/lakefs/pkg/catalog"
+	"github.com/treeverse/lakefs/pkg/config"
+	"github.com/treeverse/lakefs/pkg/graveler"
+	"github.com/treeverse/lakefs/pkg/kv"
+	"github.com/treeverse/lakefs/pkg/kv/kvtest"
+	"github.com/treeverse/lakefs/pkg/logging"
+	"github.com/treeverse/lakefs/pkg/notifications"
+)
+
+type fakeSource struct {
+	cfg *graveler.NotificationConfiguration
+}
+
+func (s *fakeSource) CachedNotificationConfiguration(_ context.Context, _ *graveler.RepositoryRecord) (*graveler.NotificationConfiguration, error) {
+	return s.cfg, nil
+}
+
+// recorder is a webhook endpoint recording the bodies it receives, failing the first failures requests
+type recorder struct {
+	mu       sync.Mutex
+	failures int
+	requests int
+	bodies   [][]byte
+	headers  []http.Header
+}
+
+func (r *recorder) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	body, _ := io.ReadAll(req.Body)
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.requests++
+	if r.requests <= r.failures {
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	r.bodies = append(r.bodies, body)
+	r.headers = append(r.headers, req.Header.Clone())
+}
+
+func (r *recorder) received() [][]byte {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.bodies
+}
+
+var repository = &graveler.RepositoryRecord{
+	RepositoryID: "repo1",
+	Repository:   &graveler.Repository{DefaultBranchID: "main"},
+}
+
+func newService(t *testing.T, sinks map[string]config.NotificationSink, targets ...*graveler.NotificationTarget) *notifications.Service {
+	t.Helper()
+	ctx := context.Background()
+	cfg := config.Notifications{
+		Enabled:       true,
+		Workers:       2,
+		QueueSize:     100,
+		MaxAttempts:   3,
+		RetryInterval: time.Millisecond,
+		Sinks:         sinks,
+	}
+	source := &fakeSource{cfg: &graveler.NotificationConfiguration{Targets: targets}}
+	svc, err := notifications.NewService(cfg, source, kvtest.GetStore(ctx, t), logging.ContextUnavailable())
+	require.NoError(t, err)
+	require.NoError(t, svc.ValidateConfiguration(source.cfg))
+	svc.Start(ctx)
+	t.Cleanup(svc.Stop)
+	return svc
+}
+
+func target(id, sink string, events ...string) *graveler.NotificationTarget {
+	return &graveler.NotificationTarget{Id: id, Arn: notifications.SinkARNPrefix + sink, Events: events}
+}
+
+func decodeRecord(t *testing.T, body []byte) notifications.Record {
+	t.Helper()
+	var records notifications.Records
+	require.NoError(t, json.Unmarshal(body, &records))
+	require.Len(t, records.Records, 1)
+	return records.Records[0]
+}
+
+func TestService_Webhook(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	hook := target("created", "hook", "s3:ObjectCreated:*")
+	hook.FilterRules = []*graveler.NotificationFilterRule{
+		{Name: notifications.FilterRulePrefix, Value: "main/data/"},
+		{Name: notifications.FilterRuleSuffix, Value: ".csv"},
+	}
+	svc := newService(t, map[string]config.NotificationSink{
+		"hook": {Type: notifications.SinkTypeWebhook, URL: server.URL, Headers: map[string]config.SecureString{"Authorization": "Bearer token"}},
+	}, hook)
+
+	ctx := context.Background()
+	svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: "data/a b.csv", Size: 3, Checksum: "abc"})
+	svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: "data/b.json"})
+	svc.EntryCreated(ctx, repository, "dev", &catalog.DBEntry{Path: "data/c.csv"})
+	svc.EntriesRemoved(ctx, repository, "main", []string{"data/a b.csv"})
+
+	require.Eventually(t, func() bool { return len(rec.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	// allow unexpected notifications to arrive
+	time.Sleep(50 * time.Millisecond)
+	bodies := rec.received()
+	require.Len(t, bodies, 1)
+	record := decodeRecord(t, bodies[0])
+	require.Equal(t, "ObjectCreated:Put", record.EventName)
+	require.Equal(t, "created", record.S3.ConfigurationID)
+	require.Equal(t, "repo1", record.S3.Bucket.Name)
+	require.Equal(t, "main/data/a+b.csv", record.S3.Object.Key)
+	require.Equal(t, int64(3), record.S3.Object.Size)
+	require.Equal(t, "abc", record.S3.Object.ETag)
+	require.Equal(t, "data/a b.csv", record.LakeFS.Path)
+	require.Equal(t, "Bearer token", rec.headers[0].Get("Authorization"))
+}
+
+func TestService_Kafka(t *testing.T) {
+	rec := &recorder{}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	svc := newService(t, map[string]config.NotificationSink{
+		"kafka": {Type: notifications.SinkTypeKafka, URL: server.URL, Topic: "events"},
+	}, target("removed", "kafka", "s3:ObjectRemoved:Delete"))
+
+	svc.EntriesRemoved(context.Background(), repository, "main", []string{"a", "b"})
+	require.Eventually(t, func() bool { return len(rec.received()) == 2 }, 5*time.Second, 10*time.Millisecond)
+	require.Equal(t, "application/vnd.kafka.json.v2+json", rec.headers[0].Get("Content-Type"))
+	var body struct {
+		Records []struct {
+			Value json.RawMessage `json:"value"`
+		} `json:"records"`
+	}
+	require.NoError(t, json.Unmarshal(rec.received()[0], &body))
+	require.Len(t, body.Records, 1)
+	record := decodeRecord(t, body.Records[0].Value)
+	require.Equal(t, "ObjectRemoved:Delete", record.EventName)
+}
+
+func TestService_File(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "queue", "events.jsonl")
+	svc := newService(t, map[string]config.NotificationSink{
+		"file": {Type: notifications.SinkTypeFile, Path: path},
+	}, target("all", "file", "*"))
+
+	ctx := context.Background()
+	hooks := svc.HooksHandler(&graveler.HooksNoOp{})
+	commit := graveler.Commit{Message: "add data", Committer: "user1"}
+	require.NoError(t, hooks.PostCommitHook(ctx, graveler.HookRecord{Repository: repository, BranchID: "main", CommitID: "c1", SourceRef: "c1", Commit: commit}))
+	require.NoError(t, hooks.PostMergeHook(ctx, graveler.HookRecord{Repository: repository, BranchID: "main", CommitID: "c2", SourceRef: "c0", MergeSource: "dev", Commit: commit}))
+
+	var lines []string
+	require.Eventually(t, func() bool {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return false
+		}
+		lines = strings.Split(strings.TrimSpace(string(data)), "\n")
+		return len(lines) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+
+	records := map[string]notifications.Record{}
+	for _, line := range lines {
+		record := decodeRecord(t, []byte(line))
+		records[record.EventName] = record
+	}
+	commitRecord := records[notifications.EventCommit]
+	require.Equal(t, "main/", commitRecord.S3.Object.Key)
+	require.Equal(t, "c1", commitRecord.LakeFS.CommitID)
+	require.Equal(t, "add data", commitRecord.LakeFS.Message)
+	require.Equal(t, "user1", commitRecord.LakeFS.Committer)
+	require.Equal(t, "dev", records[notifications.EventMerge].LakeFS.SourceRef)
+}
+
+func TestService_Retry(t *testing.T) {
+	rec := &recorder{failures: 2}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	svc := newService(t, map[string]config.NotificationSink{
+		"hook": {Type: notifications.SinkTypeWebhook, URL: server.URL},
+	}, target("created", "hook", notifications.EventObjectCreatedPut))
+
+	svc.EntryCreated(context.Background(), repository, "main", &catalog.DBEntry{Path: "a"})
+	require.Eventually(t, func() bool { return len(rec.received()) == 1 }, 5*time.Second, 10*time.Millisecond)
+	letters, err := svc.ListDeadLetters(context.Background(), "", 10)
+	require.NoError(t, err)
+	require.Empty(t, letters)
+}
+
+func TestService_DeadLetter(t *testing.T) {
+	rec := &recorder{failures: 100}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	svc := newService(t, map[string]config.NotificationSink{
+		"hook": {Type: notifications.SinkTypeWebhook, URL: server.URL},
+	}, target("created", "hook", notifications.EventObjectCreatedPut))
+
+	ctx := context.Background()
+	svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: "a"})
+	svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: "b"})
+
+	var letters []*notifications.DeadLetterData
+	require.Eventually(t, func() bool {
+		var err error
+		letters, err = svc.ListDeadLetters(ctx, "", 10)
+		require.NoError(t, err)
+		return len(letters) == 2
+	}, 5*time.Second, 10*time.Millisecond)
+	keys := []string{letters[0].GetKey(), letters[1].GetKey()}
+	require.ElementsMatch(t, []string{"main/a", "main/b"}, keys)
+	require.Equal(t, int32(3), letters[0].GetAttempts())
+	require.Equal(t, "repo1", letters[0].GetRepositoryId())
+	require.Equal(t, "created", letters[0].GetTargetId())
+	require.Equal(t, "hook", letters[0].GetSink())
+	require.Contains(t, letters[0].GetError(), "503")
+	decodeRecord(t, letters[0].GetPayload())
+
+	// list after the first dead letter
+	rest, err := svc.ListDeadLetters(ctx, letters[0].GetId(), 10)
+	require.NoError(t, err)
+	require.Len(t, rest, 1)
+	require.Equal(t, letters[1].GetId(), rest[0].GetId())
+}
+
+func TestService_DeadLetterReplay(t *testing.T) {
+	rec := &recorder{failures: 3}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	svc := newService(t, map[string]config.NotificationSink{
+		"hook": {Type: notifications.SinkTypeWebhook, URL: server.URL},
+	}, target("created", "hook", notifications.EventObjectCreatedPut))
+
+	ctx := context.Background()
+	svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: "a"})
+
+	var letters []*notifications.DeadLetterData
+	require.Eventually(t, func() bool {
+		var err error
+		letters, err = svc.ListDeadLetters(ctx, "", 10)
+		require.NoError(t, err)
+		return len(letters) == 1
+	}, 5*time.Second, 10*time.Millisecond)
+	require.Empty(t, rec.received())
+
+	require.NoError(t, svc.ReplayDeadLetter(ctx, letters[0].GetId()))
+	received := rec.received()
+	require.Len(t, received, 1)
+	require.Equal(t, letters[0].GetPayload(), received[0])
+	_, err := svc.GetDeadLetter(ctx, letters[0].GetId())
+	require.ErrorIs(t, err, kv.ErrNotFound)
+	require.ErrorIs(t, svc.ReplayDeadLetter(ctx, letters[0].GetId()), kv.ErrNotFound)
+}
+
+func TestService_DeadLetterDeleteAndPrune(t *testing.T) {
+	rec := &recorder{failures: 100}
+	server := httptest.NewServer(rec)
+	defer server.Close()
+
+	svc := newService(t, map[string]config.NotificationSink{
+		"hook": {Type: notifications.SinkTypeWebhook, URL: server.URL},
+	}, target("created", "hook", notifications.EventObjectCreatedPut))
+
+	ctx := context.Background()
+	for _, path := range []string{"a", "b", "c"} {
+		svc.EntryCreated(ctx, repository, "main", &catalog.DBEntry{Path: path})
+	}
+	var letters []*notifications.DeadLetterData
+	require.Eventually(t, func() bool {
+		var err error
+		letters, err = svc.ListDeadLetters(ctx, "", 10)
+		require.NoError(t, err)
+		return len(letters) == 3
+	}, 5*time.Second, 10*time.Millisecond)
+
+	require.ErrorIs(t, svc.ReplayDeadLetter(ctx, letters[0].GetId()), notifications.ErrDeliveryFailed)
+
+	require.NoError(t, svc.DeleteDeadLetter(ctx, letters[0].GetId()))
+	require.ErrorIs(t, svc.DeleteDeadLetter(ctx, letters[0].GetId()), kv.ErrNotFound)
+
+	pruned, err := svc.PruneDeadLetters(ctx, letters[2].GetCreationDate().AsTime().Add(-time.Hour))
+	require.NoError(t, err)
+	require.Zero(t, pruned)
+	pruned, err = svc.PruneDeadLetters(ctx, time.Now().Add(time.Second))
+	require.NoError(t, err)
+	require.Equal(t, 2, pruned)
+	rest, err := svc.ListDeadLetters(ctx, "", 10)
+	require.NoError(t, err)
+	require.Empty(t, rest)
+}
+
+func TestService_ValidateConfiguration(t *testing.T) {
+	svc := newService(t, map[string]config.NotificationSink{
+		"file": {Type: notifications.SinkTypeFile, Path: filepath.Join(t.TempDir(), "events.jsonl")},
+	})
+	tests := []struct {
+		name    string
+		targets []*graveler.NotificationTarget
+		wantErr bool
+	}{
+		{name: "empty"},
+		{name: "valid", targets: []*graveler.NotificationTarget{target("t1", "file", "s3:ObjectCreated:*", "lakefs:Commit")}},
+		{name: "all_events", targets: []*graveler.NotificationTarget{target("t1", "file", "*")}},
+		{name: "missing_id", targets: []*graveler.NotificationTarget{target("", "file", "*")}, wantErr: true},
+		{name: "duplicate_id", targets: []*graveler.NotificationTarget{target("t1", "file", "*"), target("t1", "file", "*")}, wantErr: true},
+		{name: "unknown_sink", targets: []*graveler.NotificationTarget{target("t1", "other", "*")}, wantErr: true},
+		{name: "foreign_arn", targets: []*graveler.NotificationTarget{{Id: "t1", Arn: "arn:aws:sqs:us-east-1:123456789012:queue", Events: []string{"*"}}}, wantErr: true},
+		{name: "no_events", targets: []*graveler.NotificationTarget{target("t1", "file")}, wantErr: true},
+		{name: "unsupported_event", targets: []*graveler.NotificationTarget{target("t1", "file", "s3:ObjectRestore:*")}, wantErr: true},
+		{name: "unknown_filter", targets: []*graveler.NotificationTarget{{
+			Id: "t1", Arn: notifications.SinkARNPrefix + "file", Events: []string{"*"},
+			FilterRules: []*graveler.NotificationFilterRule{{Name: "regex", Value: ".*"}},
+		}}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := svc.ValidateConfiguration(&graveler.NotificationConfiguration{Targets: tt.targets})
+			if tt.wantErr {
+				require.True(t, errors.Is(err, graveler.ErrInvalidValue), "expected invalid value, got %v", err)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestNewService_InvalidSink(t *testing.T) {
+	ctx := context.Background()
+	for name, sink := range map[string]config.NotificationSink{
+		"unknown_type": {Type: "sqs"},
+		"kafka_topic":  {Type: notifications.SinkTypeKafka, URL: "http://localhost:8082"},
+		"file_path":    {Type: notifications.SinkTypeFile},
+		"webhook_url":  {Type: notifications.SinkTypeWebhook, URL: "not a url"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := notifications.NewService(config.Notifications{Sinks: map[string]config.NotificationSink{name: sink}}, &fakeSource{}, kvtest.GetStore(ctx, t), logging.ContextUnavailable())
+			require.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package notifications
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/treeverse/lakefs/pkg/config"
+)
+
+const (
+	SinkTypeWebhook = "webhook"
+	SinkTypeFile    = "file"
+	SinkTypeKafka   = "kafka"
+
+	sinkDefaultTimeout = 30 * time.Second
+	// kafkaContentType is the Kafka REST proxy (v2) content type of JSON records
+	kafkaContentType = "application/vnd.kafka.json.v2+json"
+	// maxErrorBodySize limits the response body kept in delivery errors
+	maxErrorBodySize = 1024
+)
+
+// Sink delivers notification payloads to a destination
+type Sink interface {
+	Send(ctx context.Context, payload []byte) error
+}
+
+func newSink(name string, cfg config.NotificationSink) (Sink, error) {
+	timeout := cfg.Timeout
+	if timeout == 0 {
+		timeout = sinkDefaultTimeout
+	}
+	switch cfg.Type {
+	case SinkTypeWebhook:
+		if _, err := url.ParseRequestURI(cfg.URL); err != nil {
+			return nil, fmt.Errorf("sink %s url: %w", name, err)
+		}
+		return &webhookSink{
+			url:     cfg.URL,
+			headers: cfg.Headers,
+			client:  &http.Client{Timeout: timeout},
+		}, nil
+	case SinkTypeKafka:
+		if cfg.Topic == "" {
+			return nil, fmt.Errorf("sink %s: %w: missing topic", name, ErrInvalidSink)
+		}
+		topicURL, err := url.JoinPath(cfg.URL, "topics", url.PathEscape(cfg.Topic))
+		if err != nil {
+			return nil, fmt.Errorf("sink %s url: %w", name, err)
+		}
+		return &kafkaSink{
+			webhook: webhookSink{
+				url:         topicURL,
+				headers:     cfg.Headers,
+				contentType: kafkaContentType,
+				client:      &http.Client{Timeout: timeout},
+			},
+		}, nil
+	case SinkTypeFile:
+		if cfg.Path == "" {
+			return nil, fmt.Errorf("sink %s: %w: missing path", name, ErrInvalidSink)
+		}
+		if err := os.MkdirAll(filepath.Dir(cfg.Path), os.ModePerm); err != nil {
+			return nil, fmt.Errorf("sink %s path: %w", name, err)
+		}
+		return &fileSink{path: cfg.Path}, nil
+	default:
+		return nil, fmt.Errorf("sink %s: %w: unknown type '%s'", name, ErrInvalidSink, cfg.Type)
+	}
+}
+
+// webhookSink POSTs each payload to a URL
+type webhookSink struct {
+	url         string
+	headers     map[string]config.SecureString
+	contentType string
+	client      *http.Client
+}
+
+func (s *webhookSink) Send(ctx context.Context, payload []byte) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	contentType := s.contentType
+	if contentType == "" {
+		contentType = "application/json"
+	}
+	req.Header.Set("Content-Type", contentType)
+	for k, v := range s.headers {
+		req.Header.Set(k, v.SecureValue())
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("%w: status %d: %s", ErrDeliveryFailed, resp.StatusCode, body)
+	}
+	return nil
+}
+
+// kafkaSink produces each payload as a record to a topic through a Kafka REST proxy
+type kafkaSink struct {
+	webhook webhookSink
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	Value json.RawMessage `json:"value"`
+}
+
+func (s *kafkaSink) Send(ctx context.Context, payload []byte) error {
+	body, err := json.Marshal(kafkaRecords{Records: []kafkaRecord{{Value: payload}}})
+	if err != nil {
+		return err
+	}
+	return s.webhook.Send(ctx, body)
+}
+
+// fileSink appends each payload as a line to a local file, used as a queue by consumers tailing it
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func (s *fileSink) Send(_ context.Context, payload []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600) //nolint:mnd
+	if err != nil {
+		return err
+	}
+	_, err = f.Write(append(payload, '\n'))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	return err
+}
@@ -53,13 +53,26 @@ var Actions = []string{
 	"auth:DeleteUserExternalPrincipal",
 	"auth:ReadExternalPrincipal",
 	"ci:ReadAction",
+	"ci:ReadNotificationConfiguration",
+	"ci:WriteNotificationConfiguration",
 	"retention:PrepareGarbageCollectionCommits",
 	"retention:GetGarbageCollectionRules",
 	"retention:SetGarbageCollectionRules",
 	"retention:PrepareGarbageCollectionUncommitted",
+	"retention:GetLifecycleRules",
+	"retention:SetLifecycleRules",
+	"retention:GetObjectLockConfiguration",
+	"retention:SetObjectLockConfiguration",
+	"retention:GetObjectRetention",
+	"retention:SetObjectRetention",
+	"retention:BypassGovernanceRetention",
+	"retention:GetObjectLegalHold",
+	"retention:SetObjectLegalHold",
 	"branches:GetBranchProtectionRules",
 	"branches:SetBranchProtectionRules",
 	"pr:ReadPullRequest",
 	"pr:WritePullRequest",
 	"pr:ListPullRequests",
+	"pr:ReviewPullRequest",
+	"pr:CommentPullRequest",
 }
@@ -65,15 +65,28 @@ const (
 	DeleteUserExternalPrincipalAction         = "auth:DeleteUserExternalPrincipal"
 	ReadExternalPrincipalAction               = "auth:ReadExternalPrincipal"
 	ReadActionsAction                         = "ci:ReadAction"
+	ReadNotificationConfigurationAction       = "ci:ReadNotificationConfiguration"
+	WriteNotificationConfigurationAction      = "ci:WriteNotificationConfiguration"
 	PrepareGarbageCollectionCommitsAction     = "retention:PrepareGarbageCollectionCommits"
 	GetGarbageCollectionRulesAction           = "retention:GetGarbageCollectionRules"
 	SetGarbageCollectionRulesAction           = "retention:SetGarbageCollectionRules"
 	PrepareGarbageCollectionUncommittedAction = "retention:PrepareGarbageCollectionUncommitted"
+	GetLifecycleRulesAction                   = "retention:GetLifecycleRules"
+	SetLifecycleRulesAction                   = "retention:SetLifecycleRules"
+	GetObjectLockConfigurationAction          = "retention:GetObjectLockConfiguration"
+	SetObjectLockConfigurationAction          = "retention:SetObjectLockConfiguration"
+	GetObjectRetentionAction                  = "retention:GetObjectRetention"
+	SetObjectRetentionAction                  = "retention:SetObjectRetention"
+	BypassGovernanceRetentionAction           = "retention:BypassGovernanceRetention"
+	GetObjectLegalHoldAction                  = "retention:GetObjectLegalHold"
+	SetObjectLegalHoldAction                  = "retention:SetObjectLegalHold"
 	GetBranchProtectionRulesAction            = "branches:GetBranchProtectionRules"
 	SetBranchProtectionRulesAction            = "branches:SetBranchProtectionRules"
 	ReadPullRequestAction                     = "pr:ReadPullRequest"
 	WritePullRequestAction                    = "pr:WritePullRequest"
 	ListPullRequestsAction                    = "pr:ListPullRequests"
+	ReviewPullRequestAction                   = "pr:ReviewPullRequest"
+	CommentPullRequestAction                  = "pr:CommentPullRequest"
 )
 
 var serviceSet = map[string]struct{}{
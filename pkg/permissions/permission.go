@@ -61,3 +61,17 @@ func PolicyArn(policyID string) string {
 func ExternalPrincipalArn(principalID string) string {
 	return authArnPrefix + "externalPrincipal/" + principalID
 }
+
+// LifecycleRulesNode returns the permissions required to set or run the lifecycle rules of a repository.  Lifecycle
+// rules delete objects and commit the deletes on any branch, so setting them also requires deleting objects and
+// committing on all branches of the repository.
+func LifecycleRulesNode(repoID string) Node {
+	return Node{
+		Type: NodeTypeAnd,
+		Nodes: []Node{
+			{Permission: Permission{Action: SetLifecycleRulesAction, Resource: RepoArn(repoID)}},
+			{Permission: Permission{Action: DeleteObjectAction, Resource: ObjectArn(repoID, "*")}},
+			{Permission: Permission{Action: CreateCommitAction, Resource: BranchArn(repoID, "*")}},
+		},
+	}
+}